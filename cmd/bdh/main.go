@@ -10,8 +10,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/beadhub/bdh/internal/commands"
 )
@@ -26,13 +29,27 @@ var (
 func main() {
 	commands.SetVersionInfo(version, commit, date)
 
-	err := commands.Execute()
+	// Ctrl-C and SIGTERM cancel in-flight HTTP calls and bd; a second
+	// Ctrl-C kills bdh immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := commands.ExecuteContext(ctx)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 	}
-	// Print notifications at the end of every command
-	commands.PrintNotifications(os.Stderr)
+	// Print notifications at the end of every command, then the summary line
+	if !commands.IsInterrupted(err) {
+		commands.PrintNotifications(ctx, os.Stderr)
+		commands.PrintSummary(os.Stderr)
+	}
 	if err != nil {
+		if commands.IsInterrupted(err) {
+			os.Exit(130)
+		}
 		os.Exit(1)
 	}
 }
@@ -3,9 +3,11 @@ module github.com/beadhub/bdh
 go 1.24.0
 
 require (
+	filippo.io/age v1.2.1
 	github.com/awebai/aw v0.4.0
 	github.com/joho/godotenv v1.5.1
 	github.com/spf13/cobra v1.10.2
+	golang.org/x/sys v0.39.0
 	golang.org/x/term v0.38.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -13,5 +15,5 @@ require (
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
-	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/crypto v0.24.0 // indirect
 )
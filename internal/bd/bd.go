@@ -8,6 +8,8 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -16,6 +18,15 @@ import (
 type Runner struct {
 	// BdPath is the path to the bd executable (defaults to "bd" in PATH).
 	BdPath string
+
+	// Command, when set, is the argv bd is run with instead of BdPath, for
+	// bd behind a wrapper (["devbox", "run", "bd"]); bd's arguments are
+	// appended to it.
+	Command []string
+
+	// Env holds KEY=VALUE entries added to the environment bd inherits;
+	// they take precedence over inherited variables of the same name.
+	Env []string
 }
 
 // New creates a new bd runner.
@@ -25,6 +36,31 @@ func New() *Runner {
 	}
 }
 
+// ErrNotInstalled means the program bd is run with is not on PATH.
+var ErrNotInstalled = errors.New("bd not found")
+
+// Executable returns the program bd is run with: BdPath, or the first
+// element of Command for a wrapper.
+func (r *Runner) Executable() string {
+	if len(r.Command) > 0 {
+		return r.Command[0]
+	}
+	return r.BdPath
+}
+
+// LookPath resolves Executable on PATH. The error wraps ErrNotInstalled when
+// it cannot be found. With BDH_MOCK_BD=1 nothing needs to be installed.
+func (r *Runner) LookPath() (string, error) {
+	if MockEnabled() {
+		return "", nil
+	}
+	path, err := exec.LookPath(r.Executable())
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNotInstalled, err)
+	}
+	return path, nil
+}
+
 // Result contains the result of running a bd command.
 type Result struct {
 	Stdout   string
@@ -34,8 +70,13 @@ type Result struct {
 
 // Run executes bd with the given arguments.
 // Arguments are passed through faithfully without modification.
+// With BDH_MOCK_BD=1 the embedded emulator runs instead (see MockEnv).
 func (r *Runner) Run(ctx context.Context, args []string) (*Result, error) {
-	cmd := exec.CommandContext(ctx, r.BdPath, args...)
+	if MockEnabled() {
+		return runMock(args), nil
+	}
+
+	cmd := r.command(ctx, args)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -61,11 +102,31 @@ func (r *Runner) Run(ctx context.Context, args []string) (*Result, error) {
 	return result, nil
 }
 
+// command builds the bd invocation, with Command and Env applied.
+func (r *Runner) command(ctx context.Context, args []string) *exec.Cmd {
+	name, argv := r.BdPath, args
+	if len(r.Command) > 0 {
+		name = r.Command[0]
+		argv = append(append([]string{}, r.Command[1:]...), args...)
+	}
+	cmd := exec.CommandContext(ctx, name, argv...)
+	if len(r.Env) > 0 {
+		cmd.Env = append(os.Environ(), r.Env...)
+	}
+	return cmd
+}
+
 func commandFromArgs(args []string) string {
-	if len(args) == 0 {
+	i := commandIndex(args)
+	if i < 0 {
 		return ""
 	}
+	return args[i]
+}
 
+// commandIndex returns the position of the bd subcommand in args, skipping
+// global flags, or -1 if there is none.
+func commandIndex(args []string) int {
 	i := 0
 	for i < len(args) {
 		arg := args[i]
@@ -96,9 +157,9 @@ func commandFromArgs(args []string) string {
 	}
 
 	if i >= len(args) {
-		return ""
+		return -1
 	}
-	return args[i]
+	return i
 }
 
 // IsMutationCommand returns true if the command modifies state
@@ -112,6 +173,9 @@ func IsMutationCommand(args []string) bool {
 		// Mutation subcommands (add/remove/relate/unrelate) need sync.
 		// Read-only subcommands (list/tree/cycles) get synced but have no changes.
 		return true
+	case "label":
+		// label add/remove change the issue; list and list-all are read-only.
+		return isLabelMutation(args)
 	case "sync":
 		// `bd sync` updates the canonical JSONL export and may commit it; ensure
 		// BeadHub sees the latest JSONL even if earlier uploads were skipped.
@@ -120,3 +184,126 @@ func IsMutationCommand(args []string) bool {
 		return false
 	}
 }
+
+// IsReadOnlyCommand returns true for commands known to only read issues,
+// which are safe to run before the pre-flight check has answered.
+func IsReadOnlyCommand(args []string) bool {
+	switch commandFromArgs(args) {
+	case "list", "show", "ready", "search", "blocked", "stats", "count", "stale":
+		return true
+	case "label":
+		return !isLabelMutation(args)
+	default:
+		return false
+	}
+}
+
+func isLabelMutation(args []string) bool {
+	switch subcommand(args) {
+	case "list", "list-all", "":
+		return false
+	default:
+		return true
+	}
+}
+
+// subcommand returns the first positional argument after the bd command
+// (e.g. "add" for `bd dep add bd-2 bd-1`).
+func subcommand(args []string) string {
+	i := commandIndex(args)
+	if i < 0 {
+		return ""
+	}
+	for _, arg := range args[i+1:] {
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return ""
+}
+
+// depValueFlags are dep/label flags that take a value. --blocks names an
+// issue, so its value is an affected issue; the rest are skipped.
+var depValueFlags = map[string]bool{
+	"--type": true, "-t": true, "--blocks": true,
+	"--db": true, "--actor": true, "--lock-timeout": true,
+}
+
+// IsDependencyMutation returns true for dep subcommands that change the
+// dependency graph (add/remove/relate/unrelate and `dep <id> --blocks <id>`).
+func IsDependencyMutation(args []string) bool {
+	if commandFromArgs(args) != "dep" {
+		return false
+	}
+	switch subcommand(args) {
+	case "list", "tree", "cycles", "":
+		return false
+	default:
+		return true
+	}
+}
+
+// AffectedIssueIDs returns the issues changed by a dep or label mutation,
+// in argument order, so sync can upload just those issues. Returns nil for
+// other commands and for read-only dep/label subcommands.
+func AffectedIssueIDs(args []string) []string {
+	cmd := commandFromArgs(args)
+	switch {
+	case cmd == "dep" && IsDependencyMutation(args):
+	case cmd == "label" && isLabelMutation(args):
+	default:
+		return nil
+	}
+
+	var positional []string
+	rest := args[commandIndex(args)+1:]
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		if !depValueFlags[name] {
+			continue
+		}
+		if !hasValue && i+1 < len(rest) {
+			i++
+			value = rest[i]
+		}
+		if name == "--blocks" && value != "" {
+			positional = append(positional, value)
+		}
+	}
+
+	switch {
+	case cmd == "dep" && len(positional) > 0 && isDepSubcommand(positional[0]):
+		// dep add <issue> <depends-on>
+		positional = positional[1:]
+	case cmd == "label" && len(positional) > 0:
+		// label add <issue>... <label>: the last positional is the label
+		positional = positional[1:]
+		if len(positional) > 0 {
+			positional = positional[:len(positional)-1]
+		}
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, id := range positional {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func isDepSubcommand(s string) bool {
+	switch s {
+	case "add", "remove", "rm", "relate", "unrelate":
+		return true
+	default:
+		return false
+	}
+}
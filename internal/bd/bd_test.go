@@ -2,6 +2,8 @@ package bd
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -66,6 +68,32 @@ func TestRun_Stderr(t *testing.T) {
 	}
 }
 
+func TestRun_Env(t *testing.T) {
+	t.Setenv("BDH_TEST_INHERITED", "parent")
+	t.Setenv("BDH_TEST_OVERRIDDEN", "parent")
+	r := &Runner{BdPath: "sh", Env: []string{"BDH_TEST_OVERRIDDEN=child"}}
+	result, err := r.Run(context.Background(), []string{"-c", "echo $BDH_TEST_INHERITED $BDH_TEST_OVERRIDDEN"})
+
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Stdout != "parent child\n" {
+		t.Errorf("Stdout = %q, want %q", result.Stdout, "parent child\n")
+	}
+}
+
+func TestRun_Command(t *testing.T) {
+	r := &Runner{BdPath: "/nonexistent/bd", Command: []string{"sh", "-c", `echo "$@"`, "wrapper"}}
+	result, err := r.Run(context.Background(), []string{"show", "bd-1"})
+
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Stdout != "show bd-1\n" {
+		t.Errorf("Stdout = %q, want %q", result.Stdout, "show bd-1\n")
+	}
+}
+
 func TestRun_NotFound(t *testing.T) {
 	r := &Runner{BdPath: "/nonexistent/command"}
 	_, err := r.Run(context.Background(), []string{})
@@ -75,6 +103,20 @@ func TestRun_NotFound(t *testing.T) {
 	}
 }
 
+func TestLookPath(t *testing.T) {
+	t.Setenv(MockEnv, "")
+	if _, err := (&Runner{BdPath: "/nonexistent/bd"}).LookPath(); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("expected ErrNotInstalled, got %v", err)
+	}
+	r := &Runner{BdPath: "/nonexistent/bd", Command: []string{"sh", "-c"}}
+	if r.Executable() != "sh" {
+		t.Errorf("Executable() = %q, want the wrapper", r.Executable())
+	}
+	if path, err := r.LookPath(); err != nil || path == "" {
+		t.Errorf("LookPath() = %q, %v", path, err)
+	}
+}
+
 func TestRun_ContextCanceled(t *testing.T) {
 	r := &Runner{BdPath: "sleep"}
 	ctx, cancel := context.WithCancel(context.Background())
@@ -111,6 +153,11 @@ func TestIsMutationCommand(t *testing.T) {
 		{[]string{"dep", "list", "bd-42"}, true},
 		{[]string{"dep", "tree", "bd-42"}, true},
 		{[]string{"dep", "cycles"}, true},
+		// label mutations trigger sync; listing does not
+		{[]string{"label", "add", "bd-42", "urgent"}, true},
+		{[]string{"label", "remove", "bd-42", "urgent"}, true},
+		{[]string{"label", "list", "bd-42"}, false},
+		{[]string{"label", "list-all"}, false},
 		{[]string{"list"}, false},
 		{[]string{"show", "bd-42"}, false},
 		{[]string{"ready"}, false},
@@ -131,6 +178,56 @@ func TestIsMutationCommand(t *testing.T) {
 	}
 }
 
+func TestAffectedIssueIDs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"dep", "add", "bd-43", "bd-42"}, []string{"bd-43", "bd-42"}},
+		{[]string{"--db", ".beads/beads.db", "dep", "remove", "bd-43", "bd-42"}, []string{"bd-43", "bd-42"}},
+		{[]string{"dep", "add", "bd-43", "bd-42", "--type", "related"}, []string{"bd-43", "bd-42"}},
+		{[]string{"dep", "add", "--type=blocks", "bd-43", "bd-42"}, []string{"bd-43", "bd-42"}},
+		{[]string{"dep", "bd-42", "--blocks", "bd-43"}, []string{"bd-42", "bd-43"}},
+		{[]string{"dep", "relate", "bd-1", "bd-1"}, []string{"bd-1"}},
+		{[]string{"label", "add", "bd-1", "bd-2", "urgent"}, []string{"bd-1", "bd-2"}},
+		{[]string{"label", "remove", "bd-1", "urgent", "--json"}, []string{"bd-1"}},
+		{[]string{"dep", "tree", "bd-42"}, nil},
+		{[]string{"label", "list", "bd-42"}, nil},
+		{[]string{"update", "bd-42", "--status", "closed"}, nil},
+	}
+
+	for _, tt := range tests {
+		got := AffectedIssueIDs(tt.args)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("AffectedIssueIDs(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestIsDependencyMutation(t *testing.T) {
+	if !IsDependencyMutation([]string{"dep", "add", "bd-2", "bd-1"}) {
+		t.Error("dep add should be a dependency mutation")
+	}
+	for _, args := range [][]string{{"dep", "list", "bd-2"}, {"dep", "cycles"}, {"label", "add", "bd-1", "x"}} {
+		if IsDependencyMutation(args) {
+			t.Errorf("IsDependencyMutation(%v) = true", args)
+		}
+	}
+}
+
+func TestIsReadOnlyCommand(t *testing.T) {
+	for _, args := range [][]string{{"list"}, {"--db", "x.db", "show", "bd-1"}, {"ready", "--json"}, {"label", "list", "bd-1"}} {
+		if !IsReadOnlyCommand(args) {
+			t.Errorf("IsReadOnlyCommand(%v) = false", args)
+		}
+	}
+	for _, args := range [][]string{{}, {"update", "bd-1"}, {"label", "add", "bd-1", "x"}, {"init"}, {"sync"}} {
+		if IsReadOnlyCommand(args) {
+			t.Errorf("IsReadOnlyCommand(%v) = true", args)
+		}
+	}
+}
+
 func TestNew(t *testing.T) {
 	r := New()
 	if r.BdPath != "bd" {
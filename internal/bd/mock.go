@@ -0,0 +1,485 @@
+package bd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/beads"
+)
+
+// MockEnv enables the embedded bd emulator when set to 1 or true.
+//
+// The emulator supports just enough of bd (init, create, list, ready, show,
+// update, close, reopen, delete, export) on a local issues.jsonl for developing bdh itself,
+// and for CI, without beads installed. Other commands fail with exit code 1.
+const MockEnv = "BDH_MOCK_BD"
+
+// MockEnabled reports whether bd commands run against the emulator.
+func MockEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(MockEnv))) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+var mockIDPattern = regexp.MustCompile(`^(.+)-([0-9]+)$`)
+
+// mockStore is the emulator's database: issues.jsonl, kept in file order.
+type mockStore struct {
+	path   string
+	issues []map[string]any
+}
+
+// runMock executes args against the emulator. Errors are reported the way bd
+// reports them: on stderr with exit code 1.
+func runMock(args []string) *Result {
+	var stdout, stderr bytes.Buffer
+	dbPath, rest := mockGlobalArgs(args)
+
+	storePath := beads.IssuesJSONLPath()
+	if dbPath != "" {
+		storePath = filepath.Join(filepath.Dir(dbPath), "issues.jsonl")
+	}
+
+	if err := mockDispatch(storePath, rest, &stdout); err != nil {
+		fmt.Fprintf(&stderr, "Error: %v\n", err)
+		return &Result{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: 1}
+	}
+	return &Result{Stdout: stdout.String(), Stderr: stderr.String()}
+}
+
+// mockGlobalArgs drops bd's global flags, returning --db if given.
+func mockGlobalArgs(args []string) (dbPath string, rest []string) {
+	i := commandIndex(args)
+	for j := 0; j < len(args) && (i < 0 || j < i); j++ {
+		switch {
+		case strings.HasPrefix(args[j], "--db="):
+			dbPath = strings.TrimPrefix(args[j], "--db=")
+		case args[j] == "--db" && j+1 < len(args):
+			dbPath = args[j+1]
+		}
+	}
+	if i < 0 {
+		return dbPath, nil
+	}
+	return dbPath, args[i:]
+}
+
+func mockDispatch(storePath string, args []string, out *bytes.Buffer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command (bd mock supports init, create, list, ready, show, update, close, reopen, delete, export)")
+	}
+	cmd, opts := args[0], parseMockFlags(args[1:])
+	positional := opts.positional
+	asJSON := opts.has("json")
+
+	if cmd == "init" {
+		if err := os.MkdirAll(filepath.Dir(storePath), 0755); err != nil {
+			return err
+		}
+		if _, err := os.Stat(storePath); os.IsNotExist(err) {
+			if err := os.WriteFile(storePath, nil, 0644); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(out, "✓ bd (mock) initialized in %s\n", filepath.Dir(storePath))
+		return nil
+	}
+
+	store, err := loadMockStore(storePath)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	switch cmd {
+	case "create":
+		title := opts.value("title")
+		if title == "" && len(positional) > 0 {
+			title = strings.Join(positional, " ")
+		}
+		if title == "" {
+			return fmt.Errorf("title required")
+		}
+		issue := map[string]any{
+			"id":         store.nextID(),
+			"title":      title,
+			"status":     "open",
+			"priority":   2,
+			"issue_type": "task",
+			"created_at": now,
+			"updated_at": now,
+		}
+		if t := opts.value("type", "t"); t != "" {
+			issue["issue_type"] = t
+		}
+		if p := opts.value("priority", "p"); p != "" {
+			n, err := parseMockPriority(p)
+			if err != nil {
+				return err
+			}
+			issue["priority"] = n
+		}
+		if d := opts.value("description", "d"); d != "" {
+			issue["description"] = d
+		}
+		if a := opts.value("assignee", "a"); a != "" {
+			issue["assignee"] = a
+		}
+		store.issues = append(store.issues, issue)
+		if err := store.save(); err != nil {
+			return err
+		}
+		if asJSON {
+			return writeMockJSON(out, issue)
+		}
+		fmt.Fprintf(out, "✓ Created issue: %s\n  Title: %s\n", issue["id"], title)
+		return nil
+
+	case "list", "ready":
+		status := opts.value("status", "s")
+		if cmd == "ready" {
+			// No dependency tracking: everything open is ready.
+			status = "open"
+		}
+		var matched []map[string]any
+		for _, issue := range store.issues {
+			st := mockString(issue, "status")
+			if (status != "" && st != status) || (status == "" && st == "closed" && !opts.has("all")) {
+				continue
+			}
+			matched = append(matched, issue)
+		}
+		if asJSON {
+			if matched == nil {
+				matched = []map[string]any{}
+			}
+			return writeMockJSON(out, matched)
+		}
+		for _, issue := range matched {
+			fmt.Fprintf(out, "%s [P%v] [%s] %s - %s\n", issue["id"], issue["priority"], mockString(issue, "issue_type"),
+				mockString(issue, "status"), mockString(issue, "title"))
+		}
+		return nil
+
+	case "show":
+		issues, err := store.lookup(positional)
+		if err != nil {
+			return err
+		}
+		if asJSON {
+			return writeMockJSON(out, issues)
+		}
+		for _, issue := range issues {
+			fmt.Fprintf(out, "%s: %s\nStatus: %s\nPriority: P%v\nType: %s\n", issue["id"], mockString(issue, "title"),
+				mockString(issue, "status"), issue["priority"], mockString(issue, "issue_type"))
+			if d := mockString(issue, "description"); d != "" {
+				fmt.Fprintf(out, "\n%s\n", d)
+			}
+		}
+		return nil
+
+	case "update", "close", "reopen":
+		issues, err := store.lookup(positional)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			switch cmd {
+			case "close":
+				issue["status"] = "closed"
+				issue["closed_at"] = now
+				if r := opts.value("reason", "r"); r != "" {
+					issue["close_reason"] = r
+				}
+			case "reopen":
+				issue["status"] = "open"
+				delete(issue, "closed_at")
+			default:
+				if err := applyMockUpdate(issue, opts, now); err != nil {
+					return err
+				}
+			}
+			issue["updated_at"] = now
+		}
+		if err := store.save(); err != nil {
+			return err
+		}
+		if asJSON {
+			return writeMockJSON(out, issues)
+		}
+		verb := map[string]string{"update": "Updated", "close": "Closed", "reopen": "Reopened"}[cmd]
+		for _, issue := range issues {
+			fmt.Fprintf(out, "✓ %s issue: %s\n", verb, issue["id"])
+		}
+		return nil
+
+	case "delete":
+		if len(positional) == 0 {
+			return fmt.Errorf("issue ID required")
+		}
+		if _, err := store.lookup(positional); err != nil {
+			return err
+		}
+		drop := make(map[string]bool, len(positional))
+		for _, id := range positional {
+			drop[id] = true
+		}
+		kept := store.issues[:0]
+		for _, issue := range store.issues {
+			if !drop[mockString(issue, "id")] {
+				kept = append(kept, issue)
+			}
+		}
+		store.issues = kept
+		if err := store.save(); err != nil {
+			return err
+		}
+		for _, id := range positional {
+			fmt.Fprintf(out, "✓ Deleted issue: %s\n", id)
+		}
+		return nil
+
+	case "export":
+		target := opts.value("output", "o")
+		if target == "" {
+			return store.write(out)
+		}
+		if abs, _ := filepath.Abs(target); abs == store.absPath() {
+			return nil
+		}
+		var buf bytes.Buffer
+		if err := store.write(&buf); err != nil {
+			return err
+		}
+		return os.WriteFile(target, buf.Bytes(), 0644)
+
+	default:
+		return fmt.Errorf("bd mock does not support %q (set %s=0 to use the real bd)", cmd, MockEnv)
+	}
+}
+
+func applyMockUpdate(issue map[string]any, opts mockFlags, now string) error {
+	if s := opts.value("status", "s"); s != "" {
+		issue["status"] = s
+		if s == "closed" {
+			issue["closed_at"] = now
+		}
+	}
+	if t := opts.value("title"); t != "" {
+		issue["title"] = t
+	}
+	if p := opts.value("priority", "p"); p != "" {
+		n, err := parseMockPriority(p)
+		if err != nil {
+			return err
+		}
+		issue["priority"] = n
+	}
+	if d := opts.value("description", "d"); d != "" {
+		issue["description"] = d
+	}
+	if a := opts.value("assignee", "a"); a != "" {
+		issue["assignee"] = a
+	}
+	return nil
+}
+
+func parseMockPriority(p string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(p), "P"))
+	if err != nil || n < 0 || n > 4 {
+		return 0, fmt.Errorf("invalid priority %q (use 0-4 or P0-P4)", p)
+	}
+	return n, nil
+}
+
+func loadMockStore(path string) (*mockStore, error) {
+	store := &mockStore{path: path}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no beads database found at %s - run 'bd init' first", filepath.Dir(path))
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var issue map[string]any
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		store.issues = append(store.issues, issue)
+	}
+	return store, scanner.Err()
+}
+
+func (s *mockStore) absPath() string {
+	abs, _ := filepath.Abs(s.path)
+	return abs
+}
+
+// nextID continues the numbering of the most common ID prefix ("bd" for an
+// empty database).
+func (s *mockStore) nextID() string {
+	counts := map[string]int{}
+	highest := map[string]int{}
+	for _, issue := range s.issues {
+		m := mockIDPattern.FindStringSubmatch(mockString(issue, "id"))
+		if m == nil {
+			continue
+		}
+		counts[m[1]]++
+		if n, _ := strconv.Atoi(m[2]); n > highest[m[1]] {
+			highest[m[1]] = n
+		}
+	}
+	prefix := "bd"
+	var prefixes []string
+	for p := range counts {
+		prefixes = append(prefixes, p)
+	}
+	sort.Strings(prefixes)
+	for _, p := range prefixes {
+		if counts[p] > counts[prefix] {
+			prefix = p
+		}
+	}
+	return fmt.Sprintf("%s-%d", prefix, highest[prefix]+1)
+}
+
+func (s *mockStore) lookup(ids []string) ([]map[string]any, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("issue ID required")
+	}
+	byID := make(map[string]map[string]any, len(s.issues))
+	for _, issue := range s.issues {
+		byID[mockString(issue, "id")] = issue
+	}
+	found := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		issue, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("issue not found: %s", id)
+		}
+		found = append(found, issue)
+	}
+	return found, nil
+}
+
+func (s *mockStore) write(out *bytes.Buffer) error {
+	for _, issue := range s.issues {
+		line, err := json.Marshal(issue)
+		if err != nil {
+			return err
+		}
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	return nil
+}
+
+// save rewrites issues.jsonl atomically.
+func (s *mockStore) save() error {
+	var buf bytes.Buffer
+	if err := s.write(&buf); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".issues-*.jsonl")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func mockString(issue map[string]any, key string) string {
+	s, _ := issue[key].(string)
+	return s
+}
+
+func writeMockJSON(out *bytes.Buffer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	out.Write(data)
+	out.WriteByte('\n')
+	return nil
+}
+
+// mockFlags holds parsed command flags. Flags are matched by long or short
+// name; "--flag=value", "--flag value" and bare boolean flags are accepted.
+type mockFlags struct {
+	values     map[string]string
+	positional []string
+}
+
+// mockBoolFlags never take a value.
+var mockBoolFlags = map[string]bool{"json": true, "all": true, "force": true, "no-daemon": true, "no-db": true}
+
+func parseMockFlags(args []string) mockFlags {
+	opts := mockFlags{values: map[string]string{}}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			opts.positional = append(opts.positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			opts.positional = append(opts.positional, arg)
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if k, v, ok := strings.Cut(name, "="); ok {
+			opts.values[k] = v
+			continue
+		}
+		if !mockBoolFlags[name] && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			opts.values[name] = args[i+1]
+			i++
+			continue
+		}
+		opts.values[name] = ""
+	}
+	return opts
+}
+
+func (f mockFlags) has(name string) bool {
+	_, ok := f.values[name]
+	return ok
+}
+
+// value returns the first non-empty value among names.
+func (f mockFlags) value(names ...string) string {
+	for _, name := range names {
+		if v := f.values[name]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
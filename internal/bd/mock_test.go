@@ -0,0 +1,77 @@
+package bd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/beads"
+)
+
+func runMockT(t *testing.T, args ...string) *Result {
+	t.Helper()
+	res, err := New().Run(context.Background(), args)
+	if err != nil {
+		t.Fatalf("bd %v: %v", args, err)
+	}
+	return res
+}
+
+func TestMock_Lifecycle(t *testing.T) {
+	t.Setenv(MockEnv, "1")
+	t.Chdir(t.TempDir())
+	beads.ResetCache()
+	t.Cleanup(beads.ResetCache)
+
+	if res := runMockT(t, "list"); res.ExitCode == 0 {
+		t.Fatal("expected list to fail before init")
+	}
+	runMockT(t, "init")
+
+	res := runMockT(t, "create", "Fix login", "-t", "bug", "-p", "1", "--json")
+	var created map[string]any
+	if err := json.Unmarshal([]byte(res.Stdout), &created); err != nil || created["id"] != "bd-1" || created["issue_type"] != "bug" {
+		t.Fatalf("create = %s (%v)", res.Stdout, err)
+	}
+	runMockT(t, "create", "--title", "Second")
+	if res := runMockT(t, "--no-daemon", "update", "bd-2", "--status", "in_progress"); res.ExitCode != 0 || !strings.Contains(res.Stdout, "Updated issue: bd-2") {
+		t.Fatalf("update = %+v", res)
+	}
+	runMockT(t, "close", "bd-1", "--reason", "done")
+
+	res = runMockT(t, "list", "--json")
+	var open []map[string]any
+	if err := json.Unmarshal([]byte(res.Stdout), &open); err != nil || len(open) != 1 || open[0]["status"] != "in_progress" {
+		t.Fatalf("list = %s (%v)", res.Stdout, err)
+	}
+	if res := runMockT(t, "update", "bd-9", "--status", "open"); res.ExitCode != 1 || !strings.Contains(res.Stderr, "issue not found: bd-9") {
+		t.Errorf("missing issue = %+v", res)
+	}
+	if res := runMockT(t, "dep", "add", "bd-1", "bd-2"); res.ExitCode != 1 || !strings.Contains(res.Stderr, "does not support") {
+		t.Errorf("unsupported = %+v", res)
+	}
+
+	out := filepath.Join(t.TempDir(), "export.jsonl")
+	runMockT(t, "export", "-o", out)
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"close_reason":"done"`) {
+		t.Errorf("export = %s", data)
+	}
+}
+
+func TestMock_NextIDKeepsPrefix(t *testing.T) {
+	store := &mockStore{issues: []map[string]any{{"id": "app-3"}, {"id": "app-10"}, {"id": "bd-50"}, {"id": "app-7.1"}}}
+	if got := store.nextID(); got != "app-11" {
+		t.Errorf("nextID = %q", got)
+	}
+	if got := (&mockStore{}).nextID(); got != "bd-1" {
+		t.Errorf("empty nextID = %q", got)
+	}
+}
@@ -0,0 +1,124 @@
+package bd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ptyDrainTimeout bounds how long RunLive waits for the last output after bd
+// exits. A background process started by bd (its daemon) can inherit the
+// terminal and keep it open indefinitely.
+const ptyDrainTimeout = 200 * time.Millisecond
+
+// errPTYUnsupported is returned by openPTY on platforms without support.
+var errPTYUnsupported = errors.New("pseudo-terminals are not supported on this platform")
+
+// RunLive executes bd with its stdout and stderr attached to pseudo-terminals
+// whose output is copied to stdout and stderr as it arrives, so bd keeps its
+// colors, progress bars and interactivity. The Result still holds a copy of
+// the output, as plain text (escape sequences removed).
+//
+// streamed reports whether the output was already written. It is false when
+// no pseudo-terminal could be opened (or the emulator is active); RunLive
+// then behaves like Run and the caller prints the output itself.
+func (r *Runner) RunLive(ctx context.Context, args []string, stdout, stderr io.Writer) (result *Result, streamed bool, err error) {
+	if MockEnabled() {
+		return runMock(args), false, nil
+	}
+
+	outMaster, outSlave, err := openPTY()
+	if err != nil {
+		result, err := r.Run(ctx, args)
+		return result, false, err
+	}
+	errMaster, errSlave, err := openPTY()
+	if err != nil {
+		closeAll(outMaster, outSlave)
+		result, err := r.Run(ctx, args)
+		return result, false, err
+	}
+	defer closeAll(outMaster, errMaster)
+
+	if cols, rows, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		_ = setPTYSize(outMaster, rows, cols)
+		_ = setPTYSize(errMaster, rows, cols)
+	}
+
+	cmd := r.command(ctx, args)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = outSlave
+	cmd.Stderr = errSlave
+	startErr := cmd.Start()
+	// The child holds its own copies; closing ours lets reads end at exit.
+	closeAll(outSlave, errSlave)
+	if startErr != nil {
+		return nil, false, startErr
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(io.MultiWriter(stdout, &outBuf), outMaster)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(io.MultiWriter(stderr, &errBuf), errMaster)
+	}()
+
+	waitErr := cmd.Wait()
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(ptyDrainTimeout):
+		// Closing the masters unblocks the copies.
+		closeAll(outMaster, errMaster)
+		<-drained
+	}
+
+	result = &Result{
+		Stdout: PlainText(outBuf.String()),
+		Stderr: PlainText(errBuf.String()),
+	}
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(waitErr, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+			return result, true, nil
+		}
+		return nil, true, waitErr
+	}
+	return result, true, nil
+}
+
+func closeAll(files ...*os.File) {
+	for _, f := range files {
+		_ = f.Close()
+	}
+}
+
+// ansiEscape matches CSI sequences (colors, cursor movement), OSC sequences
+// (titles, hyperlinks) and two-byte escapes.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// PlainText removes terminal escape sequences from output captured through a
+// pseudo-terminal and undoes its newline translation (\r\n back to \n).
+func PlainText(s string) string {
+	s = ansiEscape.ReplaceAllString(s, "")
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
@@ -0,0 +1,58 @@
+package bd
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPTY opens a pseudo-terminal pair. The ioctls go through SyscallConn
+// rather than Fd so the master stays non-blocking and Close interrupts reads.
+func openPTY() (master, slave *os.File, err error) {
+	master, err = os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := master.SyscallConn()
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+	var n int
+	var ioctlErr error
+	if err := rc.Control(func(fd uintptr) {
+		if ioctlErr = unix.IoctlSetPointerInt(int(fd), unix.TIOCSPTLCK, 0); ioctlErr != nil {
+			return
+		}
+		n, ioctlErr = unix.IoctlGetInt(int(fd), unix.TIOCGPTN)
+	}); err != nil {
+		ioctlErr = err
+	}
+	if ioctlErr != nil {
+		_ = master.Close()
+		return nil, nil, ioctlErr
+	}
+	slave, err = os.OpenFile("/dev/pts/"+strconv.Itoa(n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+	return master, slave, nil
+}
+
+// setPTYSize sets the terminal size bd sees, so progress bars fit.
+func setPTYSize(master *os.File, rows, cols int) error {
+	rc, err := master.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var ioctlErr error
+	if err := rc.Control(func(fd uintptr) {
+		ioctlErr = unix.IoctlSetWinsize(int(fd), unix.TIOCSWINSZ, &unix.Winsize{Row: uint16(rows), Col: uint16(cols)})
+	}); err != nil {
+		return err
+	}
+	return ioctlErr
+}
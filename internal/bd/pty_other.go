@@ -0,0 +1,13 @@
+//go:build !linux
+
+package bd
+
+import "os"
+
+func openPTY() (master, slave *os.File, err error) {
+	return nil, nil, errPTYUnsupported
+}
+
+func setPTYSize(master *os.File, rows, cols int) error {
+	return errPTYUnsupported
+}
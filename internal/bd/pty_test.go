@@ -0,0 +1,45 @@
+package bd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunLive_StreamsThroughTerminal(t *testing.T) {
+	master, slave, err := openPTY()
+	if err != nil {
+		t.Skipf("no pseudo-terminal available: %v", err)
+	}
+	closeAll(master, slave)
+	r := &Runner{BdPath: "sh"}
+	var stdout, stderr bytes.Buffer
+	script := `if [ -t 1 ]; then printf '\033[32mtty\033[0m\n'; else echo pipe; fi; echo oops >&2; exit 3`
+	result, streamed, err := r.RunLive(context.Background(), []string{"-c", script}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("RunLive() error: %v", err)
+	}
+	if !streamed {
+		t.Fatal("expected output to be streamed")
+	}
+	if !strings.Contains(stdout.String(), "\x1b[32mtty") {
+		t.Errorf("live stdout lost colors: %q", stdout.String())
+	}
+	if result.Stdout != "tty\n" {
+		t.Errorf("Result.Stdout = %q, want plain %q", result.Stdout, "tty\n")
+	}
+	if result.Stderr != "oops\n" || !strings.Contains(stderr.String(), "oops") {
+		t.Errorf("stderr: result %q, live %q", result.Stderr, stderr.String())
+	}
+	if result.ExitCode != 3 {
+		t.Errorf("ExitCode = %d, want 3", result.ExitCode)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	in := "\x1b[1;31mred\x1b[0m\r\n\x1b]8;;https://x\x07link\x1b]8;;\x07\r\n"
+	if got, want := PlainText(in), "red\nlink\n"; got != want {
+		t.Errorf("PlainText() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import "context"
+
+// API is the method set of Client. Code that talks to BeadHub can accept an
+// API instead of a *Client, so tests can use the in-memory fake in
+// clienttest instead of an httptest server.
+type API interface {
+	ServerVersion() string
+
+	Command(ctx context.Context, req *CommandRequest) (*CommandResponse, error)
+	Sync(ctx context.Context, req *SyncRequest) (*SyncResponse, error)
+	SyncManifest(ctx context.Context, req *SyncManifestRequest) (*SyncManifestResponse, error)
+	EnsureProject(ctx context.Context, req *EnsureProjectRequest) (*EnsureProjectResponse, error)
+	Init(ctx context.Context, req *InitRequest) (*InitResponse, error)
+	ListProjects(ctx context.Context) (*ListProjectsResponse, error)
+	DeleteProject(ctx context.Context, projectID string) (*DeleteProjectResponse, error)
+	ListProjectRepos(ctx context.Context, projectID string) (*ListProjectReposResponse, error)
+	ListProjectWorkspaces(ctx context.Context, projectID string) (*WorkspacesResponse, error)
+	ProjectStatus(ctx context.Context, projectID string) (*ProjectStatusResponse, error)
+	EnsureRepo(ctx context.Context, req *EnsureRepoRequest) (*EnsureRepoResponse, error)
+	LookupRepo(ctx context.Context, req *LookupRepoRequest) (*LookupRepoResponse, error)
+	RefreshPresence(ctx context.Context, req *RefreshPresenceRequest) (*RefreshPresenceResponse, error)
+	RegisterWorkspace(ctx context.Context, req *RegisterWorkspaceRequest) (*RegisterWorkspaceResponse, error)
+	SuggestNamePrefix(ctx context.Context, req *SuggestNamePrefixRequest) (*SuggestNamePrefixResponse, error)
+	SuggestAliasPrefixByProject(ctx context.Context, req *SuggestAliasPrefixRequest) (*SuggestAliasPrefixResponse, error)
+	Inbox(ctx context.Context, req *InboxRequest) (*InboxResponse, error)
+	Ack(ctx context.Context, messageID string, req *AckRequest) (*AckResponse, error)
+	Send(ctx context.Context, req *SendRequest) (*SendResponse, error)
+	SentMessages(ctx context.Context, req *SentMessagesRequest) (*SentMessagesResponse, error)
+	MessageReceipt(ctx context.Context, messageID string) (*SentMessage, error)
+	DeleteWorkspace(ctx context.Context, workspaceID string) (*DeleteWorkspaceResponse, error)
+	Workspaces(ctx context.Context, req *WorkspacesRequest) (*WorkspacesResponse, error)
+	TeamWorkspaces(ctx context.Context, req *TeamWorkspacesRequest) (*WorkspacesResponse, error)
+	SearchBeads(ctx context.Context, req *SearchBeadsRequest) (*SearchBeadsResponse, error)
+	AddBeadNote(ctx context.Context, beadID string, req *AddBeadNoteRequest) (*BeadNote, error)
+	ListBeadNotes(ctx context.Context, req *ListBeadNotesRequest) (*ListBeadNotesResponse, error)
+	AddBeadAttachment(ctx context.Context, beadID string, req *AddBeadAttachmentRequest) (*BeadAttachment, error)
+	ListBeadAttachments(ctx context.Context, req *ListBeadAttachmentsRequest) (*ListBeadAttachmentsResponse, error)
+	ActivePolicy(ctx context.Context, req *ActivePolicyRequest) (*ActivePolicyResponse, error)
+	ActivePolicyFetch(ctx context.Context, reqParams *ActivePolicyRequest, opts *ActivePolicyFetchOptions) (*ActivePolicyFetchResponse, error)
+	ResetPolicy(ctx context.Context) (*ResetPolicyResponse, error)
+	Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error)
+	Escalate(ctx context.Context, req *EscalateRequest) (*EscalateResponse, error)
+	GetEscalation(ctx context.Context, escalationID string) (*Escalation, error)
+	ScheduleMessage(ctx context.Context, req *ScheduleMessageRequest) (*ScheduleMessageResponse, error)
+	Capabilities(ctx context.Context) (*CapabilitiesResponse, error)
+	SetAnnouncement(ctx context.Context, req *SetAnnouncementRequest) (*Announcement, error)
+	ClearAnnouncement(ctx context.Context) error
+	Version(ctx context.Context) (*VersionResponse, error)
+	CreateInvite(ctx context.Context, req *CreateInviteRequest) (*CreateInviteResponse, error)
+	Lock(ctx context.Context, req *LockRequest) (*LockResponse, error)
+	Unlock(ctx context.Context, req *UnlockRequest) (*UnlockResponse, error)
+	ListLocks(ctx context.Context, req *ListLocksRequest) (*ListLocksResponse, error)
+	ReservationHistory(ctx context.Context, req *ReservationHistoryRequest) (*ReservationHistoryResponse, error)
+	RequestTakeover(ctx context.Context, req *TakeoverRequest) (*TakeoverResponse, error)
+	SetFocus(ctx context.Context, workspaceID string, req *SetFocusRequest) (*SetFocusResponse, error)
+	Squad(ctx context.Context, workspaceID string) (*SquadResponse, error)
+	RequestApproval(ctx context.Context, req *RequestApprovalRequest) (*Approval, error)
+	ListApprovals(ctx context.Context, req *ListApprovalsRequest) (*ListApprovalsResponse, error)
+	GetApproval(ctx context.Context, approvalID string) (*Approval, error)
+	DecideApproval(ctx context.Context, approvalID string, req *DecideApprovalRequest) (*Approval, error)
+	ReportReservationEvents(ctx context.Context, req *ReportReservationEventsRequest) error
+	Activity(ctx context.Context, req *ActivityRequest) (*ActivityResponse, error)
+	PublishKey(ctx context.Context, req *PublishKeyRequest) (*PublicKey, error)
+	PublicKeys(ctx context.Context, req *PublicKeysRequest) (*PublicKeysResponse, error)
+	Raw(ctx context.Context, method, path string, body []byte) (*RawResponse, error)
+}
+
+var _ API = (*Client)(nil)
@@ -6,17 +6,22 @@
 // - POST /v1/projects/ensure - Get or create project by slug
 // - Messaging endpoints (:mail --inbox, :mail --send)
 // - Escalation endpoints (:escalate)
+// - Approval requests (:approve)
+// - Chat session management (:aweb chat sessions, :aweb chat session)
 package client
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -31,6 +36,25 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	apiKey     string // API key for Bearer auth
+
+	serverVersion atomic.Value // string, from ServerVersionHeader
+}
+
+// ServerVersionHeader carries the server's version on every response.
+const ServerVersionHeader = "X-BeadHub-Version"
+
+// noteServerVersion remembers the version a response advertises.
+func (c *Client) noteServerVersion(resp *http.Response) {
+	if v := strings.TrimSpace(resp.Header.Get(ServerVersionHeader)); v != "" {
+		c.serverVersion.Store(v)
+	}
+}
+
+// ServerVersion returns the server version seen on the last response that
+// carried one, or "".
+func (c *Client) ServerVersion() string {
+	v, _ := c.serverVersion.Load().(string)
+	return v
 }
 
 // New creates a new BeadHub client.
@@ -38,7 +62,8 @@ func New(baseURL string) *Client {
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
+			Timeout:   DefaultTimeout,
+			Transport: SharedTransport(),
 		},
 	}
 }
@@ -49,7 +74,8 @@ func NewWithAPIKey(baseURL, apiKey string) *Client {
 	return &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
+			Timeout:   DefaultTimeout,
+			Transport: SharedTransport(),
 		},
 		apiKey: apiKey,
 	}
@@ -100,10 +126,12 @@ func (c *Client) Command(ctx context.Context, req *CommandRequest) (*CommandResp
 }
 
 // SyncRequest is the request body for /v1/bdh/sync.
-// Supports two modes:
+// Supports three modes:
 //   - Full sync: IssuesJSONL contains all issues (SyncMode empty or "full")
 //   - Incremental: SyncMode="incremental", ChangedIssues contains modified issues,
 //     DeletedIDs contains IDs to remove
+//   - Patch (sync protocol v2): SyncMode="patch", like incremental but issues the
+//     server already has may be sent as IssuePatches instead of in ChangedIssues
 type SyncRequest struct {
 	WorkspaceID string `json:"workspace_id"`
 	RepoID      string `json:"repo_id,omitempty"`
@@ -121,8 +149,32 @@ type SyncRequest struct {
 	ChangedIssues string   `json:"changed_issues,omitempty"` // JSONL of changed/new issues
 	DeletedIDs    []string `json:"deleted_ids,omitempty"`    // IDs of deleted issues
 
+	// Patch sync mode
+	IssuePatches []IssuePatch `json:"issue_patches,omitempty"`
+
 	// Sync protocol negotiation (optional; enables safe schema evolution/backfills)
 	SyncProtocolVersion *int `json:"sync_protocol_version,omitempty"`
+
+	// Tooling this workspace runs, shown on the dashboard and in :doctor --team
+	Env *WorkspaceEnv `json:"env,omitempty"`
+}
+
+// WorkspaceEnv describes the tooling a workspace last synced with.
+type WorkspaceEnv struct {
+	BdhVersion string `json:"bdh_version,omitempty"`
+	BdVersion  string `json:"bd_version,omitempty"`
+	OS         string `json:"os,omitempty"` // GOOS/GOARCH
+	GitBranch  string `json:"git_branch,omitempty"`
+	GitCommit  string `json:"git_commit,omitempty"`
+}
+
+// IssuePatch is a JSON merge patch (RFC 7396) for one issue. The server
+// applies it only if its copy of the issue still hashes to BaseHash, and
+// answers 409 otherwise.
+type IssuePatch struct {
+	ID       string          `json:"id"`
+	BaseHash string          `json:"base_hash"`
+	Patch    json.RawMessage `json:"patch"`
 }
 
 // SyncStats contains detailed statistics from a sync operation.
@@ -154,6 +206,30 @@ func (c *Client) Sync(ctx context.Context, req *SyncRequest) (*SyncResponse, err
 	return &resp, nil
 }
 
+// SyncManifestRequest is the request parameters for GET /v1/bdh/sync/manifest.
+type SyncManifestRequest struct {
+	WorkspaceID string
+	RepoID      string
+}
+
+// SyncManifestResponse is the response from GET /v1/bdh/sync/manifest.
+// IssueHashes maps issue ID to the content hash computed the same way as
+// internal/sync.ComputeIssueHash.
+type SyncManifestResponse struct {
+	IssueHashes map[string]string `json:"issue_hashes"`
+	IssuesCount int               `json:"issues_count"`
+	SyncedAt    string            `json:"synced_at,omitempty"`
+}
+
+// SyncManifest returns the server's per-issue hashes for this repo.
+func (c *Client) SyncManifest(ctx context.Context, req *SyncManifestRequest) (*SyncManifestResponse, error) {
+	var resp SyncManifestResponse
+	if err := c.get(ctx, "/v1/bdh/sync/manifest", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // EnsureProjectRequest is the request body for /v1/projects/ensure.
 type EnsureProjectRequest struct {
 	Slug string `json:"slug"`
@@ -185,6 +261,7 @@ type InitRequest struct {
 	Email         string  `json:"email,omitempty"` // For Cloud (ignored by OSS)
 	Hostname      string  `json:"hostname,omitempty"`
 	WorkspacePath string  `json:"workspace_path,omitempty"`
+	InviteToken   string  `json:"invite_token,omitempty"` // From :team invite; claims a provisional workspace
 }
 
 // InitResponse is the response from POST /v1/init.
@@ -255,6 +332,71 @@ func (c *Client) DeleteProject(ctx context.Context, projectID string) (*DeletePr
 	return &resp, nil
 }
 
+// ProjectRepo is a repo registered under a project.
+type ProjectRepo struct {
+	ID              string `json:"id"`
+	CanonicalOrigin string `json:"canonical_origin"`
+	Name            string `json:"name,omitempty"`
+	WorkspaceCount  int    `json:"workspace_count"`
+}
+
+// ListProjectReposResponse is the response from GET /v1/projects/{id}/repos.
+type ListProjectReposResponse struct {
+	Repos []ProjectRepo `json:"repos"`
+}
+
+// ListProjectRepos lists the repos registered under a project.
+func (c *Client) ListProjectRepos(ctx context.Context, projectID string) (*ListProjectReposResponse, error) {
+	var resp ListProjectReposResponse
+	if err := c.get(ctx, "/v1/projects/"+url.PathEscape(projectID)+"/repos", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListProjectWorkspaces lists the workspaces that belong to a project.
+func (c *Client) ListProjectWorkspaces(ctx context.Context, projectID string) (*WorkspacesResponse, error) {
+	var resp WorkspacesResponse
+	if err := c.get(ctx, "/v1/projects/"+url.PathEscape(projectID)+"/workspaces", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ProjectRepoAgent is an agent recently active in a repo.
+type ProjectRepoAgent struct {
+	Alias      string `json:"alias"`
+	Role       string `json:"role,omitempty"`
+	LastSeen   string `json:"last_seen,omitempty"`
+	LastSyncAt string `json:"last_sync_at,omitempty"`
+}
+
+// ProjectRepoStatus is the activity summary for one repo in a project.
+type ProjectRepoStatus struct {
+	ID              string             `json:"id"`
+	CanonicalOrigin string             `json:"canonical_origin"`
+	Name            string             `json:"name,omitempty"`
+	LastSyncAt      string             `json:"last_sync_at,omitempty"`
+	IssueCounts     map[string]int     `json:"issue_counts"` // by status
+	ActiveAgents    []ProjectRepoAgent `json:"active_agents"`
+}
+
+// ProjectStatusResponse is the response from GET /v1/projects/{id}/status.
+type ProjectStatusResponse struct {
+	ProjectID string              `json:"project_id"`
+	Slug      string              `json:"slug"`
+	Repos     []ProjectRepoStatus `json:"repos"`
+}
+
+// ProjectStatus returns per-repo sync and activity for a project.
+func (c *Client) ProjectStatus(ctx context.Context, projectID string) (*ProjectStatusResponse, error) {
+	var resp ProjectStatusResponse
+	if err := c.get(ctx, "/v1/projects/"+url.PathEscape(projectID)+"/status", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // EnsureRepoRequest is the request body for /v1/repos/ensure.
 type EnsureRepoRequest struct {
 	ProjectID string `json:"project_id"`
@@ -325,6 +467,21 @@ type RefreshPresenceRequest struct {
 	Branch          string `json:"branch,omitempty"`
 	Role            string `json:"role,omitempty"`
 	TTLSeconds      int    `json:"ttl_seconds,omitempty"`
+
+	// Tags is the workspace's full set of capability tags (:tags); an empty
+	// list clears them.
+	Tags []string `json:"tags"`
+
+	// Presence status extension (:dnd). PresenceStatus is "dnd" or
+	// "available"; empty leaves the server-side status unchanged.
+	PresenceStatus string `json:"presence_status,omitempty"`
+	PresenceUntil  string `json:"presence_until,omitempty"`
+	PresenceNote   string `json:"presence_note,omitempty"`
+
+	// Away auto-reply (away.auto_reply). The server sends AwayReply to new
+	// chat sessions once the agent has been offline for AwayAfterSeconds.
+	AwayReply        string `json:"away_reply,omitempty"`
+	AwayAfterSeconds int    `json:"away_after_seconds,omitempty"`
 }
 
 // RefreshPresenceResponse is the response from /v1/agents/register.
@@ -346,6 +503,7 @@ func (c *Client) RefreshPresence(ctx context.Context, req *RefreshPresenceReques
 // RegisterWorkspaceRequest is the request body for /v1/workspaces/register.
 type RegisterWorkspaceRequest struct {
 	RepoOrigin    string `json:"repo_origin"`
+	Alias         string `json:"alias,omitempty"` // Set to rename the workspace
 	Role          string `json:"role,omitempty"`
 	Hostname      string `json:"hostname,omitempty"`
 	WorkspacePath string `json:"workspace_path,omitempty"`
@@ -426,6 +584,11 @@ type InboxRequest struct {
 	UnreadOnly    bool
 	FromWorkspace string // Filter to messages from this workspace
 	FromAlias     string // Filter to messages from sender with this alias
+
+	IncludeArchived bool // Also return messages archived via Ack with Archive set
+
+	Before string // Only messages older than this message ID (next page)
+	Query  string // Full-text search over subject and body (capability inbox_search)
 }
 
 // InboxResponse is the response from GET /v1/messages/inbox.
@@ -445,6 +608,7 @@ type Message struct {
 	Priority      string `json:"priority"`
 	ThreadID      string `json:"thread_id,omitempty"`
 	Read          bool   `json:"read"`
+	Archived      bool   `json:"archived,omitempty"`
 	CreatedAt     string `json:"created_at"`
 }
 
@@ -460,12 +624,16 @@ func (c *Client) Inbox(ctx context.Context, req *InboxRequest) (*InboxResponse,
 // AckRequest is the request body for POST /v1/messages/{id}/ack.
 type AckRequest struct {
 	WorkspaceID string `json:"workspace_id"`
+	// Archive marks the message as done, hiding it from inbox listings
+	// unless IncludeArchived is set. A plain ack means "seen, pending action".
+	Archive bool `json:"archive,omitempty"`
 }
 
 // AckResponse is the response from POST /v1/messages/{id}/ack.
 type AckResponse struct {
 	MessageID      string `json:"message_id"`
 	AcknowledgedAt string `json:"acknowledged_at"`
+	ArchivedAt     string `json:"archived_at,omitempty"`
 }
 
 // Ack acknowledges (marks as read) a message.
@@ -504,6 +672,54 @@ func (c *Client) Send(ctx context.Context, req *SendRequest) (*SendResponse, err
 	return &resp, nil
 }
 
+// SentMessagesRequest is the request parameters for GET /v1/messages/sent.
+type SentMessagesRequest struct {
+	WorkspaceID string
+	ToAlias     string // Filter to messages sent to this alias
+	Limit       int
+}
+
+// SentMessage is a message sent by the workspace, with its receipt.
+type SentMessage struct {
+	MessageID   string `json:"message_id"`
+	ToWorkspace string `json:"to_workspace"`
+	ToAlias     string `json:"to_alias"`
+	Subject     string `json:"subject"`
+	Priority    string `json:"priority"`
+	CreatedAt   string `json:"created_at"`
+	DeliveredAt string `json:"delivered_at,omitempty"`
+	ReadAt      string `json:"read_at,omitempty"`
+	ArchivedAt  string `json:"archived_at,omitempty"`
+}
+
+// SentMessagesResponse is the response from GET /v1/messages/sent.
+type SentMessagesResponse struct {
+	Messages []SentMessage `json:"messages"`
+	Count    int           `json:"count"`
+	HasMore  bool          `json:"has_more"`
+}
+
+// SentMessages lists messages sent by the workspace, newest first, with
+// their delivery and read receipts.
+func (c *Client) SentMessages(ctx context.Context, req *SentMessagesRequest) (*SentMessagesResponse, error) {
+	var resp SentMessagesResponse
+	if err := c.get(ctx, "/v1/messages/sent", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// MessageReceipt fetches the receipt of one sent message via
+// GET /v1/messages/{id}/receipt.
+func (c *Client) MessageReceipt(ctx context.Context, messageID string) (*SentMessage, error) {
+	var resp SentMessage
+	path := fmt.Sprintf("/v1/messages/%s/receipt", url.PathEscape(messageID))
+	if err := c.get(ctx, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // WorkspacesRequest is the request parameters for GET /v1/workspaces.
 type WorkspacesRequest struct {
 	HumanName       string
@@ -525,12 +741,29 @@ type TeamWorkspacesRequest struct {
 	OnlyWithClaims           *bool
 	AlwaysIncludeWorkspaceID string
 	Limit                    int
+
+	// FocusApexID and Area shard a large team: only workspaces focused on
+	// (or claiming under) FocusApexID, or holding reservations under the
+	// directory Area, are listed; the rest are summarized in Others.
+	FocusApexID string
+	Area        string
 }
 
 // WorkspacesResponse is the response from GET /v1/workspaces.
 type WorkspacesResponse struct {
 	Workspaces []Workspace `json:"workspaces"`
 	Count      int         `json:"count"`
+
+	// Others summarizes the workspaces left out by the team query's focus
+	// and area filters.
+	Others *TeamOthers `json:"others,omitempty"`
+}
+
+// TeamOthers summarizes the team members outside a sharded team query.
+type TeamOthers struct {
+	Count  int            `json:"count"`
+	Epics  int            `json:"epics"`
+	ByRole map[string]int `json:"by_role,omitempty"` // "" counts workspaces without a role
 }
 
 // Claim represents an active bead claim by a workspace.
@@ -545,25 +778,34 @@ type Claim struct {
 
 // Workspace represents workspace presence information.
 type Workspace struct {
-	WorkspaceID       string  `json:"workspace_id"`
-	Alias             string  `json:"alias"`
-	HumanName         string  `json:"human_name"`
-	ProjectSlug       string  `json:"project_slug"`
-	Role              string  `json:"role,omitempty"`
-	Hostname          string  `json:"hostname,omitempty"`
-	WorkspacePath     string  `json:"workspace_path,omitempty"`
-	ApexID            string  `json:"apex_id,omitempty"`
-	ApexTitle         string  `json:"apex_title,omitempty"`
-	ApexType          string  `json:"apex_type,omitempty"`
-	FocusApexID       string  `json:"focus_apex_id,omitempty"`
-	FocusApexTitle    string  `json:"focus_apex_title,omitempty"`
-	FocusApexType     string  `json:"focus_apex_type,omitempty"`
-	FocusApexRepoName string  `json:"focus_apex_repo_name,omitempty"`
-	FocusApexBranch   string  `json:"focus_apex_branch,omitempty"`
-	FocusUpdatedAt    string  `json:"focus_updated_at,omitempty"`
-	Status            string  `json:"status"`
-	LastSeen          string  `json:"last_seen"`
-	Claims            []Claim `json:"claims"`
+	WorkspaceID       string   `json:"workspace_id"`
+	Alias             string   `json:"alias"`
+	HumanName         string   `json:"human_name"`
+	ProjectSlug       string   `json:"project_slug"`
+	Role              string   `json:"role,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+	Hostname          string   `json:"hostname,omitempty"`
+	WorkspacePath     string   `json:"workspace_path,omitempty"`
+	ApexID            string   `json:"apex_id,omitempty"`
+	ApexTitle         string   `json:"apex_title,omitempty"`
+	ApexType          string   `json:"apex_type,omitempty"`
+	FocusApexID       string   `json:"focus_apex_id,omitempty"`
+	FocusApexTitle    string   `json:"focus_apex_title,omitempty"`
+	FocusApexType     string   `json:"focus_apex_type,omitempty"`
+	FocusApexRepoName string   `json:"focus_apex_repo_name,omitempty"`
+	FocusApexBranch   string   `json:"focus_apex_branch,omitempty"`
+	FocusUpdatedAt    string   `json:"focus_updated_at,omitempty"`
+	Status            string   `json:"status"`
+	LastSeen          string   `json:"last_seen"`
+	Claims            []Claim  `json:"claims"`
+
+	// Presence status set with :dnd ("dnd" or empty).
+	PresenceStatus string `json:"presence_status,omitempty"`
+	PresenceUntil  string `json:"presence_until,omitempty"`
+	PresenceNote   string `json:"presence_note,omitempty"`
+
+	// Env is the tooling reported with the workspace's last sync, if any.
+	Env *WorkspaceEnv `json:"env,omitempty"`
 }
 
 // DeleteWorkspaceResponse is the response from DELETE /v1/workspaces/{id}.
@@ -605,6 +847,167 @@ func (c *Client) TeamWorkspaces(ctx context.Context, req *TeamWorkspacesRequest)
 	return &resp, nil
 }
 
+// =============================================================================
+// Bead Search API
+// =============================================================================
+
+// SearchBeadsRequest is the request parameters for GET /v1/beads/search.
+type SearchBeadsRequest struct {
+	Query string
+	Repo  string // Optional: restrict to one repo (canonical origin)
+	Limit int
+}
+
+// SearchBeadResult is a single bead matching a search query.
+type SearchBeadResult struct {
+	BeadID    string           `json:"bead_id"`
+	Title     string           `json:"title"`
+	Status    string           `json:"status,omitempty"`
+	Repo      string           `json:"repo,omitempty"`
+	Score     float64          `json:"score,omitempty"`
+	Claimants []BeadInProgress `json:"claimants,omitempty"`
+}
+
+// SearchBeadsResponse is the response from GET /v1/beads/search.
+type SearchBeadsResponse struct {
+	Results []SearchBeadResult `json:"results"`
+	Count   int                `json:"count"`
+}
+
+// SearchBeads searches bead titles and descriptions across all repos in the project.
+func (c *Client) SearchBeads(ctx context.Context, req *SearchBeadsRequest) (*SearchBeadsResponse, error) {
+	var resp SearchBeadsResponse
+	if err := c.get(ctx, "/v1/beads/search", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// =============================================================================
+// Bead Notes API
+// =============================================================================
+
+// BeadNote is a short workspace-authored note attached to a bead.
+type BeadNote struct {
+	NoteID      string `json:"note_id"`
+	BeadID      string `json:"bead_id"`
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	Body        string `json:"body"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// AddBeadNoteRequest is the request body for POST /v1/beads/{id}/notes.
+type AddBeadNoteRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	RepoID      string `json:"repo_id,omitempty"`
+	Body        string `json:"body"`
+}
+
+// AddBeadNote attaches a note to a bead.
+func (c *Client) AddBeadNote(ctx context.Context, beadID string, req *AddBeadNoteRequest) (*BeadNote, error) {
+	var resp BeadNote
+	path := fmt.Sprintf("/v1/beads/%s/notes", url.PathEscape(beadID))
+	if err := c.post(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListBeadNotesRequest is the request parameters for GET /v1/beads/{id}/notes.
+type ListBeadNotesRequest struct {
+	BeadID string
+	Limit  int
+}
+
+// ListBeadNotesResponse is the response from GET /v1/beads/{id}/notes.
+type ListBeadNotesResponse struct {
+	Notes []BeadNote `json:"notes"`
+	Count int        `json:"count"`
+}
+
+// ListBeadNotes lists notes attached to a bead, newest first.
+func (c *Client) ListBeadNotes(ctx context.Context, req *ListBeadNotesRequest) (*ListBeadNotesResponse, error) {
+	var resp ListBeadNotesResponse
+	path := fmt.Sprintf("/v1/beads/%s/notes", url.PathEscape(req.BeadID))
+	if err := c.get(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Bead attachment kinds.
+const (
+	AttachmentKindPath = "path" // a file in the repo, by repo-relative path
+	AttachmentKindURL  = "url"  // a link
+	AttachmentKindText = "text" // a small text artifact stored inline
+)
+
+// BeadAttachment is a reference to an artifact attached to a bead. Only
+// metadata is stored for paths and URLs; text attachments carry Content.
+type BeadAttachment struct {
+	AttachmentID string `json:"attachment_id"`
+	BeadID       string `json:"bead_id"`
+	WorkspaceID  string `json:"workspace_id"`
+	Alias        string `json:"alias"`
+	Kind         string `json:"kind"`
+	Name         string `json:"name"`
+	Ref          string `json:"ref,omitempty"`
+	Content      string `json:"content,omitempty"`
+	SizeBytes    int64  `json:"size_bytes,omitempty"`
+	SHA256       string `json:"sha256,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// AddBeadAttachmentRequest is the request body for
+// POST /v1/beads/{id}/attachments.
+type AddBeadAttachmentRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	RepoID      string `json:"repo_id,omitempty"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Ref         string `json:"ref,omitempty"`
+	Content     string `json:"content,omitempty"`
+	SizeBytes   int64  `json:"size_bytes,omitempty"`
+	SHA256      string `json:"sha256,omitempty"`
+}
+
+// AddBeadAttachment attaches an artifact reference to a bead.
+func (c *Client) AddBeadAttachment(ctx context.Context, beadID string, req *AddBeadAttachmentRequest) (*BeadAttachment, error) {
+	var resp BeadAttachment
+	path := fmt.Sprintf("/v1/beads/%s/attachments", url.PathEscape(beadID))
+	if err := c.post(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListBeadAttachmentsRequest is the request parameters for
+// GET /v1/beads/{id}/attachments.
+type ListBeadAttachmentsRequest struct {
+	BeadID string
+	Limit  int
+}
+
+// ListBeadAttachmentsResponse is the response from
+// GET /v1/beads/{id}/attachments.
+type ListBeadAttachmentsResponse struct {
+	Attachments []BeadAttachment `json:"attachments"`
+	Count       int              `json:"count"`
+}
+
+// ListBeadAttachments lists artifacts attached to a bead, newest first.
+func (c *Client) ListBeadAttachments(ctx context.Context, req *ListBeadAttachmentsRequest) (*ListBeadAttachmentsResponse, error) {
+	var resp ListBeadAttachmentsResponse
+	path := fmt.Sprintf("/v1/beads/%s/attachments", url.PathEscape(req.BeadID))
+	if err := c.get(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // =============================================================================
 // Project Policy API
 // =============================================================================
@@ -817,6 +1220,166 @@ func (c *Client) Escalate(ctx context.Context, req *EscalateRequest) (*EscalateR
 	return &resp, nil
 }
 
+// Escalation is the current state of an escalation from GET /v1/escalations/{id}.
+type Escalation struct {
+	EscalationID string `json:"escalation_id"`
+	Subject      string `json:"subject"`
+	Status       string `json:"status"`
+	Response     string `json:"response,omitempty"`
+	RespondedBy  string `json:"responded_by,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	RespondedAt  string `json:"responded_at,omitempty"`
+	ExpiresAt    string `json:"expires_at,omitempty"`
+}
+
+// GetEscalation fetches an escalation's current status.
+func (c *Client) GetEscalation(ctx context.Context, escalationID string) (*Escalation, error) {
+	var resp Escalation
+	if err := c.get(ctx, "/v1/escalations/"+url.PathEscape(escalationID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// =============================================================================
+// Scheduled mail
+// =============================================================================
+
+// ScheduleMessageRequest is the request body for POST /v1/messages/scheduled.
+type ScheduleMessageRequest struct {
+	ToAlias   string `json:"to_alias"`
+	Subject   string `json:"subject,omitempty"`
+	Body      string `json:"body"`
+	Priority  string `json:"priority,omitempty"`
+	DeliverAt string `json:"deliver_at"` // RFC3339
+}
+
+// ScheduleMessageResponse is the response from POST /v1/messages/scheduled.
+type ScheduleMessageResponse struct {
+	ScheduledID string `json:"scheduled_id"`
+	DeliverAt   string `json:"deliver_at"`
+}
+
+// ScheduleMessage queues a message for delivery at a later time. Servers
+// without delayed delivery answer 404; callers fall back to a local queue.
+func (c *Client) ScheduleMessage(ctx context.Context, req *ScheduleMessageRequest) (*ScheduleMessageResponse, error) {
+	var resp ScheduleMessageResponse
+	if err := c.post(ctx, "/v1/messages/scheduled", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// =============================================================================
+// Capabilities API
+// =============================================================================
+
+// CapabilitiesResponse is the response from GET /v1/capabilities.
+// Features maps optional feature names (e.g. "team_query", "policies") to
+// whether this server supports them.
+type CapabilitiesResponse struct {
+	ServerVersion string          `json:"server_version,omitempty"`
+	Features      map[string]bool `json:"features"`
+	// Announcement is the project's current announcement, if any.
+	Announcement *Announcement `json:"announcement,omitempty"`
+}
+
+// Capabilities reports which optional endpoints the server supports. Servers
+// that predate capability discovery answer 404.
+func (c *Client) Capabilities(ctx context.Context) (*CapabilitiesResponse, error) {
+	var resp CapabilitiesResponse
+	if err := c.get(ctx, "/v1/capabilities", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// =============================================================================
+// Announcements API
+// =============================================================================
+
+// Announcement is a project-wide banner such as "main is frozen for release
+// until Friday". ExpiresAt is optional.
+type Announcement struct {
+	Message   string `json:"message"`
+	SetBy     string `json:"set_by,omitempty"`
+	SetAt     string `json:"set_at,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// SetAnnouncementRequest is the request body for POST /v1/announcement.
+type SetAnnouncementRequest struct {
+	Message   string `json:"message"`
+	SetBy     string `json:"set_by"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// SetAnnouncement replaces the project's announcement. Servers restrict it
+// to project admins.
+func (c *Client) SetAnnouncement(ctx context.Context, req *SetAnnouncementRequest) (*Announcement, error) {
+	var resp Announcement
+	if err := c.post(ctx, "/v1/announcement", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ClearAnnouncement removes the project's announcement.
+func (c *Client) ClearAnnouncement(ctx context.Context) error {
+	var resp map[string]any
+	return c.delete(ctx, "/v1/announcement", &resp)
+}
+
+// VersionResponse is the response from GET /v1/version.
+type VersionResponse struct {
+	Version string `json:"version"`
+}
+
+// Version returns the server's version. Servers that predate the endpoint
+// answer 404.
+func (c *Client) Version(ctx context.Context) (*VersionResponse, error) {
+	var resp VersionResponse
+	if err := c.get(ctx, "/v1/version", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// =============================================================================
+// Invites API
+// =============================================================================
+
+// CreateInviteRequest is the request body for POST /v1/invites.
+type CreateInviteRequest struct {
+	ProjectSlug string `json:"project_slug"`
+	RepoOrigin  string `json:"repo_origin,omitempty"`
+	InvitedBy   string `json:"invited_by"`
+	Role        string `json:"role,omitempty"`
+	Alias       string `json:"alias,omitempty"`
+	Email       string `json:"email,omitempty"`
+	// Provision creates the workspace now; :init with the token claims it.
+	Provision bool `json:"provision,omitempty"`
+}
+
+// CreateInviteResponse is the response from POST /v1/invites.
+type CreateInviteResponse struct {
+	Token       string `json:"token"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	Emailed     bool   `json:"emailed"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Alias       string `json:"alias,omitempty"`
+}
+
+// CreateInvite issues an invite token for a new teammate, optionally emailing
+// it and provisioning their workspace.
+func (c *Client) CreateInvite(ctx context.Context, req *CreateInviteRequest) (*CreateInviteResponse, error) {
+	var resp CreateInviteResponse
+	if err := c.post(ctx, "/v1/invites", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // =============================================================================
 // Reservations API (file reservations)
 // =============================================================================
@@ -927,6 +1490,329 @@ func (c *Client) ListLocks(ctx context.Context, req *ListLocksRequest) (*ListLoc
 	return &resp, nil
 }
 
+// ReservationHistoryRequest is the request parameters for
+// GET /v1/reservations/history.
+type ReservationHistoryRequest struct {
+	// Since is an RFC 3339 timestamp; only later events are returned.
+	Since string
+	Limit int
+}
+
+// Reservation event kinds.
+const (
+	ReservationEventAcquired = "acquired"
+	ReservationEventConflict = "conflict"
+)
+
+// ReservationEvent is one past reservation attempt. For conflicts,
+// HolderAlias held the path and RequestedBy was refused it.
+type ReservationEvent struct {
+	Path        string `json:"resource_key"`
+	Kind        string `json:"kind"`
+	HolderAlias string `json:"holder_alias"`
+	RequestedBy string `json:"requested_by,omitempty"`
+	BeadID      string `json:"bead_id,omitempty"`
+	At          string `json:"at"`
+}
+
+// ReservationHistoryResponse is the response from GET /v1/reservations/history.
+type ReservationHistoryResponse struct {
+	Events []ReservationEvent `json:"events"`
+}
+
+// ReservationHistory lists past reservation acquisitions and conflicts in the
+// project.
+func (c *Client) ReservationHistory(ctx context.Context, req *ReservationHistoryRequest) (*ReservationHistoryResponse, error) {
+	var resp ReservationHistoryResponse
+	if err := c.get(ctx, "/v1/reservations/history", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// TakeoverRequest is the request body for POST /v1/reservations/takeover.
+type TakeoverRequest struct {
+	ResourceKey  string `json:"resource_key"`
+	WorkspaceID  string `json:"workspace_id"`
+	Alias        string `json:"alias"`
+	GraceSeconds int    `json:"grace_seconds"`
+}
+
+// Takeover request statuses.
+const (
+	TakeoverQueued  = "queued"  // the holder has until GrantAt to refresh
+	TakeoverGranted = "granted" // the reservation now belongs to the requester
+	TakeoverRefused = "refused" // e.g. the holder is active; see Reason
+)
+
+// TakeoverResponse is the response from POST /v1/reservations/takeover.
+type TakeoverResponse struct {
+	TakeoverID     string `json:"takeover_id,omitempty"`
+	ResourceKey    string `json:"resource_key"`
+	HolderAlias    string `json:"holder_alias"`
+	Status         string `json:"status"`
+	GrantAt        string `json:"grant_at,omitempty"`
+	HolderNotified bool   `json:"holder_notified"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// RequestTakeover asks the server to hand a reservation over to the caller
+// unless its holder refreshes it within the grace period. The server tells
+// the holder.
+func (c *Client) RequestTakeover(ctx context.Context, req *TakeoverRequest) (*TakeoverResponse, error) {
+	var resp TakeoverResponse
+	if err := c.post(ctx, "/v1/reservations/takeover", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetFocusRequest is the request body for POST /v1/workspaces/{id}/focus.
+// An empty ApexID clears the focus.
+type SetFocusRequest struct {
+	Alias  string `json:"alias"`
+	ApexID string `json:"apex_id"`
+}
+
+// SetFocusResponse is the response from POST /v1/workspaces/{id}/focus.
+type SetFocusResponse struct {
+	WorkspaceID    string `json:"workspace_id"`
+	FocusApexID    string `json:"focus_apex_id,omitempty"`
+	FocusApexTitle string `json:"focus_apex_title,omitempty"`
+	FocusApexType  string `json:"focus_apex_type,omitempty"`
+	FocusUpdatedAt string `json:"focus_updated_at,omitempty"`
+}
+
+// SetFocus sets the apex bead (usually an epic) the workspace is focused
+// on, for when it has no claims for the server to derive a focus from.
+func (c *Client) SetFocus(ctx context.Context, workspaceID string, req *SetFocusRequest) (*SetFocusResponse, error) {
+	var resp SetFocusResponse
+	path := fmt.Sprintf("/v1/workspaces/%s/focus", url.PathEscape(workspaceID))
+	if err := c.post(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SquadResponse is the response from GET /v1/workspaces/{id}/squad.
+type SquadResponse struct {
+	Name    string        `json:"name"`
+	Members []SquadMember `json:"members"`
+}
+
+// SquadMember is one workspace in a squad.
+type SquadMember struct {
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+}
+
+// Squad returns the squad the workspace belongs to. Squadmates share file
+// reservations with each other while staying exclusive against everyone
+// else. Returns a 404 *Error when the workspace is in no squad.
+func (c *Client) Squad(ctx context.Context, workspaceID string) (*SquadResponse, error) {
+	var resp SquadResponse
+	if err := c.get(ctx, fmt.Sprintf("/v1/workspaces/%s/squad", url.PathEscape(workspaceID)), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Approval statuses.
+const (
+	ApprovalPending = "pending"
+	ApprovalGranted = "granted"
+	ApprovalDenied  = "denied"
+)
+
+// Approval is a command parked for a human supervisor to grant or deny.
+type Approval struct {
+	ApprovalID  string `json:"approval_id"`
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	CommandLine string `json:"command_line"`
+	Reason      string `json:"reason"`
+	Status      string `json:"status"`
+	DecidedBy   string `json:"decided_by,omitempty"`
+	Note        string `json:"note,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	DecidedAt   string `json:"decided_at,omitempty"`
+}
+
+// RequestApprovalRequest is the request body for POST /v1/approvals.
+type RequestApprovalRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	CommandLine string `json:"command_line"`
+	Reason      string `json:"reason"`
+}
+
+// RequestApproval parks a command until a supervisor decides on it.
+func (c *Client) RequestApproval(ctx context.Context, req *RequestApprovalRequest) (*Approval, error) {
+	var resp Approval
+	if err := c.post(ctx, "/v1/approvals", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListApprovalsRequest is the request parameters for GET /v1/approvals.
+type ListApprovalsRequest struct {
+	// Status filters by approval status; empty lists every status.
+	Status string
+	Limit  int
+}
+
+// ListApprovalsResponse is the response from GET /v1/approvals.
+type ListApprovalsResponse struct {
+	Approvals []Approval `json:"approvals"`
+}
+
+// ListApprovals lists the project's approval requests, oldest first.
+func (c *Client) ListApprovals(ctx context.Context, req *ListApprovalsRequest) (*ListApprovalsResponse, error) {
+	var resp ListApprovalsResponse
+	if err := c.get(ctx, "/v1/approvals", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetApproval fetches an approval request's current status.
+func (c *Client) GetApproval(ctx context.Context, approvalID string) (*Approval, error) {
+	var resp Approval
+	if err := c.get(ctx, "/v1/approvals/"+url.PathEscape(approvalID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DecideApprovalRequest is the request body for
+// POST /v1/approvals/{id}/decision. The server authorizes the decision
+// against WorkspaceID's registered role and refuses a workspace deciding
+// its own request.
+type DecideApprovalRequest struct {
+	Status      string `json:"status"` // ApprovalGranted or ApprovalDenied
+	DecidedBy   string `json:"decided_by"`
+	WorkspaceID string `json:"workspace_id"`
+	Role        string `json:"role,omitempty"`
+	Note        string `json:"note,omitempty"`
+}
+
+// DecideApproval grants or denies a pending approval request. Returns a 403
+// *Error when the deciding workspace may not decide it, and a 409 *Error
+// when it was already decided.
+func (c *Client) DecideApproval(ctx context.Context, approvalID string, req *DecideApprovalRequest) (*Approval, error) {
+	var resp Approval
+	if err := c.post(ctx, "/v1/approvals/"+url.PathEscape(approvalID)+"/decision", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ReportReservationEventsRequest is the request body for
+// POST /v1/reservations/history.
+type ReportReservationEventsRequest struct {
+	Events []ReservationEvent `json:"events"`
+}
+
+// ReportReservationEvents adds events seen by this workspace to the
+// project's reservation history.
+func (c *Client) ReportReservationEvents(ctx context.Context, req *ReportReservationEventsRequest) error {
+	var resp map[string]any
+	return c.post(ctx, "/v1/reservations/history", req, &resp)
+}
+
+// ActivityRequest is the request parameters for GET /v1/activity.
+type ActivityRequest struct {
+	// Since is an RFC 3339 timestamp; only later events are returned.
+	Since string
+	Limit int
+}
+
+// Activity event kinds.
+const (
+	ActivityClose      = "close"
+	ActivityClaim      = "claim"
+	ActivityEscalation = "escalation"
+	ActivityJumpIn     = "jump_in"
+)
+
+// ActivityEvent is one coordination event in the project: a bead closed or
+// claimed, an escalation raised, or a claim joined with --:jump-in.
+type ActivityEvent struct {
+	Kind      string `json:"kind"`
+	Alias     string `json:"alias"`
+	HumanName string `json:"human_name,omitempty"`
+	BeadID    string `json:"bead_id,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	At        string `json:"at"`
+}
+
+// ActivityResponse is the response from GET /v1/activity.
+type ActivityResponse struct {
+	Events []ActivityEvent `json:"events"`
+}
+
+// Activity lists the project's coordination events, oldest first.
+func (c *Client) Activity(ctx context.Context, req *ActivityRequest) (*ActivityResponse, error) {
+	var resp ActivityResponse
+	if err := c.get(ctx, "/v1/activity", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// KeyAlgorithmAgeX25519 is the only message encryption key algorithm bdh
+// publishes and accepts: an age X25519 recipient.
+const KeyAlgorithmAgeX25519 = "age-x25519"
+
+// PublishKeyRequest is the request body for POST /v1/keys.
+type PublishKeyRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	Algorithm   string `json:"algorithm"`
+	// PublicKey is the age recipient string ("age1...").
+	PublicKey string `json:"public_key"`
+}
+
+// PublicKey is a workspace's published message encryption key.
+type PublicKey struct {
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	Algorithm   string `json:"algorithm"`
+	PublicKey   string `json:"public_key"`
+	KeyID       string `json:"key_id,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+// PublishKey registers (or replaces) the caller's public key.
+func (c *Client) PublishKey(ctx context.Context, req *PublishKeyRequest) (*PublicKey, error) {
+	var resp PublicKey
+	if err := c.post(ctx, "/v1/keys", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PublicKeysRequest is the request parameters for GET /v1/keys.
+type PublicKeysRequest struct {
+	Aliases []string
+}
+
+// PublicKeysResponse is the response from GET /v1/keys. Aliases without a
+// published key are absent.
+type PublicKeysResponse struct {
+	Keys []PublicKey `json:"keys"`
+}
+
+// PublicKeys looks up the published keys of the given aliases.
+func (c *Client) PublicKeys(ctx context.Context, req *PublicKeysRequest) (*PublicKeysResponse, error) {
+	var resp PublicKeysResponse
+	if err := c.get(ctx, "/v1/keys", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // Error represents an error response from the BeadHub server.
 type Error struct {
 	StatusCode int
@@ -937,6 +1823,26 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("BeadHub error (status %d): %s", e.StatusCode, e.Body)
 }
 
+// aliasConflictCodes are the error codes servers use in a 409 body when the
+// caller's alias is registered to a different workspace.
+var aliasConflictCodes = []string{"alias_conflict", "alias_taken", "alias_exists"}
+
+// IsAliasConflict reports whether err is a 409 saying the alias now belongs
+// to another workspace (for example after an admin cleanup). Other 409s,
+// such as sync protocol mismatches, return false.
+func IsAliasConflict(err error) bool {
+	var clientErr *Error
+	if !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusConflict {
+		return false
+	}
+	for _, code := range aliasConflictCodes {
+		if strings.Contains(clientErr.Body, code) {
+			return true
+		}
+	}
+	return false
+}
+
 // post sends a POST request and decodes the JSON response.
 func (c *Client) post(ctx context.Context, path string, reqBody, respBody any) error {
 	return c.postWithHeaders(ctx, path, reqBody, respBody, nil)
@@ -968,6 +1874,7 @@ func (c *Client) postWithHeaders(ctx context.Context, path string, reqBody, resp
 		return fmt.Errorf("sending request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.noteServerVersion(resp)
 
 	// Read maxResponseSize+1 to detect oversized responses while still accepting
 	// responses exactly at the limit. If we read more than maxResponseSize, reject.
@@ -986,8 +1893,8 @@ func (c *Client) postWithHeaders(ctx context.Context, path string, reqBody, resp
 		}
 	}
 
-	if err := json.Unmarshal(respBodyBytes, respBody); err != nil {
-		return fmt.Errorf("decoding response: %w", err)
+	if err := decodeResponse(path, respBodyBytes, respBody); err != nil {
+		return err
 	}
 
 	return nil
@@ -1011,6 +1918,7 @@ func (c *Client) delete(ctx context.Context, path string, respBody any) error {
 		return fmt.Errorf("sending request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.noteServerVersion(resp)
 
 	respBodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
 	if err != nil {
@@ -1027,8 +1935,8 @@ func (c *Client) delete(ctx context.Context, path string, respBody any) error {
 		}
 	}
 
-	if err := json.Unmarshal(respBodyBytes, respBody); err != nil {
-		return fmt.Errorf("decoding response: %w", err)
+	if err := decodeResponse(path, respBodyBytes, respBody); err != nil {
+		return err
 	}
 
 	return nil
@@ -1065,6 +1973,11 @@ func (c *Client) getWithHeaders(ctx context.Context, path string, params any, re
 	if params != nil {
 		q := req.URL.Query()
 		switch p := params.(type) {
+		case *SyncManifestRequest:
+			q.Set("workspace_id", p.WorkspaceID)
+			if p.RepoID != "" {
+				q.Set("repo_id", p.RepoID)
+			}
 		case *InboxRequest:
 			q.Set("workspace_id", p.WorkspaceID)
 			if p.Limit > 0 {
@@ -1077,6 +1990,23 @@ func (c *Client) getWithHeaders(ctx context.Context, path string, params any, re
 			if p.FromAlias != "" {
 				q.Set("from_alias", p.FromAlias)
 			}
+			if p.IncludeArchived {
+				q.Set("include_archived", "true")
+			}
+			if p.Before != "" {
+				q.Set("before", p.Before)
+			}
+			if p.Query != "" {
+				q.Set("q", p.Query)
+			}
+		case *SentMessagesRequest:
+			q.Set("workspace_id", p.WorkspaceID)
+			if p.ToAlias != "" {
+				q.Set("to_alias", p.ToAlias)
+			}
+			if p.Limit > 0 {
+				q.Set("limit", fmt.Sprintf("%d", p.Limit))
+			}
 		case *WorkspacesRequest:
 			if p.HumanName != "" {
 				q.Set("human_name", p.HumanName)
@@ -1124,6 +2054,12 @@ func (c *Client) getWithHeaders(ctx context.Context, path string, params any, re
 			if p.Limit > 0 {
 				q.Set("limit", fmt.Sprintf("%d", p.Limit))
 			}
+			if p.FocusApexID != "" {
+				q.Set("focus_apex_id", p.FocusApexID)
+			}
+			if p.Area != "" {
+				q.Set("area", p.Area)
+			}
 		case *StatusRequest:
 			if p.WorkspaceID != "" {
 				q.Set("workspace_id", p.WorkspaceID)
@@ -1141,6 +2077,47 @@ func (c *Client) getWithHeaders(ctx context.Context, path string, params any, re
 			if p.PathPrefix != "" {
 				q.Set("path_prefix", p.PathPrefix)
 			}
+		case *ListApprovalsRequest:
+			if p.Status != "" {
+				q.Set("status", p.Status)
+			}
+			if p.Limit > 0 {
+				q.Set("limit", fmt.Sprintf("%d", p.Limit))
+			}
+		case *ReservationHistoryRequest:
+			if p.Since != "" {
+				q.Set("since", p.Since)
+			}
+			if p.Limit > 0 {
+				q.Set("limit", fmt.Sprintf("%d", p.Limit))
+			}
+		case *ActivityRequest:
+			if p.Since != "" {
+				q.Set("since", p.Since)
+			}
+			if p.Limit > 0 {
+				q.Set("limit", fmt.Sprintf("%d", p.Limit))
+			}
+		case *PublicKeysRequest:
+			if len(p.Aliases) > 0 {
+				q.Set("aliases", strings.Join(p.Aliases, ","))
+			}
+		case *ListBeadNotesRequest:
+			if p.Limit > 0 {
+				q.Set("limit", fmt.Sprintf("%d", p.Limit))
+			}
+		case *ListBeadAttachmentsRequest:
+			if p.Limit > 0 {
+				q.Set("limit", fmt.Sprintf("%d", p.Limit))
+			}
+		case *SearchBeadsRequest:
+			q.Set("q", p.Query)
+			if p.Repo != "" {
+				q.Set("repo", p.Repo)
+			}
+			if p.Limit > 0 {
+				q.Set("limit", fmt.Sprintf("%d", p.Limit))
+			}
 		case *ActivePolicyRequest:
 			if p.Role != "" {
 				q.Set("role", p.Role)
@@ -1157,6 +2134,7 @@ func (c *Client) getWithHeaders(ctx context.Context, path string, params any, re
 		return fmt.Errorf("sending request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.noteServerVersion(resp)
 
 	// Read maxResponseSize+1 to detect oversized responses while still accepting
 	// responses exactly at the limit. If we read more than maxResponseSize, reject.
@@ -1175,9 +2153,61 @@ func (c *Client) getWithHeaders(ctx context.Context, path string, params any, re
 		}
 	}
 
-	if err := json.Unmarshal(respBodyBytes, respBody); err != nil {
-		return fmt.Errorf("decoding response: %w", err)
+	if err := decodeResponse(path, respBodyBytes, respBody); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+// RawResponse is the undecoded response to a Raw request.
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Raw sends method to path (which may carry a query string) with the
+// client's auth and returns the body as-is, for endpoints bdh has no typed
+// method for. body, if non-nil, is sent as JSON. Non-2xx responses return
+// an *Error.
+func (c *Client) Raw(ctx context.Context, method, path string, body []byte) (*RawResponse, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	c.noteServerVersion(resp)
+
+	respBodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if int64(len(respBodyBytes)) > maxResponseSize {
+		return nil, fmt.Errorf("response exceeds maximum size of %d bytes", maxResponseSize)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBodyBytes),
+		}
+	}
+	return &RawResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBodyBytes}, nil
+}
@@ -3,6 +3,9 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -453,6 +456,55 @@ func TestAck(t *testing.T) {
 	}
 }
 
+func TestAck_Archive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req["archive"] != true {
+			t.Errorf("Expected archive true, got %v", req["archive"])
+		}
+		json.NewEncoder(w).Encode(AckResponse{
+			MessageID:      "msg_abc123",
+			AcknowledgedAt: "2025-12-08T14:03:00Z",
+			ArchivedAt:     "2025-12-08T14:03:00Z",
+		})
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	resp, err := c.Ack(context.Background(), "msg_abc123", &AckRequest{WorkspaceID: "ws-123", Archive: true})
+	if err != nil {
+		t.Fatalf("Ack() error: %v", err)
+	}
+	if resp.ArchivedAt == "" {
+		t.Error("Expected archived_at to be non-empty")
+	}
+}
+
+func TestInbox_IncludeArchived(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("include_archived"); got != "true" {
+			t.Errorf("Expected include_archived true, got %q", got)
+		}
+		json.NewEncoder(w).Encode(InboxResponse{
+			Messages: []Message{{MessageID: "msg_1", Archived: true}},
+			Count:    1,
+		})
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	resp, err := c.Inbox(context.Background(), &InboxRequest{WorkspaceID: "ws-123", IncludeArchived: true})
+	if err != nil {
+		t.Fatalf("Inbox() error: %v", err)
+	}
+	if len(resp.Messages) != 1 || !resp.Messages[0].Archived {
+		t.Errorf("Expected one archived message, got %+v", resp.Messages)
+	}
+}
+
 func TestAck_NotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -638,3 +690,244 @@ func TestNewWithAPIKey_GETSendsAuthorizationHeader(t *testing.T) {
 		t.Fatalf("ActivePolicy() error: %v", err)
 	}
 }
+
+func TestIsAliasConflict(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&Error{StatusCode: 409, Body: `{"detail":{"code":"alias_conflict"}}`}, true},
+		{fmt.Errorf("wrapped: %w", &Error{StatusCode: 409, Body: `{"error":"alias_taken"}`}), true},
+		{&Error{StatusCode: 409, Body: `{"detail":"sync protocol mismatch"}`}, false},
+		{&Error{StatusCode: 400, Body: `alias_conflict`}, false},
+		{errors.New("alias_conflict"), false},
+	}
+	for _, tt := range tests {
+		if got := IsAliasConflict(tt.err); got != tt.want {
+			t.Errorf("IsAliasConflict(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestPublicKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/keys" {
+			t.Errorf("Expected /v1/keys, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("aliases"); got != "alice,bob" {
+			t.Errorf("Expected aliases alice,bob, got %q", got)
+		}
+		json.NewEncoder(w).Encode(PublicKeysResponse{
+			Keys: []PublicKey{{Alias: "alice", Algorithm: KeyAlgorithmAgeX25519, PublicKey: "age1pub"}},
+		})
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	resp, err := c.PublicKeys(context.Background(), &PublicKeysRequest{Aliases: []string{"alice", "bob"}})
+	if err != nil {
+		t.Fatalf("PublicKeys() error: %v", err)
+	}
+	if len(resp.Keys) != 1 || resp.Keys[0].Alias != "alice" {
+		t.Errorf("Expected alice's key only, got %+v", resp.Keys)
+	}
+}
+
+func TestActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/activity" {
+			t.Errorf("Expected /v1/activity, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("since"); got != "2025-06-08T00:00:00Z" {
+			t.Errorf("Expected since 2025-06-08T00:00:00Z, got %q", got)
+		}
+		json.NewEncoder(w).Encode(ActivityResponse{
+			Events: []ActivityEvent{{Kind: ActivityClose, Alias: "alice", BeadID: "bd-1", At: "2025-06-10T12:00:00Z"}},
+		})
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	resp, err := c.Activity(context.Background(), &ActivityRequest{Since: "2025-06-08T00:00:00Z"})
+	if err != nil {
+		t.Fatalf("Activity() error: %v", err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].Kind != ActivityClose || resp.Events[0].BeadID != "bd-1" {
+		t.Errorf("Unexpected events: %+v", resp.Events)
+	}
+}
+
+func TestRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer aw_sk_test" {
+			t.Errorf("missing auth header")
+		}
+		if r.URL.Path == "/v1/missing" {
+			http.Error(w, `{"detail":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method != http.MethodPatch || r.URL.Path != "/v1/workspaces/ws-1" || r.URL.Query().Get("limit") != "5" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"role":"qa"}` || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("body = %q", body)
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	c := NewWithAPIKey(server.URL, "aw_sk_test")
+	resp, err := c.Raw(context.Background(), http.MethodPatch, "/v1/workspaces/ws-1?limit=5", []byte(`{"role":"qa"}`))
+	if err != nil || resp.StatusCode != 200 || string(resp.Body) != `{"ok":true}` {
+		t.Fatalf("Raw() = %+v, %v", resp, err)
+	}
+	_, err = c.Raw(context.Background(), http.MethodGet, "/v1/missing", nil)
+	var clientErr *Error
+	if !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 error, got %v", err)
+	}
+}
+
+func TestRequestTakeover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/reservations/takeover" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var req TakeoverRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ResourceKey != "src/api/" || req.Alias != "me" || req.GraceSeconds != 900 {
+			t.Errorf("unexpected request %+v", req)
+		}
+		json.NewEncoder(w).Encode(TakeoverResponse{ResourceKey: req.ResourceKey, HolderAlias: "alice", Status: TakeoverQueued, GrantAt: "2025-06-15T10:15:00Z", HolderNotified: true})
+	}))
+	defer server.Close()
+
+	resp, err := New(server.URL).RequestTakeover(context.Background(), &TakeoverRequest{ResourceKey: "src/api/", Alias: "me", GraceSeconds: 900})
+	if err != nil {
+		t.Fatalf("RequestTakeover() error: %v", err)
+	}
+	if resp.Status != TakeoverQueued || resp.HolderAlias != "alice" || !resp.HolderNotified {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestSetFocus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/workspaces/ws-1/focus" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var req SetFocusRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ApexID != "bd-e1" || req.Alias != "me" {
+			t.Errorf("unexpected request %+v", req)
+		}
+		json.NewEncoder(w).Encode(SetFocusResponse{WorkspaceID: "ws-1", FocusApexID: "bd-e1", FocusApexTitle: "Auth"})
+	}))
+	defer server.Close()
+
+	resp, err := New(server.URL).SetFocus(context.Background(), "ws-1", &SetFocusRequest{Alias: "me", ApexID: "bd-e1"})
+	if err != nil {
+		t.Fatalf("SetFocus() error: %v", err)
+	}
+	if resp.FocusApexID != "bd-e1" || resp.FocusApexTitle != "Auth" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestTeamWorkspaces_Shard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/workspaces/team" || q.Get("focus_apex_id") != "bd-e1" || q.Get("area") != "src/api" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"workspaces":[{"workspace_id":"ws-2","alias":"bob"}],"count":1,
+			"others":{"count":61,"epics":9,"by_role":{"backend":40,"":21}}}`))
+	}))
+	defer server.Close()
+
+	resp, err := New(server.URL).TeamWorkspaces(context.Background(), &TeamWorkspacesRequest{FocusApexID: "bd-e1", Area: "src/api"})
+	if err != nil {
+		t.Fatalf("TeamWorkspaces() error: %v", err)
+	}
+	if len(resp.Workspaces) != 1 || resp.Others == nil || resp.Others.Count != 61 || resp.Others.ByRole[""] != 21 {
+		t.Errorf("unexpected response %+v %+v", resp, resp.Others)
+	}
+}
+
+func TestApprovals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/approvals":
+			var req RequestApprovalRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CommandLine != "delete bd-1" {
+				t.Errorf("unexpected request %+v (%v)", req, err)
+			}
+			w.Write([]byte(`{"approval_id":"ap-1","command_line":"delete bd-1","status":"pending"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/approvals":
+			if r.URL.Query().Get("status") != "pending" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"approvals":[{"approval_id":"ap-1","status":"pending"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/approvals/ap-1/decision":
+			w.Write([]byte(`{"approval_id":"ap-1","status":"granted","decided_by":"lead"}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	created, err := c.RequestApproval(context.Background(), &RequestApprovalRequest{WorkspaceID: "ws-1", Alias: "claude-be", CommandLine: "delete bd-1", Reason: "high-risk"})
+	if err != nil || created.ApprovalID != "ap-1" || created.Status != ApprovalPending {
+		t.Fatalf("RequestApproval() = %+v, %v", created, err)
+	}
+	list, err := c.ListApprovals(context.Background(), &ListApprovalsRequest{Status: ApprovalPending})
+	if err != nil || len(list.Approvals) != 1 {
+		t.Fatalf("ListApprovals() = %+v, %v", list, err)
+	}
+	decided, err := c.DecideApproval(context.Background(), "ap-1", &DecideApprovalRequest{Status: ApprovalGranted, DecidedBy: "lead"})
+	if err != nil || decided.Status != ApprovalGranted || decided.DecidedBy != "lead" {
+		t.Errorf("DecideApproval() = %+v, %v", decided, err)
+	}
+}
+
+func TestSquad(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/workspaces/ws-1/squad" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"name":"web","members":[{"workspace_id":"ws-1","alias":"claude-be"},{"workspace_id":"ws-2","alias":"claude-fe"}]}`))
+	}))
+	defer server.Close()
+
+	resp, err := New(server.URL).Squad(context.Background(), "ws-1")
+	if err != nil {
+		t.Fatalf("Squad() error: %v", err)
+	}
+	if resp.Name != "web" || len(resp.Members) != 2 || resp.Members[1].Alias != "claude-fe" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestInbox_BeforeAndQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("before"); got != "msg_9" {
+			t.Errorf("Expected before msg_9, got %q", got)
+		}
+		if got := r.URL.Query().Get("q"); got != "rebase main" {
+			t.Errorf("Expected q %q, got %q", "rebase main", got)
+		}
+		json.NewEncoder(w).Encode(InboxResponse{Messages: []Message{{MessageID: "msg_8"}}, Count: 1})
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	resp, err := c.Inbox(context.Background(), &InboxRequest{WorkspaceID: "ws-123", Before: "msg_9", Query: "rebase main"})
+	if err != nil {
+		t.Fatalf("Inbox() error: %v", err)
+	}
+	if len(resp.Messages) != 1 {
+		t.Errorf("Expected one message, got %+v", resp.Messages)
+	}
+}
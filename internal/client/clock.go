@@ -0,0 +1,60 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Every response through the shared transport carries the server's Date
+// header. Comparing it with the local clock tells how far this machine's
+// clock is off, which matters because reservation expirations, claim ages
+// and chat deadlines are server timestamps rendered against local time.
+
+var (
+	clockSkewMu    sync.Mutex
+	clockSkew      time.Duration
+	clockSkewRTT   time.Duration
+	clockSkewKnown bool
+)
+
+// ClockSkew returns how far the server's clock is ahead of the local one
+// (negative when the local clock is ahead), and whether any response has
+// been seen to measure it.
+func ClockSkew() (time.Duration, bool) {
+	clockSkewMu.Lock()
+	defer clockSkewMu.Unlock()
+	return clockSkew, clockSkewKnown
+}
+
+// ResetClockSkew forgets the measured skew.
+func ResetClockSkew() {
+	clockSkewMu.Lock()
+	defer clockSkewMu.Unlock()
+	clockSkew, clockSkewRTT, clockSkewKnown = 0, 0, false
+}
+
+// recordClockSkew measures the skew from a response's Date header, taken as
+// the server time halfway between sending the request and receiving the
+// response. The header has one-second resolution, so its midpoint is used.
+// The measurement from the fastest round trip is kept, being the most
+// precise.
+func recordClockSkew(resp *http.Response, sent, received time.Time) {
+	if resp == nil {
+		return
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	rtt := received.Sub(sent)
+	local := sent.Add(rtt / 2)
+	skew := date.Add(500 * time.Millisecond).Sub(local)
+
+	clockSkewMu.Lock()
+	defer clockSkewMu.Unlock()
+	if clockSkewKnown && rtt > clockSkewRTT {
+		return
+	}
+	clockSkew, clockSkewRTT, clockSkewKnown = skew, rtt, true
+}
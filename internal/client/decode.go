@@ -0,0 +1,244 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Responses are decoded defensively so that a server whose API has drifted
+// produces a clear error instead of zero-value structs that surface later as
+// confusing empty output:
+//
+//   - a body that is not the expected JSON is reported as a PayloadError
+//     naming the endpoint;
+//   - response types may list required keys (requiredFields), which must be
+//     present in the payload;
+//   - with strict decoding on, unknown keys are rejected as well, which is
+//     useful when testing a client against a new server version.
+
+var strictDecoding atomic.Bool
+
+// SetStrictDecoding rejects response payloads that contain keys the client
+// does not know (json.Decoder.DisallowUnknownFields).
+func SetStrictDecoding(strict bool) {
+	strictDecoding.Store(strict)
+}
+
+// PayloadError reports a response body that does not match what the client
+// expects from the endpoint.
+type PayloadError struct {
+	Path string
+	Err  error
+}
+
+func (e *PayloadError) Error() string {
+	return fmt.Sprintf("server sent unexpected payload for %s: %v", e.Path, e.Err)
+}
+
+func (e *PayloadError) Unwrap() error {
+	return e.Err
+}
+
+// IsPayloadError reports whether err is (or wraps) a PayloadError.
+func IsPayloadError(err error) bool {
+	var payloadErr *PayloadError
+	return errors.As(err, &payloadErr)
+}
+
+// requiredFielder is implemented by response types whose payload must carry
+// certain top-level keys; without them the zero values would be misleading.
+type requiredFielder interface {
+	requiredFields() []string
+}
+
+func (CommandResponse) requiredFields() []string           { return []string{"approved"} }
+func (SyncResponse) requiredFields() []string              { return []string{"synced"} }
+func (EnsureProjectResponse) requiredFields() []string     { return []string{"project_id"} }
+func (InitResponse) requiredFields() []string              { return []string{"api_key", "alias"} }
+func (RegisterWorkspaceResponse) requiredFields() []string { return []string{"workspace_id", "alias"} }
+func (InboxResponse) requiredFields() []string             { return []string{"messages"} }
+func (WorkspacesResponse) requiredFields() []string        { return []string{"workspaces"} }
+
+// decodeResponse decodes a successful response body from path into v.
+func decodeResponse(path string, data []byte, v any) error {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if strictDecoding.Load() {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return &PayloadError{Path: path, Err: describeDecodeError(err)}
+	}
+	if dec.More() {
+		return &PayloadError{Path: path, Err: errors.New("trailing data after the JSON value")}
+	}
+
+	rf, ok := v.(requiredFielder)
+	if !ok {
+		return nil
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return &PayloadError{Path: path, Err: errors.New("expected a JSON object")}
+	}
+	var missing []string
+	for _, field := range rf.requiredFields() {
+		if _, ok := keys[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return &PayloadError{Path: path, Err: fmt.Errorf("missing required field(s) %s", strings.Join(missing, ", "))}
+	}
+	return nil
+}
+
+// describeDecodeError rewords encoding/json errors in terms of the payload.
+func describeDecodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "(top level)"
+		}
+		return fmt.Errorf("field %s is a JSON %s, expected %s", field, typeErr.Value, typeErr.Type)
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("invalid JSON at byte %d: %v", syntaxErr.Offset, err)
+	case errors.Is(err, io.EOF):
+		return errors.New("empty body")
+	}
+	return err
+}
+
+// maxDebugBody bounds how much of each request and response body
+// --:debug-http prints; maxDebugMessageBody how much of a message body.
+const (
+	maxDebugBody        = 4096
+	maxDebugMessageBody = 80
+)
+
+// Debug dumps end up in terminals, logs and bug reports: string fields named
+// like credentials are masked, and message bodies cut short.
+var (
+	debugSecretField  = regexp.MustCompile(`("[A-Za-z0-9_]*(?:api_key|token|secret|password|private_key)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	debugMessageField = regexp.MustCompile(`("body"\s*:\s*)"((?:[^"\\]|\\.)*)"`)
+)
+
+var (
+	debugHTTPMu sync.Mutex
+	debugHTTPW  io.Writer
+)
+
+// SetDebugHTTP dumps every request and response made through the shared
+// transport to w (nil turns dumping off). Authorization headers and secret
+// body fields are redacted.
+func SetDebugHTTP(w io.Writer) {
+	debugHTTPMu.Lock()
+	defer debugHTTPMu.Unlock()
+	debugHTTPW = w
+}
+
+func debugHTTPWriter() io.Writer {
+	debugHTTPMu.Lock()
+	defer debugHTTPMu.Unlock()
+	return debugHTTPW
+}
+
+// debugRoundTrip performs the request through next, dumping both sides to w.
+func debugRoundTrip(w io.Writer, req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("> %s %s\n", req.Method, req.URL.Redacted()))
+	for _, name := range sortedHeaderNames(req.Header) {
+		value := strings.Join(req.Header[name], ", ")
+		if strings.EqualFold(name, "Authorization") {
+			value = "[redacted]"
+		}
+		sb.WriteString(fmt.Sprintf("> %s: %s\n", name, value))
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxResponseSize+1))
+			_ = body.Close()
+			writeDebugBody(&sb, ">", data)
+		}
+	}
+
+	start := time.Now()
+	resp, err := next(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("< error after %s: %v\n", elapsed, err))
+		debugHTTPMu.Lock()
+		_, _ = io.WriteString(w, sb.String())
+		debugHTTPMu.Unlock()
+		return resp, err
+	}
+
+	sb.WriteString(fmt.Sprintf("< %s (%s)\n", resp.Status, elapsed))
+	data, readErr := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if readErr != nil {
+		sb.WriteString(fmt.Sprintf("< (reading body: %v)\n", readErr))
+	}
+	writeDebugBody(&sb, "<", data)
+
+	debugHTTPMu.Lock()
+	_, _ = io.WriteString(w, sb.String()+"\n")
+	debugHTTPMu.Unlock()
+	return resp, nil
+}
+
+// redactDebugBody masks secret fields and shortens message bodies. It works
+// on the text, so a body that is not (or no longer) valid JSON is covered.
+func redactDebugBody(data []byte) []byte {
+	data = debugSecretField.ReplaceAll(data, []byte(`$1"[redacted]"`))
+	return debugMessageField.ReplaceAllFunc(data, func(m []byte) []byte {
+		parts := debugMessageField.FindSubmatch(m)
+		body := []rune(string(parts[2]))
+		if len(body) <= maxDebugMessageBody {
+			return m
+		}
+		return []byte(fmt.Sprintf(`%s"%s... (%d chars)"`, parts[1], strings.TrimRight(string(body[:maxDebugMessageBody]), `\`), len(body)))
+	})
+}
+
+func writeDebugBody(sb *strings.Builder, prefix string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	data = redactDebugBody(data)
+	truncated := len(data) > maxDebugBody
+	if truncated {
+		data = data[:maxDebugBody]
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		sb.WriteString(prefix + " " + line + "\n")
+	}
+	if truncated {
+		sb.WriteString(prefix + " ... (truncated)\n")
+	}
+}
+
+func sortedHeaderNames(h http.Header) []string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeResponse_MissingRequiredField(t *testing.T) {
+	var resp CommandResponse
+	err := decodeResponse("/v1/bdh/command", []byte(`{"reason":"ok"}`), &resp)
+	if !IsPayloadError(err) || !strings.Contains(err.Error(), "server sent unexpected payload for /v1/bdh/command: missing required field(s) approved") {
+		t.Errorf("got %v", err)
+	}
+	if err := decodeResponse("/v1/bdh/command", []byte(`{"approved":false}`), &resp); err != nil {
+		t.Errorf("approved=false is a valid payload: %v", err)
+	}
+}
+
+func TestDecodeResponse_WrongType(t *testing.T) {
+	var resp SyncResponse
+	err := decodeResponse("/v1/bdh/sync?x=1", []byte(`{"synced":true,"issues_count":"many"}`), &resp)
+	if !IsPayloadError(err) || !strings.Contains(err.Error(), "/v1/bdh/sync: field issues_count is a JSON string, expected int") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestDecodeResponse_Strict(t *testing.T) {
+	body := []byte(`{"approved":true,"approval_v2":{}}`)
+	var resp CommandResponse
+	if err := decodeResponse("/v1/bdh/command", body, &resp); err != nil {
+		t.Fatalf("lenient decoding: %v", err)
+	}
+
+	SetStrictDecoding(true)
+	defer SetStrictDecoding(false)
+	err := decodeResponse("/v1/bdh/command", body, &resp)
+	if !IsPayloadError(err) || !strings.Contains(err.Error(), "approval_v2") {
+		t.Errorf("strict decoding: got %v", err)
+	}
+}
+
+func TestDebugHTTP_RedactsAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"approved":true}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	SetDebugHTTP(&buf)
+	defer SetDebugHTTP(nil)
+
+	c := NewWithAPIKey(server.URL, "aw_sk_secret")
+	if _, err := c.Command(context.Background(), &CommandRequest{WorkspaceID: "ws-1", CommandLine: "ready"}); err != nil {
+		t.Fatalf("Command: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"> POST " + server.URL + "/v1/bdh/command", "> Authorization: [redacted]", `"command_line":"ready"`, "< 200 OK", `< {"approved":true}`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "aw_sk_secret") {
+		t.Errorf("API key leaked:\n%s", out)
+	}
+}
+
+func TestDebugHTTP_RedactsSecretFields(t *testing.T) {
+	long := strings.Repeat("x", maxDebugMessageBody+20)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"api_key": "aw_sk_minted", "workspace_id": "ws-1", "nested": {"access_token": "tok-\"1"}, "has_token": true}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	SetDebugHTTP(&buf)
+	defer SetDebugHTTP(nil)
+
+	c := NewWithAPIKey(server.URL, "")
+	var out map[string]any
+	if err := c.post(context.Background(), "/v1/init", map[string]string{"password": "hunter2", "body": long}, &out); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if out["api_key"] != "aw_sk_minted" {
+		t.Errorf("the caller must still see the real response, got %v", out)
+	}
+	dump := buf.String()
+	for _, leaked := range []string{"aw_sk_minted", "tok-", "hunter2", long} {
+		if strings.Contains(dump, leaked) {
+			t.Errorf("%q leaked:\n%s", leaked, dump)
+		}
+	}
+	for _, want := range []string{`"api_key": "[redacted]"`, `"access_token": "[redacted]"`, `"has_token": true`, `"workspace_id": "ws-1"`, fmt.Sprintf("... (%d chars)", len(long))} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("missing %q in:\n%s", want, dump)
+		}
+	}
+}
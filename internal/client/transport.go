@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Transport tuning for the shared connection pool. A single bdh invocation
+// typically makes several requests to the same host (pre-flight, sync,
+// notifications), so keeping connections alive avoids repeated TLS handshakes.
+const (
+	maxIdleConns        = 32
+	maxIdleConnsPerHost = 8
+	idleConnTimeout     = 90 * time.Second
+	tlsHandshakeTimeout = 5 * time.Second
+)
+
+var (
+	sharedTransportOnce sync.Once
+	sharedTransport     *instrumentedTransport
+
+	connStats struct {
+		requests       atomic.Int64
+		newConns       atomic.Int64
+		reusedConns    atomic.Int64
+		tlsHandshakes  atomic.Int64
+		tlsHandshakeNs atomic.Int64
+	}
+)
+
+// ConnStats is a snapshot of connection usage by the shared transport.
+type ConnStats struct {
+	Requests      int64         `json:"requests"`
+	NewConns      int64         `json:"new_conns"`
+	ReusedConns   int64         `json:"reused_conns"`
+	TLSHandshakes int64         `json:"tls_handshakes"`
+	TLSHandshake  time.Duration `json:"tls_handshake_ns"`
+}
+
+// Stats returns connection usage since process start.
+func Stats() ConnStats {
+	return ConnStats{
+		Requests:      connStats.requests.Load(),
+		NewConns:      connStats.newConns.Load(),
+		ReusedConns:   connStats.reusedConns.Load(),
+		TLSHandshakes: connStats.tlsHandshakes.Load(),
+		TLSHandshake:  time.Duration(connStats.tlsHandshakeNs.Load()),
+	}
+}
+
+// SharedTransport returns the process-wide tuned transport (keep-alives,
+// HTTP/2, bounded idle pool) used by all BeadHub clients.
+func SharedTransport() http.RoundTripper {
+	sharedTransportOnce.Do(func() {
+		base := http.DefaultTransport.(*http.Transport).Clone()
+		base.ForceAttemptHTTP2 = true
+		base.DisableKeepAlives = false
+		base.MaxIdleConns = maxIdleConns
+		base.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		base.IdleConnTimeout = idleConnTimeout
+		base.TLSHandshakeTimeout = tlsHandshakeTimeout
+		sharedTransport = &instrumentedTransport{base: base}
+	})
+	return sharedTransport
+}
+
+// InstallAsDefault makes http.DefaultTransport the shared transport, so clients
+// constructed by other packages (e.g. the aweb client) share the same pool.
+func InstallAsDefault() {
+	http.DefaultTransport = SharedTransport()
+}
+
+// Prewarm opens a connection to baseURL so the first real request can reuse it.
+// Best-effort: errors are ignored.
+func Prewarm(ctx context.Context, baseURL string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, baseURL, nil)
+	if err != nil {
+		return
+	}
+	resp, err := (&http.Client{Transport: SharedTransport(), Timeout: DefaultTimeout}).Do(req)
+	if err != nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+}
+
+// instrumentedTransport records connection reuse and TLS handshake cost.
+type instrumentedTransport struct {
+	base *http.Transport
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	connStats.requests.Add(1)
+
+	var tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				connStats.reusedConns.Add(1)
+			} else {
+				connStats.newConns.Add(1)
+			}
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err == nil && !tlsStart.IsZero() {
+				connStats.tlsHandshakes.Add(1)
+				connStats.tlsHandshakeNs.Add(int64(time.Since(tlsStart)))
+			}
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	sent := time.Now()
+	var resp *http.Response
+	var err error
+	if w := debugHTTPWriter(); w != nil {
+		resp, err = debugRoundTrip(w, req, t.base.RoundTrip)
+	} else {
+		resp, err = t.base.RoundTrip(req)
+	}
+	if err == nil {
+		recordClockSkew(resp, sent, time.Now())
+	}
+	return resp, err
+}
+
+// CloseIdleConnections closes idle pooled connections.
+func (t *instrumentedTransport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSharedTransport_ReusesConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"approved": true})
+	}))
+	defer server.Close()
+
+	before := Stats()
+
+	c1 := New(server.URL)
+	c2 := NewWithAPIKey(server.URL, "aw_sk_test")
+	for _, c := range []*Client{c1, c2, c1} {
+		if _, err := c.Command(context.Background(), &CommandRequest{CommandLine: "ready"}); err != nil {
+			t.Fatalf("Command() error: %v", err)
+		}
+	}
+
+	after := Stats()
+	if got := after.Requests - before.Requests; got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+	if got := after.NewConns - before.NewConns; got != 1 {
+		t.Errorf("new connections = %d, want 1 (clients should share a pool)", got)
+	}
+	if got := after.ReusedConns - before.ReusedConns; got != 2 {
+		t.Errorf("reused connections = %d, want 2", got)
+	}
+}
+
+func TestSharedTransport_Tuning(t *testing.T) {
+	tr, ok := SharedTransport().(*instrumentedTransport)
+	if !ok {
+		t.Fatalf("unexpected transport type %T", SharedTransport())
+	}
+	if !tr.base.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be attempted")
+	}
+	if tr.base.DisableKeepAlives {
+		t.Error("expected keep-alives enabled")
+	}
+	if tr.base.MaxIdleConnsPerHost != maxIdleConnsPerHost {
+		t.Errorf("MaxIdleConnsPerHost = %d, want %d", tr.base.MaxIdleConnsPerHost, maxIdleConnsPerHost)
+	}
+}
+
+func TestPrewarm_OpensReusableConnection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"approved": true})
+	}))
+	defer server.Close()
+
+	before := Stats()
+	Prewarm(context.Background(), server.URL)
+	if _, err := New(server.URL).Command(context.Background(), &CommandRequest{CommandLine: "ready"}); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	after := Stats()
+	if got := after.NewConns - before.NewConns; got != 1 {
+		t.Errorf("new connections = %d, want 1", got)
+	}
+}
+
+func TestSharedTransport_MeasuresClockSkew(t *testing.T) {
+	ResetClockSkew()
+	t.Cleanup(ResetClockSkew)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(10*time.Minute).UTC().Format(http.TimeFormat))
+		json.NewEncoder(w).Encode(map[string]any{"approved": true})
+	}))
+	defer server.Close()
+
+	if _, ok := ClockSkew(); ok {
+		t.Fatal("skew known before any request")
+	}
+	if _, err := New(server.URL).Command(context.Background(), &CommandRequest{CommandLine: "ready"}); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	skew, ok := ClockSkew()
+	if !ok || skew < 10*time.Minute-2*time.Second || skew > 10*time.Minute+2*time.Second {
+		t.Errorf("ClockSkew() = %v, %v; want about 10m", skew, ok)
+	}
+}
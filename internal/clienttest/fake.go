@@ -0,0 +1,915 @@
+// Package clienttest provides an in-memory stand-in for the BeadHub server.
+//
+// Fake implements client.API. It keeps enough state to play out the usual
+// coordination scenarios without an httptest server: workspaces and their
+// claims, messages between workspaces, file reservations, the activity feed,
+// approval requests and capability discovery. Every call is recorded, and any method can be
+// made to fail with Fail. Endpoints without state answer with empty
+// responses; embed the Fake and define the method to answer differently.
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// DefaultLockTTL is the reservation TTL when a Lock request sets none.
+const DefaultLockTTL = time.Hour
+
+// Call is one recorded call: the method name and its arguments, without the
+// context.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Fake is an in-memory client.API. The zero value is not usable; call New.
+type Fake struct {
+	// Now is the fake's clock, used for timestamps and reservation expiry.
+	Now func() time.Time
+
+	mu            sync.Mutex
+	serverVersion string
+	features      map[string]bool
+	denyReason    string
+	workspaces    []client.Workspace
+	messages      []fakeMessage
+	locks         []client.LockInfo
+	events        []client.ActivityEvent
+	resEvents     []client.ReservationEvent
+	squads        map[string][]string // name -> member aliases
+	approvals     []client.Approval
+	errs          map[string]error
+	calls         []Call
+	nextID        int
+}
+
+type fakeMessage struct {
+	to   string // workspace ID
+	msg  client.Message
+	sent client.SentMessage
+}
+
+var _ client.API = (*Fake)(nil)
+
+// New returns an empty Fake. Until EnableFeatures is called it behaves like
+// a server that predates capability discovery.
+func New() *Fake {
+	return &Fake{Now: time.Now, errs: make(map[string]error)}
+}
+
+// SetServerVersion sets the version reported by ServerVersion, Version and
+// Capabilities.
+func (f *Fake) SetServerVersion(version string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.serverVersion = version
+}
+
+// EnableFeatures marks the named optional features as supported.
+func (f *Fake) EnableFeatures(names ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.features == nil {
+		f.features = make(map[string]bool)
+	}
+	for _, name := range names {
+		f.features[name] = true
+	}
+}
+
+// Deny makes Command refuse every command with reason. An empty reason
+// approves commands again.
+func (f *Fake) Deny(reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.denyReason = reason
+}
+
+// AddWorkspace adds ws to the team, replacing a workspace with the same ID.
+func (f *Fake) AddWorkspace(ws client.Workspace) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.workspaces {
+		if f.workspaces[i].WorkspaceID == ws.WorkspaceID {
+			f.workspaces[i] = ws
+			return
+		}
+	}
+	f.workspaces = append(f.workspaces, ws)
+}
+
+// AddEvents appends events to the activity feed.
+func (f *Fake) AddEvents(events ...client.ActivityEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, events...)
+}
+
+// AddReservationEvents appends events to the reservation history.
+func (f *Fake) AddReservationEvents(events ...client.ReservationEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resEvents = append(f.resEvents, events...)
+}
+
+// Fail makes every later call to method return err. A nil err clears it.
+// Server errors are best given as *client.Error, as the real client does.
+func (f *Fake) Fail(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.errs, method)
+		return
+	}
+	f.errs[method] = err
+}
+
+// Calls returns the recorded calls to method, oldest first, or every call
+// when method is "".
+func (f *Fake) Calls(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []Call
+	for _, call := range f.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// record logs a call and returns the error set for its method, if any.
+func (f *Fake) record(method string, args ...any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+	return f.errs[method]
+}
+
+func (f *Fake) now() time.Time {
+	if f.Now == nil {
+		return time.Now()
+	}
+	return f.Now()
+}
+
+func (f *Fake) stamp() string {
+	return f.now().UTC().Format(time.RFC3339)
+}
+
+// newID returns a fresh ID with prefix. Callers hold f.mu.
+func (f *Fake) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+// aliasOf returns the alias of a workspace. Callers hold f.mu.
+func (f *Fake) aliasOf(workspaceID string) string {
+	for _, ws := range f.workspaces {
+		if ws.WorkspaceID == workspaceID {
+			return ws.Alias
+		}
+	}
+	return ""
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func notFound(what string) error {
+	return &client.Error{StatusCode: http.StatusNotFound, Body: fmt.Sprintf(`{"detail":"%s not found"}`, what)}
+}
+
+// ServerVersion returns the version set with SetServerVersion.
+func (f *Fake) ServerVersion() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.serverVersion
+}
+
+// Command approves the command unless Deny was called. The context lists
+// the claims of the other workspaces and this workspace's unread messages.
+func (f *Fake) Command(ctx context.Context, req *client.CommandRequest) (*client.CommandResponse, error) {
+	if err := f.record("Command", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.denyReason != "" {
+		return &client.CommandResponse{Approved: false, Reason: f.denyReason}, nil
+	}
+	return &client.CommandResponse{Approved: true, Context: f.commandContext(req.WorkspaceID)}, nil
+}
+
+// commandContext builds the coordination context for workspaceID. Callers
+// hold f.mu.
+func (f *Fake) commandContext(workspaceID string) *client.CommandContext {
+	cc := &client.CommandContext{BeadsInProgress: []client.BeadInProgress{}}
+	for _, ws := range f.workspaces {
+		if ws.WorkspaceID == workspaceID {
+			continue
+		}
+		for _, claim := range ws.Claims {
+			cc.BeadsInProgress = append(cc.BeadsInProgress, client.BeadInProgress{
+				BeadID:      claim.BeadID,
+				WorkspaceID: ws.WorkspaceID,
+				Alias:       ws.Alias,
+				HumanName:   ws.HumanName,
+				StartedAt:   claim.ClaimedAt,
+				Title:       claim.Title,
+				Role:        ws.Role,
+			})
+		}
+	}
+	for _, m := range f.messages {
+		if m.to == workspaceID && !m.msg.Read {
+			cc.MessagesWaiting++
+		}
+	}
+	return cc
+}
+
+// Sync accepts every upload and counts the issues it carries.
+func (f *Fake) Sync(ctx context.Context, req *client.SyncRequest) (*client.SyncResponse, error) {
+	if err := f.record("Sync", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	received := countLines(req.IssuesJSONL) + countLines(req.ChangedIssues) + len(req.IssuePatches)
+	return &client.SyncResponse{
+		Synced:      true,
+		IssuesCount: received,
+		Context:     f.commandContext(req.WorkspaceID),
+		Stats:       &client.SyncStats{Received: received, Deleted: len(req.DeletedIDs)},
+	}, nil
+}
+
+func countLines(jsonl string) int {
+	n := 0
+	for _, line := range strings.Split(jsonl, "\n") {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Workspaces lists the team, filtered like the server does. Claims are
+// only included when asked for.
+func (f *Fake) Workspaces(ctx context.Context, req *client.WorkspacesRequest) (*client.WorkspacesResponse, error) {
+	if err := f.record("Workspaces", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &client.WorkspacesResponse{Workspaces: []client.Workspace{}}
+	for _, ws := range f.workspaces {
+		if (req.Alias != "" && ws.Alias != req.Alias) ||
+			(req.HumanName != "" && ws.HumanName != req.HumanName) ||
+			(req.Hostname != "" && ws.Hostname != req.Hostname) {
+			continue
+		}
+		if !req.IncludeClaims {
+			ws.Claims = nil
+		}
+		resp.Workspaces = append(resp.Workspaces, ws)
+		if req.Limit > 0 && len(resp.Workspaces) == req.Limit {
+			break
+		}
+	}
+	resp.Count = len(resp.Workspaces)
+	return resp, nil
+}
+
+// TeamWorkspaces lists the team with claims, filtered like the server does.
+func (f *Fake) TeamWorkspaces(ctx context.Context, req *client.TeamWorkspacesRequest) (*client.WorkspacesResponse, error) {
+	if err := f.record("TeamWorkspaces", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	onlyWithClaims := req.OnlyWithClaims != nil && *req.OnlyWithClaims
+	sharded := req.FocusApexID != "" || req.Area != ""
+	f.pruneLocks()
+	resp := &client.WorkspacesResponse{Workspaces: []client.Workspace{}}
+	var others *client.TeamOthers
+	otherEpics := make(map[string]bool)
+	for _, ws := range f.workspaces {
+		always := req.AlwaysIncludeWorkspaceID != "" && ws.WorkspaceID == req.AlwaysIncludeWorkspaceID
+		if !always && ((req.HumanName != "" && ws.HumanName != req.HumanName) || (onlyWithClaims && len(ws.Claims) == 0)) {
+			continue
+		}
+		if sharded && !always && !f.inShard(ws, req.FocusApexID, req.Area) {
+			if others == nil {
+				others = &client.TeamOthers{ByRole: make(map[string]int)}
+			}
+			others.Count++
+			others.ByRole[ws.Role]++
+			if ws.FocusApexID != "" {
+				otherEpics[ws.FocusApexID] = true
+			}
+			continue
+		}
+		if req.Limit > 0 && len(resp.Workspaces) == req.Limit {
+			if sharded {
+				continue // keep counting the others
+			}
+			break
+		}
+		if req.IncludeClaims != nil && !*req.IncludeClaims {
+			ws.Claims = nil
+		}
+		resp.Workspaces = append(resp.Workspaces, ws)
+	}
+	resp.Count = len(resp.Workspaces)
+	if others != nil {
+		others.Epics = len(otherEpics)
+		resp.Others = others
+	}
+	return resp, nil
+}
+
+// inShard reports whether ws is focused on or claims under apexID, or
+// holds a reservation under the directory area. Callers hold f.mu.
+func (f *Fake) inShard(ws client.Workspace, apexID, area string) bool {
+	if apexID != "" {
+		if ws.FocusApexID == apexID {
+			return true
+		}
+		for _, claim := range ws.Claims {
+			if claim.ApexID == apexID {
+				return true
+			}
+		}
+	}
+	if area != "" {
+		prefix := strings.TrimSuffix(area, "/") + "/"
+		for _, lock := range f.locks {
+			if lock.WorkspaceID == ws.WorkspaceID && strings.HasPrefix(lock.Path, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ListProjectWorkspaces lists every workspace; the fake has one project.
+func (f *Fake) ListProjectWorkspaces(ctx context.Context, projectID string) (*client.WorkspacesResponse, error) {
+	if err := f.record("ListProjectWorkspaces", projectID); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	workspaces := append([]client.Workspace{}, f.workspaces...)
+	return &client.WorkspacesResponse{Workspaces: workspaces, Count: len(workspaces)}, nil
+}
+
+// DeleteWorkspace removes a workspace. Like the real client, it returns nil
+// and no error when the workspace does not exist.
+func (f *Fake) DeleteWorkspace(ctx context.Context, workspaceID string) (*client.DeleteWorkspaceResponse, error) {
+	if err := f.record("DeleteWorkspace", workspaceID); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, ws := range f.workspaces {
+		if ws.WorkspaceID == workspaceID {
+			f.workspaces = append(f.workspaces[:i], f.workspaces[i+1:]...)
+			return &client.DeleteWorkspaceResponse{WorkspaceID: ws.WorkspaceID, Alias: ws.Alias, DeletedAt: f.stamp()}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Send delivers a message to the inbox of req.ToWorkspace.
+func (f *Fake) Send(ctx context.Context, req *client.SendRequest) (*client.SendResponse, error) {
+	if err := f.record("Send", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id, now := f.newID("msg"), f.stamp()
+	priority := req.Priority
+	if priority == "" {
+		priority = "normal"
+	}
+	f.messages = append(f.messages, fakeMessage{
+		to: req.ToWorkspace,
+		msg: client.Message{
+			MessageID:     id,
+			FromWorkspace: req.FromWorkspace,
+			FromAlias:     req.FromAlias,
+			Subject:       req.Subject,
+			Body:          req.Body,
+			Priority:      priority,
+			CreatedAt:     now,
+		},
+		sent: client.SentMessage{
+			MessageID:   id,
+			ToWorkspace: req.ToWorkspace,
+			ToAlias:     f.aliasOf(req.ToWorkspace),
+			Subject:     req.Subject,
+			Priority:    priority,
+			CreatedAt:   now,
+			DeliveredAt: now,
+		},
+	})
+	return &client.SendResponse{MessageID: id, Status: "delivered", DeliveredAt: now}, nil
+}
+
+// Inbox lists the messages sent to req.WorkspaceID, newest first, starting
+// after req.Before and matching req.Query.
+func (f *Fake) Inbox(ctx context.Context, req *client.InboxRequest) (*client.InboxResponse, error) {
+	if err := f.record("Inbox", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &client.InboxResponse{Messages: []client.Message{}}
+	query := strings.ToLower(req.Query)
+	before := req.Before != ""
+	for i := len(f.messages) - 1; i >= 0; i-- {
+		m := f.messages[i]
+		if before {
+			before = m.msg.MessageID != req.Before
+			continue
+		}
+		if m.to != req.WorkspaceID ||
+			(query != "" && !strings.Contains(strings.ToLower(m.msg.Subject+"\n"+m.msg.Body), query)) ||
+			(req.UnreadOnly && m.msg.Read) ||
+			(m.msg.Archived && !req.IncludeArchived) ||
+			(req.FromWorkspace != "" && m.msg.FromWorkspace != req.FromWorkspace) ||
+			(req.FromAlias != "" && m.msg.FromAlias != req.FromAlias) {
+			continue
+		}
+		if req.Limit > 0 && len(resp.Messages) == req.Limit {
+			resp.HasMore = true
+			break
+		}
+		resp.Messages = append(resp.Messages, m.msg)
+	}
+	resp.Count = len(resp.Messages)
+	return resp, nil
+}
+
+// Ack marks a message read, and archived when req.Archive is set.
+func (f *Fake) Ack(ctx context.Context, messageID string, req *client.AckRequest) (*client.AckResponse, error) {
+	if err := f.record("Ack", messageID, req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.messages {
+		m := &f.messages[i]
+		if m.msg.MessageID != messageID {
+			continue
+		}
+		now := f.stamp()
+		resp := &client.AckResponse{MessageID: messageID, AcknowledgedAt: now}
+		m.msg.Read = true
+		if m.sent.ReadAt == "" {
+			m.sent.ReadAt = now
+		}
+		if req != nil && req.Archive {
+			m.msg.Archived = true
+			m.sent.ArchivedAt = now
+			resp.ArchivedAt = now
+		}
+		return resp, nil
+	}
+	return nil, notFound("message")
+}
+
+// SentMessages lists the messages sent by req.WorkspaceID, newest first,
+// with their receipts.
+func (f *Fake) SentMessages(ctx context.Context, req *client.SentMessagesRequest) (*client.SentMessagesResponse, error) {
+	if err := f.record("SentMessages", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &client.SentMessagesResponse{Messages: []client.SentMessage{}}
+	for i := len(f.messages) - 1; i >= 0; i-- {
+		m := f.messages[i]
+		if m.msg.FromWorkspace != req.WorkspaceID || (req.ToAlias != "" && m.sent.ToAlias != req.ToAlias) {
+			continue
+		}
+		if req.Limit > 0 && len(resp.Messages) == req.Limit {
+			resp.HasMore = true
+			break
+		}
+		resp.Messages = append(resp.Messages, m.sent)
+	}
+	resp.Count = len(resp.Messages)
+	return resp, nil
+}
+
+// MessageReceipt returns the receipt of a sent message.
+func (f *Fake) MessageReceipt(ctx context.Context, messageID string) (*client.SentMessage, error) {
+	if err := f.record("MessageReceipt", messageID); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, m := range f.messages {
+		if m.sent.MessageID == messageID {
+			sent := m.sent
+			return &sent, nil
+		}
+	}
+	return nil, notFound("message")
+}
+
+// Lock grants each path unless another workspace holds it and either
+// reservation is exclusive. Paths this workspace already holds are renewed.
+func (f *Fake) Lock(ctx context.Context, req *client.LockRequest) (*client.LockResponse, error) {
+	if err := f.record("Lock", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pruneLocks()
+	now := f.now()
+	ttl := DefaultLockTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	expires := now.Add(ttl).UTC().Format(time.RFC3339)
+
+	resp := &client.LockResponse{Granted: []client.GrantedLock{}, Conflicts: []client.ConflictLock{}}
+	for _, path := range req.Paths {
+		var conflict *client.LockInfo
+		var mine *client.LockInfo
+		for i := range f.locks {
+			lock := &f.locks[i]
+			if lock.Path != path {
+				continue
+			}
+			if lock.WorkspaceID == req.WorkspaceID {
+				mine = lock
+			} else if lock.Exclusive || req.Exclusive {
+				conflict = lock
+			}
+		}
+		if conflict != nil {
+			acquiredAt, expiresAt := conflict.AcquiredAt, conflict.ExpiresAt
+			resp.Conflicts = append(resp.Conflicts, client.ConflictLock{
+				Path:              path,
+				HeldBy:            conflict.Alias,
+				WorkspaceID:       conflict.WorkspaceID,
+				BeadID:            conflict.BeadID,
+				Reason:            conflict.Reason,
+				Exclusive:         conflict.Exclusive,
+				AcquiredAt:        &acquiredAt,
+				ExpiresAt:         &expiresAt,
+				RetryAfterSeconds: f.remaining(conflict.ExpiresAt),
+			})
+			continue
+		}
+		if mine == nil {
+			f.locks = append(f.locks, client.LockInfo{
+				ReservationID: f.newID("res"),
+				Path:          path,
+				Alias:         req.Alias,
+				WorkspaceID:   req.WorkspaceID,
+				AcquiredAt:    now.UTC().Format(time.RFC3339),
+			})
+			mine = &f.locks[len(f.locks)-1]
+		}
+		mine.Exclusive = req.Exclusive
+		mine.ExpiresAt = expires
+		mine.BeadID = optional(req.BeadID)
+		mine.Reason = optional(req.Reason)
+		resp.Granted = append(resp.Granted, client.GrantedLock{ReservationID: mine.ReservationID, Path: path, ExpiresAt: expires})
+	}
+	return resp, nil
+}
+
+// Unlock releases the paths req.WorkspaceID holds.
+func (f *Fake) Unlock(ctx context.Context, req *client.UnlockRequest) (*client.UnlockResponse, error) {
+	if err := f.record("Unlock", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pruneLocks()
+	resp := &client.UnlockResponse{Released: []string{}, NotFound: []string{}, NotOwner: []string{}}
+	for _, path := range req.Paths {
+		found, released := false, false
+		kept := f.locks[:0]
+		for _, lock := range f.locks {
+			if lock.Path == path {
+				found = true
+				if lock.WorkspaceID == req.WorkspaceID {
+					released = true
+					continue
+				}
+			}
+			kept = append(kept, lock)
+		}
+		f.locks = kept
+		switch {
+		case released:
+			resp.Released = append(resp.Released, path)
+		case found:
+			resp.NotOwner = append(resp.NotOwner, path)
+		default:
+			resp.NotFound = append(resp.NotFound, path)
+		}
+	}
+	return resp, nil
+}
+
+// ListLocks lists the reservations that have not expired.
+func (f *Fake) ListLocks(ctx context.Context, req *client.ListLocksRequest) (*client.ListLocksResponse, error) {
+	if err := f.record("ListLocks", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pruneLocks()
+	resp := &client.ListLocksResponse{Reservations: []client.LockInfo{}}
+	for _, lock := range f.locks {
+		if (req.WorkspaceID != "" && lock.WorkspaceID != req.WorkspaceID) ||
+			(req.Alias != "" && lock.Alias != req.Alias) ||
+			(req.PathPrefix != "" && !strings.HasPrefix(lock.Path, req.PathPrefix)) {
+			continue
+		}
+		lock.TTLRemainingSeconds = f.remaining(lock.ExpiresAt)
+		resp.Reservations = append(resp.Reservations, lock)
+	}
+	resp.Count = len(resp.Reservations)
+	return resp, nil
+}
+
+// pruneLocks drops expired reservations. Callers hold f.mu.
+func (f *Fake) pruneLocks() {
+	kept := f.locks[:0]
+	for _, lock := range f.locks {
+		if f.remaining(lock.ExpiresAt) > 0 {
+			kept = append(kept, lock)
+		}
+	}
+	f.locks = kept
+}
+
+// remaining returns the seconds until expiresAt.
+func (f *Fake) remaining(expiresAt string) int {
+	t, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return 0
+	}
+	if secs := int(t.Sub(f.now()).Seconds()); secs > 0 {
+		return secs
+	}
+	return 0
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// SetFocus sets the focus of a workspace added with AddWorkspace. Titles
+// are left empty: the fake does not know the beads.
+func (f *Fake) SetFocus(ctx context.Context, workspaceID string, req *client.SetFocusRequest) (*client.SetFocusResponse, error) {
+	if err := f.record("SetFocus", workspaceID, req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.workspaces {
+		ws := &f.workspaces[i]
+		if ws.WorkspaceID != workspaceID {
+			continue
+		}
+		ws.FocusApexID, ws.FocusApexTitle, ws.FocusApexType = req.ApexID, "", ""
+		ws.FocusUpdatedAt = f.stamp()
+		return &client.SetFocusResponse{WorkspaceID: workspaceID, FocusApexID: req.ApexID, FocusUpdatedAt: ws.FocusUpdatedAt}, nil
+	}
+	return nil, notFound("workspace")
+}
+
+// AddSquad declares a squad of the workspaces with the given aliases.
+func (f *Fake) AddSquad(name string, aliases ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.squads == nil {
+		f.squads = make(map[string][]string)
+	}
+	f.squads[name] = append(f.squads[name], aliases...)
+}
+
+// Squad returns the squad added with AddSquad that the workspace's alias is
+// in, or a 404.
+func (f *Fake) Squad(ctx context.Context, workspaceID string) (*client.SquadResponse, error) {
+	if err := f.record("Squad", workspaceID); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	alias := ""
+	for _, ws := range f.workspaces {
+		if ws.WorkspaceID == workspaceID {
+			alias = ws.Alias
+		}
+	}
+	for name, aliases := range f.squads {
+		if alias == "" || !containsString(aliases, alias) {
+			continue
+		}
+		resp := &client.SquadResponse{Name: name}
+		for _, a := range aliases {
+			member := client.SquadMember{Alias: a}
+			for _, ws := range f.workspaces {
+				if ws.Alias == a {
+					member.WorkspaceID = ws.WorkspaceID
+				}
+			}
+			resp.Members = append(resp.Members, member)
+		}
+		return resp, nil
+	}
+	return nil, notFound("squad")
+}
+
+// Capabilities reports the features enabled with EnableFeatures. Before
+// any are, it answers 404 like a server that predates the endpoint.
+func (f *Fake) Capabilities(ctx context.Context) (*client.CapabilitiesResponse, error) {
+	if err := f.record("Capabilities"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.features == nil {
+		return nil, notFound("endpoint")
+	}
+	features := make(map[string]bool, len(f.features))
+	for name, ok := range f.features {
+		features[name] = ok
+	}
+	return &client.CapabilitiesResponse{ServerVersion: f.serverVersion, Features: features}, nil
+}
+
+// Version reports the version set with SetServerVersion, or answers 404
+// when none was set.
+func (f *Fake) Version(ctx context.Context) (*client.VersionResponse, error) {
+	if err := f.record("Version"); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.serverVersion == "" {
+		return nil, notFound("endpoint")
+	}
+	return &client.VersionResponse{Version: f.serverVersion}, nil
+}
+
+// Activity returns the events added with AddEvents that are later than
+// req.Since, up to req.Limit.
+func (f *Fake) Activity(ctx context.Context, req *client.ActivityRequest) (*client.ActivityResponse, error) {
+	if err := f.record("Activity", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	since, _ := time.Parse(time.RFC3339, req.Since)
+	resp := &client.ActivityResponse{Events: []client.ActivityEvent{}}
+	for _, e := range f.events {
+		if at, err := time.Parse(time.RFC3339, e.At); err == nil && !at.After(since) {
+			continue
+		}
+		resp.Events = append(resp.Events, e)
+		if req.Limit > 0 && len(resp.Events) == req.Limit {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// ReservationHistory lists the reservation events after req.Since.
+func (f *Fake) ReservationHistory(ctx context.Context, req *client.ReservationHistoryRequest) (*client.ReservationHistoryResponse, error) {
+	if err := f.record("ReservationHistory", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	since, _ := time.Parse(time.RFC3339, req.Since)
+	resp := &client.ReservationHistoryResponse{Events: []client.ReservationEvent{}}
+	for _, e := range f.resEvents {
+		if at, err := time.Parse(time.RFC3339, e.At); err == nil && !at.After(since) {
+			continue
+		}
+		resp.Events = append(resp.Events, e)
+		if req.Limit > 0 && len(resp.Events) == req.Limit {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// RequestApproval parks a command as a pending approval.
+func (f *Fake) RequestApproval(ctx context.Context, req *client.RequestApprovalRequest) (*client.Approval, error) {
+	if err := f.record("RequestApproval", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	approval := client.Approval{
+		ApprovalID:  f.newID("ap"),
+		WorkspaceID: req.WorkspaceID,
+		Alias:       req.Alias,
+		CommandLine: req.CommandLine,
+		Reason:      req.Reason,
+		Status:      client.ApprovalPending,
+		CreatedAt:   f.stamp(),
+	}
+	f.approvals = append(f.approvals, approval)
+	return &approval, nil
+}
+
+// ListApprovals lists the approvals with req.Status, oldest first.
+func (f *Fake) ListApprovals(ctx context.Context, req *client.ListApprovalsRequest) (*client.ListApprovalsResponse, error) {
+	if err := f.record("ListApprovals", req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &client.ListApprovalsResponse{Approvals: []client.Approval{}}
+	for _, a := range f.approvals {
+		if req.Status != "" && a.Status != req.Status {
+			continue
+		}
+		resp.Approvals = append(resp.Approvals, a)
+		if req.Limit > 0 && len(resp.Approvals) == req.Limit {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// GetApproval returns an approval, or a 404.
+func (f *Fake) GetApproval(ctx context.Context, approvalID string) (*client.Approval, error) {
+	if err := f.record("GetApproval", approvalID); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, a := range f.approvals {
+		if a.ApprovalID == approvalID {
+			return &a, nil
+		}
+	}
+	return nil, notFound("approval")
+}
+
+// DecideApproval grants or denies a pending approval; deciding twice is a
+// 409 and deciding from the workspace that parked it a 403, like on the
+// server.
+func (f *Fake) DecideApproval(ctx context.Context, approvalID string, req *client.DecideApprovalRequest) (*client.Approval, error) {
+	if err := f.record("DecideApproval", approvalID, req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.approvals {
+		a := &f.approvals[i]
+		if a.ApprovalID != approvalID {
+			continue
+		}
+		if a.WorkspaceID == req.WorkspaceID {
+			return nil, &client.Error{StatusCode: http.StatusForbidden, Body: `{"detail":"a workspace cannot decide its own approval request"}`}
+		}
+		if a.Status != client.ApprovalPending {
+			return nil, &client.Error{StatusCode: http.StatusConflict, Body: `{"detail":"approval already decided"}`}
+		}
+		a.Status, a.DecidedBy, a.Note, a.DecidedAt = req.Status, req.DecidedBy, req.Note, f.stamp()
+		decided := *a
+		return &decided, nil
+	}
+	return nil, notFound("approval")
+}
+
+// Raw answers 404: the fake only knows the typed endpoints.
+func (f *Fake) Raw(ctx context.Context, method, path string, body []byte) (*client.RawResponse, error) {
+	if err := f.record("Raw", method, path, body); err != nil {
+		return nil, err
+	}
+	return nil, notFound("endpoint")
+}
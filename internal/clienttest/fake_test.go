@@ -0,0 +1,127 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func newTestFake() *Fake {
+	f := New()
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	f.Now = func() time.Time { return now }
+	f.AddWorkspace(client.Workspace{WorkspaceID: "ws-a", Alias: "alice", HumanName: "Ann"})
+	f.AddWorkspace(client.Workspace{WorkspaceID: "ws-b", Alias: "bob", HumanName: "Ben",
+		Claims: []client.Claim{{BeadID: "bd-1", Title: "Login"}}})
+	return f
+}
+
+func TestFake_Messages(t *testing.T) {
+	f := newTestFake()
+	ctx := context.Background()
+
+	sent, err := f.Send(ctx, &client.SendRequest{FromWorkspace: "ws-b", FromAlias: "bob", ToWorkspace: "ws-a", Body: "hi"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	cmd, _ := f.Command(ctx, &client.CommandRequest{WorkspaceID: "ws-a"})
+	if !cmd.Approved || cmd.Context.MessagesWaiting != 1 || len(cmd.Context.BeadsInProgress) != 1 {
+		t.Errorf("unexpected command response: %+v %+v", cmd, cmd.Context)
+	}
+
+	inbox, _ := f.Inbox(ctx, &client.InboxRequest{WorkspaceID: "ws-a", UnreadOnly: true})
+	if inbox.Count != 1 || inbox.Messages[0].Body != "hi" {
+		t.Fatalf("unexpected inbox: %+v", inbox)
+	}
+	if _, err := f.Ack(ctx, sent.MessageID, &client.AckRequest{WorkspaceID: "ws-a"}); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	if inbox, _ := f.Inbox(ctx, &client.InboxRequest{WorkspaceID: "ws-a", UnreadOnly: true}); inbox.Count != 0 {
+		t.Errorf("expected no unread messages, got %+v", inbox)
+	}
+	receipt, _ := f.MessageReceipt(ctx, sent.MessageID)
+	if receipt.ToAlias != "alice" || receipt.ReadAt == "" {
+		t.Errorf("unexpected receipt: %+v", receipt)
+	}
+
+	var clientErr *client.Error
+	if _, err := f.Ack(ctx, "msg-404", nil); !errors.As(err, &clientErr) || clientErr.StatusCode != 404 {
+		t.Errorf("expected a 404 for an unknown message, got %v", err)
+	}
+}
+
+func TestFake_Locks(t *testing.T) {
+	f := newTestFake()
+	ctx := context.Background()
+
+	resp, _ := f.Lock(ctx, &client.LockRequest{WorkspaceID: "ws-a", Alias: "alice", Paths: []string{"a.go"}, Exclusive: true, TTLSeconds: 60})
+	if len(resp.Granted) != 1 {
+		t.Fatalf("expected a.go granted, got %+v", resp)
+	}
+	resp, _ = f.Lock(ctx, &client.LockRequest{WorkspaceID: "ws-b", Alias: "bob", Paths: []string{"a.go", "b.go"}})
+	if len(resp.Granted) != 1 || len(resp.Conflicts) != 1 || resp.Conflicts[0].HeldBy != "alice" || resp.Conflicts[0].RetryAfterSeconds != 60 {
+		t.Errorf("unexpected lock response: %+v", resp)
+	}
+
+	unlock, _ := f.Unlock(ctx, &client.UnlockRequest{WorkspaceID: "ws-b", Paths: []string{"a.go", "b.go", "c.go"}})
+	if len(unlock.NotOwner) != 1 || len(unlock.Released) != 1 || len(unlock.NotFound) != 1 {
+		t.Errorf("unexpected unlock response: %+v", unlock)
+	}
+
+	// Reservations expire with the fake's clock.
+	later := f.now().Add(2 * time.Minute)
+	f.Now = func() time.Time { return later }
+	if list, _ := f.ListLocks(ctx, &client.ListLocksRequest{}); list.Count != 0 {
+		t.Errorf("expected the reservation to expire, got %+v", list)
+	}
+}
+
+func TestFake_CapabilitiesAndFail(t *testing.T) {
+	f := newTestFake()
+	ctx := context.Background()
+
+	var clientErr *client.Error
+	if _, err := f.Capabilities(ctx); !errors.As(err, &clientErr) || clientErr.StatusCode != 404 {
+		t.Errorf("expected 404 before any feature is enabled, got %v", err)
+	}
+	f.EnableFeatures("activity")
+	if caps, err := f.Capabilities(ctx); err != nil || !caps.Features["activity"] {
+		t.Errorf("unexpected capabilities: %+v %v", caps, err)
+	}
+
+	boom := &client.Error{StatusCode: 503, Body: "down"}
+	f.Fail("Sync", boom)
+	if _, err := f.Sync(ctx, &client.SyncRequest{}); !errors.Is(err, boom) {
+		t.Errorf("expected the injected error, got %v", err)
+	}
+	f.Fail("Sync", nil)
+	if resp, err := f.Sync(ctx, &client.SyncRequest{IssuesJSONL: "{}\n{}\n"}); err != nil || resp.IssuesCount != 2 {
+		t.Errorf("unexpected sync: %+v %v", resp, err)
+	}
+	if calls := f.Calls("Sync"); len(calls) != 2 {
+		t.Errorf("expected 2 recorded Sync calls, got %d", len(calls))
+	}
+}
+
+func TestFake_TeamShard(t *testing.T) {
+	f := newTestFake()
+	ctx := context.Background()
+	f.AddWorkspace(client.Workspace{WorkspaceID: "ws-c", Alias: "carol", Role: "backend", FocusApexID: "bd-e1"})
+	f.AddWorkspace(client.Workspace{WorkspaceID: "ws-d", Alias: "dave", Role: "backend", FocusApexID: "bd-e2"})
+	f.Lock(ctx, &client.LockRequest{WorkspaceID: "ws-b", Alias: "bob", Paths: []string{"src/api/h.go"}})
+
+	resp, _ := f.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{FocusApexID: "bd-e1", Area: "src/api", AlwaysIncludeWorkspaceID: "ws-a"})
+	var aliases []string
+	for _, ws := range resp.Workspaces {
+		aliases = append(aliases, ws.Alias)
+	}
+	if len(aliases) != 3 || aliases[0] != "alice" || aliases[1] != "bob" || aliases[2] != "carol" {
+		t.Errorf("unexpected shard: %v", aliases)
+	}
+	if o := resp.Others; o == nil || o.Count != 1 || o.Epics != 1 || o.ByRole["backend"] != 1 {
+		t.Errorf("unexpected others: %+v", o)
+	}
+}
@@ -0,0 +1,229 @@
+package clienttest
+
+import (
+	"context"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// Endpoints the fake keeps no state for: each call is recorded and answered
+// with an empty response, or with the error set by Fail. Embed the Fake and
+// define the method to answer differently.
+
+func (f *Fake) SyncManifest(ctx context.Context, req *client.SyncManifestRequest) (*client.SyncManifestResponse, error) {
+	if err := f.record("SyncManifest", req); err != nil {
+		return nil, err
+	}
+	return &client.SyncManifestResponse{}, nil
+}
+
+func (f *Fake) EnsureProject(ctx context.Context, req *client.EnsureProjectRequest) (*client.EnsureProjectResponse, error) {
+	if err := f.record("EnsureProject", req); err != nil {
+		return nil, err
+	}
+	return &client.EnsureProjectResponse{}, nil
+}
+
+func (f *Fake) Init(ctx context.Context, req *client.InitRequest) (*client.InitResponse, error) {
+	if err := f.record("Init", req); err != nil {
+		return nil, err
+	}
+	return &client.InitResponse{}, nil
+}
+
+func (f *Fake) ListProjects(ctx context.Context) (*client.ListProjectsResponse, error) {
+	if err := f.record("ListProjects"); err != nil {
+		return nil, err
+	}
+	return &client.ListProjectsResponse{}, nil
+}
+
+func (f *Fake) DeleteProject(ctx context.Context, projectID string) (*client.DeleteProjectResponse, error) {
+	if err := f.record("DeleteProject", projectID); err != nil {
+		return nil, err
+	}
+	return &client.DeleteProjectResponse{}, nil
+}
+
+func (f *Fake) ListProjectRepos(ctx context.Context, projectID string) (*client.ListProjectReposResponse, error) {
+	if err := f.record("ListProjectRepos", projectID); err != nil {
+		return nil, err
+	}
+	return &client.ListProjectReposResponse{}, nil
+}
+
+func (f *Fake) ProjectStatus(ctx context.Context, projectID string) (*client.ProjectStatusResponse, error) {
+	if err := f.record("ProjectStatus", projectID); err != nil {
+		return nil, err
+	}
+	return &client.ProjectStatusResponse{}, nil
+}
+
+func (f *Fake) EnsureRepo(ctx context.Context, req *client.EnsureRepoRequest) (*client.EnsureRepoResponse, error) {
+	if err := f.record("EnsureRepo", req); err != nil {
+		return nil, err
+	}
+	return &client.EnsureRepoResponse{}, nil
+}
+
+func (f *Fake) LookupRepo(ctx context.Context, req *client.LookupRepoRequest) (*client.LookupRepoResponse, error) {
+	if err := f.record("LookupRepo", req); err != nil {
+		return nil, err
+	}
+	return &client.LookupRepoResponse{}, nil
+}
+
+func (f *Fake) RefreshPresence(ctx context.Context, req *client.RefreshPresenceRequest) (*client.RefreshPresenceResponse, error) {
+	if err := f.record("RefreshPresence", req); err != nil {
+		return nil, err
+	}
+	return &client.RefreshPresenceResponse{}, nil
+}
+
+func (f *Fake) RegisterWorkspace(ctx context.Context, req *client.RegisterWorkspaceRequest) (*client.RegisterWorkspaceResponse, error) {
+	if err := f.record("RegisterWorkspace", req); err != nil {
+		return nil, err
+	}
+	return &client.RegisterWorkspaceResponse{}, nil
+}
+
+func (f *Fake) SuggestNamePrefix(ctx context.Context, req *client.SuggestNamePrefixRequest) (*client.SuggestNamePrefixResponse, error) {
+	if err := f.record("SuggestNamePrefix", req); err != nil {
+		return nil, err
+	}
+	return &client.SuggestNamePrefixResponse{}, nil
+}
+
+func (f *Fake) SuggestAliasPrefixByProject(ctx context.Context, req *client.SuggestAliasPrefixRequest) (*client.SuggestAliasPrefixResponse, error) {
+	if err := f.record("SuggestAliasPrefixByProject", req); err != nil {
+		return nil, err
+	}
+	return &client.SuggestAliasPrefixResponse{}, nil
+}
+
+func (f *Fake) SearchBeads(ctx context.Context, req *client.SearchBeadsRequest) (*client.SearchBeadsResponse, error) {
+	if err := f.record("SearchBeads", req); err != nil {
+		return nil, err
+	}
+	return &client.SearchBeadsResponse{}, nil
+}
+
+func (f *Fake) AddBeadNote(ctx context.Context, beadID string, req *client.AddBeadNoteRequest) (*client.BeadNote, error) {
+	if err := f.record("AddBeadNote", beadID, req); err != nil {
+		return nil, err
+	}
+	return &client.BeadNote{}, nil
+}
+
+func (f *Fake) ListBeadNotes(ctx context.Context, req *client.ListBeadNotesRequest) (*client.ListBeadNotesResponse, error) {
+	if err := f.record("ListBeadNotes", req); err != nil {
+		return nil, err
+	}
+	return &client.ListBeadNotesResponse{}, nil
+}
+
+func (f *Fake) AddBeadAttachment(ctx context.Context, beadID string, req *client.AddBeadAttachmentRequest) (*client.BeadAttachment, error) {
+	if err := f.record("AddBeadAttachment", beadID, req); err != nil {
+		return nil, err
+	}
+	return &client.BeadAttachment{}, nil
+}
+
+func (f *Fake) ListBeadAttachments(ctx context.Context, req *client.ListBeadAttachmentsRequest) (*client.ListBeadAttachmentsResponse, error) {
+	if err := f.record("ListBeadAttachments", req); err != nil {
+		return nil, err
+	}
+	return &client.ListBeadAttachmentsResponse{}, nil
+}
+
+func (f *Fake) ActivePolicy(ctx context.Context, req *client.ActivePolicyRequest) (*client.ActivePolicyResponse, error) {
+	if err := f.record("ActivePolicy", req); err != nil {
+		return nil, err
+	}
+	return &client.ActivePolicyResponse{}, nil
+}
+
+func (f *Fake) ActivePolicyFetch(ctx context.Context, reqParams *client.ActivePolicyRequest, opts *client.ActivePolicyFetchOptions) (*client.ActivePolicyFetchResponse, error) {
+	if err := f.record("ActivePolicyFetch", reqParams, opts); err != nil {
+		return nil, err
+	}
+	return &client.ActivePolicyFetchResponse{}, nil
+}
+
+func (f *Fake) ResetPolicy(ctx context.Context) (*client.ResetPolicyResponse, error) {
+	if err := f.record("ResetPolicy"); err != nil {
+		return nil, err
+	}
+	return &client.ResetPolicyResponse{}, nil
+}
+
+func (f *Fake) Status(ctx context.Context, req *client.StatusRequest) (*client.StatusResponse, error) {
+	if err := f.record("Status", req); err != nil {
+		return nil, err
+	}
+	return &client.StatusResponse{}, nil
+}
+
+func (f *Fake) Escalate(ctx context.Context, req *client.EscalateRequest) (*client.EscalateResponse, error) {
+	if err := f.record("Escalate", req); err != nil {
+		return nil, err
+	}
+	return &client.EscalateResponse{}, nil
+}
+
+func (f *Fake) GetEscalation(ctx context.Context, escalationID string) (*client.Escalation, error) {
+	if err := f.record("GetEscalation", escalationID); err != nil {
+		return nil, err
+	}
+	return &client.Escalation{}, nil
+}
+
+func (f *Fake) ScheduleMessage(ctx context.Context, req *client.ScheduleMessageRequest) (*client.ScheduleMessageResponse, error) {
+	if err := f.record("ScheduleMessage", req); err != nil {
+		return nil, err
+	}
+	return &client.ScheduleMessageResponse{}, nil
+}
+
+func (f *Fake) SetAnnouncement(ctx context.Context, req *client.SetAnnouncementRequest) (*client.Announcement, error) {
+	if err := f.record("SetAnnouncement", req); err != nil {
+		return nil, err
+	}
+	return &client.Announcement{}, nil
+}
+
+func (f *Fake) ClearAnnouncement(ctx context.Context) error {
+	return f.record("ClearAnnouncement")
+}
+
+func (f *Fake) CreateInvite(ctx context.Context, req *client.CreateInviteRequest) (*client.CreateInviteResponse, error) {
+	if err := f.record("CreateInvite", req); err != nil {
+		return nil, err
+	}
+	return &client.CreateInviteResponse{}, nil
+}
+
+func (f *Fake) RequestTakeover(ctx context.Context, req *client.TakeoverRequest) (*client.TakeoverResponse, error) {
+	if err := f.record("RequestTakeover", req); err != nil {
+		return nil, err
+	}
+	return &client.TakeoverResponse{}, nil
+}
+
+func (f *Fake) ReportReservationEvents(ctx context.Context, req *client.ReportReservationEventsRequest) error {
+	return f.record("ReportReservationEvents", req)
+}
+
+func (f *Fake) PublishKey(ctx context.Context, req *client.PublishKeyRequest) (*client.PublicKey, error) {
+	if err := f.record("PublishKey", req); err != nil {
+		return nil, err
+	}
+	return &client.PublicKey{}, nil
+}
+
+func (f *Fake) PublicKeys(ctx context.Context, req *client.PublicKeysRequest) (*client.PublicKeysResponse, error) {
+	if err := f.record("PublicKeys", req); err != nil {
+		return nil, err
+	}
+	return &client.PublicKeysResponse{}, nil
+}
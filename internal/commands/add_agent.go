@@ -147,6 +147,7 @@ func cleanupWorktree(repoPath, worktreePath, branchName string, deleteBranch boo
 }
 
 func runAddWorktree(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Load existing config early so we have BeadHub URL for role lookup
 	cfg, err := config.Load()
 	if err != nil {
@@ -159,7 +160,7 @@ func runAddWorktree(cmd *cobra.Command, args []string) error {
 	}
 	if role == "" {
 		// Fetch available roles from server policy
-		availableRoles, err := fetchAvailablePolicyRoles(cfg.BeadhubURL)
+		availableRoles, err := fetchAvailablePolicyRoles(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
@@ -208,10 +209,10 @@ func runAddWorktree(cmd *cobra.Command, args []string) error {
 
 	// If alias is not explicit, we need an authenticated BeadHub client to ask for the
 	// next available name prefix (alice, bob, ...).
-	var c *client.Client
+	var c BeadHubAPI
 	if !aliasExplicit {
 		fmt.Println("Querying BeadHub for next available name...")
-		c, err = newBeadHubClientRequired(cfg.BeadhubURL)
+		c, err = newBeadHubClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
@@ -225,7 +226,7 @@ func runAddWorktree(cmd *cobra.Command, args []string) error {
 	const maxAttempts = 25
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		if !aliasExplicit {
-			ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+			ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 			resp, err := c.SuggestNamePrefix(ctx, &client.SuggestNamePrefixRequest{OriginURL: repoOrigin})
 			cancel()
 			if err != nil {
@@ -282,7 +283,7 @@ func runAddWorktree(cmd *cobra.Command, args []string) error {
 		initHuman = cfg.HumanName
 		initProject = cfg.ProjectSlug
 
-		initErr := runInit()
+		initErr := runInit(ctx)
 		_ = os.Chdir(origDir)
 		if initErr != nil {
 			fmt.Println()
@@ -318,9 +319,9 @@ func runAddWorktree(cmd *cobra.Command, args []string) error {
 }
 
 // fetchAvailablePolicyRoles fetches the role names defined in the server's active policy.
-func fetchAvailablePolicyRoles(beadhubURL string) ([]string, error) {
-	c := newBeadHubClient(beadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+func fetchAvailablePolicyRoles(ctx context.Context, beadhubURL string) ([]string, error) {
+	c := newBeadHubClient(ctx, beadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	onlySelected := false
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -231,6 +232,7 @@ func TestAddAgent_Integration(t *testing.T) {
 	resetAddWorktreeInitFlags()
 
 	// Run the command
+	addWorktreeCmd.SetContext(context.Background())
 	err = runAddWorktree(addWorktreeCmd, []string{"coord"})
 	if err != nil {
 		t.Fatalf("runAddWorktree() error: %v", err)
@@ -316,6 +318,7 @@ func TestAddAgent_NoRole_NonTTY_ErrorsWithAvailableRoles(t *testing.T) {
 	resetAddWorktreeInitFlags()
 
 	// Non-TTY with no args should error listing available roles
+	addWorktreeCmd.SetContext(context.Background())
 	err := runAddWorktree(addWorktreeCmd, []string{})
 	if err == nil {
 		t.Fatal("expected error when no role specified in non-TTY mode")
@@ -368,6 +371,7 @@ func TestAddAgent_NoRole_FetchRolesError_Surfaced(t *testing.T) {
 	resetAddWorktreeInitFlags()
 
 	// Should surface the fetch error rather than silently falling back
+	addWorktreeCmd.SetContext(context.Background())
 	err := runAddWorktree(addWorktreeCmd, []string{})
 	if err == nil {
 		t.Fatal("expected error when policy fetch fails")
@@ -424,6 +428,7 @@ func TestAddAgent_NoRole_NonTTY_EmptyRoles(t *testing.T) {
 	resetAddWorktreeInitFlags()
 
 	// Non-TTY with no args and empty roles should error without listing roles
+	addWorktreeCmd.SetContext(context.Background())
 	err := runAddWorktree(addWorktreeCmd, []string{})
 	if err == nil {
 		t.Fatal("expected error when no role specified in non-TTY mode")
@@ -544,6 +549,7 @@ func TestAddAgent_DirectoryAlreadyExists(t *testing.T) {
 	resetAddWorktreeInitFlags()
 
 	// Run the command - should fail due to existing directory
+	addWorktreeCmd.SetContext(context.Background())
 	err = runAddWorktree(addWorktreeCmd, []string{"coord"})
 	if err == nil {
 		t.Fatal("runAddWorktree() should error when directory exists")
@@ -607,6 +613,7 @@ func TestAddWorktree_InvalidExplicitAliasRejected(t *testing.T) {
 	addWorktreeAlias = "_invalid"
 	resetAddWorktreeInitFlags()
 
+	addWorktreeCmd.SetContext(context.Background())
 	err := runAddWorktree(addWorktreeCmd, []string{"coord"})
 	if err == nil {
 		t.Fatalf("expected error")
@@ -2,9 +2,13 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/beadhub/bdh/internal/client"
 	"github.com/beadhub/bdh/internal/config"
@@ -45,6 +49,84 @@ type AliasResolution struct {
 	WorkspaceID string
 	Alias       string
 	MatchType   MatchType
+	// StaleNote is set when the server was unreachable and the alias was
+	// matched against the local alias cache instead.
+	StaleNote string
+}
+
+// aliasCache is the last-known list of team aliases, kept in
+// .beadhub-cache/aliases.json so aliases still resolve while offline.
+type aliasCache struct {
+	FetchedAt string        `json:"fetched_at"`
+	Aliases   []cachedAlias `json:"aliases"`
+}
+
+type cachedAlias struct {
+	WorkspaceID string `json:"workspace_id"`
+	Alias       string `json:"alias"`
+	HumanName   string `json:"human_name,omitempty"`
+}
+
+func aliasCachePath() (string, error) {
+	return cacheFilePath("aliases.json")
+}
+
+func loadAliasCache(path string) (*aliasCache, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cache aliasCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &cache, nil
+}
+
+// saveAliasCache stores the alias fields of workspaces (best-effort).
+func saveAliasCache(path string, workspaces []client.Workspace, now time.Time) {
+	cache := aliasCache{FetchedAt: now.UTC().Format(time.RFC3339)}
+	for _, ws := range workspaces {
+		cache.Aliases = append(cache.Aliases, cachedAlias{
+			WorkspaceID: ws.WorkspaceID,
+			Alias:       ws.Alias,
+			HumanName:   ws.HumanName,
+		})
+	}
+	_ = writeJSONAtomic(path, cache)
+}
+
+// aliasWorkspaces lists the project's workspaces for alias matching. A
+// successful fetch refreshes the alias cache; when the server cannot be
+// reached the cache is used instead and staleNote says how old it is.
+func aliasWorkspaces(ctx context.Context, httpClient BeadHubAPI) (workspaces []client.Workspace, staleNote string, err error) {
+	// Fetch all workspaces for this project (not just active ones with claims)
+	includePresence := false
+	resp, err := httpClient.Workspaces(ctx, &client.WorkspacesRequest{
+		IncludePresence: &includePresence,
+		Limit:           maxWorkspaceQueryLimit, // Get all workspaces for matching
+	})
+	cachePath, pathErr := aliasCachePath()
+	if err == nil {
+		if pathErr == nil {
+			saveAliasCache(cachePath, resp.Workspaces, time.Now())
+		}
+		return resp.Workspaces, "", nil
+	}
+
+	var clientErr *client.Error
+	if errors.As(err, &clientErr) || client.IsPayloadError(err) || pathErr != nil {
+		return nil, "", fmt.Errorf("fetching workspaces: %w", err)
+	}
+	cache, cacheErr := loadAliasCache(cachePath)
+	if cacheErr != nil || len(cache.Aliases) == 0 {
+		return nil, "", fmt.Errorf("fetching workspaces: %w", err)
+	}
+	for _, a := range cache.Aliases {
+		workspaces = append(workspaces, client.Workspace{WorkspaceID: a.WorkspaceID, Alias: a.Alias, HumanName: a.HumanName})
+	}
+	staleNote = fmt.Sprintf("BeadHub unreachable - alias matched against the alias cache from %s", formatTimestamp(cache.FetchedAt))
+	return workspaces, staleNote, nil
 }
 
 // resolveAlias resolves a target string to a workspace.
@@ -53,7 +135,7 @@ type AliasResolution struct {
 // 2. Unique prefix match
 // 3. Unique substring match
 // Returns error with suggestions if ambiguous or not found.
-func resolveAlias(ctx context.Context, cfg *config.Config, httpClient *client.Client, target string) (*AliasResolution, error) {
+func resolveAlias(ctx context.Context, cfg *config.Config, httpClient BeadHubAPI, target string) (*AliasResolution, error) {
 	if target == "" {
 		return nil, fmt.Errorf("target alias cannot be empty")
 	}
@@ -67,26 +149,33 @@ func resolveAlias(ctx context.Context, cfg *config.Config, httpClient *client.Cl
 		}, nil
 	}
 
-	// Fetch all workspaces for this project (not just active ones with claims)
-	includePresence := false
-	resp, err := httpClient.Workspaces(ctx, &client.WorkspacesRequest{
-		IncludePresence: &includePresence,
-		Limit:           maxWorkspaceQueryLimit, // Get all workspaces for matching
-	})
+	workspaces, staleNote, err := aliasWorkspaces(ctx, httpClient)
 	if err != nil {
-		return nil, fmt.Errorf("fetching workspaces: %w", err)
+		return nil, err
 	}
-
-	if len(resp.Workspaces) == 0 {
+	if len(workspaces) == 0 {
 		return nil, fmt.Errorf("no workspaces found in project %q", cfg.ProjectSlug)
 	}
+	resolution, err := matchAlias(target, workspaces)
+	if err != nil {
+		if staleNote != "" {
+			return nil, fmt.Errorf("%w\n(%s)", err, staleNote)
+		}
+		return nil, err
+	}
+	resolution.StaleNote = staleNote
+	return resolution, nil
+}
 
+// matchAlias picks the workspace target refers to (exact, then unique
+// prefix, then unique substring match).
+func matchAlias(target string, workspaces []client.Workspace) (*AliasResolution, error) {
 	// Try matching in order of priority
 	targetLower := strings.ToLower(target)
 
 	// 1. Exact match
 	var exactMatches []AliasMatch
-	for _, ws := range resp.Workspaces {
+	for _, ws := range workspaces {
 		if strings.ToLower(ws.Alias) == targetLower {
 			exactMatches = append(exactMatches, AliasMatch{
 				WorkspaceID: ws.WorkspaceID,
@@ -110,7 +199,7 @@ func resolveAlias(ctx context.Context, cfg *config.Config, httpClient *client.Cl
 
 	// 2. Prefix match
 	var prefixMatches []AliasMatch
-	for _, ws := range resp.Workspaces {
+	for _, ws := range workspaces {
 		if strings.HasPrefix(strings.ToLower(ws.Alias), targetLower) {
 			prefixMatches = append(prefixMatches, AliasMatch{
 				WorkspaceID: ws.WorkspaceID,
@@ -134,7 +223,7 @@ func resolveAlias(ctx context.Context, cfg *config.Config, httpClient *client.Cl
 
 	// 3. Substring match
 	var substringMatches []AliasMatch
-	for _, ws := range resp.Workspaces {
+	for _, ws := range workspaces {
 		if strings.Contains(strings.ToLower(ws.Alias), targetLower) {
 			substringMatches = append(substringMatches, AliasMatch{
 				WorkspaceID: ws.WorkspaceID,
@@ -157,7 +246,7 @@ func resolveAlias(ctx context.Context, cfg *config.Config, httpClient *client.Cl
 	}
 
 	// No matches - suggest similar aliases
-	return nil, formatNotFoundError(target, resp.Workspaces)
+	return nil, formatNotFoundError(target, workspaces)
 }
 
 // formatAmbiguousError creates an error message for ambiguous matches.
@@ -272,3 +361,25 @@ func levenshteinDistance(a, b string) int {
 
 	return matrix[len(a)][len(b)]
 }
+
+// resolveQueuedMailAlias expands a partial alias for mail that is queued for
+// later delivery, using the alias cache when the server is unreachable.
+// Resolution is best-effort: without a workspace config or a unique match
+// the alias is kept as typed and the server decides at delivery time.
+func resolveQueuedMailAlias(ctx context.Context, target string) string {
+	if strings.Contains(target, "/") || isUUID(target) {
+		return target
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg.Validate() != nil {
+		return target
+	}
+	resolution, err := resolveAlias(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), target)
+	if err != nil || resolution.Alias == "" {
+		return target
+	}
+	if resolution.StaleNote != "" {
+		fmt.Fprintf(os.Stderr, "Note: %s\n", resolution.StaleNote)
+	}
+	return resolution.Alias
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// When the server answers 409 because our alias now belongs to another
+// workspace (usually after an admin cleaned up and someone re-registered the
+// name), every command would otherwise fail with the same opaque error.
+// bdh explains the situation instead and lets the user pick a new alias
+// inline with --:rename, which re-registers this workspace and updates
+// .beadhub before the command runs.
+
+// parseRename extracts --:rename <alias> (or --:rename=<alias>) from args.
+func parseRename(args []string) (cleanArgs []string, alias string, hasRename bool) {
+	cleanArgs = make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if strings.HasPrefix(arg, "--:rename=") {
+			hasRename = true
+			alias = strings.TrimPrefix(arg, "--:rename=")
+			continue
+		}
+
+		if arg == "--:rename" {
+			hasRename = true
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				alias = args[i+1]
+				i++
+			}
+			continue
+		}
+
+		cleanArgs = append(cleanArgs, arg)
+	}
+
+	return cleanArgs, strings.TrimSpace(alias), hasRename
+}
+
+// aliasConflictMessage explains a runtime alias conflict and how to recover.
+func aliasConflictMessage(alias string) string {
+	return fmt.Sprintf("alias '%s' now belongs to another workspace on BeadHub (it may have been cleaned up and re-registered) - "+
+		"re-run this command with --:rename <new-alias> to re-register this workspace", alias)
+}
+
+// renameWorkspace re-registers this workspace under newAlias and saves it to
+// .beadhub. Returns the alias the server assigned.
+func renameWorkspace(ctx context.Context, cfg *config.Config, c BeadHubAPI, newAlias string) (string, error) {
+	if newAlias == "" {
+		return "", fmt.Errorf("--:rename requires the new alias")
+	}
+	if !config.IsValidAlias(newAlias) {
+		return "", fmt.Errorf("invalid alias %q for --:rename", newAlias)
+	}
+	if cfg.ActiveOverride != "" {
+		return "", fmt.Errorf("cannot rename while project override %q is applied - run from outside %s", cfg.ActiveOverride, cfg.ActiveOverride)
+	}
+
+	hostname, _ := os.Hostname()
+	workspacePath, _ := os.Getwd()
+	repoOrigin := currentRepoOriginBestEffort(cfg)
+	if strings.TrimSpace(repoOrigin) == "" {
+		repoOrigin = cfg.RepoOrigin
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.RegisterWorkspace(ctx, &client.RegisterWorkspaceRequest{
+		RepoOrigin:    repoOrigin,
+		Alias:         newAlias,
+		Role:          cfg.Role,
+		Hostname:      hostname,
+		WorkspacePath: workspacePath,
+	})
+	if err != nil {
+		if client.IsAliasConflict(err) {
+			return "", fmt.Errorf("alias '%s' is also taken - pick another for --:rename", newAlias)
+		}
+		return "", fmt.Errorf("re-registering workspace: %w", err)
+	}
+	if strings.TrimSpace(resp.WorkspaceID) != "" && resp.WorkspaceID != cfg.WorkspaceID {
+		return "", fmt.Errorf(
+			"account/workspace mismatch: selected account agent_id=%q but .beadhub workspace_id=%q (check .aw/context)",
+			resp.WorkspaceID,
+			cfg.WorkspaceID,
+		)
+	}
+
+	alias := newAlias
+	if strings.TrimSpace(resp.Alias) != "" {
+		alias = resp.Alias
+	}
+	cfg.Alias = alias
+	if err := cfg.Save(); err != nil {
+		return "", fmt.Errorf("workspace renamed to '%s' on the server but saving .beadhub failed: %w", alias, err)
+	}
+	return alias, nil
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestParseRename(t *testing.T) {
+	args, alias, ok := parseRename([]string{"update", "bd-1", "--:rename", "alice-2", "--status", "open"})
+	if !ok || alias != "alice-2" || strings.Join(args, " ") != "update bd-1 --status open" {
+		t.Errorf("got %v, %q, %v", args, alias, ok)
+	}
+	if _, alias, ok := parseRename([]string{"ready", "--:rename=bob"}); !ok || alias != "bob" {
+		t.Errorf("= syntax: %q, %v", alias, ok)
+	}
+	if _, alias, ok := parseRename([]string{"ready", "--:rename", "--json"}); !ok || alias != "" {
+		t.Errorf("missing value: %q, %v", alias, ok)
+	}
+}
+
+func TestRenameWorkspace(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() { config.SetPath("") })
+	config.SetPath(".beadhub")
+
+	taken := map[string]bool{"bob": true}
+	var got client.RegisterWorkspaceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/workspaces/register" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		if taken[got.Alias] {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"detail":{"code":"alias_conflict"}}`))
+			return
+		}
+		json.NewEncoder(w).Encode(client.RegisterWorkspaceResponse{
+			WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+			Alias:       got.Alias,
+		})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:  server.URL,
+		ProjectSlug: "demo",
+		RepoOrigin:  "git@github.com:acme/demo.git",
+		Alias:       "alice",
+		HumanName:   "Alice",
+	}
+	c := client.New(server.URL)
+
+	if _, err := renameWorkspace(context.Background(), cfg, c, "bob"); err == nil || !strings.Contains(err.Error(), "also taken") {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := renameWorkspace(context.Background(), cfg, c, ""); err == nil {
+		t.Error("expected an error for an empty alias")
+	}
+
+	alias, err := renameWorkspace(context.Background(), cfg, c, "alice-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias != "alice-2" || cfg.Alias != "alice-2" || got.RepoOrigin != cfg.RepoOrigin {
+		t.Errorf("alias = %q, cfg.Alias = %q, request = %+v", alias, cfg.Alias, got)
+	}
+	saved, err := config.LoadFrom(".beadhub")
+	if err != nil || saved.Alias != "alice-2" {
+		t.Errorf("saved = %+v, %v", saved, err)
+	}
+}
+
+func TestAliasConflictMessage_SuggestsRename(t *testing.T) {
+	if msg := aliasConflictMessage("alice"); !strings.Contains(msg, "'alice'") || !strings.Contains(msg, "--:rename <new-alias>") {
+		t.Errorf("message = %q", msg)
+	}
+}
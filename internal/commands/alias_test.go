@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
 )
 
 func TestLevenshteinDistance(t *testing.T) {
@@ -164,3 +170,45 @@ func findSubstring(s, substr string) int {
 	}
 	return -1
 }
+
+func TestResolveAlias_FallsBackToAliasCache(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	t.Chdir(t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"workspaces": []map[string]any{
+			{"workspace_id": "ws-1", "alias": "backend-maria", "human_name": "Maria"},
+			{"workspace_id": "ws-2", "alias": "frontend-juan", "human_name": "Juan"},
+		}})
+	}))
+	cfg := &config.Config{BeadhubURL: server.URL, ProjectSlug: "demo"}
+	ctx := context.Background()
+
+	res, err := resolveAlias(ctx, cfg, newBeadHubClient(ctx, server.URL), "maria")
+	if err != nil || res.Alias != "backend-maria" || res.StaleNote != "" {
+		t.Fatalf("online: %+v, %v", res, err)
+	}
+
+	// Offline: the cached list still resolves, with a staleness note.
+	server.Close()
+	offline := newBeadHubClient(ctx, server.URL)
+	res, err = resolveAlias(ctx, cfg, offline, "juan")
+	if err != nil || res.Alias != "frontend-juan" || !strings.Contains(res.StaleNote, "alias cache") {
+		t.Fatalf("offline: %+v, %v", res, err)
+	}
+	if _, err := resolveAlias(ctx, cfg, offline, "nobody"); err == nil || !strings.Contains(err.Error(), "alias cache") {
+		t.Errorf("offline miss should mention the cache: %v", err)
+	}
+}
+
+func TestResolveAlias_NoCacheOffline(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	t.Chdir(t.TempDir())
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	cfg := &config.Config{BeadhubURL: server.URL}
+	if _, err := resolveAlias(context.Background(), cfg, newBeadHubClient(context.Background(), server.URL), "maria"); err == nil || !strings.Contains(err.Error(), "fetching workspaces") {
+		t.Errorf("expected a fetch error without a cache, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// The project announcement ("main is frozen for release until Friday")
+// arrives with the capabilities document. ready and :status show it as a
+// banner at the top of their output, once per day per announcement, which
+// is remembered in .beadhub-cache/announcement-seen.json. Capabilities are
+// otherwise cached for a day, so they are refetched for the banner when
+// older than announcementRefresh.
+
+const announcementRefresh = time.Hour
+
+var (
+	announceUntil string
+	announceJSON  bool
+)
+
+// AnnounceResult is the output of :announce commands.
+type AnnounceResult struct {
+	Announcement *client.Announcement `json:"announcement,omitempty"`
+	Cleared      bool                 `json:"cleared,omitempty"`
+}
+
+var announceCmd = &cobra.Command{
+	Use:   ":announce",
+	Short: "Show or set the project announcement banner",
+	Long: `Show or set the project announcement.
+
+The announcement is shown as a banner at the top of "bdh ready" and
+"bdh :status", once a day for each agent, until it expires or is cleared.
+Setting and clearing it is restricted to project admins by the server.
+
+--until accepts a duration (48h) or a local clock time (17:00).
+
+Examples:
+  bdh :announce
+  bdh :announce set "main is frozen for release until Friday" --until 72h
+  bdh :announce clear`,
+	Args: cobra.NoArgs,
+	RunE: runAnnounceShow,
+}
+
+var announceSetCmd = &cobra.Command{
+	Use:   "set <message>",
+	Short: "Set the project announcement (admins)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAnnounceSet,
+}
+
+var announceClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the project announcement (admins)",
+	Args:  cobra.NoArgs,
+	RunE:  runAnnounceClear,
+}
+
+func init() {
+	announceCmd.AddCommand(announceSetCmd)
+	announceCmd.AddCommand(announceClearCmd)
+	announceCmd.PersistentFlags().BoolVar(&announceJSON, "json", false, "Output as JSON")
+	announceSetCmd.Flags().StringVar(&announceUntil, "until", "", "When the announcement expires (duration or clock time)")
+}
+
+func runAnnounceShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	caps := refreshCapabilities(ctx, cfg)
+	result := &AnnounceResult{}
+	if caps != nil && activeAnnouncement(caps.Announcement, time.Now()) {
+		result.Announcement = caps.Announcement
+	}
+	fmt.Print(formatAnnounceOutput(result, announceJSON))
+	return nil
+}
+
+func runAnnounceSet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	message := strings.TrimSpace(args[0])
+	if message == "" {
+		return fmt.Errorf("announcement cannot be empty")
+	}
+	req := &client.SetAnnouncementRequest{Message: message, SetBy: cfg.Alias}
+	if announceUntil != "" {
+		until, err := parseFutureTime("--until", announceUntil, time.Now())
+		if err != nil {
+			return err
+		}
+		req.ExpiresAt = until.UTC().Format(time.RFC3339)
+	}
+	announcement, err := setAnnouncementWithConfig(ctx, cfg, req)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatAnnounceOutput(&AnnounceResult{Announcement: announcement}, announceJSON))
+	return nil
+}
+
+func runAnnounceClear(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if err := clearAnnouncementWithConfig(ctx, cfg); err != nil {
+		return err
+	}
+	fmt.Print(formatAnnounceOutput(&AnnounceResult{Cleared: true}, announceJSON))
+	return nil
+}
+
+func setAnnouncementWithConfig(ctx context.Context, cfg *config.Config, req *client.SetAnnouncementRequest) (*client.Announcement, error) {
+	if err := requireServerFeature(ctx, cfg, featureAnnouncements, "announcements"); err != nil {
+		return nil, err
+	}
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	announcement, err := c.SetAnnouncement(reqCtx, req)
+	if err != nil {
+		return nil, announceError(err)
+	}
+	refreshCapabilities(ctx, cfg)
+	return announcement, nil
+}
+
+func clearAnnouncementWithConfig(ctx context.Context, cfg *config.Config) error {
+	if err := requireServerFeature(ctx, cfg, featureAnnouncements, "announcements"); err != nil {
+		return err
+	}
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if err := c.ClearAnnouncement(reqCtx); err != nil {
+		return announceError(err)
+	}
+	refreshCapabilities(ctx, cfg)
+	return nil
+}
+
+func announceError(err error) error {
+	var clientErr *client.Error
+	if errors.As(err, &clientErr) {
+		if clientErr.StatusCode == 403 {
+			return fmt.Errorf("only project admins can change the announcement")
+		}
+		return fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+	}
+	return err
+}
+
+// activeAnnouncement reports whether a is set and not expired.
+func activeAnnouncement(a *client.Announcement, now time.Time) bool {
+	if a == nil || strings.TrimSpace(a.Message) == "" {
+		return false
+	}
+	if expires, ok := parseTimeBestEffort(a.ExpiresAt); ok && !now.Before(expires) {
+		return false
+	}
+	return true
+}
+
+// currentAnnouncement returns the active announcement of cfg's project,
+// refetching capabilities that are older than announcementRefresh.
+func currentAnnouncement(ctx context.Context, cfg *config.Config, now time.Time) *client.Announcement {
+	caps := serverCapabilities(ctx, cfg)
+	if caps == nil || caps.Legacy {
+		return nil
+	}
+	if fetchedAt, ok := parseTimeBestEffort(caps.FetchedAt); !ok || now.Sub(fetchedAt) > announcementRefresh {
+		caps = refreshCapabilities(ctx, cfg)
+	}
+	if caps == nil || !activeAnnouncement(caps.Announcement, now) {
+		return nil
+	}
+	return caps.Announcement
+}
+
+// announcementSeen records the announcement last shown as a banner.
+type announcementSeen struct {
+	Key     string `json:"key"`
+	ShownOn string `json:"shown_on"`
+}
+
+func announcementKey(a *client.Announcement) string {
+	sum := sha256.Sum256([]byte(a.SetAt + "\n" + a.Message))
+	return hex.EncodeToString(sum[:8])
+}
+
+func announcementSeenPath() (string, error) {
+	return cacheFilePath("announcement-seen.json")
+}
+
+// announcementBanner returns the announcement to show as a banner, or nil
+// when there is none or it was already shown today. Showing it marks it as
+// seen for the day.
+func announcementBanner(ctx context.Context, cfg *config.Config, now time.Time) *client.Announcement {
+	a := currentAnnouncement(ctx, cfg, now)
+	if a == nil {
+		return nil
+	}
+	path, err := announcementSeenPath()
+	if err != nil {
+		return a
+	}
+	today := now.Local().Format("2006-01-02")
+	key := announcementKey(a)
+	var seen announcementSeen
+	if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &seen) == nil {
+		if seen.Key == key && seen.ShownOn == today {
+			return nil
+		}
+	}
+	if ensureCacheDir(path) == nil {
+		if data, err := json.Marshal(announcementSeen{Key: key, ShownOn: today}); err == nil {
+			_ = os.WriteFile(path, append(data, '\n'), 0600)
+		}
+	}
+	return a
+}
+
+// formatAnnouncementBanner renders the banner shown above ready and :status.
+func formatAnnouncementBanner(a *client.Announcement) string {
+	if a == nil {
+		return ""
+	}
+	line := "ANNOUNCEMENT: " + a.Message
+	var meta []string
+	if a.SetBy != "" {
+		meta = append(meta, "from "+a.SetBy)
+	}
+	if a.ExpiresAt != "" {
+		meta = append(meta, "until "+formatClockTime(a.ExpiresAt))
+	}
+	if len(meta) > 0 {
+		line += " (" + strings.Join(meta, ", ") + ")"
+	}
+	return line + "\n\n"
+}
+
+func formatAnnounceOutput(result *AnnounceResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	if result.Cleared {
+		return "Announcement cleared.\n"
+	}
+	if result.Announcement == nil {
+		return "No announcement.\n"
+	}
+	return strings.TrimSuffix(formatAnnouncementBanner(result.Announcement), "\n")
+}
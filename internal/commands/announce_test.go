@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestAnnouncementBanner_OncePerDay(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	message := "main is frozen for release until Friday"
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]any{
+			"features":     map[string]bool{featureAnnouncements: true},
+			"announcement": map[string]any{"message": message, "set_by": "lead", "set_at": "2025-06-01T09:00:00Z"},
+		})
+	}))
+	defer server.Close()
+	cfg := &config.Config{BeadhubURL: server.URL}
+	now := time.Now()
+
+	a := announcementBanner(context.Background(), cfg, now)
+	if a == nil || a.Message != message {
+		t.Fatalf("first banner = %+v", a)
+	}
+	if out := formatAnnouncementBanner(a); !strings.Contains(out, "ANNOUNCEMENT: "+message+" (from lead)") {
+		t.Errorf("banner = %q", out)
+	}
+	if a := announcementBanner(context.Background(), cfg, now); a != nil {
+		t.Errorf("banner should be shown once per day, got %+v", a)
+	}
+	if a := currentAnnouncement(context.Background(), cfg, now); a == nil {
+		t.Error("the announcement itself stays available (JSON output)")
+	}
+	if a := announcementBanner(context.Background(), cfg, now.Add(24*time.Hour)); a == nil {
+		t.Error("banner should be shown again the next day")
+	}
+
+	// A new announcement is shown right away.
+	message = "main is open again"
+	resetCapabilitiesMemo()
+	if a := announcementBanner(context.Background(), cfg, now.Add(26*time.Hour)); a == nil || a.Message != message {
+		t.Errorf("new announcement = %+v", a)
+	}
+	if fetches < 2 {
+		t.Errorf("stale capabilities should be refetched for the banner, fetches = %d", fetches)
+	}
+}
+
+func TestActiveAnnouncement(t *testing.T) {
+	now := time.Now()
+	if activeAnnouncement(nil, now) || activeAnnouncement(&client.Announcement{Message: " "}, now) {
+		t.Error("empty announcements are not active")
+	}
+	expired := &client.Announcement{Message: "freeze", ExpiresAt: now.Add(-time.Minute).UTC().Format(time.RFC3339)}
+	if activeAnnouncement(expired, now) {
+		t.Error("expired announcement should not be active")
+	}
+	expired.ExpiresAt = now.Add(time.Hour).UTC().Format(time.RFC3339)
+	if !activeAnnouncement(expired, now) {
+		t.Error("announcement before its expiry should be active")
+	}
+}
+
+func TestSetAnnouncement_RequiresAdmin(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/capabilities":
+			json.NewEncoder(w).Encode(map[string]any{"features": map[string]bool{featureAnnouncements: true}})
+		case "/v1/announcement":
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	_, err := setAnnouncementWithConfig(context.Background(), &config.Config{BeadhubURL: server.URL}, &client.SetAnnouncementRequest{Message: "freeze"})
+	if err == nil || !strings.Contains(err.Error(), "only project admins") {
+		t.Errorf("err = %v", err)
+	}
+}
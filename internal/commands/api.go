@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :api is the escape hatch for server features bdh has no command for yet:
+// any endpoint, called with the workspace's auth, with the JSON response
+// pretty-printed. --paginate follows next_cursor (or a relative next link)
+// and merges the list fields of every page.
+
+// maxAPIPages bounds how many pages --paginate fetches.
+const maxAPIPages = 100
+
+var apiMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+var (
+	apiData     string
+	apiPaginate bool
+	apiRaw      bool
+)
+
+var apiCmd = &cobra.Command{
+	Use:   ":api [method] <path>",
+	Short: "Call any BeadHub API endpoint with this workspace's auth",
+	Long: `Send a request to a BeadHub endpoint using the configured server and API
+key, and print the JSON response. The method defaults to GET.
+
+The path is relative to the server (it must start with /) and may include a
+query string. Quote it in the shell when it contains ? or &.
+
+--data sends a JSON body: inline, @file to read a file, or @- to read stdin.
+--paginate follows next_cursor across pages (GET only) and prints one
+document with the list fields of all pages merged.
+
+Examples:
+  bdh :api '/v1/workspaces?limit=5'
+  bdh :api GET /v1/reservations/history --paginate
+  bdh :api POST /v1/announcements --data '{"message": "Deploy at 15:00"}'`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAPI,
+}
+
+func init() {
+	apiCmd.Flags().StringVarP(&apiData, "data", "d", "", "JSON request body (@file reads a file, @- reads stdin)")
+	apiCmd.Flags().BoolVar(&apiPaginate, "paginate", false, "Follow next_cursor and merge all pages (GET only)")
+	apiCmd.Flags().BoolVar(&apiRaw, "raw", false, "Print the response body as received")
+}
+
+func runAPI(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	method, path, err := parseAPIArgs(args)
+	if err != nil {
+		return err
+	}
+	body, err := readAPIData(apiData, os.Stdin)
+	if err != nil {
+		return err
+	}
+	if apiPaginate && method != http.MethodGet {
+		return fmt.Errorf("--paginate only works with GET")
+	}
+
+	beadhubURL := ""
+	if cfg, err := config.Load(); err == nil {
+		beadhubURL = cfg.BeadhubURL
+	}
+	c, err := newBeadHubClientRequired(ctx, beadhubURL)
+	if err != nil {
+		return err
+	}
+
+	out, err := callAPI(ctx, c, method, path, body, apiPaginate)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatAPIOutput(out, apiRaw))
+	return nil
+}
+
+// parseAPIArgs returns the method and path of `:api [method] <path>`.
+func parseAPIArgs(args []string) (string, string, error) {
+	method, path := http.MethodGet, args[0]
+	if len(args) == 2 {
+		method, path = strings.ToUpper(args[0]), args[1]
+	}
+	if !apiMethods[method] {
+		return "", "", fmt.Errorf("unsupported method %q (use GET, POST, PUT, PATCH or DELETE)", method)
+	}
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return "", "", fmt.Errorf("path must start with / and be relative to the BeadHub server, got %q", path)
+	}
+	return method, path, nil
+}
+
+// readAPIData returns the --data body, or nil when none was given. The body
+// must be valid JSON.
+func readAPIData(data string, stdin io.Reader) ([]byte, error) {
+	if data == "" {
+		return nil, nil
+	}
+	body := []byte(data)
+	switch {
+	case data == "@-":
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body from stdin: %w", err)
+		}
+		body = b
+	case strings.HasPrefix(data, "@"):
+		b, err := os.ReadFile(data[1:])
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+		body = b
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("--data is not valid JSON")
+	}
+	return body, nil
+}
+
+// callAPI sends the request and, with paginate, fetches the following pages
+// and merges them into the first.
+func callAPI(ctx context.Context, c BeadHubAPI, method, path string, body []byte, paginate bool) ([]byte, error) {
+	page, err := callAPIOnce(ctx, c, method, path, body)
+	if err != nil || !paginate {
+		return page, err
+	}
+
+	var merged map[string]any
+	if err := json.Unmarshal(page, &merged); err != nil {
+		// Not an object: nothing to follow.
+		return page, nil
+	}
+	for i := 1; i < maxAPIPages; i++ {
+		next := nextAPIPage(path, merged)
+		if next == "" {
+			return json.Marshal(merged)
+		}
+		page, err := callAPIOnce(ctx, c, method, next, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching page %d: %w", i+1, err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(page, &doc); err != nil {
+			return nil, fmt.Errorf("page %d is not a JSON object", i+1)
+		}
+		mergeAPIPage(merged, doc)
+	}
+	return nil, fmt.Errorf("stopped after %d pages; narrow the query", maxAPIPages)
+}
+
+func callAPIOnce(ctx context.Context, c BeadHubAPI, method, path string, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.Raw(ctx, method, path, body)
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, apiErrorDetail(clientErr.Body))
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// nextAPIPage returns the path of the page after doc, or "" on the last
+// page. Servers paginate with next_cursor (sent back as ?cursor=) or with a
+// relative next link.
+func nextAPIPage(path string, doc map[string]any) string {
+	if hasMore, ok := doc["has_more"].(bool); ok && !hasMore {
+		return ""
+	}
+	if cursor, _ := doc["next_cursor"].(string); cursor != "" {
+		u, err := url.Parse(path)
+		if err != nil {
+			return ""
+		}
+		q := u.Query()
+		q.Set("cursor", cursor)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	if next, _ := doc["next"].(string); strings.HasPrefix(next, "/") && !strings.HasPrefix(next, "//") {
+		return next
+	}
+	return ""
+}
+
+// mergeAPIPage appends the list fields of page to merged and takes its
+// other fields (cursors, counts), so merged describes the last page fetched.
+func mergeAPIPage(merged, page map[string]any) {
+	for key, value := range page {
+		list, isList := value.([]any)
+		prev, wasList := merged[key].([]any)
+		if isList && wasList {
+			merged[key] = append(prev, list...)
+			continue
+		}
+		merged[key] = value
+	}
+	// Cursors of the last page must not survive from an earlier one.
+	for _, key := range []string{"next_cursor", "next"} {
+		if _, ok := page[key]; !ok {
+			delete(merged, key)
+		}
+	}
+}
+
+// apiErrorDetail extracts the message from an error body ({"detail": ...}
+// or {"error": ...}), or returns the body itself.
+func apiErrorDetail(body string) string {
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(body), &doc); err == nil {
+		for _, key := range []string{"detail", "error", "message"} {
+			if msg, ok := doc[key].(string); ok && msg != "" {
+				return msg
+			}
+		}
+		var compact bytes.Buffer
+		if json.Compact(&compact, []byte(body)) == nil {
+			return compact.String()
+		}
+	}
+	return strings.TrimSpace(body)
+}
+
+// formatAPIOutput pretty-prints JSON bodies unless raw is set. Other bodies
+// are printed as they are.
+func formatAPIOutput(body []byte, raw bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var pretty bytes.Buffer
+	if !raw && json.Indent(&pretty, body, "", "  ") == nil {
+		return pretty.String() + "\n"
+	}
+	if body[len(body)-1] != '\n' {
+		return string(body) + "\n"
+	}
+	return string(body)
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestParseAPIArgs(t *testing.T) {
+	if method, path, err := parseAPIArgs([]string{"/v1/workspaces?limit=5"}); err != nil || method != "GET" || path != "/v1/workspaces?limit=5" {
+		t.Errorf("got %s %s, %v", method, path, err)
+	}
+	if method, _, err := parseAPIArgs([]string{"patch", "/v1/x"}); err != nil || method != "PATCH" {
+		t.Errorf("got %s, %v", method, err)
+	}
+	for _, bad := range [][]string{{"v1/x"}, {"https://evil.example/v1/x"}, {"//evil.example/v1"}, {"TRACE", "/v1/x"}} {
+		if _, _, err := parseAPIArgs(bad); err == nil {
+			t.Errorf("parseAPIArgs(%v) should fail", bad)
+		}
+	}
+}
+
+func TestReadAPIData(t *testing.T) {
+	body, err := readAPIData("@-", strings.NewReader(`{"a": 1}`))
+	if err != nil || string(body) != `{"a": 1}` {
+		t.Errorf("stdin: %q, %v", body, err)
+	}
+	if _, err := readAPIData("{not json", nil); err == nil {
+		t.Error("invalid JSON should fail")
+	}
+	if body, err := readAPIData("", nil); body != nil || err != nil {
+		t.Errorf("no data: %q, %v", body, err)
+	}
+}
+
+func TestCallAPI_Paginates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer aw_sk_test" {
+			t.Errorf("missing auth")
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			if r.URL.Query().Get("limit") != "2" {
+				t.Errorf("query lost: %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"events": []string{"a", "b"}, "next_cursor": "c2", "has_more": true})
+		case "c2":
+			if r.URL.Query().Get("limit") != "2" {
+				t.Errorf("query lost on page 2: %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"events": []string{"c"}, "has_more": false})
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithAPIKey(server.URL, "aw_sk_test")
+	out, err := callAPI(context.Background(), c, "GET", "/v1/reservations/history?limit=2", nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Events     []string `json:"events"`
+		NextCursor *string  `json:"next_cursor"`
+		HasMore    bool     `json:"has_more"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(doc.Events, ",") != "a,b,c" || doc.NextCursor != nil || doc.HasMore {
+		t.Errorf("merged = %s", out)
+	}
+}
+
+func TestCallAPI_FormatsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"detail": "Not allowed for this project"}`))
+	}))
+	defer server.Close()
+
+	_, err := callAPI(context.Background(), client.New(server.URL), "DELETE", "/v1/projects/p1", nil, false)
+	if err == nil || err.Error() != "BeadHub error (403): Not allowed for this project" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestFormatAPIOutput(t *testing.T) {
+	if got := formatAPIOutput([]byte(`{"a":[1,2]}`), false); got != "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n" {
+		t.Errorf("pretty = %q", got)
+	}
+	if got := formatAPIOutput([]byte(`{"a":1}`), true); got != "{\"a\":1}\n" {
+		t.Errorf("raw = %q", got)
+	}
+	if got := formatAPIOutput([]byte("plain text"), false); got != "plain text\n" {
+		t.Errorf("text = %q", got)
+	}
+}
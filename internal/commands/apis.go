@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// Commands reach the servers through BeadHubAPI and AwebAPI rather than the
+// concrete clients. newBeadHubClient and newAwebClient hand out HTTP clients
+// unless implementations were installed in the command context with
+// withAPIs, which is how tests substitute fakes (see clienttest) and
+// how other transports can be plugged in.
+
+// BeadHubAPI is the BeadHub server as commands use it.
+type BeadHubAPI interface {
+	client.API
+}
+
+// AwebAPI is the part of the aweb server commands use.
+type AwebAPI interface {
+	Introspect(ctx context.Context) (*aweb.IntrospectResponse, error)
+	ListAgents(ctx context.Context) (*aweb.ListAgentsResponse, error)
+
+	SendMessage(ctx context.Context, req *aweb.SendMessageRequest) (*aweb.SendMessageResponse, error)
+	Inbox(ctx context.Context, p aweb.InboxParams) (*aweb.InboxResponse, error)
+	AckMessage(ctx context.Context, messageID string) (*aweb.AckResponse, error)
+
+	ChatPending(ctx context.Context) (*aweb.ChatPendingResponse, error)
+	ChatHistory(ctx context.Context, p aweb.ChatHistoryParams) (*aweb.ChatHistoryResponse, error)
+	ChatSendMessage(ctx context.Context, sessionID string, req *aweb.ChatSendMessageRequest) (*aweb.ChatSendMessageResponse, error)
+	ChatListSessions(ctx context.Context) (*aweb.ChatListSessionsResponse, error)
+
+	ReservationAcquire(ctx context.Context, req *aweb.ReservationAcquireRequest) (*aweb.ReservationAcquireResponse, error)
+	ReservationRenew(ctx context.Context, req *aweb.ReservationRenewRequest) (*aweb.ReservationRenewResponse, error)
+	ReservationRelease(ctx context.Context, req *aweb.ReservationReleaseRequest) (*aweb.ReservationReleaseResponse, error)
+	ReservationList(ctx context.Context, prefix string) (*aweb.ReservationListResponse, error)
+}
+
+var (
+	_ BeadHubAPI = (*client.Client)(nil)
+	_ AwebAPI    = (*aweb.Client)(nil)
+)
+
+type apisKey struct{}
+
+type injectedAPIs struct {
+	beadhub BeadHubAPI
+	aweb    AwebAPI
+}
+
+// withAPIs returns a copy of ctx under which newBeadHubClient and
+// newAwebClient return bh and aw. A nil API leaves that server on HTTP.
+func withAPIs(ctx context.Context, bh BeadHubAPI, aw AwebAPI) context.Context {
+	return context.WithValue(ctx, apisKey{}, &injectedAPIs{beadhub: bh, aweb: aw})
+}
+
+func injectedBeadHubAPI(ctx context.Context) BeadHubAPI {
+	if apis, ok := ctx.Value(apisKey{}).(*injectedAPIs); ok {
+		return apis.beadhub
+	}
+	return nil
+}
+
+func injectedAwebAPI(ctx context.Context) AwebAPI {
+	if apis, ok := ctx.Value(apisKey{}).(*injectedAPIs); ok {
+		return apis.aweb
+	}
+	return nil
+}
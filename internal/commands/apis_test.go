@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// withFakeBeadHub returns a command context that uses fake as the BeadHub
+// server.
+func withFakeBeadHub(fake *clienttest.Fake) context.Context {
+	return withAPIs(context.Background(), fake, nil)
+}
+
+func TestWithAPIs_UsesInjectedBeadHub(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	fake := clienttest.New()
+	fake.EnableFeatures(featureActivity)
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-b", Alias: "bob", LastSeen: "2025-06-15T11:00:00Z"})
+	ctx := withFakeBeadHub(fake)
+
+	if c, err := newBeadHubClientRequired(ctx, ""); err != nil || c != BeadHubAPI(fake) {
+		t.Fatalf("expected the fake, got %T %v", c, err)
+	}
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid"}
+	if !serverSupports(ctx, cfg, featureActivity) || serverSupports(ctx, cfg, featureReservationTakeover) {
+		t.Error("capabilities should come from the fake")
+	}
+	if got := holderLastSeen(ctx, newBeadHubClient(ctx, ""), "bob"); got != "2025-06-15T11:00:00Z" {
+		t.Errorf("holderLastSeen = %q", got)
+	}
+	if calls := fake.Calls("Workspaces"); len(calls) != 1 {
+		t.Errorf("expected one Workspaces call, got %+v", calls)
+	}
+
+	// Without an injected aweb API, aweb stays on HTTP.
+	if aw, err := newAwebClient(ctx, "http://beadhub.invalid"); err != nil || aw == nil {
+		t.Errorf("expected an HTTP aweb client, got %v %v", aw, err)
+	}
+}
+
+func TestRequestTakeovers_FakeServer(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	fake := clienttest.New()
+	fake.EnableFeatures(featureReservationTakeover)
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-b", Alias: "bob", LastSeen: now.Add(-10 * time.Minute).Format(time.RFC3339)})
+	ctx := withFakeBeadHub(fake)
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "me", WorkspaceID: "ws-me"}
+	outcomes := requestTakeovers(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), []ReservationConflict{{ResourceKey: "web/app.ts", HeldBy: "bob"}}, now)
+	if len(outcomes) != 1 || outcomes[0].Status != takeoverNotRequested {
+		t.Errorf("bob was seen recently; expected no takeover, got %+v", outcomes)
+	}
+	if calls := fake.Calls("RequestTakeover"); len(calls) != 0 {
+		t.Errorf("unexpected takeover requests: %+v", calls)
+	}
+}
+
+func TestWithAPIs_ResolvedCredentialsUseInjected(t *testing.T) {
+	fake := clienttest.New()
+	aw := &staleMailStub{}
+	ctx := withAPIs(context.Background(), fake, aw)
+
+	if c := beadHubClientFor(ctx, "http://beadhub.invalid", "aw_sk_x"); c != BeadHubAPI(fake) {
+		t.Errorf("beadHubClientFor = %T, want the fake", c)
+	}
+	if c, err := awebClientFor(ctx, "http://beadhub.invalid", "aw_sk_x"); err != nil || c != AwebAPI(aw) {
+		t.Errorf("awebClientFor = %T %v, want the stub", c, err)
+	}
+	// The aw/chat helpers need the concrete client; a stub must not be
+	// swapped for a real connection.
+	if _, err := newAwebChatClientRequired(ctx, "http://beadhub.invalid"); err == nil {
+		t.Error("expected an error for a non-HTTP aweb API")
+	}
+
+	httpClient, err := aweb.New("http://aweb.invalid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = withAPIs(context.Background(), nil, httpClient)
+	if c, err := newAwebChatClient(ctx, "http://beadhub.invalid"); err != nil || c != httpClient {
+		t.Errorf("newAwebChatClient = %v %v, want the injected client", c, err)
+	}
+}
@@ -0,0 +1,401 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Supervised workspaces (supervision in .beadhub) park commands they may not
+// run on their own as approval requests on BeadHub: commands BeadHub
+// rejects, with supervision.approvals, and the commands listed in
+// supervision.high_risk. A human runs bdh :approve from their own workspace
+// to grant or deny them; only a workspace with an approver role
+// (supervision.approver_roles, default supervisor) may, and never the
+// workspace that parked the command. BeadHub enforces both when the
+// decision is posted; the checks bdh makes first from the local .beadhub
+// are advisory and only save a round trip. The parked command is recorded in
+// .beadhub-cache/approvals.json, so the agent's next run of the same command
+// picks up the verdict: a grant lets it run once, a denial is reported and
+// forgotten. --:wait-approval <duration> waits for the verdict instead.
+
+var approvalPollInterval = 5 * time.Second
+
+// TrackedApproval is a command parked by this workspace.
+type TrackedApproval struct {
+	ApprovalID string `json:"approval_id"`
+	Command    string `json:"command"`
+	CreatedAt  string `json:"created_at"`
+}
+
+var (
+	approveJSON bool
+	approveAll  bool
+	approveNote string
+)
+
+var approveCmd = &cobra.Command{
+	Use:   ":approve",
+	Short: "Grant or deny commands parked for a supervisor",
+	Long: `Review the commands agents parked for approval (supervision in their
+.beadhub) and release or refuse them. The agent's next run of the command
+picks up the verdict.
+
+Deciding needs a workspace whose role is listed in supervision.approver_roles
+of its .beadhub (default: supervisor), and a workspace cannot decide its own
+requests.
+
+Examples:
+  bdh :approve list
+  bdh :approve grant ap-12
+  bdh :approve deny ap-13 --note "close it after the release"`,
+}
+
+var approveListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pending approval requests",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		c, err := approvalClient(ctx)
+		if err != nil {
+			return err
+		}
+		status := client.ApprovalPending
+		if approveAll {
+			status = ""
+		}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		defer cancel()
+		resp, err := c.ListApprovals(ctx, &client.ListApprovalsRequest{Status: status})
+		if err != nil {
+			return approvalAPIError(err)
+		}
+		fmt.Print(formatApprovalList(resp.Approvals, approveJSON, time.Now()))
+		return nil
+	},
+}
+
+var approveGrantCmd = &cobra.Command{
+	Use:   "grant <approval-id>",
+	Short: "Let the agent run a parked command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		return runApproveDecision(ctx, args[0], client.ApprovalGranted)
+	},
+}
+
+var approveDenyCmd = &cobra.Command{
+	Use:   "deny <approval-id>",
+	Short: "Refuse a parked command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		return runApproveDecision(ctx, args[0], client.ApprovalDenied)
+	},
+}
+
+func init() {
+	approveCmd.PersistentFlags().BoolVar(&approveJSON, "json", false, "Output as JSON")
+	approveListCmd.Flags().BoolVar(&approveAll, "all", false, "Include decided requests")
+	approveGrantCmd.Flags().StringVar(&approveNote, "note", "", "Note for the agent")
+	approveDenyCmd.Flags().StringVar(&approveNote, "note", "", "Note for the agent (why)")
+
+	approveCmd.AddCommand(approveListCmd)
+	approveCmd.AddCommand(approveGrantCmd)
+	approveCmd.AddCommand(approveDenyCmd)
+}
+
+func approvalClient(ctx context.Context) (BeadHubAPI, error) {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireServerFeature(ctx, cfg, featureApprovals, "approval requests"); err != nil {
+		return nil, err
+	}
+	return newBeadHubClient(ctx, cfg.BeadhubURL), nil
+}
+
+func runApproveDecision(ctx context.Context, approvalID, status string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireServerFeature(ctx, cfg, featureApprovals, "approval requests"); err != nil {
+		return err
+	}
+	approval, err := decideApproval(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), strings.TrimSpace(approvalID), status, strings.TrimSpace(approveNote))
+	if err != nil {
+		return err
+	}
+	if approveJSON {
+		fmt.Print(marshalJSONOrFallback(approval))
+		return nil
+	}
+	verb := "Granted"
+	if status == client.ApprovalDenied {
+		verb = "Denied"
+	}
+	fmt.Printf("%s %s: %s will see it on its next run of `%s`\n", verb, approval.ApprovalID, approval.Alias, approval.CommandLine)
+	return nil
+}
+
+// decideApproval records cfg's verdict on an approval request after checking
+// that cfg may decide it: an approver role, and not the requester itself,
+// which would let an agent release its own parked commands.
+// decideApproval posts a verdict. The role and self-decision checks read
+// the local .beadhub, so they only fail fast; the server authorizes the
+// decision and answers 403 when this workspace may not make it.
+func decideApproval(ctx context.Context, cfg *config.Config, c BeadHubAPI, approvalID, status, note string) (*client.Approval, error) {
+	if !containsString(cfg.ApproverRoles(), config.NormalizeRole(cfg.Role)) {
+		role := cfg.Role
+		if role == "" {
+			role = "none"
+		}
+		return nil, fmt.Errorf("only a workspace with role %s can decide approval requests (this workspace's role: %s)",
+			strings.Join(cfg.ApproverRoles(), " or "), role)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	approval, err := c.GetApproval(ctx, approvalID)
+	if err != nil {
+		return nil, approvalAPIError(err)
+	}
+	if approval.WorkspaceID == cfg.WorkspaceID || strings.EqualFold(approval.Alias, cfg.Alias) {
+		return nil, fmt.Errorf("%s was parked by this workspace (%s) - another workspace must decide it", approval.ApprovalID, approval.Alias)
+	}
+
+	approval, err = c.DecideApproval(ctx, approvalID, &client.DecideApprovalRequest{
+		Status:      status,
+		DecidedBy:   cfg.Alias,
+		WorkspaceID: cfg.WorkspaceID,
+		Role:        config.NormalizeRole(cfg.Role),
+		Note:        note,
+	})
+	if err != nil {
+		return nil, approvalAPIError(err)
+	}
+	return approval, nil
+}
+
+func approvalAPIError(err error) error {
+	var clientErr *client.Error
+	if errors.As(err, &clientErr) {
+		switch clientErr.StatusCode {
+		case http.StatusForbidden:
+			return fmt.Errorf("BeadHub refused the decision: %s", apiErrorDetail(clientErr.Body))
+		case http.StatusNotFound:
+			return fmt.Errorf("no such approval request")
+		case http.StatusConflict:
+			return fmt.Errorf("approval request was already decided")
+		}
+		return fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+	}
+	return err
+}
+
+func formatApprovalList(approvals []client.Approval, asJSON bool, now time.Time) string {
+	if asJSON {
+		if approvals == nil {
+			approvals = []client.Approval{}
+		}
+		return marshalJSONOrFallback(approvals)
+	}
+	if len(approvals) == 0 {
+		return "No approval requests waiting.\n"
+	}
+	var sb strings.Builder
+	for _, a := range approvals {
+		sb.WriteString(fmt.Sprintf("%s  %s  %s  `%s`\n", a.ApprovalID, a.Alias, formatTimeAgoAt(a.CreatedAt, now), a.CommandLine))
+		if a.Reason != "" {
+			sb.WriteString(fmt.Sprintf("    why parked: %s\n", a.Reason))
+		}
+		if a.Status != client.ApprovalPending {
+			sb.WriteString(fmt.Sprintf("    %s by %s", a.Status, a.DecidedBy))
+			if a.Note != "" {
+				sb.WriteString(": " + a.Note)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// highRiskCommand returns the supervision.high_risk entry args match, if any.
+func highRiskCommand(cfg *config.Config, args []string) string {
+	for _, entry := range cfg.HighRiskCommands() {
+		words := strings.Fields(entry)
+		if len(words) == 0 || len(words) > len(args) {
+			continue
+		}
+		match := true
+		for i, w := range words {
+			if args[i] != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return entry
+		}
+	}
+	return ""
+}
+
+// superviseCommand parks the command for a supervisor when it needs one and
+// applies any verdict already given. On return result.Rejected tells whether
+// bd may run; result.Approval is the request involved.
+func superviseCommand(ctx context.Context, cfg *config.Config, c BeadHubAPI, args []string, commandLine string, result *PassthroughResult, wait time.Duration) {
+	risky := highRiskCommand(cfg, args)
+	if !(result.Rejected && cfg.ApprovalsEnabled()) && risky == "" {
+		return
+	}
+	reason := result.RejectionReason
+	if reason == "" {
+		reason = fmt.Sprintf("%s is a high-risk command and needs a supervisor's approval", risky)
+	}
+	result.Rejected, result.RejectionReason = true, reason
+	if !serverSupports(ctx, cfg, featureApprovals) {
+		if risky != "" {
+			result.RejectionReason += " - BeadHub does not support approval requests"
+		}
+		return
+	}
+
+	key := strings.Join(args, " ")
+	path, pathErr := approvalsPath()
+	var tracked []TrackedApproval
+	if pathErr == nil {
+		tracked, _ = loadTrackedApprovals(path)
+	}
+	var approval *client.Approval
+	for i, t := range tracked {
+		if t.Command != key {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		current, err := c.GetApproval(ctx, t.ApprovalID)
+		cancel()
+		var clientErr *client.Error
+		switch {
+		case err == nil:
+			approval = current
+		case errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound:
+			tracked = append(tracked[:i], tracked[i+1:]...)
+		default:
+			result.RejectionReason = fmt.Sprintf("%s (could not check approval %s: %v)", reason, t.ApprovalID, err)
+			return
+		}
+		break
+	}
+
+	if approval == nil {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		created, err := c.RequestApproval(ctx, &client.RequestApprovalRequest{
+			WorkspaceID: cfg.WorkspaceID,
+			Alias:       cfg.Alias,
+			CommandLine: commandLine,
+			Reason:      reason,
+		})
+		cancel()
+		if err != nil {
+			result.RejectionReason = fmt.Sprintf("%s (could not park it for approval: %v)", reason, err)
+			return
+		}
+		approval = created
+		tracked = append(tracked, TrackedApproval{ApprovalID: created.ApprovalID, Command: key, CreatedAt: time.Now().UTC().Format(time.RFC3339)})
+	}
+
+	if approval.Status == client.ApprovalPending && wait > 0 {
+		approval = waitForApproval(ctx, c, approval, wait)
+	}
+	result.Approval = approval
+	if approval.Status != client.ApprovalPending {
+		// A verdict is used once: the next run asks again
+		tracked = removeTrackedApproval(tracked, approval.ApprovalID)
+	}
+	if approval.Status == client.ApprovalGranted {
+		result.Rejected, result.RejectionReason = false, ""
+	}
+	if pathErr == nil {
+		_ = saveTrackedApprovals(path, tracked)
+	}
+}
+
+// waitForApproval polls a pending approval until it is decided or wait
+// passes. Returns the latest state seen.
+func waitForApproval(ctx context.Context, c BeadHubAPI, approval *client.Approval, wait time.Duration) *client.Approval {
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return approval
+		case <-deadline.C:
+			return approval
+		case <-time.After(approvalPollInterval):
+		}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		current, err := c.GetApproval(ctx, approval.ApprovalID)
+		cancel()
+		if err != nil {
+			continue
+		}
+		approval = current
+		if approval.Status != client.ApprovalPending {
+			return approval
+		}
+	}
+}
+
+func removeTrackedApproval(tracked []TrackedApproval, approvalID string) []TrackedApproval {
+	var kept []TrackedApproval
+	for _, t := range tracked {
+		if t.ApprovalID != approvalID {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+func approvalsPath() (string, error) {
+	return cacheFilePath("approvals.json")
+}
+
+func loadTrackedApprovals(path string) ([]TrackedApproval, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var tracked []TrackedApproval
+	if err := json.Unmarshal(data, &tracked); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return tracked, nil
+}
+
+func saveTrackedApprovals(path string, tracked []TrackedApproval) error {
+	if len(tracked) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONAtomic(path, tracked)
+}
@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func supervisedConfig(approvals bool, highRisk ...string) *config.Config {
+	return &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "claude-be", WorkspaceID: "ws-1",
+		Supervision: &config.SupervisionConfig{Approvals: &approvals, HighRisk: highRisk}}
+}
+
+func TestSuperviseCommand_ParkThenGrant(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+	fake := clienttest.New()
+	fake.EnableFeatures(featureApprovals)
+	ctx := withFakeBeadHub(fake)
+	cfg := supervisedConfig(true)
+	args := []string{"update", "bd-1", "--status", "in_progress"}
+
+	result := &PassthroughResult{Rejected: true, RejectionReason: "bd-1 is claimed by alice"}
+	superviseCommand(ctx, cfg, fake, args, "update bd-1 --status in_progress", result, 0)
+	if !result.Rejected || result.Approval == nil || result.Approval.Status != client.ApprovalPending {
+		t.Fatalf("expected the command parked, got %+v", result)
+	}
+	id := result.Approval.ApprovalID
+	if out := formatPassthroughOutput(result); !strings.Contains(out, "bdh :approve grant "+id) {
+		t.Errorf("expected a hint to grant %s:\n%s", id, out)
+	}
+
+	// Running it again while pending does not park it twice
+	result = &PassthroughResult{Rejected: true, RejectionReason: "bd-1 is claimed by alice"}
+	superviseCommand(ctx, cfg, fake, args, "update bd-1 --status in_progress", result, 0)
+	if n := len(fake.Calls("RequestApproval")); n != 1 || !result.Rejected || result.Approval.ApprovalID != id {
+		t.Fatalf("expected the same pending request (%d requests), got %+v", n, result)
+	}
+
+	if _, err := fake.DecideApproval(ctx, id, &client.DecideApprovalRequest{Status: client.ApprovalGranted, DecidedBy: "juan"}); err != nil {
+		t.Fatal(err)
+	}
+	result = &PassthroughResult{Rejected: true, RejectionReason: "bd-1 is claimed by alice"}
+	superviseCommand(ctx, cfg, fake, args, "update bd-1 --status in_progress", result, 0)
+	if result.Rejected || result.Approval == nil || result.Approval.Status != client.ApprovalGranted {
+		t.Fatalf("expected the grant to release the command, got %+v", result)
+	}
+	if out := formatPassthroughOutput(result); !strings.Contains(out, "granted by juan") {
+		t.Errorf("expected the grant to be shown:\n%s", out)
+	}
+
+	// A grant is used once
+	result = &PassthroughResult{Rejected: true, RejectionReason: "bd-1 is claimed by alice"}
+	superviseCommand(ctx, cfg, fake, args, "update bd-1 --status in_progress", result, 0)
+	if !result.Rejected || result.Approval.ApprovalID == id {
+		t.Fatalf("expected a new request after the grant was used, got %+v", result)
+	}
+}
+
+func TestSuperviseCommand_Denied(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+	fake := clienttest.New()
+	fake.EnableFeatures(featureApprovals)
+	ctx := withFakeBeadHub(fake)
+	cfg := supervisedConfig(false, "delete")
+	args := []string{"delete", "bd-7"}
+
+	result := &PassthroughResult{}
+	superviseCommand(ctx, cfg, fake, args, "delete bd-7", result, 0)
+	if !result.Rejected || result.Approval == nil || !strings.Contains(result.RejectionReason, "high-risk") {
+		t.Fatalf("expected a high-risk command to be parked, got %+v", result)
+	}
+	if _, err := fake.DecideApproval(ctx, result.Approval.ApprovalID, &client.DecideApprovalRequest{Status: client.ApprovalDenied, DecidedBy: "juan", Note: "keep it for the audit"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result = &PassthroughResult{}
+	superviseCommand(ctx, cfg, fake, args, "delete bd-7", result, 0)
+	if !result.Rejected || result.Approval.Status != client.ApprovalDenied {
+		t.Fatalf("expected the denial to keep the command rejected, got %+v", result)
+	}
+	out := formatPassthroughOutput(result)
+	if !strings.Contains(out, "denied by juan") || !strings.Contains(out, "keep it for the audit") {
+		t.Errorf("expected the denial and note:\n%s", out)
+	}
+
+	// Rejections are not parked unless supervision.approvals is on
+	result = &PassthroughResult{Rejected: true, RejectionReason: "claimed"}
+	superviseCommand(ctx, cfg, fake, []string{"update", "bd-1"}, "update bd-1", result, 0)
+	if result.Approval != nil || result.RejectionReason != "claimed" {
+		t.Errorf("expected a plain rejection, got %+v", result)
+	}
+}
+
+func TestSuperviseCommand_WaitsForVerdict(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+	oldInterval := approvalPollInterval
+	approvalPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { approvalPollInterval = oldInterval })
+	fake := clienttest.New()
+	fake.EnableFeatures(featureApprovals)
+	ctx := withFakeBeadHub(fake)
+	cfg := supervisedConfig(false, "delete")
+
+	go func() {
+		for {
+			resp, _ := fake.ListApprovals(ctx, &client.ListApprovalsRequest{Status: client.ApprovalPending})
+			if resp != nil && len(resp.Approvals) > 0 {
+				_, _ = fake.DecideApproval(ctx, resp.Approvals[0].ApprovalID, &client.DecideApprovalRequest{Status: client.ApprovalGranted, DecidedBy: "juan"})
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+	result := &PassthroughResult{}
+	superviseCommand(ctx, cfg, fake, []string{"delete", "bd-7"}, "delete bd-7", result, 5*time.Second)
+	if result.Rejected || result.Approval == nil || result.Approval.Status != client.ApprovalGranted {
+		t.Fatalf("expected the wait to pick up the grant, got %+v", result)
+	}
+}
+
+func TestDecideApproval_RequiresAnotherApprover(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fake := clienttest.New()
+	ctx := withFakeBeadHub(fake)
+	cfg := supervisedConfig(false, "delete")
+	result := &PassthroughResult{}
+	superviseCommand(ctx, cfg, fake, []string{"delete", "bd-7"}, "delete bd-7", result, 0)
+	if result.Approval == nil {
+		t.Fatalf("expected the command parked, got %+v", result)
+	}
+	id := result.Approval.ApprovalID
+
+	// The agent grants its own request, even after giving itself the role
+	cfg.Role = "supervisor"
+	if _, err := decideApproval(ctx, cfg, fake, id, client.ApprovalGranted, ""); err == nil || !strings.Contains(err.Error(), "parked by this workspace") {
+		t.Fatalf("expected a self-grant to be refused, got %v", err)
+	}
+	other := &config.Config{Alias: "juan", WorkspaceID: "ws-2", Role: "backend"}
+	if _, err := decideApproval(ctx, other, fake, id, client.ApprovalGranted, ""); err == nil || !strings.Contains(err.Error(), "role supervisor") {
+		t.Fatalf("expected a non-approver to be refused, got %v", err)
+	}
+	if n := len(fake.Calls("DecideApproval")); n != 0 {
+		t.Fatalf("refused decisions must not reach the server, got %d", n)
+	}
+
+	other.Supervision = &config.SupervisionConfig{ApproverRoles: []string{"Tech Lead", "backend"}}
+	approval, err := decideApproval(ctx, other, fake, id, client.ApprovalGranted, "ok")
+	if err != nil || approval.Status != client.ApprovalGranted || approval.DecidedBy != "juan" {
+		t.Fatalf("expected the grant, got %+v, %v", approval, err)
+	}
+	calls := fake.Calls("DecideApproval")
+	if req := calls[len(calls)-1].Args[1].(*client.DecideApprovalRequest); req.WorkspaceID != "ws-2" || req.Role != "backend" {
+		t.Errorf("the server needs the deciding workspace and role, got %+v", req)
+	}
+}
+
+func TestDecideApproval_ServerRefusalIsReported(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fake := clienttest.New()
+	ctx := withFakeBeadHub(fake)
+	cfg := supervisedConfig(false, "delete")
+	result := &PassthroughResult{}
+	superviseCommand(ctx, cfg, fake, []string{"delete", "bd-7"}, "delete bd-7", result, 0)
+	if result.Approval == nil {
+		t.Fatalf("expected the command parked, got %+v", result)
+	}
+
+	// A .beadhub edited to pass the local checks still meets the server's
+	fake.Fail("DecideApproval", &client.Error{StatusCode: http.StatusForbidden, Body: `{"detail":"role backend cannot decide approvals"}`})
+	forged := &config.Config{Alias: "juan", WorkspaceID: "ws-2", Role: "supervisor"}
+	_, err := decideApproval(ctx, forged, fake, result.Approval.ApprovalID, client.ApprovalGranted, "")
+	if err == nil || err.Error() != "BeadHub refused the decision: role backend cannot decide approvals" {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestSuperviseCommand_HighRiskWithoutServerSupport(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+	fake := clienttest.New()
+	fake.EnableFeatures(featureSquads)
+	ctx := withFakeBeadHub(fake)
+	cfg := supervisedConfig(true, "close --force")
+
+	result := &PassthroughResult{}
+	superviseCommand(ctx, cfg, fake, []string{"close", "--force", "bd-1"}, "close --force bd-1", result, 0)
+	if !result.Rejected || result.Approval != nil || !strings.Contains(result.RejectionReason, "does not support approval requests") {
+		t.Fatalf("expected a high-risk command to stay blocked, got %+v", result)
+	}
+	if n := len(fake.Calls("RequestApproval")); n != 0 {
+		t.Errorf("expected no approval request, got %d", n)
+	}
+}
+
+func TestHighRiskCommand(t *testing.T) {
+	cfg := supervisedConfig(false, "delete", "close --force")
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"delete", "bd-1"}, "delete"},
+		{[]string{"close", "--force", "bd-1"}, "close --force"},
+		{[]string{"close", "bd-1"}, ""},
+		{[]string{"deleted"}, ""},
+		{nil, ""},
+	} {
+		if got := highRiskCommand(cfg, tc.args); got != tc.want {
+			t.Errorf("highRiskCommand(%v) = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestFormatApprovalList(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	approvals := []client.Approval{
+		{ApprovalID: "ap-1", Alias: "claude-be", CommandLine: "delete bd-7", Reason: "delete is a high-risk command",
+			Status: client.ApprovalPending, CreatedAt: "2026-03-01T11:55:00Z"},
+		{ApprovalID: "ap-2", Alias: "claude-fe", CommandLine: "update bd-1", Status: client.ApprovalDenied,
+			DecidedBy: "juan", Note: "wait for alice", CreatedAt: "2026-03-01T10:00:00Z"},
+	}
+	out := formatApprovalList(approvals, false, now)
+	for _, want := range []string{"ap-1  claude-be  5m ago  `delete bd-7`", "why parked: delete is a high-risk command", "denied by juan: wait for alice"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(formatApprovalList(nil, false, now), "No approval requests") {
+		t.Error("expected a message for an empty queue")
+	}
+	if js := formatApprovalList(nil, true, now); strings.TrimSpace(js) != "[]" {
+		t.Errorf("expected an empty JSON list, got %s", js)
+	}
+}
@@ -0,0 +1,385 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :attach records references to artifacts (design docs, dashboards, logs) on
+// a bead in BeadHub, so they travel with the bead instead of getting lost in
+// chats. Files are referenced by their path in the repo; only small text
+// artifacts are stored inline.
+
+const (
+	// maxInlineAttachmentBytes bounds text stored on the server with --inline
+	// or --text.
+	maxInlineAttachmentBytes = 8 * 1024
+	defaultAttachListLimit   = 20
+	// passthroughAttachmentsLimit bounds attachments shown in rejection/jump-in context.
+	passthroughAttachmentsLimit = 5
+)
+
+var (
+	attachName   string
+	attachText   string
+	attachInline bool
+	attachLimit  int
+	attachJSON   bool
+)
+
+var attachCmd = &cobra.Command{
+	Use:   ":attach <bead-id> [path|url]...",
+	Short: "Attach file references, links and small text artifacts to a bead",
+	Long: `Attach references to a bead, stored on BeadHub so every agent sees them.
+Without a path or URL, list the bead's attachments.
+
+Files are referenced by their path relative to the workspace root, with
+their size and SHA-256; the content stays in git. With --inline, small text
+files (up to 8 KB) are stored on the server as well. --text attaches a
+snippet directly.
+
+Attachments are also shown when a claim is rejected or when you --:jump-in.
+
+Examples:
+  bdh :attach bd-42 ./docs/design.md
+  bdh :attach bd-42 https://grafana.example.com/d/latency --name "latency dashboard"
+  bdh :attach bd-42 ./repro.sh --inline
+  bdh :attach bd-42 --text "repro: run with GOMAXPROCS=1" --name repro
+  bdh :attach bd-42            # List attachments`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAttach,
+}
+
+func init() {
+	attachCmd.Flags().StringVar(&attachName, "name", "", "Display name (default: file name or URL)")
+	attachCmd.Flags().StringVar(&attachText, "text", "", "Attach this text instead of a file or URL")
+	attachCmd.Flags().BoolVar(&attachInline, "inline", false, "Store the content of small text files on the server")
+	attachCmd.Flags().IntVar(&attachLimit, "limit", defaultAttachListLimit, "Maximum attachments to list")
+	attachCmd.Flags().BoolVar(&attachJSON, "json", false, "Output as JSON")
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+
+	beadID, refs := strings.TrimSpace(args[0]), args[1:]
+	if beadID == "" {
+		return fmt.Errorf("bead ID cannot be empty")
+	}
+	if attachText != "" && len(refs) > 0 {
+		return fmt.Errorf("--text cannot be combined with a path or URL")
+	}
+	if attachName != "" && len(refs) > 1 {
+		return fmt.Errorf("--name applies to a single attachment")
+	}
+
+	var reqs []*client.AddBeadAttachmentRequest
+	if attachText != "" {
+		req, err := textAttachmentRequest(attachText, attachName)
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, req)
+	}
+	if len(refs) > 0 {
+		root, err := config.WorkspaceRoot()
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			req, err := attachmentRequest(root, ref, attachName, attachInline)
+			if err != nil {
+				return err
+			}
+			reqs = append(reqs, req)
+		}
+	}
+
+	if len(reqs) == 0 {
+		attachments, err := listAttachmentsWithConfig(ctx, cfg, beadID, attachLimit)
+		if err != nil {
+			return err
+		}
+		fmt.Print(formatAttachmentListOutput(beadID, attachments, attachJSON))
+		return nil
+	}
+
+	var added []client.BeadAttachment
+	for _, req := range reqs {
+		a, err := addAttachmentWithConfig(ctx, cfg, beadID, req)
+		if err != nil {
+			return err
+		}
+		added = append(added, *a)
+	}
+	if attachJSON {
+		fmt.Print(marshalJSONOrFallback(added))
+		return nil
+	}
+	for _, a := range added {
+		fmt.Printf("Attached %s to %s\n", a.Name, beadID)
+	}
+	return nil
+}
+
+// attachmentRequest describes a path or URL argument. Paths must be inside
+// the workspace and are recorded relative to its root.
+func attachmentRequest(root, ref, name string, inline bool) (*client.AddBeadAttachmentRequest, error) {
+	if u, err := url.Parse(ref); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		if inline {
+			return nil, fmt.Errorf("--inline applies to files, not URLs")
+		}
+		if name == "" {
+			name = ref
+		}
+		return &client.AddBeadAttachmentRequest{Kind: client.AttachmentKindURL, Name: name, Ref: ref}, nil
+	}
+
+	abs, err := filepath.Abs(ref)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot attach %s: %w", ref, err)
+	}
+	// Compare resolved paths so symlinked checkouts (e.g. /tmp on macOS) match.
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("cannot attach %s: is a directory", ref)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%s is outside the workspace - attach a URL, or its text with --text", ref)
+	}
+
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ref, err)
+	}
+	sum := sha256.Sum256(data)
+	if name == "" {
+		name = filepath.Base(abs)
+	}
+	req := &client.AddBeadAttachmentRequest{
+		Kind:      client.AttachmentKindPath,
+		Name:      name,
+		Ref:       filepath.ToSlash(rel),
+		SizeBytes: int64(len(data)),
+		SHA256:    hex.EncodeToString(sum[:]),
+	}
+	if inline {
+		if err := checkInlineText(ref, data); err != nil {
+			return nil, err
+		}
+		req.Content = string(data)
+	}
+	return req, nil
+}
+
+// textAttachmentRequest describes a --text snippet.
+func textAttachmentRequest(text, name string) (*client.AddBeadAttachmentRequest, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("--text cannot be empty")
+	}
+	if err := checkInlineText("--text", []byte(text)); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = truncateText(firstLine(text), 40)
+	}
+	sum := sha256.Sum256([]byte(text))
+	return &client.AddBeadAttachmentRequest{
+		Kind:      client.AttachmentKindText,
+		Name:      name,
+		Content:   text,
+		SizeBytes: int64(len(text)),
+		SHA256:    hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+func checkInlineText(what string, data []byte) error {
+	if len(data) > maxInlineAttachmentBytes {
+		return fmt.Errorf("%s is too large to store inline (%s, max %s) - attach it by path instead",
+			what, formatByteSize(int64(len(data))), formatByteSize(maxInlineAttachmentBytes))
+	}
+	if !utf8.Valid(data) || strings.ContainsRune(string(data), 0) {
+		return fmt.Errorf("%s is not text - attach it by path instead", what)
+	}
+	return nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// addAttachmentWithConfig attaches a reference to a bead (for testing).
+func addAttachmentWithConfig(ctx context.Context, cfg *config.Config, beadID string, req *client.AddBeadAttachmentRequest) (*client.BeadAttachment, error) {
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req.WorkspaceID = cfg.WorkspaceID
+	req.Alias = cfg.Alias
+	req.RepoID = cfg.RepoID
+	a, err := c.AddBeadAttachment(ctx, beadID, req)
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("failed to attach %s: %w", req.Name, err)
+	}
+	if a.BeadID == "" {
+		a.BeadID = beadID
+	}
+	if a.Name == "" {
+		a.Name = req.Name
+	}
+	return a, nil
+}
+
+// listAttachmentsWithConfig lists attachments on a bead (for testing).
+func listAttachmentsWithConfig(ctx context.Context, cfg *config.Config, beadID string, limit int) ([]client.BeadAttachment, error) {
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	resp, err := c.ListBeadAttachments(ctx, &client.ListBeadAttachmentsRequest{BeadID: beadID, Limit: limit})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return resp.Attachments, nil
+}
+
+// fetchBeadAttachments returns recent attachments for a bead. Like notes they
+// are best-effort context; servers without attachments (404) have none.
+func fetchBeadAttachments(ctx context.Context, cfg *config.Config, beadID string) ([]client.BeadAttachment, error) {
+	if beadID == "" {
+		return nil, nil
+	}
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	resp, err := c.ListBeadAttachments(ctx, &client.ListBeadAttachmentsRequest{BeadID: beadID, Limit: passthroughAttachmentsLimit})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Attachments, nil
+}
+
+func formatAttachmentListOutput(beadID string, attachments []client.BeadAttachment, asJSON bool) string {
+	if asJSON {
+		output := struct {
+			BeadID      string                  `json:"bead_id"`
+			Attachments []client.BeadAttachment `json:"attachments"`
+			Count       int                     `json:"count"`
+		}{
+			BeadID:      beadID,
+			Attachments: attachments,
+			Count:       len(attachments),
+		}
+		if output.Attachments == nil {
+			output.Attachments = []client.BeadAttachment{}
+		}
+		return marshalJSONOrFallback(output)
+	}
+
+	if len(attachments) == 0 {
+		return fmt.Sprintf("No attachments on %s.\n", beadID)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("ATTACHMENTS on %s:\n", beadID))
+	sb.WriteString(formatAttachmentLines(attachments, "  "))
+	return sb.String()
+}
+
+// formatAttachmentLines renders attachments as
+// "- name (kind: ref, size) by alias (age)" lines; text attachments show
+// their first line.
+func formatAttachmentLines(attachments []client.BeadAttachment, indent string) string {
+	var sb strings.Builder
+	for _, a := range attachments {
+		var details []string
+		switch a.Kind {
+		case client.AttachmentKindPath:
+			details = append(details, a.Ref)
+		case client.AttachmentKindURL:
+			if a.Ref != a.Name {
+				details = append(details, a.Ref)
+			}
+		default:
+			details = append(details, a.Kind)
+		}
+		if a.SizeBytes > 0 {
+			details = append(details, formatByteSize(a.SizeBytes))
+		}
+
+		line := indent + "- " + a.Name
+		if len(details) > 0 {
+			line += " (" + strings.Join(details, ", ") + ")"
+		}
+		if a.Alias != "" {
+			line += " by " + a.Alias
+		}
+		if a.CreatedAt != "" {
+			line += " (" + formatTimestamp(a.CreatedAt) + ")"
+		}
+		if a.Kind == client.AttachmentKindText && a.Content != "" && firstLine(a.Content) != a.Name {
+			line += ": " + truncateText(firstLine(a.Content), 80)
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// formatByteSize renders a size as "512 B", "4.2 KB" or "3.1 MB".
+func formatByteSize(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestAttachmentRequest_Path(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "docs"), 0755)
+	path := filepath.Join(root, "docs", "design.md")
+	if err := os.WriteFile(path, []byte("# Design\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := attachmentRequest(root, path, "", true)
+	if err != nil {
+		t.Fatalf("attachmentRequest: %v", err)
+	}
+	if req.Kind != client.AttachmentKindPath || req.Ref != "docs/design.md" || req.Name != "design.md" ||
+		req.SizeBytes != 9 || len(req.SHA256) != 64 || req.Content != "# Design\n" {
+		t.Errorf("unexpected request: %+v", req)
+	}
+
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	os.WriteFile(outside, []byte("x"), 0644)
+	if _, err := attachmentRequest(root, outside, "", false); err == nil || !strings.Contains(err.Error(), "outside the workspace") {
+		t.Errorf("expected outside-workspace error, got %v", err)
+	}
+}
+
+func TestAttachmentRequest_URLAndText(t *testing.T) {
+	req, err := attachmentRequest(t.TempDir(), "https://example.com/d/latency", "dashboard", false)
+	if err != nil || req.Kind != client.AttachmentKindURL || req.Ref != "https://example.com/d/latency" || req.Name != "dashboard" {
+		t.Errorf("url: %+v, %v", req, err)
+	}
+	if _, err := attachmentRequest(t.TempDir(), "https://example.com", "", true); err == nil {
+		t.Error("expected --inline to be rejected for URLs")
+	}
+
+	req, err = textAttachmentRequest("repro: run with GOMAXPROCS=1\nthen wait", "")
+	if err != nil || req.Kind != client.AttachmentKindText || req.Name != "repro: run with GOMAXPROCS=1" {
+		t.Errorf("text: %+v, %v", req, err)
+	}
+	if _, err := textAttachmentRequest(strings.Repeat("x", maxInlineAttachmentBytes+1), ""); err == nil {
+		t.Error("expected oversized text to be rejected")
+	}
+}
+
+func TestAddAttachment_PostsToBead(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+
+	var gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		json.NewEncoder(w).Encode(map[string]any{"attachment_id": "att-1", "kind": gotBody["kind"], "alias": "alice"})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab", BeadhubURL: server.URL, Alias: "alice"}
+	a, err := addAttachmentWithConfig(context.Background(), cfg, "bd-42", &client.AddBeadAttachmentRequest{
+		Kind: client.AttachmentKindURL, Name: "dashboard", Ref: "https://example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/v1/beads/bd-42/attachments" || gotBody["alias"] != "alice" || gotBody["ref"] != "https://example.com" {
+		t.Errorf("unexpected request %s %v", gotPath, gotBody)
+	}
+	if a.BeadID != "bd-42" || a.Name != "dashboard" {
+		t.Errorf("unexpected attachment: %+v", a)
+	}
+}
+
+func TestFormatAttachmentLines(t *testing.T) {
+	out := formatAttachmentLines([]client.BeadAttachment{
+		{Kind: client.AttachmentKindPath, Name: "design.md", Ref: "docs/design.md", SizeBytes: 4300, Alias: "bob"},
+		{Kind: client.AttachmentKindURL, Name: "https://example.com", Ref: "https://example.com"},
+		{Kind: client.AttachmentKindText, Name: "repro", Content: "run with GOMAXPROCS=1", SizeBytes: 21},
+	}, "  ")
+	for _, want := range []string{
+		"  - design.md (docs/design.md, 4.2 KB) by bob\n",
+		"  - https://example.com\n",
+		"  - repro (text, 21 B): run with GOMAXPROCS=1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if got := formatAttachmentListOutput("bd-42", nil, true); !strings.Contains(got, `"attachments": []`) {
+		t.Errorf("expected empty JSON array, got: %s", got)
+	}
+}
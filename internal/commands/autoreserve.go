@@ -22,6 +22,9 @@ type ReservationConflict struct {
 	HeldBy            string `json:"held_by"`
 	RetryAfterSeconds int    `json:"retry_after_seconds"`
 	ExpiresAt         string `json:"expires_at,omitempty"`
+	// HeldKey is the overlapping reservation when it is not ResourceKey
+	// itself, e.g. a directory reservation covering the file.
+	HeldKey string `json:"held_key,omitempty"`
 }
 
 type AutoReserveResult struct {
@@ -29,6 +32,7 @@ type AutoReserveResult struct {
 	Renewed   []string
 	Released  []string
 	Conflicts []ReservationConflict
+	Shared    []ReservationConflict // Held by squadmates: not conflicts
 	Warning   string
 }
 
@@ -39,7 +43,10 @@ type gitStatusEntry struct {
 	OrigPath string // rename/copy source path (if any)
 }
 
-func autoReserve(ctx context.Context, cfg *config.Config, c *aweb.Client) *AutoReserveResult {
+// autoReserve reserves the files changed in the working tree and releases
+// auto-reservations no longer needed. New reservations are tagged with
+// beadID, the bead they are for, when known.
+func autoReserve(ctx context.Context, cfg *config.Config, c AwebAPI, beadID string) *AutoReserveResult {
 	if !cfg.AutoReserveEnabled() {
 		return nil
 	}
@@ -75,8 +82,12 @@ func autoReserve(ctx context.Context, cfg *config.Config, c *aweb.Client) *AutoR
 		return result
 	}
 
+	// Many changed files under one directory become one directory reservation
+	desired = collapseLockPaths(desired, autoReserveCollapseThreshold, allLocksResp.Reservations, cfg.Alias)
+
 	heldAny := make(map[string]struct{}, len(allLocksResp.Reservations))
 	heldAuto := make(map[string]struct{}, len(allLocksResp.Reservations))
+	heldManual := make(map[string]aweb.ReservationView)
 	for _, lock := range allLocksResp.Reservations {
 		if lock.HolderAlias != cfg.Alias {
 			continue
@@ -87,6 +98,8 @@ func autoReserve(ctx context.Context, cfg *config.Config, c *aweb.Client) *AutoR
 		heldAny[lock.ResourceKey] = struct{}{}
 		if reason, ok := lock.Metadata["reason"].(string); ok && reason == autoReserveReason {
 			heldAuto[lock.ResourceKey] = struct{}{}
+		} else {
+			heldManual[lock.ResourceKey] = lock
 		}
 	}
 
@@ -99,6 +112,10 @@ func autoReserve(ctx context.Context, cfg *config.Config, c *aweb.Client) *AutoR
 			}
 			continue
 		}
+		// Already covered by a directory you reserved yourself
+		if _, ok := coveringReservation(heldManual, path); ok {
+			continue
+		}
 		toAcquire = append(toAcquire, path)
 	}
 
@@ -110,6 +127,12 @@ func autoReserve(ctx context.Context, cfg *config.Config, c *aweb.Client) *AutoR
 		toRelease = append(toRelease, path)
 	}
 
+	// Servers without renew keep reservations until they expire; they are
+	// re-acquired by the first command after that.
+	if len(toRenew) > 0 && !serverSupports(ctx, cfg, featureReservationsRenew) {
+		toRenew = nil
+	}
+
 	sort.Strings(toAcquire)
 	sort.Strings(toRenew)
 	sort.Strings(toRelease)
@@ -119,26 +142,53 @@ func autoReserve(ctx context.Context, cfg *config.Config, c *aweb.Client) *AutoR
 	}
 
 	if len(toAcquire) > 0 {
+		squadmate := squadResolver(ctx, cfg)
 		for _, path := range toAcquire {
+			// The server only refuses identical keys; overlaps are checked here
+			if r, ok := overlappingReservation(allLocksResp.Reservations, cfg.Alias, path); ok && r.ResourceKey != path {
+				conflict := ReservationConflict{
+					ResourceKey:       path,
+					HeldBy:            r.HolderAlias,
+					RetryAfterSeconds: ttlRemainingSeconds(r.ExpiresAt, serverNow()),
+					ExpiresAt:         r.ExpiresAt,
+					HeldKey:           r.ResourceKey,
+				}
+				if !squadmate(r.HolderAlias) {
+					result.Conflicts = append(result.Conflicts, conflict)
+					continue
+				}
+				// Shared with the squad: reserve the path too, so it stays
+				// exclusive against outsiders after the squadmate releases
+				result.Shared = append(result.Shared, conflict)
+			}
+			metadata := map[string]any{"reason": autoReserveReason}
+			if beadID != "" {
+				metadata[reservationBeadIDKey] = beadID
+			}
+			if cfg.Squad != nil && strings.TrimSpace(cfg.Squad.Name) != "" {
+				metadata[reservationSquadKey] = strings.TrimSpace(cfg.Squad.Name)
+			}
 			lockCtx, lockCancel := context.WithTimeout(ctx, apiTimeout)
 			_, err := c.ReservationAcquire(lockCtx, &aweb.ReservationAcquireRequest{
 				ResourceKey: path,
 				TTLSeconds:  reserveDefaultTTL,
-				Metadata: map[string]any{
-					"reason": autoReserveReason,
-				},
+				Metadata:    metadata,
 			})
 			lockCancel()
 			if err != nil {
 				var held *aweb.ReservationHeldError
 				if errors.As(err, &held) {
-					retryAfter := ttlRemainingSeconds(held.ExpiresAt, time.Now())
-					result.Conflicts = append(result.Conflicts, ReservationConflict{
+					conflict := ReservationConflict{
 						ResourceKey:       path,
 						HeldBy:            held.HolderAlias,
-						RetryAfterSeconds: retryAfter,
+						RetryAfterSeconds: ttlRemainingSeconds(held.ExpiresAt, serverNow()),
 						ExpiresAt:         held.ExpiresAt,
-					})
+					}
+					if squadmate(held.HolderAlias) {
+						result.Shared = append(result.Shared, conflict)
+					} else {
+						result.Conflicts = append(result.Conflicts, conflict)
+					}
 					continue
 				}
 				result.Warning = fmt.Sprintf("Auto-reserve: unable to acquire reservations (%v)", err)
@@ -226,7 +226,7 @@ func TestAutoReserve_RenewsExistingAutoLocks(t *testing.T) {
 		t.Fatalf("aweb.NewWithAPIKey: %v", err)
 	}
 
-	res := autoReserve(context.Background(), cfg, aw)
+	res := autoReserve(context.Background(), cfg, aw, "")
 	if res == nil {
 		t.Fatalf("expected autoReserve to take action (renew), got nil")
 	}
@@ -362,7 +362,7 @@ func TestAutoReserve_ReleasesStaleAutoLocks(t *testing.T) {
 		t.Fatalf("aweb.NewWithAPIKey: %v", err)
 	}
 
-	res := autoReserve(context.Background(), cfg, aw)
+	res := autoReserve(context.Background(), cfg, aw, "")
 	if res == nil {
 		t.Fatalf("expected autoReserve to take action (release), got nil")
 	}
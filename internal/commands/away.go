@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// The away auto-responder (away.auto_reply) keeps senders from waiting on an
+// agent that is not there. While :dnd is on, bdh answers each new chat
+// session once, from the notification check that runs after every command.
+// An agent that has gone offline cannot answer for itself, so the rendered
+// message is also sent with presence refreshes and the server replies once
+// the agent has been offline for away.offline_after_minutes.
+
+// Statuses rendered into {status}.
+const (
+	awayStatusDND     = "do not disturb"
+	awayStatusOffline = "offline"
+)
+
+// AwayAutoReply records a chat session answered by the auto-responder.
+type AwayAutoReply struct {
+	SessionID string
+	To        string
+	Error     string
+}
+
+// chatMessageSender is the part of the aweb client the auto-responder uses.
+type chatMessageSender interface {
+	ChatSendMessage(ctx context.Context, sessionID string, req *aweb.ChatSendMessageRequest) (*aweb.ChatSendMessageResponse, error)
+}
+
+// renderAwayMessage fills the {alias}, {status}, {until} and {note}
+// placeholders of tmpl and tidies the spacing left by empty values.
+func renderAwayMessage(tmpl, alias, status, until, note string) string {
+	if until == "" {
+		until = "later"
+	}
+	msg := strings.NewReplacer(
+		"{alias}", alias,
+		"{status}", status,
+		"{until}", until,
+		"{note}", strings.TrimSpace(note),
+	).Replace(tmpl)
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// applyAwayPresence adds the offline auto-reply to a presence refresh.
+func applyAwayPresence(cfg *config.Config, req *client.RefreshPresenceRequest) {
+	if !cfg.AwayAutoReplyEnabled() {
+		return
+	}
+	req.AwayReply = renderAwayMessage(cfg.AwayMessage(), cfg.Alias, awayStatusOffline, "", "")
+	req.AwayAfterSeconds = int(cfg.AwayOfflineAfter() / time.Second)
+}
+
+// autoReplyWhileAway answers pending chat sessions while do-not-disturb is
+// on. Each session is answered at most once per do-not-disturb period.
+func autoReplyWhileAway(ctx context.Context, cfg *config.Config, sender chatMessageSender, pending []PendingConversation, now time.Time) []AwayAutoReply {
+	if !cfg.AwayAutoReplyEnabled() || len(pending) == 0 {
+		return nil
+	}
+	path, err := dndPath()
+	if err != nil {
+		return nil
+	}
+	state, err := loadDNDState(path)
+	if err != nil || !activeDNDState(state, now) {
+		return nil
+	}
+
+	replied := make(map[string]bool, len(state.AutoReplied))
+	for _, id := range state.AutoReplied {
+		replied[id] = true
+	}
+	body := renderAwayMessage(cfg.AwayMessage(), cfg.Alias, awayStatusDND, "at "+formatClockTime(state.Until), state.Note)
+
+	var out []AwayAutoReply
+	for _, conv := range pending {
+		from := strings.TrimSpace(conv.LastFrom)
+		if conv.SessionID == "" || replied[conv.SessionID] || from == "" || from == cfg.Alias {
+			continue
+		}
+		_, err := sender.ChatSendMessage(ctx, conv.SessionID, &aweb.ChatSendMessageRequest{Body: body})
+		reply := AwayAutoReply{SessionID: conv.SessionID, To: from}
+		if err != nil {
+			reply.Error = err.Error()
+			if unreachable(ctx, err) {
+				out = append(out, reply)
+				break
+			}
+		} else {
+			replied[conv.SessionID] = true
+			state.AutoReplied = append(state.AutoReplied, conv.SessionID)
+		}
+		out = append(out, reply)
+	}
+	if len(out) > 0 {
+		_ = saveDNDState(path, state)
+	}
+	return out
+}
+
+func formatAwayAutoReply(r AwayAutoReply) string {
+	if r.Error != "" {
+		return fmt.Sprintf("- **CHAT**: Could not auto-reply to %s (%s)", r.To, truncateText(r.Error, 200))
+	}
+	return fmt.Sprintf("- **CHAT**: Auto-replied to %s that you are away (do not disturb)", r.To)
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+type fakeChatSender struct {
+	sent map[string]string
+	fail string
+}
+
+func (f *fakeChatSender) ChatSendMessage(ctx context.Context, sessionID string, req *aweb.ChatSendMessageRequest) (*aweb.ChatSendMessageResponse, error) {
+	if sessionID == f.fail {
+		return nil, errors.New("session closed")
+	}
+	f.sent[sessionID] = req.Body
+	return &aweb.ChatSendMessageResponse{Delivered: true}, nil
+}
+
+func awayConfig() *config.Config {
+	enabled := true
+	return &config.Config{Alias: "maria-be", Away: &config.AwayConfig{AutoReply: &enabled}}
+}
+
+func TestRenderAwayMessage(t *testing.T) {
+	got := renderAwayMessage(config.DefaultAwayMessage, "maria-be", awayStatusOffline, "", "")
+	if got != "Auto-reply: maria-be is away (offline) and expects to be back later." {
+		t.Errorf("got %q", got)
+	}
+	got = renderAwayMessage("{alias}: {status} until {until} {note}", "maria-be", awayStatusDND, "15:00", "release freeze")
+	if got != "maria-be: do not disturb until 15:00 release freeze" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestAutoReplyWhileAway_RepliesOncePerSession(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := awayConfig()
+	now := time.Now()
+	path, _ := dndPath()
+	if err := saveDNDState(path, &DNDState{Until: now.Add(time.Hour).UTC().Format(time.RFC3339), Note: "deep work"}); err != nil {
+		t.Fatal(err)
+	}
+
+	pending := []PendingConversation{
+		{SessionID: "s1", LastFrom: "bob"},
+		{SessionID: "s2", LastFrom: "maria-be"},
+		{SessionID: "s3", LastFrom: "carol"},
+	}
+	sender := &fakeChatSender{sent: map[string]string{}, fail: "s3"}
+	replies := autoReplyWhileAway(context.Background(), cfg, sender, pending, now)
+	if len(replies) != 2 || replies[0].To != "bob" || replies[1].Error == "" {
+		t.Fatalf("replies = %+v", replies)
+	}
+	if body := sender.sent["s1"]; !strings.Contains(body, "maria-be is away (do not disturb)") || !strings.Contains(body, "deep work") {
+		t.Errorf("body = %q", body)
+	}
+	if _, ok := sender.sent["s2"]; ok {
+		t.Error("sessions where the last message is ours should not be answered")
+	}
+
+	// s1 is remembered; the failed s3 is retried.
+	sender.fail = ""
+	replies = autoReplyWhileAway(context.Background(), cfg, sender, pending, now)
+	if len(replies) != 1 || replies[0].SessionID != "s3" || replies[0].Error != "" {
+		t.Fatalf("second pass replies = %+v", replies)
+	}
+	if out := formatAwayAutoReply(replies[0]); !strings.Contains(out, "Auto-replied to carol") {
+		t.Errorf("notice = %q", out)
+	}
+
+	// Nothing is sent once do-not-disturb has expired.
+	sender.sent = map[string]string{}
+	pending = append(pending, PendingConversation{SessionID: "s4", LastFrom: "dave"})
+	if replies := autoReplyWhileAway(context.Background(), cfg, sender, pending, now.Add(2*time.Hour)); len(replies) != 0 {
+		t.Errorf("expired dnd replies = %+v", replies)
+	}
+}
+
+func TestAutoReplyWhileAway_Disabled(t *testing.T) {
+	t.Chdir(t.TempDir())
+	now := time.Now()
+	path, _ := dndPath()
+	_ = saveDNDState(path, &DNDState{Until: now.Add(time.Hour).UTC().Format(time.RFC3339)})
+	sender := &fakeChatSender{sent: map[string]string{}}
+	cfg := &config.Config{Alias: "maria-be"}
+	if replies := autoReplyWhileAway(context.Background(), cfg, sender, []PendingConversation{{SessionID: "s1", LastFrom: "bob"}}, now); len(replies) != 0 {
+		t.Errorf("replies = %+v", replies)
+	}
+}
+
+func TestApplyAwayPresence(t *testing.T) {
+	cfg := awayConfig()
+	minutes := 30
+	cfg.Away.OfflineAfterMinutes = &minutes
+	req := &client.RefreshPresenceRequest{}
+	applyAwayPresence(cfg, req)
+	if req.AwayAfterSeconds != 1800 || !strings.Contains(req.AwayReply, "maria-be is away (offline)") {
+		t.Errorf("req = %+v", req)
+	}
+
+	req = &client.RefreshPresenceRequest{}
+	applyAwayPresence(&config.Config{Alias: "maria-be"}, req)
+	if req.AwayReply != "" || req.AwayAfterSeconds != 0 {
+		t.Errorf("disabled auto-reply should not be sent: %+v", req)
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	aweb "github.com/awebai/aw"
+	"github.com/beadhub/bdh/internal/client"
 )
 
 var awebCmd = &cobra.Command{
@@ -61,16 +63,17 @@ var awebWhoamiCmd = &cobra.Command{
 	Use:   "whoami",
 	Short: "Show the current aweb identity",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		if len(args) != 0 {
 			return fmt.Errorf("whoami takes no arguments")
 		}
 
-		client, err := newAwebClientRequired("")
+		client, err := newAwebClientRequired(ctx, "")
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
 		resp, err := client.Introspect(ctx)
@@ -112,22 +115,40 @@ func init() {
 var (
 	awebWhoJSON       bool
 	awebWhoOnlineOnly bool
+	awebWhoWatch      bool
+	awebWhoInterval   time.Duration
 )
 
 var awebWhoCmd = &cobra.Command{
 	Use:   "who",
 	Short: "List agents in the current project",
+	Long: `List agents in the current project, online first.
+
+With --watch the list is redrawn every --interval, with agents coming online
+or going offline and claims appearing or disappearing highlighted.
+
+Examples:
+  bdh :aweb who
+  bdh :aweb who --online-only
+  bdh :aweb who --watch --interval 10s`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		if len(args) != 0 {
 			return fmt.Errorf("who takes no arguments")
 		}
+		if awebWhoWatch && awebWhoJSON {
+			return fmt.Errorf("--watch cannot be combined with --json")
+		}
 
-		client, err := newAwebClientRequired("")
+		client, err := newAwebClientRequired(ctx, "")
 		if err != nil {
 			return err
 		}
+		if awebWhoWatch {
+			return runAwebWhoWatch(ctx, client)
+		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
 		resp, err := client.ListAgents(ctx)
@@ -191,6 +212,8 @@ var awebWhoCmd = &cobra.Command{
 func init() {
 	awebWhoCmd.Flags().BoolVar(&awebWhoJSON, "json", false, "Output as JSON")
 	awebWhoCmd.Flags().BoolVar(&awebWhoOnlineOnly, "online-only", false, "Only show online agents")
+	awebWhoCmd.Flags().BoolVar(&awebWhoWatch, "watch", false, "Refresh continuously, highlighting changes (Ctrl-C to stop)")
+	awebWhoCmd.Flags().DurationVar(&awebWhoInterval, "interval", defaultWhoWatchInterval, "Refresh interval for --watch")
 }
 
 // =============================================================================
@@ -208,27 +231,72 @@ var (
 	awebMailPriority string
 	awebMailAll      bool
 	awebMailLimit    int
+
+	awebMailIncludeArchived bool
+	awebMailArchive         bool
+
+	awebMailNoBeadRefs bool
+	awebMailEncrypt    bool
 )
 
 var awebMailSendCmd = &cobra.Command{
-	Use:   "send <alias> <message>",
+	Use:   "send <alias> [message]",
 	Short: "Send a message",
-	Args:  cobra.ExactArgs(2),
+	Long: `Send a message to another agent's inbox.
+
+Use --send-at or --delay to queue low-priority updates for later instead of
+interrupting a peer mid-task.
+
+Bead IDs from this repo mentioned in the message get a footer with their
+current status and title (disable with --no-bead-refs).
+
+With --nag-after, high or urgent mail still unread after that long gets a
+reminder sent to the recipient (up to 3, one per interval); 'mail sent'
+shows delivery and read receipts.
+
+With --flag the message is never acked automatically when shown inline
+(notifications.auto_ack_displayed), so it stays unread until handled.
+
+With --encrypt the body is sealed for the recipient's published key (see
+bdh :keys) so the server only stores ciphertext.
+
+With --template the message is a template from .beadhub (templates), its
+{{variables}} filled in with --var; see 'mail templates'.
+
+Examples:
+  bdh :aweb mail send alice "API is merged"
+  bdh :aweb mail send bob --template handoff --var status="tests green" --var next=bd-43
+  bdh :aweb mail send alice "staging token: ..." --encrypt
+  bdh :aweb mail send alice "end-of-day summary" --send-at 17:00
+  bdh :aweb mail send bob "no rush: see bd-42" --delay 30m
+  bdh :aweb mail send carol "prod is down" --priority urgent --nag-after 2h`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		targetAlias := strings.TrimSpace(args[0])
-		body := args[1]
 		if targetAlias == "" {
 			return fmt.Errorf("alias cannot be empty")
 		}
+		body, err := mailSendBody(args)
+		if err != nil {
+			return err
+		}
 		if strings.TrimSpace(body) == "" {
 			return fmt.Errorf("message cannot be empty")
 		}
+		body = outgoingMessageBody(body, awebMailNoBeadRefs)
+		subject := strings.TrimSpace(awebMailSubject)
 
-		identity, err := currentAgentIdentityForAweb()
+		now := time.Now()
+		deliverAt, scheduled, err := resolveMailDeliverAt(awebMailSendAt, awebMailDelay, now)
 		if err != nil {
 			return err
 		}
-		client, err := aweb.NewWithAPIKey(identity.BaseURL, identity.APIKey)
+		nagAfter, err := parseNagAfter(awebMailNagAfter, awebMailPriority, scheduled)
+		if err != nil {
+			return err
+		}
+
+		identity, err := currentAgentIdentityForAweb()
 		if err != nil {
 			return err
 		}
@@ -236,15 +304,50 @@ var awebMailSendCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
 		defer cancel()
 
-		resp, err := client.SendMessage(ctx, &aweb.SendMessageRequest{
+		if awebMailEncrypt {
+			c := beadHubClientFor(ctx, identity.BaseURL, identity.APIKey)
+			body, err = sealOutgoingBody(ctx, c, body, []string{targetAlias}, identity.AgentAlias)
+			if err != nil {
+				return err
+			}
+		}
+		body = withMailMetadata(body, MailMetadata{Flagged: awebMailFlag})
+
+		if scheduled {
+			targetAlias = resolveQueuedMailAlias(ctx, targetAlias)
+			result, err := scheduleMail(ctx, beadHubClientFor(ctx, identity.BaseURL, identity.APIKey), &client.ScheduleMessageRequest{
+				ToAlias:   targetAlias,
+				Subject:   subject,
+				Body:      body,
+				Priority:  strings.TrimSpace(awebMailPriority),
+				DeliverAt: deliverAt.UTC().Format(time.RFC3339),
+			}, now)
+			if err != nil {
+				return err
+			}
+			fmt.Print(formatScheduledMailResult(result, awebMailJSON))
+			return nil
+		}
+
+		aw, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
+		if err != nil {
+			return err
+		}
+
+		resp, err := aw.SendMessage(ctx, &aweb.SendMessageRequest{
 			ToAlias:  targetAlias,
-			Subject:  strings.TrimSpace(awebMailSubject),
+			Subject:  subject,
 			Body:     body,
 			Priority: aweb.MessagePriority(strings.TrimSpace(awebMailPriority)),
 		})
 		if err != nil {
 			return err
 		}
+		if nagAfter > 0 {
+			if err := trackMailReceipt(resp.MessageID, targetAlias, subject, strings.TrimSpace(awebMailPriority), nagAfter, now); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not record mail for read-receipt reminders: %v\n", err)
+			}
+		}
 
 		if awebMailJSON {
 			fmt.Print(marshalJSONOrFallback(resp))
@@ -260,7 +363,17 @@ var awebMailSendCmd = &cobra.Command{
 var awebMailListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List inbox messages",
+	Long: `List inbox messages, newest first (unread only unless --all).
+
+--limit is the page size: --page 2 shows the next --limit messages and
+--all-pages fetches them all. --search filters by sender, subject or body.
+
+Examples:
+  bdh :aweb mail list
+  bdh :aweb mail list --all --page 2
+  bdh :aweb mail list --all --all-pages --search rebase`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		if len(args) != 0 {
 			return fmt.Errorf("mail list takes no arguments")
 		}
@@ -268,12 +381,15 @@ var awebMailListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		client, err := aweb.NewWithAPIKey(identity.BaseURL, identity.APIKey)
+		if wantsPagedMailList() {
+			return listMailPaged(ctx, identity)
+		}
+		client, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
 		resp, err := client.Inbox(ctx, aweb.InboxParams{
@@ -285,6 +401,9 @@ var awebMailListCmd = &cobra.Command{
 		}
 
 		if awebMailJSON {
+			for i := range resp.Messages {
+				resp.Messages[i].Body = readMessageBody(resp.Messages[i].Body)
+			}
 			fmt.Print(marshalJSONOrFallback(resp))
 			fmt.Print("\n")
 			return nil
@@ -301,21 +420,83 @@ var awebMailListCmd = &cobra.Command{
 
 		fmt.Printf("MAILS: %d\n\n", len(resp.Messages))
 		for _, msg := range resp.Messages {
-			subj := strings.TrimSpace(msg.Subject)
-			if subj != "" {
-				subj = " — " + subj
-			}
-			fmt.Printf("- %s%s: %s\n", msg.FromAlias, subj, msg.Body)
+			fmt.Print(formatMailListLine(msg.MessageID, msg.FromAlias, msg.Subject, renderMessageBody(msg.Body), msg.CreatedAt, false))
 		}
 		return nil
 	},
 }
 
+var awebMailAckCmd = &cobra.Command{
+	Use:   "ack <message-id>",
+	Short: "Mark a message as read (--archive to mark it done)",
+	Long: `Acknowledge a message.
+
+A plain ack marks the message as read: seen, but still pending action.
+With --archive the message is also marked done and hidden from
+'mail list --all' unless --include-archived is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		messageID := strings.TrimSpace(args[0])
+		if messageID == "" {
+			return fmt.Errorf("message ID cannot be empty")
+		}
+		identity, err := currentAgentIdentityForAweb()
+		if err != nil {
+			return err
+		}
+		c := beadHubClientFor(ctx, identity.BaseURL, identity.APIKey)
+
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		defer cancel()
+
+		resp, err := c.Ack(ctx, messageID, &client.AckRequest{
+			WorkspaceID: identity.AgentID,
+			Archive:     awebMailArchive,
+		})
+		if err != nil {
+			return err
+		}
+
+		if awebMailJSON {
+			fmt.Print(marshalJSONOrFallback(resp))
+			fmt.Print("\n")
+			return nil
+		}
+
+		if awebMailArchive {
+			fmt.Printf("Archived %s\n", messageID)
+		} else {
+			fmt.Printf("Marked %s as read\n", messageID)
+		}
+		return nil
+	},
+}
+
+// formatMailListLine renders one inbox entry for 'mail list'. The send time
+// is shown only when output.time_format is set.
+func formatMailListLine(messageID, from, subject, body, createdAt string, archived bool) string {
+	subj := strings.TrimSpace(subject)
+	if subj != "" {
+		subj = " — " + subj
+	}
+	marker := ""
+	if archived {
+		marker = " [archived]"
+	}
+	sent := ""
+	if at := formatMessageTime(createdAt); at != "" {
+		sent = ", " + at
+	}
+	return fmt.Sprintf("- %s%s: %s (id: %s%s)%s\n", from, subj, body, messageID, sent, marker)
+}
+
 var awebMailOpenCmd = &cobra.Command{
 	Use:   "open <alias>",
 	Short: "Show unread messages from alias and acknowledge them",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		targetAlias := strings.TrimSpace(args[0])
 		if targetAlias == "" {
 			return fmt.Errorf("alias cannot be empty")
@@ -324,12 +505,12 @@ var awebMailOpenCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		client, err := aweb.NewWithAPIKey(identity.BaseURL, identity.APIKey)
+		client, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
 		resp, err := client.Inbox(ctx, aweb.InboxParams{
@@ -356,6 +537,9 @@ var awebMailOpenCmd = &cobra.Command{
 		}
 
 		if awebMailJSON {
+			for i := range filtered {
+				filtered[i].Body = readMessageBody(filtered[i].Body)
+			}
 			fmt.Print(marshalJSONOrFallback(struct {
 				From     string              `json:"from"`
 				Messages []aweb.InboxMessage `json:"messages"`
@@ -366,7 +550,10 @@ var awebMailOpenCmd = &cobra.Command{
 
 		fmt.Printf("Mail from %s (%d):\n\n", targetAlias, len(filtered))
 		for _, msg := range filtered {
-			fmt.Printf("%s\n\n", msg.Body)
+			if at := formatMessageTime(msg.CreatedAt); at != "" {
+				fmt.Printf("[%s]\n", at)
+			}
+			fmt.Printf("%s\n\n", renderMessageBody(msg.Body))
 		}
 		return nil
 	},
@@ -376,14 +563,23 @@ func init() {
 	awebMailCmd.AddCommand(awebMailSendCmd)
 	awebMailCmd.AddCommand(awebMailListCmd)
 	awebMailCmd.AddCommand(awebMailOpenCmd)
+	awebMailCmd.AddCommand(awebMailAckCmd)
 
 	awebMailCmd.PersistentFlags().BoolVar(&awebMailJSON, "json", false, "Output as JSON")
 
 	awebMailSendCmd.Flags().StringVar(&awebMailSubject, "subject", "", "Message subject")
 	awebMailSendCmd.Flags().StringVar(&awebMailPriority, "priority", "normal", "Priority: low|normal|high|urgent")
+	awebMailSendCmd.Flags().BoolVar(&awebMailNoBeadRefs, "no-bead-refs", false, "Don't append status/title of mentioned beads")
+	awebMailSendCmd.Flags().BoolVar(&awebMailEncrypt, "encrypt", false, "Encrypt the body for the recipient's published key")
 
 	awebMailListCmd.Flags().BoolVar(&awebMailAll, "all", false, "Include read messages")
 	awebMailListCmd.Flags().IntVar(&awebMailLimit, "limit", 50, "Max messages")
+	awebMailListCmd.Flags().BoolVar(&awebMailIncludeArchived, "include-archived", false, "Include archived messages")
+	awebMailListCmd.Flags().IntVar(&awebMailPage, "page", 0, "Show this page of --limit messages (1 is the newest)")
+	awebMailListCmd.Flags().BoolVar(&awebMailAllPages, "all-pages", false, "Fetch every page instead of the first --limit messages")
+	awebMailListCmd.Flags().StringVar(&awebMailSearch, "search", "", "Only messages whose sender, subject or body contain this text")
+
+	awebMailAckCmd.Flags().BoolVar(&awebMailArchive, "archive", false, "Also archive the message (mark it done)")
 }
 
 // =============================================================================
@@ -404,6 +600,7 @@ var awebLocksCmd = &cobra.Command{
 	Use:   "locks",
 	Short: "List active reservations",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		if len(args) != 0 {
 			return fmt.Errorf("locks takes no arguments")
 		}
@@ -411,12 +608,12 @@ var awebLocksCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		client, err := aweb.NewWithAPIKey(identity.BaseURL, identity.APIKey)
+		client, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
 		resp, err := client.ReservationList(ctx, awebLocksPrefix)
@@ -452,9 +649,13 @@ var awebLocksCmd = &cobra.Command{
 			return nil
 		}
 
-		now := time.Now()
+		now := serverNow()
 		for _, r := range res {
-			fmt.Printf("- %s — %s (expires in %s)\n", r.ResourceKey, r.HolderAlias, formatDuration(ttlRemainingSeconds(r.ExpiresAt, now)))
+			kind := ""
+			if isDirReservation(r.ResourceKey) {
+				kind = " (directory)"
+			}
+			fmt.Printf("- %s%s — %s (%s)\n", r.ResourceKey, kind, displayAlias(r.HolderAlias), formatExpiry(r.ExpiresAt, now))
 		}
 		return nil
 	},
@@ -468,25 +669,47 @@ var (
 var awebLockCmd = &cobra.Command{
 	Use:   "lock <resource_key>",
 	Short: "Acquire a reservation",
-	Args:  cobra.ExactArgs(1),
+	Long: `Acquire a reservation on a file, or on a whole directory with a
+trailing /** (src/api/** or src/api/). A directory reservation conflicts
+with every reservation under it, and a file conflicts with any directory
+reservation above it.
+
+Examples:
+  bdh :aweb lock src/api.py
+  bdh :aweb lock 'src/api/**'`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		resourceKey := strings.TrimSpace(args[0])
-		if resourceKey == "" {
+		ctx := cmd.Context()
+		if strings.TrimSpace(args[0]) == "" {
 			return fmt.Errorf("resource_key cannot be empty")
 		}
+		resourceKey, err := normalizeReservationKey(args[0])
+		if err != nil {
+			return err
+		}
 
 		identity, err := currentAgentIdentityForAweb()
 		if err != nil {
 			return err
 		}
-		client, err := aweb.NewWithAPIKey(identity.BaseURL, identity.APIKey)
+		client, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
+		// The server only refuses identical keys; overlaps are checked here
+		existing, err := client.ReservationList(ctx, "")
+		if err != nil {
+			return err
+		}
+		if r, ok := overlappingReservation(existing.Reservations, identity.AgentAlias, resourceKey); ok && r.ResourceKey != resourceKey {
+			return fmt.Errorf("%s overlaps %s held by %s (expires in %s)",
+				resourceKey, r.ResourceKey, r.HolderAlias, formatDuration(ttlRemainingSeconds(r.ExpiresAt, serverNow())))
+		}
+
 		resp, err := client.ReservationAcquire(ctx, &aweb.ReservationAcquireRequest{
 			ResourceKey: resourceKey,
 			TTLSeconds:  awebLockTTLSeconds,
@@ -517,21 +740,25 @@ var awebUnlockCmd = &cobra.Command{
 	Short: "Release a reservation",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		resourceKey := strings.TrimSpace(args[0])
-		if resourceKey == "" {
+		ctx := cmd.Context()
+		if strings.TrimSpace(args[0]) == "" {
 			return fmt.Errorf("resource_key cannot be empty")
 		}
+		resourceKey, err := normalizeReservationKey(args[0])
+		if err != nil {
+			return err
+		}
 
 		identity, err := currentAgentIdentityForAweb()
 		if err != nil {
 			return err
 		}
-		client, err := aweb.NewWithAPIKey(identity.BaseURL, identity.APIKey)
+		client, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
 		if err != nil {
 			return err
 		}
 
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
 		resp, err := client.ReservationRelease(ctx, &aweb.ReservationReleaseRequest{
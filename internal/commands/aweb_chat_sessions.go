@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+)
+
+// aw lists chat sessions (Client.ChatListSessions) but has no calls to
+// rename them or change their participants, and its Client keeps the base
+// URL and API key private. awebChatSessions covers those aweb routes with
+// the same credentials newAwebHTTPClientRequired resolves.
+
+// ChatSession is a chat session with its participants and optional name.
+type ChatSession struct {
+	SessionID    string   `json:"session_id"`
+	Name         string   `json:"name,omitempty"`
+	Participants []string `json:"participants"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+type awebChatSessions struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// awebChatSessionError is a non-2xx answer from a session route.
+type awebChatSessionError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *awebChatSessionError) Error() string {
+	return fmt.Sprintf("aweb: http %d: %s", e.StatusCode, e.Body)
+}
+
+func newAwebChatSessions(beadhubURL string) (*awebChatSessions, error) {
+	sel, err := resolveBeadhubAuth(beadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(sel.APIKey) == "" {
+		return nil, fmt.Errorf("missing beadhub API key (configure ~/.config/aw/config.yaml + .aw/context, or set BEADHUB_API_KEY)")
+	}
+	return &awebChatSessions{
+		baseURL:    strings.TrimRight(sel.BaseURL, "/"),
+		apiKey:     sel.APIKey,
+		httpClient: &http.Client{Timeout: aweb.DefaultTimeout},
+	}, nil
+}
+
+// Rename sets or clears (empty name) a session's display name.
+func (s *awebChatSessions) Rename(ctx context.Context, sessionID, name string) (*ChatSession, error) {
+	var resp ChatSession
+	path := "/v1/chat/sessions/" + url.PathEscape(sessionID) + "/name"
+	if err := s.do(ctx, http.MethodPost, path, map[string]string{"name": name}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AddParticipants adds agents to an existing session.
+func (s *awebChatSessions) AddParticipants(ctx context.Context, sessionID string, aliases []string) (*ChatSession, error) {
+	var resp ChatSession
+	path := "/v1/chat/sessions/" + url.PathEscape(sessionID) + "/participants"
+	if err := s.do(ctx, http.MethodPost, path, map[string][]string{"aliases": aliases}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveParticipant removes one agent from a session.
+func (s *awebChatSessions) RemoveParticipant(ctx context.Context, sessionID, alias string) (*ChatSession, error) {
+	var resp ChatSession
+	path := "/v1/chat/sessions/" + url.PathEscape(sessionID) + "/participants/" + url.PathEscape(alias)
+	if err := s.do(ctx, http.MethodDelete, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (s *awebChatSessions) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &awebChatSessionError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+	return json.Unmarshal(data, out)
+}
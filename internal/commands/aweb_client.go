@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -8,7 +9,73 @@ import (
 	aweb "github.com/awebai/aw"
 )
 
-func newAwebClient(beadhubURL string) (*aweb.Client, error) {
+// newAwebClient returns the aweb API for beadhubURL: the one installed in
+// the command context, if any, or else an HTTP client.
+func newAwebClient(ctx context.Context, beadhubURL string) (AwebAPI, error) {
+	if aw := injectedAwebAPI(ctx); aw != nil {
+		return aw, nil
+	}
+	c, err := newAwebHTTPClient(beadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newAwebClientRequired is newAwebClient for commands that need an API key.
+func newAwebClientRequired(ctx context.Context, beadhubURL string) (AwebAPI, error) {
+	if aw := injectedAwebAPI(ctx); aw != nil {
+		return aw, nil
+	}
+	c, err := newAwebHTTPClientRequired(beadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// awebClientFor is newAwebClient for a server and API key the caller has
+// already resolved.
+func awebClientFor(ctx context.Context, baseURL, apiKey string) (AwebAPI, error) {
+	if aw := injectedAwebAPI(ctx); aw != nil {
+		return aw, nil
+	}
+	c, err := aweb.NewWithAPIKey(baseURL, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newAwebChatClient returns the concrete client the aw/chat helpers take.
+// An injected *aweb.Client is used as is; any other injected AwebAPI cannot
+// drive chat, which is an error rather than a quiet fall back to HTTP.
+func newAwebChatClient(ctx context.Context, beadhubURL string) (*aweb.Client, error) {
+	if aw := injectedAwebAPI(ctx); aw != nil {
+		return injectedAwebChatClient(aw)
+	}
+	return newAwebHTTPClient(beadhubURL)
+}
+
+// newAwebChatClientRequired is newAwebChatClient for commands that need an
+// API key.
+func newAwebChatClientRequired(ctx context.Context, beadhubURL string) (*aweb.Client, error) {
+	if aw := injectedAwebAPI(ctx); aw != nil {
+		return injectedAwebChatClient(aw)
+	}
+	return newAwebHTTPClientRequired(beadhubURL)
+}
+
+func injectedAwebChatClient(aw AwebAPI) (*aweb.Client, error) {
+	c, ok := aw.(*aweb.Client)
+	if !ok {
+		return nil, fmt.Errorf("chat needs an HTTP aweb client, got %T", aw)
+	}
+	return c, nil
+}
+
+// newAwebHTTPClient always returns an HTTP client.
+func newAwebHTTPClient(beadhubURL string) (*aweb.Client, error) {
 	sel, err := resolveBeadhubAuth(beadhubURL)
 	if err == nil && strings.TrimSpace(sel.BaseURL) != "" && strings.TrimSpace(sel.APIKey) != "" {
 		return aweb.NewWithAPIKey(sel.BaseURL, sel.APIKey)
@@ -26,7 +93,7 @@ func newAwebClient(beadhubURL string) (*aweb.Client, error) {
 	return aweb.New(baseURL)
 }
 
-func newAwebClientRequired(beadhubURL string) (*aweb.Client, error) {
+func newAwebHTTPClientRequired(beadhubURL string) (*aweb.Client, error) {
 	sel, err := resolveBeadhubAuth(beadhubURL)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,14 @@
+package commands
+
+import "testing"
+
+func TestFormatMailListLine(t *testing.T) {
+	got := formatMailListLine("msg_1", "alice", "bd-42", "can you review?", "", false)
+	if want := "- alice — bd-42: can you review? (id: msg_1)\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	got = formatMailListLine("msg_2", "bob", "", "done", "2025-06-15T10:30:00Z", true)
+	if want := "- bob: done (id: msg_2) [archived]\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// :aweb who --watch redraws the agent list every --interval and marks what
+// changed since the previous refresh: agents coming online or going offline,
+// and claims appearing or disappearing. Recent changes stay listed below the
+// table so a human supervising a fleet can glance at it now and then.
+
+const (
+	defaultWhoWatchInterval = 5 * time.Second
+	minWhoWatchInterval     = time.Second
+	// maxWhoWatchChanges is how many recent changes stay on screen.
+	maxWhoWatchChanges = 10
+
+	ansiClearScreen = "\033[H\033[2J"
+	ansiGreen       = "\033[32m"
+	ansiRed         = "\033[31m"
+	ansiReset       = "\033[0m"
+)
+
+// WhoAgentState is one agent as shown by the watch view.
+type WhoAgentState struct {
+	Alias     string
+	AgentType string
+	Status    string
+	Online    bool
+	Claims    []string
+}
+
+// WhoChange is a difference between two refreshes. Up is true for agents
+// coming online and new claims, false for the opposite.
+type WhoChange struct {
+	At     time.Time
+	Alias  string
+	Detail string
+	Up     bool
+}
+
+// fetchWhoSnapshot lists the project's agents with their claims. Claims are
+// best-effort: without them the view still shows presence.
+func fetchWhoSnapshot(ctx context.Context, aw AwebAPI, bh BeadHubAPI) (string, map[string]WhoAgentState, error) {
+	resp, err := aw.ListAgents(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	snapshot := make(map[string]WhoAgentState, len(resp.Agents))
+	for _, agent := range resp.Agents {
+		snapshot[agent.Alias] = WhoAgentState{
+			Alias:     agent.Alias,
+			AgentType: agent.AgentType,
+			Status:    strings.TrimSpace(agent.Status),
+			Online:    agent.Online,
+		}
+	}
+
+	if bh != nil {
+		if ws, err := bh.Workspaces(ctx, &client.WorkspacesRequest{IncludeClaims: true, Limit: defaultStatusTeamLimit}); err == nil {
+			for _, w := range ws.Workspaces {
+				state, ok := snapshot[w.Alias]
+				if !ok {
+					continue
+				}
+				for _, claim := range w.Claims {
+					state.Claims = append(state.Claims, claim.BeadID)
+				}
+				sort.Strings(state.Claims)
+				snapshot[w.Alias] = state
+			}
+		}
+	}
+	return resp.ProjectID, snapshot, nil
+}
+
+// diffWhoSnapshots lists what changed from prev to cur, by alias.
+func diffWhoSnapshots(prev, cur map[string]WhoAgentState, at time.Time) []WhoChange {
+	aliases := make(map[string]bool, len(cur))
+	for alias := range prev {
+		aliases[alias] = true
+	}
+	for alias := range cur {
+		aliases[alias] = true
+	}
+	sorted := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		sorted = append(sorted, alias)
+	}
+	sort.Strings(sorted)
+
+	var changes []WhoChange
+	for _, alias := range sorted {
+		before, hadBefore := prev[alias]
+		after, hasAfter := cur[alias]
+		switch {
+		case !hadBefore && hasAfter && after.Online:
+			changes = append(changes, WhoChange{At: at, Alias: alias, Detail: "joined (online)", Up: true})
+		case !hadBefore && hasAfter:
+			changes = append(changes, WhoChange{At: at, Alias: alias, Detail: "joined", Up: true})
+		case hadBefore && !hasAfter:
+			changes = append(changes, WhoChange{At: at, Alias: alias, Detail: "left the project"})
+		case before.Online != after.Online && after.Online:
+			changes = append(changes, WhoChange{At: at, Alias: alias, Detail: "came online", Up: true})
+		case before.Online != after.Online:
+			changes = append(changes, WhoChange{At: at, Alias: alias, Detail: "went offline"})
+		}
+		added, removed := diffSets(before.Claims, after.Claims)
+		for _, id := range added {
+			changes = append(changes, WhoChange{At: at, Alias: alias, Detail: "claimed " + id, Up: true})
+		}
+		for _, id := range removed {
+			changes = append(changes, WhoChange{At: at, Alias: alias, Detail: "released " + id})
+		}
+	}
+	return changes
+}
+
+// renderWhoWatch draws one screen. Agents in changed are highlighted.
+func renderWhoWatch(projectID string, agents map[string]WhoAgentState, recent []WhoChange, changed map[string]bool, onlineOnly, color bool, interval time.Duration, now time.Time) string {
+	paint := func(s, ansi string) string {
+		if !color {
+			return s
+		}
+		return ansi + s + ansiReset
+	}
+
+	var online, offline []WhoAgentState
+	for _, agent := range agents {
+		if agent.Online {
+			online = append(online, agent)
+		} else {
+			offline = append(offline, agent)
+		}
+	}
+	sort.Slice(online, func(i, j int) bool { return online[i].Alias < online[j].Alias })
+	sort.Slice(offline, func(i, j int) bool { return offline[i].Alias < offline[j].Alias })
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Project: %s — %s (every %s, Ctrl-C to stop)\n\n", projectID, now.Format("15:04:05"), interval))
+	if len(online) > 0 {
+		sb.WriteString(fmt.Sprintf("ONLINE (%d)\n", len(online)))
+		for _, agent := range online {
+			desc := agent.Status
+			if desc == "" {
+				desc = "active"
+			}
+			line := fmt.Sprintf("  %s (%s) — %s", agent.Alias, agent.AgentType, desc)
+			if len(agent.Claims) > 0 {
+				line += " — " + strings.Join(agent.Claims, ", ")
+			}
+			if changed[agent.Alias] {
+				line = paint("* "+strings.TrimPrefix(line, "  "), ansiGreen)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	if len(offline) > 0 && !onlineOnly {
+		sb.WriteString(fmt.Sprintf("OFFLINE (%d)\n", len(offline)))
+		for _, agent := range offline {
+			line := fmt.Sprintf("  %s (%s)", agent.Alias, agent.AgentType)
+			if len(agent.Claims) > 0 {
+				line += " — " + strings.Join(agent.Claims, ", ")
+			}
+			if changed[agent.Alias] {
+				line = paint("* "+strings.TrimPrefix(line, "  "), ansiRed)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(recent) > 0 {
+		sb.WriteString("RECENT CHANGES\n")
+		for i := len(recent) - 1; i >= 0; i-- {
+			c := recent[i]
+			mark, ansi := "-", ansiRed
+			if c.Up {
+				mark, ansi = "+", ansiGreen
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s\n", c.At.Format("15:04:05"), paint(fmt.Sprintf("%s %s %s", mark, c.Alias, c.Detail), ansi)))
+		}
+	}
+	return sb.String()
+}
+
+// runAwebWhoWatch refreshes the who view until interrupted.
+func runAwebWhoWatch(ctx context.Context, aw AwebAPI) error {
+	if awebWhoInterval < minWhoWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWhoWatchInterval)
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	var bh BeadHubAPI
+	if c, err := newBeadHubClientRequired(ctx, ""); err == nil {
+		bh = c
+	}
+	color := term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == ""
+
+	var prev map[string]WhoAgentState
+	var recent []WhoChange
+	ticker := time.NewTicker(awebWhoInterval)
+	defer ticker.Stop()
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+		projectID, snapshot, err := fetchWhoSnapshot(fetchCtx, aw, bh)
+		cancel()
+		now := time.Now()
+
+		var screen string
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			screen = fmt.Sprintf("Refresh failed at %s: %v (retrying every %s)\n", now.Format("15:04:05"), err, awebWhoInterval)
+		} else {
+			changed := make(map[string]bool)
+			if prev != nil {
+				for _, c := range diffWhoSnapshots(prev, snapshot, now) {
+					changed[c.Alias] = true
+					recent = append(recent, c)
+				}
+				if len(recent) > maxWhoWatchChanges {
+					recent = recent[len(recent)-maxWhoWatchChanges:]
+				}
+			}
+			prev = snapshot
+			screen = renderWhoWatch(projectID, snapshot, recent, changed, awebWhoOnlineOnly, color, awebWhoInterval, now)
+		}
+		fmt.Print(ansiClearScreen + screen)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffWhoSnapshots(t *testing.T) {
+	at := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	prev := map[string]WhoAgentState{
+		"alice": {Alias: "alice", Online: true, Claims: []string{"bd-1", "bd-2"}},
+		"bob":   {Alias: "bob", Online: false},
+		"carol": {Alias: "carol", Online: true},
+	}
+	cur := map[string]WhoAgentState{
+		"alice": {Alias: "alice", Online: true, Claims: []string{"bd-2", "bd-3"}},
+		"bob":   {Alias: "bob", Online: true},
+		"dave":  {Alias: "dave", Online: true},
+	}
+
+	var got []string
+	for _, c := range diffWhoSnapshots(prev, cur, at) {
+		mark := "-"
+		if c.Up {
+			mark = "+"
+		}
+		got = append(got, mark+" "+c.Alias+" "+c.Detail)
+	}
+	want := []string{
+		"+ alice claimed bd-3",
+		"- alice released bd-1",
+		"+ bob came online",
+		"- carol left the project",
+		"+ dave joined (online)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\n%s", strings.Join(got, "\n"))
+	}
+	if changes := diffWhoSnapshots(cur, cur, at); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestRenderWhoWatch(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 30, 5, 0, time.UTC)
+	agents := map[string]WhoAgentState{
+		"alice": {Alias: "alice", AgentType: "agent", Online: true, Claims: []string{"bd-3"}},
+		"bob":   {Alias: "bob", AgentType: "agent", Online: false},
+	}
+	recent := []WhoChange{
+		{At: now.Add(-time.Minute), Alias: "bob", Detail: "went offline"},
+		{At: now, Alias: "alice", Detail: "claimed bd-3", Up: true},
+	}
+	out := renderWhoWatch("proj-1", agents, recent, map[string]bool{"alice": true}, false, false, 5*time.Second, now)
+	for _, want := range []string{
+		"Project: proj-1 — 09:30:05 (every 5s, Ctrl-C to stop)",
+		"ONLINE (1)\n* alice (agent) — active — bd-3\n",
+		"OFFLINE (1)\n  bob (agent)\n",
+		"RECENT CHANGES\n  09:30:05 + alice claimed bd-3\n  09:29:05 - bob went offline\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\033[") {
+		t.Error("unexpected color codes without color")
+	}
+
+	colored := renderWhoWatch("proj-1", agents, recent, map[string]bool{"alice": true}, true, true, 5*time.Second, now)
+	if !strings.Contains(colored, ansiGreen+"* alice") || strings.Contains(colored, "OFFLINE") {
+		t.Errorf("colored, online-only output:\n%q", colored)
+	}
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// bdAPIKeyEnv is passed to bd so integrations running under it (hooks,
+// plugins) can reach BeadHub as the selected account.
+const bdAPIKeyEnv = "BEADHUB_API_KEY"
+
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// parseBdEnvFlags checks --:bd-env values, each KEY=VALUE.
+func parseBdEnvFlags(values []string) ([]string, error) {
+	env := make([]string, 0, len(values))
+	for _, v := range values {
+		key, _, ok := strings.Cut(v, "=")
+		if !ok || !envVarNamePattern.MatchString(key) {
+			return nil, fmt.Errorf("--:bd-env expects KEY=VALUE, got %q", v)
+		}
+		env = append(env, v)
+	}
+	return env, nil
+}
+
+// bdEnv is the environment bdh adds for bd: bd.env from the config, then
+// --:bd-env values, which win. The selected account's API key is added when
+// neither these nor bdh's own environment set one.
+func bdEnv(cfg *config.Config, extra []string) []string {
+	var env []string
+	if cfg != nil {
+		env = append(env, cfg.BdEnv()...)
+	}
+	env = append(env, extra...)
+
+	if cfg == nil || apiKeyFromEnv() != "" {
+		return env
+	}
+	for _, kv := range env {
+		if strings.HasPrefix(kv, bdAPIKeyEnv+"=") {
+			return env
+		}
+	}
+	if sel, err := resolveBeadhubAuth(cfg.BeadhubURL); err == nil && strings.TrimSpace(sel.APIKey) != "" {
+		env = append(env, bdAPIKeyEnv+"="+sel.APIKey)
+	}
+	return env
+}
+
+// newBdRunner returns a bd runner with bd.command and bdEnv applied.
+func newBdRunner(cfg *config.Config, extra []string) *bd.Runner {
+	runner := bd.New()
+	if cfg != nil {
+		runner.Command = cfg.BdCommand()
+	}
+	runner.Env = bdEnv(cfg, extra)
+	return runner
+}
+
+// defaultBdRunner is newBdRunner for commands that may run without a
+// workspace: it uses the config when there is a valid one.
+func defaultBdRunner() *bd.Runner {
+	cfg, err := config.Load()
+	if err != nil || cfg.Validate() != nil {
+		cfg = nil
+	}
+	return newBdRunner(cfg, nil)
+}
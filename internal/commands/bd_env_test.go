@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/awebai/aw/awconfig"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestParseBdEnvFlags(t *testing.T) {
+	env, err := parseBdEnvFlags([]string{"BD_NO_DAEMON=1", "BEADS_DB=/tmp/a=b.db", "EMPTY="})
+	if err != nil || strings.Join(env, " ") != "BD_NO_DAEMON=1 BEADS_DB=/tmp/a=b.db EMPTY=" {
+		t.Errorf("env = %v, err = %v", env, err)
+	}
+	for _, bad := range []string{"", "BD_NO_DAEMON", "=1", "BD-MODE=fast"} {
+		if _, err := parseBdEnvFlags([]string{bad}); err == nil {
+			t.Errorf("parseBdEnvFlags(%q) should fail", bad)
+		}
+	}
+}
+
+func TestBdEnv_InheritsAccountAPIKey(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("AW_CONFIG_PATH", filepath.Join(tmp, "aw-config.yaml"))
+	t.Setenv("BEADHUB_API_KEY", "")
+	t.Setenv("BEADHUB_URL", "")
+	t.Chdir(tmp)
+
+	if err := awconfig.UpdateGlobalAt(os.Getenv("AW_CONFIG_PATH"), func(gc *awconfig.GlobalConfig) error {
+		gc.Servers = map[string]awconfig.Server{"localhost:8000": {URL: "http://localhost:8000"}}
+		gc.Accounts = map[string]awconfig.Account{"acct": {Server: "localhost:8000", APIKey: "aw_sk_account"}}
+		gc.DefaultAccount = "acct"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		BeadhubURL: "http://localhost:8000",
+		BD:         &config.BDConfig{Env: map[string]string{"BD_NO_DAEMON": "1", "BEADS_DB": "cfg.db"}},
+	}
+	got := strings.Join(bdEnv(cfg, []string{"BEADS_DB=flag.db"}), " ")
+	if got != "BD_NO_DAEMON=1 BEADS_DB=cfg.db BEADS_DB=flag.db BEADHUB_API_KEY=aw_sk_account" {
+		t.Errorf("bdEnv = %q", got)
+	}
+
+	// An explicit key, or one bdh itself runs with, is left alone
+	if got := bdEnv(cfg, []string{"BEADHUB_API_KEY=aw_sk_mine"}); strings.Count(strings.Join(got, " "), "BEADHUB_API_KEY") != 1 {
+		t.Errorf("explicit key: bdEnv = %v", got)
+	}
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_env")
+	if got := strings.Join(bdEnv(cfg, nil), " "); got != "BD_NO_DAEMON=1 BEADS_DB=cfg.db" {
+		t.Errorf("key from the environment: bdEnv = %q", got)
+	}
+}
+
+func TestNewBdRunner_Command(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_env")
+	cfg := &config.Config{BD: &config.BDConfig{Command: []string{"docker", "exec", "toolbox", "bd"}}}
+	if got := strings.Join(newBdRunner(cfg, nil).Command, " "); got != "docker exec toolbox bd" {
+		t.Errorf("Command = %q", got)
+	}
+	if runner := newBdRunner(nil, nil); runner.Command != nil || runner.BdPath != "bd" {
+		t.Errorf("without a config bd should run from PATH, got %+v", runner)
+	}
+}
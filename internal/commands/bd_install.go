@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/bd"
+)
+
+// When bd is not on PATH, bd commands stop before the pre-flight check with
+// install instructions for the platform instead of an exec error. bdh's own
+// coordination commands (:status, :aweb mail, :aweb chat, ...) never run bd
+// and keep working. --:install-bd downloads the pinned bd release into
+// ~/.local/bin, verifying it against checksums compiled into bdh.
+
+// pinnedBdVersion is the bd release --:install-bd installs.
+const pinnedBdVersion = "0.49.0"
+
+// pinnedBdChecksums lists the SHA-256 of each pinnedBdVersion asset. They
+// are compiled in rather than fetched next to the archive, so a tampered
+// release cannot vouch for itself.
+//
+//go:embed bd_checksums.txt
+var pinnedBdChecksums []byte
+
+// bdReleaseBaseURL is where bd releases are downloaded from.
+var bdReleaseBaseURL = "https://github.com/steveyegge/beads/releases/download"
+
+const (
+	bdInstallTimeout = 5 * time.Minute
+	maxBdArchiveSize = 200 << 20
+)
+
+// checkBdInstalled returns install instructions as an error when the bd that
+// runner runs cannot be found.
+func checkBdInstalled(runner *bd.Runner) error {
+	if _, err := runner.LookPath(); err != nil {
+		return bdNotInstalledError(runner, runtime.GOOS)
+	}
+	return nil
+}
+
+func bdNotInstalledError(runner *bd.Runner, goos string) error {
+	var sb strings.Builder
+	if len(runner.Command) > 0 {
+		fmt.Fprintf(&sb, "%s (from bd.command in .beadhub) not found on PATH.\n", runner.Executable())
+		sb.WriteString("Install it, or fix bd.command to run bd another way.\n")
+	} else {
+		sb.WriteString("bd (beads) not found on PATH; bdh runs bd for every bd command.\n\n")
+		sb.WriteString(bdInstallInstructions(goos))
+	}
+	sb.WriteString("\nCoordination commands work without bd: bdh :status, bdh :aweb mail list, bdh :aweb chat pending")
+	return errors.New(sb.String())
+}
+
+// bdInstallInstructions lists the ways to install bd on goos.
+func bdInstallInstructions(goos string) string {
+	var sb strings.Builder
+	sb.WriteString("Install bd:\n")
+	if goos == "darwin" {
+		sb.WriteString("  brew install steveyegge/beads/bd\n")
+	}
+	if goos != "windows" {
+		sb.WriteString("  curl -fsSL https://raw.githubusercontent.com/steveyegge/beads/main/scripts/install.sh | bash\n")
+	}
+	sb.WriteString("  go install github.com/steveyegge/beads/cmd/bd@latest\n")
+	if asset, err := bdReleaseAsset(goos, runtime.GOARCH); err == nil && hasPinnedBdChecksum(asset) {
+		fmt.Fprintf(&sb, "Or let bdh install bd %s into ~/.local/bin (checksum verified):\n", pinnedBdVersion)
+		sb.WriteString("  bdh --:install-bd\n")
+	}
+	return sb.String()
+}
+
+// bdReleaseAsset names the release archive for goos/goarch.
+func bdReleaseAsset(goos, goarch string) (string, error) {
+	switch goos + "/" + goarch {
+	case "linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64":
+		return fmt.Sprintf("beads_%s_%s_%s.tar.gz", pinnedBdVersion, goos, goarch), nil
+	case "windows/amd64", "windows/arm64":
+		return fmt.Sprintf("beads_%s_%s_%s.zip", pinnedBdVersion, goos, goarch), nil
+	}
+	return "", fmt.Errorf("no bd %s release for %s/%s - install bd from source: go install github.com/steveyegge/beads/cmd/bd@latest", pinnedBdVersion, goos, goarch)
+}
+
+// runInstallBd handles --:install-bd.
+func runInstallBd(ctx context.Context) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("finding home directory: %w", err)
+	}
+	dir := filepath.Join(home, ".local", "bin")
+	fmt.Printf("Installing bd %s into %s...\n", pinnedBdVersion, dir)
+
+	ctx, cancel := context.WithTimeout(ctx, bdInstallTimeout)
+	defer cancel()
+	path, err := installBd(ctx, dir, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return fmt.Errorf("installing bd: %w", err)
+	}
+	fmt.Printf("Installed %s (checksum verified)\n", path)
+	if !dirOnPath(dir) {
+		fmt.Printf("Note: %s is not on PATH. Add it, e.g.: export PATH=\"$HOME/.local/bin:$PATH\"\n", dir)
+	}
+	return nil
+}
+
+// installBd downloads the pinned bd release for goos/goarch, checks it
+// against pinnedBdChecksums and extracts the bd binary into dir. Returns the
+// installed path.
+func installBd(ctx context.Context, dir, goos, goarch string) (string, error) {
+	asset, err := bdReleaseAsset(goos, goarch)
+	if err != nil {
+		return "", err
+	}
+	want, ok := releaseChecksum(pinnedBdChecksums, asset)
+	if !ok {
+		return "", fmt.Errorf("this bdh has no checksum for %s, so it cannot verify it - install bd from source: go install github.com/steveyegge/beads/cmd/bd@latest", asset)
+	}
+	base := fmt.Sprintf("%s/v%s/", strings.TrimRight(bdReleaseBaseURL, "/"), pinnedBdVersion)
+	archive, err := downloadBdRelease(ctx, base+asset)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return "", fmt.Errorf("checksum mismatch for %s: got %s, want %s", asset, got, want)
+	}
+
+	name := "bd"
+	if goos == "windows" {
+		name = "bd.exe"
+	}
+	var binary []byte
+	if strings.HasSuffix(asset, ".zip") {
+		binary, err = extractFromZip(archive, name)
+	} else {
+		binary, err = extractFromTarGz(archive, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", asset, err)
+	}
+	return writeExecutable(dir, name, binary)
+}
+
+func downloadBdRelease(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: HTTP %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBdArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	if len(data) > maxBdArchiveSize {
+		return nil, fmt.Errorf("downloading %s: larger than %d MB", url, maxBdArchiveSize>>20)
+	}
+	return data, nil
+}
+
+func hasPinnedBdChecksum(asset string) bool {
+	_, ok := releaseChecksum(pinnedBdChecksums, asset)
+	return ok
+}
+
+// releaseChecksum finds asset's SHA-256 in a "<hex>  <name>" checksums file.
+func releaseChecksum(sums []byte, asset string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+func extractFromTarGz(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no %s in archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == name {
+			return io.ReadAll(io.LimitReader(tr, maxBdArchiveSize))
+		}
+	}
+}
+
+func extractFromZip(archive []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().Mode().IsRegular() && filepath.Base(f.Name) == name {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(io.LimitReader(rc, maxBdArchiveSize))
+		}
+	}
+	return nil, fmt.Errorf("no %s in archive", name)
+}
+
+// writeExecutable installs data as dir/name, replacing any previous file
+// atomically.
+func writeExecutable(dir, name string, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if err := writeFileAtomic(path, data, 0755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func dirOnPath(dir string) bool {
+	for _, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if filepath.Clean(entry) == filepath.Clean(dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/bd"
+)
+
+func bdTarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct {
+		name string
+		body []byte
+	}{{"README.md", []byte("beads")}, {name, content}} {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0755, Size: int64(len(f.body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(f.body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// bdReleaseServer serves the linux/amd64 archive of the pinned bd release
+// and compiles in sum as its checksum. It returns the number of downloads.
+func bdReleaseServer(t *testing.T, archive []byte, sum string) *int {
+	t.Helper()
+	asset, _ := bdReleaseAsset("linux", "amd64")
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v" + pinnedBdVersion + "/" + asset:
+			downloads++
+			w.Write(archive)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	origURL, origSums := bdReleaseBaseURL, pinnedBdChecksums
+	bdReleaseBaseURL = server.URL
+	pinnedBdChecksums = []byte(fmt.Sprintf("# test\n0000  beads_%s_darwin_arm64.tar.gz\n%s  %s\n", pinnedBdVersion, sum, asset))
+	t.Cleanup(func() { bdReleaseBaseURL, pinnedBdChecksums = origURL, origSums })
+	return &downloads
+}
+
+func TestInstallBd_VerifiesAndExtracts(t *testing.T) {
+	archive := bdTarGz(t, "beads/bd", []byte("#!/bin/sh\necho bd\n"))
+	sum := sha256.Sum256(archive)
+	bdReleaseServer(t, archive, hex.EncodeToString(sum[:]))
+
+	dir := filepath.Join(t.TempDir(), ".local", "bin")
+	path, err := installBd(context.Background(), dir, "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, "bd") {
+		t.Errorf("installed at %s", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		t.Errorf("bd is not executable: %v", info.Mode())
+	}
+	if data, _ := os.ReadFile(path); string(data) != "#!/bin/sh\necho bd\n" {
+		t.Errorf("unexpected contents %q", data)
+	}
+}
+
+func TestInstallBd_ChecksumMismatch(t *testing.T) {
+	archive := bdTarGz(t, "bd", []byte("tampered"))
+	bdReleaseServer(t, archive, strings.Repeat("ab", 32))
+
+	dir := t.TempDir()
+	if _, err := installBd(context.Background(), dir, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bd")); !os.IsNotExist(err) {
+		t.Error("a binary that failed verification must not be installed")
+	}
+	if _, err := installBd(context.Background(), dir, "plan9", "386"); err == nil || !strings.Contains(err.Error(), "go install") {
+		t.Errorf("expected an unsupported platform error, got %v", err)
+	}
+}
+
+func TestInstallBd_NoPinnedChecksum(t *testing.T) {
+	archive := bdTarGz(t, "bd", []byte("#!/bin/sh\n"))
+	sum := sha256.Sum256(archive)
+	downloads := bdReleaseServer(t, archive, hex.EncodeToString(sum[:]))
+
+	dir := t.TempDir()
+	if _, err := installBd(context.Background(), dir, "linux", "arm64"); err == nil || !strings.Contains(err.Error(), "no checksum") {
+		t.Fatalf("expected a missing checksum error, got %v", err)
+	}
+	if *downloads != 0 {
+		t.Errorf("nothing should be downloaded without a pinned checksum, got %d downloads", *downloads)
+	}
+}
+
+func TestBdNotInstalledError(t *testing.T) {
+	t.Setenv(bd.MockEnv, "")
+	runner := &bd.Runner{BdPath: "/nonexistent/bd"}
+	err := checkBdInstalled(runner)
+	if err == nil {
+		t.Fatal("expected an error for a missing bd")
+	}
+
+	darwin := bdNotInstalledError(runner, "darwin").Error()
+	for _, want := range []string{"bd (beads) not found on PATH", "brew install", "go install github.com/steveyegge/beads/cmd/bd@latest", "bdh :status"} {
+		if !strings.Contains(darwin, want) {
+			t.Errorf("missing %q in:\n%s", want, darwin)
+		}
+	}
+	if linux := bdNotInstalledError(runner, "linux").Error(); strings.Contains(linux, "brew") || !strings.Contains(linux, "install.sh") {
+		t.Errorf("unexpected linux instructions:\n%s", linux)
+	}
+
+	wrapped := bdNotInstalledError(&bd.Runner{Command: []string{"devbox", "run", "bd"}}, "linux").Error()
+	if !strings.Contains(wrapped, "devbox (from bd.command in .beadhub) not found") {
+		t.Errorf("expected the wrapper to be named:\n%s", wrapped)
+	}
+
+	t.Setenv(bd.MockEnv, "1")
+	if err := checkBdInstalled(runner); err != nil {
+		t.Errorf("the mock needs no bd: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// With bd.pty set, bd runs on a pseudo-terminal and its output is shown as it
+// is produced, colors and progress bars included, instead of being captured
+// and reprinted after coordination. bdh's own sections still follow it.
+
+// useBdPTY reports whether this bd invocation should run on a pseudo-terminal.
+// JSON output and help are captured as usual: the first is parsed and the
+// second rewritten to say bdh.
+func useBdPTY(cfg *config.Config, jsonMode bool, args []string) bool {
+	if !cfg.BdPTYEnabled() || jsonMode || isBdHelpInvocation(args) {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// isBdHelpInvocation reports whether args ask bd for help.
+func isBdHelpInvocation(args []string) bool {
+	if len(args) == 0 {
+		return true
+	}
+	for _, arg := range args {
+		switch arg {
+		case "help", "--help", "-h":
+			return true
+		}
+	}
+	return false
+}
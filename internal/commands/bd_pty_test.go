@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestUseBdPTY_OffByDefaultAndForJSONOrHelp(t *testing.T) {
+	on := true
+	cfg := &config.Config{BD: &config.BDConfig{PTY: &on}}
+	if useBdPTY(&config.Config{}, false, []string{"list"}) {
+		t.Error("bd.pty unset should not use a pseudo-terminal")
+	}
+	if useBdPTY(cfg, true, []string{"list", "--json"}) {
+		t.Error("JSON output must be captured")
+	}
+	if useBdPTY(cfg, false, []string{"list", "--help"}) {
+		t.Error("help must be captured so it can be rewritten")
+	}
+}
+
+func TestFormatPassthroughOutput_StreamedOutputNotRepeated(t *testing.T) {
+	result := &PassthroughResult{Stdout: "bd-1 Fix login\n", Stderr: "note\n", BdStreamed: true, SyncWarning: "sync slow"}
+	out := formatPassthroughOutput(result)
+	if strings.Contains(out, "Fix login") || strings.Contains(out, "note") {
+		t.Errorf("streamed bd output printed again:\n%s", out)
+	}
+	result.Verbosity = config.VerbosityQuiet
+	if out := formatPassthroughOutput(result); strings.Contains(out, "Fix login") {
+		t.Errorf("quiet: streamed bd output printed again:\n%s", out)
+	}
+}
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/awebai/aw/chat"
+)
+
+// Bead IDs mentioned in outgoing mail and chat get a footer with their title
+// and status from issues.jsonl, so the recipient has context without asking.
+// When messages are displayed, mentioned beads are annotated with their live
+// local status ("bd-42 [closed]"), falling back to the sender's snapshot in
+// the footer for beads this repo doesn't have.
+
+const (
+	beadRefsFooter = "\n\n-- beads --\n"
+	maxBeadRefs    = 10
+)
+
+var beadRefPattern = regexp.MustCompile(`[A-Za-z][A-Za-z0-9]*-[A-Za-z0-9]+(?:\.[0-9]+)*`)
+
+// footerLinePattern parses "bd-42 [in_progress] Fix login" footer lines.
+var footerLinePattern = regexp.MustCompile(`^(\S+) \[([^\]]*)\](?: (.*))?$`)
+
+// BeadRef is a bead mentioned in a message.
+type BeadRef struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Title  string `json:"title,omitempty"`
+}
+
+var (
+	beadRefIndexOnce sync.Once
+	beadRefIndex     map[string]Issue
+)
+
+// localIssueIndex returns this repo's issues by ID, loaded once per process.
+// Returns nil when there is no readable issues.jsonl.
+func localIssueIndex() map[string]Issue {
+	beadRefIndexOnce.Do(func() {
+		issues, err := loadIssues()
+		if err != nil {
+			return
+		}
+		beadRefIndex = make(map[string]Issue, len(issues))
+		for _, issue := range issues {
+			beadRefIndex[issue.ID] = issue
+		}
+	})
+	return beadRefIndex
+}
+
+func isBeadRefBoundary(b byte) bool {
+	return !(b == '-' || b == '_' || b == '/' ||
+		b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z')
+}
+
+// beadRefMatches returns the [start, end) positions of whole-token bead ID
+// candidates in text, so "bd-42" matches in "see bd-42." but not in "bd-42-fix".
+func beadRefMatches(text string) [][]int {
+	var matches [][]int
+	for _, m := range beadRefPattern.FindAllStringIndex(text, -1) {
+		if m[0] > 0 && !isBeadRefBoundary(text[m[0]-1]) {
+			continue
+		}
+		if m[1] < len(text) && !isBeadRefBoundary(text[m[1]]) {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// findBeadRefs returns the known beads mentioned in text, in order of first
+// mention.
+func findBeadRefs(text string, issues map[string]Issue) []BeadRef {
+	seen := make(map[string]bool)
+	var refs []BeadRef
+	for _, m := range beadRefMatches(text) {
+		id := text[m[0]:m[1]]
+		issue, ok := issues[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		refs = append(refs, BeadRef{ID: id, Status: issue.Status, Title: issue.Title})
+		if len(refs) == maxBeadRefs {
+			break
+		}
+	}
+	return refs
+}
+
+// withBeadRefs appends a footer describing the beads mentioned in body.
+// Bodies without known beads, or that already carry a footer, are unchanged.
+func withBeadRefs(body string, issues map[string]Issue) string {
+	if strings.Contains(body, beadRefsFooter) {
+		return body
+	}
+	refs := findBeadRefs(body, issues)
+	if len(refs) == 0 {
+		return body
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(body, "\n"))
+	sb.WriteString(beadRefsFooter)
+	for i, ref := range refs {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("%s [%s]", ref.ID, ref.Status))
+		if title := strings.Join(strings.Fields(ref.Title), " "); title != "" {
+			sb.WriteString(" " + title)
+		}
+	}
+	return sb.String()
+}
+
+// splitBeadRefs separates a message body from its bead footer.
+func splitBeadRefs(body string) (string, []BeadRef) {
+	idx := strings.LastIndex(body, beadRefsFooter)
+	if idx < 0 {
+		return body, nil
+	}
+	var refs []BeadRef
+	for _, line := range strings.Split(body[idx+len(beadRefsFooter):], "\n") {
+		m := footerLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			// Not a footer we wrote; leave the body alone.
+			return body, nil
+		}
+		refs = append(refs, BeadRef{ID: m[1], Status: m[2], Title: m[3]})
+	}
+	return body[:idx], refs
+}
+
+// renderBeadRefs drops the bead footer from body and annotates each mentioned
+// bead with its status: live from issues when known locally, otherwise the
+// sender's snapshot from the footer.
+func renderBeadRefs(body string, issues map[string]Issue) string {
+	text, footer := splitBeadRefs(body)
+	snapshot := make(map[string]string, len(footer))
+	for _, ref := range footer {
+		snapshot[ref.ID] = ref.Status
+	}
+	if len(issues) == 0 && len(snapshot) == 0 {
+		return text
+	}
+	statusOf := func(id string) string {
+		if issue, ok := issues[id]; ok {
+			return issue.Status
+		}
+		return snapshot[id]
+	}
+
+	var sb strings.Builder
+	last := 0
+	annotated := make(map[string]bool)
+	for _, m := range beadRefMatches(text) {
+		id := text[m[0]:m[1]]
+		st := statusOf(id)
+		if st == "" || annotated[id] || strings.HasPrefix(text[m[1]:], " [") {
+			continue
+		}
+		annotated[id] = true
+		sb.WriteString(text[last:m[1]])
+		sb.WriteString(" [" + st + "]")
+		last = m[1]
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// renderMessageBody decrypts a sealed body and annotates bead references
+// against this repo's issues.
+func renderMessageBody(body string) string {
+	return renderBeadRefs(readMessageBody(body), localIssueIndex())
+}
+
+// outgoingMessageBody adds the bead footer unless disabled with --no-bead-refs.
+func outgoingMessageBody(body string, disabled bool) string {
+	if disabled {
+		return body
+	}
+	return withBeadRefs(body, localIssueIndex())
+}
+
+// renderChatEvents annotates bead references in chat messages for display.
+func renderChatEvents(events []chat.Event) {
+	for i := range events {
+		events[i].Body = renderMessageBody(events[i].Body)
+	}
+}
+
+// decryptChatEvents decrypts sealed chat messages for --json output, which
+// carries bodies without bead annotations.
+func decryptChatEvents(events []chat.Event) {
+	for i := range events {
+		events[i].Body = decryptMessageBody(events[i].Body)
+	}
+}
+
+// renderChatSendResult prepares the reply and events of a send or listen
+// for display, or only decrypts them for --json.
+func renderChatSendResult(result *chat.SendResult, asJSON bool) {
+	if asJSON {
+		result.Reply = decryptMessageBody(result.Reply)
+		decryptChatEvents(result.Events)
+		return
+	}
+	result.Reply = renderMessageBody(result.Reply)
+	renderChatEvents(result.Events)
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithBeadRefs(t *testing.T) {
+	issues := map[string]Issue{
+		"bd-42":   {ID: "bd-42", Title: "Fix   login\nflow", Status: "in_progress"},
+		"bd-42.1": {ID: "bd-42.1", Title: "Subtask", Status: "open"},
+		"bd-7":    {ID: "bd-7", Title: "Old", Status: "closed"},
+	}
+
+	got := withBeadRefs("bd-42 is blocked on bd-42.1 (see bd-42, branch bd-7-fix, bd-99)", issues)
+	want := "bd-42 is blocked on bd-42.1 (see bd-42, branch bd-7-fix, bd-99)" +
+		beadRefsFooter + "bd-42 [in_progress] Fix login flow\nbd-42.1 [open] Subtask"
+	if got != want {
+		t.Errorf("withBeadRefs =\n%q\nwant\n%q", got, want)
+	}
+
+	if got := withBeadRefs("nothing to see", issues); got != "nothing to see" {
+		t.Errorf("body without beads changed: %q", got)
+	}
+	if again := withBeadRefs(want, issues); again != want {
+		t.Errorf("footer added twice: %q", again)
+	}
+}
+
+func TestRenderBeadRefs(t *testing.T) {
+	sent := withBeadRefs("Done with bd-42; bd-9 is next", map[string]Issue{
+		"bd-42": {ID: "bd-42", Title: "Fix login", Status: "in_progress"},
+		"bd-9":  {ID: "bd-9", Title: "Next", Status: "open"},
+	})
+
+	// The recipient has bd-42 locally (now closed) but not bd-9.
+	local := map[string]Issue{"bd-42": {ID: "bd-42", Status: "closed"}}
+	if got := renderBeadRefs(sent, local); got != "Done with bd-42 [closed]; bd-9 [open] is next" {
+		t.Errorf("render = %q", got)
+	}
+
+	// Without a footer, only local beads are annotated, once each.
+	if got := renderBeadRefs("bd-42 then bd-42 again, bd-5", local); got != "bd-42 [closed] then bd-42 again, bd-5" {
+		t.Errorf("render = %q", got)
+	}
+	// Already-annotated mentions are left alone.
+	if got := renderBeadRefs("bd-42 [open] per sender", local); got != "bd-42 [open] per sender" {
+		t.Errorf("render = %q", got)
+	}
+	// A trailing section that isn't our footer is kept verbatim.
+	odd := "hi" + beadRefsFooter + "not a footer line"
+	if got := renderBeadRefs(odd, nil); got != odd {
+		t.Errorf("render = %q", got)
+	}
+}
+
+func TestOutgoingMessageBody_Disabled(t *testing.T) {
+	if got := outgoingMessageBody("see bd-1", true); strings.Contains(got, beadRefsFooter) {
+		t.Errorf("footer added with --no-bead-refs: %q", got)
+	}
+}
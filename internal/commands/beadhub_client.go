@@ -1,12 +1,16 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/awebai/aw/awconfig"
 	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
 )
 
 type beadhubAuthSelection struct {
@@ -72,7 +76,13 @@ func resolveBeadhubAuth(beadhubURLHint string) (*beadhubAuthSelection, error) {
 	}, nil
 }
 
-func newBeadHubClient(beadhubURL string) *client.Client {
+// newBeadHubClient returns the BeadHub API for beadhubURL: the one
+// installed in the command context, if any, or else an HTTP client that may
+// lack credentials.
+func newBeadHubClient(ctx context.Context, beadhubURL string) BeadHubAPI {
+	if bh := injectedBeadHubAPI(ctx); bh != nil {
+		return bh
+	}
 	sel, err := resolveBeadhubAuth(beadhubURL)
 	if err == nil && strings.TrimSpace(sel.APIKey) != "" {
 		return client.NewWithAPIKey(sel.BaseURL, sel.APIKey)
@@ -86,7 +96,24 @@ func newBeadHubClient(beadhubURL string) *client.Client {
 	return client.New(resolveConfig("", "BEADHUB_URL", "http://localhost:8000"))
 }
 
-func newBeadHubClientRequired(beadhubURL string) (*client.Client, error) {
+// beadHubClientFor is newBeadHubClient for a server and API key the caller
+// has already resolved. An empty apiKey gives an unauthenticated client.
+func beadHubClientFor(ctx context.Context, baseURL, apiKey string) BeadHubAPI {
+	if bh := injectedBeadHubAPI(ctx); bh != nil {
+		return bh
+	}
+	if apiKey == "" {
+		return client.New(baseURL)
+	}
+	return client.NewWithAPIKey(baseURL, apiKey)
+}
+
+// newBeadHubClientRequired is newBeadHubClient for commands that need an
+// API key.
+func newBeadHubClientRequired(ctx context.Context, beadhubURL string) (BeadHubAPI, error) {
+	if bh := injectedBeadHubAPI(ctx); bh != nil {
+		return bh, nil
+	}
 	sel, err := resolveBeadhubAuth(beadhubURL)
 	if err != nil {
 		return nil, err
@@ -96,3 +123,33 @@ func newBeadHubClientRequired(beadhubURL string) (*client.Client, error) {
 	}
 	return client.NewWithAPIKey(sel.BaseURL, sel.APIKey), nil
 }
+
+// prewarmBeadhubConnection starts connecting to the configured server in the
+// background when http.prewarm is enabled in .beadhub.
+func prewarmBeadhubConnection(ctx context.Context) {
+	cfg, err := config.Load()
+	if err != nil || !cfg.HTTPPrewarmEnabled() || strings.TrimSpace(cfg.BeadhubURL) == "" {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		defer cancel()
+		client.Prewarm(ctx, cfg.BeadhubURL)
+	}()
+}
+
+// configureHTTPDecoding turns on strict response decoding when
+// http.strict_decoding is set in .beadhub.
+func configureHTTPDecoding() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	client.SetStrictDecoding(cfg.HTTPStrictDecodingEnabled())
+}
+
+func printHTTPStats(w io.Writer) {
+	stats := client.Stats()
+	fmt.Fprintf(w, "HTTP: %d requests, %d new connections, %d reused, %d TLS handshakes (%s)\n",
+		stats.Requests, stats.NewConns, stats.ReusedConns, stats.TLSHandshakes, stats.TLSHandshake.Round(time.Millisecond))
+}
@@ -0,0 +1,414 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+// :bench times the client-side sync pipeline on synthetic issues so
+// regressions on large repos show up as numbers instead of "feels slow".
+// Nothing is written to the server: the sync payload is built and encoded
+// but never sent, and the ready-context stage only issues the same read-only
+// queries `bdh ready` does.
+
+const (
+	defaultBenchIssues     = 1000
+	defaultBenchIterations = 5
+	maxBenchIssues         = 1000000
+)
+
+var (
+	benchIssues     int
+	benchIterations int
+	benchChanged    float64
+	benchServer     string
+	benchMock       bool
+	benchJSON       bool
+)
+
+var benchCmd = &cobra.Command{
+	Use:   ":bench",
+	Short: "Benchmark sync and ready performance on synthetic issues",
+	Long: `Generate synthetic issues and time each stage of the sync pipeline:
+
+  export          encode the issues as issues.jsonl
+  hash            compute per-issue content hashes
+  diff            find changed/deleted issues and extract the incremental payload
+  full-payload    build and encode a full sync request
+  ready-context   fetch team status and locks (as 'bdh ready' does)
+
+The ready-context stage runs against the configured BeadHub server, the one
+given with --server, or an in-process mock with --mock (the default when no
+.beadhub exists). Only read-only queries are sent; no sync is performed.
+
+Examples:
+  bdh :bench
+  bdh :bench --issues 20000 --iterations 10
+  bdh :bench --mock --json`,
+	Args: cobra.NoArgs,
+	RunE: runBench,
+}
+
+func init() {
+	benchCmd.Flags().IntVar(&benchIssues, "issues", defaultBenchIssues, "Number of synthetic issues to generate")
+	benchCmd.Flags().IntVar(&benchIterations, "iterations", defaultBenchIterations, "Runs per stage")
+	benchCmd.Flags().Float64Var(&benchChanged, "changed-percent", 1, "Percent of issues modified for the incremental diff")
+	benchCmd.Flags().StringVar(&benchServer, "server", "", "BeadHub URL for the ready-context stage (default: configured server)")
+	benchCmd.Flags().BoolVar(&benchMock, "mock", false, "Use an in-process mock server for the ready-context stage")
+	benchCmd.Flags().BoolVar(&benchJSON, "json", false, "Output as JSON")
+}
+
+// BenchOptions configures a benchmark run.
+type BenchOptions struct {
+	Issues         int
+	Iterations     int
+	ChangedPercent float64
+	ServerURL      string // empty with Mock=false skips the ready-context stage
+	Mock           bool
+	WorkspaceID    string
+}
+
+// BenchStage is the timing summary of one pipeline stage.
+type BenchStage struct {
+	Name     string `json:"name"`
+	MinMS    int64  `json:"min_ms"`
+	MedianMS int64  `json:"median_ms"`
+	MaxMS    int64  `json:"max_ms"`
+	Bytes    int    `json:"bytes,omitempty"`
+	Error    string `json:"error,omitempty"`
+
+	samples []time.Duration
+}
+
+// BenchResult is the output of :bench.
+type BenchResult struct {
+	Issues       int          `json:"issues"`
+	Iterations   int          `json:"iterations"`
+	ChangedCount int          `json:"changed_count"`
+	Server       string       `json:"server"`
+	Stages       []BenchStage `json:"stages"`
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	opts := BenchOptions{
+		Issues:         benchIssues,
+		Iterations:     benchIterations,
+		ChangedPercent: benchChanged,
+		ServerURL:      strings.TrimSpace(benchServer),
+		Mock:           benchMock,
+	}
+
+	if !opts.Mock && opts.ServerURL == "" {
+		cfg, err := config.Load()
+		switch {
+		case err == nil:
+			opts.ServerURL = cfg.BeadhubURL
+			opts.WorkspaceID = cfg.WorkspaceID
+		case os.IsNotExist(err):
+			opts.Mock = true
+		default:
+			return fmt.Errorf("loading config: %w", err)
+		}
+	}
+
+	result, err := runBenchWithOptions(ctx, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatBenchOutput(result, benchJSON))
+	return nil
+}
+
+func runBenchWithOptions(ctx context.Context, opts BenchOptions) (*BenchResult, error) {
+	if opts.Issues <= 0 || opts.Issues > maxBenchIssues {
+		return nil, fmt.Errorf("--issues must be between 1 and %d", maxBenchIssues)
+	}
+	if opts.Iterations <= 0 {
+		return nil, fmt.Errorf("--iterations must be at least 1")
+	}
+	if opts.ChangedPercent < 0 || opts.ChangedPercent > 100 {
+		return nil, fmt.Errorf("--changed-percent must be between 0 and 100")
+	}
+
+	issues := syntheticIssues(opts.Issues)
+	changed := int(float64(opts.Issues) * opts.ChangedPercent / 100)
+	if changed == 0 && opts.ChangedPercent > 0 {
+		changed = 1
+	}
+
+	result := &BenchResult{
+		Issues:       opts.Issues,
+		Iterations:   opts.Iterations,
+		ChangedCount: changed,
+	}
+
+	// export
+	var content []byte
+	export := &BenchStage{Name: "export"}
+	for i := 0; i < opts.Iterations; i++ {
+		start := time.Now()
+		data, err := encodeIssuesJSONL(issues)
+		export.samples = append(export.samples, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("encoding synthetic issues: %w", err)
+		}
+		content = data
+	}
+	export.Bytes = len(content)
+	result.Stages = append(result.Stages, export.summarize())
+
+	// hash
+	var baseline map[string]string
+	hash := &BenchStage{Name: "hash"}
+	for i := 0; i < opts.Iterations; i++ {
+		start := time.Now()
+		hashes, err := sync.ComputeIssueHashes(content)
+		hash.samples = append(hash.samples, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("hashing synthetic issues: %w", err)
+		}
+		baseline = hashes
+	}
+	result.Stages = append(result.Stages, hash.summarize())
+
+	// diff: edit some issues, delete one, then do what an incremental sync does.
+	modified := make([]map[string]any, len(issues))
+	copy(modified, issues)
+	for i := 0; i < changed && i < len(modified); i++ {
+		edited := make(map[string]any, len(modified[i]))
+		for k, v := range modified[i] {
+			edited[k] = v
+		}
+		edited["status"] = "in_progress"
+		edited["updated_at"] = "2025-02-01T00:00:00Z"
+		modified[i] = edited
+	}
+	if len(modified) > 1 {
+		modified = modified[:len(modified)-1]
+	}
+	modifiedContent, err := encodeIssuesJSONL(modified)
+	if err != nil {
+		return nil, fmt.Errorf("encoding synthetic issues: %w", err)
+	}
+	diff := &BenchStage{Name: "diff"}
+	for i := 0; i < opts.Iterations; i++ {
+		start := time.Now()
+		current, err := sync.ComputeIssueHashes(modifiedContent)
+		if err == nil {
+			changedIDs := sync.FindChangedIssues(current, baseline)
+			sync.FindDeletedIssues(current, baseline)
+			var payload string
+			payload, err = sync.ExtractIssuesByID(modifiedContent, changedIDs)
+			diff.Bytes = len(payload)
+		}
+		diff.samples = append(diff.samples, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("diffing synthetic issues: %w", err)
+		}
+	}
+	result.Stages = append(result.Stages, diff.summarize())
+
+	// full-payload
+	full := &BenchStage{Name: "full-payload"}
+	for i := 0; i < opts.Iterations; i++ {
+		start := time.Now()
+		data, err := json.Marshal(&client.SyncRequest{
+			WorkspaceID: "bench",
+			Alias:       "bench",
+			SyncMode:    "full",
+			IssuesJSONL: string(content),
+		})
+		full.samples = append(full.samples, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("encoding sync request: %w", err)
+		}
+		full.Bytes = len(data)
+	}
+	result.Stages = append(result.Stages, full.summarize())
+
+	// ready-context
+	serverURL := opts.ServerURL
+	if opts.Mock {
+		mock := newBenchMockServer()
+		defer mock.Close()
+		serverURL = mock.URL
+		result.Server = "mock"
+	} else {
+		result.Server = serverURL
+	}
+	if serverURL != "" {
+		result.Stages = append(result.Stages, benchReadyContext(ctx, serverURL, opts.WorkspaceID, opts.Iterations))
+	}
+
+	return result, nil
+}
+
+// benchReadyContext times the team and lock queries `bdh ready` makes.
+// Failures are reported on the stage instead of aborting the run.
+func benchReadyContext(ctx context.Context, serverURL, workspaceID string, iterations int) BenchStage {
+	stage := &BenchStage{Name: "ready-context"}
+	c := newBeadHubClient(ctx, serverURL)
+	aw, err := newAwebClient(ctx, serverURL)
+	if err != nil {
+		stage.Error = err.Error()
+		return stage.summarize()
+	}
+
+	includeClaims := true
+	includePresence := true
+	onlyWithClaims := false
+	for i := 0; i < iterations; i++ {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		start := time.Now()
+		_, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+			IncludeClaims:            &includeClaims,
+			IncludePresence:          &includePresence,
+			OnlyWithClaims:           &onlyWithClaims,
+			AlwaysIncludeWorkspaceID: workspaceID,
+			Limit:                    defaultReadyTeamLimit + readyTeamQueryOverflow,
+		})
+		if err == nil {
+			_, err = aw.ReservationList(ctx, "")
+		}
+		stage.samples = append(stage.samples, time.Since(start))
+		cancel()
+		if err != nil {
+			stage.Error = err.Error()
+			break
+		}
+	}
+	return stage.summarize()
+}
+
+// newBenchMockServer answers the ready-context queries with a small team.
+func newBenchMockServer() *httptest.Server {
+	team := make([]map[string]any, 0, 8)
+	for i := 0; i < 8; i++ {
+		team = append(team, map[string]any{
+			"workspace_id": fmt.Sprintf("ws-%d", i),
+			"alias":        fmt.Sprintf("agent-%d", i),
+			"status":       "active",
+			"claims":       []any{map[string]any{"bead_id": fmt.Sprintf("bench-%d", i), "title": "synthetic"}},
+		})
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/workspaces/team":
+			_ = json.NewEncoder(w).Encode(map[string]any{"workspaces": team, "count": len(team)})
+		case "/v1/reservations":
+			_ = json.NewEncoder(w).Encode(map[string]any{"reservations": []any{}, "count": 0})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// syntheticIssues generates n issues shaped like a bd export.
+func syntheticIssues(n int) []map[string]any {
+	statuses := []string{"open", "open", "open", "in_progress", "closed"}
+	types := []string{"task", "bug", "feature", "epic"}
+	issues := make([]map[string]any, n)
+	for i := 0; i < n; i++ {
+		issue := map[string]any{
+			"id":          fmt.Sprintf("bench-%d", i+1),
+			"title":       fmt.Sprintf("Synthetic issue %d", i+1),
+			"description": strings.Repeat("Benchmark description text. ", 8),
+			"status":      statuses[i%len(statuses)],
+			"priority":    i % 5,
+			"issue_type":  types[i%len(types)],
+			"labels":      []string{"bench", fmt.Sprintf("area-%d", i%10)},
+			"created_at":  "2025-01-01T00:00:00Z",
+			"updated_at":  "2025-01-01T00:00:00Z",
+		}
+		if i > 0 && i%3 == 0 {
+			issue["dependencies"] = []map[string]any{{
+				"issue_id":      issue["id"],
+				"depends_on_id": fmt.Sprintf("bench-%d", i),
+				"type":          "blocks",
+			}}
+		}
+		issues[i] = issue
+	}
+	return issues
+}
+
+func encodeIssuesJSONL(issues []map[string]any) ([]byte, error) {
+	var sb strings.Builder
+	for _, issue := range issues {
+		line, err := json.Marshal(issue)
+		if err != nil {
+			return nil, err
+		}
+		sb.Write(line)
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String()), nil
+}
+
+func (s *BenchStage) summarize() BenchStage {
+	out := *s
+	out.samples = nil
+	if len(s.samples) == 0 {
+		return out
+	}
+	sorted := append([]time.Duration(nil), s.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	out.MinMS = sorted[0].Milliseconds()
+	out.MedianMS = sorted[len(sorted)/2].Milliseconds()
+	out.MaxMS = sorted[len(sorted)-1].Milliseconds()
+	return out
+}
+
+func formatBenchOutput(result *BenchResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Benchmark: %d issues, %d changed, %d iterations", result.Issues, result.ChangedCount, result.Iterations))
+	if result.Server != "" {
+		sb.WriteString(fmt.Sprintf(" (server: %s)", result.Server))
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("%-14s %9s %9s %9s %10s\n", "STAGE", "MIN", "MEDIAN", "MAX", "SIZE"))
+	for _, s := range result.Stages {
+		size := ""
+		if s.Bytes > 0 {
+			size = formatBenchBytes(s.Bytes)
+		}
+		sb.WriteString(fmt.Sprintf("%-14s %7dms %7dms %7dms %10s\n", s.Name, s.MinMS, s.MedianMS, s.MaxMS, size))
+		if s.Error != "" {
+			sb.WriteString(fmt.Sprintf("  error: %s\n", s.Error))
+		}
+	}
+	if result.Server == "" {
+		sb.WriteString("\nready-context skipped (no server configured; use --mock or --server)\n")
+	}
+	return sb.String()
+}
+
+func formatBenchBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRunBenchWithOptions_Mock(t *testing.T) {
+	result, err := runBenchWithOptions(context.Background(), BenchOptions{Issues: 200, Iterations: 2, ChangedPercent: 5, Mock: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ChangedCount != 10 || result.Server != "mock" {
+		t.Errorf("result = %+v", result)
+	}
+
+	var names []string
+	for _, s := range result.Stages {
+		names = append(names, s.Name)
+		if s.Error != "" {
+			t.Errorf("stage %s failed: %s", s.Name, s.Error)
+		}
+	}
+	if got := strings.Join(names, ","); got != "export,hash,diff,full-payload,ready-context" {
+		t.Errorf("stages = %s", got)
+	}
+	if result.Stages[0].Bytes == 0 || result.Stages[3].Bytes <= result.Stages[0].Bytes {
+		t.Errorf("payload sizes look wrong: %+v", result.Stages)
+	}
+
+	out := formatBenchOutput(result, false)
+	if !strings.Contains(out, "Benchmark: 200 issues, 10 changed, 2 iterations (server: mock)") || !strings.Contains(out, "ready-context") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if !json.Valid([]byte(formatBenchOutput(result, true))) {
+		t.Error("JSON output is not valid JSON")
+	}
+}
+
+func TestRunBenchWithOptions_SkipsReadyWithoutServer(t *testing.T) {
+	result, err := runBenchWithOptions(context.Background(), BenchOptions{Issues: 10, Iterations: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stages) != 4 {
+		t.Errorf("stages = %+v", result.Stages)
+	}
+	if !strings.Contains(formatBenchOutput(result, false), "ready-context skipped") {
+		t.Error("expected skip note")
+	}
+
+	if _, err := runBenchWithOptions(context.Background(), BenchOptions{Issues: 0, Iterations: 1}); err == nil {
+		t.Error("expected error for zero issues")
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Local state (checkpoints, queues, caches) lives in .beadhub-cache at the
+// workspace root, kept out of git.
+const cacheDirName = ".beadhub-cache"
+
+// cacheFilePath returns the path of the named state file in .beadhub-cache.
+func cacheFilePath(name string) (string, error) {
+	root, err := config.WorkspaceRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, cacheDirName, name), nil
+}
+
+// ensureCacheDir creates the directory holding the state file at path and
+// adds it to the repo's git excludes.
+func ensureCacheDir(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	_ = ensureGitExcludePattern(filepath.Dir(dir), cacheDirName+"/")
+	return nil
+}
+
+// writeJSONAtomic writes v as indented JSON to the state file at path,
+// creating its directory. Readers never see a partial file.
+func writeJSONAtomic(path string, v any) error {
+	if err := ensureCacheDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'), 0600)
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory and a rename.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmpFile.Name()
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteJSONAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), cacheDirName, "state.json")
+
+	if err := writeJSONAtomic(path, map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeJSONAtomic(path, map[string]int{"b": 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]int
+	if err := json.Unmarshal(data, &got); err != nil || got["b"] != 2 || len(got) != 1 {
+		t.Errorf("got %s (%v)", data, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+func TestLockCacheFileTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	unlock, err := lockCacheFileTimeout(path, 0)
+	if err != nil {
+		t.Fatalf("expected the first lock to succeed: %v", err)
+	}
+	if _, err := lockCacheFileTimeout(path, 30*time.Millisecond); !errors.Is(err, errCacheLockTimeout) {
+		t.Fatalf("expected the second lock to time out, got %v", err)
+	}
+	unlock()
+	unlock, err = lockCacheFileTimeout(path, 0)
+	if err != nil {
+		t.Fatalf("expected the lock once released: %v", err)
+	}
+	unlock()
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// errCacheLockTimeout is returned by lockCacheFileTimeout when another bdh
+// process kept the lock for the whole wait.
+var errCacheLockTimeout = errors.New("timed out waiting for another bdh process to release the lock")
+
+// lockCacheFile takes an exclusive lock on path+".lock", blocking until
+// other bdh processes release it. Call the returned func to unlock. The OS
+// drops the lock when a process dies, so a crash never leaves it held.
+func lockCacheFile(path string) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f, true); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return func() {
+		unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
+
+// lockCacheFileTimeout is lockCacheFile for best-effort writers: it gives up
+// with errCacheLockTimeout once timeout has passed.
+func lockCacheFileTimeout(path string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err := lockFile(f, false)
+		if err == nil {
+			return func() {
+				unlockFile(f)
+				_ = f.Close()
+			}, nil
+		}
+		if !errors.Is(err, errLockBusy) || time.Now().After(deadline) {
+			_ = f.Close()
+			if errors.Is(err, errLockBusy) {
+				err = errCacheLockTimeout
+			}
+			return nil, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
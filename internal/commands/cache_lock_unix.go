@@ -0,0 +1,31 @@
+//go:build unix
+
+package commands
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// errLockBusy is lockFile's error when wait is false and the lock is held.
+var errLockBusy = unix.EWOULDBLOCK
+
+// lockFile takes an exclusive flock on f; without wait it fails with
+// errLockBusy instead of blocking.
+func lockFile(f *os.File, wait bool) error {
+	how := unix.LOCK_EX
+	if !wait {
+		how |= unix.LOCK_NB
+	}
+	err := unix.Flock(int(f.Fd()), how)
+	if errors.Is(err, unix.EAGAIN) {
+		return errLockBusy
+	}
+	return err
+}
+
+func unlockFile(f *os.File) {
+	_ = unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
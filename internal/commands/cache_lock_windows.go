@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// errLockBusy is lockFile's error when wait is false and the lock is held.
+var errLockBusy = windows.ERROR_LOCK_VIOLATION
+
+// lockFile takes an exclusive LockFileEx lock on f; without wait it fails
+// with errLockBusy instead of blocking.
+func lockFile(f *os.File, wait bool) error {
+	flags := uint32(windows.LOCKFILE_EXCLUSIVE_LOCK)
+	if !wait {
+		flags |= windows.LOCKFILE_FAIL_IMMEDIATELY
+	}
+	return windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, new(windows.Overlapped))
+}
+
+func unlockFile(f *os.File) {
+	_ = windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, new(windows.Overlapped))
+}
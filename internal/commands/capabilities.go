@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Optional server features are discovered with GET /v1/capabilities, at most
+// once a day per server (cached in .beadhub-cache/capabilities.json). Features
+// a server does not advertise are hidden instead of failing with a 404.
+// Servers that predate discovery answer 404 themselves; they are assumed to
+// support everything, which is how bdh behaved before discovery existed.
+
+// Optional server features.
+const (
+	featureTeamQuery           = "team_query"
+	featurePolicies            = "policies"
+	featureChatSessions        = "chat_v2_5"
+	featureReservationsRenew   = "reservations_renew"
+	featureInvites             = "invites"
+	featureReservationHistory  = "reservation_history"
+	featureActivity            = "activity"
+	featureAnnouncements       = "announcements"
+	featureReservationTakeover = "reservation_takeover"
+	featureFocus               = "focus"
+	featureTeamShards          = "team_shards"
+	featureSquads              = "squads"
+	featureApprovals           = "approvals"
+	featureInboxSearch         = "inbox_search"
+)
+
+const capabilitiesTTL = 24 * time.Hour
+
+// ServerCapabilities is the cached result of capability discovery.
+type ServerCapabilities struct {
+	BeadhubURL    string          `json:"beadhub_url"`
+	FetchedAt     string          `json:"fetched_at"`
+	ServerVersion string          `json:"server_version,omitempty"`
+	Features      map[string]bool `json:"features,omitempty"`
+	// Legacy is set when the server has no capabilities endpoint.
+	Legacy bool `json:"legacy,omitempty"`
+
+	// Announcement is the project banner sent along with the capabilities.
+	Announcement *client.Announcement `json:"announcement,omitempty"`
+}
+
+// Supports reports whether the server supports feature. Unknown capabilities
+// (nil, or a legacy server) support everything.
+func (c *ServerCapabilities) Supports(feature string) bool {
+	if c == nil || c.Legacy {
+		return true
+	}
+	return c.Features[feature]
+}
+
+var (
+	capabilitiesMu   sync.Mutex
+	capabilitiesMemo = map[string]*ServerCapabilities{}
+)
+
+func capabilitiesPath() (string, error) {
+	return cacheFilePath("capabilities.json")
+}
+
+func loadCachedCapabilities(path, beadhubURL string, now time.Time) *ServerCapabilities {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var caps ServerCapabilities
+	if json.Unmarshal(data, &caps) != nil || caps.BeadhubURL != beadhubURL {
+		return nil
+	}
+	fetchedAt, ok := parseTimeBestEffort(caps.FetchedAt)
+	if !ok || now.Sub(fetchedAt) > capabilitiesTTL || fetchedAt.After(now.Add(time.Minute)) {
+		return nil
+	}
+	return &caps
+}
+
+func saveCachedCapabilities(path string, caps *ServerCapabilities) error {
+	return writeJSONAtomic(path, caps)
+}
+
+// fetchCapabilities asks the server for its capabilities. A 404 marks a
+// legacy server; other failures return an error and are not cached.
+func fetchCapabilities(ctx context.Context, c BeadHubAPI, beadhubURL string, now time.Time) (*ServerCapabilities, error) {
+	caps := &ServerCapabilities{
+		BeadhubURL: beadhubURL,
+		FetchedAt:  now.UTC().Format(time.RFC3339),
+	}
+	resp, err := c.Capabilities(ctx)
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) && clientErr.StatusCode == 404 {
+			caps.Legacy = true
+			caps.ServerVersion = c.ServerVersion()
+			return caps, nil
+		}
+		return nil, err
+	}
+	if resp.Features == nil {
+		// Not a capabilities document (e.g. a proxy answering every path).
+		caps.Legacy = true
+		caps.ServerVersion = c.ServerVersion()
+		return caps, nil
+	}
+	caps.ServerVersion = resp.ServerVersion
+	if caps.ServerVersion == "" {
+		caps.ServerVersion = c.ServerVersion()
+	}
+	caps.Features = resp.Features
+	caps.Announcement = resp.Announcement
+	return caps, nil
+}
+
+// serverCapabilities returns the capabilities of cfg's server, from the
+// process memo, the daily cache, or the server. Returns nil (everything
+// supported) when they cannot be determined.
+func serverCapabilities(ctx context.Context, cfg *config.Config) *ServerCapabilities {
+	if cfg == nil || strings.TrimSpace(cfg.BeadhubURL) == "" {
+		return nil
+	}
+	url := cfg.BeadhubURL
+
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	if caps, ok := capabilitiesMemo[url]; ok {
+		return caps
+	}
+
+	now := time.Now()
+	path, pathErr := capabilitiesPath()
+	if pathErr == nil {
+		if caps := loadCachedCapabilities(path, url, now); caps != nil {
+			capabilitiesMemo[url] = caps
+			return caps
+		}
+	}
+
+	caps := fetchAndCacheCapabilitiesLocked(ctx, url, now)
+	capabilitiesMemo[url] = caps
+	return caps
+}
+
+// refreshCapabilities fetches the capabilities again, bypassing the daily
+// cache. It falls back to the cached ones when the server cannot be reached.
+func refreshCapabilities(ctx context.Context, cfg *config.Config) *ServerCapabilities {
+	cached := serverCapabilities(ctx, cfg)
+	if cached == nil {
+		return nil
+	}
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	caps := fetchAndCacheCapabilitiesLocked(ctx, cfg.BeadhubURL, time.Now())
+	if caps == nil {
+		return cached
+	}
+	capabilitiesMemo[cfg.BeadhubURL] = caps
+	return caps
+}
+
+// fetchAndCacheCapabilitiesLocked fetches from the server and updates the
+// daily cache. Callers hold capabilitiesMu. Returns nil on failure so the
+// next command tries again rather than caching a guess.
+func fetchAndCacheCapabilitiesLocked(ctx context.Context, url string, now time.Time) *ServerCapabilities {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	caps, err := fetchCapabilities(ctx, newBeadHubClient(ctx, url), url, now)
+	if err != nil {
+		return nil
+	}
+	if path, err := capabilitiesPath(); err == nil {
+		_ = saveCachedCapabilities(path, caps)
+	}
+	return caps
+}
+
+// serverSupports reports whether cfg's server supports feature, warning
+// when the server is known to be too old to serve it properly.
+func serverSupports(ctx context.Context, cfg *config.Config, feature string) bool {
+	caps := serverCapabilities(ctx, cfg)
+	if !caps.Supports(feature) {
+		return false
+	}
+	if caps != nil && caps.ServerVersion != "" {
+		checkServerVersion(caps.ServerVersion, feature)
+	}
+	return true
+}
+
+// requireServerFeature returns an error naming the missing feature, for
+// commands that cannot work without it.
+func requireServerFeature(ctx context.Context, cfg *config.Config, feature, what string) error {
+	if serverSupports(ctx, cfg, feature) {
+		return nil
+	}
+	return fmt.Errorf("this BeadHub server does not support %s (capability %q) - upgrade the server to use this command", what, feature)
+}
+
+// resetCapabilitiesMemo clears the per-process memo.
+func resetCapabilitiesMemo() {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	capabilitiesMemo = map[string]*ServerCapabilities{}
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestFetchCapabilities(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/capabilities" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"server_version":"0.9.0","features":{"team_query":true,"policies":false}}`))
+		}
+	}))
+	defer server.Close()
+	c := client.New(server.URL)
+	now := time.Now()
+
+	caps, err := fetchCapabilities(context.Background(), c, server.URL, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.Supports(featureTeamQuery) || caps.Supports(featurePolicies) || caps.Supports(featureChatSessions) {
+		t.Errorf("caps = %+v", caps)
+	}
+
+	status = http.StatusNotFound
+	caps, err = fetchCapabilities(context.Background(), c, server.URL, now)
+	if err != nil || !caps.Legacy || !caps.Supports(featurePolicies) {
+		t.Errorf("legacy caps = %+v, err = %v", caps, err)
+	}
+
+	status = http.StatusInternalServerError
+	if _, err := fetchCapabilities(context.Background(), c, server.URL, now); err == nil {
+		t.Error("expected error for 500")
+	}
+
+	var unknown *ServerCapabilities
+	if !unknown.Supports(featureTeamQuery) {
+		t.Error("unknown capabilities should support everything")
+	}
+}
+
+func TestServerCapabilities_CachedDaily(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"features":{"team_query":true}}`))
+	}))
+	defer server.Close()
+	cfg := &config.Config{BeadhubURL: server.URL}
+
+	if !serverSupports(context.Background(), cfg, featureTeamQuery) || serverSupports(context.Background(), cfg, featureChatSessions) {
+		t.Fatal("unexpected capabilities")
+	}
+	resetCapabilitiesMemo()
+	serverSupports(context.Background(), cfg, featureTeamQuery)
+	if calls != 1 {
+		t.Errorf("expected the cached capabilities to be reused, got %d fetches", calls)
+	}
+
+	path, _ := capabilitiesPath()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("cache file: %v", err)
+	}
+	if caps := loadCachedCapabilities(path, server.URL, time.Now().Add(25*time.Hour)); caps != nil {
+		t.Error("cache should expire after a day")
+	}
+	if caps := loadCachedCapabilities(path, "http://other", time.Now()); caps != nil {
+		t.Error("cache is per server")
+	}
+
+	err := requireServerFeature(context.Background(), cfg, featureChatSessions, "chat sessions")
+	if err == nil || !strings.Contains(err.Error(), "does not support chat sessions") {
+		t.Errorf("err = %v", err)
+	}
+	if filepath.Base(path) != "capabilities.json" {
+		t.Errorf("path = %s", path)
+	}
+}
@@ -0,0 +1,307 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :changelog turns the beads closed since a release into Markdown release
+// notes. Beads and their close times come from issues.jsonl; who closed each
+// one comes from the server's activity feed, when the server has one.
+
+// Ways to group changelog entries.
+const (
+	changelogGroupEpic  = "epic"
+	changelogGroupLabel = "label"
+)
+
+// ChangelogEntry is one closed bead.
+type ChangelogEntry struct {
+	BeadID   string `json:"bead_id"`
+	Title    string `json:"title"`
+	Type     string `json:"type,omitempty"`
+	ClosedAt string `json:"closed_at"`
+	ClosedBy string `json:"closed_by,omitempty"`
+}
+
+// ChangelogGroup is the entries under one epic or label. Key is empty for
+// entries outside any epic or without labels.
+type ChangelogGroup struct {
+	Key     string           `json:"key,omitempty"`
+	Title   string           `json:"title,omitempty"`
+	Entries []ChangelogEntry `json:"entries"`
+}
+
+// ChangelogCredit counts the beads an agent closed.
+type ChangelogCredit struct {
+	Alias  string `json:"alias"`
+	Closed int    `json:"closed"`
+}
+
+// ChangelogResult is the output of :changelog.
+type ChangelogResult struct {
+	Since   time.Time         `json:"since"`
+	From    string            `json:"from"`
+	GroupBy string            `json:"group_by"`
+	Groups  []ChangelogGroup  `json:"groups"`
+	Credits []ChangelogCredit `json:"credits,omitempty"`
+	Warning string            `json:"warning,omitempty"`
+}
+
+var (
+	changelogSince   string
+	changelogGroupBy string
+	changelogJSON    bool
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   ":changelog",
+	Short: "Write release notes from the beads closed since a tag or date",
+	Long: `Collect the beads closed since a git tag, a date or a duration and print
+them as CHANGELOG-style Markdown, grouped by epic (or label), crediting the
+agents that closed them.
+
+--since takes a git tag or other ref (the time of its commit), a date
+(2025-06-01 or RFC 3339), or a duration (168h). By default the most recent
+tag is used.
+
+Examples:
+  bdh :changelog                        # Since the latest tag
+  bdh :changelog --since v1.4.0
+  bdh :changelog --since 2025-06-01 --group-by label
+  bdh :changelog --json`,
+	Args: cobra.NoArgs,
+	RunE: runChangelog,
+}
+
+func init() {
+	changelogCmd.Flags().StringVar(&changelogSince, "since", "", "Git tag/ref, date, or duration to start from (default: latest tag)")
+	changelogCmd.Flags().StringVar(&changelogGroupBy, "group-by", changelogGroupEpic, "Group entries by epic or label")
+	changelogCmd.Flags().BoolVar(&changelogJSON, "json", false, "Output as JSON")
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	if changelogGroupBy != changelogGroupEpic && changelogGroupBy != changelogGroupLabel {
+		return fmt.Errorf("--group-by must be epic or label, got %q", changelogGroupBy)
+	}
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	since, from, err := resolveChangelogSince(cmd.Context(), changelogSince, time.Now())
+	if err != nil {
+		return err
+	}
+	issues, err := loadIssues()
+	if err != nil {
+		return fmt.Errorf("reading issues: %w", err)
+	}
+
+	closers, warning := changelogClosers(cmd.Context(), cfg, since)
+	result := buildChangelog(issues, closers, since, changelogGroupBy)
+	result.From = from
+	result.Warning = warning
+	fmt.Print(formatChangelogOutput(result, changelogJSON))
+	return nil
+}
+
+// resolveChangelogSince turns --since into a time, and a label for it. Dates
+// and durations are tried first; anything else is a git ref.
+func resolveChangelogSince(ctx context.Context, value string, now time.Time) (time.Time, string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		out, err := exec.CommandContext(ctx, "git", "describe", "--tags", "--abbrev=0").Output()
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("no git tag found - pass --since with a tag, date, or duration")
+		}
+		value = strings.TrimSpace(string(out))
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, "", fmt.Errorf("--since must be positive, got %q", value)
+		}
+		return now.Add(-d), value + " ago", nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, value, nil
+	}
+	if t, ok := parseTimeBestEffort(value); ok {
+		return t, value, nil
+	}
+	out, err := exec.CommandContext(ctx, "git", "log", "-1", "--format=%cI", value, "--").Output()
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("--since %q is not a date, a duration, or a git ref", value)
+	}
+	t, ok := parseTimeBestEffort(strings.TrimSpace(string(out)))
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("could not read the commit time of %s", value)
+	}
+	return t, value, nil
+}
+
+// changelogClosers maps each bead closed since since to its close event in
+// the activity feed. Best-effort: a warning explains why there are no
+// credits.
+func changelogClosers(ctx context.Context, cfg *config.Config, since time.Time) (map[string]client.ActivityEvent, string) {
+	if !serverSupports(ctx, cfg, featureActivity) {
+		return nil, "this server has no activity feed - closing agents are not credited"
+	}
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.Activity(ctx, &client.ActivityRequest{Since: since.UTC().Format(time.RFC3339)})
+	if err != nil {
+		return nil, fmt.Sprintf("could not fetch activity (%v) - closing agents are not credited", err)
+	}
+	closers := make(map[string]client.ActivityEvent)
+	for _, e := range resp.Events {
+		if e.Kind != client.ActivityClose || e.BeadID == "" || e.Alias == "" {
+			continue
+		}
+		// After a reopen, the last close counts.
+		if prev, ok := closers[e.BeadID]; !ok || e.At > prev.At {
+			closers[e.BeadID] = e
+		}
+	}
+	return closers, ""
+}
+
+// buildChangelog collects the beads closed at or after since into groups.
+// Grouping by epic puts each bead under its nearest epic; by label, under
+// its first label in alphabetical order. A bead exported without closed_at
+// uses the time of its close event, if any.
+func buildChangelog(issues []Issue, closers map[string]client.ActivityEvent, since time.Time, groupBy string) *ChangelogResult {
+	result := &ChangelogResult{Since: since, GroupBy: groupBy}
+	epicOf := beadEpics(issues)
+	titles := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		titles[issue.ID] = issue.Title
+	}
+
+	groups := make(map[string]*ChangelogGroup)
+	credits := make(map[string]int)
+	for _, issue := range issues {
+		if issue.Status != "closed" {
+			continue
+		}
+		closer := closers[issue.ID]
+		closedAtText := issue.ClosedAt
+		if closedAtText == "" {
+			closedAtText = closer.At
+		}
+		closedAt, ok := parseTimeBestEffort(closedAtText)
+		if !ok || closedAt.Before(since) {
+			continue
+		}
+		if groupBy == changelogGroupEpic && epicOf[issue.ID] == issue.ID {
+			// A closed epic is its group's heading, not an entry.
+			continue
+		}
+
+		key, title := "", ""
+		switch groupBy {
+		case changelogGroupEpic:
+			key = epicOf[issue.ID]
+			title = titles[key]
+		case changelogGroupLabel:
+			if len(issue.Labels) > 0 {
+				labels := append([]string(nil), issue.Labels...)
+				sort.Strings(labels)
+				key, title = labels[0], labels[0]
+			}
+		}
+		group := groups[key]
+		if group == nil {
+			group = &ChangelogGroup{Key: key, Title: title}
+			groups[key] = group
+		}
+		entry := ChangelogEntry{
+			BeadID:   issue.ID,
+			Title:    issue.Title,
+			Type:     issue.IssueType,
+			ClosedAt: closedAt.UTC().Format(time.RFC3339),
+			ClosedBy: closer.Alias,
+		}
+		group.Entries = append(group.Entries, entry)
+		if entry.ClosedBy != "" {
+			credits[entry.ClosedBy]++
+		}
+	}
+
+	for _, key := range sortedKeys(groups) {
+		group := groups[key]
+		sort.SliceStable(group.Entries, func(i, j int) bool {
+			return group.Entries[i].ClosedAt < group.Entries[j].ClosedAt
+		})
+		result.Groups = append(result.Groups, *group)
+	}
+	// Entries outside any epic or label go last.
+	sort.SliceStable(result.Groups, func(i, j int) bool {
+		return result.Groups[i].Key != "" && result.Groups[j].Key == ""
+	})
+
+	for _, alias := range sortedKeys(credits) {
+		result.Credits = append(result.Credits, ChangelogCredit{Alias: alias, Closed: credits[alias]})
+	}
+	sort.SliceStable(result.Credits, func(i, j int) bool {
+		return result.Credits[i].Closed > result.Credits[j].Closed
+	})
+	return result
+}
+
+func formatChangelogOutput(result *ChangelogResult, asJSON bool) string {
+	if asJSON {
+		if result.Groups == nil {
+			result.Groups = []ChangelogGroup{}
+		}
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString(fmt.Sprintf("<!-- Warning: %s -->\n", result.Warning))
+	}
+	sb.WriteString(fmt.Sprintf("## Changes since %s\n", result.From))
+	if len(result.Groups) == 0 {
+		sb.WriteString("\nNo beads closed since then.\n")
+		return sb.String()
+	}
+
+	for _, group := range result.Groups {
+		switch {
+		case group.Key == "" && result.GroupBy == changelogGroupEpic:
+			sb.WriteString("\n### Other changes\n\n")
+		case group.Key == "":
+			sb.WriteString("\n### Unlabeled\n\n")
+		case result.GroupBy == changelogGroupEpic && group.Title != "":
+			sb.WriteString(fmt.Sprintf("\n### %s (%s)\n\n", group.Title, group.Key))
+		default:
+			sb.WriteString(fmt.Sprintf("\n### %s\n\n", group.Key))
+		}
+		for _, e := range group.Entries {
+			line := fmt.Sprintf("- %s (%s)", e.Title, e.BeadID)
+			if e.ClosedBy != "" {
+				line += " — " + displayAlias(e.ClosedBy)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	if len(result.Credits) > 0 {
+		names := make([]string, 0, len(result.Credits))
+		for _, c := range result.Credits {
+			names = append(names, fmt.Sprintf("%s (%d)", displayAlias(c.Alias), c.Closed))
+		}
+		sb.WriteString("\nClosed by " + strings.Join(names, ", ") + ".\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func changelogTestIssues() []Issue {
+	closed := func(id, title, closedAt string, labels ...string) Issue {
+		return Issue{ID: id, Title: title, Status: "closed", IssueType: "task", ClosedAt: closedAt, Labels: labels,
+			Dependencies: []Dependency{{IssueID: id, DependsOnID: "bd-e1", Type: "parent-child"}}}
+	}
+	return []Issue{
+		{ID: "bd-e1", Title: "Auth", Status: "closed", IssueType: "epic", ClosedAt: "2025-06-12T09:00:00Z"},
+		closed("bd-1", "Add login", "2025-06-10T09:00:00Z", "ui", "auth"),
+		closed("bd-2", "Old fix", "2025-05-01T09:00:00Z"), // before since
+		{ID: "bd-3", Title: "Fix crash", Status: "closed", IssueType: "bug"},
+		{ID: "bd-4", Title: "Still open", Status: "open", IssueType: "task", ClosedAt: "2025-06-10T09:00:00Z"},
+	}
+}
+
+func TestBuildChangelog_ByEpic(t *testing.T) {
+	withDisplayNames(t, map[string]string{"alice": "Alice"})
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	closers := map[string]client.ActivityEvent{
+		"bd-1": {Kind: client.ActivityClose, Alias: "alice", BeadID: "bd-1", At: "2025-06-10T09:00:00Z"},
+		// bd-3 has no closed_at in the export; the close event dates it.
+		"bd-3": {Kind: client.ActivityClose, Alias: "bob", BeadID: "bd-3", At: "2025-06-11T09:00:00Z"},
+	}
+	result := buildChangelog(changelogTestIssues(), closers, since, changelogGroupEpic)
+	result.From = "v1.0.0"
+
+	if len(result.Groups) != 2 || result.Groups[0].Key != "bd-e1" || result.Groups[1].Key != "" {
+		t.Fatalf("unexpected groups: %+v", result.Groups)
+	}
+	if len(result.Groups[0].Entries) != 1 || result.Groups[0].Entries[0].BeadID != "bd-1" {
+		t.Errorf("unexpected epic entries: %+v", result.Groups[0].Entries)
+	}
+	if len(result.Credits) != 2 {
+		t.Errorf("unexpected credits: %+v", result.Credits)
+	}
+
+	out := formatChangelogOutput(result, false)
+	for _, want := range []string{
+		"## Changes since v1.0.0",
+		"### Auth (bd-e1)",
+		"- Add login (bd-1) — Alice",
+		"### Other changes",
+		"- Fix crash (bd-3) — bob",
+		"Closed by Alice (1), bob (1).",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Old fix") || strings.Contains(out, "Still open") {
+		t.Errorf("unexpected beads in:\n%s", out)
+	}
+}
+
+func TestBuildChangelog_ByLabel(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	result := buildChangelog(changelogTestIssues(), nil, since, changelogGroupLabel)
+
+	if len(result.Groups) != 2 || result.Groups[0].Key != "auth" || result.Groups[1].Key != "" {
+		t.Fatalf("unexpected groups: %+v", result.Groups)
+	}
+	// The closed epic is an entry when grouping by label.
+	if got := len(result.Groups[1].Entries); got != 1 {
+		t.Errorf("expected the epic in the unlabeled group, got %+v", result.Groups[1].Entries)
+	}
+	out := formatChangelogOutput(result, false)
+	if !strings.Contains(out, "### auth") || !strings.Contains(out, "### Unlabeled") || strings.Contains(out, "Closed by") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestResolveChangelogSince(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+		from  string
+	}{
+		{"2025-06-01", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), "2025-06-01"},
+		{"2025-06-01T10:00:00Z", time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC), "2025-06-01T10:00:00Z"},
+		{"48h", now.Add(-48 * time.Hour), "48h ago"},
+	}
+	for _, tt := range tests {
+		got, from, err := resolveChangelogSince(context.Background(), tt.value, now)
+		if err != nil || !got.Equal(tt.want) || from != tt.from {
+			t.Errorf("resolveChangelogSince(%q) = %v, %q, %v", tt.value, got, from, err)
+		}
+	}
+	if _, _, err := resolveChangelogSince(context.Background(), "-1h", now); err == nil {
+		t.Error("expected an error for a negative duration")
+	}
+}
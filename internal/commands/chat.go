@@ -26,6 +26,8 @@ var (
 	chatListenWait        int
 	chatStartConversation bool
 	chatLeaveConversation bool
+	chatNoBeadRefs        bool
+	chatEncrypt           bool
 )
 
 var chatCmd = &cobra.Command{
@@ -47,7 +49,10 @@ Examples:
   bdh :aweb chat send bob "Thanks, I'm done here." --leave-conversation
   bdh :aweb chat open bob
   bdh :aweb chat pending
-  bdh :aweb chat history bob`,
+  bdh :aweb chat history bob
+  bdh :aweb chat send alice,bob "Kickoff" --start-conversation   # Group session
+  bdh :aweb chat sessions --groups
+  bdh :aweb chat session rename alice,bob "api-design"`,
 }
 
 var chatSendCmd = &cobra.Command{
@@ -57,17 +62,15 @@ var chatSendCmd = &cobra.Command{
 
 By default, waits 120 seconds for a reply. Use --start-conversation for
 a 5-minute wait when initiating a new exchange. Use --leave-conversation
-to send a final message and exit immediately.`,
+to send a final message and exit immediately.
+
+With --encrypt the message is sealed for every recipient's published key
+(see bdh :keys).`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		ctx := cmd.Context()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
-		}
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid .beadhub config: %w", err)
-		}
-		if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 			return err
 		}
 
@@ -90,7 +93,7 @@ to send a final message and exit immediately.`,
 			return fmt.Errorf("message cannot be empty")
 		}
 
-		baseCtx := cmd.Context()
+		baseCtx := ctx
 		targetAgents, err := resolveTargetAliases(baseCtx, cfg, args[0])
 		if err != nil {
 			return err
@@ -99,7 +102,14 @@ to send a final message and exit immediately.`,
 			SetExcludeChatAlias(t)
 		}
 
-		aw, err := newAwebClientRequired(cfg.BeadhubURL)
+		if chatStartConversation {
+			notices := headsDownNotices(baseCtx, cfg, targetAgents, time.Now())
+			if !confirmInterruptHeadsDown(notices) {
+				return fmt.Errorf("not sent - use 'bdh :aweb mail send' to reach them without interrupting")
+			}
+		}
+
+		aw, err := newAwebChatClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
@@ -114,10 +124,22 @@ to send a final message and exit immediately.`,
 		ctx, cancel := context.WithTimeout(baseCtx, chat.MaxSendTimeout)
 		defer cancel()
 
-		result, err := chat.Send(ctx, aw, cfg.Alias, targetAgents, args[1], opts, chatStatusCallback)
+		body := outgoingMessageBody(args[1], chatNoBeadRefs)
+		if chatEncrypt {
+			c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+			if err != nil {
+				return err
+			}
+			body, err = sealOutgoingBody(ctx, c, body, targetAgents, cfg.Alias)
+			if err != nil {
+				return err
+			}
+		}
+		result, err := chat.Send(ctx, aw, cfg.Alias, targetAgents, body, opts, chatStatusCallback)
 		if err != nil {
 			return err
 		}
+		renderChatSendResult(result, chatJSON)
 		fmt.Print(formatChatOutput(result, chatJSON))
 		return nil
 	},
@@ -128,22 +150,17 @@ var chatPendingCmd = &cobra.Command{
 	Short: "List conversations with unread messages",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		ctx := cmd.Context()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
-		}
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid .beadhub config: %w", err)
-		}
-		if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 			return err
 		}
 
-		aw, err := newAwebClientRequired(cfg.BeadhubURL)
+		aw, err := newAwebChatClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 
 		result, err := chat.Pending(ctx, aw)
@@ -160,25 +177,20 @@ var chatOpenCmd = &cobra.Command{
 	Short: "Read unread messages and mark as read",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		ctx := cmd.Context()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
-		}
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid .beadhub config: %w", err)
-		}
-		if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 			return err
 		}
 
-		baseCtx := cmd.Context()
+		baseCtx := ctx
 		targetAgent, err := resolveTargetAlias(baseCtx, cfg, args[0])
 		if err != nil {
 			return err
 		}
 		SetExcludeChatAlias(targetAgent)
 
-		aw, err := newAwebClientRequired(cfg.BeadhubURL)
+		aw, err := newAwebChatClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
@@ -189,6 +201,11 @@ var chatOpenCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if chatJSON {
+			decryptChatEvents(result.Messages)
+		} else {
+			renderChatEvents(result.Messages)
+		}
 		fmt.Print(formatChatOpenOutput(result, chatJSON))
 		return nil
 	},
@@ -199,25 +216,20 @@ var chatHistoryCmd = &cobra.Command{
 	Short: "Show conversation history",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		ctx := cmd.Context()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
-		}
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid .beadhub config: %w", err)
-		}
-		if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 			return err
 		}
 
-		baseCtx := cmd.Context()
+		baseCtx := ctx
 		targetAgent, err := resolveTargetAlias(baseCtx, cfg, args[0])
 		if err != nil {
 			return err
 		}
 		SetExcludeChatAlias(targetAgent)
 
-		aw, err := newAwebClientRequired(cfg.BeadhubURL)
+		aw, err := newAwebChatClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
@@ -228,6 +240,11 @@ var chatHistoryCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if chatJSON {
+			decryptChatEvents(result.Messages)
+		} else {
+			renderChatEvents(result.Messages)
+		}
 		fmt.Print(formatHistoryOutput(result, chatJSON))
 		return nil
 	},
@@ -238,14 +255,9 @@ var chatHangOnCmd = &cobra.Command{
 	Short: "Request more time before replying",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		ctx := cmd.Context()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
-		}
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid .beadhub config: %w", err)
-		}
-		if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 			return err
 		}
 
@@ -253,14 +265,14 @@ var chatHangOnCmd = &cobra.Command{
 			return fmt.Errorf("message cannot be empty")
 		}
 
-		baseCtx := cmd.Context()
+		baseCtx := ctx
 		targetAgent, err := resolveTargetAlias(baseCtx, cfg, args[0])
 		if err != nil {
 			return err
 		}
 		SetExcludeChatAlias(targetAgent)
 
-		aw, err := newAwebClientRequired(cfg.BeadhubURL)
+		aw, err := newAwebChatClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
@@ -285,25 +297,20 @@ Connects to the session's SSE stream and returns when a message arrives
 or the wait timeout elapses.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		ctx := cmd.Context()
+		cfg, err := loadWorkspaceConfig()
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
-		}
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid .beadhub config: %w", err)
-		}
-		if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 			return err
 		}
 
-		baseCtx := cmd.Context()
+		baseCtx := ctx
 		targetAgent, err := resolveTargetAlias(baseCtx, cfg, args[0])
 		if err != nil {
 			return err
 		}
 		SetExcludeChatAlias(targetAgent)
 
-		aw, err := newAwebClientRequired(cfg.BeadhubURL)
+		aw, err := newAwebChatClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
@@ -315,6 +322,7 @@ or the wait timeout elapses.`,
 		if err != nil {
 			return err
 		}
+		renderChatSendResult(result, chatJSON)
 		fmt.Print(formatChatOutput(result, chatJSON))
 		return nil
 	},
@@ -333,6 +341,8 @@ func init() {
 	chatSendCmd.Flags().IntVar(&chatWait, "wait", defaultChatWait, "Timeout in seconds (0 to not wait)")
 	chatSendCmd.Flags().BoolVar(&chatStartConversation, "start-conversation", false, "Initiate a new exchange (5 min wait)")
 	chatSendCmd.Flags().BoolVar(&chatLeaveConversation, "leave-conversation", false, "Send final message and exit (no wait)")
+	chatSendCmd.Flags().BoolVar(&chatNoBeadRefs, "no-bead-refs", false, "Don't append status/title of mentioned beads")
+	chatSendCmd.Flags().BoolVar(&chatEncrypt, "encrypt", false, "Encrypt the message for the recipients' published keys")
 
 	chatListenCmd.Flags().IntVar(&chatListenWait, "wait", defaultChatWait, "Seconds to wait for a message (0 = no wait)")
 }
@@ -352,7 +362,7 @@ func resolveTargetAlias(ctx context.Context, cfg *config.Config, target string)
 // resolveTargetAliases resolves comma-separated aliases with fuzzy matching.
 // Each part is resolved individually. Prevents chatting with self.
 func resolveTargetAliases(ctx context.Context, cfg *config.Config, targetInput string) ([]string, error) {
-	httpClient := newBeadHubClient(cfg.BeadhubURL)
+	httpClient := newBeadHubClient(ctx, cfg.BeadhubURL)
 
 	resolveCtx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
@@ -369,6 +379,9 @@ func resolveTargetAliases(ctx context.Context, cfg *config.Config, targetInput s
 		if err != nil {
 			return nil, err
 		}
+		if resolution.StaleNote != "" {
+			fmt.Fprintf(os.Stderr, "Note: %s\n", resolution.StaleNote)
+		}
 
 		targetAgent := resolution.Alias
 		if targetAgent == "" {
@@ -406,9 +419,10 @@ func formatChatOutput(result *chat.SendResult, asJSON bool) string {
 	var sb strings.Builder
 
 	writeChatLine := func(prefix, agent, ts string) {
+		agent = displayAlias(agent)
 		timeAgo := ""
 		if ts != "" {
-			timeAgo = formatTimeAgo(ts)
+			timeAgo = formatTimestamp(ts)
 		}
 		if timeAgo != "" {
 			sb.WriteString(fmt.Sprintf("%s: %s — %s\n", prefix, agent, timeAgo))
@@ -431,7 +445,7 @@ func formatChatOutput(result *chat.SendResult, asJSON bool) string {
 	case "sender_left":
 		writeChatLine("Chat from", result.TargetAgent, firstTimestamp)
 		sb.WriteString(fmt.Sprintf("Body: %s\n", result.Reply))
-		sb.WriteString(fmt.Sprintf("Note: %s has left the exchange\n", result.TargetAgent))
+		sb.WriteString(fmt.Sprintf("Note: %s has left the exchange\n", displayAlias(result.TargetAgent)))
 		return sb.String()
 
 	case "pending":
@@ -450,14 +464,14 @@ func formatChatOutput(result *chat.SendResult, asJSON bool) string {
 		} else {
 			writeChatLine("Chat to", result.TargetAgent, firstTimestamp)
 			sb.WriteString(fmt.Sprintf("Body: %s\n", result.Reply))
-			sb.WriteString(fmt.Sprintf("Awaiting reply from %s.\n", result.TargetAgent))
+			sb.WriteString(fmt.Sprintf("Awaiting reply from %s.\n", displayAlias(result.TargetAgent)))
 		}
 		return sb.String()
 
 	case "sent":
-		sb.WriteString(fmt.Sprintf("Message sent to %s\n", result.TargetAgent))
+		sb.WriteString(fmt.Sprintf("Message sent to %s\n", displayAlias(result.TargetAgent)))
 		if result.TargetNotConnected {
-			sb.WriteString(fmt.Sprintf("Note: %s was not connected.\n", result.TargetAgent))
+			sb.WriteString(fmt.Sprintf("Note: %s was not connected.\n", displayAlias(result.TargetAgent)))
 		}
 		if result.WaitedSeconds > 0 {
 			sb.WriteString(fmt.Sprintf("Waited %ds — no reply\n", result.WaitedSeconds))
@@ -465,8 +479,8 @@ func formatChatOutput(result *chat.SendResult, asJSON bool) string {
 		return sb.String()
 
 	case "targets_left":
-		sb.WriteString(fmt.Sprintf("Message sent to %s\n", result.TargetAgent))
-		sb.WriteString(fmt.Sprintf("%s previously left the conversation.\n", result.TargetAgent))
+		sb.WriteString(fmt.Sprintf("Message sent to %s\n", displayAlias(result.TargetAgent)))
+		sb.WriteString(fmt.Sprintf("%s previously left the conversation.\n", displayAlias(result.TargetAgent)))
 		sb.WriteString(fmt.Sprintf("To start a new exchange, run: \"bdh :aweb chat send %s \\\"message\\\" --start-conversation\"\n", result.TargetAgent))
 		return sb.String()
 	}
@@ -558,9 +572,9 @@ func formatPendingOutput(result *chat.PendingResult, selfAlias string, asJSON bo
 			if p.TimeRemainingSeconds != nil && *p.TimeRemainingSeconds < 60 && *p.TimeRemainingSeconds > 0 {
 				timeInfo = fmt.Sprintf(" (%ds left)", *p.TimeRemainingSeconds)
 			}
-			sb.WriteString(fmt.Sprintf("  CHAT WAITING: %s%s (unread: %d)%s\n", p.LastFrom, timeInfo, p.UnreadCount, openHint))
+			sb.WriteString(fmt.Sprintf("  CHAT WAITING: %s%s (unread: %d)%s\n", displayAlias(p.LastFrom), timeInfo, p.UnreadCount, openHint))
 		} else {
-			sb.WriteString(fmt.Sprintf("  CHAT: %s (unread: %d)%s\n", p.LastFrom, p.UnreadCount, openHint))
+			sb.WriteString(fmt.Sprintf("  CHAT: %s (unread: %d)%s\n", displayAlias(p.LastFrom), p.UnreadCount, openHint))
 		}
 	}
 
@@ -582,16 +596,11 @@ func formatHistoryOutput(result *chat.HistoryResult, asJSON bool) string {
 	sb.WriteString(fmt.Sprintf("Conversation history (%d messages):\n\n", len(result.Messages)))
 
 	for _, m := range result.Messages {
-		timestamp := ""
-		if m.Timestamp != "" {
-			if t, err := time.Parse(time.RFC3339, m.Timestamp); err == nil {
-				timestamp = t.Format("15:04:05")
-			}
-		}
+		timestamp := formatTranscriptTime(m.Timestamp)
 		if timestamp != "" {
-			sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, m.FromAgent, m.Body))
+			sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", timestamp, displayAlias(m.FromAgent), m.Body))
 		} else {
-			sb.WriteString(fmt.Sprintf("%s: %s\n", m.FromAgent, m.Body))
+			sb.WriteString(fmt.Sprintf("%s: %s\n", displayAlias(m.FromAgent), m.Body))
 		}
 	}
 
@@ -615,23 +624,18 @@ func formatChatOpenOutput(result *chat.OpenResult, asJSON bool) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Unread chat messages (%d marked as read):\n\n", result.MarkedRead))
 	if result.SenderWaiting {
-		sb.WriteString(fmt.Sprintf("Status: %s is WAITING for your reply\n\n", result.TargetAgent))
+		sb.WriteString(fmt.Sprintf("Status: %s is WAITING for your reply\n\n", displayAlias(result.TargetAgent)))
 	}
 
 	for i, m := range result.Messages {
 		if i > 0 {
 			sb.WriteString("\n---\n\n")
 		}
-		ts := ""
-		if m.Timestamp != "" {
-			if t, err := time.Parse(time.RFC3339, m.Timestamp); err == nil {
-				ts = t.Format("15:04:05")
-			}
-		}
+		ts := formatTranscriptTime(m.Timestamp)
 		if ts != "" {
-			sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", ts, m.FromAgent, m.Body))
+			sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", ts, displayAlias(m.FromAgent), m.Body))
 		} else {
-			sb.WriteString(fmt.Sprintf("%s: %s\n", m.FromAgent, m.Body))
+			sb.WriteString(fmt.Sprintf("%s: %s\n", displayAlias(m.FromAgent), m.Body))
 		}
 	}
 
@@ -0,0 +1,413 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Group chat sessions are created implicitly by sending to several aliases
+// (chat send alice,bob ...). These commands list them and manage their name
+// and participants after the fact, through the aweb chat routes.
+
+var chatSessionsGroupsOnly bool
+
+var chatSessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List chat sessions you participate in",
+	Long: `List chat sessions you participate in, most recently started first.
+
+Examples:
+  bdh :aweb chat sessions            # All sessions
+  bdh :aweb chat sessions --groups   # Only group sessions`,
+	Args: cobra.NoArgs,
+	RunE: runChatSessions,
+}
+
+var chatSessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Manage a chat session's name and participants",
+	Long: `Manage a chat session's name and participants.
+
+<session> can be a session ID (or unique prefix), or the comma-separated
+list of the other participants (e.g. "alice,bob").
+
+Examples:
+  bdh :aweb chat session rename alice,bob "api-design"
+  bdh :aweb chat session add alice,bob carol
+  bdh :aweb chat session remove alice,bob,carol bob
+  bdh :aweb chat session remove alice,bob <your-alias>   # Leave the group`,
+}
+
+var chatSessionRenameCmd = &cobra.Command{
+	Use:   "rename <session> <name>",
+	Short: "Name a session (empty name clears it)",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cfg, err := loadChatSessionConfig(ctx)
+		if err != nil {
+			return err
+		}
+		result, err := chatSessionRenameWithConfig(ctx, cfg, args[0], strings.TrimSpace(args[1]))
+		if err != nil {
+			return err
+		}
+		fmt.Print(formatChatSessionUpdate(result, chatJSON))
+		return nil
+	},
+}
+
+var chatSessionAddCmd = &cobra.Command{
+	Use:   "add <session> <alias>[,<alias>...]",
+	Short: "Add participants to a session",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cfg, err := loadChatSessionConfig(ctx)
+		if err != nil {
+			return err
+		}
+		aliases, err := resolveTargetAliases(ctx, cfg, args[1])
+		if err != nil {
+			return err
+		}
+		result, err := chatSessionAddWithConfig(ctx, cfg, args[0], aliases)
+		if err != nil {
+			return err
+		}
+		fmt.Print(formatChatSessionUpdate(result, chatJSON))
+		return nil
+	},
+}
+
+var chatSessionRemoveCmd = &cobra.Command{
+	Use:   "remove <session> <alias>",
+	Short: "Remove a participant from a session",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cfg, err := loadChatSessionConfig(ctx)
+		if err != nil {
+			return err
+		}
+		alias := strings.TrimSpace(args[1])
+		if alias != cfg.Alias {
+			if alias, err = resolveTargetAlias(ctx, cfg, alias); err != nil {
+				return err
+			}
+		}
+		result, err := chatSessionRemoveWithConfig(ctx, cfg, args[0], alias)
+		if err != nil {
+			return err
+		}
+		fmt.Print(formatChatSessionUpdate(result, chatJSON))
+		return nil
+	},
+}
+
+func init() {
+	chatSessionsCmd.Flags().BoolVar(&chatSessionsGroupsOnly, "groups", false, "Only show group sessions (3+ participants)")
+
+	chatSessionCmd.AddCommand(chatSessionRenameCmd)
+	chatSessionCmd.AddCommand(chatSessionAddCmd)
+	chatSessionCmd.AddCommand(chatSessionRemoveCmd)
+
+	chatCmd.AddCommand(chatSessionsCmd)
+	chatCmd.AddCommand(chatSessionCmd)
+}
+
+// ChatSessionsResult is the output of chat sessions.
+type ChatSessionsResult struct {
+	Sessions []ChatSession `json:"sessions"`
+}
+
+// ChatSessionUpdateResult is the output of a chat session change.
+type ChatSessionUpdateResult struct {
+	Action  string      `json:"action"` // "renamed", "added", "removed"
+	Detail  string      `json:"detail"`
+	Session ChatSession `json:"session"`
+}
+
+func loadChatSessionConfig(ctx context.Context) (*config.Config, error) {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireServerFeature(ctx, cfg, featureChatSessions, "chat sessions"); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func runChatSessions(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadChatSessionConfig(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := chatSessionsWithConfig(ctx, cfg, chatSessionsGroupsOnly)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatChatSessionsOutput(result, cfg.Alias, chatJSON))
+	return nil
+}
+
+// chatSessionsWithConfig lists sessions, most recently started first.
+func chatSessionsWithConfig(ctx context.Context, cfg *config.Config, groupsOnly bool) (*ChatSessionsResult, error) {
+	sessions, err := listChatSessions(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	result := &ChatSessionsResult{Sessions: []ChatSession{}}
+	for _, s := range sessions {
+		if groupsOnly && !isGroupSession(s) {
+			continue
+		}
+		result.Sessions = append(result.Sessions, s)
+	}
+	sort.SliceStable(result.Sessions, func(i, j int) bool {
+		return result.Sessions[i].CreatedAt > result.Sessions[j].CreatedAt
+	})
+	return result, nil
+}
+
+func chatSessionRenameWithConfig(ctx context.Context, cfg *config.Config, ref, name string) (*ChatSessionUpdateResult, error) {
+	c, session, err := findChatSession(ctx, cfg, ref)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	updated, err := c.Rename(ctx, session.SessionID, name)
+	if err != nil {
+		return nil, chatSessionError("rename session", err)
+	}
+	detail := fmt.Sprintf("named %q", name)
+	if name == "" {
+		detail = "name cleared"
+	}
+	return &ChatSessionUpdateResult{Action: "renamed", Detail: detail, Session: *updated}, nil
+}
+
+func chatSessionAddWithConfig(ctx context.Context, cfg *config.Config, ref string, aliases []string) (*ChatSessionUpdateResult, error) {
+	c, session, err := findChatSession(ctx, cfg, ref)
+	if err != nil {
+		return nil, err
+	}
+	var toAdd []string
+	for _, a := range aliases {
+		if !containsString(session.Participants, a) {
+			toAdd = append(toAdd, a)
+		}
+	}
+	if len(toAdd) == 0 {
+		return nil, fmt.Errorf("%s already in session %s", strings.Join(aliases, ", "), chatSessionLabel(*session, cfg.Alias))
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	updated, err := c.AddParticipants(ctx, session.SessionID, toAdd)
+	if err != nil {
+		return nil, chatSessionError("add participants", err)
+	}
+	return &ChatSessionUpdateResult{Action: "added", Detail: strings.Join(toAdd, ", "), Session: *updated}, nil
+}
+
+func chatSessionRemoveWithConfig(ctx context.Context, cfg *config.Config, ref, alias string) (*ChatSessionUpdateResult, error) {
+	c, session, err := findChatSession(ctx, cfg, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(session.Participants, alias) {
+		return nil, fmt.Errorf("%s is not in session %s", alias, chatSessionLabel(*session, cfg.Alias))
+	}
+	if len(session.Participants) <= 2 {
+		return nil, fmt.Errorf("session %s has only two participants - participants can only be removed from group sessions", chatSessionLabel(*session, cfg.Alias))
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	updated, err := c.RemoveParticipant(ctx, session.SessionID, alias)
+	if err != nil {
+		return nil, chatSessionError("remove participant", err)
+	}
+	return &ChatSessionUpdateResult{Action: "removed", Detail: alias, Session: *updated}, nil
+}
+
+func listChatSessions(ctx context.Context, cfg *config.Config) ([]ChatSession, error) {
+	aw, err := newAwebChatClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := aw.ChatListSessions(ctx)
+	if err != nil {
+		return nil, chatSessionError("list sessions", err)
+	}
+	sessions := make([]ChatSession, 0, len(resp.Sessions))
+	for _, s := range resp.Sessions {
+		sessions = append(sessions, ChatSession{SessionID: s.SessionID, Participants: s.Participants, CreatedAt: s.CreatedAt})
+	}
+	return sessions, nil
+}
+
+// findChatSession lists sessions and resolves ref to exactly one of them.
+func findChatSession(ctx context.Context, cfg *config.Config, ref string) (*awebChatSessions, *ChatSession, error) {
+	c, err := newAwebChatSessions(cfg.BeadhubURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	sessions, err := listChatSessions(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := resolveChatSession(sessions, ref, cfg.Alias)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, session, nil
+}
+
+// resolveChatSession matches ref against sessions in order of precedence:
+// exact session ID, the set of other participants ("alice,bob"), then a
+// unique session ID prefix. aw's session list carries no names, so a
+// session cannot be found by name.
+func resolveChatSession(sessions []ChatSession, ref, selfAlias string) (*ChatSession, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("session cannot be empty")
+	}
+
+	for i := range sessions {
+		if sessions[i].SessionID == ref {
+			return &sessions[i], nil
+		}
+	}
+
+	var matches []int
+	want := participantKey(strings.Split(ref, ","), selfAlias)
+	for i := range sessions {
+		if participantKey(sessions[i].Participants, selfAlias) == want {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		for i := range sessions {
+			if strings.HasPrefix(sessions[i].SessionID, ref) {
+				matches = append(matches, i)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no chat session matches %q - run 'bdh :aweb chat sessions' to list them", ref)
+	case 1:
+		return &sessions[matches[0]], nil
+	default:
+		var ids []string
+		for _, i := range matches {
+			ids = append(ids, sessions[i].SessionID)
+		}
+		return nil, fmt.Errorf("%q matches %d sessions (%s) - use the session ID", ref, len(matches), strings.Join(ids, ", "))
+	}
+}
+
+// participantKey is a canonical form of a participant set, excluding self.
+func participantKey(participants []string, selfAlias string) string {
+	var others []string
+	for _, p := range participants {
+		p = strings.TrimSpace(p)
+		if p != "" && p != selfAlias {
+			others = append(others, p)
+		}
+	}
+	sort.Strings(others)
+	return strings.Join(others, ",")
+}
+
+func isGroupSession(s ChatSession) bool {
+	return len(s.Participants) > 2
+}
+
+// chatSessionLabel names a session for display: its name, or its other participants.
+func chatSessionLabel(s ChatSession, selfAlias string) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if others := participantKey(s.Participants, selfAlias); others != "" {
+		return strings.ReplaceAll(others, ",", ", ")
+	}
+	return s.SessionID
+}
+
+func chatSessionError(action string, err error) error {
+	var sessionErr *awebChatSessionError
+	if errors.As(err, &sessionErr) {
+		return fmt.Errorf("aweb error (%d): %s", sessionErr.StatusCode, apiErrorDetail(sessionErr.Body))
+	}
+	if status, ok := aweb.HTTPStatusCode(err); ok {
+		body, _ := aweb.HTTPErrorBody(err)
+		return fmt.Errorf("aweb error (%d): %s", status, apiErrorDetail(body))
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func formatChatSessionsOutput(result *ChatSessionsResult, selfAlias string, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	if len(result.Sessions) == 0 {
+		return "No chat sessions\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("CHAT SESSIONS: %d\n\n", len(result.Sessions)))
+	for _, s := range result.Sessions {
+		kind := "direct"
+		if isGroupSession(s) {
+			kind = fmt.Sprintf("group of %d", len(s.Participants))
+		}
+		sb.WriteString(fmt.Sprintf("  %s (%s) — %s", chatSessionLabel(s, selfAlias), kind, s.SessionID))
+		if ago := formatTimestamp(s.CreatedAt); ago != "" {
+			sb.WriteString(fmt.Sprintf(" — started %s", ago))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func formatChatSessionUpdate(result *ChatSessionUpdateResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	switch result.Action {
+	case "renamed":
+		sb.WriteString(fmt.Sprintf("Session %s %s\n", result.Session.SessionID, result.Detail))
+	case "added":
+		sb.WriteString(fmt.Sprintf("Added %s to session %s\n", result.Detail, result.Session.SessionID))
+	case "removed":
+		sb.WriteString(fmt.Sprintf("Removed %s from session %s\n", result.Detail, result.Session.SessionID))
+	}
+	sb.WriteString(fmt.Sprintf("Participants: %s\n", strings.Join(result.Session.Participants, ", ")))
+	return sb.String()
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestResolveChatSession(t *testing.T) {
+	sessions := []ChatSession{
+		{SessionID: "sess-aaa111", Participants: []string{"me", "alice"}},
+		{SessionID: "sess-bbb222", Participants: []string{"me", "alice", "bob"}},
+		{SessionID: "sess-bbb333", Participants: []string{"me", "carol", "dave"}},
+	}
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "sess-aaa111", want: "sess-aaa111"},
+		{ref: "bob,alice", want: "sess-bbb222"},
+		{ref: "dave, carol, me", want: "sess-bbb333"},
+		{ref: "alice", want: "sess-aaa111"},
+		{ref: "sess-a", want: "sess-aaa111"},
+		{ref: "sess-bbb", wantErr: "matches 2 sessions"},
+		{ref: "zed", wantErr: "no chat session matches"},
+		{ref: " ", wantErr: "cannot be empty"},
+	}
+	for _, tt := range tests {
+		got, err := resolveChatSession(sessions, tt.ref, "me")
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveChatSession(%q) error = %v, want %q", tt.ref, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("resolveChatSession(%q) unexpected error: %v", tt.ref, err)
+			continue
+		}
+		if got.SessionID != tt.want {
+			t.Errorf("resolveChatSession(%q) = %s, want %s", tt.ref, got.SessionID, tt.want)
+		}
+	}
+}
+
+func newChatSessionServer(t *testing.T, sessions []ChatSession, handle func(w http.ResponseWriter, r *http.Request)) *config.Config {
+	t.Helper()
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/v1/chat/sessions" {
+			json.NewEncoder(w).Encode(map[string]any{"sessions": sessions})
+			return
+		}
+		handle(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return &config.Config{BeadhubURL: server.URL, Alias: "me", WorkspaceID: "ws-me"}
+}
+
+func TestChatSessionsWithConfig_GroupsOnlyNewestFirst(t *testing.T) {
+	cfg := newChatSessionServer(t, []ChatSession{
+		{SessionID: "s1", Participants: []string{"me", "alice"}, CreatedAt: "2025-06-01T10:00:00Z"},
+		{SessionID: "s2", Participants: []string{"me", "alice", "bob"}, CreatedAt: "2025-06-01T08:00:00Z"},
+		{SessionID: "s3", Participants: []string{"me", "carol", "dave"}, CreatedAt: "2025-06-01T09:00:00Z"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	result, err := chatSessionsWithConfig(context.Background(), cfg, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Sessions) != 2 || result.Sessions[0].SessionID != "s3" || result.Sessions[1].SessionID != "s2" {
+		t.Fatalf("sessions = %+v", result.Sessions)
+	}
+
+	out := formatChatSessionsOutput(result, "me", false)
+	if !strings.Contains(out, "carol, dave (group of 3) — s3") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestChatSessionRenameWithConfig(t *testing.T) {
+	var gotPath, gotName, gotAuth string
+	cfg := newChatSessionServer(t, []ChatSession{
+		{SessionID: "s2", Participants: []string{"me", "alice", "bob"}},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		var req struct {
+			Name string `json:"name"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		gotName = req.Name
+		json.NewEncoder(w).Encode(ChatSession{SessionID: "s2", Name: req.Name, Participants: []string{"me", "alice", "bob"}})
+	})
+
+	result, err := chatSessionRenameWithConfig(context.Background(), cfg, "alice,bob", "api-design")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "POST /v1/chat/sessions/s2/name" || gotName != "api-design" || gotAuth != "Bearer aw_sk_test123" {
+		t.Errorf("request = %s name=%q auth=%q", gotPath, gotName, gotAuth)
+	}
+	if !strings.Contains(formatChatSessionUpdate(result, false), `Session s2 named "api-design"`) {
+		t.Errorf("output = %s", formatChatSessionUpdate(result, false))
+	}
+}
+
+func TestChatSessionAddWithConfig_SkipsExistingParticipants(t *testing.T) {
+	var gotAliases []string
+	cfg := newChatSessionServer(t, []ChatSession{
+		{SessionID: "s2", Participants: []string{"me", "alice", "bob"}},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/sessions/s2/participants" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			Aliases []string `json:"aliases"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		gotAliases = req.Aliases
+		json.NewEncoder(w).Encode(ChatSession{SessionID: "s2", Participants: []string{"me", "alice", "bob", "carol"}})
+	})
+
+	result, err := chatSessionAddWithConfig(context.Background(), cfg, "alice,bob", []string{"bob", "carol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(gotAliases, ",") != "carol" || result.Detail != "carol" {
+		t.Errorf("aliases sent = %v, detail = %q", gotAliases, result.Detail)
+	}
+
+	if _, err := chatSessionAddWithConfig(context.Background(), cfg, "s2", []string{"alice"}); err == nil || !strings.Contains(err.Error(), "already in session alice, bob") {
+		t.Errorf("expected already-in-session error, got %v", err)
+	}
+}
+
+func TestChatSessionRemoveWithConfig(t *testing.T) {
+	var gotPath string
+	cfg := newChatSessionServer(t, []ChatSession{
+		{SessionID: "s1", Participants: []string{"me", "alice"}},
+		{SessionID: "s2", Participants: []string{"me", "alice", "bob"}},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"detail":"only participants can remove"}`))
+	})
+
+	_, err := chatSessionRemoveWithConfig(context.Background(), cfg, "alice,bob", "bob")
+	if err == nil || !strings.Contains(err.Error(), "aweb error (403): only participants can remove") {
+		t.Errorf("expected server error, got %v", err)
+	}
+	if gotPath != "DELETE /v1/chat/sessions/s2/participants/bob" {
+		t.Errorf("request = %s", gotPath)
+	}
+
+	if _, err := chatSessionRemoveWithConfig(context.Background(), cfg, "s1", "alice"); err == nil || !strings.Contains(err.Error(), "only two participants") {
+		t.Errorf("expected direct-session error, got %v", err)
+	}
+	if _, err := chatSessionRemoveWithConfig(context.Background(), cfg, "s2", "carol"); err == nil || !strings.Contains(err.Error(), "carol is not in session") {
+		t.Errorf("expected not-in-session error, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// Reservation expirations, claim ages and presence deadlines are server
+// timestamps shown relative to the local clock. On a machine whose clock
+// drifts they come out wrong ("expires in 0s" for a fresh lock), so bdh
+// measures the offset from the server's Date headers, renders those times
+// against server time instead, and warns once the offset is large enough
+// to matter.
+
+// clockSkewThreshold is the offset from which bdh corrects times and warns.
+// Below it the Date header's one-second resolution and network latency
+// make the measurement noise.
+const clockSkewThreshold = 30 * time.Second
+
+// measuredClockSkew returns the server clock's offset from the local one
+// when it is at least clockSkewThreshold. Tests replace this.
+var measuredClockSkew = func() (time.Duration, bool) {
+	skew, ok := client.ClockSkew()
+	if !ok || (skew < clockSkewThreshold && skew > -clockSkewThreshold) {
+		return 0, false
+	}
+	return skew, true
+}
+
+// serverNow is the current time on the server's clock, as far as bdh has
+// measured it; the local time otherwise.
+func serverNow() time.Time {
+	skew, _ := measuredClockSkew()
+	return time.Now().Add(skew)
+}
+
+// warnClockSkew tells the user their clock is off, if it is.
+func warnClockSkew(w io.Writer) {
+	skew, ok := measuredClockSkew()
+	if !ok {
+		return
+	}
+	fmt.Fprint(w, formatClockSkewWarning(skew))
+}
+
+func formatClockSkewWarning(skew time.Duration) string {
+	direction := "behind"
+	if skew < 0 {
+		direction = "ahead of"
+		skew = -skew
+	}
+	return fmt.Sprintf("Warning: this machine's clock is %s %s the BeadHub server. "+
+		"Reservation expirations, claim ages and deadlines were corrected for it; "+
+		"other local times may be wrong until the clock is synced (e.g. enable NTP).\n",
+		formatDuration(int(skew.Round(time.Second).Seconds())), direction)
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func withClockSkew(t *testing.T, skew time.Duration) {
+	t.Helper()
+	orig := measuredClockSkew
+	measuredClockSkew = func() (time.Duration, bool) { return skew, skew != 0 }
+	t.Cleanup(func() { measuredClockSkew = orig })
+}
+
+func TestServerNow_AppliesSkew(t *testing.T) {
+	withClockSkew(t, -10*time.Minute)
+
+	// The local clock is 10 minutes ahead: a lock the server says expires
+	// in 5 minutes looks already expired against local time.
+	expiresAt := time.Now().Add(-5 * time.Minute).UTC().Format(time.RFC3339)
+	if got := formatDuration(ttlRemainingSeconds(expiresAt, serverNow())); got != "4m59s" && got != "5m" {
+		t.Errorf("remaining = %s, want about 5m", got)
+	}
+	claimedAt := time.Now().Add(-15 * time.Minute).UTC().Format(time.RFC3339)
+	if got := formatTimeAgoAt(claimedAt, serverNow()); got != "5m ago" && got != "4m ago" {
+		t.Errorf("claimed = %s, want 5m ago", got)
+	}
+}
+
+func TestFormatClockSkewWarning(t *testing.T) {
+	if got := formatClockSkewWarning(-(2*time.Minute + 30*time.Second)); !strings.Contains(got, "clock is 2m30s ahead of the BeadHub server") {
+		t.Errorf("ahead: %q", got)
+	}
+	if got := formatClockSkewWarning(time.Hour); !strings.Contains(got, "clock is 1h behind the BeadHub server") {
+		t.Errorf("behind: %q", got)
+	}
+
+	withClockSkew(t, 0)
+	var sb strings.Builder
+	warnClockSkew(&sb)
+	if sb.Len() != 0 {
+		t.Errorf("no skew: warned %q", sb.String())
+	}
+}
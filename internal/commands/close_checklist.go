@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Close checklists turn the policy's close_requirements items into a gate:
+// every item must be confirmed (or marked not applicable) before bd close
+// runs, and the answers are recorded in the close reason. On a terminal bdh
+// asks for each item; otherwise the answers come from --:check and --:na,
+// which take 1-based item numbers ("1,3") or "all".
+
+// Checklist answers.
+const (
+	checklistDone = "done"
+	checklistNA   = "n/a"
+)
+
+// parseValueFlag removes every occurrence of a --:flag that takes a value
+// from args, supporting "--:flag value" and "--:flag=value".
+func parseValueFlag(args []string, flag string) (cleanArgs []string, values []string) {
+	cleanArgs = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, flag+"=") {
+			values = append(values, strings.TrimPrefix(arg, flag+"="))
+			continue
+		}
+		if arg == flag {
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				values = append(values, args[i+1])
+				i++
+			} else {
+				values = append(values, "")
+			}
+			continue
+		}
+		cleanArgs = append(cleanArgs, arg)
+	}
+	return cleanArgs, values
+}
+
+// applyChecklistFlags records answers given on the command line. Each value
+// is a comma-separated list of item numbers, or "all".
+func applyChecklistFlags(items []string, answers map[string]string, flag string, values []string, answer string) error {
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if strings.EqualFold(part, "all") {
+				for _, item := range items {
+					answers[item] = answer
+				}
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 1 || n > len(items) {
+				return fmt.Errorf("%s expects item numbers between 1 and %d or \"all\", got %q", flag, len(items), part)
+			}
+			answers[items[n-1]] = answer
+		}
+	}
+	return nil
+}
+
+// promptChecklist asks about each unanswered item. "y" confirms it, "na"
+// marks it not applicable; anything else leaves it unconfirmed.
+func promptChecklist(items []string, answers map[string]string, in *bufio.Reader, out io.Writer) {
+	for i, item := range items {
+		if answers[item] != "" {
+			continue
+		}
+		fmt.Fprintf(out, "%d. %s? [y/n/na]: ", i+1, item)
+		line, err := readMenuLine(in)
+		if err != nil {
+			return
+		}
+		switch strings.ToLower(line) {
+		case "y", "yes":
+			answers[item] = checklistDone
+		case "na", "n/a":
+			answers[item] = checklistNA
+		}
+	}
+}
+
+// unconfirmedChecklistItems returns the items without an answer, numbered.
+func unconfirmedChecklistItems(items []string, answers map[string]string) []string {
+	var missing []string
+	for i, item := range items {
+		if answers[item] == "" {
+			missing = append(missing, fmt.Sprintf("%d. %s", i+1, item))
+		}
+	}
+	return missing
+}
+
+// closeChecklistBlock explains which close requirements are still open and
+// how to confirm them.
+func closeChecklistBlock(missing []string) string {
+	var sb strings.Builder
+	sb.WriteString("close requirements from the project policy are not confirmed:\n")
+	for _, m := range missing {
+		sb.WriteString("  " + m + "\n")
+	}
+	sb.WriteString("Confirm them with --:check <n>[,<n>...] (or --:check all), or mark them not applicable with --:na <n>.")
+	return sb.String()
+}
+
+// formatChecklistReason renders the answers for the close reason.
+func formatChecklistReason(items []string, answers map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString("Close checklist:")
+	for _, item := range items {
+		mark := "[x]"
+		if answers[item] == checklistNA {
+			mark = "[n/a]"
+		}
+		sb.WriteString(fmt.Sprintf("\n- %s %s", mark, item))
+	}
+	return sb.String()
+}
+
+// runCloseChecklist collects answers for the close requirements of adapter.
+// It returns a block reason when items remain unconfirmed; otherwise it
+// records the answers on the adapter and returns the close reason text.
+func runCloseChecklist(adapter *PolicyAdapter, checked, na []string, interactive bool) (reasonText, blocked string, err error) {
+	if adapter == nil || adapter.Key != policyAdapterClose || len(adapter.Items) == 0 {
+		if len(checked) > 0 || len(na) > 0 {
+			return "", "", fmt.Errorf("--:check and --:na need close requirements in the project policy")
+		}
+		return "", "", nil
+	}
+
+	answers := make(map[string]string, len(adapter.Items))
+	if err := applyChecklistFlags(adapter.Items, answers, "--:na", na, checklistNA); err != nil {
+		return "", "", err
+	}
+	if err := applyChecklistFlags(adapter.Items, answers, "--:check", checked, checklistDone); err != nil {
+		return "", "", err
+	}
+	if interactive && len(unconfirmedChecklistItems(adapter.Items, answers)) > 0 {
+		fmt.Fprintln(os.Stderr, "Close requirements (project policy):")
+		promptChecklist(adapter.Items, answers, bufio.NewReader(os.Stdin), os.Stderr)
+	}
+
+	if missing := unconfirmedChecklistItems(adapter.Items, answers); len(missing) > 0 {
+		return "", closeChecklistBlock(missing), nil
+	}
+	adapter.Answers = answers
+	return formatChecklistReason(adapter.Items, answers), "", nil
+}
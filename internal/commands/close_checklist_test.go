@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func closeChecklistAdapter() *PolicyAdapter {
+	return &PolicyAdapter{
+		Key:   policyAdapterClose,
+		Items: []string{"Tests run", "Docs updated", "Reviewer notified"},
+	}
+}
+
+func TestParseValueFlag(t *testing.T) {
+	clean, values := parseValueFlag([]string{"close", "bd-1", "--:check", "1,2", "--:check=3", "--reason", "done"}, "--:check")
+	if strings.Join(clean, " ") != "close bd-1 --reason done" {
+		t.Errorf("clean args: %v", clean)
+	}
+	if strings.Join(values, "|") != "1,2|3" {
+		t.Errorf("values: %v", values)
+	}
+}
+
+func TestRunCloseChecklist_Flags(t *testing.T) {
+	adapter := closeChecklistAdapter()
+	reason, blocked, err := runCloseChecklist(adapter, []string{"1,3"}, []string{"2"}, false)
+	if err != nil || blocked != "" {
+		t.Fatalf("unexpected block=%q err=%v", blocked, err)
+	}
+	want := "Close checklist:\n- [x] Tests run\n- [n/a] Docs updated\n- [x] Reviewer notified"
+	if reason != want {
+		t.Errorf("reason:\ngot  %q\nwant %q", reason, want)
+	}
+	if out := formatPolicyAdapterSection(adapter); !strings.Contains(out, "- [n/a] Docs updated") {
+		t.Errorf("section should show answers:\n%s", out)
+	}
+}
+
+func TestRunCloseChecklist_BlocksUnconfirmed(t *testing.T) {
+	_, blocked, err := runCloseChecklist(closeChecklistAdapter(), []string{"1"}, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(blocked, "2. Docs updated") || !strings.Contains(blocked, "3. Reviewer notified") || strings.Contains(blocked, "1. Tests run") {
+		t.Errorf("unexpected block:\n%s", blocked)
+	}
+}
+
+func TestRunCloseChecklist_Errors(t *testing.T) {
+	if _, _, err := runCloseChecklist(closeChecklistAdapter(), []string{"4"}, nil, false); err == nil {
+		t.Error("expected an error for an out-of-range item")
+	}
+	if _, _, err := runCloseChecklist(nil, []string{"all"}, nil, false); err == nil {
+		t.Error("expected an error without close requirements")
+	}
+	if reason, blocked, err := runCloseChecklist(nil, nil, nil, false); reason != "" || blocked != "" || err != nil {
+		t.Errorf("no requirements: %q %q %v", reason, blocked, err)
+	}
+}
+
+func TestPromptChecklist(t *testing.T) {
+	adapter := closeChecklistAdapter()
+	answers := map[string]string{"Tests run": checklistDone}
+	promptChecklist(adapter.Items, answers, bufio.NewReader(strings.NewReader("na\nno\n")), io.Discard)
+	if answers["Docs updated"] != checklistNA || answers["Reviewer notified"] != "" {
+		t.Errorf("unexpected answers: %v", answers)
+	}
+}
+
+func TestWithCloseSummaryReason_Checklist(t *testing.T) {
+	args := withCloseSummaryReason([]string{"close", "bd-1", "--reason", "fixed"}, "Close checklist:\n- [x] Tests run")
+	if args[3] != "fixed\n\nClose checklist:\n- [x] Tests run" {
+		t.Errorf("unexpected reason: %q", args[3])
+	}
+}
@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Closure summaries (--:summary on close) record what was done on a bead:
+// how long it was claimed, files reserved and modified, and the last message
+// of chats that mention it. The summary is appended to the bd close reason and
+// posted as a bead note so agents in other repos see it too.
+
+const (
+	closeSummaryMaxFiles       = 15
+	closeSummaryMaxChats       = 3
+	closeSummaryChatsScanned   = 10
+	closeSummaryChatHistory    = 20
+	closeSummaryMessagePreview = 120
+)
+
+// CloseSummary is what --:summary compiles for a bead being closed.
+type CloseSummary struct {
+	BeadID          string             `json:"bead_id"`
+	ClaimedAt       string             `json:"claimed_at,omitempty"`
+	ClaimedSeconds  int                `json:"claimed_seconds,omitempty"`
+	ReservedFiles   []string           `json:"reserved_files,omitempty"`
+	ModifiedFiles   []string           `json:"modified_files,omitempty"`
+	Chats           []CloseSummaryChat `json:"chats,omitempty"`
+	Text            string             `json:"text"`
+	NoteWarning     string             `json:"note_warning,omitempty"`
+	CollectWarnings []string           `json:"collect_warnings,omitempty"`
+}
+
+// CloseSummaryChat is the last message mentioning the bead in one chat.
+type CloseSummaryChat struct {
+	With      []string `json:"with"`
+	From      string   `json:"from"`
+	Body      string   `json:"body"`
+	Timestamp string   `json:"timestamp,omitempty"`
+}
+
+// buildCloseSummary gathers summary data. Every source is best-effort; a
+// failure is recorded in CollectWarnings and the rest of the summary is kept.
+func buildCloseSummary(ctx context.Context, cfg *config.Config, aw AwebAPI, beadID string, beadsInProgress []client.BeadInProgress, now time.Time) *CloseSummary {
+	summary := &CloseSummary{BeadID: beadID}
+
+	for _, bip := range beadsInProgress {
+		if bip.BeadID != beadID || bip.WorkspaceID != cfg.WorkspaceID {
+			continue
+		}
+		summary.ClaimedAt = bip.StartedAt
+		if ts, ok := parseTimeBestEffort(bip.StartedAt); ok && now.After(ts) {
+			summary.ClaimedSeconds = int(now.Sub(ts).Seconds())
+		}
+	}
+
+	if root, err := gitRepoRoot(ctx); err == nil {
+		if entries, err := gitStatusPorcelainV1Z(ctx, root, false); err == nil {
+			for _, e := range entries {
+				summary.ModifiedFiles = append(summary.ModifiedFiles, e.Path)
+			}
+			sort.Strings(summary.ModifiedFiles)
+		} else {
+			summary.CollectWarnings = append(summary.CollectWarnings, fmt.Sprintf("git status: %v", err))
+		}
+	}
+
+	if aw == nil {
+		summary.Text = summary.render()
+		return summary
+	}
+
+	if resp, err := aw.ReservationList(ctx, ""); err == nil {
+		for _, r := range resp.Reservations {
+			if r.HolderAgentID == cfg.WorkspaceID || (r.HolderAgentID == "" && r.HolderAlias == cfg.Alias) {
+				summary.ReservedFiles = append(summary.ReservedFiles, r.ResourceKey)
+			}
+		}
+		sort.Strings(summary.ReservedFiles)
+	} else {
+		summary.CollectWarnings = append(summary.CollectWarnings, fmt.Sprintf("reservations: %v", err))
+	}
+
+	summary.Chats = relatedChatMessages(ctx, aw, beadID, cfg.Alias, &summary.CollectWarnings)
+	summary.Text = summary.render()
+	return summary
+}
+
+// relatedChatMessages returns the last message mentioning beadID in each of
+// the most recent chat sessions.
+func relatedChatMessages(ctx context.Context, aw AwebAPI, beadID, myAlias string, warnings *[]string) []CloseSummaryChat {
+	sessions, err := aw.ChatListSessions(ctx)
+	if err != nil {
+		*warnings = append(*warnings, fmt.Sprintf("chats: %v", err))
+		return nil
+	}
+	items := sessions.Sessions
+	sort.SliceStable(items, func(i, j int) bool { return items[i].CreatedAt > items[j].CreatedAt })
+	if len(items) > closeSummaryChatsScanned {
+		items = items[:closeSummaryChatsScanned]
+	}
+
+	var chats []CloseSummaryChat
+	for _, s := range items {
+		history, err := aw.ChatHistory(ctx, aweb.ChatHistoryParams{SessionID: s.SessionID, Limit: closeSummaryChatHistory})
+		if err != nil {
+			continue
+		}
+		for i := len(history.Messages) - 1; i >= 0; i-- {
+			msg := history.Messages[i]
+			if !strings.Contains(msg.Body, beadID) {
+				continue
+			}
+			var with []string
+			for _, p := range s.Participants {
+				if p != myAlias {
+					with = append(with, p)
+				}
+			}
+			chats = append(chats, CloseSummaryChat{With: with, From: msg.FromAgent, Body: msg.Body, Timestamp: msg.Timestamp})
+			break
+		}
+		if len(chats) == closeSummaryMaxChats {
+			break
+		}
+	}
+	return chats
+}
+
+// render formats the summary as plain lines for the close reason and bead note.
+func (s *CloseSummary) render() string {
+	var lines []string
+	if s.ClaimedSeconds > 0 {
+		lines = append(lines, fmt.Sprintf("Claimed for %s.", formatDuration(s.ClaimedSeconds)))
+	}
+	if len(s.ReservedFiles) > 0 {
+		lines = append(lines, "Reserved: "+joinCapped(s.ReservedFiles, closeSummaryMaxFiles))
+	}
+	if len(s.ModifiedFiles) > 0 {
+		lines = append(lines, "Modified: "+joinCapped(s.ModifiedFiles, closeSummaryMaxFiles))
+	}
+	for _, chat := range s.Chats {
+		lines = append(lines, fmt.Sprintf("Chat with %s, last: %s: %s",
+			strings.Join(chat.With, ", "), chat.From, truncateText(chat.Body, closeSummaryMessagePreview)))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "Summary: " + strings.Join(lines, "\n")
+}
+
+func joinCapped(items []string, max int) string {
+	if len(items) <= max {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(items[:max], ", "), len(items)-max)
+}
+
+func truncateText(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-3]) + "..."
+}
+
+// withCloseSummaryReason appends summary to the --reason/-r value in bd close
+// args, or adds --reason if none was given.
+func withCloseSummaryReason(args []string, summary string) []string {
+	if summary == "" {
+		return args
+	}
+	out := append([]string{}, args...)
+	for i, arg := range out {
+		switch {
+		case (arg == "--reason" || arg == "-r") && i+1 < len(out):
+			out[i+1] = joinReason(out[i+1], summary)
+			return out
+		case strings.HasPrefix(arg, "--reason="):
+			out[i] = "--reason=" + joinReason(strings.TrimPrefix(arg, "--reason="), summary)
+			return out
+		}
+	}
+	return append(out, "--reason", summary)
+}
+
+func joinReason(reason, summary string) string {
+	if strings.TrimSpace(reason) == "" {
+		return summary
+	}
+	return reason + "\n\n" + summary
+}
+
+// truncateNoteBody keeps line breaks but fits the server's note length limit.
+func truncateNoteBody(s string) string {
+	r := []rune(s)
+	if len(r) <= maxNoteLength {
+		return s
+	}
+	return string(r[:maxNoteLength-3]) + "..."
+}
+
+// postCloseSummaryNote attaches the summary to the bead as a BeadHub note.
+func postCloseSummaryNote(ctx context.Context, cfg *config.Config, summary *CloseSummary) {
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		summary.NoteWarning = err.Error()
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	_, err = c.AddBeadNote(ctx, summary.BeadID, &client.AddBeadNoteRequest{
+		WorkspaceID: cfg.WorkspaceID,
+		Alias:       cfg.Alias,
+		RepoID:      cfg.RepoID,
+		Body:        truncateNoteBody(summary.Text),
+	})
+	if err != nil {
+		summary.NoteWarning = fmt.Sprintf("could not post summary note: %v", err)
+	}
+}
+
+// formatCloseSummarySection renders the summary after a close.
+func formatCloseSummarySection(summary *CloseSummary) string {
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinationHeader())
+	sb.WriteString(fmt.Sprintf("\n## Closure Summary for %s\n", summary.BeadID))
+	if summary.Text == "" {
+		sb.WriteString("Nothing to summarize (no claim, reservations, changes, or chats found).\n")
+	} else {
+		for _, line := range strings.Split(strings.TrimPrefix(summary.Text, "Summary: "), "\n") {
+			sb.WriteString(fmt.Sprintf("- %s\n", line))
+		}
+	}
+	if summary.NoteWarning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", summary.NoteWarning))
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestWithCloseSummaryReason(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"close", "bd-1"}, "close bd-1 --reason S"},
+		{[]string{"close", "bd-1", "--reason", "done"}, "close bd-1 --reason done\n\nS"},
+		{[]string{"close", "bd-1", "-r", "done"}, "close bd-1 -r done\n\nS"},
+		{[]string{"close", "bd-1", "--reason=done"}, "close bd-1 --reason=done\n\nS"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(withCloseSummaryReason(tt.args, "S"), " "); got != tt.want {
+			t.Errorf("withCloseSummaryReason(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+	if got := withCloseSummaryReason([]string{"close", "bd-1"}, ""); len(got) != 2 {
+		t.Errorf("empty summary should leave args alone, got %v", got)
+	}
+}
+
+func TestBuildCloseSummary(t *testing.T) {
+	t.Chdir(t.TempDir()) // outside git: no modified files
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/reservations":
+			json.NewEncoder(w).Encode(map[string]any{"reservations": []map[string]any{
+				{"resource_key": "src/b.go", "holder_agent_id": "ws-me", "holder_alias": "alice"},
+				{"resource_key": "src/a.go", "holder_agent_id": "ws-me", "holder_alias": "alice"},
+				{"resource_key": "other.go", "holder_agent_id": "ws-bob", "holder_alias": "bob"},
+			}})
+		case "/v1/chat/sessions":
+			json.NewEncoder(w).Encode(map[string]any{"sessions": []map[string]any{
+				{"session_id": "s1", "participants": []string{"alice", "bob"}, "created_at": "2025-06-01T10:00:00Z"},
+				{"session_id": "s2", "participants": []string{"alice", "carol"}, "created_at": "2025-06-01T09:00:00Z"},
+			}})
+		case "/v1/chat/sessions/s1/messages":
+			json.NewEncoder(w).Encode(map[string]any{"messages": []map[string]any{
+				{"from_agent": "bob", "body": "is bd-42 blocked on the schema?"},
+				{"from_agent": "alice", "body": "bd-42: schema landed, wiring it up"},
+				{"from_agent": "bob", "body": "thanks"},
+			}})
+		case "/v1/chat/sessions/s2/messages":
+			json.NewEncoder(w).Encode(map[string]any{"messages": []map[string]any{
+				{"from_agent": "carol", "body": "unrelated"},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	aw, err := aweb.NewWithAPIKey(server.URL, "aw_sk_test123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{WorkspaceID: "ws-me", Alias: "alice"}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	bips := []client.BeadInProgress{
+		{BeadID: "bd-42", WorkspaceID: "ws-me", StartedAt: "2025-06-01T10:30:00Z"},
+		{BeadID: "bd-42", WorkspaceID: "ws-bob", StartedAt: "2025-06-01T08:00:00Z"},
+	}
+
+	summary := buildCloseSummary(context.Background(), cfg, aw, "bd-42", bips, now)
+
+	if summary.ClaimedSeconds != 90*60 {
+		t.Errorf("ClaimedSeconds = %d", summary.ClaimedSeconds)
+	}
+	if strings.Join(summary.ReservedFiles, ",") != "src/a.go,src/b.go" {
+		t.Errorf("ReservedFiles = %v", summary.ReservedFiles)
+	}
+	if len(summary.Chats) != 1 || summary.Chats[0].From != "alice" || strings.Join(summary.Chats[0].With, ",") != "bob" {
+		t.Errorf("Chats = %+v", summary.Chats)
+	}
+	for _, want := range []string{"Claimed for 1h30m.", "Reserved: src/a.go, src/b.go", "Chat with bob, last: alice: bd-42: schema landed"} {
+		if !strings.Contains(summary.Text, want) {
+			t.Errorf("summary text missing %q:\n%s", want, summary.Text)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Command aliases (the aliases map in .beadhub) are shortcuts for bd
+// invocations: with r: "ready --priority 1", `bdh r --json` runs
+// `bd ready --priority 1 --json`, with the usual pre-flight and sync. Only the
+// first word is expanded, and only once, so an alias may shadow the bd
+// command it expands to (ready: "ready --limit 5").
+
+// CommandAlias is one configured shortcut.
+type CommandAlias struct {
+	Name      string `json:"name"`
+	Expansion string `json:"expansion"`
+}
+
+var commandAliasJSON bool
+
+var commandAliasCmd = &cobra.Command{
+	Use:   ":alias",
+	Short: "Manage command shortcuts (aliases in .beadhub)",
+	Long: `Manage command shortcuts. An alias expands to a bd invocation before
+bdh runs it; anything typed after the alias is appended.
+
+Examples:
+  bdh :alias add r "ready --priority 1"
+  bdh :alias add done -- close --reason
+  bdh r                                   # runs: bd ready --priority 1
+  bdh done bd-42 "fixed"                  # runs: bd close --reason bd-42 "fixed"
+  bdh :alias list
+  bdh :alias remove r`,
+}
+
+var commandAliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List command aliases",
+	Args:  cobra.NoArgs,
+	RunE:  runCommandAliasList,
+}
+
+var commandAliasAddCmd = &cobra.Command{
+	Use:   "add <name> <expansion...>",
+	Short: "Add or replace a command alias",
+	Long: `Add or replace a command alias. Quote the expansion, or put it after --
+so its flags are not read as flags of this command.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runCommandAliasAdd,
+}
+
+var commandAliasRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a command alias",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCommandAliasRemove,
+}
+
+func init() {
+	commandAliasListCmd.Flags().BoolVar(&commandAliasJSON, "json", false, "Output as JSON")
+
+	commandAliasCmd.AddCommand(commandAliasListCmd)
+	commandAliasCmd.AddCommand(commandAliasAddCmd)
+	commandAliasCmd.AddCommand(commandAliasRemoveCmd)
+}
+
+func runCommandAliasList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatCommandAliasList(sortedCommandAliases(cfg), commandAliasJSON))
+	return nil
+}
+
+func runCommandAliasAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	replaced, err := setCommandAlias(cfg, args[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	verb := "Added"
+	if replaced {
+		verb = "Replaced"
+	}
+	fmt.Printf("%s alias %s → bd %s\n", verb, args[0], cfg.Aliases[args[0]])
+	return nil
+}
+
+func runCommandAliasRemove(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Aliases[args[0]]; !ok {
+		return fmt.Errorf("no alias named %q (see bdh :alias list)", args[0])
+	}
+	delete(cfg.Aliases, args[0])
+	if len(cfg.Aliases) == 0 {
+		cfg.Aliases = nil
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	fmt.Printf("Removed alias %s\n", args[0])
+	return nil
+}
+
+// setCommandAlias validates and stores an alias on cfg. Reports whether an
+// existing alias was replaced.
+func setCommandAlias(cfg *config.Config, name, expansion string) (bool, error) {
+	if !config.IsValidCommandAlias(name) {
+		return false, fmt.Errorf("invalid alias name %q: must start with a letter and contain only letters, digits, dashes, or underscores", name)
+	}
+	expansion = strings.TrimSpace(expansion)
+	words, err := splitAliasExpansion(expansion)
+	if err != nil {
+		return false, fmt.Errorf("alias %s: %w", name, err)
+	}
+	if len(words) == 0 {
+		return false, fmt.Errorf("alias %s: expansion cannot be empty", name)
+	}
+	if strings.HasPrefix(words[0], ":") {
+		return false, fmt.Errorf("alias %s: expansion must be a bd command, not a bdh one (%s)", name, words[0])
+	}
+	_, replaced := cfg.Aliases[name]
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[name] = expansion
+	return replaced, nil
+}
+
+// expandCommandAlias replaces args[0] with its alias expansion, if any.
+// Returns the expanded args and the alias used ("" when none applied).
+func expandCommandAlias(aliases map[string]string, args []string) ([]string, string, error) {
+	if len(args) == 0 || len(aliases) == 0 {
+		return args, "", nil
+	}
+	expansion, ok := aliases[args[0]]
+	if !ok {
+		return args, "", nil
+	}
+	words, err := splitAliasExpansion(expansion)
+	if err != nil {
+		return nil, "", fmt.Errorf("alias %s in .beadhub: %w", args[0], err)
+	}
+	expanded := make([]string, 0, len(words)+len(args)-1)
+	expanded = append(expanded, words...)
+	expanded = append(expanded, args[1:]...)
+	return expanded, args[0], nil
+}
+
+// splitAliasExpansion splits an expansion into words the way a shell would
+// for simple cases: whitespace separates words, single and double quotes
+// group them, and a backslash escapes the next character outside single
+// quotes.
+func splitAliasExpansion(s string) ([]string, error) {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+func sortedCommandAliases(cfg *config.Config) []CommandAlias {
+	aliases := make([]CommandAlias, 0, len(cfg.Aliases))
+	for name, expansion := range cfg.Aliases {
+		aliases = append(aliases, CommandAlias{Name: name, Expansion: expansion})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases
+}
+
+func formatCommandAliasList(aliases []CommandAlias, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(struct {
+			Aliases []CommandAlias `json:"aliases"`
+		}{Aliases: aliases})
+	}
+	if len(aliases) == 0 {
+		return "No aliases defined. Add one with: bdh :alias add <name> \"<bd command>\"\n"
+	}
+	width := 0
+	for _, a := range aliases {
+		width = max(width, len(a.Name))
+	}
+	var sb strings.Builder
+	for _, a := range aliases {
+		sb.WriteString(fmt.Sprintf("%-*s → bd %s\n", width, a.Name, a.Expansion))
+	}
+	return sb.String()
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestSplitAliasExpansion(t *testing.T) {
+	cases := map[string][]string{
+		"ready --priority 1":             {"ready", "--priority", "1"},
+		`close --reason "fixed in main"`: {"close", "--reason", "fixed in main"},
+		`list --title 'it''s'`:           {"list", "--title", "its"},
+		`create a\ b ""`:                 {"create", "a b", ""},
+		"  ":                             nil,
+	}
+	for in, want := range cases {
+		got, err := splitAliasExpansion(in)
+		if err != nil || strings.Join(got, "|") != strings.Join(want, "|") || len(got) != len(want) {
+			t.Errorf("splitAliasExpansion(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := splitAliasExpansion(`close --reason "oops`); err == nil {
+		t.Error("expected unterminated quote error")
+	}
+}
+
+func TestExpandCommandAlias(t *testing.T) {
+	aliases := map[string]string{
+		"r":     "ready --priority 1",
+		"done":  "close --reason",
+		"ready": "ready --limit 5",
+	}
+	got, used, err := expandCommandAlias(aliases, []string{"done", "bd-42", "fixed it"})
+	if err != nil || used != "done" || strings.Join(got, "|") != "close|--reason|bd-42|fixed it" {
+		t.Fatalf("got %q %q %v", got, used, err)
+	}
+	// Expansion happens once, so an alias can shadow the command it runs
+	got, _, _ = expandCommandAlias(aliases, []string{"ready"})
+	if strings.Join(got, " ") != "ready --limit 5" {
+		t.Fatalf("got %q", got)
+	}
+	got, used, _ = expandCommandAlias(aliases, []string{"show", "bd-1"})
+	if used != "" || strings.Join(got, " ") != "show bd-1" {
+		t.Fatalf("got %q %q", got, used)
+	}
+}
+
+func TestSetCommandAlias(t *testing.T) {
+	cfg := &config.Config{}
+	replaced, err := setCommandAlias(cfg, "r", "ready --priority 1")
+	if err != nil || replaced || cfg.Aliases["r"] != "ready --priority 1" {
+		t.Fatalf("got %v %v %v", replaced, err, cfg.Aliases)
+	}
+	if replaced, err := setCommandAlias(cfg, "r", " ready "); err != nil || !replaced || cfg.Aliases["r"] != "ready" {
+		t.Fatalf("got %v %v %v", replaced, err, cfg.Aliases)
+	}
+	for name, expansion := range map[string]string{"1r": "ready", "st": ":status", "x": "  ", "q": `ready "open`} {
+		if _, err := setCommandAlias(cfg, name, expansion); err == nil {
+			t.Errorf("setCommandAlias(%q, %q) should fail", name, expansion)
+		}
+	}
+
+	out := formatCommandAliasList(sortedCommandAliases(&config.Config{Aliases: map[string]string{"r": "ready", "done": "close --reason"}}), false)
+	if out != "done → bd close --reason\nr    → bd ready\n" {
+		t.Fatalf("unexpected list:\n%s", out)
+	}
+}
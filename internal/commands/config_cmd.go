@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+var configValidateJSON bool
+
+var configCmd = &cobra.Command{
+	Use:   ":config",
+	Short: "Check the .beadhub configuration file",
+	Long: `Inspect and validate the .beadhub configuration file.
+
+Editors that understand YAML schemas can validate .beadhub as you type.
+Write the schema next to it and reference it with a comment:
+
+  bdh :config schema > .beadhub.schema.json
+  # yaml-language-server: $schema=./.beadhub.schema.json
+
+The comment is kept when bdh rewrites .beadhub.
+
+Examples:
+  bdh :config validate          # Report every problem in .beadhub
+  bdh :config validate --json   # Output as JSON
+  bdh :config schema            # Print the JSON Schema for .beadhub`,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Report all problems in .beadhub, including unknown keys",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigValidate,
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for .beadhub",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Print(marshalJSONOrFallback(config.JSONSchema()))
+		return nil
+	},
+}
+
+func init() {
+	configValidateCmd.Flags().BoolVar(&configValidateJSON, "json", false, "Output as JSON")
+
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+// ConfigValidateResult is the outcome of validating a .beadhub file.
+type ConfigValidateResult struct {
+	Path          string           `json:"path"`
+	Valid         bool             `json:"valid"`
+	Problems      []config.Problem `json:"problems"`
+	Schema        string           `json:"schema,omitempty"`
+	SchemaWarning string           `json:"schema_warning,omitempty"`
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	path, err := config.FindPath()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return err
+	}
+
+	result, err := validateConfigFile(path)
+	if err != nil {
+		return err
+	}
+	output := formatConfigValidateOutput(result, configValidateJSON)
+	if !result.Valid && !configValidateJSON {
+		// The problem list becomes the error so main prints it once
+		return errors.New(strings.TrimSuffix(output, "\n"))
+	}
+	fmt.Print(output)
+	if !result.Valid {
+		return fmt.Errorf("%s has %d problem(s)", path, len(result.Problems))
+	}
+	return nil
+}
+
+func validateConfigFile(path string) (*ConfigValidateResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	result := &ConfigValidateResult{
+		Path:     path,
+		Problems: config.ValidateBytes(data),
+		Schema:   config.SchemaReference(data),
+	}
+	if result.Problems == nil {
+		result.Problems = []config.Problem{}
+	}
+	result.Valid = len(result.Problems) == 0
+
+	// Only local schema files can be checked; URLs are left to the editor.
+	if ref := result.Schema; ref != "" && !strings.Contains(ref, "://") {
+		schemaPath := ref
+		if !filepath.IsAbs(schemaPath) {
+			schemaPath = filepath.Join(filepath.Dir(path), ref)
+		}
+		if _, err := os.Stat(schemaPath); err != nil {
+			result.SchemaWarning = fmt.Sprintf("$schema file %s not found - create it with 'bdh :config schema > %s'", ref, ref)
+		}
+	}
+	return result, nil
+}
+
+func formatConfigValidateOutput(result *ConfigValidateResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Valid {
+		sb.WriteString(fmt.Sprintf("%s is valid.\n", result.Path))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s has %d problem(s):\n", result.Path, len(result.Problems)))
+		for _, p := range result.Problems {
+			sb.WriteString(fmt.Sprintf("  - %s\n", p.String()))
+		}
+	}
+	if result.SchemaWarning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", result.SchemaWarning))
+	}
+	return sb.String()
+}
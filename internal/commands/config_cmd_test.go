@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".beadhub")
+	content := `# yaml-language-server: $schema=./.beadhub.schema.json
+workspace_id: "a1b2c3d4-5678-90ab-cdef-1234567890ab"
+beadhub_url: "http://localhost:8000"
+project_slug: "test"
+repo_origin: "git@github.com:test/repo.git"
+canonical_origin: "github.com/test/repo"
+alias: "alice"
+human_name: "Alice"
+auto_reserv: false
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := validateConfigFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Valid || len(result.Problems) != 1 {
+		t.Fatalf("expected one problem, got %+v", result.Problems)
+	}
+	if !strings.Contains(result.SchemaWarning, "bdh :config schema > ./.beadhub.schema.json") {
+		t.Errorf("schema warning = %q", result.SchemaWarning)
+	}
+
+	out := formatConfigValidateOutput(result, false)
+	if !strings.Contains(out, `line 9: unknown key "auto_reserv" (did you mean "auto_reserve"?)`) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(formatConfigValidateOutput(result, true)), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded["valid"] != false {
+		t.Errorf("valid = %v", decoded["valid"])
+	}
+}
+
+func TestRunConfigValidate_ReportsProblemsOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".beadhub")
+	if err := os.WriteFile(path, []byte("auto_reserv: false\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config.SetPath(path)
+	t.Cleanup(func() { config.SetPath("") })
+
+	var err error
+	configValidateCmd.SetContext(context.Background())
+	out := captureStdout(t, func() { err = runConfigValidate(configValidateCmd, nil) })
+	if out != "" {
+		t.Errorf("stdout = %q, want the problems only in the error", out)
+	}
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := strings.Count(err.Error(), "problem(s)"); got != 1 {
+		t.Errorf("summary printed %d times:\n%s", got, err)
+	}
+	if !strings.Contains(err.Error(), `unknown key "auto_reserv"`) {
+		t.Errorf("error missing the problem list:\n%s", err)
+	}
+}
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// loadWorkspaceConfig loads and validates .beadhub for commands that need a
+// workspace, and checks it belongs to the current repo.
+func loadWorkspaceConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
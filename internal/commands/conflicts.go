@@ -0,0 +1,332 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :conflicts history is the personal view of reservation conflicts: which
+// agents this workspace keeps colliding with, on which paths, and how that
+// changes week by week. It reads the same events as :hotspots (the local
+// reservation log, or the project history when the server keeps one). With
+// report_conflicts set, auto-reserve also sends its conflicts to the server
+// so they show up in everyone's history.
+
+const (
+	defaultConflictsWindow = reservationLogRetention
+	defaultConflictsLimit  = 10
+)
+
+// ConflictPartner is an agent this workspace collided with.
+type ConflictPartner struct {
+	Alias         string   `json:"alias"`
+	Conflicts     int      `json:"conflicts"`
+	BlockedMe     int      `json:"blocked_me"`    // they held a path I wanted
+	BlockedByMe   int      `json:"blocked_by_me"` // I held a path they wanted
+	Paths         []string `json:"paths"`         // most contended first
+	LastAt        string   `json:"last_at,omitempty"`
+	pathConflicts map[string]int
+}
+
+// ConflictPath is a path this workspace collided on.
+type ConflictPath struct {
+	Path      string   `json:"path"`
+	Conflicts int      `json:"conflicts"`
+	Agents    []string `json:"agents"`
+	LastAt    string   `json:"last_at,omitempty"`
+}
+
+// ConflictWeek counts conflicts in the week starting on Week (a Monday).
+type ConflictWeek struct {
+	Week      string `json:"week"`
+	Conflicts int    `json:"conflicts"`
+}
+
+// ConflictsResult is the output of :conflicts history.
+type ConflictsResult struct {
+	Alias     string            `json:"alias"`
+	Source    string            `json:"source"`
+	Since     time.Time         `json:"since"`
+	Conflicts int               `json:"conflicts"`
+	Agents    []ConflictPartner `json:"agents"`
+	Paths     []ConflictPath    `json:"paths"`
+	Weeks     []ConflictWeek    `json:"weeks"`
+	Warning   string            `json:"warning,omitempty"`
+}
+
+var (
+	conflictsSince time.Duration
+	conflictsLimit int
+	conflictsLocal bool
+	conflictsJSON  bool
+)
+
+var conflictsCmd = &cobra.Command{
+	Use:   ":conflicts",
+	Short: "Show who you collide with on file reservations",
+	Long: `Show the reservation conflicts you were part of: the agents you
+collide with most, the paths you collide on, and conflicts per week. Use it
+to decide ownership boundaries (see also :hotspots for the whole project).
+
+Conflicts are recorded by auto-reserve in .beadhub-cache/reservation-log.json.
+Set report_conflicts: true in .beadhub to also send them to the server, where
+they count in everyone's history.
+
+Examples:
+  bdh :conflicts history               # Last 30 days
+  bdh :conflicts history --since 168h  # Last week
+  bdh :conflicts history --local       # Only this workspace's log
+  bdh :conflicts history --json`,
+	Args: cobra.NoArgs,
+	RunE: runConflictsHistory,
+}
+
+var conflictsHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Summarize your reservation conflicts over time",
+	Args:  cobra.NoArgs,
+	RunE:  runConflictsHistory,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{conflictsCmd, conflictsHistoryCmd} {
+		cmd.Flags().DurationVar(&conflictsSince, "since", defaultConflictsWindow, "Only count conflicts within this long")
+		cmd.Flags().IntVar(&conflictsLimit, "limit", defaultConflictsLimit, "Maximum number of agents and paths to show")
+		cmd.Flags().BoolVar(&conflictsLocal, "local", false, "Only use this workspace's reservation log")
+		cmd.Flags().BoolVar(&conflictsJSON, "json", false, "Output as JSON")
+	}
+	conflictsCmd.AddCommand(conflictsHistoryCmd)
+}
+
+func runConflictsHistory(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if conflictsSince <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	since := time.Now().Add(-conflictsSince)
+	events, source, warning, err := loadReservationEvents(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), since, conflictsLocal)
+	if err != nil {
+		return err
+	}
+	result := aggregateConflicts(cfg.Alias, events)
+	result.Source = source
+	result.Since = since
+	result.Warning = warning
+	if conflictsLimit > 0 {
+		if len(result.Agents) > conflictsLimit {
+			result.Agents = result.Agents[:conflictsLimit]
+		}
+		if len(result.Paths) > conflictsLimit {
+			result.Paths = result.Paths[:conflictsLimit]
+		}
+	}
+	fmt.Print(formatConflictsOutput(result, conflictsJSON))
+	return nil
+}
+
+// aggregateConflicts summarizes the conflicts alias took part in, on
+// either side. Agents and paths are ranked by conflict count.
+func aggregateConflicts(alias string, events []client.ReservationEvent) *ConflictsResult {
+	result := &ConflictsResult{Alias: alias}
+	partners := make(map[string]*ConflictPartner)
+	paths := make(map[string]*ConflictPath)
+	pathAgents := make(map[string]map[string]bool)
+	weeks := make(map[string]int)
+
+	for _, e := range events {
+		if e.Kind != client.ReservationEventConflict || e.Path == "" {
+			continue
+		}
+		var other string
+		switch {
+		case e.RequestedBy == alias && e.HolderAlias != alias:
+			other = e.HolderAlias
+		case e.HolderAlias == alias && e.RequestedBy != alias:
+			other = e.RequestedBy
+		default:
+			continue
+		}
+		if other == "" {
+			other = "unknown"
+		}
+		result.Conflicts++
+
+		p := partners[other]
+		if p == nil {
+			p = &ConflictPartner{Alias: other, pathConflicts: make(map[string]int)}
+			partners[other] = p
+		}
+		p.Conflicts++
+		if e.RequestedBy == alias {
+			p.BlockedMe++
+		} else {
+			p.BlockedByMe++
+		}
+		p.pathConflicts[e.Path]++
+		p.LastAt = laterTimestamp(p.LastAt, e.At)
+
+		cp := paths[e.Path]
+		if cp == nil {
+			cp = &ConflictPath{Path: e.Path}
+			paths[e.Path] = cp
+			pathAgents[e.Path] = make(map[string]bool)
+		}
+		cp.Conflicts++
+		pathAgents[e.Path][other] = true
+		cp.LastAt = laterTimestamp(cp.LastAt, e.At)
+
+		if at, ok := parseTimeBestEffort(e.At); ok {
+			weeks[weekStart(at)]++
+		}
+	}
+
+	for _, p := range partners {
+		for path := range p.pathConflicts {
+			p.Paths = append(p.Paths, path)
+		}
+		sort.Slice(p.Paths, func(i, j int) bool {
+			a, b := p.pathConflicts[p.Paths[i]], p.pathConflicts[p.Paths[j]]
+			if a != b {
+				return a > b
+			}
+			return p.Paths[i] < p.Paths[j]
+		})
+		result.Agents = append(result.Agents, *p)
+	}
+	sort.Slice(result.Agents, func(i, j int) bool {
+		a, b := result.Agents[i], result.Agents[j]
+		if a.Conflicts != b.Conflicts {
+			return a.Conflicts > b.Conflicts
+		}
+		return a.Alias < b.Alias
+	})
+
+	for path, cp := range paths {
+		for a := range pathAgents[path] {
+			cp.Agents = append(cp.Agents, a)
+		}
+		sort.Strings(cp.Agents)
+		result.Paths = append(result.Paths, *cp)
+	}
+	sort.Slice(result.Paths, func(i, j int) bool {
+		a, b := result.Paths[i], result.Paths[j]
+		if a.Conflicts != b.Conflicts {
+			return a.Conflicts > b.Conflicts
+		}
+		return a.Path < b.Path
+	})
+
+	for week, n := range weeks {
+		result.Weeks = append(result.Weeks, ConflictWeek{Week: week, Conflicts: n})
+	}
+	sort.Slice(result.Weeks, func(i, j int) bool { return result.Weeks[i].Week < result.Weeks[j].Week })
+	return result
+}
+
+// weekStart returns the Monday of t's week (UTC) as YYYY-MM-DD.
+func weekStart(t time.Time) string {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset).Format("2006-01-02")
+}
+
+// laterTimestamp returns whichever of two timestamps is later.
+func laterTimestamp(a, b string) string {
+	ta, okA := parseTimeBestEffort(a)
+	tb, okB := parseTimeBestEffort(b)
+	if !okA || (okB && tb.After(ta)) {
+		return b
+	}
+	return a
+}
+
+// reportReservationConflicts sends auto-reserve conflicts to the server's
+// reservation history when report_conflicts is set (best-effort).
+func reportReservationConflicts(ctx context.Context, cfg *config.Config, c BeadHubAPI, conflicts []ReservationConflict, now time.Time) {
+	if len(conflicts) == 0 || !cfg.ReportConflictsEnabled() || !serverSupports(ctx, cfg, featureReservationHistory) {
+		return
+	}
+	at := now.UTC().Format(time.RFC3339)
+	events := make([]client.ReservationEvent, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		events = append(events, client.ReservationEvent{
+			Path:        conflict.ResourceKey,
+			Kind:        client.ReservationEventConflict,
+			HolderAlias: conflict.HeldBy,
+			RequestedBy: cfg.Alias,
+			At:          at,
+		})
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	_ = c.ReportReservationEvents(ctx, &client.ReportReservationEventsRequest{Events: events})
+}
+
+func formatConflictsOutput(result *ConflictsResult, asJSON bool) string {
+	if asJSON {
+		if result.Agents == nil {
+			result.Agents = []ConflictPartner{}
+		}
+		if result.Paths == nil {
+			result.Paths = []ConflictPath{}
+		}
+		if result.Weeks == nil {
+			result.Weeks = []ConflictWeek{}
+		}
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString("Warning: " + result.Warning + "\n\n")
+	}
+	scope := "project history"
+	if result.Source == hotspotsSourceLocal {
+		scope = "this workspace's log"
+	}
+	if result.Conflicts == 0 {
+		sb.WriteString(fmt.Sprintf("No reservation conflicts for %s since %s (%s).\n",
+			result.Alias, result.Since.Local().Format("2006-01-02"), scope))
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("%d reservation conflict(s) for %s since %s (%s)\n",
+		result.Conflicts, result.Alias, result.Since.Local().Format("2006-01-02"), scope))
+
+	sb.WriteString("\nAgents you collide with:\n")
+	for _, p := range result.Agents {
+		paths := p.Paths
+		more := ""
+		if len(paths) > 3 {
+			more = fmt.Sprintf(" +%d more", len(paths)-3)
+			paths = paths[:3]
+		}
+		sb.WriteString(fmt.Sprintf("  %-16s %3d  (blocked you %d, you blocked them %d)  %s%s\n",
+			p.Alias, p.Conflicts, p.BlockedMe, p.BlockedByMe, strings.Join(paths, ", "), more))
+	}
+
+	sb.WriteString("\nPaths:\n")
+	for _, p := range result.Paths {
+		sb.WriteString(fmt.Sprintf("  %-40s %3d  %s  last %s\n",
+			p.Path, p.Conflicts, strings.Join(p.Agents, ", "), formatTimestamp(p.LastAt)))
+	}
+
+	if len(result.Weeks) > 1 {
+		sb.WriteString("\nBy week:\n")
+		for _, w := range result.Weeks {
+			sb.WriteString(fmt.Sprintf("  %s  %3d  %s\n", w.Week, w.Conflicts, strings.Repeat("#", min(w.Conflicts, 40))))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestAggregateConflicts_BothSidesAndWeeks(t *testing.T) {
+	ev := func(path, holder, requester, at string) client.ReservationEvent {
+		return client.ReservationEvent{Path: path, Kind: client.ReservationEventConflict, HolderAlias: holder, RequestedBy: requester, At: at}
+	}
+	result := aggregateConflicts("alice", []client.ReservationEvent{
+		ev("api/router.go", "bob", "alice", "2026-10-05T10:00:00Z"),
+		ev("api/router.go", "bob", "alice", "2026-10-13T10:00:00Z"),
+		ev("api/auth.go", "alice", "bob", "2026-10-14T10:00:00Z"),
+		ev("db/schema.sql", "carol", "alice", "2026-10-15T10:00:00Z"),
+		ev("other.go", "dave", "erin", "2026-10-15T10:00:00Z"),
+		{Path: "api/router.go", Kind: client.ReservationEventAcquired, HolderAlias: "alice", At: "2026-10-15T10:00:00Z"},
+	})
+
+	if result.Conflicts != 4 || len(result.Agents) != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+	bob := result.Agents[0]
+	if bob.Alias != "bob" || bob.Conflicts != 3 || bob.BlockedMe != 2 || bob.BlockedByMe != 1 {
+		t.Errorf("bob = %+v", bob)
+	}
+	if strings.Join(bob.Paths, ",") != "api/router.go,api/auth.go" || bob.LastAt != "2026-10-14T10:00:00Z" {
+		t.Errorf("bob paths/last = %v %s", bob.Paths, bob.LastAt)
+	}
+	if result.Paths[0].Path != "api/router.go" || result.Paths[0].Conflicts != 2 {
+		t.Errorf("paths = %+v", result.Paths)
+	}
+	weeks := make([]string, 0, len(result.Weeks))
+	for _, w := range result.Weeks {
+		weeks = append(weeks, fmt.Sprintf("%s=%d", w.Week, w.Conflicts))
+	}
+	if strings.Join(weeks, ",") != "2026-10-05=1,2026-10-12=3" {
+		t.Errorf("weeks = %v", weeks)
+	}
+
+	out := formatConflictsOutput(result, false)
+	for _, want := range []string{"4 reservation conflict(s) for alice", "(blocked you 2, you blocked them 1)", "By week:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatConflictsOutput_Empty(t *testing.T) {
+	result := aggregateConflicts("alice", nil)
+	result.Source = hotspotsSourceLocal
+	if out := formatConflictsOutput(result, false); !strings.Contains(out, "No reservation conflicts for alice") {
+		t.Errorf("output = %q", out)
+	}
+	if out := formatConflictsOutput(result, true); !strings.Contains(out, `"agents": []`) {
+		t.Errorf("JSON output = %s", out)
+	}
+}
+
+func TestReportReservationConflicts(t *testing.T) {
+	resetCapabilitiesMemo()
+	t.Cleanup(resetCapabilitiesMemo)
+	var posted []client.ReservationEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/reservations/history" {
+			var req client.ReportReservationEventsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			posted = append(posted, req.Events...)
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	conflicts := []ReservationConflict{{ResourceKey: "a.go", HeldBy: "bob"}}
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	cfg := &config.Config{BeadhubURL: server.URL, Alias: "alice"}
+	c := client.New(server.URL)
+
+	reportReservationConflicts(context.Background(), cfg, c, conflicts, now)
+	if len(posted) != 0 {
+		t.Fatalf("reported without report_conflicts: %+v", posted)
+	}
+
+	enabled := true
+	cfg.ReportConflicts = &enabled
+	reportReservationConflicts(context.Background(), cfg, c, conflicts, now)
+	if len(posted) != 1 || posted[0].Path != "a.go" || posted[0].HolderAlias != "bob" || posted[0].RequestedBy != "alice" {
+		t.Fatalf("posted = %+v", posted)
+	}
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// ContextError records a coordination context fetch that failed. JSON output
+// includes these so agents can tell "no team activity" apart from "the team
+// query failed"; text output keeps omitting the section as before.
+type ContextError struct {
+	Context  string `json:"context"`
+	Endpoint string `json:"endpoint"`
+	Status   int    `json:"status,omitempty"`
+	Message  string `json:"message"`
+}
+
+// contextErrorFrom builds a ContextError for a failed request to endpoint.
+// Status is the HTTP status when the server answered, 0 otherwise.
+func contextErrorFrom(contextName, endpoint string, err error) ContextError {
+	ce := ContextError{Context: contextName, Endpoint: endpoint}
+
+	var clientErr *client.Error
+	switch {
+	case errors.As(err, &clientErr):
+		ce.Status = clientErr.StatusCode
+		ce.Message = strings.TrimSpace(clientErr.Body)
+	default:
+		if status, ok := aweb.HTTPStatusCode(err); ok {
+			ce.Status = status
+			if body, ok := aweb.HTTPErrorBody(err); ok {
+				ce.Message = strings.TrimSpace(body)
+			}
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		ce.Message = "request timed out"
+	}
+	if ce.Message == "" {
+		ce.Message = err.Error()
+	}
+	ce.Message = truncateText(ce.Message, 300)
+	return ce
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestContextErrorFrom(t *testing.T) {
+	ce := contextErrorFrom("team", "GET /v1/workspaces/team", &client.Error{StatusCode: 500, Body: " database unavailable\n"})
+	if ce.Status != 500 || ce.Message != "database unavailable" || ce.Context != "team" {
+		t.Errorf("client error = %+v", ce)
+	}
+
+	ce = contextErrorFrom("locks", "GET /v1/reservations", fmt.Errorf("request: %w", context.DeadlineExceeded))
+	if ce.Status != 0 || ce.Message != "request timed out" {
+		t.Errorf("timeout = %+v", ce)
+	}
+}
+
+func TestPassthrough_ReadyJSONReportsContextErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a sh stub for bd")
+	}
+
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	os.MkdirAll(".beads", 0755)
+
+	binDir := filepath.Join(tmpDir, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("mkdir bin: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte("#!/bin/sh\necho '[]'\n"), 0755); err != nil {
+		t.Fatalf("write bd stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/bdh/command":
+			_ = json.NewEncoder(w).Encode(map[string]any{"approved": true})
+		case "/v1/workspaces/team":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("team query failed"))
+		case "/v1/reservations":
+			_ = json.NewEncoder(w).Encode(map[string]any{"reservations": []any{}, "count": 0})
+		case "/v1/chat/pending":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"pending": []any{map[string]any{"session_id": "s1", "participants": []string{"bob"}, "last_from": "bob", "unread_count": 2}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	cfg.Save()
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+
+	result, err := runPassthrough(context.Background(), []string{"ready", "--json"})
+	if err != nil {
+		t.Fatalf("runPassthrough error: %v", err)
+	}
+
+	var out struct {
+		ContextErrors []ContextError `json:"context_errors"`
+		ReadyContext  struct {
+			PendingChats []PendingConversation `json:"pending_chats"`
+		} `json:"ready_context"`
+	}
+	if err := json.Unmarshal([]byte(formatPassthroughOutput(result)), &out); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if len(out.ContextErrors) != 1 {
+		t.Fatalf("context_errors = %+v", out.ContextErrors)
+	}
+	got := out.ContextErrors[0]
+	if got.Context != "team" || got.Endpoint != "GET /v1/workspaces/team" || got.Status != 500 || got.Message != "team query failed" {
+		t.Errorf("context error = %+v", got)
+	}
+	if len(out.ReadyContext.PendingChats) != 1 || out.ReadyContext.PendingChats[0].UnreadCount != 2 {
+		t.Errorf("pending_chats = %+v", out.ReadyContext.PendingChats)
+	}
+}
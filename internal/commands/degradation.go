@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// The degradation settings in .beadhub decide what happens when coordination
+// fails. The default for every failure mode is warn: bd always runs and the
+// failure is reported. Repos that prefer blocking over divergence can set
+// block (and, for sync failures, queue). Blocking only ever applies to bd
+// commands that change issues; reads run regardless.
+
+// pendingFailedSync returns the recorded failed sync, if any.
+func pendingFailedSync() *FailedOperation {
+	path, err := failedOpsPath()
+	if err != nil {
+		return nil
+	}
+	ops, err := loadFailedOps(path)
+	if err != nil {
+		return nil
+	}
+	return lastFailedSync(ops)
+}
+
+func lastFailedSync(ops []FailedOperation) *FailedOperation {
+	for i := len(ops) - 1; i >= 0; i-- {
+		if ops[i].Kind == failedOpSync {
+			return &ops[i]
+		}
+	}
+	return nil
+}
+
+// degradationBlockBeforeRun returns why bd must not run given the state known
+// before pre-flight, or "" to proceed.
+func degradationBlockBeforeRun(cfg *config.Config, bdArgs []string) string {
+	if cfg.OnSyncFailure() != config.DegradeBlock || !bd.IsMutationCommand(bdArgs) {
+		return ""
+	}
+	op := pendingFailedSync()
+	if op == nil {
+		return ""
+	}
+	return fmt.Sprintf("a previous sync failed (%s) - run 'bdh :replay %s' before making more changes (degradation.on_sync_failure: block)",
+		op.Error, op.ID)
+}
+
+// serverUnreachableBlock returns why bd must not run when the pre-flight
+// check could not reach the server, or "" to proceed.
+func serverUnreachableBlock(cfg *config.Config, bdArgs []string, warning string) string {
+	if cfg.OnServerUnreachable() != config.DegradeBlock || !bd.IsMutationCommand(bdArgs) {
+		return ""
+	}
+	warning = strings.TrimSuffix(warning, " - running without coordination")
+	return fmt.Sprintf("%s - not running bd (degradation.on_server_unreachable: block)", warning)
+}
+
+// reservationConflictBlock returns why bd must not run while files changed in
+// this workspace are reserved by others, or "" to proceed.
+func reservationConflictBlock(cfg *config.Config, bdArgs []string, conflicts []ReservationConflict) string {
+	if len(conflicts) == 0 || cfg.OnReservationConflict() != config.DegradeBlock || !bd.IsMutationCommand(bdArgs) {
+		return ""
+	}
+	held := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		held = append(held, fmt.Sprintf("%s (%s)", c.ResourceKey, c.HeldBy))
+	}
+	return fmt.Sprintf("files you changed are reserved by others: %s - not running bd (degradation.on_reservation_conflict: block)",
+		strings.Join(held, ", "))
+}
+
+// syncFailureBlock returns the message for a sync that failed after bd ran
+// when on_sync_failure is block, or "".
+func syncFailureBlock(cfg *config.Config, opID string) string {
+	if cfg.OnSyncFailure() != config.DegradeBlock {
+		return ""
+	}
+	retry := "bdh :replay last"
+	if opID != "" {
+		retry = "bdh :replay " + opID
+	}
+	return fmt.Sprintf("changes were saved locally but not synced; further changes are blocked until '%s' succeeds (degradation.on_sync_failure: block)", retry)
+}
+
+// strictSyncFailure returns the error for a sync that did not complete
+// under --:strict-sync or sync.strict, or "". A sync with nothing to send
+// is not a failure.
+func strictSyncFailure(strict bool, sync *SyncResult, opID string) string {
+	if !strict || sync.Synced || sync.Warning == "" {
+		return ""
+	}
+	msg := "changes were saved locally but not synced to BeadHub (strict sync)"
+	if sync.Retryable {
+		retry := "bdh :replay last"
+		if opID != "" {
+			retry = "bdh :replay " + opID
+		}
+		msg += "; retry with '" + retry + "'"
+	}
+	return msg
+}
+
+// flushQueuedSync retries a failed sync before the command runs when
+// on_sync_failure is queue. Returns a notice describing the outcome, or "".
+func flushQueuedSync(ctx context.Context, cfg *config.Config) string {
+	if cfg.OnSyncFailure() != config.DegradeQueue {
+		return ""
+	}
+	path, err := failedOpsPath()
+	if err != nil {
+		return ""
+	}
+	ops, err := loadFailedOps(path)
+	if err != nil {
+		return ""
+	}
+	op := lastFailedSync(ops)
+	if op == nil {
+		return ""
+	}
+	result, err := replayWithConfig(ctx, cfg, path, ops, op.ID)
+	if err != nil {
+		return ""
+	}
+	if result.Succeeded {
+		return "queued sync from an earlier command succeeded"
+	}
+	return fmt.Sprintf("queued sync is still failing (attempt %d): %s", result.Operation.Attempts, result.Error)
+}
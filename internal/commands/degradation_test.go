@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestDegradation_DefaultsNeverBlock(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{}
+	recordFailedOp(FailedOperation{Kind: failedOpSync, Error: "sync failed (503)"})
+
+	mutation := []string{"update", "bd-1", "--status", "in_progress"}
+	if got := degradationBlockBeforeRun(cfg, mutation); got != "" {
+		t.Errorf("before run = %q", got)
+	}
+	if got := serverUnreachableBlock(cfg, mutation, "BeadHub unreachable"); got != "" {
+		t.Errorf("unreachable = %q", got)
+	}
+	if got := reservationConflictBlock(cfg, mutation, []ReservationConflict{{ResourceKey: "a.go", HeldBy: "bob"}}); got != "" {
+		t.Errorf("conflict = %q", got)
+	}
+	if got := syncFailureBlock(cfg, "op-1"); got != "" {
+		t.Errorf("sync failure = %q", got)
+	}
+}
+
+func TestDegradation_Block(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{Degradation: &config.DegradationConfig{
+		OnServerUnreachable:   config.DegradeBlock,
+		OnSyncFailure:         config.DegradeBlock,
+		OnReservationConflict: config.DegradeBlock,
+	}}
+	mutation := []string{"close", "bd-1"}
+	read := []string{"list"}
+
+	if got := degradationBlockBeforeRun(cfg, mutation); got != "" {
+		t.Errorf("blocked without a failed sync: %q", got)
+	}
+	id := recordFailedOp(FailedOperation{Kind: failedOpSync, Error: "sync failed (503)"})
+	if got := degradationBlockBeforeRun(cfg, mutation); !strings.Contains(got, "bdh :replay "+id) {
+		t.Errorf("before run = %q", got)
+	}
+	if got := degradationBlockBeforeRun(cfg, read); got != "" {
+		t.Errorf("read blocked: %q", got)
+	}
+
+	got := serverUnreachableBlock(cfg, mutation, "BeadHub unreachable at http://x - running without coordination")
+	if got != "BeadHub unreachable at http://x - not running bd (degradation.on_server_unreachable: block)" {
+		t.Errorf("unreachable = %q", got)
+	}
+	if got := serverUnreachableBlock(cfg, read, "BeadHub unreachable"); got != "" {
+		t.Errorf("read blocked: %q", got)
+	}
+
+	conflicts := []ReservationConflict{{ResourceKey: "a.go", HeldBy: "bob"}, {ResourceKey: "b.go", HeldBy: "carol"}}
+	if got := reservationConflictBlock(cfg, mutation, conflicts); !strings.Contains(got, "a.go (bob), b.go (carol)") {
+		t.Errorf("conflict = %q", got)
+	}
+	if got := reservationConflictBlock(cfg, mutation, nil); got != "" {
+		t.Errorf("blocked without conflicts: %q", got)
+	}
+
+	if got := syncFailureBlock(cfg, "op-abc123"); !strings.Contains(got, "'bdh :replay op-abc123'") {
+		t.Errorf("sync failure = %q", got)
+	}
+}
+
+func TestFlushQueuedSync(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open"}
+`)
+
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing || r.URL.Path != "/v1/bdh/sync" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"synced": true, "issues_count": 1}`))
+	}))
+	defer server.Close()
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:  server.URL,
+		Alias:       "alice",
+		Degradation: &config.DegradationConfig{OnSyncFailure: config.DegradeQueue},
+	}
+
+	if got := flushQueuedSync(context.Background(), cfg); got != "" {
+		t.Errorf("nothing queued, got %q", got)
+	}
+	recordFailedOp(FailedOperation{Kind: failedOpSync, Error: "sync failed (503)", BdArgs: []string{"close", "bd-1"}})
+
+	if got := flushQueuedSync(context.Background(), cfg); !strings.Contains(got, "still failing (attempt 1)") {
+		t.Errorf("failing flush = %q", got)
+	}
+	failing = false
+	if got := flushQueuedSync(context.Background(), cfg); got != "queued sync from an earlier command succeeded" {
+		t.Errorf("flush = %q", got)
+	}
+	if pendingFailedSync() != nil {
+		t.Error("sync still pending after a successful flush")
+	}
+}
+
+func TestStrictSyncFailure(t *testing.T) {
+	failed := &SyncResult{Warning: "sync failed (503)", Retryable: true}
+	if got := strictSyncFailure(false, failed, "op-1"); got != "" {
+		t.Errorf("non-strict = %q", got)
+	}
+	if got := strictSyncFailure(true, failed, "op-1"); !strings.Contains(got, "'bdh :replay op-1'") {
+		t.Errorf("strict = %q", got)
+	}
+	blocked := &SyncResult{Warning: "sync blocked: issue graph has 1 problem(s)"}
+	if got := strictSyncFailure(true, blocked, ""); got == "" || strings.Contains(got, ":replay") {
+		t.Errorf("strict non-retryable = %q", got)
+	}
+	if got := strictSyncFailure(true, &SyncResult{}, ""); got != "" {
+		t.Errorf("nothing to sync should not fail: %q", got)
+	}
+	if got := strictSyncFailure(true, &SyncResult{Synced: true, Warning: "synced with --:force"}, ""); got != "" {
+		t.Errorf("completed sync should not fail: %q", got)
+	}
+
+	out := formatQuietPassthroughOutput(&PassthroughResult{SyncStrictFailed: "changes were saved locally"})
+	if !strings.Contains(out, "SYNC FAILED: changes were saved locally") {
+		t.Errorf("quiet output = %q", out)
+	}
+}
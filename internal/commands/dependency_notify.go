@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// DependencyNotice records a teammate told about a dependency edit on a bead
+// they have claimed.
+type DependencyNotice struct {
+	Alias  string `json:"alias"`
+	BeadID string `json:"bead_id"`
+	Error  string `json:"error,omitempty"`
+}
+
+// dependencyChangeRecipients returns one entry per other workspace that has
+// claimed any of the affected beads, in claim order.
+func dependencyChangeRecipients(affected []string, myWorkspaceID string, beadsInProgress []client.BeadInProgress) []client.BeadInProgress {
+	wanted := make(map[string]bool, len(affected))
+	for _, id := range affected {
+		wanted[id] = true
+	}
+	seen := make(map[string]bool)
+	var recipients []client.BeadInProgress
+	for _, bip := range beadsInProgress {
+		if !wanted[bip.BeadID] || bip.WorkspaceID == myWorkspaceID || seen[bip.WorkspaceID] {
+			continue
+		}
+		seen[bip.WorkspaceID] = true
+		recipients = append(recipients, bip)
+	}
+	return recipients
+}
+
+func formatDependencyChangeMessage(alias, beadID string, bdArgs []string) string {
+	return fmt.Sprintf("%s changed the dependencies of %s, which you are working on: bd %s\nRun 'bdh dep list %s' to review.",
+		alias, beadID, strings.Join(bdArgs, " "), beadID)
+}
+
+// notifyDependencyChange mails the claimants of beads touched by a successful
+// dep mutation. Failures are recorded per recipient, never returned.
+func notifyDependencyChange(ctx context.Context, cfg *config.Config, aw AwebAPI, bdArgs []string, beadsInProgress []client.BeadInProgress) []DependencyNotice {
+	if aw == nil || !bd.IsDependencyMutation(bdArgs) {
+		return nil
+	}
+	recipients := dependencyChangeRecipients(bd.AffectedIssueIDs(bdArgs), cfg.WorkspaceID, beadsInProgress)
+
+	var notices []DependencyNotice
+	for _, bip := range recipients {
+		notice := DependencyNotice{Alias: bip.Alias, BeadID: bip.BeadID}
+		req := &aweb.SendMessageRequest{
+			ToAgentID: bip.WorkspaceID,
+			Subject:   fmt.Sprintf("Dependencies changed: %s", bip.BeadID),
+			Body:      formatDependencyChangeMessage(cfg.Alias, bip.BeadID, bdArgs),
+		}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, err := aw.SendMessage(ctx, req)
+		cancel()
+		if err != nil {
+			notice.Error = err.Error() + replayHint(recordFailedNotification(req, bip.Alias, err))
+		}
+		notices = append(notices, notice)
+	}
+	return notices
+}
+
+func formatDependencyNotices(notices []DependencyNotice) string {
+	if len(notices) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\nDEPENDENCY CHANGE NOTIFIED:\n")
+	for _, n := range notices {
+		if n.Error != "" {
+			sb.WriteString(fmt.Sprintf("  %s (%s) — not notified: %s\n", n.Alias, n.BeadID, n.Error))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s (%s)\n", n.Alias, n.BeadID))
+		}
+	}
+	return sb.String()
+}
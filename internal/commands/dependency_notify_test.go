@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+func TestDependencyChangeRecipients(t *testing.T) {
+	beadsInProgress := []client.BeadInProgress{
+		{BeadID: "bd-1", WorkspaceID: "ws-me", Alias: "me"},
+		{BeadID: "bd-1", WorkspaceID: "ws-bob", Alias: "bob"},
+		{BeadID: "bd-2", WorkspaceID: "ws-bob", Alias: "bob"},
+		{BeadID: "bd-2", WorkspaceID: "ws-carol", Alias: "carol"},
+		{BeadID: "bd-9", WorkspaceID: "ws-dave", Alias: "dave"},
+	}
+	got := dependencyChangeRecipients([]string{"bd-2", "bd-1"}, "ws-me", beadsInProgress)
+	var aliases []string
+	for _, r := range got {
+		aliases = append(aliases, r.Alias+":"+r.BeadID)
+	}
+	if strings.Join(aliases, ",") != "bob:bd-1,carol:bd-2" {
+		t.Errorf("recipients = %v", aliases)
+	}
+}
+
+func TestNotifyDependencyChange(t *testing.T) {
+	var sent []aweb.SendMessageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req aweb.SendMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		sent = append(sent, req)
+		json.NewEncoder(w).Encode(aweb.SendMessageResponse{MessageID: "m1"})
+	}))
+	defer server.Close()
+	aw, err := aweb.NewWithAPIKey(server.URL, "aw_sk_test123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{WorkspaceID: "ws-me", Alias: "me"}
+	beadsInProgress := []client.BeadInProgress{{BeadID: "bd-42", WorkspaceID: "ws-bob", Alias: "bob"}}
+
+	notices := notifyDependencyChange(context.Background(), cfg, aw, []string{"dep", "add", "bd-43", "bd-42"}, beadsInProgress)
+	if len(notices) != 1 || notices[0].Alias != "bob" || notices[0].Error != "" {
+		t.Fatalf("notices = %+v", notices)
+	}
+	if len(sent) != 1 || sent[0].ToAgentID != "ws-bob" || !strings.Contains(sent[0].Body, "me changed the dependencies of bd-42") ||
+		!strings.Contains(sent[0].Body, "bd dep add bd-43 bd-42") {
+		t.Errorf("sent = %+v", sent)
+	}
+	if out := formatDependencyNotices(notices); !strings.Contains(out, "bob (bd-42)") {
+		t.Errorf("output = %s", out)
+	}
+
+	// Read-only dep commands and label edits don't notify anyone.
+	for _, args := range [][]string{{"dep", "list", "bd-42"}, {"label", "add", "bd-42", "urgent"}} {
+		if notices := notifyDependencyChange(context.Background(), cfg, aw, args, beadsInProgress); notices != nil {
+			t.Errorf("%v notified %+v", args, notices)
+		}
+	}
+}
+
+func TestSyncToBeadHub_TargetedSync(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open","dependencies":[{"issue_id":"bd-1","depends_on_id":"bd-2","type":"blocks"}]}
+{"id":"bd-2","title":"Two","status":"open"}
+{"id":"bd-3","title":"Three","status":"open"}
+`)
+
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		Alias:       "alice",
+	}
+	statePath := syncStatePathForConfig(cfg)
+	if err := sync.SaveState(statePath, &sync.SyncState{IssueHashes: map[string]string{
+		"bd-1": "stale", "bd-2": "stale", "bd-3": "stale",
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got client.SyncRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"synced": true, "issues_count": 3}`))
+	}))
+	defer server.Close()
+	cfg.BeadhubURL = server.URL
+
+	result := syncToBeadHub(context.Background(), cfg, []string{"dep", "add", "bd-1", "bd-2"}, syncOptions{Targets: []string{"bd-1", "bd-2"}})
+	if result.Warning != "" || result.SyncMode != "incremental" {
+		t.Fatalf("result = %+v", result)
+	}
+	if strings.Contains(got.ChangedIssues, `"bd-3"`) || !strings.Contains(got.ChangedIssues, `"bd-1"`) || len(got.DeletedIDs) != 0 {
+		t.Errorf("changed issues = %s, deleted = %v", got.ChangedIssues, got.DeletedIDs)
+	}
+
+	state, err := sync.LoadState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.IssueHashes["bd-1"] == "stale" || state.IssueHashes["bd-3"] != "stale" {
+		t.Errorf("state = %v; bd-3 must stay pending for the next sync", state.IssueHashes)
+	}
+}
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+)
+
+// With notifications.desktop: true, urgent mail and chats where the sender is
+// waiting also raise an OS notification (osascript on macOS, notify-send on
+// Linux, a toast on Windows), since humans co-working with agents rarely
+// watch the terminal scrollback. Each message or chat turn alerts once; the
+// keys already shown are kept in .beadhub-cache/desktop-notified.json.
+
+const (
+	desktopNotifyTimeout = 3 * time.Second
+	maxDesktopNotified   = 200
+)
+
+// UrgentMail is an unread message sent with urgent priority.
+type UrgentMail struct {
+	MessageID string `json:"message_id"`
+	From      string `json:"from"`
+	Subject   string `json:"subject,omitempty"`
+}
+
+// DesktopAlert is one OS notification. Key identifies what it is about so it
+// is shown only once.
+type DesktopAlert struct {
+	Key   string
+	Title string
+	Body  string
+}
+
+// desktopNotifier raises an OS notification; replaced in tests.
+var desktopNotifier = sendDesktopNotification
+
+func urgentMailFrom(messages []aweb.InboxMessage) []UrgentMail {
+	var urgent []UrgentMail
+	for _, m := range messages {
+		if m.Priority != aweb.PriorityUrgent {
+			continue
+		}
+		urgent = append(urgent, UrgentMail{MessageID: m.MessageID, From: m.FromAlias, Subject: m.Subject})
+	}
+	return urgent
+}
+
+// desktopAlerts returns the alerts for ctx: urgent mail, and chats whose
+// sender is waiting (except with excludeAlias, who is being chatted with).
+func desktopAlerts(ctx *NotificationContext, excludeAlias string) []DesktopAlert {
+	var alerts []DesktopAlert
+	for _, m := range ctx.UrgentMail {
+		body := m.Subject
+		if body == "" {
+			body = "Check: bdh :aweb mail list"
+		}
+		alerts = append(alerts, DesktopAlert{
+			Key:   "mail:" + m.MessageID,
+			Title: fmt.Sprintf("bdh: urgent mail from %s", m.From),
+			Body:  body,
+		})
+	}
+	for _, conv := range ctx.PendingConversations {
+		from := strings.TrimSpace(conv.LastFrom)
+		if !conv.SenderWaiting || from == "" || from == excludeAlias {
+			continue
+		}
+		alerts = append(alerts, DesktopAlert{
+			Key:   fmt.Sprintf("chat:%s:%s", conv.SessionID, conv.LastActivity),
+			Title: fmt.Sprintf("bdh: %s is waiting for your reply", from),
+			Body:  truncateAlertBody(conv.LastMessage),
+		})
+	}
+	return alerts
+}
+
+func truncateAlertBody(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 140 {
+		return string(r[:139]) + "…"
+	}
+	return s
+}
+
+func desktopNotifiedPath() (string, error) {
+	return cacheFilePath("desktop-notified.json")
+}
+
+func loadDesktopNotified(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	if json.Unmarshal(data, &keys) != nil {
+		return nil
+	}
+	return keys
+}
+
+func saveDesktopNotified(path string, keys []string) error {
+	if len(keys) > maxDesktopNotified {
+		keys = keys[len(keys)-maxDesktopNotified:]
+	}
+	return writeJSONAtomic(path, keys)
+}
+
+// notifyDesktop raises alerts not shown before. Best-effort: failures to
+// notify or to record what was shown are ignored.
+func notifyDesktop(ctx context.Context, nc *NotificationContext, excludeAlias string) {
+	alerts := desktopAlerts(nc, excludeAlias)
+	if len(alerts) == 0 {
+		return
+	}
+	path, err := desktopNotifiedPath()
+	if err != nil {
+		return
+	}
+	shown := loadDesktopNotified(path)
+	seen := make(map[string]bool, len(shown))
+	for _, key := range shown {
+		seen[key] = true
+	}
+
+	changed := false
+	for _, alert := range alerts {
+		if seen[alert.Key] {
+			continue
+		}
+		seen[alert.Key] = true
+		if desktopNotifier(ctx, alert.Title, alert.Body) == nil {
+			shown = append(shown, alert.Key)
+			changed = true
+		}
+	}
+	if changed {
+		_ = saveDesktopNotified(path, shown)
+	}
+}
+
+// Environment variables carrying the notification text to PowerShell.
+const (
+	desktopNotifyTitleEnv = "BDH_NOTIFY_TITLE"
+	desktopNotifyBodyEnv  = "BDH_NOTIFY_BODY"
+)
+
+// windowsToastScript shows a toast whose text comes from the environment:
+// peer-supplied text is never part of the script, so no quoting (PowerShell
+// also treats the typographic quotes as delimiters) can break out of it.
+const windowsToastScript = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $xml.GetElementsByTagName('text')
+$text.Item(0).AppendChild($xml.CreateTextNode($env:` + desktopNotifyTitleEnv + `)) > $null
+$text.Item(1).AppendChild($xml.CreateTextNode($env:` + desktopNotifyBodyEnv + `)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('bdh').Show([Windows.UI.Notifications.ToastNotification]::new($xml))`
+
+// desktopNotifyCommand returns the command that shows a notification on
+// goos, and any variables to add to its environment, or nil when the
+// platform has no supported notifier.
+func desktopNotifyCommand(goos, title, body string) (argv []string, env []string) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s sound name \"Glass\"",
+			appleScriptString(body), appleScriptString(title))
+		return []string{"osascript", "-e", script}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		// "--" so a title or body starting with "-" is not taken as a flag
+		return []string{"notify-send", "--urgency=critical", "--app-name=bdh", "--", title, body}, nil
+	case "windows":
+		return []string{"powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToastScript},
+			[]string{desktopNotifyTitleEnv + "=" + title, desktopNotifyBodyEnv + "=" + body}
+	}
+	return nil, nil
+}
+
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func sendDesktopNotification(ctx context.Context, title, body string) error {
+	argv, env := desktopNotifyCommand(runtime.GOOS, title, body)
+	if argv == nil {
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	ctx, cancel := context.WithTimeout(ctx, desktopNotifyTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return cmd.Run()
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+)
+
+func TestNotifyDesktop_AlertsOncePerMessage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var shown []string
+	orig := desktopNotifier
+	desktopNotifier = func(ctx context.Context, title, body string) error {
+		shown = append(shown, title+" | "+body)
+		return nil
+	}
+	t.Cleanup(func() { desktopNotifier = orig })
+
+	nc := &NotificationContext{
+		UrgentMail: urgentMailFrom([]aweb.InboxMessage{
+			{MessageID: "m1", FromAlias: "bob", Subject: "Prod is down", Priority: aweb.PriorityUrgent},
+			{MessageID: "m2", FromAlias: "carol", Subject: "FYI", Priority: aweb.PriorityHigh},
+		}),
+		PendingConversations: []PendingConversation{
+			{SessionID: "s1", LastFrom: "dave", LastMessage: "can you\nlook?", LastActivity: "t1", SenderWaiting: true},
+			{SessionID: "s2", LastFrom: "erin", LastActivity: "t1", SenderWaiting: true},
+			{SessionID: "s3", LastFrom: "frank", LastActivity: "t1"},
+		},
+	}
+
+	notifyDesktop(context.Background(), nc, "erin")
+	want := []string{
+		"bdh: urgent mail from bob | Prod is down",
+		"bdh: dave is waiting for your reply | can you look?",
+	}
+	if strings.Join(shown, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("shown = %q", shown)
+	}
+
+	// Same notifications on the next command: nothing new.
+	notifyDesktop(context.Background(), nc, "erin")
+	if len(shown) != 2 {
+		t.Fatalf("alerted again: %q", shown)
+	}
+
+	// A new turn in the same chat alerts again.
+	nc.PendingConversations[0].LastActivity = "t2"
+	notifyDesktop(context.Background(), nc, "erin")
+	if len(shown) != 3 {
+		t.Errorf("new chat turn not alerted: %q", shown)
+	}
+}
+
+func TestDesktopNotifyCommand_Quoting(t *testing.T) {
+	argv, _ := desktopNotifyCommand("darwin", `Say "hi"`, `back\slash`)
+	if argv[0] != "osascript" || argv[2] != `display notification "back\\slash" with title "Say \"hi\"" sound name "Glass"` {
+		t.Errorf("darwin = %q", argv)
+	}
+	argv, _ = desktopNotifyCommand("linux", "-t", "b")
+	if strings.Join(argv, " ") != "notify-send --urgency=critical --app-name=bdh -- -t b" {
+		t.Errorf("linux = %q", argv)
+	}
+	// Smart quotes end a PowerShell string too; the text must stay out of
+	// the script entirely.
+	title := "it\u2019s'; Remove-Item -Recurse ~ #"
+	argv, env := desktopNotifyCommand("windows", title, "b")
+	if argv[0] != "powershell" || strings.Contains(argv[len(argv)-1], "Remove-Item") {
+		t.Errorf("windows = %q", argv)
+	}
+	if strings.Join(env, "|") != desktopNotifyTitleEnv+"="+title+"|"+desktopNotifyBodyEnv+"=b" {
+		t.Errorf("windows env = %q", env)
+	}
+	if argv, _ := desktopNotifyCommand("plan9", "t", "b"); argv != nil {
+		t.Error("expected no notifier for an unsupported OS")
+	}
+}
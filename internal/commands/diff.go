@@ -0,0 +1,497 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/beads"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+// Every successful sync records the uploaded version of each changed issue in
+// .beadhub-cache/sync-history.jsonl (see sync.RecordHistory). :diff compares
+// consecutive versions so a reviewer can see how a bead evolved before
+// closing it, and by whose sync each change arrived.
+
+// maxDescriptionDiffLines caps the description lines shown per change.
+const maxDescriptionDiffLines = 20
+
+// BeadFieldChange is one field that differs between two versions of a bead.
+// Scalar fields use From/To; dependencies and labels use Added/Removed; the
+// description uses Lines ("- old" / "+ new").
+type BeadFieldChange struct {
+	Field   string   `json:"field"`
+	From    string   `json:"from,omitempty"`
+	To      string   `json:"to,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Lines   []string `json:"lines,omitempty"`
+}
+
+// BeadVersion is a recorded version of a bead and how it differs from the
+// previous one.
+type BeadVersion struct {
+	SyncedAt time.Time `json:"synced_at,omitzero"`
+	SyncedBy string    `json:"synced_by,omitempty"`
+	Command  string    `json:"command,omitempty"`
+	// First marks the oldest recorded version; there is nothing to compare
+	// it with, so Changes is empty.
+	First    bool              `json:"first,omitempty"`
+	Deleted  bool              `json:"deleted,omitempty"`
+	Unsynced bool              `json:"unsynced,omitempty"`
+	Status   string            `json:"status,omitempty"`
+	Changes  []BeadFieldChange `json:"changes,omitempty"`
+}
+
+// BeadDiffResult is the history of one bead, oldest version first.
+type BeadDiffResult struct {
+	BeadID   string        `json:"bead_id"`
+	Title    string        `json:"title,omitempty"`
+	Versions []BeadVersion `json:"versions"`
+}
+
+var diffJSON bool
+
+var diffCmd = &cobra.Command{
+	Use:   ":diff <bead-id>",
+	Short: "Show how a bead changed over time",
+	Long: `Show how a bead changed across syncs: status transitions, title and
+description edits, dependency and label changes, with when each change was
+synced and by which workspace.
+
+bdh keeps a snapshot of every version it uploads, so history starts with
+the first sync after upgrading and covers changes synced from this
+workspace (including ones pulled in through git). Local changes not yet
+synced are shown last.
+
+Examples:
+  bdh :diff bd-42         # Review a bead before closing it
+  bdh :diff bd-42 --json  # Output as JSON`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "Output as JSON")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+
+	history, err := sync.LoadHistory(syncHistoryPathForConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("reading sync history: %w", err)
+	}
+	local, _ := os.ReadFile(beads.IssuesJSONLPath())
+
+	result, err := diffBead(args[0], history[args[0]], local)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatDiffOutput(result, diffJSON))
+	return nil
+}
+
+// syncHistoryPathForConfig sits beside the sync state, so an overridden
+// project keeps its own history too.
+func syncHistoryPathForConfig(cfg *config.Config) string {
+	state := syncStatePathForConfig(cfg)
+	name := strings.Replace(filepath.Base(state), "sync-state", "sync-history", 1)
+	return filepath.Join(filepath.Dir(state), strings.TrimSuffix(name, ".json")+".jsonl")
+}
+
+// recordSyncHistory snapshots the issues a successful sync uploaded.
+// synced is nil when every issue was sent; previous holds the hashes from
+// before the sync, to spot deletions. Failures are ignored - history is a
+// review aid and must never affect the sync.
+func recordSyncHistory(cfg *config.Config, bdArgs []string, content []byte, current, previous map[string]string, synced []string) {
+	ids := synced
+	var deleted []string
+	if ids == nil {
+		ids = make([]string, 0, len(current))
+		for id := range current {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		deleted = sync.FindDeletedIssues(current, previous)
+	}
+	meta := sync.HistoryMeta{
+		SyncedAt: time.Now(),
+		SyncedBy: cfg.Alias,
+		Targets:  commandNamedIssues(bdArgs, current),
+	}
+	if len(bdArgs) > 0 {
+		meta.Command = "bd " + strings.Join(bdArgs, " ")
+	}
+
+	// Concurrent syncs would both append the same versions, or one would
+	// rewrite the file over the other's append
+	path := syncHistoryPathForConfig(cfg)
+	if ensureCacheDir(path) != nil {
+		return
+	}
+	unlock, err := lockCacheFile(path)
+	if err != nil {
+		return
+	}
+	defer unlock()
+	_ = sync.RecordHistory(path, content, current, ids, deleted, meta)
+}
+
+// commandNamedIssues returns the known issue IDs among the arguments of a bd
+// command; changes to other issues were not made by that command.
+func commandNamedIssues(bdArgs []string, known map[string]string) []string {
+	var ids []string
+	for _, arg := range bdArgs {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if _, ok := known[arg]; ok {
+			ids = append(ids, arg)
+		}
+	}
+	return ids
+}
+
+// diffBead builds the version list for beadID from its recorded history and,
+// when it differs from the last synced version, the bead's local state.
+func diffBead(beadID string, entries []sync.HistoryEntry, local []byte) (*BeadDiffResult, error) {
+	result := &BeadDiffResult{BeadID: beadID}
+
+	var prev map[string]any
+	lastHash := ""
+	for i, entry := range entries {
+		version := BeadVersion{
+			SyncedAt: entry.SyncedAt,
+			SyncedBy: entry.SyncedBy,
+			Command:  entry.Command,
+			First:    i == 0,
+			Deleted:  entry.Deleted,
+		}
+		if entry.Deleted {
+			prev, lastHash = nil, ""
+			result.Versions = append(result.Versions, version)
+			continue
+		}
+		var issue map[string]any
+		if err := json.Unmarshal(entry.Issue, &issue); err != nil {
+			continue
+		}
+		version.Status = issueString(issue, "status")
+		if prev != nil {
+			version.Changes = compareIssueVersions(prev, issue)
+		}
+		result.Versions = append(result.Versions, version)
+		prev, lastHash = issue, entry.Hash
+	}
+
+	if len(local) > 0 {
+		if line, err := sync.ExtractIssuesByID(local, []string{beadID}); err == nil && strings.TrimSpace(line) != "" {
+			hashes, _ := sync.ComputeIssueHashes([]byte(line))
+			var issue map[string]any
+			if hashes[beadID] != lastHash && json.Unmarshal([]byte(strings.TrimSpace(line)), &issue) == nil {
+				version := BeadVersion{Unsynced: true, First: len(result.Versions) == 0, Status: issueString(issue, "status")}
+				if prev != nil {
+					version.Changes = compareIssueVersions(prev, issue)
+				}
+				result.Versions = append(result.Versions, version)
+				prev = issue
+			}
+		}
+	}
+
+	if len(result.Versions) == 0 {
+		return nil, fmt.Errorf("no history for %s - it has not been synced since history was recorded, and is not in issues.jsonl", beadID)
+	}
+	if prev != nil {
+		result.Title = issueString(prev, "title")
+	}
+	return result, nil
+}
+
+// diffIgnoredFields change on every edit and would only add noise.
+var diffIgnoredFields = map[string]bool{
+	"id":         true,
+	"updated_at": true,
+}
+
+// diffFieldOrder lists the fields reviewers care about most, shown first.
+var diffFieldOrder = []string{"status", "title", "priority", "assignee", "issue_type", "description", "dependencies", "labels"}
+
+// compareIssueVersions returns the fields that differ between two versions.
+func compareIssueVersions(old, new map[string]any) []BeadFieldChange {
+	fields := make(map[string]bool)
+	for k := range old {
+		fields[k] = true
+	}
+	for k := range new {
+		fields[k] = true
+	}
+	var ordered []string
+	for _, f := range diffFieldOrder {
+		if fields[f] {
+			ordered = append(ordered, f)
+			delete(fields, f)
+		}
+	}
+	var rest []string
+	for f := range fields {
+		rest = append(rest, f)
+	}
+	sort.Strings(rest)
+	ordered = append(ordered, rest...)
+
+	var changes []BeadFieldChange
+	for _, field := range ordered {
+		if diffIgnoredFields[field] {
+			continue
+		}
+		switch field {
+		case "description":
+			from, to := issueString(old, field), issueString(new, field)
+			if from != to {
+				changes = append(changes, BeadFieldChange{Field: field, Lines: diffLines(from, to)})
+			}
+		case "dependencies":
+			added, removed := diffSets(dependencyKeys(old[field]), dependencyKeys(new[field]))
+			if len(added) > 0 || len(removed) > 0 {
+				changes = append(changes, BeadFieldChange{Field: field, Added: added, Removed: removed})
+			}
+		case "labels":
+			added, removed := diffSets(stringList(old[field]), stringList(new[field]))
+			if len(added) > 0 || len(removed) > 0 {
+				changes = append(changes, BeadFieldChange{Field: field, Added: added, Removed: removed})
+			}
+		default:
+			from, to := diffValue(old[field]), diffValue(new[field])
+			if from != to {
+				changes = append(changes, BeadFieldChange{Field: field, From: from, To: to})
+			}
+		}
+	}
+	return changes
+}
+
+func issueString(issue map[string]any, field string) string {
+	s, _ := issue[field].(string)
+	return s
+}
+
+// diffValue renders a field value for display; absent and empty are the same.
+func diffValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// dependencyKeys renders dependencies as "type:depends_on_id".
+func dependencyKeys(v any) []string {
+	list, _ := v.([]any)
+	var keys []string
+	for _, item := range list {
+		dep, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		target := issueString(dep, "depends_on_id")
+		if target == "" {
+			continue
+		}
+		if depType := issueString(dep, "type"); depType != "" {
+			target = depType + ":" + target
+		}
+		keys = append(keys, target)
+	}
+	return keys
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	var out []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// diffSets returns the sorted items only in new (added) and only in old
+// (removed).
+func diffSets(old, new []string) (added, removed []string) {
+	inOld := make(map[string]bool, len(old))
+	for _, s := range old {
+		inOld[s] = true
+	}
+	inNew := make(map[string]bool, len(new))
+	for _, s := range new {
+		inNew[s] = true
+		if !inOld[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range old {
+		if !inNew[s] {
+			removed = append(removed, s)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// diffLines returns the removed ("- ") and added ("+ ") lines between two
+// texts, in order, using a longest-common-subsequence alignment.
+func diffLines(from, to string) []string {
+	a, b := splitTextLines(from), splitTextLines(to)
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+	if len(lines) > maxDescriptionDiffLines {
+		more := len(lines) - maxDescriptionDiffLines
+		lines = append(lines[:maxDescriptionDiffLines], fmt.Sprintf("... %d more line(s)", more))
+	}
+	return lines
+}
+
+func splitTextLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(s, "\n"), "\n")
+}
+
+func formatDiffOutput(result *BeadDiffResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Title != "" {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", result.BeadID, result.Title))
+	} else {
+		sb.WriteString(result.BeadID + "\n")
+	}
+
+	for _, v := range result.Versions {
+		sb.WriteString("\n")
+		sb.WriteString(formatDiffVersionHeader(v))
+		for _, change := range v.Changes {
+			sb.WriteString(formatDiffChange(change))
+		}
+	}
+	return sb.String()
+}
+
+func formatDiffVersionHeader(v BeadVersion) string {
+	var when string
+	if v.Unsynced {
+		when = "not yet synced"
+	} else {
+		when = v.SyncedAt.Local().Format("2006-01-02 15:04")
+		if v.SyncedBy != "" {
+			when += " by " + v.SyncedBy
+		}
+	}
+	var what string
+	switch {
+	case v.Deleted:
+		what = "deleted"
+	case v.First && v.Unsynced:
+		what = fmt.Sprintf("local only (%s)", v.Status)
+	case v.First:
+		what = fmt.Sprintf("first synced version (%s)", v.Status)
+	case len(v.Changes) == 0:
+		what = "no visible changes"
+	}
+	header := when
+	if what != "" {
+		header += " - " + what
+	}
+	if v.Command != "" {
+		header += fmt.Sprintf("\n  via: %s", v.Command)
+	}
+	return header + "\n"
+}
+
+func formatDiffChange(change BeadFieldChange) string {
+	var sb strings.Builder
+	switch {
+	case change.Lines != nil:
+		sb.WriteString(fmt.Sprintf("  %s:\n", change.Field))
+		for _, line := range change.Lines {
+			sb.WriteString("    " + line + "\n")
+		}
+	case change.Added != nil || change.Removed != nil:
+		for _, s := range change.Added {
+			sb.WriteString(fmt.Sprintf("  %s: + %s\n", change.Field, s))
+		}
+		for _, s := range change.Removed {
+			sb.WriteString(fmt.Sprintf("  %s: - %s\n", change.Field, s))
+		}
+	default:
+		sb.WriteString(fmt.Sprintf("  %s: %s -> %s\n", change.Field, orNone(change.From), orNone(change.To)))
+	}
+	return sb.String()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
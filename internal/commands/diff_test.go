@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+func historyEntry(t *testing.T, issue string, at time.Time, by, command string) sync.HistoryEntry {
+	t.Helper()
+	hashes, err := sync.ComputeIssueHashes([]byte(issue + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var probe struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal([]byte(issue), &probe)
+	return sync.HistoryEntry{ID: probe.ID, Hash: hashes[probe.ID], SyncedAt: at, SyncedBy: by, Command: command, Issue: json.RawMessage(issue)}
+}
+
+func TestDiffBead_ShowsChangesBetweenVersions(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	v1 := `{"id":"bd-42","title":"Fix login","status":"open","priority":2,"description":"Login fails.\nSteps below.","labels":["auth"],"updated_at":"a"}`
+	v2 := `{"id":"bd-42","title":"Fix login","status":"in_progress","priority":1,"description":"Login fails on Safari.\nSteps below.","labels":["auth","urgent"],"dependencies":[{"issue_id":"bd-42","depends_on_id":"bd-7","type":"blocks"}],"updated_at":"b"}`
+	entries := []sync.HistoryEntry{
+		historyEntry(t, v1, t0, "alice", "bd create Fix login"),
+		historyEntry(t, v2, t0.Add(time.Hour), "bob", ""),
+	}
+	local := []byte(`{"id":"bd-1","title":"Other"}` + "\n" + `{"id":"bd-42","title":"Fix login","status":"closed","priority":1,"description":"Login fails on Safari.\nSteps below.","labels":["urgent"],"updated_at":"c"}` + "\n")
+
+	result, err := diffBead("bd-42", entries, local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Versions) != 3 {
+		t.Fatalf("versions = %+v", result.Versions)
+	}
+	if !result.Versions[0].First || result.Versions[0].Status != "open" || len(result.Versions[0].Changes) != 0 {
+		t.Errorf("first = %+v", result.Versions[0])
+	}
+
+	out := formatDiffOutput(result, false)
+	for _, want := range []string{
+		"bd-42: Fix login",
+		"by alice - first synced version (open)",
+		"via: bd create Fix login",
+		"by bob\n",
+		"  status: open -> in_progress\n",
+		"  priority: 2 -> 1\n",
+		"    - Login fails.\n    + Login fails on Safari.\n",
+		"  dependencies: + blocks:bd-7\n",
+		"  labels: + urgent\n",
+		"not yet synced\n  status: in_progress -> closed\n  dependencies: - blocks:bd-7\n  labels: - auth\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "updated_at") || strings.Contains(out, "Steps below") {
+		t.Errorf("output has noise:\n%s", out)
+	}
+
+	// Local state matching the last synced version adds nothing
+	result, err = diffBead("bd-42", entries, []byte(v2+"\n"))
+	if err != nil || len(result.Versions) != 2 {
+		t.Errorf("versions = %+v, err = %v", result, err)
+	}
+}
+
+func TestDiffBead_NoHistory(t *testing.T) {
+	if _, err := diffBead("bd-42", nil, nil); err == nil || !strings.Contains(err.Error(), "no history for bd-42") {
+		t.Errorf("err = %v", err)
+	}
+	result, err := diffBead("bd-42", nil, []byte(`{"id":"bd-42","title":"New","status":"open"}`+"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := formatDiffOutput(result, false); !strings.Contains(out, "not yet synced - local only (open)") {
+		t.Errorf("output = %s", out)
+	}
+}
+
+func TestCommandNamedIssues(t *testing.T) {
+	known := map[string]string{"bd-1": "h", "bd-2": "h"}
+	got := commandNamedIssues([]string{"dep", "add", "bd-1", "bd-2", "--type", "blocks"}, known)
+	if strings.Join(got, ",") != "bd-1,bd-2" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestRecordSyncHistory_ConcurrentSyncsKeepEveryVersion(t *testing.T) {
+	setupBeadsWorkspace(t, "")
+	cfg := &config.Config{Alias: "claude-be"}
+
+	const syncs = 8
+	done := make(chan struct{})
+	for i := 0; i < syncs; i++ {
+		go func(i int) {
+			defer func() { done <- struct{}{} }()
+			content := []byte(fmt.Sprintf(`{"id":"bd-%d","title":"Version %d"}`+"\n", i, i))
+			hashes, err := sync.ComputeIssueHashes(content)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			recordSyncHistory(cfg, nil, content, hashes, hashes, []string{fmt.Sprintf("bd-%d", i)})
+		}(i)
+	}
+	for i := 0; i < syncs; i++ {
+		<-done
+	}
+
+	history, err := sync.LoadHistory(syncHistoryPathForConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != syncs {
+		t.Errorf("recorded %d of %d issues: %v", len(history), syncs, history)
+	}
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"sync"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// display.aliases gives other agents readable names ("🤖 Backend" for
+// claude-be) in team status, locks and chat. Only names shown to people
+// change: commands bdh suggests and JSON output keep the alias, and
+// --:raw-aliases turns the names off for scripts parsing text output.
+
+// rawAliases is set by --:raw-aliases.
+var rawAliases bool
+
+// currentDisplayConfig returns the config holding display.aliases, or nil
+// when there is none. The config is read once per process; tests replace
+// this.
+var currentDisplayConfig = sync.OnceValue(func() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg
+})
+
+// displayAlias returns the name to show for alias.
+func displayAlias(alias string) string {
+	if rawAliases || alias == "" {
+		return alias
+	}
+	cfg := currentDisplayConfig()
+	if cfg == nil {
+		return alias
+	}
+	return cfg.DisplayName(alias)
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/awebai/aw/chat"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func withDisplayNames(t *testing.T, names map[string]string) {
+	t.Helper()
+	orig := currentDisplayConfig
+	cfg := &config.Config{Display: &config.DisplayConfig{Aliases: names}}
+	currentDisplayConfig = func() *config.Config { return cfg }
+	t.Cleanup(func() { currentDisplayConfig = orig })
+}
+
+func TestDisplayAlias(t *testing.T) {
+	withDisplayNames(t, map[string]string{"claude-be": "🤖 Backend"})
+
+	if got := displayAlias("claude-be"); got != "🤖 Backend" {
+		t.Errorf("displayAlias(claude-be) = %q", got)
+	}
+	if got := displayAlias("claude-fe"); got != "claude-fe" {
+		t.Errorf("displayAlias(claude-fe) = %q", got)
+	}
+	if got := teamMemberLabel(client.Workspace{Alias: "claude-be", Tags: []string{"go"}}); got != "🤖 Backend [go]" {
+		t.Errorf("teamMemberLabel = %q", got)
+	}
+
+	rawAliases = true
+	t.Cleanup(func() { rawAliases = false })
+	if got := displayAlias("claude-be"); got != "claude-be" {
+		t.Errorf("--:raw-aliases: displayAlias(claude-be) = %q", got)
+	}
+}
+
+func TestDisplayAlias_ChatKeepsAliasInCommands(t *testing.T) {
+	withDisplayNames(t, map[string]string{"alice": "Alice (frontend)"})
+
+	out := formatChatOpenOutput(&chat.OpenResult{
+		TargetAgent:   "alice",
+		MarkedRead:    1,
+		SenderWaiting: true,
+		Messages:      []chat.Event{{Type: "message", FromAgent: "alice", Body: "Can you help?"}},
+	}, false)
+	for _, want := range []string{"Alice (frontend) is WAITING", "Alice (frontend): Can you help?", "chat send alice"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %q", want, out)
+		}
+	}
+
+	out = formatPendingOutput(&chat.PendingResult{Pending: []chat.PendingConversation{
+		{Participants: []string{"me", "alice"}, LastFrom: "alice", UnreadCount: 1},
+	}}, "me", false)
+	if !strings.Contains(out, "CHAT: Alice (frontend)") || !strings.Contains(out, "chat open alice") {
+		t.Errorf("pending output = %q", out)
+	}
+}
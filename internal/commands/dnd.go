@@ -0,0 +1,370 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/i18n"
+)
+
+// Do-not-disturb is kept locally in .beadhub-cache/dnd.json and sent with
+// every presence refresh, so it expires on its own even if the server never
+// hears a ":dnd off". Other agents see it in pre-flight team status, and chat
+// --start-conversation warns before interrupting.
+
+// defaultDNDDuration applies when :dnd on is given no --until.
+const defaultDNDDuration = time.Hour
+
+const (
+	presenceStatusDND       = "dnd"
+	presenceStatusAvailable = "available"
+)
+
+var (
+	dndJSON  bool
+	dndUntil string
+	dndNote  string
+)
+
+var dndCmd = &cobra.Command{
+	Use:   ":dnd",
+	Short: "Set a do-not-disturb presence status",
+	Long: `Mark yourself heads-down so other agents prefer mail over chat.
+
+While on, other agents' team status shows "<you> is heads-down until 15:00;
+prefer mail over chat", and starting a chat with you warns first. The status
+expires on its own at --until.
+
+With away.auto_reply set in .beadhub, new chat sessions get one automatic
+reply with your status and expected return (template: away.message).
+
+--until accepts a duration (2h, 45m) or a local clock time (15:00, the next
+time that clock time occurs). Default: 1h.
+
+Examples:
+  bdh :dnd on --until 2h
+  bdh :dnd on --until 17:30 --note "release freeze"
+  bdh :dnd off
+  bdh :dnd status --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDNDStatus(cmd, args)
+	},
+}
+
+var dndOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Go heads-down until a time",
+	Args:  cobra.NoArgs,
+	RunE:  runDNDOn,
+}
+
+var dndOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Clear do-not-disturb",
+	Args:  cobra.NoArgs,
+	RunE:  runDNDOff,
+}
+
+var dndStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show your do-not-disturb status",
+	Args:  cobra.NoArgs,
+	RunE:  runDNDStatus,
+}
+
+func init() {
+	dndCmd.PersistentFlags().BoolVar(&dndJSON, "json", false, "Output as JSON")
+	dndOnCmd.Flags().StringVar(&dndUntil, "until", "", "Duration (2h) or local time (15:00); default 1h")
+	dndOnCmd.Flags().StringVar(&dndNote, "note", "", "Short reason shown to other agents")
+
+	dndCmd.AddCommand(dndOnCmd)
+	dndCmd.AddCommand(dndOffCmd)
+	dndCmd.AddCommand(dndStatusCmd)
+}
+
+// DNDState is the locally stored do-not-disturb status.
+type DNDState struct {
+	Until string `json:"until"`
+	Note  string `json:"note,omitempty"`
+	SetAt string `json:"set_at"`
+
+	// AutoReplied lists the chat sessions already answered by the away
+	// auto-responder during this do-not-disturb period.
+	AutoReplied []string `json:"auto_replied,omitempty"`
+}
+
+// DNDResult is the output of :dnd commands.
+type DNDResult struct {
+	Active  bool   `json:"active"`
+	Until   string `json:"until,omitempty"`
+	Note    string `json:"note,omitempty"`
+	Warning string `json:"warning,omitempty"`
+}
+
+func dndPath() (string, error) {
+	return cacheFilePath("dnd.json")
+}
+
+func loadDNDState(path string) (*DNDState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state DNDState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func saveDNDState(path string, state *DNDState) error {
+	return writeJSONAtomic(path, state)
+}
+
+// parseDNDUntil accepts a positive duration ("2h") or a local clock time
+// ("15:00"), which resolves to its next occurrence after now.
+func parseDNDUntil(value string, now time.Time) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return now.Add(defaultDNDDuration), nil
+	}
+	return parseFutureTime("--until", value, now)
+}
+
+// activeDNDState returns the stored state if it has not expired yet.
+func activeDNDState(state *DNDState, now time.Time) bool {
+	if state == nil {
+		return false
+	}
+	until, ok := parseTimeBestEffort(state.Until)
+	return ok && now.Before(until)
+}
+
+// applyDNDPresence adds the local do-not-disturb status to a presence refresh.
+// An expired status is sent as "available" once and then forgotten.
+func applyDNDPresence(req *client.RefreshPresenceRequest, now time.Time) {
+	path, err := dndPath()
+	if err != nil {
+		return
+	}
+	state, err := loadDNDState(path)
+	if err != nil || state == nil {
+		return
+	}
+	if activeDNDState(state, now) {
+		req.PresenceStatus = presenceStatusDND
+		req.PresenceUntil = state.Until
+		req.PresenceNote = state.Note
+		return
+	}
+	req.PresenceStatus = presenceStatusAvailable
+	_ = os.Remove(path)
+}
+
+func runDNDOn(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	result, err := dndOnWithConfig(ctx, cfg, dndUntil, dndNote, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatDNDOutput(result, dndJSON))
+	return nil
+}
+
+func runDNDOff(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	result, err := dndOffWithConfig(ctx, cfg, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatDNDOutput(result, dndJSON))
+	return nil
+}
+
+func runDNDStatus(cmd *cobra.Command, args []string) error {
+	path, err := dndPath()
+	if err != nil {
+		return err
+	}
+	state, err := loadDNDState(path)
+	if err != nil {
+		return err
+	}
+	result := &DNDResult{}
+	if activeDNDState(state, time.Now()) {
+		result.Active = true
+		result.Until = state.Until
+		result.Note = state.Note
+	}
+	fmt.Print(formatDNDOutput(result, dndJSON))
+	return nil
+}
+
+// dndOnWithConfig stores the status and pushes it to the server right away.
+// A failed push is only a warning: the next presence refresh retries it.
+func dndOnWithConfig(ctx context.Context, cfg *config.Config, untilValue, note string, now time.Time) (*DNDResult, error) {
+	until, err := parseDNDUntil(untilValue, now)
+	if err != nil {
+		return nil, err
+	}
+	path, err := dndPath()
+	if err != nil {
+		return nil, err
+	}
+	state := &DNDState{
+		Until: until.UTC().Format(time.RFC3339),
+		Note:  strings.TrimSpace(note),
+		SetAt: now.UTC().Format(time.RFC3339),
+	}
+	if err := saveDNDState(path, state); err != nil {
+		return nil, fmt.Errorf("saving do-not-disturb status: %w", err)
+	}
+
+	result := &DNDResult{Active: true, Until: state.Until, Note: state.Note}
+	result.Warning = pushPresence(ctx, cfg, presenceRequest(ctx, cfg, now))
+	return result, nil
+}
+
+func dndOffWithConfig(ctx context.Context, cfg *config.Config, now time.Time) (*DNDResult, error) {
+	path, err := dndPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("clearing do-not-disturb status: %w", err)
+	}
+	req := presenceRequest(ctx, cfg, now)
+	req.PresenceStatus = presenceStatusAvailable
+	return &DNDResult{Warning: pushPresence(ctx, cfg, req)}, nil
+}
+
+func pushPresence(ctx context.Context, cfg *config.Config, req *client.RefreshPresenceRequest) string {
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if _, err := c.RefreshPresence(ctx, req); err != nil {
+		clearPresenceStamp()
+		return fmt.Sprintf("could not update presence on BeadHub (%v) - it will be retried on the next bdh command", err)
+	}
+	stampPresenceRefresh(cfg, time.Now())
+	return ""
+}
+
+func formatDNDOutput(result *DNDResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	var sb strings.Builder
+	if result.Active {
+		sb.WriteString(fmt.Sprintf("Do not disturb: on until %s", formatClockTime(result.Until)))
+		if result.Note != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", result.Note))
+		}
+		sb.WriteString("\nOther agents will be asked to prefer mail over chat.\n")
+	} else {
+		sb.WriteString("Do not disturb: off\n")
+	}
+	if result.Warning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", result.Warning))
+	}
+	return sb.String()
+}
+
+// formatClockTime renders an RFC3339 timestamp as local "15:04", adding the
+// date when it is not today.
+func formatClockTime(ts string) string {
+	t, ok := parseTimeBestEffort(ts)
+	if !ok {
+		return ts
+	}
+	t = t.Local()
+	now := time.Now()
+	if t.Year() != now.Year() || t.YearDay() != now.YearDay() {
+		return t.Format("Jan 2 15:04")
+	}
+	return t.Format("15:04")
+}
+
+// presenceNotice describes a workspace's do-not-disturb status for other
+// agents, or returns "" when it is not heads-down.
+func presenceNotice(ws client.Workspace, now time.Time) string {
+	if ws.PresenceStatus != presenceStatusDND {
+		return ""
+	}
+	notice := i18n.T("presence.heads_down", ws.Alias)
+	if until, ok := parseTimeBestEffort(ws.PresenceUntil); ok {
+		if !now.Before(until) {
+			return ""
+		}
+		notice += i18n.T("presence.until", formatClockTime(ws.PresenceUntil))
+	}
+	if ws.PresenceNote != "" {
+		notice += fmt.Sprintf(" (%s)", ws.PresenceNote)
+	}
+	return notice + i18n.T("presence.prefer_mail")
+}
+
+// headsDownNotices looks up the presence of chat targets. Best-effort:
+// lookup failures produce no notice.
+func headsDownNotices(ctx context.Context, cfg *config.Config, aliases []string, now time.Time) []string {
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	var notices []string
+	for _, alias := range aliases {
+		lookupCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.Workspaces(lookupCtx, &client.WorkspacesRequest{Alias: alias, Limit: 1})
+		cancel()
+		if err != nil {
+			continue
+		}
+		for _, ws := range resp.Workspaces {
+			if ws.Alias != alias {
+				continue
+			}
+			if notice := presenceNotice(ws, now); notice != "" {
+				notices = append(notices, notice)
+			}
+		}
+	}
+	return notices
+}
+
+// confirmInterruptHeadsDown warns about heads-down chat targets and, on a
+// terminal, asks before interrupting. Non-interactive callers proceed.
+func confirmInterruptHeadsDown(notices []string) bool {
+	if len(notices) == 0 {
+		return true
+	}
+	for _, n := range notices {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", n)
+	}
+	if !isTTY() {
+		return true
+	}
+	fmt.Fprint(os.Stderr, "Interrupt anyway? [y/N]: ")
+	answer, err := readMenuLine(bufio.NewReader(os.Stdin))
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestParseDNDUntil(t *testing.T) {
+	loc := time.FixedZone("test", 2*3600)
+	now := time.Date(2025, 6, 1, 13, 0, 0, 0, loc)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "", want: now.Add(time.Hour)},
+		{value: "2h", want: now.Add(2 * time.Hour)},
+		{value: "15:00", want: time.Date(2025, 6, 1, 15, 0, 0, 0, loc)},
+		{value: "09:30", want: time.Date(2025, 6, 2, 9, 30, 0, 0, loc)},
+		{value: "13:00", want: time.Date(2025, 6, 2, 13, 0, 0, 0, loc)},
+		{value: "-1h", wantErr: true},
+		{value: "tomorrow", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseDNDUntil(tt.value, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDNDUntil(%q) expected error", tt.value)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseDNDUntil(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestPresenceNotice(t *testing.T) {
+	now := time.Now()
+	until := now.Add(2 * time.Hour).UTC().Format(time.RFC3339)
+
+	ws := client.Workspace{Alias: "maria-be", PresenceStatus: "dnd", PresenceUntil: until}
+	want := "maria-be is heads-down until " + formatClockTime(until) + "; prefer mail over chat"
+	if got := presenceNotice(ws, now); got != want {
+		t.Errorf("presenceNotice = %q, want %q", got, want)
+	}
+
+	ws.PresenceNote = "release freeze"
+	if got := presenceNotice(ws, now); !strings.Contains(got, "(release freeze); prefer mail") {
+		t.Errorf("note missing: %q", got)
+	}
+
+	ws.PresenceUntil = now.Add(-time.Minute).UTC().Format(time.RFC3339)
+	if got := presenceNotice(ws, now); got != "" {
+		t.Errorf("expired status should not be shown, got %q", got)
+	}
+	if got := presenceNotice(client.Workspace{Alias: "bob"}, now); got != "" {
+		t.Errorf("available agent should not be shown, got %q", got)
+	}
+}
+
+func TestDNDOnOff_PushesPresence(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	t.Chdir(t.TempDir())
+
+	var pushed []client.RefreshPresenceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/agents/register" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req client.RefreshPresenceRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		pushed = append(pushed, req)
+		json.NewEncoder(w).Encode(map[string]any{})
+	}))
+	defer server.Close()
+	cfg := &config.Config{BeadhubURL: server.URL, WorkspaceID: "ws-1", Alias: "maria-be"}
+	now := time.Now()
+
+	result, err := dndOnWithConfig(context.Background(), cfg, "2h", "deep work", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Active || result.Warning != "" {
+		t.Errorf("result = %+v", result)
+	}
+	if len(pushed) != 1 || pushed[0].PresenceStatus != "dnd" || pushed[0].PresenceNote != "deep work" {
+		t.Fatalf("pushed = %+v", pushed)
+	}
+
+	// Heartbeats keep sending the status while it is active...
+	req := &client.RefreshPresenceRequest{}
+	applyDNDPresence(req, now.Add(time.Hour))
+	if req.PresenceStatus != "dnd" {
+		t.Errorf("active status not applied: %+v", req)
+	}
+	// ...and send "available" once it expires, then forget it.
+	req = &client.RefreshPresenceRequest{}
+	applyDNDPresence(req, now.Add(3*time.Hour))
+	if req.PresenceStatus != "available" {
+		t.Errorf("expired status should clear: %+v", req)
+	}
+	path, _ := dndPath()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expired state file should be removed, stat err = %v", err)
+	}
+
+	if _, err := dndOffWithConfig(context.Background(), cfg, now); err != nil {
+		t.Fatal(err)
+	}
+	if last := pushed[len(pushed)-1]; last.PresenceStatus != "available" {
+		t.Errorf("off should push available, got %+v", last)
+	}
+}
+
+func TestFormatReadyTeamStatus_ShowsHeadsDown(t *testing.T) {
+	until := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	result := &PassthroughResult{
+		IsReadyCommand: true,
+		TeamStatus: []client.Workspace{
+			{Alias: "maria-be", PresenceStatus: "dnd", PresenceUntil: until,
+				Claims: []client.Claim{{BeadID: "bd-1"}}},
+		},
+	}
+	out := formatPassthroughOutput(result)
+	if !strings.Contains(out, "- maria-be is heads-down until "+formatClockTime(until)+"; prefer mail over chat") {
+		t.Errorf("team status missing heads-down line:\n%s", out)
+	}
+}
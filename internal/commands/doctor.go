@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :doctor reports the tooling this workspace runs. With --team it lists the
+// tooling every workspace reported on its last sync and flags the ones behind
+// the newest bdh or bd release seen in the team.
+
+// DoctorMember is one workspace's reported tooling.
+type DoctorMember struct {
+	Alias    string               `json:"alias"`
+	Env      *client.WorkspaceEnv `json:"env,omitempty"`
+	Outdated []string             `json:"outdated,omitempty"`
+}
+
+// DoctorResult is the output of :doctor.
+type DoctorResult struct {
+	Local      *client.WorkspaceEnv `json:"local"`
+	Team       []DoctorMember       `json:"team,omitempty"`
+	LatestBdh  string               `json:"latest_bdh,omitempty"`
+	LatestBd   string               `json:"latest_bd,omitempty"`
+	Mismatches int                  `json:"mismatches"`
+
+	// BeadHub server version and the feature minimums it does not meet
+	ServerVersion  string   `json:"server_version,omitempty"`
+	ServerWarnings []string `json:"server_warnings,omitempty"`
+}
+
+var (
+	doctorTeam bool
+	doctorJSON bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   ":doctor",
+	Short: "Show tooling versions and spot stale ones across the team",
+	Long: `Show the bdh and bd versions, OS and git position of this workspace.
+
+With --team, list what every workspace reported on its last sync and flag
+the ones running an older bdh or bd than the newest in the team. Workspaces
+that have not synced since upgrading to a version that reports its tooling
+are listed as not reported.
+
+Examples:
+  bdh :doctor              # This workspace
+  bdh :doctor --team       # Everyone, with version mismatches flagged
+  bdh :doctor --team --json`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorTeam, "team", false, "Compare tooling across all workspaces")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output as JSON")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+
+	result := &DoctorResult{Local: currentWorkspaceEnv(ctx)}
+	result.ServerVersion = doctorServerVersion(ctx, cfg)
+	result.ServerWarnings = serverVersionProblems(result.ServerVersion)
+	if doctorTeam {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		defer cancel()
+		resp, err := newBeadHubClient(ctx, cfg.BeadhubURL).Workspaces(ctx, &client.WorkspacesRequest{Limit: defaultStatusTeamLimit})
+		if err != nil {
+			return fmt.Errorf("listing workspaces: %w", err)
+		}
+		compareTeamTooling(result, resp.Workspaces)
+	}
+	fmt.Print(formatDoctorOutput(result, doctorJSON))
+	return nil
+}
+
+// compareTeamTooling fills result.Team and flags members behind the newest
+// bdh and bd versions reported. Non-release versions ("dev") are never
+// flagged and never count as newest.
+func compareTeamTooling(result *DoctorResult, workspaces []client.Workspace) {
+	for _, ws := range workspaces {
+		if ws.Env == nil {
+			continue
+		}
+		if isNewerRelease(ws.Env.BdhVersion, result.LatestBdh) {
+			result.LatestBdh = ws.Env.BdhVersion
+		}
+		if isNewerRelease(ws.Env.BdVersion, result.LatestBd) {
+			result.LatestBd = ws.Env.BdVersion
+		}
+	}
+
+	result.Team = make([]DoctorMember, 0, len(workspaces))
+	result.Mismatches = 0
+	for _, ws := range workspaces {
+		member := DoctorMember{Alias: ws.Alias, Env: ws.Env}
+		if ws.Env != nil {
+			if cmp, ok := compareVersions(ws.Env.BdhVersion, result.LatestBdh); ok && cmp < 0 {
+				member.Outdated = append(member.Outdated, "bdh")
+			}
+			if cmp, ok := compareVersions(ws.Env.BdVersion, result.LatestBd); ok && cmp < 0 {
+				member.Outdated = append(member.Outdated, "bd")
+			}
+		}
+		if len(member.Outdated) > 0 {
+			result.Mismatches++
+		}
+		result.Team = append(result.Team, member)
+	}
+	sort.SliceStable(result.Team, func(i, j int) bool { return result.Team[i].Alias < result.Team[j].Alias })
+}
+
+// isNewerRelease reports whether candidate is a release version above current.
+func isNewerRelease(candidate, current string) bool {
+	if _, ok := versionParts(candidate); !ok {
+		return false
+	}
+	cmp, ok := compareVersions(candidate, current)
+	return !ok || cmp > 0
+}
+
+func formatDoctorOutput(result *DoctorResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("This workspace:\n")
+	sb.WriteString(fmt.Sprintf("  bdh:  %s\n", orUnknown(result.Local.BdhVersion)))
+	sb.WriteString(fmt.Sprintf("  bd:   %s\n", orUnknown(result.Local.BdVersion)))
+	sb.WriteString(fmt.Sprintf("  os:   %s\n", result.Local.OS))
+	if result.Local.GitBranch != "" || result.Local.GitCommit != "" {
+		sb.WriteString(fmt.Sprintf("  git:  %s\n", gitPosition(result.Local)))
+	}
+	sb.WriteString(fmt.Sprintf("\nBeadHub server: %s\n", orUnknown(result.ServerVersion)))
+	for _, w := range result.ServerWarnings {
+		sb.WriteString("  ⚠ " + w + "\n")
+	}
+	if result.Team == nil {
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\nTeam (%d workspaces):\n", len(result.Team)))
+	for _, member := range result.Team {
+		if member.Env == nil {
+			sb.WriteString(fmt.Sprintf("  %s — not reported\n", member.Alias))
+			continue
+		}
+		line := fmt.Sprintf("  %s — bdh %s, bd %s, %s", member.Alias,
+			orUnknown(member.Env.BdhVersion), orUnknown(member.Env.BdVersion), orUnknown(member.Env.OS))
+		if pos := gitPosition(member.Env); pos != "" {
+			line += ", " + pos
+		}
+		if len(member.Outdated) > 0 {
+			line += fmt.Sprintf("  ⚠ outdated %s", strings.Join(member.Outdated, ", "))
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	if result.Mismatches == 0 {
+		sb.WriteString("\nAll reporting workspaces run the same tooling versions.\n")
+		return sb.String()
+	}
+	var latest []string
+	if result.LatestBdh != "" {
+		latest = append(latest, "bdh "+result.LatestBdh)
+	}
+	if result.LatestBd != "" {
+		latest = append(latest, "bd "+result.LatestBd)
+	}
+	sb.WriteString(fmt.Sprintf("\n%d workspace(s) behind the newest tooling in the team (%s).\n",
+		result.Mismatches, strings.Join(latest, ", ")))
+	return sb.String()
+}
+
+func gitPosition(env *client.WorkspaceEnv) string {
+	switch {
+	case env.GitBranch != "" && env.GitCommit != "":
+		return env.GitBranch + "@" + env.GitCommit
+	case env.GitCommit != "":
+		return env.GitCommit
+	default:
+		return env.GitBranch
+	}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return unknownToolValue
+	}
+	return s
+}
+
+// doctorServerVersion finds the server's version from fresh capabilities,
+// asking GET /v1/version when they do not say. Returns "" when unknown or
+// the server is unreachable.
+func doctorServerVersion(ctx context.Context, cfg *config.Config) string {
+	caps := refreshCapabilities(ctx, cfg)
+	if caps == nil {
+		return ""
+	}
+	if caps.ServerVersion != "" {
+		return caps.ServerVersion
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := newBeadHubClient(ctx, cfg.BeadhubURL).Version(ctx)
+	if err != nil {
+		return ""
+	}
+	return resp.Version
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestParseBdVersion(t *testing.T) {
+	cases := map[string]string{
+		"bd version 0.29.0 (dev)\n": "0.29.0",
+		"bd version v0.30.1":        "0.30.1",
+		"0.28.2\n":                  "0.28.2",
+		"":                          "",
+	}
+	for in, want := range cases {
+		if got := parseBdVersion(in); got != want {
+			t.Errorf("parseBdVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		cmp  int
+		ok   bool
+	}{
+		{"0.29.0", "0.29.0", 0, true},
+		{"0.29", "0.29.0", 0, true},
+		{"v0.30.0", "0.29.9", 1, true},
+		{"0.9.0", "0.10.0", -1, true},
+		{"1.2.0-rc1", "1.2.0", 0, true},
+		{"dev", "0.29.0", 0, false},
+		{"", "0.29.0", 0, false},
+	}
+	for _, tc := range cases {
+		cmp, ok := compareVersions(tc.a, tc.b)
+		if cmp != tc.cmp || ok != tc.ok {
+			t.Errorf("compareVersions(%q, %q) = %d, %v; want %d, %v", tc.a, tc.b, cmp, ok, tc.cmp, tc.ok)
+		}
+	}
+}
+
+func TestCompareTeamTooling_FlagsOutdatedMembers(t *testing.T) {
+	result := &DoctorResult{Local: &client.WorkspaceEnv{BdhVersion: "0.5.0"}}
+	compareTeamTooling(result, []client.Workspace{
+		{Alias: "carol", Env: &client.WorkspaceEnv{BdhVersion: "dev", BdVersion: "0.29.0"}},
+		{Alias: "alice", Env: &client.WorkspaceEnv{BdhVersion: "0.5.0", BdVersion: "0.29.0", OS: "linux/amd64", GitBranch: "main", GitCommit: "abc1234"}},
+		{Alias: "bob", Env: &client.WorkspaceEnv{BdhVersion: "0.4.2", BdVersion: "0.28.1", OS: "darwin/arm64"}},
+		{Alias: "dave"},
+	})
+
+	if result.LatestBdh != "0.5.0" || result.LatestBd != "0.29.0" {
+		t.Fatalf("latest = bdh %q bd %q, want 0.5.0 / 0.29.0", result.LatestBdh, result.LatestBd)
+	}
+	if result.Mismatches != 1 {
+		t.Fatalf("Mismatches = %d, want 1", result.Mismatches)
+	}
+	var aliases []string
+	for _, m := range result.Team {
+		aliases = append(aliases, m.Alias)
+	}
+	if got := strings.Join(aliases, ","); got != "alice,bob,carol,dave" {
+		t.Fatalf("team order = %s", got)
+	}
+	if got := strings.Join(result.Team[1].Outdated, ","); got != "bdh,bd" {
+		t.Fatalf("bob outdated = %q, want bdh,bd", got)
+	}
+	if len(result.Team[2].Outdated) != 0 {
+		t.Fatalf("dev build should never be flagged, got %v", result.Team[2].Outdated)
+	}
+
+	out := formatDoctorOutput(result, false)
+	for _, want := range []string{
+		"alice — bdh 0.5.0, bd 0.29.0, linux/amd64, main@abc1234",
+		"bob — bdh 0.4.2, bd 0.28.1, darwin/arm64  ⚠ outdated bdh, bd",
+		"dave — not reported",
+		"1 workspace(s) behind the newest tooling in the team (bdh 0.5.0, bd 0.29.0)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
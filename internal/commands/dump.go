@@ -0,0 +1,419 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :dump writes the project's coordination activity as newline-delimited
+// JSON for data pipelines. Every line is a DumpRecord; the shape of its data
+// depends on the stream and is documented in the command's help. Records
+// are grouped by stream, in the order the streams were given, and oldest
+// first within a stream.
+
+// dumpSchemaVersion is bumped when a record changes incompatibly.
+const dumpSchemaVersion = 1
+
+const defaultDumpWindow = 24 * time.Hour
+
+// Dump streams.
+const (
+	dumpClaims       = "claims"
+	dumpReservations = "reservations"
+	dumpTeam         = "team"
+	dumpMessages     = "messages"
+)
+
+var dumpStreams = []string{dumpClaims, dumpReservations, dumpTeam, dumpMessages}
+
+// maxDumpInboxPages bounds the inbox walk for the messages stream.
+const maxDumpInboxPages = 50
+
+// dumpInboxPageSize is the inbox page size for the messages stream.
+const dumpInboxPageSize = 100
+
+// DumpRecord is one line of a dump.
+type DumpRecord struct {
+	SchemaVersion int    `json:"schema_version"`
+	Stream        string `json:"stream"`
+	Project       string `json:"project"`
+	At            string `json:"at"`
+	Data          any    `json:"data"`
+}
+
+// DumpClaim is a claims record. Event is claim, jump_in or close from the
+// activity feed, or active for a claim still held when the server has no
+// activity feed.
+type DumpClaim struct {
+	Event       string `json:"event"`
+	BeadID      string `json:"bead_id"`
+	Alias       string `json:"alias"`
+	HumanName   string `json:"human_name,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Detail      string `json:"detail,omitempty"`
+}
+
+// DumpReservation is a reservations record.
+type DumpReservation struct {
+	Event       string `json:"event"` // acquired or conflict
+	Path        string `json:"path"`
+	HolderAlias string `json:"holder_alias"`
+	RequestedBy string `json:"requested_by,omitempty"`
+	BeadID      string `json:"bead_id,omitempty"`
+}
+
+// DumpTeamMember is a team record: a workspace seen within the window.
+type DumpTeamMember struct {
+	WorkspaceID string   `json:"workspace_id"`
+	Alias       string   `json:"alias"`
+	HumanName   string   `json:"human_name,omitempty"`
+	Role        string   `json:"role,omitempty"`
+	Status      string   `json:"status"`
+	FocusApexID string   `json:"focus_apex_id,omitempty"`
+	Claims      []string `json:"claims"`
+}
+
+// DumpMessage is a messages record: mail received by this workspace.
+type DumpMessage struct {
+	MessageID     string `json:"message_id"`
+	FromAlias     string `json:"from_alias"`
+	FromWorkspace string `json:"from_workspace"`
+	ToWorkspace   string `json:"to_workspace"`
+	Subject       string `json:"subject,omitempty"`
+	Body          string `json:"body"`
+	Priority      string `json:"priority,omitempty"`
+	ThreadID      string `json:"thread_id,omitempty"`
+	Read          bool   `json:"read"`
+}
+
+var (
+	dumpStreamsFlag []string
+	dumpSince       time.Duration
+	dumpOut         string
+)
+
+var dumpCmd = &cobra.Command{
+	Use:   ":dump",
+	Short: "Export coordination activity as JSONL for data pipelines",
+	Long: `Write the project's coordination activity as newline-delimited JSON,
+one record per line, for loading into a warehouse.
+
+Every line has the same envelope:
+
+  {"schema_version": 1, "stream": "claims", "project": "<slug>",
+   "at": "<RFC 3339 time of the record>", "data": {...}}
+
+data depends on the stream:
+
+  claims        event (claim, jump_in, close; active when the server has no
+                activity feed), bead_id, alias, human_name, workspace_id,
+                title, detail
+  reservations  event (acquired, conflict), path, holder_alias,
+                requested_by, bead_id
+  team          workspace_id, alias, human_name, role, status,
+                focus_apex_id, claims (bead IDs); at is the last time seen
+  messages      message_id, from_alias, from_workspace, to_workspace,
+                subject, body, priority, thread_id, read - mail received by
+                this workspace
+
+Records are grouped by stream in the order given, oldest first. Fields are
+only ever added within a schema_version.
+
+Examples:
+  bdh :dump --out dump.jsonl
+  bdh :dump --streams claims,reservations --since 168h --out week.jsonl
+  bdh :dump --streams team | jq .data.alias`,
+	Args: cobra.NoArgs,
+	RunE: runDump,
+}
+
+func init() {
+	dumpCmd.Flags().StringSliceVar(&dumpStreamsFlag, "streams", dumpStreams, "Streams to export ("+strings.Join(dumpStreams, ", ")+")")
+	dumpCmd.Flags().DurationVar(&dumpSince, "since", defaultDumpWindow, "Export records within this long")
+	dumpCmd.Flags().StringVar(&dumpOut, "out", "-", "File to write (- for stdout)")
+}
+
+func runDump(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+	if dumpSince <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	streams, err := parseDumpStreams(dumpStreamsFlag)
+	if err != nil {
+		return err
+	}
+
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+	records, err := collectDump(ctx, cfg, c, streams, time.Now().Add(-dumpSince))
+	if err != nil {
+		return err
+	}
+
+	if dumpOut == "" || dumpOut == "-" {
+		return writeDump(os.Stdout, records)
+	}
+	f, err := os.Create(dumpOut)
+	if err != nil {
+		return err
+	}
+	if err := writeDump(f, records); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s to %s\n", dumpCounts(records, streams), dumpOut)
+	return nil
+}
+
+// parseDumpStreams checks and dedupes the --streams values, keeping their
+// order. Values may be comma-separated.
+func parseDumpStreams(values []string) ([]string, error) {
+	var streams []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if !containsString(dumpStreams, name) {
+				return nil, fmt.Errorf("unknown stream %q (use %s)", name, strings.Join(dumpStreams, ", "))
+			}
+			if !containsString(streams, name) {
+				streams = append(streams, name)
+			}
+		}
+	}
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("--streams is empty (use %s)", strings.Join(dumpStreams, ", "))
+	}
+	return streams, nil
+}
+
+// collectDump fetches the records of streams since the given time.
+func collectDump(ctx context.Context, cfg *config.Config, c BeadHubAPI, streams []string, since time.Time) ([]DumpRecord, error) {
+	var workspaces []client.Workspace
+	if containsString(streams, dumpTeam) || (containsString(streams, dumpClaims) && !serverSupports(ctx, cfg, featureActivity)) {
+		wctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.Workspaces(wctx, &client.WorkspacesRequest{IncludeClaims: true, Limit: maxWorkspaceQueryLimit})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("fetching workspaces: %w", err)
+		}
+		workspaces = resp.Workspaces
+	}
+
+	var records []DumpRecord
+	for _, stream := range streams {
+		var batch []DumpRecord
+		var err error
+		switch stream {
+		case dumpClaims:
+			batch, err = dumpClaimRecords(ctx, cfg, c, workspaces, since)
+		case dumpReservations:
+			batch, err = dumpReservationRecords(ctx, cfg, c, since)
+		case dumpTeam:
+			batch = dumpTeamRecords(workspaces, since)
+		case dumpMessages:
+			batch, err = dumpMessageRecords(ctx, cfg, c, since)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", stream, err)
+		}
+		sortDumpRecords(batch)
+		for i := range batch {
+			batch[i].SchemaVersion = dumpSchemaVersion
+			batch[i].Stream = stream
+			batch[i].Project = cfg.ProjectSlug
+		}
+		records = append(records, batch...)
+	}
+	return records, nil
+}
+
+func dumpClaimRecords(ctx context.Context, cfg *config.Config, c BeadHubAPI, workspaces []client.Workspace, since time.Time) ([]DumpRecord, error) {
+	var records []DumpRecord
+	if serverSupports(ctx, cfg, featureActivity) {
+		actx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.Activity(actx, &client.ActivityRequest{Since: since.UTC().Format(time.RFC3339)})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("fetching activity: %w", err)
+		}
+		for _, e := range resp.Events {
+			if e.Kind != client.ActivityClaim && e.Kind != client.ActivityJumpIn && e.Kind != client.ActivityClose {
+				continue
+			}
+			records = append(records, DumpRecord{At: e.At, Data: DumpClaim{
+				Event: e.Kind, BeadID: e.BeadID, Alias: e.Alias, HumanName: e.HumanName, Detail: e.Detail,
+			}})
+		}
+		return records, nil
+	}
+	for _, ws := range workspaces {
+		for _, claim := range ws.Claims {
+			if !dumpWithin(claim.ClaimedAt, since) {
+				continue
+			}
+			records = append(records, DumpRecord{At: claim.ClaimedAt, Data: DumpClaim{
+				Event: "active", BeadID: claim.BeadID, Alias: ws.Alias, HumanName: ws.HumanName,
+				WorkspaceID: ws.WorkspaceID, Title: claim.Title,
+			}})
+		}
+	}
+	return records, nil
+}
+
+func dumpReservationRecords(ctx context.Context, cfg *config.Config, c BeadHubAPI, since time.Time) ([]DumpRecord, error) {
+	events, _, warning, err := loadReservationEvents(ctx, cfg, c, since, false)
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	var records []DumpRecord
+	for _, e := range events {
+		if !dumpWithin(e.At, since) {
+			continue
+		}
+		records = append(records, DumpRecord{At: e.At, Data: DumpReservation{
+			Event: e.Kind, Path: e.Path, HolderAlias: e.HolderAlias, RequestedBy: e.RequestedBy, BeadID: e.BeadID,
+		}})
+	}
+	return records, nil
+}
+
+func dumpTeamRecords(workspaces []client.Workspace, since time.Time) []DumpRecord {
+	var records []DumpRecord
+	for _, ws := range workspaces {
+		if !dumpWithin(ws.LastSeen, since) {
+			continue
+		}
+		member := DumpTeamMember{
+			WorkspaceID: ws.WorkspaceID, Alias: ws.Alias, HumanName: ws.HumanName, Role: ws.Role,
+			Status: ws.Status, FocusApexID: ws.FocusApexID, Claims: []string{},
+		}
+		for _, claim := range ws.Claims {
+			member.Claims = append(member.Claims, claim.BeadID)
+		}
+		records = append(records, DumpRecord{At: ws.LastSeen, Data: member})
+	}
+	return records
+}
+
+// dumpMessageRecords walks this workspace's inbox, read and archived mail
+// included, back to since.
+func dumpMessageRecords(ctx context.Context, cfg *config.Config, c BeadHubAPI, since time.Time) ([]DumpRecord, error) {
+	var records []DumpRecord
+	seen := make(map[string]bool)
+	before := ""
+	for range maxDumpInboxPages {
+		ictx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.Inbox(ictx, &client.InboxRequest{
+			WorkspaceID: cfg.WorkspaceID, Limit: dumpInboxPageSize, IncludeArchived: true, Before: before,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("fetching inbox: %w", err)
+		}
+		reachedSince := false
+		for _, m := range resp.Messages {
+			if seen[m.MessageID] {
+				continue
+			}
+			seen[m.MessageID] = true
+			if !dumpWithin(m.CreatedAt, since) {
+				reachedSince = true
+				continue
+			}
+			records = append(records, DumpRecord{At: m.CreatedAt, Data: DumpMessage{
+				MessageID: m.MessageID, FromAlias: m.FromAlias, FromWorkspace: m.FromWorkspace, ToWorkspace: cfg.WorkspaceID,
+				Subject: m.Subject, Body: m.Body, Priority: m.Priority, ThreadID: m.ThreadID, Read: m.Read,
+			}})
+		}
+		if !resp.HasMore || reachedSince || len(resp.Messages) == 0 {
+			break
+		}
+		next := resp.Messages[len(resp.Messages)-1].MessageID
+		if next == before {
+			break // The server ignores the cursor
+		}
+		before = next
+	}
+	return records, nil
+}
+
+// dumpWithin reports whether timestamp is at or after since. Records
+// without a readable time are kept.
+func dumpWithin(timestamp string, since time.Time) bool {
+	t, ok := parseTimeBestEffort(timestamp)
+	return !ok || !t.Before(since)
+}
+
+// sortDumpRecords orders records oldest first, those without a readable
+// time last; ties keep the server's order.
+func sortDumpRecords(records []DumpRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		ti, iok := parseTimeBestEffort(records[i].At)
+		tj, jok := parseTimeBestEffort(records[j].At)
+		if iok != jok {
+			return iok
+		}
+		return iok && ti.Before(tj)
+	})
+}
+
+func writeDump(w io.Writer, records []DumpRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpCounts summarizes records per stream: "5 records (claims 3, team 2)".
+func dumpCounts(records []DumpRecord, streams []string) string {
+	counts := make(map[string]int)
+	for _, r := range records {
+		counts[r.Stream]++
+	}
+	var parts []string
+	for _, stream := range streams {
+		parts = append(parts, fmt.Sprintf("%s %d", stream, counts[stream]))
+	}
+	noun := "records"
+	if len(records) == 1 {
+		noun = "record"
+	}
+	return fmt.Sprintf("%d %s (%s)", len(records), noun, strings.Join(parts, ", "))
+}
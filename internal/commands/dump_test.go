@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestCollectDump_Streams(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	fake := clienttest.New()
+	fake.EnableFeatures(featureActivity, featureReservationHistory)
+	fake.Now = func() time.Time { return now.Add(-time.Hour) }
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-a", Alias: "alice", Status: "active", LastSeen: now.Add(-time.Minute).Format(time.RFC3339),
+		Claims: []client.Claim{{BeadID: "bd-1"}}})
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-old", Alias: "gone", LastSeen: now.Add(-72 * time.Hour).Format(time.RFC3339)})
+	fake.AddEvents(
+		client.ActivityEvent{Kind: client.ActivityClaim, Alias: "alice", BeadID: "bd-1", At: "2026-03-02T09:00:00Z"},
+		client.ActivityEvent{Kind: client.ActivityEscalation, Alias: "alice", At: "2026-03-02T09:30:00Z"},
+		client.ActivityEvent{Kind: client.ActivityClose, Alias: "alice", BeadID: "bd-0", At: "2026-03-02T08:00:00Z"},
+	)
+	fake.AddReservationEvents(client.ReservationEvent{Path: "web/app.ts", Kind: client.ReservationEventConflict, HolderAlias: "alice", RequestedBy: "me", At: "2026-03-02T10:00:00Z"})
+	if _, err := fake.Send(context.Background(), &client.SendRequest{FromWorkspace: "ws-a", FromAlias: "alice", ToWorkspace: "ws-me", Body: "rebased <main>"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := withFakeBeadHub(fake)
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", ProjectSlug: "demo", WorkspaceID: "ws-me"}
+	streams, err := parseDumpStreams([]string{"claims,team", "messages", "reservations", "team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := collectDump(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), streams, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeDump(&buf, records); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("bad line %q: %v", line, err)
+		}
+		lines = append(lines, rec)
+	}
+	var got []string
+	for _, rec := range lines {
+		data := rec["data"].(map[string]any)
+		key := rec["stream"].(string) + ":"
+		switch rec["stream"] {
+		case dumpClaims:
+			key += data["event"].(string) + " " + data["bead_id"].(string)
+		case dumpTeam:
+			key += data["alias"].(string)
+		case dumpMessages:
+			key += data["from_alias"].(string) + " " + data["body"].(string)
+		case dumpReservations:
+			key += data["event"].(string) + " " + data["path"].(string)
+		}
+		if rec["project"] != "demo" || rec["schema_version"] != float64(dumpSchemaVersion) {
+			t.Errorf("bad envelope %v", rec)
+		}
+		got = append(got, key)
+	}
+	want := "claims:close bd-0|claims:claim bd-1|team:alice|messages:alice rebased <main>|reservations:conflict web/app.ts"
+	if strings.Join(got, "|") != want {
+		t.Errorf("records:\n%s\nwant:\n%s", strings.Join(got, "|"), want)
+	}
+	if !strings.Contains(buf.String(), "rebased <main>") {
+		t.Error("HTML should not be escaped in the dump")
+	}
+	if got := dumpCounts(records, streams); got != "5 records (claims 2, team 1, messages 1, reservations 1)" {
+		t.Errorf("dumpCounts = %q", got)
+	}
+}
+
+func TestCollectDump_ClaimsWithoutActivityFeed(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	fake := clienttest.New()
+	fake.EnableFeatures(featureReservationHistory)
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-a", Alias: "alice", Claims: []client.Claim{
+		{BeadID: "bd-2", Title: "New", ClaimedAt: now.Add(-time.Hour).Format(time.RFC3339)},
+		{BeadID: "bd-1", ClaimedAt: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+	}})
+	ctx := withFakeBeadHub(fake)
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", ProjectSlug: "demo", WorkspaceID: "ws-me"}
+	records, err := collectDump(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), []string{dumpClaims}, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected only the claim taken in the window, got %+v", records)
+	}
+	if claim := records[0].Data.(DumpClaim); claim.Event != "active" || claim.BeadID != "bd-2" || claim.WorkspaceID != "ws-a" || claim.Title != "New" {
+		t.Errorf("unexpected claim %+v", claim)
+	}
+	if calls := fake.Calls("Activity"); len(calls) != 0 {
+		t.Errorf("the activity feed is not supported, got %d calls", len(calls))
+	}
+}
+
+func TestParseDumpStreams(t *testing.T) {
+	if _, err := parseDumpStreams([]string{"claims,locks"}); err == nil || !strings.Contains(err.Error(), `unknown stream "locks"`) {
+		t.Errorf("expected an unknown stream error, got %v", err)
+	}
+	if _, err := parseDumpStreams([]string{" , "}); err == nil {
+		t.Error("expected an error for empty --streams")
+	}
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Read-only bd commands start before the pre-flight check instead of after
+// it, so the server round trips (pre-flight, team status, locks) overlap
+// with bd's own run. Reads never change issues, so a rejection that arrives
+// after bd started is only reported as a warning.
+
+// earlyBdRun is a bd invocation started before the pre-flight check.
+type earlyBdRun struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	result *bd.Result
+	err    error
+}
+
+// startsBeforePreflight reports whether bd can run concurrently with the
+// pre-flight check: a read-only command that bdh prints itself, not joining
+// a bead and not replaced by a ready view.
+func startsBeforePreflight(cfg *config.Config, args []string, jsonMode, hasJumpIn bool, readyView string) bool {
+	if hasJumpIn || readyView != "" || !bd.IsReadOnlyCommand(args) || highRiskCommand(cfg, args) != "" {
+		return false
+	}
+	return !useBdPTY(cfg, jsonMode, args)
+}
+
+func startEarlyBdRun(ctx context.Context, runner *bd.Runner, args []string) *earlyBdRun {
+	ctx, cancel := context.WithCancel(ctx)
+	run := &earlyBdRun{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(run.done)
+		run.result, run.err = runner.Run(ctx, args)
+	}()
+	return run
+}
+
+// wait returns bd's result once it has finished.
+func (r *earlyBdRun) wait() (*bd.Result, error) {
+	<-r.done
+	return r.result, r.err
+}
+
+// stop kills bd if it is still running (the command ended without using its
+// output) and waits for it to exit.
+func (r *earlyBdRun) stop() {
+	r.cancel()
+	<-r.done
+}
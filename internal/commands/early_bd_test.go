@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestPassthrough_ReadRunsBdBeforePreflightAnswers(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, "")
+
+	// A fake bd that leaves a marker, so the server can tell bd already ran
+	binDir := t.TempDir()
+	marker := filepath.Join(binDir, "bd-ran")
+	script := "#!/bin/sh\ntouch \"$BDH_TEST_MARKER\"\necho \"bd-1 listed\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("BDH_TEST_MARKER", marker)
+
+	var ranFirst atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/bdh/command" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if _, err := os.Stat(marker); err == nil {
+				ranFirst.Store(true)
+				break
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"approved": false, "reason": "policy says no"})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	cfg.Save()
+
+	result, err := runPassthrough(context.Background(), []string{"list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ranFirst.Load() {
+		t.Error("bd should start before the pre-flight check answers")
+	}
+	if result.Rejected || !strings.Contains(result.Warning, "policy says no") || result.Stdout != "bd-1 listed\n" {
+		t.Errorf("result: rejected=%v warning=%q stdout=%q", result.Rejected, result.Warning, result.Stdout)
+	}
+}
+
+func TestStartsBeforePreflight(t *testing.T) {
+	cfg := &config.Config{}
+	if !startsBeforePreflight(cfg, []string{"show", "bd-1"}, false, false, "") {
+		t.Error("show should start early")
+	}
+	for _, tc := range []struct {
+		args      []string
+		jumpIn    bool
+		readyView string
+	}{
+		{args: []string{"update", "bd-1", "--status", "in_progress"}},
+		{args: []string{"close", "bd-1"}},
+		{args: []string{"show", "bd-1"}, jumpIn: true},
+		{args: []string{"ready"}, readyView: readyViewMine},
+	} {
+		if startsBeforePreflight(cfg, tc.args, false, tc.jumpIn, tc.readyView) {
+			t.Errorf("%v (jump-in %v, view %q) should wait for the pre-flight check", tc.args, tc.jumpIn, tc.readyView)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// Every sync reports the tooling the workspace runs (bdh and bd versions, OS,
+// git branch and commit) so the dashboard and :doctor --team can point out
+// agents on stale tooling. Asking bd for its version costs a process, so the
+// answer is cached in .beadhub-cache/bd-version.json for bdVersionTTL.
+
+const (
+	bdVersionTTL     = time.Hour
+	envProbeTimeout  = 2 * time.Second
+	unknownToolValue = "unknown"
+)
+
+type cachedBdVersion struct {
+	Version   string `json:"version"`
+	CheckedAt string `json:"checked_at"`
+}
+
+// currentWorkspaceEnv describes this workspace's tooling, best-effort.
+func currentWorkspaceEnv(ctx context.Context) *client.WorkspaceEnv {
+	repoRoot := currentRepoRoot(ctx)
+	return &client.WorkspaceEnv{
+		BdhVersion: versionInfo.version,
+		BdVersion:  cachedBdVersionBestEffort(ctx, time.Now()),
+		OS:         runtime.GOOS + "/" + runtime.GOARCH,
+		GitBranch:  currentGitBranch(ctx, repoRoot),
+		GitCommit:  currentGitCommit(ctx, repoRoot),
+	}
+}
+
+func currentGitCommit(ctx context.Context, repoRoot string) string {
+	ctx, cancel := context.WithTimeout(ctx, 750*time.Millisecond)
+	defer cancel()
+
+	args := []string{"rev-parse", "--short", "HEAD"}
+	if repoRoot != "" {
+		args = append([]string{"-C", repoRoot}, args...)
+	}
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func bdVersionCachePath() (string, error) {
+	return cacheFilePath("bd-version.json")
+}
+
+// cachedBdVersionBestEffort returns bd's version, asking bd at most once per
+// bdVersionTTL. Returns "" when bd cannot say.
+func cachedBdVersionBestEffort(ctx context.Context, now time.Time) string {
+	path, err := bdVersionCachePath()
+	if err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			var cached cachedBdVersion
+			if json.Unmarshal(data, &cached) == nil {
+				if at, ok := parseTimeBestEffort(cached.CheckedAt); ok && now.Sub(at) < bdVersionTTL && !at.After(now) {
+					return cached.Version
+				}
+			}
+		}
+	}
+
+	version := probeBdVersion(ctx)
+	if path != "" {
+		data, _ := json.Marshal(cachedBdVersion{Version: version, CheckedAt: now.UTC().Format(time.RFC3339)})
+		if ensureCacheDir(path) == nil {
+			_ = os.WriteFile(path, data, 0600)
+		}
+	}
+	return version
+}
+
+func probeBdVersion(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, envProbeTimeout)
+	defer cancel()
+	result, err := defaultBdRunner().Run(ctx, []string{"version"})
+	if err != nil || result.ExitCode != 0 {
+		return ""
+	}
+	return parseBdVersion(result.Stdout)
+}
+
+// parseBdVersion extracts the version from `bd version` output such as
+// "bd version 0.29.0 (dev)".
+func parseBdVersion(output string) string {
+	line := strings.TrimSpace(strings.SplitN(strings.TrimSpace(output), "\n", 2)[0])
+	fields := strings.Fields(line)
+	for i, f := range fields {
+		if f == "version" && i+1 < len(fields) {
+			return strings.TrimPrefix(fields[i+1], "v")
+		}
+	}
+	if len(fields) > 0 {
+		return strings.TrimPrefix(fields[len(fields)-1], "v")
+	}
+	return ""
+}
+
+// compareVersions compares dotted numeric versions ("0.29.1" vs "v0.30").
+// ok is false when either is not a release version (e.g. "dev").
+func compareVersions(a, b string) (cmp int, ok bool) {
+	pa, okA := versionParts(a)
+	pb, okB := versionParts(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+func versionParts(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
@@ -22,6 +22,11 @@ var escalateCmd = &cobra.Command{
 Use this when you're blocked and cannot resolve the issue with other agents.
 A human will review the escalation and respond.
 
+Escalations are tracked locally. bdh reports when one is answered, and
+reminds you when it stays pending past escalations.sla_minutes (default 60)
+in .beadhub, at most once per escalations.reminder_interval_minutes
+(default 30).
+
 Examples:
   bdh :escalate "Blocked on bd-42" "other-agent has had bd-42 for 3 hours"
   bdh :escalate "Need clarification" "Requirements unclear for feature X" --json`,
@@ -42,30 +47,24 @@ type EscalateResult struct {
 }
 
 func runEscalate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	subject := args[0]
 	situation := args[1]
 
-	cfg, err := config.Load()
+	cfg, err := loadWorkspaceConfig()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
-		}
-		return fmt.Errorf("loading config: %w", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid .beadhub config: %w", err)
-	}
-	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 		return err
 	}
 
 	// Notifications are handled by main.go's PrintNotifications
 
-	result, err := createEscalationWithConfig(cfg, subject, situation)
+	result, err := createEscalationWithConfig(ctx, cfg, subject, situation)
 	if err != nil {
 		return err
 	}
+	if err := trackEscalation(subject, result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not record escalation for reminders: %v\n", err)
+	}
 
 	output := formatEscalateOutput(result, escalateJSON)
 	fmt.Print(output)
@@ -73,7 +72,7 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 }
 
 // createEscalationWithConfig creates an escalation using the provided config (for testing).
-func createEscalationWithConfig(cfg *config.Config, subject, situation string) (*EscalateResult, error) {
+func createEscalationWithConfig(ctx context.Context, cfg *config.Config, subject, situation string) (*EscalateResult, error) {
 	if subject == "" {
 		return nil, fmt.Errorf("subject cannot be empty")
 	}
@@ -81,11 +80,11 @@ func createEscalationWithConfig(cfg *config.Config, subject, situation string) (
 		return nil, fmt.Errorf("situation cannot be empty")
 	}
 
-	c, err := newBeadHubClientRequired(cfg.BeadhubURL)
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
 	if err != nil {
 		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	resp, err := c.Escalate(ctx, &client.EscalateRequest{
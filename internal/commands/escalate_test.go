@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -48,7 +49,7 @@ func TestEscalate(t *testing.T) {
 		HumanName:   "Test Human",
 	}
 
-	result, err := createEscalationWithConfig(cfg, "Blocked on bd-42", "Agent not responding")
+	result, err := createEscalationWithConfig(context.Background(), cfg, "Blocked on bd-42", "Agent not responding")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -71,7 +72,7 @@ func TestEscalate_EmptySubject(t *testing.T) {
 		HumanName:   "Test Human",
 	}
 
-	_, err := createEscalationWithConfig(cfg, "", "Some situation")
+	_, err := createEscalationWithConfig(context.Background(), cfg, "", "Some situation")
 	if err == nil {
 		t.Error("expected error for empty subject")
 	}
@@ -90,7 +91,7 @@ func TestEscalate_EmptySituation(t *testing.T) {
 		HumanName:   "Test Human",
 	}
 
-	_, err := createEscalationWithConfig(cfg, "Some subject", "")
+	_, err := createEscalationWithConfig(context.Background(), cfg, "Some subject", "")
 	if err == nil {
 		t.Error("expected error for empty situation")
 	}
@@ -117,7 +118,7 @@ func TestEscalate_ServerError(t *testing.T) {
 		HumanName:   "Test Human",
 	}
 
-	_, err := createEscalationWithConfig(cfg, "Subject", "Situation")
+	_, err := createEscalationWithConfig(context.Background(), cfg, "Subject", "Situation")
 	if err == nil {
 		t.Error("expected error for server error")
 	}
@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Escalations created with :escalate are recorded locally so that
+// PrintNotifications can poll their status: answered escalations are reported
+// once and dropped, and ones pending past the SLA trigger a reminder at most
+// once per reminder interval. Each escalation is polled at most once per
+// escalationPollInterval, however many commands run in between.
+
+const (
+	// maxTrackedEscalationPolls bounds the status requests made per command.
+	maxTrackedEscalationPolls = 5
+	// escalationPollInterval is the minimum time between polls of one
+	// escalation.
+	escalationPollInterval = 2 * time.Minute
+)
+
+// TrackedEscalation is a locally recorded escalation.
+type TrackedEscalation struct {
+	EscalationID   string `json:"escalation_id"`
+	Subject        string `json:"subject"`
+	CreatedAt      string `json:"created_at"`
+	Status         string `json:"status"`
+	LastRemindedAt string `json:"last_reminded_at,omitempty"`
+	LastCheckedAt  string `json:"last_checked_at,omitempty"`
+}
+
+// EscalationNotice is an escalation update shown with notifications.
+type EscalationNotice struct {
+	EscalationID string
+	Subject      string
+	Status       string        // server status ("pending" when overdue)
+	Response     string        // human response, if answered
+	PendingFor   time.Duration // only set for overdue reminders
+	SLA          time.Duration
+}
+
+func escalationsPath() (string, error) {
+	return cacheFilePath("escalations.json")
+}
+
+func loadTrackedEscalations(path string) ([]TrackedEscalation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var tracked []TrackedEscalation
+	if err := json.Unmarshal(data, &tracked); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return tracked, nil
+}
+
+func saveTrackedEscalations(path string, tracked []TrackedEscalation) error {
+	if len(tracked) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONAtomic(path, tracked)
+}
+
+// trackEscalation records a newly created escalation. Best-effort: tracking
+// failures never fail the escalation itself.
+func trackEscalation(subject string, result *EscalateResult) error {
+	path, err := escalationsPath()
+	if err != nil {
+		return err
+	}
+	tracked, err := loadTrackedEscalations(path)
+	if err != nil {
+		return err
+	}
+	createdAt := result.CreatedAt
+	if createdAt == "" {
+		createdAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	tracked = append(tracked, TrackedEscalation{
+		EscalationID: result.EscalationID,
+		Subject:      subject,
+		CreatedAt:    createdAt,
+		Status:       result.Status,
+	})
+	return saveTrackedEscalations(path, tracked)
+}
+
+// checkTrackedEscalations polls tracked escalations and returns the notices to
+// show. Answered escalations are dropped from tracking; reminders update
+// LastRemindedAt so they repeat at most once per interval, and polls update
+// LastCheckedAt so each escalation is polled at most once per
+// escalationPollInterval.
+func checkTrackedEscalations(ctx context.Context, cfg *config.Config, c BeadHubAPI, now time.Time) []EscalationNotice {
+	path, err := escalationsPath()
+	if err != nil {
+		return nil
+	}
+	tracked, err := loadTrackedEscalations(path)
+	if err != nil || len(tracked) == 0 || !escalationPollDue(tracked, now) {
+		return nil
+	}
+
+	sla := cfg.EscalationSLA()
+	interval := cfg.EscalationReminderInterval()
+
+	var notices []EscalationNotice
+	var kept []TrackedEscalation
+	polls := 0
+	for _, t := range tracked {
+		if polls >= maxTrackedEscalationPolls || ctx.Err() != nil || !escalationCheckDue(t, now) {
+			kept = append(kept, t)
+			continue
+		}
+		polls++
+
+		esc, err := c.GetEscalation(ctx, t.EscalationID)
+		if err != nil {
+			if unreachable(ctx, err) {
+				kept = append(kept, t)
+				continue
+			}
+			t.LastCheckedAt = now.UTC().Format(time.RFC3339)
+			var clientErr *client.Error
+			if errors.As(err, &clientErr) && clientErr.StatusCode == 404 {
+				continue // gone on the server; stop tracking
+			}
+			kept = append(kept, t)
+			continue
+		}
+
+		t.LastCheckedAt = now.UTC().Format(time.RFC3339)
+		notice, keep := evaluateTrackedEscalation(&t, esc, now, sla, interval)
+		if notice != nil {
+			notices = append(notices, *notice)
+		}
+		if keep {
+			kept = append(kept, t)
+		}
+	}
+
+	_ = saveTrackedEscalations(path, kept)
+	return notices
+}
+
+// escalationCheckDue reports whether t was last polled at least
+// escalationPollInterval ago (or never).
+func escalationCheckDue(t TrackedEscalation, now time.Time) bool {
+	last, ok := parseTimeBestEffort(t.LastCheckedAt)
+	return !ok || now.Sub(last) >= escalationPollInterval
+}
+
+// escalationPollDue reports whether any tracked escalation is due a poll, so
+// commands in between leave the file alone.
+func escalationPollDue(tracked []TrackedEscalation, now time.Time) bool {
+	for _, t := range tracked {
+		if escalationCheckDue(t, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateTrackedEscalation decides what to show for one escalation given its
+// server state. It updates t in place and reports whether to keep tracking it.
+func evaluateTrackedEscalation(t *TrackedEscalation, esc *client.Escalation, now time.Time, sla, interval time.Duration) (*EscalationNotice, bool) {
+	status := strings.ToLower(esc.Status)
+	t.Status = status
+	if status != "" && status != "pending" {
+		return &EscalationNotice{
+			EscalationID: t.EscalationID,
+			Subject:      t.Subject,
+			Status:       status,
+			Response:     esc.Response,
+		}, false
+	}
+
+	created, ok := parseTimeBestEffort(t.CreatedAt)
+	if !ok {
+		return nil, true
+	}
+	pendingFor := now.Sub(created)
+	if pendingFor < sla {
+		return nil, true
+	}
+	if last, ok := parseTimeBestEffort(t.LastRemindedAt); ok && now.Sub(last) < interval {
+		return nil, true
+	}
+	t.LastRemindedAt = now.UTC().Format(time.RFC3339)
+	return &EscalationNotice{
+		EscalationID: t.EscalationID,
+		Subject:      t.Subject,
+		Status:       "pending",
+		PendingFor:   pendingFor,
+		SLA:          sla,
+	}, true
+}
+
+// formatEscalationNotice renders one notice as a notification line.
+func formatEscalationNotice(n EscalationNotice) string {
+	if n.Status != "pending" {
+		line := fmt.Sprintf("- **ESCALATION**: \"%s\" was %s", n.Subject, n.Status)
+		if n.Response != "" {
+			line += fmt.Sprintf("\n  → Response: %s", truncateText(n.Response, 200))
+		}
+		return line
+	}
+	return fmt.Sprintf("- **ESCALATION**: \"%s\" has been pending %s (SLA %s)\n"+
+		"  → Re-escalate with more context: `bdh :escalate \"%s\" \"<what changed>\"`\n"+
+		"  → Or fall back: release the blocked bead and pick other work with `bdh ready`",
+		n.Subject, formatDuration(int(n.PendingFor.Seconds())), formatDuration(int(n.SLA.Seconds())), n.Subject)
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestEvaluateTrackedEscalation(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	sla, interval := time.Hour, 30*time.Minute
+	pending := &client.Escalation{Status: "pending"}
+
+	// Within SLA: nothing to show.
+	tracked := TrackedEscalation{Subject: "s", CreatedAt: now.Add(-30 * time.Minute).Format(time.RFC3339)}
+	if n, keep := evaluateTrackedEscalation(&tracked, pending, now, sla, interval); n != nil || !keep {
+		t.Errorf("within SLA: notice=%v keep=%v", n, keep)
+	}
+
+	// Past SLA: remind once, then stay quiet until the interval passes.
+	tracked = TrackedEscalation{Subject: "s", CreatedAt: now.Add(-2 * time.Hour).Format(time.RFC3339)}
+	n, keep := evaluateTrackedEscalation(&tracked, pending, now, sla, interval)
+	if n == nil || !keep || n.PendingFor != 2*time.Hour {
+		t.Fatalf("overdue: notice=%+v keep=%v", n, keep)
+	}
+	if n, _ := evaluateTrackedEscalation(&tracked, pending, now.Add(10*time.Minute), sla, interval); n != nil {
+		t.Errorf("should not nag again within the interval")
+	}
+	if n, _ := evaluateTrackedEscalation(&tracked, pending, now.Add(31*time.Minute), sla, interval); n == nil {
+		t.Errorf("should nag again after the interval")
+	}
+
+	// Answered: report and stop tracking.
+	n, keep = evaluateTrackedEscalation(&tracked, &client.Escalation{Status: "responded", Response: "go ahead"}, now, sla, interval)
+	if n == nil || keep || n.Response != "go ahead" {
+		t.Errorf("answered: notice=%+v keep=%v", n, keep)
+	}
+}
+
+func TestCheckTrackedEscalations(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	t.Chdir(t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/escalations":
+			json.NewEncoder(w).Encode(map[string]any{
+				"escalation_id": "esc-1", "status": "pending",
+				"created_at": time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339),
+			})
+		case "/v1/escalations/esc-1":
+			json.NewEncoder(w).Encode(map[string]any{"escalation_id": "esc-1", "status": "pending"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab", BeadhubURL: server.URL, Alias: "alice"}
+	result, err := createEscalationWithConfig(context.Background(), cfg, "Blocked on bd-42", "no response")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := trackEscalation("Blocked on bd-42", result); err != nil {
+		t.Fatalf("track: %v", err)
+	}
+
+	c := client.New(server.URL)
+	notices := checkTrackedEscalations(context.Background(), cfg, c, time.Now())
+	if len(notices) != 1 {
+		t.Fatalf("expected one reminder, got %+v", notices)
+	}
+	out := FormatNotifications(&NotificationContext{Escalations: notices}, "")
+	if !strings.Contains(out, `**ESCALATION**: "Blocked on bd-42" has been pending 3h`) {
+		t.Errorf("unexpected notification:\n%s", out)
+	}
+
+	// Reminder is rate-limited across commands via the saved state.
+	if again := checkTrackedEscalations(context.Background(), cfg, c, time.Now()); len(again) != 0 {
+		t.Errorf("expected no repeat reminder, got %+v", again)
+	}
+}
+
+func TestCheckTrackedEscalations_PollsAtMostOncePerInterval(t *testing.T) {
+	t.Chdir(t.TempDir())
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		json.NewEncoder(w).Encode(map[string]any{"escalation_id": "esc-1", "status": "pending"})
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	if err := trackEscalation("Blocked on bd-42", &EscalateResult{EscalationID: "esc-1", Status: "pending", CreatedAt: now.UTC().Format(time.RFC3339)}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{BeadhubURL: server.URL, Alias: "alice"}
+	c := client.New(server.URL)
+
+	for _, at := range []time.Time{now, now.Add(time.Minute), now.Add(escalationPollInterval + time.Minute)} {
+		checkTrackedEscalations(context.Background(), cfg, c, at)
+	}
+	if polls != 2 {
+		t.Errorf("expected 2 polls (the second command is within the interval), got %d", polls)
+	}
+}
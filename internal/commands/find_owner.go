@@ -0,0 +1,286 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :find-owner suggests whom to ask about a file or directory. Agents score
+// for each capability tag matching the path (its directories, words, and
+// language) and for each time they reserved it, or a file next to it, within
+// the window.
+
+const (
+	defaultFindOwnerWindow = 30 * 24 * time.Hour
+	defaultFindOwnerLimit  = 5
+
+	ownerTagScore       = 3
+	ownerPathScore      = 2
+	ownerNeighbourScore = 1
+)
+
+// extensionTags maps file extensions to the language tag agents would use.
+var extensionTags = map[string]string{
+	".py":   "python",
+	".go":   "go",
+	".js":   "javascript",
+	".jsx":  "javascript",
+	".ts":   "typescript",
+	".tsx":  "typescript",
+	".rb":   "ruby",
+	".rs":   "rust",
+	".java": "java",
+	".kt":   "kotlin",
+	".sql":  "sql",
+	".sh":   "shell",
+	".tf":   "terraform",
+	".css":  "css",
+	".html": "html",
+}
+
+// OwnerCandidate is one agent suggested by :find-owner.
+type OwnerCandidate struct {
+	Alias        string   `json:"alias"`
+	Score        int      `json:"score"`
+	MatchedTags  []string `json:"matched_tags,omitempty"`
+	Reservations int      `json:"reservations,omitempty"`
+	Neighbours   int      `json:"neighbours,omitempty"`
+	LastAt       string   `json:"last_at,omitempty"`
+}
+
+// FindOwnerResult is the output of :find-owner.
+type FindOwnerResult struct {
+	Path       string           `json:"path"`
+	Source     string           `json:"source"`
+	Candidates []OwnerCandidate `json:"candidates"`
+	Warning    string           `json:"warning,omitempty"`
+}
+
+var (
+	findOwnerSince time.Duration
+	findOwnerLimit int
+	findOwnerJSON  bool
+)
+
+var findOwnerCmd = &cobra.Command{
+	Use:   ":find-owner <path>",
+	Short: "Suggest whom to ask about a file or directory",
+	Long: `Suggest the agents most likely to know a file or directory, from the
+capability tags they advertise (bdh :tags) and who has reserved it, or files
+next to it, recently.
+
+Paths are relative to the repository root; end a directory with / to ask
+about everything under it.
+
+Examples:
+  bdh :find-owner src/api/auth.py
+  bdh :find-owner frontend/ --since 2160h
+  bdh :find-owner src/api/auth.py --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFindOwner,
+}
+
+func init() {
+	findOwnerCmd.Flags().DurationVar(&findOwnerSince, "since", defaultFindOwnerWindow, "Only count reservations within this long")
+	findOwnerCmd.Flags().IntVar(&findOwnerLimit, "limit", defaultFindOwnerLimit, "Maximum number of agents to suggest")
+	findOwnerCmd.Flags().BoolVar(&findOwnerJSON, "json", false, "Output as JSON")
+}
+
+func runFindOwner(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if findOwnerSince <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	target, err := normalizeOwnerPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	result, err := findOwner(ctx, cfg, c, target, time.Now().Add(-findOwnerSince))
+	if err != nil {
+		return err
+	}
+	if findOwnerLimit > 0 && len(result.Candidates) > findOwnerLimit {
+		result.Candidates = result.Candidates[:findOwnerLimit]
+	}
+	fmt.Print(formatFindOwnerOutput(result, findOwnerJSON))
+	return nil
+}
+
+// normalizeOwnerPath cleans a path argument into reservation-key form.
+func normalizeOwnerPath(p string) (string, error) {
+	isDir := strings.HasSuffix(p, "/")
+	p = strings.TrimPrefix(path.Clean(strings.ReplaceAll(strings.TrimSpace(p), "\\", "/")), "./")
+	if isDir {
+		p += "/"
+	}
+	return normalizeReservationKey(p)
+}
+
+// findOwner gathers the team's tags and the reservation history and ranks
+// the agents for target.
+func findOwner(ctx context.Context, cfg *config.Config, c BeadHubAPI, target string, since time.Time) (*FindOwnerResult, error) {
+	events, source, warning, err := loadReservationEvents(ctx, cfg, c, since, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	resp, wsErr := c.Workspaces(ctx, &client.WorkspacesRequest{Limit: maxWorkspaceQueryLimit})
+	cancel()
+	var workspaces []client.Workspace
+	if wsErr == nil {
+		workspaces = resp.Workspaces
+	} else if warning == "" {
+		var clientErr *client.Error
+		if errors.As(wsErr, &clientErr) {
+			warning = fmt.Sprintf("could not list workspaces (BeadHub error %d) - tags are not considered", clientErr.StatusCode)
+		} else {
+			warning = fmt.Sprintf("could not list workspaces (%v) - tags are not considered", wsErr)
+		}
+	}
+
+	return &FindOwnerResult{
+		Path:       target,
+		Source:     source,
+		Candidates: rankOwnerCandidates(target, cfg.Alias, workspaces, events),
+		Warning:    warning,
+	}, nil
+}
+
+// pathTagHints returns the words a tag could match for p: its directory
+// names, the words of its file name, and the language of its extension.
+func pathTagHints(p string) map[string]bool {
+	hints := make(map[string]bool)
+	p = strings.TrimSuffix(p, dirReservationSuffix)
+	if lang, ok := extensionTags[strings.ToLower(path.Ext(p))]; ok {
+		hints[lang] = true
+	}
+	split := func(r rune) bool { return r == '/' || r == '.' || r == '_' || r == '-' }
+	for _, word := range strings.FieldsFunc(strings.ToLower(p), split) {
+		hints[word] = true
+	}
+	for _, segment := range strings.Split(strings.ToLower(p), "/") {
+		if segment != "" {
+			hints[segment] = true
+		}
+	}
+	return hints
+}
+
+// isNeighbourPath reports whether key is in the same directory as target
+// without overlapping it.
+func isNeighbourPath(key, target string) bool {
+	dir := path.Dir(strings.TrimSuffix(target, dirReservationSuffix))
+	if dir == "." {
+		return false
+	}
+	return reservationCovers(dirReservationKey(dir), key)
+}
+
+// rankOwnerCandidates scores every agent but self and returns those with a
+// positive score, best first.
+func rankOwnerCandidates(target, self string, workspaces []client.Workspace, events []client.ReservationEvent) []OwnerCandidate {
+	byAlias := make(map[string]*OwnerCandidate)
+	candidate := func(alias string) *OwnerCandidate {
+		if byAlias[alias] == nil {
+			byAlias[alias] = &OwnerCandidate{Alias: alias}
+		}
+		return byAlias[alias]
+	}
+
+	hints := pathTagHints(target)
+	for _, ws := range workspaces {
+		if ws.Alias == "" || ws.Alias == self {
+			continue
+		}
+		for _, tag := range ws.Tags {
+			if hints[tag] {
+				oc := candidate(ws.Alias)
+				oc.MatchedTags = append(oc.MatchedTags, tag)
+				oc.Score += ownerTagScore
+			}
+		}
+	}
+
+	for _, e := range events {
+		if e.Kind != client.ReservationEventAcquired || e.HolderAlias == "" || e.HolderAlias == self || e.Path == "" {
+			continue
+		}
+		switch {
+		case reservationsOverlap(e.Path, target):
+			oc := candidate(e.HolderAlias)
+			oc.Reservations++
+			oc.Score += ownerPathScore
+			oc.LastAt = laterTimestamp(oc.LastAt, e.At)
+		case isNeighbourPath(e.Path, target):
+			oc := candidate(e.HolderAlias)
+			oc.Neighbours++
+			oc.Score += ownerNeighbourScore
+			oc.LastAt = laterTimestamp(oc.LastAt, e.At)
+		}
+	}
+
+	candidates := make([]OwnerCandidate, 0, len(byAlias))
+	for _, oc := range byAlias {
+		candidates = append(candidates, *oc)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Alias < candidates[j].Alias
+	})
+	return candidates
+}
+
+func formatFindOwnerOutput(result *FindOwnerResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", result.Warning))
+	}
+	if len(result.Candidates) == 0 {
+		sb.WriteString(fmt.Sprintf("No one to suggest for %s: no matching tags or recent reservations.\n", result.Path))
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("Who to ask about %s:\n", result.Path))
+	now := time.Now()
+	for _, oc := range result.Candidates {
+		var reasons []string
+		if len(oc.MatchedTags) > 0 {
+			reasons = append(reasons, "tags: "+strings.Join(oc.MatchedTags, ", "))
+		}
+		if oc.Reservations > 0 {
+			reasons = append(reasons, fmt.Sprintf("reserved it %d×", oc.Reservations))
+		}
+		if oc.Neighbours > 0 {
+			reasons = append(reasons, fmt.Sprintf("reserved %d nearby file(s)", oc.Neighbours))
+		}
+		if oc.LastAt != "" {
+			reasons = append(reasons, "last "+formatTimeAgoAt(oc.LastAt, now))
+		}
+		sb.WriteString(fmt.Sprintf("  %s — %s\n", oc.Alias, strings.Join(reasons, "; ")))
+	}
+	if result.Source == hotspotsSourceLocal {
+		sb.WriteString("(reservation history from this workspace only)\n")
+	}
+	return sb.String()
+}
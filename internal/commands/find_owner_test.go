@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestNormalizeOwnerPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"./src/api/auth.py": "src/api/auth.py",
+		"src/api/":          "src/api/**",
+		"src//api/../web/":  "src/web/**",
+	} {
+		if got, err := normalizeOwnerPath(in); err != nil || got != want {
+			t.Errorf("normalizeOwnerPath(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := normalizeOwnerPath("./"); err == nil {
+		t.Error("the whole repository should be rejected")
+	}
+}
+
+func TestRankOwnerCandidates(t *testing.T) {
+	workspaces := []client.Workspace{
+		{Alias: "me", Tags: []string{"python"}},
+		{Alias: "alice", Tags: []string{"python", "frontend"}},
+		{Alias: "bob", Tags: []string{"api"}},
+		{Alias: "carol", Tags: []string{"infra"}},
+	}
+	events := []client.ReservationEvent{
+		{Path: "src/api/auth.py", Kind: client.ReservationEventAcquired, HolderAlias: "dave", At: "2026-03-01T10:00:00Z"},
+		{Path: "src/api/**", Kind: client.ReservationEventAcquired, HolderAlias: "dave", At: "2026-03-02T10:00:00Z"},
+		{Path: "src/api/users.py", Kind: client.ReservationEventAcquired, HolderAlias: "bob", At: "2026-03-01T10:00:00Z"},
+		{Path: "src/api/auth.py", Kind: client.ReservationEventConflict, HolderAlias: "carol", RequestedBy: "erin"},
+		{Path: "src/web/app.ts", Kind: client.ReservationEventAcquired, HolderAlias: "carol"},
+		{Path: "src/api/auth.py", Kind: client.ReservationEventAcquired, HolderAlias: "me"},
+	}
+
+	candidates := rankOwnerCandidates("src/api/auth.py", "me", workspaces, events)
+	var got []string
+	for _, oc := range candidates {
+		got = append(got, oc.Alias)
+	}
+	// dave: 2 reservations (4); bob: api tag + a neighbour (4); alice: python (3)
+	if strings.Join(got, ",") != "bob,dave,alice" {
+		t.Fatalf("candidates = %+v", candidates)
+	}
+	if dave := candidates[1]; dave.Reservations != 2 || dave.LastAt != "2026-03-02T10:00:00Z" {
+		t.Errorf("dave = %+v", dave)
+	}
+
+	out := formatFindOwnerOutput(&FindOwnerResult{Path: "src/api/auth.py", Candidates: candidates}, false)
+	for _, want := range []string{"bob — tags: api; reserved 1 nearby file(s)", "dave — reserved it 2×", "alice — tags: python"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
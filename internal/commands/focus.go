@@ -0,0 +1,349 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/i18n"
+)
+
+// An agent's focus is the apex (usually an epic) it works under. The server
+// derives it from claims; :focus sets it by hand, which matters most when an
+// agent has nothing claimed yet. bdh ready then suggests epics to focus on:
+// those with the most ready beads nobody else has claimed, and those where
+// this agent's recent closes cluster.
+
+const (
+	focusSuggestionLimit = 3
+	focusRecentWindow    = 14 * 24 * time.Hour
+
+	// focusCloseWeight makes one recent close count as much as two ready
+	// beads: staying in familiar code is worth a smaller queue.
+	focusCloseWeight = 2
+)
+
+// FocusSuggestion is an epic worth focusing on.
+type FocusSuggestion struct {
+	EpicID         string `json:"epic_id"`
+	Title          string `json:"title,omitempty"`
+	ReadyUnclaimed int    `json:"ready_unclaimed"`
+	RecentCloses   int    `json:"recent_closes,omitempty"`
+}
+
+func (s FocusSuggestion) score() int {
+	return s.ReadyUnclaimed + focusCloseWeight*s.RecentCloses
+}
+
+// FocusResult is the output of :focus.
+type FocusResult struct {
+	FocusApexID    string            `json:"focus_apex_id,omitempty"`
+	FocusApexTitle string            `json:"focus_apex_title,omitempty"`
+	Claims         int               `json:"claims"`
+	Suggestions    []FocusSuggestion `json:"suggestions"`
+}
+
+var focusJSON bool
+
+var focusCmd = &cobra.Command{
+	Use:   ":focus",
+	Short: "Show, set or clear the epic you are focused on",
+	Long: `Show your current focus and suggested epics to focus on. The team sees
+your focus in bdh ready; it follows your claims unless you set it.
+
+Suggestions rank epics by how many ready beads in them nobody has claimed,
+and by how many beads you closed in them over the last two weeks.
+
+Examples:
+  bdh :focus                 # Current focus and suggestions
+  bdh :focus set bd-e1       # Focus on an epic
+  bdh :focus clear`,
+	Args: cobra.NoArgs,
+	RunE: runFocusShow,
+}
+
+var focusSetCmd = &cobra.Command{
+	Use:   "set <bead-id>",
+	Short: "Focus on an epic (or any apex bead)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runFocusSet,
+}
+
+var focusClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Clear your focus so it follows your claims again",
+	Args:  cobra.NoArgs,
+	RunE:  runFocusClear,
+}
+
+func init() {
+	focusCmd.Flags().BoolVar(&focusJSON, "json", false, "Output as JSON")
+	focusCmd.AddCommand(focusSetCmd)
+	focusCmd.AddCommand(focusClearCmd)
+}
+
+func runFocusShow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.Workspaces(ctx, &client.WorkspacesRequest{Alias: cfg.Alias, IncludeClaims: true})
+	if err != nil {
+		return fmt.Errorf("fetching your workspace: %w", err)
+	}
+	result := &FocusResult{Suggestions: []FocusSuggestion{}}
+	for _, ws := range resp.Workspaces {
+		if ws.WorkspaceID == cfg.WorkspaceID {
+			result.FocusApexID = ws.FocusApexID
+			result.FocusApexTitle = ws.FocusApexTitle
+			result.Claims = len(ws.Claims)
+		}
+	}
+
+	var team []client.Workspace
+	if serverSupports(ctx, cfg, featureTeamQuery) {
+		includeClaims := true
+		if teamResp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{IncludeClaims: &includeClaims}); err == nil {
+			team = teamResp.Workspaces
+		}
+	}
+	if suggestions := focusSuggestionsFor(ctx, cfg, c, team, time.Now()); suggestions != nil {
+		result.Suggestions = suggestions
+	}
+	fmt.Print(formatFocusOutput(result, focusJSON))
+	return nil
+}
+
+func runFocusSet(cmd *cobra.Command, args []string) error {
+	return setFocus(cmd.Context(), strings.TrimSpace(args[0]))
+}
+
+func runFocusClear(cmd *cobra.Command, args []string) error {
+	return setFocus(cmd.Context(), "")
+}
+
+// setFocus sets the focus to apexID, or clears it when apexID is "".
+func setFocus(ctx context.Context, apexID string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if err := requireServerFeature(ctx, cfg, featureFocus, "setting a focus"); err != nil {
+		return err
+	}
+	title := ""
+	if apexID != "" {
+		// Catch typos when the bead is known locally; a bead from another
+		// repo can still be set.
+		if issues, err := loadIssues(); err == nil {
+			found := false
+			for _, issue := range issues {
+				if issue.ID == apexID {
+					found, title = true, issue.Title
+					break
+				}
+			}
+			if !found && len(issues) > 0 {
+				return fmt.Errorf("no bead %s in this repo", apexID)
+			}
+		}
+	}
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.SetFocus(ctx, cfg.WorkspaceID, &client.SetFocusRequest{Alias: cfg.Alias, ApexID: apexID})
+	if err != nil {
+		return fmt.Errorf("setting focus: %w", err)
+	}
+	if apexID == "" {
+		fmt.Println("Focus cleared; it follows your claims again.")
+		return nil
+	}
+	if resp.FocusApexTitle != "" {
+		title = resp.FocusApexTitle
+	}
+	if title != "" {
+		fmt.Printf("Focused on %s %q\n", apexID, title)
+	} else {
+		fmt.Printf("Focused on %s\n", apexID)
+	}
+	return nil
+}
+
+// focusSuggestionsFor suggests epics from the local beads, the claims in
+// team and this agent's recent closes. Best-effort: it returns nil when the
+// beads cannot be read.
+func focusSuggestionsFor(ctx context.Context, cfg *config.Config, c BeadHubAPI, team []client.Workspace, now time.Time) []FocusSuggestion {
+	issues, err := loadIssues()
+	if err != nil {
+		return nil
+	}
+	claimed := make(map[string]bool)
+	for _, ws := range team {
+		if ws.WorkspaceID == cfg.WorkspaceID {
+			continue
+		}
+		for _, claim := range ws.Claims {
+			claimed[claim.BeadID] = true
+		}
+	}
+	return suggestFocus(issues, claimed, myRecentCloses(ctx, cfg, c, now), focusSuggestionLimit)
+}
+
+// myRecentCloses returns the beads this agent closed within
+// focusRecentWindow, from the activity feed when the server has one.
+func myRecentCloses(ctx context.Context, cfg *config.Config, c BeadHubAPI, now time.Time) []string {
+	if !serverSupports(ctx, cfg, featureActivity) {
+		return nil
+	}
+	resp, err := c.Activity(ctx, &client.ActivityRequest{Since: now.Add(-focusRecentWindow).UTC().Format(time.RFC3339)})
+	if err != nil {
+		return nil
+	}
+	var closed []string
+	for _, e := range resp.Events {
+		if e.Kind == client.ActivityClose && e.Alias == cfg.Alias && e.BeadID != "" {
+			closed = append(closed, e.BeadID)
+		}
+	}
+	return closed
+}
+
+// suggestFocus ranks the open epics that have ready beads nobody has
+// claimed, by those beads and by myCloses, and returns the best limit.
+func suggestFocus(issues []Issue, claimed map[string]bool, myCloses []string, limit int) []FocusSuggestion {
+	epicOf := beadEpics(issues)
+	byID := make(map[string]*Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	suggestions := make(map[string]*FocusSuggestion)
+	suggestion := func(epicID string) *FocusSuggestion {
+		s := suggestions[epicID]
+		if s == nil {
+			s = &FocusSuggestion{EpicID: epicID, Title: byID[epicID].Title}
+			suggestions[epicID] = s
+		}
+		return s
+	}
+	for _, issue := range issues {
+		epicID := epicOf[issue.ID]
+		if epicID == "" || epicID == issue.ID || byID[epicID].Status == "closed" {
+			continue
+		}
+		if isReadyIssue(issue, byID) && !claimed[issue.ID] {
+			suggestion(epicID).ReadyUnclaimed++
+		}
+	}
+	for _, beadID := range myCloses {
+		epicID := epicOf[beadID]
+		if epicID == "" || epicID == beadID || byID[epicID].Status == "closed" {
+			continue
+		}
+		suggestion(epicID).RecentCloses++
+	}
+
+	var ranked []FocusSuggestion
+	for _, s := range suggestions {
+		// Familiar code with nothing left to pick up is no place to focus.
+		if s.ReadyUnclaimed > 0 {
+			ranked = append(ranked, *s)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score() != ranked[j].score() {
+			return ranked[i].score() > ranked[j].score()
+		}
+		return ranked[i].EpicID < ranked[j].EpicID
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// isReadyIssue reports whether issue is open and blocked by nothing still
+// open, as bd ready decides. Blockers missing from byID do not block.
+func isReadyIssue(issue Issue, byID map[string]*Issue) bool {
+	if issue.Status != "open" || issue.IssueType == "epic" {
+		return false
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.Type != "blocks" {
+			continue
+		}
+		if blocker := byID[dep.DependsOnID]; blocker != nil && blocker.Status != "closed" {
+			return false
+		}
+	}
+	return true
+}
+
+// formatFocusSuggestions renders suggestions for ready output.
+func formatFocusSuggestions(suggestions []FocusSuggestion) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinationHeader())
+	sb.WriteString("\n## " + i18n.T("ready.focus_suggest.title") + "\n")
+	sb.WriteString(i18n.T("ready.focus_suggest.intro") + "\n")
+	writeFocusSuggestions(&sb, suggestions)
+	return sb.String()
+}
+
+func writeFocusSuggestions(sb *strings.Builder, suggestions []FocusSuggestion) {
+	for i, s := range suggestions {
+		reasons := []string{i18n.T("ready.focus_suggest.ready", s.ReadyUnclaimed)}
+		if s.RecentCloses > 0 {
+			reasons = append(reasons, i18n.T("ready.focus_suggest.closes", s.RecentCloses))
+		}
+		if s.Title != "" {
+			sb.WriteString(fmt.Sprintf("%d. %s \"%s\" — %s\n", i+1, s.EpicID, s.Title, strings.Join(reasons, ", ")))
+		} else {
+			sb.WriteString(fmt.Sprintf("%d. %s — %s\n", i+1, s.EpicID, strings.Join(reasons, ", ")))
+		}
+	}
+	sb.WriteString(i18n.T("ready.focus_suggest.hint", suggestions[0].EpicID) + "\n")
+}
+
+func formatFocusOutput(result *FocusResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	var sb strings.Builder
+	switch {
+	case result.FocusApexID != "" && result.FocusApexTitle != "":
+		sb.WriteString(fmt.Sprintf("Focus: %s \"%s\"\n", result.FocusApexID, result.FocusApexTitle))
+	case result.FocusApexID != "":
+		sb.WriteString(fmt.Sprintf("Focus: %s\n", result.FocusApexID))
+	default:
+		sb.WriteString("No focus set.\n")
+	}
+	if len(result.Suggestions) == 0 {
+		sb.WriteString("No epics with unclaimed ready work to suggest.\n")
+		return sb.String()
+	}
+	sb.WriteString("\nSuggested:\n")
+	writeFocusSuggestions(&sb, result.Suggestions)
+	return sb.String()
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func focusTestIssues() []Issue {
+	task := func(id, parent, status string, blockers ...string) Issue {
+		issue := Issue{ID: id, Title: id, Status: status, IssueType: "task",
+			Dependencies: []Dependency{{IssueID: id, DependsOnID: parent, Type: "parent-child"}}}
+		for _, b := range blockers {
+			issue.Dependencies = append(issue.Dependencies, Dependency{IssueID: id, DependsOnID: b, Type: "blocks"})
+		}
+		return issue
+	}
+	return []Issue{
+		{ID: "bd-e1", Title: "Auth", Status: "open", IssueType: "epic"},
+		task("bd-1", "bd-e1", "open"),
+		task("bd-2", "bd-e1", "open"),
+		task("bd-3", "bd-e1", "open", "bd-1"), // blocked
+		{ID: "bd-e2", Title: "Billing", Status: "open", IssueType: "epic"},
+		task("bd-4", "bd-e2", "open"),
+		task("bd-5", "bd-e2", "closed"),
+		task("bd-6", "bd-e2", "closed"),
+		{ID: "bd-e3", Title: "Search", Status: "open", IssueType: "epic"},
+		task("bd-7", "bd-e3", "open"), // claimed by someone else
+		task("bd-8", "bd-e3", "closed"),
+		{ID: "bd-e4", Title: "Done", Status: "closed", IssueType: "epic"},
+		task("bd-9", "bd-e4", "open"),
+	}
+}
+
+func TestSuggestFocus(t *testing.T) {
+	claimed := map[string]bool{"bd-7": true}
+	got := suggestFocus(focusTestIssues(), claimed, []string{"bd-5", "bd-6", "bd-8"}, 3)
+
+	// Billing: 1 ready + 2 recent closes scores 5; Auth: 2 ready scores 2.
+	// Search has nothing ready nobody claimed; Done is closed.
+	if len(got) != 2 || got[0].EpicID != "bd-e2" || got[1].EpicID != "bd-e1" {
+		t.Fatalf("unexpected suggestions: %+v", got)
+	}
+	if got[0].ReadyUnclaimed != 1 || got[0].RecentCloses != 2 || got[1].ReadyUnclaimed != 2 {
+		t.Errorf("unexpected counts: %+v", got)
+	}
+	if got := suggestFocus(focusTestIssues(), claimed, nil, 1); len(got) != 1 || got[0].EpicID != "bd-e1" {
+		t.Errorf("without closes Auth should lead, got %+v", got)
+	}
+}
+
+func TestFormatFocusSuggestions(t *testing.T) {
+	t.Setenv("BDH_LANG", "")
+	if got := formatFocusSuggestions(nil); got != "" {
+		t.Errorf("expected nothing without suggestions, got %q", got)
+	}
+	out := formatFocusSuggestions([]FocusSuggestion{
+		{EpicID: "bd-e2", Title: "Billing", ReadyUnclaimed: 1, RecentCloses: 2},
+		{EpicID: "bd-e1", Title: "Auth", ReadyUnclaimed: 2},
+	})
+	for _, want := range []string{
+		"## Suggested Focus",
+		`1. bd-e2 "Billing" — 1 ready and unclaimed, you closed 2 here recently`,
+		`2. bd-e1 "Auth" — 2 ready and unclaimed`,
+		"`bdh :focus set bd-e2`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatPassthroughOutput_FocusSuggestionsOnlyWithoutFocus(t *testing.T) {
+	t.Setenv("BDH_LANG", "")
+	result := &PassthroughResult{
+		IsReadyCommand:   true,
+		MyAlias:          "me",
+		FocusSuggestions: []FocusSuggestion{{EpicID: "bd-e1", Title: "Auth", ReadyUnclaimed: 2}},
+	}
+	if out := formatPassthroughOutput(result); !strings.Contains(out, "bdh :focus set bd-e1") {
+		t.Errorf("expected suggestions in:\n%s", out)
+	}
+	result.MyFocusApexID = "bd-e2"
+	if out := formatPassthroughOutput(result); strings.Contains(out, "Suggested Focus") {
+		t.Errorf("suggestions shown despite a focus:\n%s", out)
+	}
+}
@@ -1,12 +1,12 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
-	"github.com/beadhub/bdh/internal/beads"
 	"github.com/beadhub/bdh/internal/config"
 )
 
@@ -26,32 +26,21 @@ func init() {
 }
 
 func runForceSync(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
-		}
-		return fmt.Errorf("loading config: %w", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid .beadhub config: %w", err)
-	}
-	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 		return err
 	}
 
-	// Clear the sync state cache
-	syncStatePath := beads.SyncStatePath()
-	if err := os.Remove(syncStatePath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to clear sync cache: %w", err)
-	}
-
-	fmt.Println("Sync cache cleared, performing full sync...")
+	fmt.Println("Clearing sync cache, performing full sync...")
 
-	// Trigger full sync
-	result := syncToBeadHub(cfg, nil)
+	// :force-sync is the documented way past the graph hygiene block
+	result, err := forceFullSync(ctx, cfg, syncOptions{Force: true})
+	if err != nil {
+		return err
+	}
 
+	fmt.Print(formatGraphProblems(result.GraphProblems))
 	if result.Warning != "" {
 		return fmt.Errorf("sync failed: %s", result.Warning)
 	}
@@ -69,3 +58,12 @@ func runForceSync(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// forceFullSync clears the local sync state cache and uploads every issue.
+func forceFullSync(ctx context.Context, cfg *config.Config, opts syncOptions) (*SyncResult, error) {
+	syncStatePath := syncStatePathForConfig(cfg)
+	if err := os.Remove(syncStatePath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to clear sync cache: %w", err)
+	}
+	return syncToBeadHub(ctx, cfg, nil, opts), nil
+}
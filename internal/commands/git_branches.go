@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gitBranchTimeout bounds each local git query; gitFetchTimeout the fetch
+// that refreshes remote branches first.
+const (
+	gitBranchTimeout = 5 * time.Second
+	gitFetchTimeout  = 30 * time.Second
+)
+
+// BranchFate is what happened upstream to a local branch.
+type BranchFate struct {
+	Branch   string `json:"branch"`
+	Upstream string `json:"upstream,omitempty"`
+	// Merged: the branch was pushed and is now contained in the default
+	// branch (a merge or fast-forward; squash merges show up as Gone once
+	// the remote branch is deleted).
+	Merged bool `json:"merged"`
+	// Gone: the branch tracked an upstream branch that no longer exists.
+	Gone bool `json:"gone"`
+}
+
+// Done reports whether the branch's work has landed or been abandoned
+// upstream.
+func (f BranchFate) Done() bool {
+	return f.Merged || f.Gone
+}
+
+func gitOutput(ctx context.Context, root string, args ...string) (string, error) {
+	subcommand := args[0]
+	if root != "" {
+		args = append([]string{"-C", root}, args...)
+	}
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", subcommand, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", subcommand, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitFetchPrune refreshes origin's branches, dropping deleted ones, so Gone
+// and Merged reflect the server.
+func gitFetchPrune(ctx context.Context, root string) error {
+	ctx, cancel := context.WithTimeout(ctx, gitFetchTimeout)
+	defer cancel()
+	_, err := gitOutput(ctx, root, "fetch", "--prune", "--quiet", "origin")
+	return err
+}
+
+// gitDefaultBranch returns origin's default branch as a remote-tracking ref
+// ("origin/main"), or "" if it cannot be told.
+func gitDefaultBranch(ctx context.Context, root string) string {
+	ctx, cancel := context.WithTimeout(ctx, gitBranchTimeout)
+	defer cancel()
+	if ref, err := gitOutput(ctx, root, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil && ref != "" {
+		return ref
+	}
+	for _, ref := range []string{"origin/main", "origin/master"} {
+		if _, err := gitOutput(ctx, root, "rev-parse", "--verify", "--quiet", ref); err == nil {
+			return ref
+		}
+	}
+	return ""
+}
+
+// gitBranchFates reports the fate of every local branch other than the
+// default branch itself. defaultRef may be empty, in which case only Gone is
+// detected.
+func gitBranchFates(ctx context.Context, root, defaultRef string) ([]BranchFate, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitBranchTimeout)
+	defer cancel()
+	out, err := gitOutput(ctx, root, "for-each-ref", "refs/heads", "--format=%(refname:short)%09%(upstream:short)%09%(upstream:track)")
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]bool)
+	if defaultRef != "" {
+		list, err := gitOutput(ctx, root, "for-each-ref", "refs/heads", "--merged", defaultRef, "--format=%(refname:short)")
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range strings.Split(list, "\n") {
+			merged[name] = true
+		}
+	}
+	_, defaultName, _ := strings.Cut(defaultRef, "/")
+
+	var fates []BranchFate
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 || fields[0] == "" || fields[0] == defaultName {
+			continue
+		}
+		fate := BranchFate{Branch: fields[0], Upstream: fields[1]}
+		fate.Gone = fate.Upstream != "" && strings.Contains(fields[2], "gone")
+		// Unpushed branches are contained in the default branch until they
+		// get their first commit; only pushed ones count as merged
+		fate.Merged = fate.Upstream != "" && merged[fate.Branch]
+		fates = append(fates, fate)
+	}
+	return fates, nil
+}
@@ -0,0 +1,438 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :hooks install drops git hooks that run coordination checks:
+//   - pre-commit: none of the staged files is reserved by another agent
+//   - pre-push: the bead named in each pushed branch is claimed by me
+//
+// The hooks call back into `bdh :hooks run <hook>`. Like the rest of bdh
+// they only block on a definite answer: if the server is unreachable or the
+// workspace is not initialized, the commit or push goes through.
+
+// gitHookMarker identifies hook scripts written by bdh, so install never
+// clobbers a user's own hook and uninstall only removes ours.
+const gitHookMarker = "# Installed by bdh :hooks install"
+
+var managedGitHooks = []string{"pre-commit", "pre-push"}
+
+var gitHooksForce bool
+
+var hooksCmd = &cobra.Command{
+	Use:   ":hooks",
+	Short: "Install git hooks that check reservations and claims",
+	Long: `Install git hooks that run BeadHub coordination checks.
+
+pre-commit blocks a commit that touches files another agent has reserved.
+pre-push blocks pushing a branch whose name references a bead (bd-42-fix-login)
+unless you have claimed that bead.
+
+Both hooks let the commit/push through when the server can't be reached.
+Bypass a check deliberately with git's --no-verify.
+
+Examples:
+  bdh :hooks install
+  bdh :hooks install --force   # replace existing hooks (kept as <hook>.bak)
+  bdh :hooks uninstall`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the pre-commit and pre-push hooks",
+	Args:  cobra.NoArgs,
+	RunE:  runHooksInstall,
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove hooks installed by bdh",
+	Args:  cobra.NoArgs,
+	RunE:  runHooksUninstall,
+}
+
+var hooksRunCmd = &cobra.Command{
+	Use:    "run <hook> [args...]",
+	Short:  "Run a hook check (called by the installed git hooks)",
+	Hidden: true,
+	Args:   cobra.MinimumNArgs(1),
+	RunE:   runHooksRun,
+}
+
+func init() {
+	hooksInstallCmd.Flags().BoolVar(&gitHooksForce, "force", false, "Replace existing hooks (the old hook is kept as <hook>.bak)")
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksRunCmd)
+}
+
+// gitHooksDir returns the hooks directory git uses for the repo at dir,
+// honoring core.hooksPath and linked worktrees.
+func gitHooksDir(ctx context.Context, dir string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository (or git not available)")
+	}
+	hooksDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+	return hooksDir, nil
+}
+
+func gitHookScript(hook string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s
+# BeadHub coordination check. Bypass once with: git %s --no-verify
+command -v bdh >/dev/null 2>&1 || exit 0
+exec bdh :hooks run %s "$@"
+`, gitHookMarker, map[string]string{"pre-commit": "commit", "pre-push": "push"}[hook], hook)
+}
+
+func isBdhGitHook(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), gitHookMarker)
+}
+
+// installGitHooks writes the bdh hooks into hooksDir. Existing hooks that
+// bdh did not write are left alone unless force is set, in which case they
+// are renamed to <hook>.bak.
+func installGitHooks(hooksDir string, force bool) (installed, skipped []string, err error) {
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return nil, nil, err
+	}
+	for _, hook := range managedGitHooks {
+		path := filepath.Join(hooksDir, hook)
+		if _, statErr := os.Stat(path); statErr == nil && !isBdhGitHook(path) {
+			if !force {
+				skipped = append(skipped, hook)
+				continue
+			}
+			if err := os.Rename(path, path+".bak"); err != nil {
+				return installed, skipped, fmt.Errorf("backing up %s: %w", path, err)
+			}
+		}
+		if err := os.WriteFile(path, []byte(gitHookScript(hook)), 0o755); err != nil {
+			return installed, skipped, fmt.Errorf("writing %s: %w", path, err)
+		}
+		installed = append(installed, hook)
+	}
+	return installed, skipped, nil
+}
+
+// uninstallGitHooks removes bdh hooks and restores any <hook>.bak.
+func uninstallGitHooks(hooksDir string) ([]string, error) {
+	var removed []string
+	for _, hook := range managedGitHooks {
+		path := filepath.Join(hooksDir, hook)
+		if !isBdhGitHook(path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		if _, err := os.Stat(path + ".bak"); err == nil {
+			_ = os.Rename(path+".bak", path)
+		}
+		removed = append(removed, hook)
+	}
+	return removed, nil
+}
+
+func runHooksInstall(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	root := currentRepoRoot(ctx)
+	if root == "" {
+		return fmt.Errorf("not a git repository")
+	}
+	hooksDir, err := gitHooksDir(ctx, root)
+	if err != nil {
+		return err
+	}
+	installed, skipped, err := installGitHooks(hooksDir, gitHooksForce)
+	if err != nil {
+		return err
+	}
+	for _, hook := range installed {
+		fmt.Printf("Installed %s hook (%s)\n", hook, filepath.Join(hooksDir, hook))
+	}
+	for _, hook := range skipped {
+		fmt.Printf("Skipped %s: a hook not written by bdh already exists (use --force to replace it, or add `bdh :hooks run %s \"$@\"` to it)\n", hook, hook)
+	}
+	return nil
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	root := currentRepoRoot(ctx)
+	if root == "" {
+		return fmt.Errorf("not a git repository")
+	}
+	hooksDir, err := gitHooksDir(ctx, root)
+	if err != nil {
+		return err
+	}
+	removed, err := uninstallGitHooks(hooksDir)
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		fmt.Println("No bdh hooks installed.")
+		return nil
+	}
+	fmt.Printf("Removed hooks: %s\n", strings.Join(removed, ", "))
+	return nil
+}
+
+func runHooksRun(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// Hooks run on every commit; keep them quiet and quick.
+	SuppressNotifications()
+
+	cfg, err := config.Load()
+	if err != nil || cfg.Validate() != nil {
+		return nil // not a BeadHub workspace: nothing to check
+	}
+
+	var problems []string
+	switch args[0] {
+	case "pre-commit":
+		problems, err = preCommitCheck(ctx, cfg)
+	case "pre-push":
+		problems, err = prePushCheck(ctx, cfg, os.Stdin)
+	default:
+		return fmt.Errorf("unknown hook %q (expected pre-commit or pre-push)", args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bdh %s: skipping coordination check (%v)\n", args[0], err)
+		return nil
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	verb := map[string]string{"pre-commit": "commit", "pre-push": "push"}[args[0]]
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("bdh %s: %s blocked by BeadHub coordination check\n", args[0], verb))
+	for _, p := range problems {
+		sb.WriteString("  - " + p + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("To %s anyway: git %s --no-verify", verb, verb))
+	return errors.New(sb.String())
+}
+
+// preCommitCheck lists staged files that another agent holds a reservation on.
+func preCommitCheck(ctx context.Context, cfg *config.Config) ([]string, error) {
+	gitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(gitCtx, "git", "diff", "--cached", "--name-only", "-z").Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing staged files: %w", err)
+	}
+	var staged []string
+	for _, p := range strings.Split(string(out), "\x00") {
+		if p != "" {
+			staged = append(staged, p)
+		}
+	}
+	if len(staged) == 0 {
+		return nil, nil
+	}
+
+	aw, err := newAwebClient(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	listCtx, listCancel := context.WithTimeout(ctx, apiTimeout)
+	defer listCancel()
+	resp, err := aw.ReservationList(listCtx, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing reservations: %w", err)
+	}
+	return stagedReservationConflicts(staged, resp.Reservations, cfg.Alias, serverNow()), nil
+}
+
+// blockingReservations indexes by key the reservations held by others; look
+// paths up with coveringReservation so directory reservations apply.
+// Reservations marked non-exclusive in their metadata don't block.
+func blockingReservations(reservations []aweb.ReservationView, myAlias string) map[string]aweb.ReservationView {
+	held := make(map[string]aweb.ReservationView)
+	for _, r := range reservations {
+		if r.HolderAlias == myAlias || r.ResourceKey == "" {
+			continue
+		}
+		if exclusive, ok := r.Metadata["exclusive"].(bool); ok && !exclusive {
+			continue
+		}
+		held[r.ResourceKey] = r
+	}
+	return held
+}
+
+// stagedReservationConflicts reports staged paths reserved by someone else.
+func stagedReservationConflicts(staged []string, reservations []aweb.ReservationView, myAlias string, now time.Time) []string {
+	held := blockingReservations(reservations, myAlias)
+
+	var problems []string
+	for _, path := range staged {
+		r, ok := coveringReservation(held, path)
+		if !ok {
+			continue
+		}
+		holder := r.HolderAlias
+		if holder == "" {
+			holder = "another agent"
+		}
+		problems = append(problems, fmt.Sprintf("%s is reserved by %s (expires in %s) - ask them: bdh :aweb mail send %s \"...\"",
+			path, holder, formatDuration(ttlRemainingSeconds(r.ExpiresAt, now)), holder))
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// prePushCheck verifies that beads referenced by pushed branch names are
+// claimed by this workspace; closed beads are left alone, since a branch
+// often outlives its bead. stdin carries git's pre-push ref lines.
+func prePushCheck(ctx context.Context, cfg *config.Config, stdin io.Reader) ([]string, error) {
+	branches := pushedBranches(stdin)
+	if len(branches) == 0 {
+		return nil, nil
+	}
+	issues, err := loadIssues()
+	if err != nil {
+		return nil, nil // no local issues: nothing to match branch names against
+	}
+	ids := make([]string, 0, len(issues))
+	closed := make(map[string]bool)
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+		closed[issue.ID] = issue.Status == "closed"
+	}
+
+	referenced := make(map[string]string) // bead -> branch
+	for _, branch := range branches {
+		if id := beadIDInBranch(branch, ids); id != "" && !closed[id] {
+			referenced[id] = branch
+		}
+	}
+	if len(referenced) == 0 {
+		return nil, nil
+	}
+
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	includeClaims := true
+	onlyWithClaims := true
+	resp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+		IncludeClaims:            &includeClaims,
+		OnlyWithClaims:           &onlyWithClaims,
+		AlwaysIncludeWorkspaceID: cfg.WorkspaceID,
+		Limit:                    maxWorkspaceQueryLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching claims: %w", err)
+	}
+	return unclaimedBranchBeads(referenced, resp.Workspaces, cfg.WorkspaceID), nil
+}
+
+// pushedBranches reads branch names from pre-push stdin
+// ("<local ref> <local sha> <remote ref> <remote sha>" per line). Deletions
+// (local ref "(delete)") are ignored.
+func pushedBranches(r io.Reader) []string {
+	var branches []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 1 {
+			continue
+		}
+		if name, ok := strings.CutPrefix(fields[0], "refs/heads/"); ok {
+			branches = append(branches, name)
+		}
+	}
+	return branches
+}
+
+// beadIDInBranch returns the longest known bead ID that appears in branch
+// as a whole token: "feature/bd-42-login" references bd-42, but
+// "bd-420" does not.
+func beadIDInBranch(branch string, ids []string) string {
+	lower := strings.ToLower(branch)
+	best := ""
+	for _, id := range ids {
+		idLower := strings.ToLower(id)
+		if idLower == "" || len(idLower) <= len(best) {
+			continue
+		}
+		for start := 0; ; {
+			i := strings.Index(lower[start:], idLower)
+			if i < 0 {
+				break
+			}
+			i += start
+			end := i + len(idLower)
+			if (i == 0 || !isBranchIDChar(lower[i-1])) && (end == len(lower) || !isBranchIDChar(lower[end])) {
+				best = id
+				break
+			}
+			start = i + 1
+		}
+	}
+	return best
+}
+
+// isBranchIDChar reports whether c continues a bead ID. '-' is a separator
+// after the ID ("bd-42-fix") but digits and letters are not.
+func isBranchIDChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '.'
+}
+
+// unclaimedBranchBeads reports referenced beads not claimed by myWorkspaceID.
+func unclaimedBranchBeads(referenced map[string]string, workspaces []client.Workspace, myWorkspaceID string) []string {
+	claimants := make(map[string][]string)
+	mine := make(map[string]bool)
+	for _, ws := range workspaces {
+		for _, claim := range ws.Claims {
+			if ws.WorkspaceID == myWorkspaceID {
+				mine[claim.BeadID] = true
+			} else {
+				claimants[claim.BeadID] = append(claimants[claim.BeadID], ws.Alias)
+			}
+		}
+	}
+
+	var problems []string
+	for bead, branch := range referenced {
+		if mine[bead] {
+			continue
+		}
+		if others := claimants[bead]; len(others) > 0 {
+			problems = append(problems, fmt.Sprintf("branch %s references %s, which is claimed by %s, not you", branch, bead, strings.Join(others, ", ")))
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("branch %s references %s, which you have not claimed - claim it: bdh update %s --status in_progress", branch, bead, bead))
+	}
+	sort.Strings(problems)
+	return problems
+}
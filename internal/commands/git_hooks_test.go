@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestInstallGitHooks_RespectsExistingHooks(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	own := filepath.Join(hooksDir, "pre-push")
+	if err := os.WriteFile(own, []byte("#!/bin/sh\necho mine\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	installed, skipped, err := installGitHooks(hooksDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(installed, ",") != "pre-commit" || strings.Join(skipped, ",") != "pre-push" {
+		t.Fatalf("installed=%v skipped=%v", installed, skipped)
+	}
+	if !isBdhGitHook(filepath.Join(hooksDir, "pre-commit")) || isBdhGitHook(own) {
+		t.Fatal("wrong hooks written")
+	}
+
+	// Reinstalling is idempotent; --force backs up the user's hook.
+	installed, _, err = installGitHooks(hooksDir, true)
+	if err != nil || len(installed) != 2 {
+		t.Fatalf("force install = %v, %v", installed, err)
+	}
+	if data, _ := os.ReadFile(own + ".bak"); string(data) != "#!/bin/sh\necho mine\n" {
+		t.Errorf("backup = %q", data)
+	}
+
+	removed, err := uninstallGitHooks(hooksDir)
+	if err != nil || len(removed) != 2 {
+		t.Fatalf("uninstall = %v, %v", removed, err)
+	}
+	if data, _ := os.ReadFile(own); string(data) != "#!/bin/sh\necho mine\n" {
+		t.Errorf("original hook not restored: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(hooksDir, "pre-commit")); !os.IsNotExist(err) {
+		t.Errorf("pre-commit should be removed, stat err = %v", err)
+	}
+}
+
+func TestGitHooksDir_RealGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	got, err := gitHooksDir(context.Background(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := filepath.EvalSymlinks(filepath.Join(dir, ".git", "hooks"))
+	if resolved, _ := filepath.EvalSymlinks(got); resolved != want {
+		t.Errorf("hooks dir = %s, want %s", got, want)
+	}
+}
+
+func TestStagedReservationConflicts(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(30 * time.Minute).UTC().Format(time.RFC3339)
+	reservations := []aweb.ReservationView{
+		{ResourceKey: "src/api.go", HolderAlias: "alice", ExpiresAt: expires},
+		{ResourceKey: "src/mine.go", HolderAlias: "me", ExpiresAt: expires},
+		{ResourceKey: "docs/shared.md", HolderAlias: "bob", ExpiresAt: expires, Metadata: map[string]any{"exclusive": false}},
+	}
+	problems := stagedReservationConflicts([]string{"src/api.go", "src/mine.go", "docs/shared.md", "README.md"}, reservations, "me", now)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "src/api.go is reserved by alice (expires in 30m)") {
+		t.Errorf("problems = %v", problems)
+	}
+}
+
+func TestBeadIDInBranch(t *testing.T) {
+	ids := []string{"bd-4", "bd-42", "bd-42.1", "api-7"}
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "bd-42-fix-login", want: "bd-42"},
+		{branch: "feature/BD-42", want: "bd-42"},
+		{branch: "alice/bd-42.1-subtask", want: "bd-42.1"},
+		{branch: "fix-api-7", want: "api-7"},
+		{branch: "bd-420-other", want: ""},
+		{branch: "main", want: ""},
+	}
+	for _, tt := range tests {
+		if got := beadIDInBranch(tt.branch, ids); got != tt.want {
+			t.Errorf("beadIDInBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestPushedBranches(t *testing.T) {
+	stdin := "refs/heads/bd-42-fix abc refs/heads/bd-42-fix 000\n" +
+		"(delete) 000 refs/heads/old 123\n" +
+		"refs/tags/v1 abc refs/tags/v1 000\n"
+	if got := pushedBranches(strings.NewReader(stdin)); strings.Join(got, ",") != "bd-42-fix" {
+		t.Errorf("branches = %v", got)
+	}
+}
+
+func TestUnclaimedBranchBeads(t *testing.T) {
+	workspaces := []client.Workspace{
+		{WorkspaceID: "ws-me", Alias: "me", Claims: []client.Claim{{BeadID: "bd-1"}}},
+		{WorkspaceID: "ws-alice", Alias: "alice", Claims: []client.Claim{{BeadID: "bd-2"}}},
+	}
+	problems := unclaimedBranchBeads(map[string]string{
+		"bd-1": "bd-1-mine",
+		"bd-2": "bd-2-theirs",
+		"bd-3": "bd-3-nobody",
+	}, workspaces, "ws-me")
+	if len(problems) != 2 ||
+		!strings.Contains(problems[0], "bd-2, which is claimed by alice, not you") ||
+		!strings.Contains(problems[1], "claim it: bdh update bd-3 --status in_progress") {
+		t.Errorf("problems = %v", problems)
+	}
+}
+
+func TestPrePushCheck_SkipsClosedBeads(t *testing.T) {
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"Done","status":"closed"}
+{"id":"bd-2","title":"Open","status":"open"}
+`)
+	fake := clienttest.New()
+	ctx := withFakeBeadHub(fake)
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", WorkspaceID: "ws-me", Alias: "me"}
+
+	stdin := "refs/heads/bd-1-done abc refs/heads/bd-1-done 000\n" +
+		"refs/heads/bd-2-open abc refs/heads/bd-2-open 000\n"
+	problems, err := prePushCheck(ctx, cfg, strings.NewReader(stdin))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "references bd-2") {
+		t.Errorf("problems = %v", problems)
+	}
+	calls := fake.Calls("TeamWorkspaces")
+	if len(calls) != 1 || calls[0].Args[0].(*client.TeamWorkspacesRequest).Limit != maxWorkspaceQueryLimit {
+		t.Errorf("TeamWorkspaces calls = %+v", calls)
+	}
+}
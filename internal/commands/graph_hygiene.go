@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Issue graph hygiene checks, run before uploading issues.jsonl so a broken
+// graph doesn't propagate to every other agent's view. Sync is blocked when
+// problems are found unless --:force is given; advisory problems (a related
+// or discovered-from link to a closed and pruned issue) are only reported.
+
+// Graph problem kinds.
+const (
+	graphProblemCycle       = "cycle"
+	graphProblemDangling    = "dangling_dependency"
+	graphProblemInversion   = "parent_child_inversion"
+	graphProblemDuplicateID = "duplicate_id"
+)
+
+// maxGraphProblemsShown caps the fix list in text output.
+const maxGraphProblemsShown = 10
+
+// GraphProblem is a single issue graph defect with a suggested fix.
+type GraphProblem struct {
+	Kind    string   `json:"kind"`
+	IssueID string   `json:"issue_id"`
+	Related []string `json:"related,omitempty"`
+	Fix     string   `json:"fix"`
+	// Advisory problems are reported but never block sync.
+	Advisory bool `json:"advisory,omitempty"`
+}
+
+// issueTypeRank orders issue types so a parent should never rank below its child.
+var issueTypeRank = map[string]int{
+	"epic":    3,
+	"feature": 2,
+	"task":    1,
+	"bug":     1,
+	"chore":   1,
+}
+
+// checkIssueGraph validates the dependency graph of issues.
+func checkIssueGraph(issues []Issue) []GraphProblem {
+	var problems []GraphProblem
+
+	byID := make(map[string]*Issue, len(issues))
+	counts := make(map[string]int)
+	for i := range issues {
+		id := issues[i].ID
+		counts[id]++
+		if _, ok := byID[id]; !ok {
+			byID[id] = &issues[i]
+		}
+	}
+	for _, id := range sortedKeys(counts) {
+		if counts[id] > 1 {
+			problems = append(problems, GraphProblem{
+				Kind:    graphProblemDuplicateID,
+				IssueID: id,
+				Fix:     fmt.Sprintf("%s appears %d times in issues.jsonl; re-export with 'bd export' or remove the stale line", id, counts[id]),
+			})
+		}
+	}
+
+	// Ordering edges: "blocks" (the default) and "parent-child".
+	edges := make(map[string][]string)
+	for _, id := range sortedKeys(byID) {
+		issue := byID[id]
+		for _, dep := range issue.Dependencies {
+			target := dep.DependsOnID
+			if target == "" || strings.HasPrefix(target, "external:") {
+				continue
+			}
+			parent, ok := byID[target]
+			if !ok {
+				problems = append(problems, GraphProblem{
+					Kind:     graphProblemDangling,
+					IssueID:  id,
+					Related:  []string{target},
+					Fix:      fmt.Sprintf("bd dep remove %s %s", id, target),
+					Advisory: !isOrderingDependency(dep.Type),
+				})
+				continue
+			}
+			switch dep.Type {
+			case "", "blocks":
+				edges[id] = append(edges[id], target)
+			case "parent-child":
+				edges[id] = append(edges[id], target)
+				childRank, parentRank := issueTypeRank[issue.IssueType], issueTypeRank[parent.IssueType]
+				if childRank > 0 && parentRank > 0 && childRank > parentRank {
+					problems = append(problems, GraphProblem{
+						Kind:    graphProblemInversion,
+						IssueID: id,
+						Related: []string{target},
+						Fix: fmt.Sprintf("%s (%s) is a child of %s (%s); swap them or change the parent",
+							id, issue.IssueType, target, parent.IssueType),
+					})
+				}
+			}
+		}
+	}
+
+	for _, cycle := range findDependencyCycles(edges) {
+		problems = append(problems, GraphProblem{
+			Kind:    graphProblemCycle,
+			IssueID: cycle[0],
+			Related: cycle[1:],
+			Fix: fmt.Sprintf("break the cycle %s -> %s, e.g. bd dep remove %s %s",
+				strings.Join(cycle, " -> "), cycle[0], cycle[len(cycle)-1], cycle[0]),
+		})
+	}
+
+	return problems
+}
+
+// isOrderingDependency reports whether a dependency type orders work; a
+// dangling one of these leaves an issue blocked on nothing.
+func isOrderingDependency(depType string) bool {
+	return depType == "" || depType == "blocks" || depType == "parent-child"
+}
+
+// blockingGraphProblems counts the problems that block sync.
+func blockingGraphProblems(problems []GraphProblem) int {
+	n := 0
+	for _, p := range problems {
+		if !p.Advisory {
+			n++
+		}
+	}
+	return n
+}
+
+// findDependencyCycles returns each cycle once, as the list of IDs along it.
+func findDependencyCycles(edges map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = onStack
+		stack = append(stack, id)
+		for _, next := range edges[id] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case onStack:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == next {
+						cycles = append(cycles, append([]string{}, stack[i:]...))
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+
+	for _, id := range sortedKeys(edges) {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return cycles
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatGraphProblems renders the fix list shown when sync is blocked.
+func formatGraphProblems(problems []GraphProblem) string {
+	if len(problems) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	if blockingGraphProblems(problems) > 0 {
+		sb.WriteString("\nIssue graph problems (fix, then run any bdh mutation or 'bdh :force-sync'):\n")
+	} else {
+		sb.WriteString("\nIssue graph warnings (synced anyway):\n")
+	}
+	for i, p := range problems {
+		if i >= maxGraphProblemsShown {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(problems)-maxGraphProblemsShown))
+			break
+		}
+		kind := p.Kind
+		if p.Advisory {
+			kind += ", warning"
+		}
+		sb.WriteString(fmt.Sprintf("  - [%s] %s: %s\n", kind, p.IssueID, p.Fix))
+	}
+	return sb.String()
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func problemKinds(problems []GraphProblem) map[string][]string {
+	kinds := make(map[string][]string)
+	for _, p := range problems {
+		kinds[p.Kind] = append(kinds[p.Kind], p.IssueID)
+	}
+	return kinds
+}
+
+func TestCheckIssueGraph_CleanGraph(t *testing.T) {
+	issues := []Issue{
+		{ID: "bd-1", IssueType: "epic"},
+		{ID: "bd-2", IssueType: "task", Dependencies: []Dependency{{DependsOnID: "bd-1", Type: "parent-child"}}},
+		{ID: "bd-3", Dependencies: []Dependency{
+			{DependsOnID: "bd-2", Type: "blocks"},
+			{DependsOnID: "external:other:cap", Type: "blocks"},
+			{DependsOnID: "bd-99", Type: "discovered-from"},
+		}},
+	}
+	// discovered-from to a missing bead is still a dangling reference, but
+	// only an advisory one.
+	problems := checkIssueGraph(issues)
+	if len(problems) != 1 || problems[0].Kind != graphProblemDangling || !problems[0].Advisory {
+		t.Errorf("expected only the advisory dangling discovered-from, got %+v", problems)
+	}
+	if blockingGraphProblems(problems) != 0 {
+		t.Error("an advisory problem must not block sync")
+	}
+}
+
+func TestCheckIssueGraph_DetectsProblems(t *testing.T) {
+	issues := []Issue{
+		{ID: "bd-1", Dependencies: []Dependency{{DependsOnID: "bd-2"}}},
+		{ID: "bd-2", Dependencies: []Dependency{{DependsOnID: "bd-3", Type: "blocks"}}},
+		{ID: "bd-3", Dependencies: []Dependency{{DependsOnID: "bd-1", Type: "blocks"}}},
+		{ID: "bd-4", Dependencies: []Dependency{{DependsOnID: "bd-404", Type: "blocks"}}},
+		{ID: "bd-8", Dependencies: []Dependency{{DependsOnID: "bd-405", Type: "related"}}},
+		{ID: "bd-5", IssueType: "task"},
+		{ID: "bd-6", IssueType: "epic", Dependencies: []Dependency{{DependsOnID: "bd-5", Type: "parent-child"}}},
+		{ID: "bd-7"},
+		{ID: "bd-7"},
+	}
+
+	problems := checkIssueGraph(issues)
+	if blocking := blockingGraphProblems(problems); blocking != len(problems)-1 {
+		t.Errorf("only the related link to bd-405 should be advisory, %d of %d block", blocking, len(problems))
+	}
+	kinds := problemKinds(problems)
+	if len(kinds[graphProblemCycle]) != 1 {
+		t.Errorf("expected one cycle, got %v", kinds)
+	}
+	if strings.Join(kinds[graphProblemDangling], ",") != "bd-4,bd-8" {
+		t.Errorf("dangling = %v", kinds[graphProblemDangling])
+	}
+	if strings.Join(kinds[graphProblemInversion], ",") != "bd-6" {
+		t.Errorf("inversion = %v", kinds[graphProblemInversion])
+	}
+	if strings.Join(kinds[graphProblemDuplicateID], ",") != "bd-7" {
+		t.Errorf("duplicates = %v", kinds[graphProblemDuplicateID])
+	}
+}
+
+func TestFormatGraphProblems_CapsList(t *testing.T) {
+	var problems []GraphProblem
+	for i := 0; i < maxGraphProblemsShown+3; i++ {
+		problems = append(problems, GraphProblem{Kind: graphProblemDangling, IssueID: "bd-1", Fix: "bd dep remove bd-1 bd-2"})
+	}
+	out := formatGraphProblems(problems)
+	if !strings.Contains(out, "[dangling_dependency] bd-1: bd dep remove bd-1 bd-2") || !strings.Contains(out, "and 3 more") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestSyncToBeadHub_BlocksOnGraphProblemsUnlessForced(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open","dependencies":[{"issue_id":"bd-1","depends_on_id":"bd-404","type":"blocks"}]}
+`)
+
+	syncs := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/bdh/sync" {
+			syncs++
+		}
+		w.Write([]byte(`{"synced": true, "issues_count": 1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:  server.URL,
+		Alias:       "alice",
+	}
+
+	result := syncToBeadHub(context.Background(), cfg, nil, syncOptions{})
+	if !strings.Contains(result.Warning, "sync blocked") || len(result.GraphProblems) != 1 {
+		t.Fatalf("expected blocked sync, got %+v", result)
+	}
+	if syncs != 0 {
+		t.Errorf("server should not be called when blocked, got %d syncs", syncs)
+	}
+
+	result = syncToBeadHub(context.Background(), cfg, nil, syncOptions{Force: true})
+	if result.Warning != "" {
+		t.Errorf("forced sync warning: %s", result.Warning)
+	}
+	if syncs != 1 {
+		t.Errorf("expected forced sync to reach the server, got %d syncs", syncs)
+	}
+}
+
+func TestForceFullSync_UploadsDanglingDependency(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open","dependencies":[{"issue_id":"bd-1","depends_on_id":"bd-404","type":"blocks"}]}
+`)
+
+	var uploaded string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/bdh/sync" {
+			var req client.SyncRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			uploaded = req.IssuesJSONL
+		}
+		w.Write([]byte(`{"synced": true, "issues_count": 1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:  server.URL,
+		Alias:       "alice",
+	}
+
+	result, err := forceFullSync(context.Background(), cfg, syncOptions{Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Warning != "" || len(result.GraphProblems) != 1 {
+		t.Fatalf("expected the problem reported but not blocking, got %+v", result)
+	}
+	if !strings.Contains(uploaded, `"bd-404"`) {
+		t.Errorf(":force-sync should upload the dangling dependency, got %q", uploaded)
+	}
+}
+
+func TestSyncToBeadHub_AdvisoryGraphProblemsDoNotBlock(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open","dependencies":[{"issue_id":"bd-1","depends_on_id":"bd-0","type":"discovered-from"}]}
+`)
+
+	syncs := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/bdh/sync" {
+			syncs++
+		}
+		w.Write([]byte(`{"synced": true, "issues_count": 1}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:  server.URL,
+		Alias:       "alice",
+	}
+
+	result := syncToBeadHub(context.Background(), cfg, nil, syncOptions{})
+	if result.Warning != "" || syncs != 1 {
+		t.Fatalf("a discovered-from link to a pruned issue should not block sync, got %+v", result)
+	}
+	if out := formatGraphProblems(result.GraphProblems); !strings.Contains(out, "synced anyway") || !strings.Contains(out, "[dangling_dependency, warning] bd-1") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
@@ -19,6 +19,10 @@ func validateRepoOriginMatchesCurrent(cfg *config.Config) error {
 	if os.Getenv("BEADHUB_SKIP_REPO_CHECK") == "1" {
 		return nil
 	}
+	// A workspace that is not a git repo has nothing to check against.
+	if cfg.NoGit {
+		return nil
+	}
 
 	origin := strings.TrimSpace(os.Getenv("BEADHUB_REPO_ORIGIN"))
 	if origin == "" {
@@ -112,6 +116,9 @@ func canonicalizeOriginURL(origin string) string {
 }
 
 func currentRepoOriginBestEffort(cfg *config.Config) string {
+	if cfg.NoGit {
+		return cfg.RepoOrigin
+	}
 	origin := strings.TrimSpace(os.Getenv("BEADHUB_REPO_ORIGIN"))
 	if origin != "" {
 		return origin
@@ -123,8 +130,8 @@ func currentRepoOriginBestEffort(cfg *config.Config) string {
 	return cfg.RepoOrigin
 }
 
-func currentGitBranch(repoRoot string) string {
-	ctx, cancel := context.WithTimeout(context.Background(), 750*time.Millisecond)
+func currentGitBranch(ctx context.Context, repoRoot string) string {
+	ctx, cancel := context.WithTimeout(ctx, 750*time.Millisecond)
 	defer cancel()
 
 	args := []string{"rev-parse", "--abbrev-ref", "HEAD"}
@@ -143,8 +150,8 @@ func currentGitBranch(repoRoot string) string {
 	return branch
 }
 
-func currentRepoRoot() string {
-	ctx, cancel := context.WithTimeout(context.Background(), 750*time.Millisecond)
+func currentRepoRoot(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, 750*time.Millisecond)
 	defer cancel()
 
 	root, err := gitRepoRoot(ctx)
@@ -154,9 +161,27 @@ func currentRepoRoot() string {
 	return root
 }
 
-func refreshPresenceHeartbeat(cfg *config.Config) {
-	repoRoot := currentRepoRoot()
-	branch := currentGitBranch(repoRoot)
+// refreshPresenceHeartbeat refreshes presence unless another command in
+// this workspace did so within http.presence_interval_seconds.
+func refreshPresenceHeartbeat(ctx context.Context, cfg *config.Config) {
+	now := time.Now()
+	if !presenceRefreshDue(cfg, now) {
+		return
+	}
+	stampPresenceRefresh(cfg, now)
+
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	if _, err := c.RefreshPresence(ctx, presenceRequest(ctx, cfg, now)); err != nil {
+		unreachable(ctx, err)
+		clearPresenceStamp()
+	}
+}
+
+// presenceRequest builds the presence refresh for this workspace, including
+// any local do-not-disturb status.
+func presenceRequest(ctx context.Context, cfg *config.Config, now time.Time) *client.RefreshPresenceRequest {
+	repoRoot := currentRepoRoot(ctx)
+	branch := currentGitBranch(ctx, repoRoot)
 	repoOrigin := currentRepoOriginBestEffort(cfg)
 
 	hostname, _ := os.Hostname()
@@ -167,11 +192,7 @@ func refreshPresenceHeartbeat(cfg *config.Config) {
 		}
 	}
 
-	c := newBeadHubClient(cfg.BeadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
-	defer cancel()
-
-	_, _ = c.RefreshPresence(ctx, &client.RefreshPresenceRequest{
+	req := &client.RefreshPresenceRequest{
 		WorkspaceID:     cfg.WorkspaceID,
 		Alias:           cfg.Alias,
 		HumanName:       cfg.HumanName,
@@ -185,5 +206,9 @@ func refreshPresenceHeartbeat(cfg *config.Config) {
 		Branch:          branch,
 		Program:         "claude-code",
 		Role:            cfg.Role,
-	})
+		Tags:            append([]string{}, cfg.Tags...),
+	}
+	applyDNDPresence(req, now)
+	applyAwayPresence(cfg, req)
+	return req
 }
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :hook-context is meant to run from an agent hook at the start of every turn
+// (Claude Code's UserPromptSubmit, Cursor's beforeSubmitPrompt), so its output
+// lands in the model's context each time. It prints only what the agent must
+// act on, most urgent first, and stops at a token budget. It never fails the
+// hook: anything it cannot fetch is left out, and with nothing to report it
+// prints nothing at all.
+
+const (
+	defaultHookContextTokens = 300
+	// hookContextCharsPerToken is a rough estimate, good enough for a budget.
+	hookContextCharsPerToken = 4
+	hookContextTimeout       = 3 * time.Second
+	// maxHookContextLine keeps one long subject from eating the budget.
+	maxHookContextLine = 160
+)
+
+// HookReservationConflict is a file the agent modified that someone else
+// has reserved.
+type HookReservationConflict struct {
+	Path             string `json:"path"`
+	HeldBy           string `json:"held_by"`
+	RemainingSeconds int    `json:"remaining_seconds"`
+}
+
+// HookContext is the coordination state summarized for a hook.
+type HookContext struct {
+	Alias        string                    `json:"alias"`
+	UrgentMail   []UrgentMail              `json:"urgent_mail,omitempty"`
+	WaitingChats []PendingConversation     `json:"waiting_chats,omitempty"`
+	Conflicts    []HookReservationConflict `json:"reservation_conflicts,omitempty"`
+	Claims       []ClaimInfo               `json:"claims,omitempty"`
+	UnreadMail   int                       `json:"unread_mail,omitempty"`
+}
+
+var (
+	hookContextMaxTokens int
+	hookContextJSON      bool
+)
+
+var hookContextCmd = &cobra.Command{
+	Use:   ":hook-context",
+	Short: "Print a compact coordination snippet for agent hooks",
+	Long: `Print a short coordination summary for injection into an agent's
+context at the start of each turn: urgent mail, chats waiting for your
+reply, files you changed that someone else has reserved, and your claims.
+
+Output is capped at --max-tokens (estimated) and is empty when there is
+nothing to report. Errors are never reported, so a broken network never
+breaks the hook.
+
+Claude Code (.claude/settings.json):
+  "hooks": {"UserPromptSubmit": [{"hooks": [
+    {"type": "command", "command": "bdh :hook-context"}]}]}
+
+Examples:
+  bdh :hook-context                  # Default budget (~300 tokens)
+  bdh :hook-context --max-tokens 100 # Tighter budget
+  bdh :hook-context --json           # Structured output`,
+	Args: cobra.NoArgs,
+	RunE: runHookContext,
+}
+
+func init() {
+	hookContextCmd.Flags().IntVar(&hookContextMaxTokens, "max-tokens", defaultHookContextTokens, "Approximate output budget in tokens")
+	hookContextCmd.Flags().BoolVar(&hookContextJSON, "json", false, "Output as JSON")
+}
+
+func runHookContext(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// The end-of-command notifications would blow the budget
+	SuppressNotifications()
+
+	cfg, err := config.Load()
+	if err != nil || cfg.Validate() != nil {
+		return nil
+	}
+	hc := fetchHookContext(ctx, cfg)
+	if hookContextJSON {
+		fmt.Print(marshalJSONOrFallback(hc))
+		return nil
+	}
+	fmt.Print(formatHookContext(hc, hookContextMaxTokens))
+	return nil
+}
+
+// fetchHookContext gathers the snippet's data, best-effort.
+func fetchHookContext(ctx context.Context, cfg *config.Config) *HookContext {
+	hc := &HookContext{Alias: cfg.Alias}
+	ctx, cancel := context.WithTimeout(ctx, hookContextTimeout)
+	defer cancel()
+
+	if aw, err := newAwebClient(ctx, cfg.BeadhubURL); err == nil && aw != nil {
+		if inbox, err := aw.Inbox(ctx, aweb.InboxParams{UnreadOnly: true, Limit: 500}); err == nil {
+			hc.UrgentMail = urgentMailFrom(inbox.Messages)
+			hc.UnreadMail = len(inbox.Messages)
+		}
+		if pending, err := aw.ChatPending(ctx); err == nil {
+			for _, conv := range pendingConversationsFrom(pending) {
+				if conv.SenderWaiting && strings.TrimSpace(conv.LastFrom) != "" {
+					hc.WaitingChats = append(hc.WaitingChats, conv)
+				}
+			}
+		}
+		if modified := modifiedPaths(ctx, cfg); len(modified) > 0 {
+			if locks, err := aw.ReservationList(ctx, ""); err == nil {
+				hc.Conflicts = hookReservationConflicts(modified, locks.Reservations, cfg.Alias, serverNow())
+			}
+		}
+	}
+
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	if resp, err := c.Workspaces(ctx, &client.WorkspacesRequest{Alias: cfg.Alias, IncludeClaims: true}); err == nil {
+		for _, ws := range resp.Workspaces {
+			if ws.WorkspaceID != cfg.WorkspaceID {
+				continue
+			}
+			for _, claim := range ws.Claims {
+				hc.Claims = append(hc.Claims, ClaimInfo{BeadID: claim.BeadID, Title: claim.Title, ClaimedAt: claim.ClaimedAt})
+			}
+		}
+	}
+	return hc
+}
+
+// modifiedPaths returns the files changed in the working tree, as
+// auto-reserve would reserve them.
+func modifiedPaths(ctx context.Context, cfg *config.Config) []string {
+	root, err := gitRepoRoot(ctx)
+	if err != nil {
+		return nil
+	}
+	entries, err := gitStatusPorcelainV1Z(ctx, root, cfg.ReserveUntrackedEnabled())
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for path := range desiredLockPaths(entries, cfg.ReserveUntrackedEnabled()) {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// hookReservationConflicts reports modified paths reserved by someone else.
+func hookReservationConflicts(modified []string, reservations []aweb.ReservationView, myAlias string, now time.Time) []HookReservationConflict {
+	held := blockingReservations(reservations, myAlias)
+	var conflicts []HookReservationConflict
+	for _, path := range modified {
+		if r, ok := coveringReservation(held, path); ok {
+			conflicts = append(conflicts, HookReservationConflict{
+				Path:             path,
+				HeldBy:           r.HolderAlias,
+				RemainingSeconds: ttlRemainingSeconds(r.ExpiresAt, now),
+			})
+		}
+	}
+	return conflicts
+}
+
+// formatHookContext renders hc most urgent first, dropping whatever does not
+// fit in maxTokens. Returns "" when there is nothing to report.
+func formatHookContext(hc *HookContext, maxTokens int) string {
+	var lines []string
+	for _, m := range hc.UrgentMail {
+		line := fmt.Sprintf("! URGENT mail from %s", m.From)
+		if m.Subject != "" {
+			line += ": " + m.Subject
+		}
+		lines = append(lines, line+" (bdh :aweb mail list)")
+	}
+	for _, conv := range hc.WaitingChats {
+		lines = append(lines, fmt.Sprintf("! %s is waiting for your reply: %s (bdh :aweb chat send %s \"...\")",
+			conv.LastFrom, truncateAlertBody(conv.LastMessage), conv.LastFrom))
+	}
+	for _, conflict := range hc.Conflicts {
+		holder := conflict.HeldBy
+		if holder == "" {
+			holder = "another agent"
+		}
+		lines = append(lines, fmt.Sprintf("! %s is reserved by %s (%s left) - coordinate before editing it",
+			conflict.Path, holder, formatDuration(conflict.RemainingSeconds)))
+	}
+	if len(hc.Claims) > 0 {
+		claims := make([]string, 0, len(hc.Claims))
+		for _, claim := range hc.Claims {
+			if claim.Title != "" {
+				claims = append(claims, fmt.Sprintf("%s %q", claim.BeadID, claim.Title))
+			} else {
+				claims = append(claims, claim.BeadID)
+			}
+		}
+		lines = append(lines, "Your claims: "+strings.Join(claims, ", "))
+	}
+	if other := hc.UnreadMail - len(hc.UrgentMail); other > 0 {
+		lines = append(lines, fmt.Sprintf("%d unread message(s) (bdh :aweb mail list)", other))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	header := fmt.Sprintf("[bdh coordination for %s]", hc.Alias)
+	budget := maxTokens * hookContextCharsPerToken
+	used := len(header) + 1
+	var sb strings.Builder
+	sb.WriteString(header + "\n")
+	for i, line := range lines {
+		if r := []rune(line); len(r) > maxHookContextLine {
+			line = string(r[:maxHookContextLine-1]) + "…"
+		}
+		if maxTokens > 0 && used+len(line)+1 > budget {
+			sb.WriteString(fmt.Sprintf("(+%d more - run bdh :status)\n", len(lines)-i))
+			break
+		}
+		sb.WriteString(line + "\n")
+		used += len(line) + 1
+	}
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+)
+
+func TestFormatHookContext_PriorityAndBudget(t *testing.T) {
+	hc := &HookContext{
+		Alias:        "alice",
+		UrgentMail:   []UrgentMail{{MessageID: "m1", From: "bob", Subject: "Prod is down"}},
+		WaitingChats: []PendingConversation{{LastFrom: "carol", LastMessage: "can you\nreview?", SenderWaiting: true}},
+		Conflicts:    []HookReservationConflict{{Path: "api/router.go", HeldBy: "dave", RemainingSeconds: 600}},
+		Claims:       []ClaimInfo{{BeadID: "bd-42", Title: "Fix login"}, {BeadID: "bd-7"}},
+		UnreadMail:   3,
+	}
+
+	out := formatHookContext(hc, 0)
+	want := `[bdh coordination for alice]
+! URGENT mail from bob: Prod is down (bdh :aweb mail list)
+! carol is waiting for your reply: can you review? (bdh :aweb chat send carol "...")
+! api/router.go is reserved by dave (10m left) - coordinate before editing it
+Your claims: bd-42 "Fix login", bd-7
+2 unread message(s) (bdh :aweb mail list)
+`
+	if out != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+
+	// A tight budget keeps the most urgent lines and says what was dropped
+	out = formatHookContext(hc, 40)
+	if !strings.Contains(out, "URGENT mail from bob") || strings.Contains(out, "Your claims") || !strings.HasSuffix(out, "(+4 more - run bdh :status)\n") {
+		t.Errorf("budgeted output:\n%s", out)
+	}
+	if len(out) > 40*hookContextCharsPerToken+40 {
+		t.Errorf("output too long (%d chars)", len(out))
+	}
+
+	if out := formatHookContext(&HookContext{Alias: "alice"}, 300); out != "" {
+		t.Errorf("expected no output with nothing to report, got %q", out)
+	}
+}
+
+func TestHookReservationConflicts(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	reservations := []aweb.ReservationView{
+		{ResourceKey: "a.go", HolderAlias: "bob", ExpiresAt: now.Add(5 * time.Minute).Format(time.RFC3339)},
+		{ResourceKey: "b.go", HolderAlias: "alice"},
+		{ResourceKey: "c.go", HolderAlias: "carol", Metadata: map[string]any{"exclusive": false}},
+		{ResourceKey: "d.go", HolderAlias: "dave"},
+	}
+	conflicts := hookReservationConflicts([]string{"a.go", "b.go", "c.go"}, reservations, "alice", now)
+	if len(conflicts) != 1 || conflicts[0].Path != "a.go" || conflicts[0].HeldBy != "bob" || conflicts[0].RemainingSeconds != 300 {
+		t.Errorf("conflicts = %+v", conflicts)
+	}
+}
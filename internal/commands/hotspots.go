@@ -0,0 +1,368 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :hotspots ranks the paths agents fight over. Servers with reservation
+// history report it for the whole project; otherwise bdh falls back to the
+// reservation attempts this workspace made itself, which auto-reserve records
+// in .beadhub-cache/reservation-log.json.
+
+const (
+	// maxReservationLogEntries bounds the local reservation log.
+	maxReservationLogEntries = 1000
+	// reservationLogRetention drops local events older than this.
+	reservationLogRetention = 30 * 24 * time.Hour
+
+	defaultHotspotsWindow = 7 * 24 * time.Hour
+	defaultHotspotsLimit  = 10
+
+	// ownershipShare is the share of acquisitions above which one agent is
+	// suggested as a path's owner.
+	ownershipShare = 0.6
+)
+
+const (
+	hotspotsSourceServer = "server"
+	hotspotsSourceLocal  = "local"
+)
+
+// Hotspot aggregates the reservation activity on one path.
+type Hotspot struct {
+	Path         string         `json:"path"`
+	Conflicts    int            `json:"conflicts"`
+	Acquisitions int            `json:"acquisitions"`
+	Agents       []string       `json:"agents"`
+	Holders      map[string]int `json:"holders,omitempty"`
+	LastAt       string         `json:"last_at,omitempty"`
+	Suggestion   string         `json:"suggestion,omitempty"`
+}
+
+// HotspotsResult is the output of :hotspots.
+type HotspotsResult struct {
+	Source   string    `json:"source"`
+	Since    time.Time `json:"since"`
+	Events   int       `json:"events"`
+	Hotspots []Hotspot `json:"hotspots"`
+	Warning  string    `json:"warning,omitempty"`
+}
+
+var (
+	hotspotsSince time.Duration
+	hotspotsLimit int
+	hotspotsLocal bool
+	hotspotsJSON  bool
+)
+
+var hotspotsCmd = &cobra.Command{
+	Use:   ":hotspots",
+	Short: "Show the files agents contend for most",
+	Long: `Rank the paths with the most reservation conflicts across agents, so
+the team can split up busy files or give them an owner.
+
+Uses the project-wide reservation history when the server provides it.
+Otherwise (or with --local) only reservation attempts made from this
+workspace are counted.
+
+Examples:
+  bdh :hotspots               # Last 7 days
+  bdh :hotspots --since 720h  # Last 30 days
+  bdh :hotspots --limit 25    # Show more paths
+  bdh :hotspots --json        # Output as JSON`,
+	Args: cobra.NoArgs,
+	RunE: runHotspots,
+}
+
+func init() {
+	hotspotsCmd.Flags().DurationVar(&hotspotsSince, "since", defaultHotspotsWindow, "Only count reservation activity within this long")
+	hotspotsCmd.Flags().IntVar(&hotspotsLimit, "limit", defaultHotspotsLimit, "Maximum number of paths to show")
+	hotspotsCmd.Flags().BoolVar(&hotspotsLocal, "local", false, "Only use this workspace's reservation log")
+	hotspotsCmd.Flags().BoolVar(&hotspotsJSON, "json", false, "Output as JSON")
+}
+
+func runHotspots(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+	if hotspotsSince <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	result, err := fetchHotspots(ctx, cfg, c, time.Now().Add(-hotspotsSince), hotspotsLocal)
+	if err != nil {
+		return err
+	}
+	if hotspotsLimit > 0 && len(result.Hotspots) > hotspotsLimit {
+		result.Hotspots = result.Hotspots[:hotspotsLimit]
+	}
+	fmt.Print(formatHotspotsOutput(result, hotspotsJSON))
+	return nil
+}
+
+// fetchHotspots loads reservation events since the given time, from the
+// server when possible, and ranks them.
+func fetchHotspots(ctx context.Context, cfg *config.Config, c BeadHubAPI, since time.Time, localOnly bool) (*HotspotsResult, error) {
+	events, source, warning, err := loadReservationEvents(ctx, cfg, c, since, localOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &HotspotsResult{
+		Source:   source,
+		Since:    since,
+		Events:   len(events),
+		Hotspots: aggregateHotspots(events),
+		Warning:  warning,
+	}, nil
+}
+
+// loadReservationEvents returns the reservation events since the given
+// time: the project's history when the server has it, otherwise this
+// workspace's log. source is hotspotsSourceServer or hotspotsSourceLocal;
+// warning explains a fallback to the local log.
+func loadReservationEvents(ctx context.Context, cfg *config.Config, c BeadHubAPI, since time.Time, localOnly bool) (events []client.ReservationEvent, source, warning string, err error) {
+	source = hotspotsSourceLocal
+	fromServer := false
+	if !localOnly && serverSupports(ctx, cfg, featureReservationHistory) {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.ReservationHistory(ctx, &client.ReservationHistoryRequest{Since: since.UTC().Format(time.RFC3339)})
+		cancel()
+		var clientErr *client.Error
+		switch {
+		case err == nil:
+			events, fromServer = resp.Events, true
+			source = hotspotsSourceServer
+		case errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound:
+			// Legacy server without the endpoint
+		case errors.As(err, &clientErr):
+			return nil, "", "", fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		case client.IsPayloadError(err):
+			return nil, "", "", err
+		default:
+			warning = fmt.Sprintf("could not reach BeadHub (%v) - showing this workspace's reservations only", err)
+		}
+	}
+	if !fromServer {
+		path, err := reservationLogPath()
+		if err != nil {
+			return nil, "", "", err
+		}
+		events, err = loadReservationLog(path)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("reading reservation log: %w", err)
+		}
+	}
+
+	var recent []client.ReservationEvent
+	for _, e := range events {
+		if at, ok := parseTimeBestEffort(e.At); ok && at.Before(since) {
+			continue
+		}
+		recent = append(recent, e)
+	}
+	return recent, source, warning, nil
+}
+
+// aggregateHotspots groups events by path and ranks paths by conflicts, then
+// by how many agents touched them, then by acquisitions.
+func aggregateHotspots(events []client.ReservationEvent) []Hotspot {
+	byPath := make(map[string]*Hotspot)
+	agents := make(map[string]map[string]bool)
+	lastAt := make(map[string]time.Time)
+	for _, e := range events {
+		if e.Path == "" || (e.Kind != client.ReservationEventConflict && e.Kind != client.ReservationEventAcquired) {
+			continue
+		}
+		h := byPath[e.Path]
+		if h == nil {
+			h = &Hotspot{Path: e.Path, Holders: make(map[string]int)}
+			byPath[e.Path] = h
+			agents[e.Path] = make(map[string]bool)
+		}
+		if e.Kind == client.ReservationEventConflict {
+			h.Conflicts++
+		} else {
+			h.Acquisitions++
+			if e.HolderAlias != "" {
+				h.Holders[e.HolderAlias]++
+			}
+		}
+		for _, alias := range []string{e.HolderAlias, e.RequestedBy} {
+			if alias != "" {
+				agents[e.Path][alias] = true
+			}
+		}
+		if at, ok := parseTimeBestEffort(e.At); ok && at.After(lastAt[e.Path]) {
+			lastAt[e.Path] = at
+			h.LastAt = e.At
+		}
+	}
+
+	hotspots := make([]Hotspot, 0, len(byPath))
+	for path, h := range byPath {
+		for alias := range agents[path] {
+			h.Agents = append(h.Agents, alias)
+		}
+		sort.Strings(h.Agents)
+		if len(h.Holders) == 0 {
+			h.Holders = nil
+		}
+		h.Suggestion = hotspotSuggestion(h)
+		hotspots = append(hotspots, *h)
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		a, b := hotspots[i], hotspots[j]
+		if a.Conflicts != b.Conflicts {
+			return a.Conflicts > b.Conflicts
+		}
+		if len(a.Agents) != len(b.Agents) {
+			return len(a.Agents) > len(b.Agents)
+		}
+		if a.Acquisitions != b.Acquisitions {
+			return a.Acquisitions > b.Acquisitions
+		}
+		return a.Path < b.Path
+	})
+	return hotspots
+}
+
+// hotspotSuggestion proposes an owner when one agent holds the path most of
+// the time, and a refactor when several agents keep colliding on it.
+func hotspotSuggestion(h *Hotspot) string {
+	if h.Conflicts == 0 {
+		return ""
+	}
+	topAlias, top := "", 0
+	for alias, n := range h.Holders {
+		if n > top || (n == top && alias < topAlias) {
+			topAlias, top = alias, n
+		}
+	}
+	if h.Acquisitions > 1 && float64(top) >= ownershipShare*float64(h.Acquisitions) {
+		return fmt.Sprintf("mostly held by %s - consider making them its owner", topAlias)
+	}
+	if len(h.Agents) >= 3 {
+		return fmt.Sprintf("contended by %d agents - consider splitting it up", len(h.Agents))
+	}
+	return ""
+}
+
+func reservationLogPath() (string, error) {
+	return cacheFilePath("reservation-log.json")
+}
+
+func loadReservationLog(path string) ([]client.ReservationEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var events []client.ReservationEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func saveReservationLog(path string, events []client.ReservationEvent) error {
+	return writeJSONAtomic(path, events)
+}
+
+// recordReservationEvents appends an auto-reserve run's acquisitions and
+// conflicts to the local reservation log. Best-effort: errors are ignored.
+func recordReservationEvents(cfg *config.Config, result *AutoReserveResult, now time.Time) {
+	if result == nil || len(result.Acquired) == 0 && len(result.Conflicts) == 0 {
+		return
+	}
+	path, err := reservationLogPath()
+	if err != nil {
+		return
+	}
+	events, _ := loadReservationLog(path)
+
+	at := now.UTC().Format(time.RFC3339)
+	for _, p := range result.Acquired {
+		events = append(events, client.ReservationEvent{Path: p, Kind: client.ReservationEventAcquired, HolderAlias: cfg.Alias, At: at})
+	}
+	for _, conflict := range result.Conflicts {
+		events = append(events, client.ReservationEvent{
+			Path:        conflict.ResourceKey,
+			Kind:        client.ReservationEventConflict,
+			HolderAlias: conflict.HeldBy,
+			RequestedBy: cfg.Alias,
+			At:          at,
+		})
+	}
+
+	cutoff := now.Add(-reservationLogRetention)
+	kept := events[:0]
+	for _, e := range events {
+		if ts, ok := parseTimeBestEffort(e.At); ok && ts.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	if len(kept) > maxReservationLogEntries {
+		kept = kept[len(kept)-maxReservationLogEntries:]
+	}
+	_ = saveReservationLog(path, kept)
+}
+
+func formatHotspotsOutput(result *HotspotsResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString("Warning: " + result.Warning + "\n\n")
+	}
+	scope := "project-wide"
+	if result.Source == hotspotsSourceLocal {
+		scope = "this workspace only"
+	}
+	sb.WriteString(fmt.Sprintf("Reservation hotspots since %s (%s, %d events)\n",
+		result.Since.Local().Format("2006-01-02 15:04"), scope, result.Events))
+
+	if len(result.Hotspots) == 0 {
+		sb.WriteString("\nNo reservation activity in this period.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("\n")
+	for i, h := range result.Hotspots {
+		sb.WriteString(fmt.Sprintf("%2d. %s\n", i+1, h.Path))
+		sb.WriteString(fmt.Sprintf("    %d conflict(s), %d reservation(s) by %s\n", h.Conflicts, h.Acquisitions, strings.Join(h.Agents, ", ")))
+		if h.Suggestion != "" {
+			sb.WriteString("    → " + h.Suggestion + "\n")
+		}
+	}
+	if result.Source == hotspotsSourceLocal {
+		sb.WriteString("\nOnly reservations attempted from this workspace are counted.\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestAggregateHotspots_RanksAndSuggests(t *testing.T) {
+	ev := func(path, kind, holder, requester string) client.ReservationEvent {
+		return client.ReservationEvent{Path: path, Kind: kind, HolderAlias: holder, RequestedBy: requester, At: "2026-10-15T10:00:00Z"}
+	}
+	acquired, conflict := client.ReservationEventAcquired, client.ReservationEventConflict
+	hotspots := aggregateHotspots([]client.ReservationEvent{
+		ev("api/router.go", acquired, "alice", ""),
+		ev("api/router.go", conflict, "alice", "bob"),
+		ev("api/router.go", conflict, "bob", "carol"),
+		ev("api/router.go", acquired, "bob", ""),
+		ev("db/schema.sql", acquired, "dave", ""),
+		ev("db/schema.sql", acquired, "dave", ""),
+		ev("db/schema.sql", acquired, "dave", ""),
+		ev("db/schema.sql", conflict, "dave", "erin"),
+		ev("README.md", acquired, "alice", ""),
+		ev("ignored.go", "released", "alice", ""),
+	})
+
+	if len(hotspots) != 3 {
+		t.Fatalf("hotspots = %+v", hotspots)
+	}
+	router, schema, readme := hotspots[0], hotspots[1], hotspots[2]
+	if router.Path != "api/router.go" || router.Conflicts != 2 || strings.Join(router.Agents, ",") != "alice,bob,carol" {
+		t.Errorf("router = %+v", router)
+	}
+	if router.Suggestion != "contended by 3 agents - consider splitting it up" {
+		t.Errorf("router suggestion = %q", router.Suggestion)
+	}
+	if schema.Path != "db/schema.sql" || schema.Suggestion != "mostly held by dave - consider making them its owner" {
+		t.Errorf("schema = %+v", schema)
+	}
+	if readme.Conflicts != 0 || readme.Suggestion != "" {
+		t.Errorf("readme = %+v", readme)
+	}
+}
+
+func TestFetchHotspots_FallsBackToLocalLog(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/reservations/history" {
+			if r.URL.Query().Get("since") == "" {
+				t.Errorf("missing since: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"events":[{"resource_key":"a.go","kind":"conflict","holder_alias":"bob","requested_by":"carol","at":"2026-10-15T10:00:00Z"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	cfg := &config.Config{BeadhubURL: server.URL, Alias: "alice"}
+	c := client.New(server.URL)
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-defaultHotspotsWindow)
+
+	// Legacy server: project-wide history from the endpoint
+	resetCapabilitiesMemo()
+	result, err := fetchHotspots(context.Background(), cfg, c, since, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Source != hotspotsSourceServer || len(result.Hotspots) != 1 || result.Hotspots[0].Path != "a.go" {
+		t.Errorf("server result = %+v", result)
+	}
+
+	// --local reads what auto-reserve recorded, within the window
+	recordReservationEvents(cfg, &AutoReserveResult{Acquired: []string{"old.go"}}, now.Add(-10*24*time.Hour))
+	recordReservationEvents(cfg, &AutoReserveResult{
+		Acquired:  []string{"b.go"},
+		Conflicts: []ReservationConflict{{ResourceKey: "c.go", HeldBy: "bob"}},
+	}, now)
+	result, err = fetchHotspots(context.Background(), cfg, c, since, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Source != hotspotsSourceLocal || result.Events != 2 || result.Hotspots[0].Path != "c.go" {
+		t.Errorf("local result = %+v", result)
+	}
+	out := formatHotspotsOutput(result, false)
+	for _, want := range []string{"this workspace only, 2 events", " 1. c.go\n    1 conflict(s), 0 reservation(s) by alice, bob\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -8,11 +8,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
+	"github.com/beadhub/bdh/internal/bd"
 	"github.com/beadhub/bdh/internal/beads"
 	"github.com/beadhub/bdh/internal/client"
 	"github.com/beadhub/bdh/internal/config"
@@ -28,6 +30,8 @@ var (
 	initUpdate     bool
 	initInjectDocs bool
 	initSetupHooks bool
+	initInvite     string
+	initNoGit      bool
 )
 
 var initCmd = &cobra.Command{
@@ -40,8 +44,8 @@ This command will:
 2. Create .beadhub configuration file
 
 Configuration sources (in priority order):
-1. Command line flags (--beadhub-url, --alias, --human, --project, --role)
-2. Environment variables (BEADHUB_URL, BEADHUB_ALIAS, BEADHUB_HUMAN, BEADHUB_PROJECT, BEADHUB_ROLE)
+1. Command line flags (--beadhub-url, --alias, --human, --project, --role, --invite-token)
+2. Environment variables (BEADHUB_URL, BEADHUB_ALIAS, BEADHUB_HUMAN, BEADHUB_PROJECT, BEADHUB_ROLE, BEADHUB_INVITE_TOKEN)
 3. .env file in current directory
 4. Interactive prompts (TTY mode only)
 5. Defaults (role: agent, alias: server-suggested, human: $USER)
@@ -49,10 +53,24 @@ Configuration sources (in priority order):
 Default alias format: <name>-<role> (e.g., alice-implementer, bob-reviewer).
 The server suggests a unique name prefix per project; you can override in TTY mode.
 
+If other git worktrees of this repo already have a .beadhub, :init lists them,
+suggests an alias continuing their numbering (alice-dev-2 -> alice-dev-3), and
+warns when the alias matches or looks like one of theirs.
+
 Use --update to update the workspace's hostname and workspace_path on the server.
-This is useful when moving a workspace to a different machine or directory.`,
+This is useful when moving a workspace to a different machine or directory.
+
+Workspaces that are not git repositories (docs folders, data workspaces) are
+detected and registered with a stable synthetic origin; pass --no-git to force
+this inside a repository. Messaging, claims and sync work as usual; origin
+checks and auto-reserve of modified files are off.
+
+Teammates can print a ready-made :init command for you with 'bdh :team invite'.
+Its --invite-token claims the workspace they provisioned, where the server
+supports invites.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runInit()
+		ctx := cmd.Context()
+		return runInit(ctx)
 	},
 }
 
@@ -65,6 +83,8 @@ func init() {
 	initCmd.Flags().BoolVar(&initUpdate, "update", false, "Update workspace location (hostname/path) on server")
 	initCmd.Flags().BoolVar(&initInjectDocs, "inject-docs", false, "Inject bdh instructions into CLAUDE.md/AGENTS.md")
 	initCmd.Flags().BoolVar(&initSetupHooks, "setup-hooks", false, "Set up Claude Code hooks for chat notifications")
+	initCmd.Flags().StringVar(&initInvite, "invite-token", "", "Invite token from 'bdh :team invite'")
+	initCmd.Flags().BoolVar(&initNoGit, "no-git", false, "Register a workspace that is not a git repository")
 }
 
 // isTTY returns true if stdin is a terminal.
@@ -74,7 +94,7 @@ func isTTY() bool {
 
 // runInit implements the :init command logic.
 // Flags are parsed by Cobra and stored in initURL, initAlias, etc.
-func runInit() error {
+func runInit(ctx context.Context) error {
 	// Load .env best-effort (workspace root preferred) so env-based config works even
 	// when invoked from a subdirectory.
 	loadDotenvBestEffort()
@@ -152,11 +172,11 @@ func runInit() error {
 			repoOrigin = cfg.RepoOrigin
 		}
 
-		c, err := newBeadHubClientRequired(cfg.BeadhubURL)
+		c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
 		if err != nil {
 			return err
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 		defer cancel()
 		workspaceResp, err := c.RegisterWorkspace(ctx, &client.RegisterWorkspaceRequest{
 			RepoOrigin:    repoOrigin,
@@ -203,7 +223,7 @@ func runInit() error {
 
 	// Branch based on API key existence:
 	// - Always use /v1/init endpoint (gets API key + creates all resources)
-	return runInitWithNewEndpoint(needsBeadsInit)
+	return runInitWithNewEndpoint(ctx, needsBeadsInit)
 }
 
 // resolveConfig returns value with priority: CLI flag > env var > default.
@@ -300,14 +320,9 @@ func promptForAlias(suggested string) (string, error) {
 	return strings.ToLower(input), nil
 }
 
-func suggestAliasForRepo(beadhubURL, repoOrigin, role, apiKey string) (string, error) {
-	var c *client.Client
-	if strings.TrimSpace(apiKey) != "" {
-		c = client.NewWithAPIKey(beadhubURL, apiKey)
-	} else {
-		c = client.New(beadhubURL)
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+func suggestAliasForRepo(ctx context.Context, beadhubURL, repoOrigin, role, apiKey string) (string, error) {
+	c := beadHubClientFor(ctx, beadhubURL, strings.TrimSpace(apiKey))
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	resp, err := c.SuggestNamePrefix(ctx, &client.SuggestNamePrefixRequest{OriginURL: repoOrigin})
@@ -323,9 +338,9 @@ func suggestAliasForRepo(beadhubURL, repoOrigin, role, apiKey string) (string, e
 	return fmt.Sprintf("%s-%s", resp.NamePrefix, config.RoleToAliasPrefix(role)), nil
 }
 
-func suggestAliasForProject(beadhubURL, projectSlug, role string) (string, error) {
-	c := client.New(beadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+func suggestAliasForProject(ctx context.Context, beadhubURL, projectSlug, role string) (string, error) {
+	c := beadHubClientFor(ctx, beadhubURL, "")
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	resp, err := c.SuggestAliasPrefixByProject(ctx, &client.SuggestAliasPrefixRequest{ProjectSlug: projectSlug})
@@ -407,14 +422,26 @@ func addToGitignore(entry string) error {
 
 // runInitWithNewEndpoint implements the new init flow using POST /v1/init.
 // This atomically creates project, repo, workspace, and API key in one call.
-func runInitWithNewEndpoint(needsBeadsInit bool) error {
-	// Get git remote origin
+func runInitWithNewEndpoint(ctx context.Context, needsBeadsInit bool) error {
+	// Get git remote origin, or a synthetic one outside git
 	repoOrigin := os.Getenv("BEADHUB_REPO_ORIGIN")
+	noGit := initNoGit
+	if repoOrigin == "" && !noGit && currentRepoRoot(ctx) == "" {
+		fmt.Println("Not a git repository: registering without git (origin checks and auto-reserve are off).")
+		noGit = true
+	}
 	if repoOrigin == "" {
 		var err error
-		repoOrigin, err = getGitOrigin()
-		if err != nil {
-			return fmt.Errorf("failed to get git remote origin: %w\n(are you in a git repository?)", err)
+		if noGit {
+			repoOrigin, err = currentSyntheticRepoOrigin()
+			if err != nil {
+				return err
+			}
+		} else {
+			repoOrigin, err = getGitOrigin()
+			if err != nil {
+				return fmt.Errorf("failed to get git remote origin: %w\n(add an origin remote, or use --no-git to register without git)", err)
+			}
 		}
 	}
 
@@ -446,6 +473,10 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 		role = "agent"
 	}
 
+	// Other worktrees of this repo on this machine, used to continue their
+	// alias numbering and to warn about look-alike aliases
+	siblings := findSiblingWorktrees(ctx)
+
 	// Get alias with priority: CLI flag > env var > prompt (TTY) > default
 	aliasFromFlag := initAlias != ""
 	aliasFromEnv := os.Getenv("BEADHUB_ALIAS") != ""
@@ -457,7 +488,7 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 		// Try project-based lookup first if --project is provided
 		projectSlugForSuggestion := resolveConfig(initProject, "BEADHUB_PROJECT", "")
 		if projectSlugForSuggestion != "" {
-			if serverSuggested, err := suggestAliasForProject(beadhubURL, projectSlugForSuggestion, role); err == nil {
+			if serverSuggested, err := suggestAliasForProject(ctx, beadhubURL, projectSlugForSuggestion, role); err == nil {
 				suggestedAlias = serverSuggested
 			} else {
 				var clientErr *client.Error
@@ -465,7 +496,7 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 					return fmt.Errorf("failed to get alias suggestion: %w", err)
 				}
 			}
-		} else if serverSuggested, err := suggestAliasForRepo(beadhubURL, repoOrigin, role, apiKeyFromEnv()); err == nil {
+		} else if serverSuggested, err := suggestAliasForRepo(ctx, beadhubURL, repoOrigin, role, apiKeyFromEnv()); err == nil {
 			suggestedAlias = serverSuggested
 		} else {
 			var clientErr *client.Error
@@ -474,6 +505,12 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 			}
 		}
 		if isTTY() {
+			if len(siblings) > 0 {
+				fmt.Print(formatSiblingWorktrees(siblings))
+				if fromSiblings := suggestAliasFromSiblings(siblings); fromSiblings != "" {
+					suggestedAlias = fromSiblings
+				}
+			}
 			var err error
 			alias, err = promptForAlias(suggestedAlias)
 			if err != nil {
@@ -485,6 +522,12 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 			aliasIsDefaultSuggestion = true
 		}
 	}
+	if checked, err := warnAliasCollisions(alias, siblings, isTTY()); err != nil {
+		return fmt.Errorf("getting alias: %w", err)
+	} else if checked != alias {
+		alias = checked
+		aliasIsDefaultSuggestion = false
+	}
 
 	// Get hostname and workspace path
 	hostname, _ := os.Hostname()
@@ -505,6 +548,7 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 		Email:         email,
 		Hostname:      hostname,
 		WorkspacePath: workspacePath,
+		InviteToken:   resolveConfig(initInvite, "BEADHUB_INVITE_TOKEN", ""),
 	}
 
 	// Get project slug if provided via flag/env
@@ -513,12 +557,12 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 		initReq.ProjectSlug = projectSlug
 	}
 
-	c := client.New(beadhubURL)
+	c := beadHubClientFor(ctx, beadhubURL, "")
 
 	fmt.Println("Initializing workspace...")
 
 	// Call POST /v1/init
-	initResp, err := c.Init(context.Background(), initReq)
+	initResp, err := c.Init(ctx, initReq)
 	if err != nil {
 		// Check for specific error codes
 		if clientErr, ok := err.(*client.Error); ok {
@@ -556,7 +600,7 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 
 				// Retry with project_slug
 				initReq.ProjectSlug = projectSlug
-				initResp, err = c.Init(context.Background(), initReq)
+				initResp, err = c.Init(ctx, initReq)
 				if err != nil {
 					return fmt.Errorf("failed to initialize workspace: %w", err)
 				}
@@ -565,7 +609,7 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 				if !aliasExplicit {
 					fmt.Printf("Default alias '%s' is already taken; asking server to assign the next available name...\n", alias)
 					initReq.Alias = nil
-					initResp, err = c.Init(context.Background(), initReq)
+					initResp, err = c.Init(ctx, initReq)
 					if err != nil {
 						return fmt.Errorf("failed to initialize workspace: %w", err)
 					}
@@ -580,6 +624,8 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 			} else {
 				return fmt.Errorf("failed to initialize workspace: %w", err)
 			}
+		} else if client.IsPayloadError(err) {
+			return err
 		} else {
 			return fmt.Errorf("could not reach BeadHub at %s: %w", beadhubURL, err)
 		}
@@ -605,6 +651,7 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 		Alias:           initResp.Alias,
 		HumanName:       humanName,
 		Role:            role,
+		NoGit:           noGit,
 	}
 
 	// Validate config before any writes
@@ -635,8 +682,10 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 	configWritten = true
 
 	// Add .beadhub to .gitignore (non-fatal, but cleanup on total failure)
-	if err := addToGitignore(config.FileName); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not update .gitignore: %v\n", err)
+	if !noGit {
+		if err := addToGitignore(config.FileName); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not update .gitignore: %v\n", err)
+		}
 	}
 
 	// Persist a beadhub account (API key) globally and create worktree-local context.
@@ -649,7 +698,9 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 		contextPath = filepath.Join(root, ".aw", "context")
 		contextWritten = true
 	}
-	_ = addToGitignore(".aw/")
+	if !noGit {
+		_ = addToGitignore(".aw/")
+	}
 
 	// Print success
 	fmt.Println()
@@ -659,6 +710,9 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 	fmt.Printf("  project_slug: %s\n", cfg.ProjectSlug)
 	fmt.Printf("  repo_id: %s\n", cfg.RepoID)
 	fmt.Printf("  canonical_origin: %s\n", cfg.CanonicalOrigin)
+	if cfg.NoGit {
+		fmt.Println("  no_git: true (not a git repository)")
+	}
 	fmt.Printf("  alias: %s\n", cfg.Alias)
 	fmt.Printf("  role: %s\n", cfg.Role)
 	if initResp.WorkspaceCreated {
@@ -676,7 +730,7 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 	// Run bd init first if beads database doesn't exist
 	// (this creates AGENTS.md with bd commands that we'll convert to bdh)
 	if needsBeadsInit {
-		runBeadsInit(initResp.APIKey)
+		runBeadsInit(ctx, initResp.APIKey)
 	}
 
 	// Inject bdh instructions into CLAUDE.md/AGENTS.md
@@ -701,13 +755,19 @@ func runInitWithNewEndpoint(needsBeadsInit bool) error {
 
 // runBeadsInit attempts to initialize beads issue tracking.
 // Provides appropriate error messages based on whether bd is installed.
-func runBeadsInit(apiKey string) {
+func runBeadsInit(ctx context.Context, apiKey string) {
 	fmt.Println()
 
+	if bd.MockEnabled() {
+		res, _ := bd.New().Run(ctx, []string{"init"})
+		fmt.Print(res.Stdout, res.Stderr)
+		return
+	}
+
 	// Check if bd is installed
 	if _, err := exec.LookPath("bd"); err != nil {
 		fmt.Println("Beads (bd) not found in PATH.")
-		fmt.Println("Install beads for issue tracking: https://github.com/steveyegge/beads")
+		fmt.Print(bdInstallInstructions(runtime.GOOS))
 		fmt.Println("Then run 'bd init' in this directory.")
 		return
 	}
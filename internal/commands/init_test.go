@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -98,7 +99,7 @@ func TestInitCommand_CreatesBeadhubFile(t *testing.T) {
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 	t.Setenv("BEADHUB_PROJECT", "test-project")
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() error: %v", err)
 	}
 
@@ -194,7 +195,7 @@ func TestInitCommand_SucceedsIfAlreadyInitialized(t *testing.T) {
 		t.Fatalf("write .beadhub: %v", err)
 	}
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() should succeed when .beadhub exists, got error: %v", err)
 	}
 }
@@ -208,7 +209,7 @@ func TestInitCommand_FailsIfServerUnreachable(t *testing.T) {
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 	t.Setenv("BEADHUB_PROJECT", "test-project")
 
-	if err := runInit(); err == nil {
+	if err := runInit(context.Background()); err == nil {
 		t.Fatal("runInit() should error when server unreachable")
 	}
 }
@@ -219,7 +220,7 @@ func TestInitCommand_FailsWithInvalidRole(t *testing.T) {
 	t.Setenv("BEADHUB_REPO_ORIGIN", "git@github.com:test/repo.git")
 	t.Setenv("BEADHUB_ROLE", "invalid role with too many words")
 
-	err := runInit()
+	err := runInit(context.Background())
 	if err == nil {
 		t.Fatal("runInit() should error when role is invalid")
 	}
@@ -259,7 +260,7 @@ func TestInitCommand_AddsToGitignore(t *testing.T) {
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 	t.Setenv("BEADHUB_PROJECT", "test-project")
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() error: %v", err)
 	}
 
@@ -300,7 +301,7 @@ func TestInitCommand_CreatesGitignoreIfMissing(t *testing.T) {
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 	t.Setenv("BEADHUB_PROJECT", "test-project")
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() error: %v", err)
 	}
 
@@ -347,7 +348,7 @@ func TestInitCommand_DoesNotDuplicateGitignoreEntry(t *testing.T) {
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 	t.Setenv("BEADHUB_PROJECT", "test-project")
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() error: %v", err)
 	}
 
@@ -404,7 +405,7 @@ func TestInitCommand_UsesProjectEnvVar(t *testing.T) {
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 	t.Setenv("BEADHUB_PROJECT", "my-actual-project")
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() error: %v", err)
 	}
 
@@ -448,7 +449,7 @@ func TestInitCommand_AutoDetectsProjectFromRepo(t *testing.T) {
 	t.Setenv("BEADHUB_ALIAS", "test-agent")
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() error: %v", err)
 	}
 
@@ -508,7 +509,7 @@ func TestInitCommand_SendsHostnameAndWorkspacePath(t *testing.T) {
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 	t.Setenv("BEADHUB_PROJECT", "test-project")
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit() error: %v", err)
 	}
 
@@ -547,7 +548,7 @@ func TestInitCommand_FailsOnEmptyCanonicalOriginFromLookup(t *testing.T) {
 	t.Setenv("BEADHUB_ALIAS", "test-agent")
 	t.Setenv("BEADHUB_HUMAN", "Test Human")
 
-	err := runInit()
+	err := runInit(context.Background())
 	if err == nil {
 		t.Fatal("runInit() should error when canonical_origin is empty")
 	}
@@ -626,7 +627,7 @@ func TestInitCommand_SanitizesInvalidDirectoryNames(t *testing.T) {
 			t.Setenv("BEADHUB_ALIAS", "test-agent")
 			t.Setenv("BEADHUB_HUMAN", "Test Human")
 
-			if err := runInit(); err != nil {
+			if err := runInit(context.Background()); err != nil {
 				t.Fatalf("runInit() error for %q: %v", tc.dirName, err)
 			}
 
@@ -735,7 +736,7 @@ func TestInitCommand_UpdateUsesRegisterWorkspace(t *testing.T) {
 	initUpdate = true
 	initRole = "reviewer"
 
-	if err := runInit(); err != nil {
+	if err := runInit(context.Background()); err != nil {
 		t.Fatalf("runInit --update: %v", err)
 	}
 
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Developers often run one agent per git worktree. :init looks at the other
+// worktrees of the same repository that already have a .beadhub, suggests an
+// alias that continues their numbering, and warns when the chosen alias
+// collides with (or is easily confused with) one already in use.
+
+// SiblingWorktree is another worktree of this repo with a .beadhub config.
+type SiblingWorktree struct {
+	Path  string
+	Alias string
+	Role  string
+}
+
+// listGitWorktrees returns the paths of all worktrees of the repo at dir.
+func listGitWorktrees(ctx context.Context, dir string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "worktree", "list", "--porcelain")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktreeList(string(out)), nil
+}
+
+// parseWorktreeList extracts worktree paths from `git worktree list --porcelain`.
+func parseWorktreeList(output string) []string {
+	var paths []string
+	for _, line := range strings.Split(output, "\n") {
+		if path, ok := strings.CutPrefix(strings.TrimRight(line, "\r"), "worktree "); ok && path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// siblingWorktreeConfigs reads .beadhub from every worktree except current.
+// Worktrees without a readable config are ignored.
+func siblingWorktreeConfigs(current string, worktrees []string) []SiblingWorktree {
+	current = cleanWorktreePath(current)
+	var siblings []SiblingWorktree
+	for _, wt := range worktrees {
+		if cleanWorktreePath(wt) == current {
+			continue
+		}
+		cfg, err := config.LoadFrom(filepath.Join(wt, config.FileName))
+		if err != nil || strings.TrimSpace(cfg.Alias) == "" {
+			continue
+		}
+		siblings = append(siblings, SiblingWorktree{Path: wt, Alias: cfg.Alias, Role: cfg.Role})
+	}
+	sort.Slice(siblings, func(i, j int) bool { return siblings[i].Alias < siblings[j].Alias })
+	return siblings
+}
+
+func cleanWorktreePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Clean(path)
+}
+
+// findSiblingWorktrees is the best-effort entry point used by :init.
+func findSiblingWorktrees(ctx context.Context) []SiblingWorktree {
+	root := currentRepoRoot(ctx)
+	if root == "" {
+		return nil
+	}
+	worktrees, err := listGitWorktrees(ctx, root)
+	if err != nil {
+		return nil
+	}
+	return siblingWorktreeConfigs(root, worktrees)
+}
+
+var aliasNumberSuffix = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// suggestAliasFromSiblings continues the most common numbered alias pattern
+// among siblings ("alice-dev-1", "alice-dev-2" -> "alice-dev-3"). Returns ""
+// when no sibling alias ends in a number.
+func suggestAliasFromSiblings(siblings []SiblingWorktree) string {
+	type stemInfo struct {
+		count, max, width int
+	}
+	stems := make(map[string]*stemInfo)
+	taken := make(map[string]bool)
+	for _, s := range siblings {
+		taken[s.Alias] = true
+		m := aliasNumberSuffix.FindStringSubmatch(s.Alias)
+		if m == nil || m[1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+		info := stems[m[1]]
+		if info == nil {
+			info = &stemInfo{}
+			stems[m[1]] = info
+		}
+		info.count++
+		if n >= info.max {
+			info.max = n
+			info.width = len(m[2])
+		}
+	}
+
+	best := ""
+	for stem, info := range stems {
+		if best == "" || info.count > stems[best].count || (info.count == stems[best].count && stem < best) {
+			best = stem
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	info := stems[best]
+	for n := info.max + 1; ; n++ {
+		candidate := fmt.Sprintf("%s%0*d", best, info.width, n)
+		if !taken[candidate] && config.IsValidAlias(candidate) {
+			return candidate
+		}
+		if n > info.max+100 {
+			return ""
+		}
+	}
+}
+
+// aliasLookalikeKey folds an alias to the part people actually read:
+// lowercase letters and digits, ignoring case and separators, so "alice-dev2"
+// and "Alice_Dev-2" look alike but "alice-dev-2" and "alice-dev-3" do not.
+func aliasLookalikeKey(alias string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(alias) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// aliasCollisionWarnings reports siblings whose alias equals alias or is a
+// near-duplicate of it (same letters and digits once case and separators are
+// ignored).
+func aliasCollisionWarnings(alias string, siblings []SiblingWorktree) []string {
+	var warnings []string
+	key := aliasLookalikeKey(alias)
+	for _, s := range siblings {
+		switch {
+		case s.Alias == alias:
+			warnings = append(warnings, fmt.Sprintf("alias '%s' is already used by the worktree at %s - two workspaces with one alias will fight over claims and messages", alias, s.Path))
+		case key != "" && aliasLookalikeKey(s.Alias) == key:
+			warnings = append(warnings, fmt.Sprintf("alias '%s' is easy to confuse with '%s' (worktree at %s)", alias, s.Alias, s.Path))
+		}
+	}
+	return warnings
+}
+
+// formatSiblingWorktrees lists sibling worktrees for the :init prompt.
+func formatSiblingWorktrees(siblings []SiblingWorktree) string {
+	var sb strings.Builder
+	sb.WriteString("Other worktrees of this repo already registered:\n")
+	for _, s := range siblings {
+		if s.Role != "" {
+			sb.WriteString(fmt.Sprintf("  %s (%s) — %s\n", s.Alias, s.Role, s.Path))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s — %s\n", s.Alias, s.Path))
+		}
+	}
+	return sb.String()
+}
+
+// warnAliasCollisions prints collision warnings and, on a terminal, lets the
+// user pick another alias. Returns the alias to register.
+func warnAliasCollisions(alias string, siblings []SiblingWorktree, interactive bool) (string, error) {
+	warnings := aliasCollisionWarnings(alias, siblings)
+	if len(warnings) == 0 {
+		return alias, nil
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if !interactive {
+		return alias, nil
+	}
+	suggested := suggestAliasFromSiblings(siblings)
+	if suggested == "" {
+		suggested = alias
+	}
+	return promptForAlias(suggested)
+}
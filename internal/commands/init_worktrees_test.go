@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeWorktreeConfig(t *testing.T, dir, alias, role string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "alias: " + alias + "\n"
+	if role != "" {
+		content += "role: " + role + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".beadhub"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	output := "worktree /src/repo\nHEAD abc\nbranch refs/heads/main\n\n" +
+		"worktree /src/repo-wt1\r\nHEAD def\r\ndetached\r\n\n" +
+		"worktree /src/repo wt2\nHEAD 123\nbranch refs/heads/x\n"
+
+	got := parseWorktreeList(output)
+	want := []string{"/src/repo", "/src/repo-wt1", "/src/repo wt2"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("parseWorktreeList = %q, want %q", got, want)
+	}
+}
+
+func TestSiblingWorktreeConfigs(t *testing.T) {
+	base := t.TempDir()
+	current := filepath.Join(base, "main")
+	writeWorktreeConfig(t, current, "alice-dev-1", "dev")
+	writeWorktreeConfig(t, filepath.Join(base, "wt2"), "alice-dev-2", "dev")
+	writeWorktreeConfig(t, filepath.Join(base, "wt3"), "", "")
+	if err := os.MkdirAll(filepath.Join(base, "wt4"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	siblings := siblingWorktreeConfigs(current, []string{
+		current, filepath.Join(base, "wt2"), filepath.Join(base, "wt3"), filepath.Join(base, "wt4"),
+	})
+	if len(siblings) != 1 || siblings[0].Alias != "alice-dev-2" || siblings[0].Role != "dev" {
+		t.Errorf("siblings = %+v", siblings)
+	}
+}
+
+func TestSuggestAliasFromSiblings(t *testing.T) {
+	tests := []struct {
+		aliases []string
+		want    string
+	}{
+		{aliases: []string{"alice-dev-1", "alice-dev-2"}, want: "alice-dev-3"},
+		{aliases: []string{"wt-07", "wt-09", "bob-1"}, want: "wt-10"},
+		{aliases: []string{"alice-dev", "bob-reviewer"}, want: ""},
+		{aliases: nil, want: ""},
+	}
+	for _, tt := range tests {
+		var siblings []SiblingWorktree
+		for _, a := range tt.aliases {
+			siblings = append(siblings, SiblingWorktree{Alias: a})
+		}
+		if got := suggestAliasFromSiblings(siblings); got != tt.want {
+			t.Errorf("suggestAliasFromSiblings(%v) = %q, want %q", tt.aliases, got, tt.want)
+		}
+	}
+}
+
+func TestAliasCollisionWarnings(t *testing.T) {
+	siblings := []SiblingWorktree{
+		{Path: "/src/wt1", Alias: "alice-dev-1"},
+		{Path: "/src/wt2", Alias: "alice-dev2"},
+	}
+
+	if w := aliasCollisionWarnings("alice-dev-3", siblings); len(w) != 0 {
+		t.Errorf("next in series should not warn: %v", w)
+	}
+
+	w := aliasCollisionWarnings("alice-dev-1", siblings)
+	if len(w) != 1 || !strings.Contains(w[0], "already used by the worktree at /src/wt1") {
+		t.Errorf("exact collision: %v", w)
+	}
+
+	w = aliasCollisionWarnings("Alice_Dev-2", siblings)
+	if len(w) != 1 || !strings.Contains(w[0], "easy to confuse with 'alice-dev2'") {
+		t.Errorf("look-alike: %v", w)
+	}
+}
+
+func TestFindSiblingWorktrees_RealGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	base := t.TempDir()
+	main := filepath.Join(base, "main")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.MkdirAll(main, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run(main, "init", "-q")
+	run(main, "commit", "-q", "--allow-empty", "-m", "init")
+	run(main, "worktree", "add", "-q", filepath.Join(base, "wt1"))
+	writeWorktreeConfig(t, filepath.Join(base, "wt1"), "bob-impl-1", "impl")
+
+	t.Chdir(main)
+	siblings := findSiblingWorktrees(context.Background())
+	if len(siblings) != 1 || siblings[0].Alias != "bob-impl-1" {
+		t.Fatalf("siblings = %+v", siblings)
+	}
+	if got := suggestAliasFromSiblings(siblings); got != "bob-impl-2" {
+		t.Errorf("suggestion = %q", got)
+	}
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+)
+
+// Urgent mail is shown inline in the notifications, body included, so an
+// agent sees it without running 'mail list'. With
+// notifications.auto_ack_displayed: true, low-priority mail is shown inline
+// as well, and every message whose full body was shown is acked afterwards,
+// so it does not reappear on every later command. Long bodies are cut and
+// stay unread. Senders can opt a message out with 'mail send --flag': flagged
+// messages are shown but never auto-acked. The aweb mail API has no metadata
+// field, so the flag travels in a metadata block after the body, like the
+// bead footer, and is stripped before the body is shown.
+
+const (
+	// inlineMailMaxRunes is the longest body shown in full.
+	inlineMailMaxRunes = 600
+	// maxInlineMail bounds the messages shown inline per command.
+	maxInlineMail = 5
+
+	// mailMetadataFooter starts the metadata block, one "key: value" per line.
+	mailMetadataFooter = "\n\n-- bdh --\n"
+)
+
+var awebMailFlag bool
+
+func init() {
+	awebMailSendCmd.Flags().BoolVar(&awebMailFlag, "flag", false, "Keep the message unread until handled, even when shown inline")
+}
+
+// InlineMail is an unread message shown inline in the notifications.
+type InlineMail struct {
+	MessageID string
+	From      string
+	Subject   string
+	Body      string
+	Priority  aweb.MessagePriority
+	Flagged   bool
+	// Complete is set when Body is the full message body.
+	Complete bool
+}
+
+// autoAckable reports whether m may be acked once displayed.
+func (m InlineMail) autoAckable() bool {
+	return m.Complete && !m.Flagged
+}
+
+// MailMetadata is what bdh attaches to a message besides its text.
+type MailMetadata struct {
+	// Flagged keeps the message unread when it is shown inline (--flag).
+	Flagged bool
+}
+
+// withMailMetadata appends meta to body. A zero meta leaves body unchanged.
+func withMailMetadata(body string, meta MailMetadata) string {
+	if !meta.Flagged {
+		return body
+	}
+	return strings.TrimRight(body, "\n") + mailMetadataFooter + "flagged: true"
+}
+
+// splitMailMetadata separates a message body from its metadata block.
+// Unknown keys are ignored, so newer senders can add some.
+func splitMailMetadata(body string) (string, MailMetadata) {
+	var meta MailMetadata
+	idx := strings.LastIndex(body, mailMetadataFooter)
+	if idx < 0 {
+		return body, meta
+	}
+	for _, line := range strings.Split(body[idx+len(mailMetadataFooter):], "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok {
+			// Not a block we wrote; leave the body alone.
+			return body, MailMetadata{}
+		}
+		if key == "flagged" {
+			meta.Flagged = value == "true"
+		}
+	}
+	return body[:idx], meta
+}
+
+// readMessageBody returns body as the recipient reads it: without the
+// metadata block and decrypted. Bead references are left as sent.
+func readMessageBody(body string) string {
+	text, _ := splitMailMetadata(body)
+	return decryptMessageBody(text)
+}
+
+// inlineMailFrom picks the unread messages to show inline: urgent ones, and
+// low-priority ones when includeLow is set.
+func inlineMailFrom(messages []aweb.InboxMessage, includeLow bool) []InlineMail {
+	var inline []InlineMail
+	for _, m := range messages {
+		if m.Priority != aweb.PriorityUrgent && (!includeLow || m.Priority != aweb.PriorityLow) {
+			continue
+		}
+		if len(inline) == maxInlineMail {
+			break
+		}
+		_, meta := splitMailMetadata(m.Body)
+		body := strings.TrimSpace(renderMessageBody(m.Body))
+		complete := true
+		if r := []rune(body); len(r) > inlineMailMaxRunes {
+			body = string(r[:inlineMailMaxRunes-1]) + "…"
+			complete = false
+		}
+		inline = append(inline, InlineMail{
+			MessageID: m.MessageID,
+			From:      m.FromAlias,
+			Subject:   m.Subject,
+			Body:      body,
+			Priority:  m.Priority,
+			Flagged:   meta.Flagged,
+			Complete:  complete,
+		})
+	}
+	return inline
+}
+
+func formatInlineMail(m InlineMail) string {
+	label := "MAIL"
+	if m.Priority == aweb.PriorityUrgent {
+		label = "URGENT MAIL"
+	}
+	header := fmt.Sprintf("- **%s** from %s", label, m.From)
+	if m.Subject != "" {
+		header += ": " + m.Subject
+	}
+	var sb strings.Builder
+	sb.WriteString(header)
+	for _, line := range strings.Split(m.Body, "\n") {
+		sb.WriteString("\n  > " + line)
+	}
+	if !m.Complete {
+		sb.WriteString(fmt.Sprintf("\n  → Read in full: `bdh :aweb mail open %s`", m.From))
+	}
+	return sb.String()
+}
+
+// autoAckDisplayedMail acks the inline messages whose full body was shown,
+// best-effort. Returns how many were acked.
+func autoAckDisplayedMail(ctx context.Context, aw AwebAPI, inline []InlineMail) int {
+	acked := 0
+	for _, m := range inline {
+		if !m.autoAckable() {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, err := aw.AckMessage(ctx, m.MessageID)
+		cancel()
+		if err == nil {
+			acked++
+		}
+	}
+	return acked
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+)
+
+func TestInlineMailFrom(t *testing.T) {
+	messages := []aweb.InboxMessage{
+		{MessageID: "m1", FromAlias: "alice", Subject: "prod is down", Body: "rollback now", Priority: aweb.PriorityUrgent},
+		{MessageID: "m2", FromAlias: "bob", Body: "fyi: merged", Priority: aweb.PriorityLow},
+		{MessageID: "m3", FromAlias: "carol", Body: "question", Priority: aweb.PriorityNormal},
+		{MessageID: "m4", FromAlias: "dave", Subject: "keys rotated", Body: withMailMetadata("update your env", MailMetadata{Flagged: true}), Priority: aweb.PriorityUrgent},
+		{MessageID: "m5", FromAlias: "erin", Body: strings.Repeat("x", inlineMailMaxRunes+1), Priority: aweb.PriorityUrgent},
+	}
+
+	inline := inlineMailFrom(messages, false)
+	if len(inline) != 3 || inline[0].MessageID != "m1" || inline[1].MessageID != "m4" || inline[2].MessageID != "m5" {
+		t.Fatalf("expected only urgent mail, got %+v", inline)
+	}
+	if !inline[0].autoAckable() {
+		t.Error("a short unflagged message should be auto-ackable")
+	}
+	if !inline[1].Flagged || inline[1].autoAckable() || inline[1].Body != "update your env" {
+		t.Errorf("a flagged message must not be auto-acked: %+v", inline[1])
+	}
+	if inline[2].Complete || inline[2].autoAckable() || len([]rune(inline[2].Body)) != inlineMailMaxRunes {
+		t.Errorf("a long body should be cut and stay unread: complete=%v len=%d", inline[2].Complete, len([]rune(inline[2].Body)))
+	}
+
+	if inline := inlineMailFrom(messages, true); len(inline) != 4 || inline[1].MessageID != "m2" {
+		t.Errorf("expected low-priority mail with includeLow, got %+v", inline)
+	}
+}
+
+func TestMailMetadata(t *testing.T) {
+	body := withMailMetadata("deploy at 5\n", MailMetadata{Flagged: true})
+	if text, meta := splitMailMetadata(body); text != "deploy at 5" || !meta.Flagged {
+		t.Errorf("split(%q) = %q, %+v", body, text, meta)
+	}
+	withRefs := withMailMetadata(withBeadRefs("see bd-1", map[string]Issue{"bd-1": {ID: "bd-1", Status: "open"}}), MailMetadata{Flagged: true})
+	if got := renderBeadRefs(readMessageBody(withRefs), nil); got != "see bd-1 [open]" {
+		t.Errorf("bead footer lost behind the metadata: %q", got)
+	}
+	if got := withMailMetadata("deploy", MailMetadata{}); got != "deploy" {
+		t.Errorf("zero metadata changed the body: %q", got)
+	}
+
+	// A subject or body that merely looks like a flag is not one
+	for _, body := range []string{"[flag] deploy", "notes\n\n-- bdh --\nnot metadata"} {
+		if text, meta := splitMailMetadata(body); text != body || meta.Flagged {
+			t.Errorf("split(%q) = %q, %+v", body, text, meta)
+		}
+	}
+}
+
+func TestFormatNotifications_InlineMail(t *testing.T) {
+	ctx := &NotificationContext{
+		MessagesWaiting: 3,
+		InlineMail: []InlineMail{
+			{MessageID: "m1", From: "alice", Subject: "prod is down", Body: "roll back\nnow", Priority: aweb.PriorityUrgent, Complete: true},
+			{MessageID: "m2", From: "bob", Body: "long…", Priority: aweb.PriorityLow},
+		},
+	}
+	out := FormatNotifications(ctx, "")
+	for _, want := range []string{
+		"**URGENT MAIL** from alice: prod is down\n  > roll back\n  > now",
+		"**MAIL** from bob\n  > long…\n  → Read in full: `bdh :aweb mail open bob`",
+		"You have 1 more unread message",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestAutoAckDisplayedMail(t *testing.T) {
+	var acked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acked = append(acked, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message_id":"x","acknowledged_at":"2026-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+	aw, err := aweb.NewWithAPIKey(server.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := autoAckDisplayedMail(context.Background(), aw, []InlineMail{
+		{MessageID: "shown", Complete: true},
+		{MessageID: "flagged", Complete: true, Flagged: true},
+		{MessageID: "cut"},
+	})
+	if n != 1 || len(acked) != 1 || acked[0] != "/v1/messages/shown/ack" {
+		t.Errorf("expected only the complete unflagged message acked, got %d %v", n, acked)
+	}
+}
@@ -0,0 +1,555 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+// :inspect shows everything known about one bead in a single view: bd's own
+// view of it, who claims it, the reservations taken for it, the chat
+// sessions that mention it, whether BeadHub has its latest version, and the
+// beads around it. Each server section is best-effort; one that cannot be
+// fetched becomes a warning rather than failing the command.
+
+const (
+	inspectReservationWindow = 7 * 24 * time.Hour
+	maxInspectEvents         = 10
+	maxInspectChatSessions   = 20
+	inspectChatHistoryLimit  = 50
+	inspectExcerptRunes      = 80
+)
+
+// Sync freshness of an inspected bead.
+const (
+	inspectSyncInSync   = "in_sync"
+	inspectSyncUnsynced = "unsynced"
+	inspectSyncDrift    = "drift"
+	inspectSyncMissing  = "missing_on_server"
+)
+
+// InspectClaim is an agent claiming the inspected bead.
+type InspectClaim struct {
+	Alias     string `json:"alias"`
+	ClaimedAt string `json:"claimed_at,omitempty"`
+}
+
+// InspectReservation is a reservation currently held for the bead.
+type InspectReservation struct {
+	Path        string `json:"path"`
+	HolderAlias string `json:"holder_alias"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+}
+
+// InspectChat is a chat session mentioning the bead.
+type InspectChat struct {
+	SessionID    string   `json:"session_id"`
+	Participants []string `json:"participants"`
+	Mentions     int      `json:"mentions"`
+	LastFrom     string   `json:"last_from"`
+	LastAt       string   `json:"last_at,omitempty"`
+	Excerpt      string   `json:"excerpt"`
+}
+
+// InspectSync is the bead's sync freshness.
+type InspectSync struct {
+	State    string `json:"state"`
+	LastSync string `json:"last_sync,omitempty"`
+}
+
+// InspectBeadRef is a bead next to the inspected one in the dependency graph.
+type InspectBeadRef struct {
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// InspectTree is the inspected bead's immediate dependency neighbourhood.
+type InspectTree struct {
+	Parent    *InspectBeadRef  `json:"parent,omitempty"`
+	Children  []InspectBeadRef `json:"children,omitempty"`
+	BlockedBy []InspectBeadRef `json:"blocked_by,omitempty"`
+	Blocks    []InspectBeadRef `json:"blocks,omitempty"`
+}
+
+// InspectResult is the output of :inspect.
+type InspectResult struct {
+	BeadID            string                    `json:"bead_id"`
+	Bead              json.RawMessage           `json:"bead,omitempty"`
+	Claims            []InspectClaim            `json:"claims"`
+	Reservations      []InspectReservation      `json:"reservations"`
+	ReservationEvents []client.ReservationEvent `json:"reservation_events"`
+	Chats             []InspectChat             `json:"chats"`
+	Sync              *InspectSync              `json:"sync,omitempty"`
+	Tree              *InspectTree              `json:"tree,omitempty"`
+	Warnings          []string                  `json:"warnings,omitempty"`
+
+	show string // bd show's text output
+}
+
+var inspectJSON bool
+
+var inspectCmd = &cobra.Command{
+	Use:   ":inspect <bead-id>",
+	Short: "Show a bead together with what BeadHub knows about it",
+	Long: `Show bd's view of a bead followed by what the team knows about it:
+who claims it, reservations held or taken for it in the last week, chat
+sessions mentioning it, whether BeadHub has its latest version, and its
+parent, children and blockers.
+
+Examples:
+  bdh :inspect bd-42
+  bdh :inspect bd-42 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInspect,
+}
+
+func init() {
+	inspectCmd.Flags().BoolVar(&inspectJSON, "json", false, "Output as JSON")
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	beadID := strings.TrimSpace(args[0])
+	if beadID == "" {
+		return fmt.Errorf("bead ID is required")
+	}
+	result, err := inspectBead(ctx, cfg, beadID, inspectJSON)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatInspectOutput(result, inspectJSON))
+	return nil
+}
+
+// inspectBead gathers every section for beadID. Only a failing bd show is
+// an error.
+func inspectBead(ctx context.Context, cfg *config.Config, beadID string, asJSON bool) (*InspectResult, error) {
+	result := &InspectResult{
+		BeadID:            beadID,
+		Claims:            []InspectClaim{},
+		Reservations:      []InspectReservation{},
+		ReservationEvents: []client.ReservationEvent{},
+		Chats:             []InspectChat{},
+	}
+	if err := inspectShow(ctx, cfg, result, asJSON); err != nil {
+		return nil, err
+	}
+
+	issues, err := loadIssues()
+	if err != nil && !os.IsNotExist(err) {
+		result.warn("could not read issues.jsonl (%v) - dependencies are not shown", err)
+	}
+	result.Tree = inspectTree(beadID, issues)
+
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	inspectClaims(ctx, cfg, c, result)
+	inspectReservationEvents(ctx, cfg, c, result)
+	if aw, err := newAwebClient(ctx, cfg.BeadhubURL); err == nil && aw != nil {
+		inspectReservations(ctx, aw, result)
+		inspectChats(ctx, aw, result)
+	} else {
+		result.warn("could not reach aweb (%v) - held reservations and chats are not shown", err)
+	}
+	inspectSyncState(ctx, cfg, c, result)
+	return result, nil
+}
+
+func (r *InspectResult) warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// inspectShow runs bd show: as JSON for --json output, as text otherwise.
+func inspectShow(ctx context.Context, cfg *config.Config, result *InspectResult, asJSON bool) error {
+	args := []string{"show", result.BeadID}
+	if asJSON {
+		args = append(args, "--json")
+	}
+	runCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	res, err := newBdRunner(cfg, nil).Run(runCtx, args)
+	if err != nil {
+		return fmt.Errorf("running bd show: %w", err)
+	}
+	if res.ExitCode != 0 {
+		if msg := strings.TrimSpace(res.Stderr); msg != "" {
+			return fmt.Errorf("bd show %s failed: %s", result.BeadID, msg)
+		}
+		return fmt.Errorf("bd show %s failed (exit %d)", result.BeadID, res.ExitCode)
+	}
+	if !asJSON {
+		result.show = res.Stdout
+		return nil
+	}
+	// bd show --json prints an array with one issue per ID
+	out := json.RawMessage(strings.TrimSpace(res.Stdout))
+	var shown []json.RawMessage
+	if json.Unmarshal(out, &shown) == nil && len(shown) == 1 {
+		out = shown[0]
+	}
+	if !json.Valid(out) {
+		return fmt.Errorf("parsing bd show output: not JSON")
+	}
+	result.Bead = out
+	return nil
+}
+
+// inspectTree returns the bead's parent, children and blockers from issues.
+func inspectTree(beadID string, issues []Issue) *InspectTree {
+	byID := make(map[string]*Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	ref := func(id string) InspectBeadRef {
+		if issue, ok := byID[id]; ok {
+			return InspectBeadRef{ID: id, Title: issue.Title, Status: issue.Status}
+		}
+		return InspectBeadRef{ID: id}
+	}
+
+	tree := &InspectTree{}
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep.DependsOnID == "" {
+				continue
+			}
+			switch {
+			case issue.ID == beadID && dep.Type == "parent-child" && tree.Parent == nil:
+				parent := ref(dep.DependsOnID)
+				tree.Parent = &parent
+			case issue.ID == beadID && dep.Type == "blocks":
+				tree.BlockedBy = append(tree.BlockedBy, ref(dep.DependsOnID))
+			case dep.DependsOnID == beadID && dep.Type == "parent-child":
+				tree.Children = append(tree.Children, ref(issue.ID))
+			case dep.DependsOnID == beadID && dep.Type == "blocks":
+				tree.Blocks = append(tree.Blocks, ref(issue.ID))
+			}
+		}
+	}
+	if tree.Parent == nil && len(tree.Children) == 0 && len(tree.BlockedBy) == 0 && len(tree.Blocks) == 0 {
+		return nil
+	}
+	return tree
+}
+
+func inspectClaims(ctx context.Context, cfg *config.Config, c BeadHubAPI, result *InspectResult) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	// Bead IDs are only unique within a repo
+	resp, err := c.Workspaces(ctx, &client.WorkspacesRequest{Repo: cfg.CanonicalOrigin, IncludeClaims: true, Limit: maxWorkspaceQueryLimit})
+	if err != nil {
+		result.warn("could not list workspaces (%v) - claims are not shown", err)
+		return
+	}
+	for _, ws := range resp.Workspaces {
+		for _, claim := range ws.Claims {
+			if claim.BeadID == result.BeadID {
+				result.Claims = append(result.Claims, InspectClaim{Alias: ws.Alias, ClaimedAt: claim.ClaimedAt})
+			}
+		}
+	}
+	sort.Slice(result.Claims, func(i, j int) bool { return result.Claims[i].Alias < result.Claims[j].Alias })
+}
+
+// inspectReservationEvents keeps the last week's reservation events taken
+// for the bead, newest first.
+func inspectReservationEvents(ctx context.Context, cfg *config.Config, c BeadHubAPI, result *InspectResult) {
+	events, _, warning, err := loadReservationEvents(ctx, cfg, c, time.Now().Add(-inspectReservationWindow), false)
+	if err != nil {
+		result.warn("could not load reservation history (%v)", err)
+		return
+	}
+	if warning != "" {
+		result.Warnings = append(result.Warnings, warning)
+	}
+	for _, e := range events {
+		if e.BeadID == result.BeadID {
+			result.ReservationEvents = append(result.ReservationEvents, e)
+		}
+	}
+	sort.SliceStable(result.ReservationEvents, func(i, j int) bool {
+		return newerTimestamp(result.ReservationEvents[i].At, result.ReservationEvents[j].At)
+	})
+	if len(result.ReservationEvents) > maxInspectEvents {
+		result.ReservationEvents = result.ReservationEvents[:maxInspectEvents]
+	}
+}
+
+// inspectReservations lists the reservations held now whose bead_id
+// metadata is the bead.
+func inspectReservations(ctx context.Context, aw AwebAPI, result *InspectResult) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := aw.ReservationList(ctx, "")
+	if err != nil {
+		result.warn("could not list reservations (%v)", err)
+		return
+	}
+	for _, r := range resp.Reservations {
+		if beadID, _ := r.Metadata[reservationBeadIDKey].(string); beadID == result.BeadID {
+			result.Reservations = append(result.Reservations, InspectReservation{
+				Path:        r.ResourceKey,
+				HolderAlias: r.HolderAlias,
+				ExpiresAt:   r.ExpiresAt,
+			})
+		}
+	}
+	sort.Slice(result.Reservations, func(i, j int) bool { return result.Reservations[i].Path < result.Reservations[j].Path })
+}
+
+// inspectChats searches the recent history of your chat sessions for
+// mentions of the bead.
+func inspectChats(ctx context.Context, aw AwebAPI, result *InspectResult) {
+	listCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	resp, err := aw.ChatListSessions(listCtx)
+	cancel()
+	if err != nil {
+		result.warn("could not list chat sessions (%v)", err)
+		return
+	}
+	sessions := resp.Sessions
+	if len(sessions) > maxInspectChatSessions {
+		sessions = sessions[:maxInspectChatSessions]
+	}
+	for _, session := range sessions {
+		historyCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+		history, err := aw.ChatHistory(historyCtx, aweb.ChatHistoryParams{SessionID: session.SessionID, Limit: inspectChatHistoryLimit})
+		cancel()
+		if err != nil {
+			continue
+		}
+		if chat := chatMentions(session, history.Messages, result.BeadID); chat != nil {
+			result.Chats = append(result.Chats, *chat)
+		}
+	}
+	sort.SliceStable(result.Chats, func(i, j int) bool {
+		return newerTimestamp(result.Chats[i].LastAt, result.Chats[j].LastAt)
+	})
+}
+
+// chatMentions summarizes the messages mentioning beadID, or returns nil
+// when none does.
+func chatMentions(session aweb.ChatSessionItem, messages []aweb.ChatMessage, beadID string) *InspectChat {
+	var chat *InspectChat
+	for _, m := range messages {
+		if !mentionsBead(m.Body, beadID) {
+			continue
+		}
+		if chat == nil {
+			chat = &InspectChat{SessionID: session.SessionID, Participants: session.Participants}
+		}
+		chat.Mentions++
+		if chat.LastAt == "" || !newerTimestamp(chat.LastAt, m.Timestamp) {
+			chat.LastFrom, chat.LastAt = m.FromAgent, m.Timestamp
+			chat.Excerpt = truncateText(m.Body, inspectExcerptRunes)
+		}
+	}
+	return chat
+}
+
+// newerTimestamp reports whether a is strictly later than b. Unparseable
+// timestamps sort last.
+func newerTimestamp(a, b string) bool {
+	ta, okA := parseTimeBestEffort(a)
+	tb, okB := parseTimeBestEffort(b)
+	return okA && (!okB || ta.After(tb))
+}
+
+// mentionsBead reports whether text mentions beadID as a whole token.
+func mentionsBead(text, beadID string) bool {
+	for _, m := range beadRefMatches(text) {
+		if text[m[0]:m[1]] == beadID {
+			return true
+		}
+	}
+	return false
+}
+
+// inspectSyncState compares the bead's local hash with the server's and with
+// the one recorded at the last sync.
+func inspectSyncState(ctx context.Context, cfg *config.Config, c BeadHubAPI, result *InspectResult) {
+	issuesPath, _ := resolveIssuesPathAndExportArgs(nil)
+	content, err := os.ReadFile(issuesPath)
+	if err != nil {
+		result.warn("could not read %s (%v) - sync freshness is not shown", issuesPath, err)
+		return
+	}
+	content, _ = sync.NormalizeJSONL(content)
+	localHashes, err := sync.ComputeIssueHashes(content)
+	if err != nil {
+		result.warn("could not hash local issues (%v) - sync freshness is not shown", err)
+		return
+	}
+	localHash, ok := localHashes[result.BeadID]
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	manifest, err := c.SyncManifest(ctx, &client.SyncManifestRequest{WorkspaceID: cfg.WorkspaceID, RepoID: cfg.RepoID})
+	if err != nil {
+		result.warn("could not fetch the sync manifest (%v) - sync freshness is not shown", err)
+		return
+	}
+
+	state := &InspectSync{LastSync: manifest.SyncedAt}
+	var lastSynced string
+	if saved, err := sync.LoadState(syncStatePathForConfig(cfg)); err == nil {
+		lastSynced = saved.IssueHashes[result.BeadID]
+		if state.LastSync == "" && !saved.LastSync.IsZero() {
+			state.LastSync = saved.LastSync.UTC().Format(time.RFC3339)
+		}
+	}
+	serverHash, onServer := manifest.IssueHashes[result.BeadID]
+	switch {
+	case onServer && serverHash == localHash:
+		state.State = inspectSyncInSync
+	case lastSynced != localHash:
+		state.State = inspectSyncUnsynced
+	case !onServer:
+		state.State = inspectSyncMissing
+	default:
+		state.State = inspectSyncDrift
+	}
+	result.Sync = state
+}
+
+func formatInspectOutput(result *InspectResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	now := time.Now()
+	var sb strings.Builder
+	if result.show != "" {
+		sb.WriteString(strings.TrimRight(result.show, "\n"))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\nClaimed by: ")
+	if len(result.Claims) == 0 {
+		sb.WriteString("nobody\n")
+	} else {
+		claims := make([]string, 0, len(result.Claims))
+		for _, claim := range result.Claims {
+			if claim.ClaimedAt != "" {
+				claims = append(claims, fmt.Sprintf("%s (since %s)", claim.Alias, formatTimeAgoAt(claim.ClaimedAt, now)))
+			} else {
+				claims = append(claims, claim.Alias)
+			}
+		}
+		sb.WriteString(strings.Join(claims, ", ") + "\n")
+	}
+
+	if result.Sync != nil {
+		sb.WriteString("Sync: " + formatInspectSync(result.Sync, now) + "\n")
+	}
+
+	if len(result.Reservations) > 0 || len(result.ReservationEvents) > 0 {
+		sb.WriteString("\nReservations:\n")
+		for _, r := range result.Reservations {
+			sb.WriteString(fmt.Sprintf("  `%s` — held by %s (expires in %s)\n", r.Path, r.HolderAlias,
+				formatDuration(ttlRemainingSeconds(r.ExpiresAt, serverNow()))))
+		}
+		for _, e := range result.ReservationEvents {
+			line := fmt.Sprintf("  %s `%s` %s by %s", formatTimeAgoAt(e.At, now), e.Path, e.Kind, e.HolderAlias)
+			if e.Kind == client.ReservationEventConflict && e.RequestedBy != "" {
+				line += fmt.Sprintf(", refused to %s", e.RequestedBy)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	if len(result.Chats) > 0 {
+		sb.WriteString("\nMentioned in chat:\n")
+		for _, chat := range result.Chats {
+			sb.WriteString(fmt.Sprintf("  with %s — %d message(s), last %s from %s: %q\n",
+				strings.Join(chat.Participants, ", "), chat.Mentions, formatTimeAgoAt(chat.LastAt, now), chat.LastFrom, chat.Excerpt))
+		}
+	}
+
+	if result.Tree != nil {
+		sb.WriteString("\nDependencies:\n")
+		sb.WriteString(formatInspectTree(result.BeadID, result.Tree))
+	}
+
+	for _, w := range result.Warnings {
+		sb.WriteString(fmt.Sprintf("\nWarning: %s", w))
+	}
+	if len(result.Warnings) > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func formatInspectSync(s *InspectSync, now time.Time) string {
+	var text string
+	switch s.State {
+	case inspectSyncInSync:
+		text = "BeadHub has the latest version"
+	case inspectSyncUnsynced:
+		text = "local changes not synced yet"
+	case inspectSyncMissing:
+		text = "not on BeadHub - run 'bdh :verify-sync --repair'"
+	default:
+		text = "BeadHub's copy differs - run 'bdh :verify-sync --repair'"
+	}
+	if s.LastSync != "" {
+		text += fmt.Sprintf(" (last sync %s)", formatTimeAgoAt(s.LastSync, now))
+	}
+	return text
+}
+
+// formatInspectTree draws the parent, the bead and its children as a tree,
+// with blockers listed below.
+func formatInspectTree(beadID string, tree *InspectTree) string {
+	describe := func(ref InspectBeadRef) string {
+		s := ref.ID
+		if ref.Title != "" {
+			s += "  " + ref.Title
+		}
+		if ref.Status != "" {
+			s += fmt.Sprintf(" [%s]", ref.Status)
+		}
+		return s
+	}
+
+	var sb strings.Builder
+	indent := "  "
+	if tree.Parent != nil {
+		sb.WriteString(indent + describe(*tree.Parent) + "\n")
+		sb.WriteString(indent + "└── " + beadID + " (this bead)\n")
+		indent += "    "
+	} else {
+		sb.WriteString(indent + beadID + " (this bead)\n")
+	}
+	for i, child := range tree.Children {
+		branch := "├── "
+		if i == len(tree.Children)-1 {
+			branch = "└── "
+		}
+		sb.WriteString(indent + branch + describe(child) + "\n")
+	}
+	for _, ref := range tree.BlockedBy {
+		sb.WriteString("  blocked by " + describe(ref) + "\n")
+	}
+	for _, ref := range tree.Blocks {
+		sb.WriteString("  blocks " + describe(ref) + "\n")
+	}
+	return sb.String()
+}
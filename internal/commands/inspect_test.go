@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// inspectBeadHub is the fake with a sync manifest and reservation history.
+type inspectBeadHub struct {
+	*clienttest.Fake
+	manifest *client.SyncManifestResponse
+	events   []client.ReservationEvent
+}
+
+func (f *inspectBeadHub) SyncManifest(ctx context.Context, req *client.SyncManifestRequest) (*client.SyncManifestResponse, error) {
+	return f.manifest, nil
+}
+
+func (f *inspectBeadHub) ReservationHistory(ctx context.Context, req *client.ReservationHistoryRequest) (*client.ReservationHistoryResponse, error) {
+	return &client.ReservationHistoryResponse{Events: f.events}, nil
+}
+
+// chatStub is an aweb server with reservations and chat history.
+type chatStub struct {
+	reservationsStub
+	sessions []aweb.ChatSessionItem
+	messages map[string][]aweb.ChatMessage
+}
+
+func (s *chatStub) ChatListSessions(ctx context.Context) (*aweb.ChatListSessionsResponse, error) {
+	return &aweb.ChatListSessionsResponse{Sessions: s.sessions}, nil
+}
+
+func (s *chatStub) ChatHistory(ctx context.Context, p aweb.ChatHistoryParams) (*aweb.ChatHistoryResponse, error) {
+	return &aweb.ChatHistoryResponse{Messages: s.messages[p.SessionID]}, nil
+}
+
+func TestInspectBead_MergesServerData(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"Login epic","status":"open","issue_type":"epic"}
+{"id":"bd-42","title":"Fix login","status":"in_progress","dependencies":[{"issue_id":"bd-42","depends_on_id":"bd-1","type":"parent-child"},{"issue_id":"bd-42","depends_on_id":"bd-40","type":"blocks"}]}
+{"id":"bd-40","title":"Session store","status":"open"}
+{"id":"bd-43","title":"Login tests","status":"open","dependencies":[{"issue_id":"bd-43","depends_on_id":"bd-42","type":"parent-child"}]}
+`)
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	now := time.Now().UTC()
+	fake := &inspectBeadHub{
+		Fake:     clienttest.New(),
+		manifest: &client.SyncManifestResponse{IssueHashes: map[string]string{"bd-42": "stale"}},
+		events: []client.ReservationEvent{
+			{Path: "auth.go", Kind: client.ReservationEventAcquired, HolderAlias: "claude-be", BeadID: "bd-42", At: now.Add(-2 * time.Hour).Format(time.RFC3339)},
+			{Path: "auth.go", Kind: client.ReservationEventConflict, HolderAlias: "claude-be", RequestedBy: "claude-fe", BeadID: "bd-42", At: now.Add(-time.Hour).Format(time.RFC3339)},
+			{Path: "other.go", Kind: client.ReservationEventAcquired, HolderAlias: "claude-fe", BeadID: "bd-7", At: now.Format(time.RFC3339)},
+		},
+	}
+	fake.EnableFeatures(featureReservationHistory)
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-be", Alias: "claude-be",
+		Claims: []client.Claim{{BeadID: "bd-42", ClaimedAt: now.Add(-3 * time.Hour).Format(time.RFC3339)}}})
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-fe", Alias: "claude-fe",
+		Claims: []client.Claim{{BeadID: "bd-7"}}})
+
+	expires := now.Add(time.Hour).Format(time.RFC3339)
+	aw := &chatStub{
+		reservationsStub: reservationsStub{held: []aweb.ReservationView{
+			{ResourceKey: "auth.go", HolderAlias: "claude-be", ExpiresAt: expires, Metadata: map[string]any{reservationBeadIDKey: "bd-42"}},
+			{ResourceKey: "other.go", HolderAlias: "claude-fe", ExpiresAt: expires, Metadata: map[string]any{reservationBeadIDKey: "bd-7"}},
+		}},
+		sessions: []aweb.ChatSessionItem{
+			{SessionID: "s-1", Participants: []string{"claude-be", "claude-fe"}},
+			{SessionID: "s-2", Participants: []string{"claude-be", "claude-qa"}},
+		},
+		messages: map[string][]aweb.ChatMessage{
+			"s-1": {
+				{FromAgent: "claude-fe", Body: "are you on bd-42?", Timestamp: now.Add(-time.Hour).Format(time.RFC3339)},
+				{FromAgent: "claude-be", Body: "yes, bd-42 needs bd-40 first", Timestamp: now.Add(-30 * time.Minute).Format(time.RFC3339)},
+			},
+			"s-2": {{FromAgent: "claude-qa", Body: "bd-420 is flaky", Timestamp: now.Format(time.RFC3339)}},
+		},
+	}
+	ctx := withAPIs(context.Background(), fake, aw)
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "claude-be", WorkspaceID: "ws-be", RepoID: "repo-1",
+		CanonicalOrigin: "github.com/test/repo"}
+	result, err := inspectBead(ctx, cfg, "bd-42", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bead Issue
+	if err := json.Unmarshal(result.Bead, &bead); err != nil || bead.Title != "Fix login" {
+		t.Errorf("bead = %s (%v)", result.Bead, err)
+	}
+	if len(result.Claims) != 1 || result.Claims[0].Alias != "claude-be" {
+		t.Errorf("claims = %+v", result.Claims)
+	}
+	if calls := fake.Calls("Workspaces"); len(calls) != 1 || calls[0].Args[0].(*client.WorkspacesRequest).Repo != "github.com/test/repo" {
+		t.Errorf("claims must be listed for this repo only, got %+v", calls)
+	}
+	if len(result.Reservations) != 1 || result.Reservations[0].Path != "auth.go" {
+		t.Errorf("reservations = %+v", result.Reservations)
+	}
+	if len(result.ReservationEvents) != 2 || result.ReservationEvents[0].Kind != client.ReservationEventConflict {
+		t.Errorf("reservation events = %+v", result.ReservationEvents)
+	}
+	if len(result.Chats) != 1 || result.Chats[0].SessionID != "s-1" || result.Chats[0].Mentions != 2 || result.Chats[0].LastFrom != "claude-be" {
+		t.Errorf("chats = %+v", result.Chats)
+	}
+	if result.Sync == nil || result.Sync.State != inspectSyncUnsynced {
+		t.Errorf("sync = %+v", result.Sync)
+	}
+	tree := result.Tree
+	if tree == nil || tree.Parent == nil || tree.Parent.ID != "bd-1" || len(tree.Children) != 1 || tree.Children[0].ID != "bd-43" ||
+		len(tree.BlockedBy) != 1 || tree.BlockedBy[0].Title != "Session store" {
+		t.Errorf("tree = %+v", tree)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("warnings = %v", result.Warnings)
+	}
+
+	result.show = "bd-42: Fix login\n"
+	out := formatInspectOutput(result, false)
+	for _, want := range []string{
+		"bd-42: Fix login\n\nClaimed by: claude-be (since 3h ago)",
+		"Sync: local changes not synced yet",
+		"`auth.go` — held by claude-be (expires in",
+		"`auth.go` conflict by claude-be, refused to claude-fe",
+		"with claude-be, claude-fe — 2 message(s), last 30m ago from claude-be: \"yes, bd-42 needs bd-40 first\"",
+		"  bd-1  Login epic [open]\n  └── bd-42 (this bead)\n      └── bd-43  Login tests [open]\n  blocked by bd-40  Session store [open]\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestInspectBead_UnknownBeadFails(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open"}
+`)
+	ctx := withFakeBeadHub(clienttest.New())
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "claude-be", WorkspaceID: "ws-be"}
+	if _, err := inspectBead(ctx, cfg, "bd-99", false); err == nil || !strings.Contains(err.Error(), "bd show bd-99 failed") {
+		t.Errorf("expected bd show failure, got %v", err)
+	}
+}
+
+func TestMentionsBead(t *testing.T) {
+	for text, want := range map[string]bool{
+		"see bd-42.":      true,
+		"(bd-42)":         true,
+		"bd-420 is flaky": false,
+		"bd-42-fix":       false,
+		"nothing here":    false,
+	} {
+		if got := mentionsBead(text, "bd-42"); got != want {
+			t.Errorf("mentionsBead(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
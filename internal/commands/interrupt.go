@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/bd"
+)
+
+// main runs every command under one context that Ctrl-C and SIGTERM cancel.
+// HTTP calls and bd share it, so an interrupt stops whatever is in flight.
+// runPassthrough then undoes what it can: reservations it acquired are
+// released and a mutation that was not synced is recorded for :replay. The
+// returned InterruptedError lists what did and did not happen.
+
+// interrupted reports whether the command context has been cancelled.
+func interrupted(ctx context.Context) bool {
+	return ctx.Err() != nil
+}
+
+// cleanupContext is for the calls that tidy up after an interrupt; it keeps
+// the command context's values but not its cancellation.
+func cleanupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), apiTimeout)
+}
+
+// InterruptedError is returned when a command was cancelled part way. Done
+// lists what had already happened, in order.
+type InterruptedError struct {
+	Command string
+	Done    []string
+}
+
+func (e *InterruptedError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("interrupted")
+	if e.Command != "" {
+		sb.WriteString(": bdh " + e.Command)
+	}
+	for _, d := range e.Done {
+		sb.WriteString("\n  - " + d)
+	}
+	return sb.String()
+}
+
+// Unwrap lets callers test for cancellation with errors.Is(err, context.Canceled).
+func (e *InterruptedError) Unwrap() error {
+	return context.Canceled
+}
+
+// IsInterrupted reports whether err comes from a cancelled command.
+func IsInterrupted(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// releaseInterruptedReservations releases the reservations a cancelled
+// command acquired and describes the outcome for the summary.
+func releaseInterruptedReservations(ctx context.Context, aw AwebAPI, acquired []string) string {
+	if len(acquired) == 0 {
+		return ""
+	}
+	if aw == nil {
+		return fmt.Sprintf("could not release %d reservation(s) acquired by this command: %s", len(acquired), strings.Join(acquired, ", "))
+	}
+	var failed []string
+	for _, path := range acquired {
+		ctx, cancel := cleanupContext(ctx)
+		_, err := aw.ReservationRelease(ctx, &aweb.ReservationReleaseRequest{ResourceKey: path})
+		cancel()
+		if err != nil {
+			failed = append(failed, path)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Sprintf("could not release %s - run 'bdh :aweb locks' to check", strings.Join(failed, ", "))
+	}
+	return fmt.Sprintf("released %d reservation(s) acquired by this command", len(acquired))
+}
+
+// interruptedPassthrough tidies up after an interrupt in runPassthrough:
+// it records an unsynced mutation for :replay, releases the reservations
+// the command acquired and summarises what happened. bdRan says whether bd
+// was started and needsSync whether its changes still have to be synced.
+func interruptedPassthrough(ctx context.Context, args []string, aw AwebAPI, result *PassthroughResult, bdRan, needsSync bool) *InterruptedError {
+	e := &InterruptedError{Command: shellQuoteArgs(args)}
+	switch {
+	case !bdRan:
+		e.Done = append(e.Done, "bd did not run")
+	case result.ExitCode != 0:
+		e.Done = append(e.Done, fmt.Sprintf("bd was stopped (exit code %d); its changes may be partial", result.ExitCode))
+	default:
+		e.Done = append(e.Done, "bd finished")
+	}
+	switch {
+	case needsSync:
+		opID := recordFailedOp(FailedOperation{
+			Kind:    failedOpSync,
+			Error:   "interrupted before sync",
+			BdArgs:  args,
+			Targets: bd.AffectedIssueIDs(args),
+		})
+		e.Done = append(e.Done, "changes were not synced to BeadHub"+replayHint(opID))
+	case result.SyncFailed:
+		e.Done = append(e.Done, "sync to BeadHub did not complete (see the warning above)")
+	case result.SyncStats != nil:
+		e.Done = append(e.Done, "changes were synced to BeadHub")
+	}
+	if note := releaseInterruptedReservations(ctx, aw, result.AutoReserved); note != "" {
+		e.Done = append(e.Done, note)
+		result.AutoReserved = nil
+	}
+	return e
+}
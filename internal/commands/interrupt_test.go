@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestInterruptedError(t *testing.T) {
+	err := error(&InterruptedError{Command: "close bd-1", Done: []string{"bd did not run"}})
+	if !IsInterrupted(err) || !errors.Is(err, context.Canceled) {
+		t.Fatal("InterruptedError should unwrap to context.Canceled")
+	}
+	if !IsInterrupted(fmt.Errorf("listing: %w", context.Canceled)) || IsInterrupted(errors.New("boom")) {
+		t.Fatal("IsInterrupted misclassifies errors")
+	}
+	if got := err.Error(); got != "interrupted: bdh close bd-1\n  - bd did not run" {
+		t.Fatalf("Error() = %q", got)
+	}
+}
+
+func TestRunPassthrough_InterruptedBeforeBdRuns(t *testing.T) {
+	server, _ := readyViewServer(t)
+	defer server.Close()
+	setupReadyViewWorkspace(t, server.URL)
+	// bd is not on PATH: the interrupted command must not try to run it
+	t.Setenv("PATH", t.TempDir())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := runPassthrough(ctx, []string{"update", "bd-1", "--status", "in_progress"})
+	var ie *InterruptedError
+	if !errors.As(err, &ie) {
+		t.Fatalf("err = %v, want InterruptedError", err)
+	}
+	if result == nil || result.ExitCode != 0 || result.Stdout != "" {
+		t.Fatalf("partial result = %+v", result)
+	}
+	if !strings.Contains(err.Error(), "bd did not run") {
+		t.Fatalf("summary = %q", err.Error())
+	}
+}
+
+func TestInterruptedPassthrough_ReleasesAndQueuesSync(t *testing.T) {
+	var mu sync.Mutex
+	var released []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/reservations/release" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		released = append(released, body["resource_key"].(string))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"status": "released", "resource_key": body["resource_key"]})
+	}))
+	defer server.Close()
+	setupReadyViewWorkspace(t, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	aw, err := newAwebClient(ctx, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &PassthroughResult{ExitCode: -1, AutoReserved: []string{"a.go", "b.go"}}
+	ie := interruptedPassthrough(ctx, []string{"close", "bd-1"}, aw, result, true, true)
+
+	if strings.Join(released, ",") != "a.go,b.go" {
+		t.Fatalf("released %v despite the cancelled command context", released)
+	}
+	if result.AutoReserved != nil {
+		t.Error("released reservations should not be reported as held")
+	}
+	summary := ie.Error()
+	for _, want := range []string{"bd was stopped (exit code -1)", "not synced to BeadHub (retry: bdh :replay", "released 2 reservation(s)"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+	if op := pendingFailedSync(); op == nil || strings.Join(op.BdArgs, " ") != "close bd-1" {
+		t.Fatalf("queued sync = %+v", op)
+	}
+}
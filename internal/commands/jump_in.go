@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// When several agents hold the bead, --:jump-in tells them all by default.
+// The claimant who started first is the primary: the one to coordinate with.
+// The notification and the output list every claimant with when they were
+// last seen, and --:notify-only narrows the recipients to some of them.
+
+// JumpInClaimant is an agent holding the bead joined with --:jump-in.
+type JumpInClaimant struct {
+	Alias       string `json:"alias"`
+	HumanName   string `json:"human_name,omitempty"`
+	WorkspaceID string `json:"workspace_id"`
+	StartedAt   string `json:"started_at,omitempty"`
+	LastSeen    string `json:"last_seen,omitempty"`
+	Primary     bool   `json:"primary,omitempty"`
+	Notified    bool   `json:"notified"`
+}
+
+// rankJumpInClaimants orders claimants by when they started on the bead and
+// marks the earliest as primary. Claims without a start time sort last.
+func rankJumpInClaimants(claimants []client.BeadInProgress, lastSeen map[string]string) []JumpInClaimant {
+	out := make([]JumpInClaimant, 0, len(claimants))
+	for _, c := range claimants {
+		out = append(out, JumpInClaimant{
+			Alias:       c.Alias,
+			HumanName:   c.HumanName,
+			WorkspaceID: c.WorkspaceID,
+			StartedAt:   c.StartedAt,
+			LastSeen:    lastSeen[c.WorkspaceID],
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ti, oki := parseTimeBestEffort(out[i].StartedAt)
+		tj, okj := parseTimeBestEffort(out[j].StartedAt)
+		if oki != okj {
+			return oki
+		}
+		return oki && ti.Before(tj)
+	})
+	if len(out) > 0 {
+		out[0].Primary = true
+	}
+	return out
+}
+
+// parseNotifyOnly splits --:notify-only values into aliases. Each value may
+// be a comma-separated list.
+func parseNotifyOnly(values []string) ([]string, error) {
+	var aliases []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				aliases = append(aliases, part)
+			}
+		}
+	}
+	if len(values) > 0 && len(aliases) == 0 {
+		return nil, fmt.Errorf("--:notify-only requires an alias")
+	}
+	return aliases, nil
+}
+
+// markJumpInRecipients marks the claimants to notify: all of them, or only
+// those named by --:notify-only. Naming an alias that does not hold the bead
+// is an error, so a typo does not silently leave someone out.
+func markJumpInRecipients(claimants []JumpInClaimant, notifyOnly []string) error {
+	if len(notifyOnly) == 0 {
+		for i := range claimants {
+			claimants[i].Notified = true
+		}
+		return nil
+	}
+	var unknown []string
+	for _, alias := range notifyOnly {
+		found := false
+		for i := range claimants {
+			if strings.EqualFold(claimants[i].Alias, alias) {
+				claimants[i].Notified = true
+				found = true
+			}
+		}
+		if !found {
+			unknown = append(unknown, alias)
+		}
+	}
+	if len(unknown) > 0 {
+		var holders []string
+		for _, c := range claimants {
+			holders = append(holders, c.Alias)
+		}
+		return fmt.Errorf("--:notify-only: %s not working on this bead (claimants: %s)",
+			strings.Join(unknown, ", "), strings.Join(holders, ", "))
+	}
+	return nil
+}
+
+// fetchClaimantLastSeen looks up when each claimant was last seen
+// (best-effort: an empty map when the team query is unavailable).
+func fetchClaimantLastSeen(ctx context.Context, cfg *config.Config, c BeadHubAPI, claimants []client.BeadInProgress) map[string]string {
+	lastSeen := make(map[string]string)
+	if len(claimants) == 0 || !serverSupports(ctx, cfg, featureTeamQuery) {
+		return lastSeen
+	}
+	wanted := make(map[string]bool, len(claimants))
+	for _, bip := range claimants {
+		wanted[bip.WorkspaceID] = true
+	}
+	includePresence := true
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+		IncludePresence: &includePresence,
+		Limit:           maxWorkspaceQueryLimit,
+	})
+	if err != nil {
+		return lastSeen
+	}
+	for _, ws := range resp.Workspaces {
+		if wanted[ws.WorkspaceID] && ws.LastSeen != "" {
+			lastSeen[ws.WorkspaceID] = ws.LastSeen
+		}
+	}
+	return lastSeen
+}
+
+// jumpInNotifications builds the message for every claimant that asked to
+// be notified.
+func jumpInNotifications(alias, beadID, reason string, claimants []JumpInClaimant, now time.Time) []TxnNotification {
+	body := jumpInNotifyMessage(alias, beadID, reason, claimants, now)
+	var notes []TxnNotification
+	for _, agent := range claimants {
+		if agent.Notified {
+			notes = append(notes, TxnNotification{ToAgentID: agent.WorkspaceID, ToAlias: agent.Alias, Body: body})
+		}
+	}
+	return notes
+}
+
+// jumpInNotifyMessage is the message sent to claimants. With more than one
+// claimant it lists all of them so each knows who else is on the bead.
+func jumpInNotifyMessage(alias, beadID, reason string, claimants []JumpInClaimant, now time.Time) string {
+	msg := fmt.Sprintf("%s is joining work on %s: %s", alias, beadID, reason)
+	if len(claimants) < 2 {
+		return msg
+	}
+	var sb strings.Builder
+	sb.WriteString(msg + "\n\nCurrent claimants:\n")
+	for _, c := range claimants {
+		sb.WriteString("- " + describeJumpInClaimant(c, func(ts string) string { return formatTimeAgoAt(ts, now) }) + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// describeJumpInClaimant renders "alias (Human) - primary, started 2h ago,
+// last seen 5m ago" with timestamps rendered by at.
+func describeJumpInClaimant(c JumpInClaimant, at func(string) string) string {
+	line := c.Alias
+	if c.HumanName != "" {
+		line += " (" + c.HumanName + ")"
+	}
+	var meta []string
+	if c.Primary {
+		meta = append(meta, "primary")
+	}
+	if c.StartedAt != "" {
+		meta = append(meta, "started "+at(c.StartedAt))
+	}
+	if c.LastSeen != "" {
+		meta = append(meta, "last seen "+at(c.LastSeen))
+	} else {
+		meta = append(meta, "last seen unknown")
+	}
+	return line + " - " + strings.Join(meta, ", ")
+}
+
+// formatJumpInSection lists the claimants of the joined bead and which of
+// them were notified.
+func formatJumpInSection(beadID string, claimants []JumpInClaimant) string {
+	if len(claimants) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinationHeader())
+	sb.WriteString(fmt.Sprintf("\n## Joined %s\n", beadID))
+	for _, c := range claimants {
+		line := "- " + describeJumpInClaimant(c, formatTimestamp)
+		if !c.Notified {
+			line += " (not notified)"
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
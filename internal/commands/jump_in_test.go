@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestRankJumpInClaimants_EarliestIsPrimary(t *testing.T) {
+	claimants := []client.BeadInProgress{
+		{Alias: "late", WorkspaceID: "ws-late", StartedAt: "2025-01-01T12:00:00Z"},
+		{Alias: "unknown", WorkspaceID: "ws-unknown"},
+		{Alias: "early", WorkspaceID: "ws-early", StartedAt: "2025-01-01T09:00:00Z"},
+	}
+	ranked := rankJumpInClaimants(claimants, map[string]string{"ws-late": "2025-01-01T12:30:00Z"})
+
+	var order []string
+	for _, c := range ranked {
+		order = append(order, c.Alias)
+	}
+	if got := strings.Join(order, ","); got != "early,late,unknown" {
+		t.Fatalf("order = %s, want early,late,unknown", got)
+	}
+	if !ranked[0].Primary || ranked[1].Primary || ranked[2].Primary {
+		t.Fatalf("only the earliest claimant should be primary: %+v", ranked)
+	}
+	if ranked[1].LastSeen != "2025-01-01T12:30:00Z" || ranked[0].LastSeen != "" {
+		t.Fatalf("last seen not attached by workspace: %+v", ranked)
+	}
+}
+
+func TestMarkJumpInRecipients(t *testing.T) {
+	claimants := []JumpInClaimant{{Alias: "alice"}, {Alias: "bob"}, {Alias: "carol"}}
+	if err := markJumpInRecipients(claimants, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range claimants {
+		if !c.Notified {
+			t.Fatalf("without --:notify-only everyone is notified: %+v", claimants)
+		}
+	}
+
+	claimants = []JumpInClaimant{{Alias: "alice"}, {Alias: "bob"}, {Alias: "carol"}}
+	if err := markJumpInRecipients(claimants, []string{"Bob", "carol"}); err != nil {
+		t.Fatal(err)
+	}
+	if claimants[0].Notified || !claimants[1].Notified || !claimants[2].Notified {
+		t.Fatalf("unexpected recipients: %+v", claimants)
+	}
+
+	err := markJumpInRecipients([]JumpInClaimant{{Alias: "alice"}}, []string{"mallory"})
+	if err == nil || !strings.Contains(err.Error(), "mallory") || !strings.Contains(err.Error(), "alice") {
+		t.Fatalf("expected unknown-alias error naming claimants, got %v", err)
+	}
+}
+
+func TestParseNotifyOnly(t *testing.T) {
+	got, err := parseNotifyOnly([]string{"alice, bob", "carol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "alice,bob,carol" {
+		t.Fatalf("got %v", got)
+	}
+	if _, err := parseNotifyOnly([]string{""}); err == nil {
+		t.Fatal("expected error for empty --:notify-only")
+	}
+}
+
+func TestJumpInNotifyMessage_ListsClaimants(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	single := jumpInNotifyMessage("me", "bd-42", "helping out", []JumpInClaimant{{Alias: "alice", Primary: true}}, now)
+	if single != "me is joining work on bd-42: helping out" {
+		t.Fatalf("single-claimant message = %q", single)
+	}
+
+	msg := jumpInNotifyMessage("me", "bd-42", "helping out", []JumpInClaimant{
+		{Alias: "alice", Primary: true, StartedAt: "2025-01-01T10:00:00Z", LastSeen: "2025-01-01T11:55:00Z"},
+		{Alias: "bob", StartedAt: "2025-01-01T11:00:00Z"},
+	}, now)
+	for _, want := range []string{
+		"me is joining work on bd-42: helping out",
+		"alice - primary, started 2h ago, last seen 5m ago",
+		"bob - started 1h ago, last seen unknown",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestPassthrough_JumpInNotifyOnly(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	resetCapabilitiesMemo()
+	t.Cleanup(resetCapabilitiesMemo)
+
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+	os.MkdirAll(".beads", 0755)
+	os.WriteFile(".beads/issues.jsonl", []byte(`{"id":"bd-42","title":"Test","status":"open"}`), 0644)
+
+	var sentTo []string
+	var sentBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/bdh/command":
+			json.NewEncoder(w).Encode(map[string]any{
+				"approved": false,
+				"reason":   "bd-42 is being worked on",
+				"context": map[string]any{
+					"beads_in_progress": []any{
+						map[string]any{"bead_id": "bd-42", "workspace_id": "ws-bob", "alias": "bob", "started_at": "2025-01-01T11:00:00Z"},
+						map[string]any{"bead_id": "bd-42", "workspace_id": "ws-alice", "alias": "alice", "started_at": "2025-01-01T10:00:00Z"},
+					},
+				},
+			})
+		case "/v1/workspaces/team":
+			json.NewEncoder(w).Encode(map[string]any{
+				"workspaces": []any{
+					map[string]any{"workspace_id": "ws-alice", "alias": "alice", "last_seen": "2025-01-01T11:55:00Z"},
+				},
+			})
+		case "/v1/bdh/sync":
+			json.NewEncoder(w).Encode(map[string]any{"synced": true, "issues_count": 1})
+		case "/v1/messages":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			sentTo = append(sentTo, req["to_agent_id"])
+			sentBody = req["body"]
+			json.NewEncoder(w).Encode(map[string]any{"message_id": "msg_1", "status": "delivered"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	cfg.Save()
+
+	result, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--status", "in_progress", "--:jump-in", "pairing", "--:notify-only", "alice"})
+	if err != nil {
+		t.Fatalf("runPassthrough: %v", err)
+	}
+	if len(sentTo) != 1 || sentTo[0] != "ws-alice" {
+		t.Fatalf("notified %v, want only ws-alice", sentTo)
+	}
+	if !strings.Contains(sentBody, "bob") || !strings.Contains(sentBody, "alice - primary") {
+		t.Errorf("notification should list all claimants, got:\n%s", sentBody)
+	}
+	if len(result.JumpInClaimants) != 2 || result.JumpInClaimants[0].Alias != "alice" || result.JumpInClaimants[0].LastSeen == "" {
+		t.Fatalf("claimants = %+v", result.JumpInClaimants)
+	}
+	if result.JumpInClaimants[1].Notified {
+		t.Error("bob should not be notified")
+	}
+
+	out := formatPassthroughOutput(result)
+	if !strings.Contains(out, "Joined bd-42") || !strings.Contains(out, "bob - started") || !strings.Contains(out, "(not notified)") {
+		t.Errorf("output should list claimants:\n%s", out)
+	}
+
+	if _, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--:notify-only", "alice"}); err == nil {
+		t.Error("--:notify-only without --:jump-in should error")
+	}
+	if _, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--:jump-in", "pairing", "--:notify-only", "mallory"}); err == nil || !strings.Contains(err.Error(), "mallory") {
+		t.Errorf("unknown --:notify-only alias should error, got %v", err)
+	}
+}
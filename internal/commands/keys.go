@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// KeyListEntry is one row of :keys list.
+type KeyListEntry struct {
+	Alias     string `json:"alias"`
+	KeyID     string `json:"key_id,omitempty"`
+	Published bool   `json:"published"`
+	CreatedAt string `json:"created_at,omitempty"`
+	// Changed is set when the published key differs from the pinned one.
+	Changed bool `json:"changed,omitempty"`
+}
+
+var (
+	keysForce bool
+	keysJSON  bool
+)
+
+var keysCmd = &cobra.Command{
+	Use:   ":keys",
+	Short: "Manage the key used for encrypted mail and chat",
+	Long: `Manage this workspace's message encryption key.
+
+'bdh :keys init' creates a key pair, keeps the private key in
+.beadhub-cache/message-key.json and publishes the public key so others can
+send this workspace encrypted messages (mail send --encrypt, chat send
+--encrypt). Encrypted messages are decrypted automatically when shown.
+
+The first key seen for each recipient is pinned; if it later changes,
+encrypting for them fails until the new key is accepted with
+'bdh :keys trust <alias>'.
+
+Examples:
+  bdh :keys init
+  bdh :keys list alice bob
+  bdh :keys trust alice
+  bdh :aweb mail send alice "deploy token: ..." --encrypt`,
+}
+
+var keysInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create (or re-publish) this workspace's key",
+	Long: `Create this workspace's key pair and publish the public key. When a key
+already exists it is published again; use --force to replace it (messages
+sealed for the old key can no longer be read).`,
+	Args: cobra.NoArgs,
+	RunE: runKeysInit,
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list [alias...]",
+	Short: "Show published keys (default: this workspace)",
+	RunE:  runKeysList,
+}
+
+var keysTrustCmd = &cobra.Command{
+	Use:   "trust <alias>...",
+	Short: "Accept the currently published keys of aliases",
+	Long: `Pin the keys the aliases currently publish, replacing the ones seen
+before. Run it after confirming with them, out of band, that they replaced
+their key (bdh :keys init --force); 'bdh :keys list' shows the key IDs to
+compare.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runKeysTrust,
+}
+
+func init() {
+	keysInitCmd.Flags().BoolVar(&keysForce, "force", false, "Replace the existing key")
+	keysListCmd.Flags().BoolVar(&keysJSON, "json", false, "Output as JSON")
+
+	keysCmd.AddCommand(keysInitCmd)
+	keysCmd.AddCommand(keysListCmd)
+	keysCmd.AddCommand(keysTrustCmd)
+}
+
+func runKeysInit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	key, err := loadMessageKey()
+	if err != nil {
+		return err
+	}
+	created := false
+	if key == nil || keysForce {
+		key, err = age.GenerateX25519Identity()
+		if err != nil {
+			return err
+		}
+		if err := saveMessageKey(key, cfg.Alias, time.Now()); err != nil {
+			return fmt.Errorf("saving key: %w", err)
+		}
+		created = true
+	}
+
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if _, err := c.PublishKey(ctx, &client.PublishKeyRequest{
+		WorkspaceID: cfg.WorkspaceID,
+		Alias:       cfg.Alias,
+		Algorithm:   client.KeyAlgorithmAgeX25519,
+		PublicKey:   key.Recipient().String(),
+	}); err != nil {
+		return fmt.Errorf("publishing key: %w", err)
+	}
+
+	verb := "Published existing"
+	if created {
+		verb = "Created and published"
+	}
+	fmt.Printf("%s key %s for %s\n", verb, messageKeyID(key.Recipient()), cfg.Alias)
+	return nil
+}
+
+func runKeysList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	aliases := args
+	if len(aliases) == 0 {
+		aliases = []string{cfg.Alias}
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+	defer cancel()
+	resp, err := newBeadHubClient(ctx, cfg.BeadhubURL).PublicKeys(ctx, &client.PublicKeysRequest{Aliases: aliases})
+	if err != nil {
+		return fmt.Errorf("looking up keys: %w", err)
+	}
+	path, err := knownKeysPath()
+	if err != nil {
+		return err
+	}
+	known, err := loadKnownKeys(path)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatKeyList(keyListEntries(aliases, resp.Keys, known), keysJSON))
+	return nil
+}
+
+func runKeysTrust(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+	defer cancel()
+	resp, err := newBeadHubClient(ctx, cfg.BeadhubURL).PublicKeys(ctx, &client.PublicKeysRequest{Aliases: args})
+	if err != nil {
+		return fmt.Errorf("looking up keys: %w", err)
+	}
+	entries := keyListEntries(args, resp.Keys, nil)
+	for _, e := range entries {
+		if !e.Published {
+			return fmt.Errorf("%s has no usable published key", e.Alias)
+		}
+	}
+	var keys []messageRecipientKey
+	for _, k := range resp.Keys {
+		pub, err := parsePublishedKey(k)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, messageRecipientKey{Alias: k.Alias, Key: pub})
+	}
+	if err := trustKeys(keys, time.Now()); err != nil {
+		return fmt.Errorf("saving trusted keys: %w", err)
+	}
+	for _, e := range entries {
+		fmt.Printf("Trusted key %s for %s\n", e.KeyID, e.Alias)
+	}
+	return nil
+}
+
+// keyListEntries lists every requested alias, published or not, in order,
+// flagging keys that differ from the pinned ones in known.
+func keyListEntries(aliases []string, keys []client.PublicKey, known map[string]knownMessageKey) []KeyListEntry {
+	byAlias := make(map[string]client.PublicKey, len(keys))
+	for _, k := range keys {
+		byAlias[k.Alias] = k
+	}
+	entries := make([]KeyListEntry, 0, len(aliases))
+	for _, alias := range aliases {
+		entry := KeyListEntry{Alias: alias}
+		if k, ok := byAlias[alias]; ok {
+			if pub, err := parsePublishedKey(k); err == nil {
+				entry.KeyID = messageKeyID(pub)
+				entry.Published = true
+				entry.CreatedAt = k.CreatedAt
+				if pinned, ok := known[alias]; ok && pinned.PublicKey != pub.String() {
+					entry.Changed = true
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func formatKeyList(entries []KeyListEntry, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(struct {
+			Keys []KeyListEntry `json:"keys"`
+		}{Keys: entries})
+	}
+	var sb strings.Builder
+	for _, e := range entries {
+		if !e.Published {
+			sb.WriteString(fmt.Sprintf("%s — no published key\n", e.Alias))
+			continue
+		}
+		if e.Changed {
+			sb.WriteString(fmt.Sprintf("%s — key %s (changed since first seen; 'bdh :keys trust %s' to accept)\n", e.Alias, e.KeyID, e.Alias))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s — key %s\n", e.Alias, e.KeyID))
+	}
+	return sb.String()
+}
@@ -0,0 +1,305 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// defaultOverloadedClaims is the claim count above which an agent is flagged overloaded.
+const defaultOverloadedClaims = 3
+
+var (
+	loadJSON         bool
+	loadMaxClaims    int
+	loadIncludeStale bool
+)
+
+var loadCmd = &cobra.Command{
+	Use:   ":load",
+	Short: "Show per-agent claim and reservation load across the team",
+	Long: `Show how much work each agent holds, to help distribute beads.
+
+For every agent in the project, lists active claims with their ages and
+the number of file reservations held. Agents holding more than
+--max-claims beads are flagged overloaded; agents with no claims are
+flagged idle. Agents are sorted busiest first.
+
+By default only agents active in the last 6 hours are shown; use
+--include-stale to show everyone.
+
+JSON output:
+  {
+    "max_claims": 3,
+    "total_claims": 5,
+    "total_reservations": 2,
+    "overloaded": ["alice"],
+    "idle": ["carol"],
+    "agents": [
+      {"alias": "alice", "human_name": "Alice", "role": "backend",
+       "status": "active", "last_seen": "...",
+       "claim_count": 4, "oldest_claim_age_seconds": 5400,
+       "reservation_count": 2, "overloaded": true, "idle": false,
+       "claims": [{"bead_id": "bd-1", "title": "...", "claimed_at": "...", "age_seconds": 5400}]}
+    ]
+  }
+
+Examples:
+  bdh :load
+  bdh :load --max-claims 2
+  bdh :load --json`,
+	Args: cobra.NoArgs,
+	RunE: runLoad,
+}
+
+func init() {
+	loadCmd.Flags().BoolVar(&loadJSON, "json", false, "Output as JSON")
+	loadCmd.Flags().IntVar(&loadMaxClaims, "max-claims", defaultOverloadedClaims, "Flag agents holding more than this many claims")
+	loadCmd.Flags().BoolVar(&loadIncludeStale, "include-stale", false, "Include agents not seen in the last 6 hours")
+}
+
+// LoadClaim is a single claim in the :load view.
+type LoadClaim struct {
+	BeadID     string `json:"bead_id"`
+	Title      string `json:"title,omitempty"`
+	ClaimedAt  string `json:"claimed_at"`
+	AgeSeconds int64  `json:"age_seconds"`
+}
+
+// AgentLoad is one agent's share of the team's work.
+type AgentLoad struct {
+	Alias                 string      `json:"alias"`
+	HumanName             string      `json:"human_name,omitempty"`
+	Role                  string      `json:"role,omitempty"`
+	Status                string      `json:"status,omitempty"`
+	LastSeen              string      `json:"last_seen,omitempty"`
+	ClaimCount            int         `json:"claim_count"`
+	OldestClaimAgeSeconds int64       `json:"oldest_claim_age_seconds"`
+	ReservationCount      int         `json:"reservation_count"`
+	Overloaded            bool        `json:"overloaded"`
+	Idle                  bool        `json:"idle"`
+	Claims                []LoadClaim `json:"claims"`
+}
+
+// LoadResult is the aggregated team load.
+type LoadResult struct {
+	MaxClaims         int         `json:"max_claims"`
+	TotalClaims       int         `json:"total_claims"`
+	TotalReservations int         `json:"total_reservations"`
+	Overloaded        []string    `json:"overloaded"`
+	Idle              []string    `json:"idle"`
+	Agents            []AgentLoad `json:"agents"`
+	Warning           string      `json:"warning,omitempty"`
+}
+
+func runLoad(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if loadMaxClaims < 0 {
+		return fmt.Errorf("--max-claims must be >= 0")
+	}
+
+	result, err := loadWithConfig(ctx, cfg, loadMaxClaims, loadIncludeStale)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatLoadOutput(result, loadJSON))
+	return nil
+}
+
+func loadWithConfig(ctx context.Context, cfg *config.Config, maxClaims int, includeStale bool) (*LoadResult, error) {
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	includeClaims := true
+	includePresence := true
+	resp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+		IncludeClaims:   &includeClaims,
+		IncludePresence: &includePresence,
+		Limit:           maxWorkspaceQueryLimit,
+	})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		if client.IsPayloadError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("BeadHub unreachable at %s: %w", cfg.BeadhubURL, err)
+	}
+
+	workspaces := resp.Workspaces
+	if !includeStale {
+		workspaces = filterRecentlyActive(workspaces, teamActivityThreshold())
+	}
+
+	// Reservation counts are best-effort; the claim view is still useful without them.
+	var locks []client.LockInfo
+	var warning string
+	locksResp, err := c.ListLocks(ctx, &client.ListLocksRequest{WorkspaceID: cfg.WorkspaceID})
+	if err != nil {
+		warning = fmt.Sprintf("could not fetch reservations: %v", err)
+	} else {
+		locks = locksResp.Reservations
+	}
+
+	result := aggregateLoad(workspaces, locks, maxClaims, time.Now())
+	result.Warning = warning
+	return result, nil
+}
+
+// filterRecentlyActive keeps workspaces seen since threshold. Workspaces holding
+// claims are always kept so no work goes unaccounted for.
+func filterRecentlyActive(workspaces []client.Workspace, threshold time.Time) []client.Workspace {
+	var kept []client.Workspace
+	for _, ws := range workspaces {
+		seen, ok := parseTimeBestEffort(ws.LastSeen)
+		if len(ws.Claims) > 0 || !ok || !seen.Before(threshold) {
+			kept = append(kept, ws)
+		}
+	}
+	return kept
+}
+
+// aggregateLoad builds per-agent load from team workspaces and reservations,
+// sorted by claim count, then oldest claim, then alias.
+func aggregateLoad(workspaces []client.Workspace, locks []client.LockInfo, maxClaims int, now time.Time) *LoadResult {
+	result := &LoadResult{MaxClaims: maxClaims, Overloaded: []string{}, Idle: []string{}}
+
+	reservationsByWorkspace := make(map[string]int)
+	reservationsByAlias := make(map[string]int)
+	for _, lock := range locks {
+		if lock.WorkspaceID != "" {
+			reservationsByWorkspace[lock.WorkspaceID]++
+		} else {
+			reservationsByAlias[lock.Alias]++
+		}
+	}
+
+	for _, ws := range workspaces {
+		agent := AgentLoad{
+			Alias:            ws.Alias,
+			HumanName:        ws.HumanName,
+			Role:             ws.Role,
+			Status:           ws.Status,
+			LastSeen:         ws.LastSeen,
+			ClaimCount:       len(ws.Claims),
+			ReservationCount: reservationsByWorkspace[ws.WorkspaceID] + reservationsByAlias[ws.Alias],
+			Claims:           []LoadClaim{},
+		}
+		for _, claim := range ws.Claims {
+			lc := LoadClaim{BeadID: claim.BeadID, Title: claim.Title, ClaimedAt: claim.ClaimedAt}
+			if ts, ok := parseTimeBestEffort(claim.ClaimedAt); ok && now.After(ts) {
+				lc.AgeSeconds = int64(now.Sub(ts).Seconds())
+			}
+			if lc.AgeSeconds > agent.OldestClaimAgeSeconds {
+				agent.OldestClaimAgeSeconds = lc.AgeSeconds
+			}
+			agent.Claims = append(agent.Claims, lc)
+		}
+		sort.SliceStable(agent.Claims, func(i, j int) bool {
+			return agent.Claims[i].AgeSeconds > agent.Claims[j].AgeSeconds
+		})
+		agent.Overloaded = agent.ClaimCount > maxClaims
+		agent.Idle = agent.ClaimCount == 0
+
+		result.TotalClaims += agent.ClaimCount
+		result.TotalReservations += agent.ReservationCount
+		result.Agents = append(result.Agents, agent)
+	}
+
+	sort.SliceStable(result.Agents, func(i, j int) bool {
+		a, b := result.Agents[i], result.Agents[j]
+		if a.ClaimCount != b.ClaimCount {
+			return a.ClaimCount > b.ClaimCount
+		}
+		if a.OldestClaimAgeSeconds != b.OldestClaimAgeSeconds {
+			return a.OldestClaimAgeSeconds > b.OldestClaimAgeSeconds
+		}
+		return a.Alias < b.Alias
+	})
+
+	for _, agent := range result.Agents {
+		if agent.Overloaded {
+			result.Overloaded = append(result.Overloaded, agent.Alias)
+		}
+		if agent.Idle {
+			result.Idle = append(result.Idle, agent.Alias)
+		}
+	}
+	if result.Agents == nil {
+		result.Agents = []AgentLoad{}
+	}
+	return result
+}
+
+func formatLoadOutput(result *LoadResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n\n", result.Warning))
+	}
+	if len(result.Agents) == 0 {
+		sb.WriteString("No active agents in the project.\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("## Team Load (%d agents, %d claims, %d reservations)\n",
+		len(result.Agents), result.TotalClaims, result.TotalReservations))
+	for _, agent := range result.Agents {
+		name := agent.Alias
+		if agent.HumanName != "" {
+			name = fmt.Sprintf("%s (%s)", agent.Alias, agent.HumanName)
+		}
+		sb.WriteString(fmt.Sprintf("- %s — %d claim(s), %d reservation(s)", name, agent.ClaimCount, agent.ReservationCount))
+		if agent.ClaimCount > 0 {
+			sb.WriteString(fmt.Sprintf(", oldest %s", formatDuration(int(agent.OldestClaimAgeSeconds))))
+		}
+		switch {
+		case agent.Overloaded:
+			sb.WriteString(" [OVERLOADED]")
+		case agent.Idle:
+			sb.WriteString(" [idle]")
+		}
+		sb.WriteString("\n")
+		for _, claim := range agent.Claims {
+			sb.WriteString(fmt.Sprintf("    %s", claim.BeadID))
+			if claim.Title != "" {
+				sb.WriteString(fmt.Sprintf(" \"%s\"", claim.Title))
+			}
+			sb.WriteString(fmt.Sprintf(" — %s", formatTimestamp(claim.ClaimedAt)))
+			if isClaimStale(claim.ClaimedAt) {
+				sb.WriteString(" (stale)")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(result.Overloaded) > 0 {
+		sb.WriteString(fmt.Sprintf("\nOverloaded (more than %d claims): %s\n", result.MaxClaims, strings.Join(result.Overloaded, ", ")))
+	}
+	if len(result.Idle) > 0 {
+		sb.WriteString(fmt.Sprintf("Idle (no claims): %s\n", strings.Join(result.Idle, ", ")))
+	}
+	return sb.String()
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestAggregateLoad_SortsAndFlags(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+
+	workspaces := []client.Workspace{
+		{WorkspaceID: "ws-carol", Alias: "carol"},
+		{WorkspaceID: "ws-bob", Alias: "bob", Claims: []client.Claim{
+			{BeadID: "bd-5", ClaimedAt: ago(3 * time.Hour)},
+		}},
+		{WorkspaceID: "ws-alice", Alias: "alice", Claims: []client.Claim{
+			{BeadID: "bd-1", ClaimedAt: ago(time.Hour)},
+			{BeadID: "bd-2", ClaimedAt: ago(2 * time.Hour)},
+			{BeadID: "bd-3", ClaimedAt: ago(30 * time.Minute)},
+		}},
+		{WorkspaceID: "ws-dave", Alias: "dave", Claims: []client.Claim{
+			{BeadID: "bd-6", ClaimedAt: ago(time.Hour)},
+		}},
+	}
+	locks := []client.LockInfo{
+		{WorkspaceID: "ws-alice", Alias: "alice", Path: "a.go"},
+		{WorkspaceID: "ws-alice", Alias: "alice", Path: "b.go"},
+		{WorkspaceID: "ws-carol", Alias: "carol", Path: "c.go"},
+	}
+
+	result := aggregateLoad(workspaces, locks, 2, now)
+
+	var order []string
+	for _, a := range result.Agents {
+		order = append(order, a.Alias)
+	}
+	// Equal claim counts fall back to oldest claim: bob's is older than dave's.
+	if strings.Join(order, ",") != "alice,bob,dave,carol" {
+		t.Errorf("order = %v", order)
+	}
+	alice := result.Agents[0]
+	if !alice.Overloaded || alice.ReservationCount != 2 || alice.OldestClaimAgeSeconds != 7200 {
+		t.Errorf("alice = %+v", alice)
+	}
+	if alice.Claims[0].BeadID != "bd-2" {
+		t.Errorf("claims should be oldest first, got %+v", alice.Claims)
+	}
+	if strings.Join(result.Overloaded, ",") != "alice" || strings.Join(result.Idle, ",") != "carol" {
+		t.Errorf("overloaded = %v, idle = %v", result.Overloaded, result.Idle)
+	}
+	if result.TotalClaims != 5 || result.TotalReservations != 3 {
+		t.Errorf("totals = %d claims, %d reservations", result.TotalClaims, result.TotalReservations)
+	}
+}
+
+func TestLoadWithConfig_JSON(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	recent := time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/workspaces/team":
+			if r.URL.Query().Get("include_claims") != "true" {
+				t.Errorf("expected include_claims=true, got %q", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"workspaces": []map[string]any{
+					{"workspace_id": "ws-1", "alias": "alice", "last_seen": recent,
+						"claims": []map[string]any{{"bead_id": "bd-1", "claimed_at": recent}}},
+					{"workspace_id": "ws-2", "alias": "gone", "last_seen": old},
+				},
+			})
+		case "/v1/reservations":
+			json.NewEncoder(w).Encode(map[string]any{
+				"reservations": []map[string]any{{"resource_key": "a.go", "holder_alias": "alice", "holder_agent_id": "ws-1"}},
+				"count":        1,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab", BeadhubURL: server.URL, Alias: "alice"}
+	result, err := loadWithConfig(context.Background(), cfg, defaultOverloadedClaims, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Agents) != 1 {
+		t.Fatalf("stale idle agent should be filtered, got %+v", result.Agents)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(formatLoadOutput(result, true)), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, key := range []string{"max_claims", "total_claims", "total_reservations", "overloaded", "idle", "agents"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("JSON missing %q", key)
+		}
+	}
+	if result.Agents[0].ReservationCount != 1 {
+		t.Errorf("reservation count = %d", result.Agents[0].ReservationCount)
+	}
+}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// mail list pages through the inbox with the server's before cursor:
+// --page N shows the Nth page of --limit messages, --all-pages follows the
+// cursor to the end. --search keeps the messages whose sender, subject or
+// body contain the text; servers with inbox_search filter too, and the
+// filter is applied again here for servers that ignore the parameter.
+// Results are ordered newest first, ties broken by message ID, so the same
+// inbox always pages the same way.
+
+var (
+	awebMailPage     int
+	awebMailAllPages bool
+	awebMailSearch   string
+)
+
+// inboxPageOptions selects the messages mail list shows.
+type inboxPageOptions struct {
+	WorkspaceID     string
+	Limit           int // Page size
+	Page            int // 1-based
+	AllPages        bool
+	UnreadOnly      bool
+	IncludeArchived bool
+	Search          string
+	ServerSearch    bool // Send Search to the server as well
+}
+
+// InboxPage is the messages shown by a paged mail list.
+type InboxPage struct {
+	Messages []client.Message `json:"messages"`
+	Count    int              `json:"count"`
+	Page     int              `json:"page,omitempty"`
+	HasMore  bool             `json:"has_more"`
+	Search   string           `json:"search,omitempty"`
+}
+
+// wantsPagedMailList reports whether mail list needs the paged listing.
+func wantsPagedMailList() bool {
+	return awebMailIncludeArchived || awebMailPage != 0 || awebMailAllPages || strings.TrimSpace(awebMailSearch) != ""
+}
+
+func mailListPageOptions(ctx context.Context, workspaceID string) (inboxPageOptions, error) {
+	if awebMailPage < 0 {
+		return inboxPageOptions{}, fmt.Errorf("--page must be 1 or more")
+	}
+	if awebMailPage > 0 && awebMailAllPages {
+		return inboxPageOptions{}, fmt.Errorf("use either --page or --all-pages, not both")
+	}
+	opts := inboxPageOptions{
+		WorkspaceID:     workspaceID,
+		Limit:           awebMailLimit,
+		Page:            awebMailPage,
+		AllPages:        awebMailAllPages,
+		UnreadOnly:      !awebMailAll,
+		IncludeArchived: awebMailIncludeArchived,
+		Search:          strings.TrimSpace(awebMailSearch),
+	}
+	if opts.Search != "" {
+		if cfg, err := config.Load(); err == nil {
+			opts.ServerSearch = serverSupports(ctx, cfg, featureInboxSearch)
+		}
+	}
+	return opts, nil
+}
+
+// fetchInboxPage fetches pages from the server until the requested page is
+// complete, or every page with AllPages.
+func fetchInboxPage(ctx context.Context, c BeadHubAPI, opts inboxPageOptions) (*InboxPage, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 50
+	}
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+	query := ""
+	if opts.ServerSearch {
+		query = opts.Search
+	}
+	end := opts.Page * opts.Limit
+
+	var matched []client.Message
+	seen := make(map[string]bool)
+	before, serverHasMore := "", true
+	for serverHasMore && (opts.AllPages || len(matched) < end) {
+		reqCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.Inbox(reqCtx, &client.InboxRequest{
+			WorkspaceID:     opts.WorkspaceID,
+			Limit:           opts.Limit,
+			UnreadOnly:      opts.UnreadOnly,
+			IncludeArchived: opts.IncludeArchived,
+			Before:          before,
+			Query:           query,
+		})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		added := 0
+		for _, msg := range resp.Messages {
+			if seen[msg.MessageID] {
+				continue
+			}
+			seen[msg.MessageID] = true
+			added++
+			if inboxMessageMatches(msg, opts.Search) {
+				matched = append(matched, msg)
+			}
+		}
+		// A server without the cursor sends the first page again
+		serverHasMore = resp.HasMore && added > 0 && len(resp.Messages) > 0
+		if serverHasMore {
+			before = resp.Messages[len(resp.Messages)-1].MessageID
+		}
+	}
+	sortInboxMessages(matched)
+
+	page := &InboxPage{Messages: []client.Message{}, Search: opts.Search}
+	if opts.AllPages {
+		page.Messages = append(page.Messages, matched...)
+	} else {
+		page.Page = opts.Page
+		start := end - opts.Limit
+		if start < len(matched) {
+			page.Messages = append(page.Messages, matched[start:min(end, len(matched))]...)
+		}
+		page.HasMore = len(matched) > end || serverHasMore
+	}
+	page.Count = len(page.Messages)
+	return page, nil
+}
+
+// listMailPaged runs mail list through the BeadHub client, which reports
+// whether more messages follow and takes the paging cursor.
+func listMailPaged(ctx context.Context, identity *beadhubAuthSelection) error {
+	opts, err := mailListPageOptions(ctx, identity.AgentID)
+	if err != nil {
+		return err
+	}
+	page, err := fetchInboxPage(ctx, newBeadHubClient(ctx, identity.BaseURL), opts)
+	if err != nil {
+		return err
+	}
+	if awebMailJSON {
+		for i := range page.Messages {
+			page.Messages[i].Body = readMessageBody(page.Messages[i].Body)
+		}
+		fmt.Print(marshalJSONOrFallback(page))
+		fmt.Print("\n")
+		return nil
+	}
+	fmt.Print(formatInboxPage(page, opts.UnreadOnly))
+	return nil
+}
+
+// inboxMessageMatches reports whether msg contains search (case-insensitive)
+// in its sender, subject or body.
+func inboxMessageMatches(msg client.Message, search string) bool {
+	if search == "" {
+		return true
+	}
+	haystack := strings.ToLower(msg.FromAlias + "\n" + msg.Subject + "\n" + msg.Body)
+	return strings.Contains(haystack, strings.ToLower(search))
+}
+
+// sortInboxMessages orders messages newest first, ties broken by message ID.
+func sortInboxMessages(messages []client.Message) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		ti, iok := parseTimeBestEffort(messages[i].CreatedAt)
+		tj, jok := parseTimeBestEffort(messages[j].CreatedAt)
+		if iok && jok && !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		if iok != jok {
+			return iok
+		}
+		return messages[i].MessageID > messages[j].MessageID
+	})
+}
+
+func formatInboxPage(page *InboxPage, unreadOnly bool) string {
+	if len(page.Messages) == 0 {
+		switch {
+		case page.Page > 1:
+			return fmt.Sprintf("No messages on page %d.\n", page.Page)
+		case page.Search != "":
+			return fmt.Sprintf("No messages matching %q.\n", page.Search)
+		case unreadOnly:
+			return "No unread messages.\n"
+		}
+		return "No messages.\n"
+	}
+	var sb strings.Builder
+	header := fmt.Sprintf("MAILS: %d", len(page.Messages))
+	if page.Page > 1 || page.HasMore {
+		header += fmt.Sprintf(" (page %d)", page.Page)
+	}
+	if page.Search != "" {
+		header += fmt.Sprintf(" matching %q", page.Search)
+	}
+	sb.WriteString(header + "\n\n")
+	for _, msg := range page.Messages {
+		sb.WriteString(formatMailListLine(msg.MessageID, msg.FromAlias, msg.Subject, renderMessageBody(msg.Body), msg.CreatedAt, msg.Archived))
+	}
+	if page.HasMore {
+		sb.WriteString(fmt.Sprintf("\n(more: --page %d, or --all-pages)\n", page.Page+1))
+	}
+	return sb.String()
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+)
+
+// inboxFake returns a fake whose ws-me inbox holds bodies, oldest first,
+// one minute apart.
+func inboxFake(t *testing.T, bodies ...string) *clienttest.Fake {
+	t.Helper()
+	fake := clienttest.New()
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	fake.Now = func() time.Time { return at }
+	for _, body := range bodies {
+		if _, err := fake.Send(context.Background(), &client.SendRequest{FromWorkspace: "ws-bob", FromAlias: "bob", ToWorkspace: "ws-me", Body: body}); err != nil {
+			t.Fatal(err)
+		}
+		at = at.Add(time.Minute)
+	}
+	return fake
+}
+
+func inboxBodies(page *InboxPage) string {
+	var bodies []string
+	for _, m := range page.Messages {
+		bodies = append(bodies, m.Body)
+	}
+	return strings.Join(bodies, ",")
+}
+
+func TestFetchInboxPage_Pages(t *testing.T) {
+	fake := inboxFake(t, "m1", "m2", "m3", "m4", "m5", "m6", "m7")
+	opts := inboxPageOptions{WorkspaceID: "ws-me", Limit: 3}
+
+	for _, tc := range []struct {
+		page    int
+		want    string
+		hasMore bool
+	}{
+		{1, "m7,m6,m5", true},
+		{2, "m4,m3,m2", true},
+		{3, "m1", false},
+		{4, "", false},
+	} {
+		opts.Page = tc.page
+		page, err := fetchInboxPage(context.Background(), fake, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := inboxBodies(page); got != tc.want || page.HasMore != tc.hasMore || page.Count != len(page.Messages) {
+			t.Errorf("page %d = %q (more=%v), want %q (more=%v)", tc.page, got, page.HasMore, tc.want, tc.hasMore)
+		}
+	}
+
+	opts.Page, opts.AllPages = 0, true
+	page, err := fetchInboxPage(context.Background(), fake, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := inboxBodies(page); got != "m7,m6,m5,m4,m3,m2,m1" || page.HasMore {
+		t.Errorf("all pages = %q (more=%v)", got, page.HasMore)
+	}
+}
+
+func TestFetchInboxPage_SearchAcrossPages(t *testing.T) {
+	fake := inboxFake(t, "rebase onto main", "lunch?", "tests green", "REBASE done", "ping", "ping")
+	opts := inboxPageOptions{WorkspaceID: "ws-me", Limit: 2, Search: "rebase"}
+
+	page, err := fetchInboxPage(context.Background(), fake, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := inboxBodies(page); got != "REBASE done,rebase onto main" {
+		t.Errorf("search = %q", got)
+	}
+	for _, call := range fake.Calls("Inbox") {
+		if req := call.Args[0].(*client.InboxRequest); req.Query != "" {
+			t.Errorf("search should stay client-side without inbox_search, sent q=%q", req.Query)
+		}
+	}
+
+	opts.ServerSearch = true
+	if _, err := fetchInboxPage(context.Background(), fake, opts); err != nil {
+		t.Fatal(err)
+	}
+	calls := fake.Calls("Inbox")
+	if req := calls[len(calls)-1].Args[0].(*client.InboxRequest); req.Query != "rebase" {
+		t.Errorf("expected the server to get q=rebase, got %q", req.Query)
+	}
+}
+
+// cursorlessInbox is a server that ignores the before cursor.
+type cursorlessInbox struct {
+	*clienttest.Fake
+}
+
+func (c cursorlessInbox) Inbox(ctx context.Context, req *client.InboxRequest) (*client.InboxResponse, error) {
+	copied := *req
+	copied.Before = ""
+	return c.Fake.Inbox(ctx, &copied)
+}
+
+func TestFetchInboxPage_ServerWithoutCursor(t *testing.T) {
+	fake := inboxFake(t, "m1", "m2", "m3", "m4")
+	page, err := fetchInboxPage(context.Background(), cursorlessInbox{fake}, inboxPageOptions{WorkspaceID: "ws-me", Limit: 2, AllPages: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := inboxBodies(page); got != "m4,m3" {
+		t.Errorf("got %q", got)
+	}
+	if n := len(fake.Calls("Inbox")); n != 2 {
+		t.Errorf("expected to stop after the repeated page, got %d requests", n)
+	}
+}
+
+func TestSortInboxMessages_Stable(t *testing.T) {
+	messages := []client.Message{
+		{MessageID: "msg-a", CreatedAt: "2026-03-01T09:00:00Z"},
+		{MessageID: "msg-c", CreatedAt: "2026-03-01T10:00:00Z"},
+		{MessageID: "msg-z"},
+		{MessageID: "msg-b", CreatedAt: "2026-03-01T10:00:00Z"},
+	}
+	sortInboxMessages(messages)
+	var ids []string
+	for _, m := range messages {
+		ids = append(ids, m.MessageID)
+	}
+	if got := strings.Join(ids, ","); got != "msg-c,msg-b,msg-a,msg-z" {
+		t.Errorf("order = %s", got)
+	}
+}
+
+func TestFormatInboxPage(t *testing.T) {
+	page := &InboxPage{Page: 2, HasMore: true, Search: "rebase", Messages: []client.Message{
+		{MessageID: "msg-4", FromAlias: "bob", Body: "rebase done"},
+	}}
+	out := formatInboxPage(page, true)
+	for _, want := range []string{`MAILS: 1 (page 2) matching "rebase"`, "- bob: rebase done (id: msg-4)", "(more: --page 3, or --all-pages)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	for _, tc := range []struct {
+		page *InboxPage
+		want string
+	}{
+		{&InboxPage{Page: 3}, "No messages on page 3."},
+		{&InboxPage{Page: 1, Search: "x"}, `No messages matching "x".`},
+		{&InboxPage{Page: 1}, "No unread messages."},
+	} {
+		if got := formatInboxPage(tc.page, true); !strings.Contains(got, tc.want) {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+	}
+	if got := formatInboxPage(&InboxPage{}, false); got != "No messages.\n" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,337 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// The server records when each message is delivered and read. 'mail sent'
+// shows those receipts to the sender. Urgent mail sent with --nag-after is
+// also recorded locally in .beadhub-cache/mail-receipts.json; while it stays
+// unread, PrintNotifications re-sends a short reminder to the recipient once
+// per interval and tells the sender, and reports when it is finally read.
+
+const (
+	// maxTrackedReceiptPolls bounds the receipt requests made per command.
+	maxTrackedReceiptPolls = 5
+	// maxMailNags is how many reminders are sent before giving up.
+	maxMailNags = 3
+)
+
+// TrackedReceipt is a sent message waiting to be read.
+type TrackedReceipt struct {
+	MessageID    string `json:"message_id"`
+	ToAlias      string `json:"to_alias"`
+	Subject      string `json:"subject,omitempty"`
+	Priority     string `json:"priority"`
+	SentAt       string `json:"sent_at"`
+	NagAfter     string `json:"nag_after"` // Go duration
+	Nags         int    `json:"nags"`
+	LastNaggedAt string `json:"last_nagged_at,omitempty"`
+}
+
+// MailReceiptNotice reports a tracked message that was read, or a reminder
+// sent for one that was not.
+type MailReceiptNotice struct {
+	ToAlias string
+	Subject string
+	ReadAt  string // set when the message was read
+	Nags    int    // reminders sent so far, when not read
+	GaveUp  bool   // the last reminder was sent; tracking stopped
+	Error   string // the reminder could not be sent
+}
+
+var (
+	awebMailNagAfter string
+
+	awebMailSentTo    string
+	awebMailSentLimit int
+)
+
+var awebMailSentCmd = &cobra.Command{
+	Use:   "sent",
+	Short: "List sent mail with delivery and read receipts",
+	Long: `List mail you sent, newest first, with whether each message was
+delivered and when it was read.
+
+Send high or urgent mail with --nag-after to have the recipient reminded
+while it stays unread:
+  bdh :aweb mail send alice "prod is down" --priority urgent --nag-after 2h
+
+Examples:
+  bdh :aweb mail sent
+  bdh :aweb mail sent --to alice
+  bdh :aweb mail sent --json`,
+	Args: cobra.NoArgs,
+	RunE: runMailSent,
+}
+
+func init() {
+	awebMailSendCmd.Flags().StringVar(&awebMailNagAfter, "nag-after", "", "Remind the recipient when high/urgent mail is unread after this long (4h)")
+	awebMailSentCmd.Flags().StringVar(&awebMailSentTo, "to", "", "Only show mail sent to this alias")
+	awebMailSentCmd.Flags().IntVar(&awebMailSentLimit, "limit", 20, "Maximum number of messages")
+	awebMailCmd.AddCommand(awebMailSentCmd)
+}
+
+func runMailSent(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	identity, err := currentAgentIdentityForAweb()
+	if err != nil {
+		return err
+	}
+	c := beadHubClientFor(ctx, identity.BaseURL, identity.APIKey)
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	resp, err := c.SentMessages(ctx, &client.SentMessagesRequest{
+		WorkspaceID: identity.AgentID,
+		ToAlias:     strings.TrimSpace(awebMailSentTo),
+		Limit:       awebMailSentLimit,
+	})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) && clientErr.StatusCode == 404 {
+			return fmt.Errorf("this BeadHub server does not keep read receipts (no /v1/messages/sent)")
+		}
+		return err
+	}
+	fmt.Print(formatSentMail(resp, awebMailJSON, time.Now()))
+	return nil
+}
+
+// mailReceiptStatus describes how far a sent message got.
+func mailReceiptStatus(m client.SentMessage, now time.Time) string {
+	switch {
+	case m.ReadAt != "":
+		return "read " + formatTimeAgoAt(m.ReadAt, now)
+	case m.DeliveredAt != "":
+		return "delivered, unread"
+	default:
+		return "not delivered yet"
+	}
+}
+
+func formatSentMail(resp *client.SentMessagesResponse, asJSON bool, now time.Time) string {
+	if asJSON {
+		if resp.Messages == nil {
+			resp.Messages = []client.SentMessage{}
+		}
+		return marshalJSONOrFallback(resp) + "\n"
+	}
+	if len(resp.Messages) == 0 {
+		return "No sent messages.\n"
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("SENT: %d\n\n", len(resp.Messages)))
+	for _, m := range resp.Messages {
+		subj := strings.TrimSpace(m.Subject)
+		if subj != "" {
+			subj = " — " + subj
+		}
+		prio := ""
+		if m.Priority == "high" || m.Priority == "urgent" {
+			prio = " [" + m.Priority + "]"
+		}
+		sb.WriteString(fmt.Sprintf("- to %s%s%s: %s (id: %s, sent %s)\n",
+			m.ToAlias, subj, prio, mailReceiptStatus(m, now), m.MessageID, formatTimeAgoAt(m.CreatedAt, now)))
+	}
+	if resp.HasMore {
+		sb.WriteString("\n(more messages not shown; use --limit)\n")
+	}
+	return sb.String()
+}
+
+// parseNagAfter validates --nag-after for a message of the given priority.
+// Returns 0 when no nagging was requested.
+func parseNagAfter(value, priority string, scheduled bool) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --nag-after %q: use a positive duration like 4h", value)
+	}
+	if p := strings.TrimSpace(priority); p != "high" && p != "urgent" {
+		return 0, fmt.Errorf("--nag-after only applies to --priority high or urgent")
+	}
+	if scheduled {
+		return 0, fmt.Errorf("--nag-after cannot be combined with --send-at or --delay")
+	}
+	return d, nil
+}
+
+func mailReceiptsPath() (string, error) {
+	return cacheFilePath("mail-receipts.json")
+}
+
+func loadTrackedReceipts(path string) ([]TrackedReceipt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var tracked []TrackedReceipt
+	if err := json.Unmarshal(data, &tracked); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return tracked, nil
+}
+
+func saveTrackedReceipts(path string, tracked []TrackedReceipt) error {
+	if len(tracked) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONAtomic(path, tracked)
+}
+
+// trackMailReceipt records a message sent with --nag-after. Best-effort:
+// tracking failures never fail the send itself.
+func trackMailReceipt(messageID, toAlias, subject, priority string, nagAfter time.Duration, now time.Time) error {
+	path, err := mailReceiptsPath()
+	if err != nil {
+		return err
+	}
+	tracked, err := loadTrackedReceipts(path)
+	if err != nil {
+		return err
+	}
+	tracked = append(tracked, TrackedReceipt{
+		MessageID: messageID,
+		ToAlias:   toAlias,
+		Subject:   subject,
+		Priority:  priority,
+		SentAt:    now.UTC().Format(time.RFC3339),
+		NagAfter:  nagAfter.String(),
+	})
+	return saveTrackedReceipts(path, tracked)
+}
+
+// checkMailReceipts polls tracked messages: read ones are reported once and
+// dropped, and unread ones past their interval get a reminder. Messages the
+// server no longer knows are dropped silently.
+func checkMailReceipts(ctx context.Context, c BeadHubAPI, aw AwebAPI, now time.Time) []MailReceiptNotice {
+	path, err := mailReceiptsPath()
+	if err != nil {
+		return nil
+	}
+	tracked, err := loadTrackedReceipts(path)
+	if err != nil || len(tracked) == 0 {
+		return nil
+	}
+
+	var notices []MailReceiptNotice
+	var kept []TrackedReceipt
+	polls := 0
+	for _, t := range tracked {
+		if polls >= maxTrackedReceiptPolls || ctx.Err() != nil {
+			kept = append(kept, t)
+			continue
+		}
+		polls++
+
+		receipt, err := c.MessageReceipt(ctx, t.MessageID)
+		if err != nil {
+			unreachable(ctx, err)
+			var clientErr *client.Error
+			if errors.As(err, &clientErr) && clientErr.StatusCode == 404 {
+				continue
+			}
+			kept = append(kept, t)
+			continue
+		}
+		if receipt.ReadAt != "" {
+			notices = append(notices, MailReceiptNotice{ToAlias: t.ToAlias, Subject: t.Subject, ReadAt: receipt.ReadAt})
+			continue
+		}
+		if !mailNagDue(t, now) {
+			kept = append(kept, t)
+			continue
+		}
+
+		_, err = aw.SendMessage(ctx, &aweb.SendMessageRequest{
+			ToAlias:  t.ToAlias,
+			Subject:  mailNagSubject(t.Subject),
+			Body:     mailNagBody(t, now),
+			Priority: aweb.MessagePriority(t.Priority),
+		})
+		if err != nil {
+			unreachable(ctx, err)
+			notices = append(notices, MailReceiptNotice{ToAlias: t.ToAlias, Subject: t.Subject, Nags: t.Nags, Error: err.Error()})
+			kept = append(kept, t)
+			continue
+		}
+		t.Nags++
+		t.LastNaggedAt = now.UTC().Format(time.RFC3339)
+		notice := MailReceiptNotice{ToAlias: t.ToAlias, Subject: t.Subject, Nags: t.Nags}
+		if t.Nags >= maxMailNags {
+			notice.GaveUp = true
+		} else {
+			kept = append(kept, t)
+		}
+		notices = append(notices, notice)
+	}
+
+	_ = saveTrackedReceipts(path, kept)
+	return notices
+}
+
+// mailNagDue reports whether an unread message is due a reminder: NagAfter
+// since it was sent, then NagAfter since the last reminder.
+func mailNagDue(t TrackedReceipt, now time.Time) bool {
+	interval, err := time.ParseDuration(t.NagAfter)
+	if err != nil || interval <= 0 {
+		return false
+	}
+	since := t.SentAt
+	if t.LastNaggedAt != "" {
+		since = t.LastNaggedAt
+	}
+	at, ok := parseTimeBestEffort(since)
+	return ok && now.Sub(at) >= interval
+}
+
+func mailNagSubject(subject string) string {
+	if subject == "" {
+		return "Reminder: unread message"
+	}
+	return "Reminder: " + subject
+}
+
+func mailNagBody(t TrackedReceipt, now time.Time) string {
+	return fmt.Sprintf("My %s message (id: %s), sent %s, is still unread. Please read it: `bdh :aweb mail list`",
+		t.Priority, t.MessageID, formatTimeAgoAt(t.SentAt, now))
+}
+
+func formatMailReceiptNotice(n MailReceiptNotice) string {
+	what := "Your mail to " + n.ToAlias
+	if n.Subject != "" {
+		what += fmt.Sprintf(" (\"%s\")", n.Subject)
+	}
+	switch {
+	case n.ReadAt != "":
+		return fmt.Sprintf("- **MAIL**: %s was read %s", what, formatTimestamp(n.ReadAt))
+	case n.Error != "":
+		return fmt.Sprintf("- **MAIL**: %s is still unread; the reminder could not be sent: %s", what, truncateText(n.Error, 200))
+	case n.GaveUp:
+		return fmt.Sprintf("- **MAIL**: %s is still unread after %d reminders; no more reminders will be sent\n  → Try chat: `bdh :aweb chat send %s \"...\"`", what, n.Nags, n.ToAlias)
+	default:
+		return fmt.Sprintf("- **MAIL**: %s is still unread; reminder %d of %d sent", what, n.Nags, maxMailNags)
+	}
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestParseNagAfter(t *testing.T) {
+	if d, err := parseNagAfter("", "normal", false); err != nil || d != 0 {
+		t.Errorf("no flag = %v, %v", d, err)
+	}
+	if d, err := parseNagAfter("4h", "urgent", false); err != nil || d != 4*time.Hour {
+		t.Errorf("4h urgent = %v, %v", d, err)
+	}
+	for _, tc := range []struct{ value, priority string }{{"4h", "normal"}, {"soon", "high"}, {"-1h", "high"}} {
+		if _, err := parseNagAfter(tc.value, tc.priority, false); err == nil {
+			t.Errorf("parseNagAfter(%q, %q) should fail", tc.value, tc.priority)
+		}
+	}
+	if _, err := parseNagAfter("4h", "high", true); err == nil {
+		t.Error("--nag-after with scheduled mail should fail")
+	}
+}
+
+func TestFormatSentMail(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	out := formatSentMail(&client.SentMessagesResponse{Messages: []client.SentMessage{
+		{MessageID: "m1", ToAlias: "alice", Subject: "deploy", Priority: "urgent", CreatedAt: "2026-03-02T10:00:00Z",
+			DeliveredAt: "2026-03-02T10:00:01Z", ReadAt: "2026-03-02T11:30:00Z"},
+		{MessageID: "m2", ToAlias: "bob", Priority: "normal", CreatedAt: "2026-03-02T11:00:00Z", DeliveredAt: "2026-03-02T11:00:01Z"},
+		{MessageID: "m3", ToAlias: "carol", CreatedAt: "2026-03-02T11:59:00Z"},
+	}}, false, now)
+	for _, want := range []string{
+		"to alice — deploy [urgent]: read 30m ago",
+		"to bob: delivered, unread (id: m2",
+		"to carol: not delivered yet",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCheckMailReceipts(t *testing.T) {
+	t.Chdir(t.TempDir())
+	now := time.Now()
+	sent := now.Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+	path, _ := mailReceiptsPath()
+	if err := saveTrackedReceipts(path, []TrackedReceipt{
+		{MessageID: "read", ToAlias: "alice", Priority: "urgent", SentAt: sent, NagAfter: "1h"},
+		{MessageID: "unread", ToAlias: "bob", Subject: "prod", Priority: "high", SentAt: sent, NagAfter: "1h"},
+		{MessageID: "recent", ToAlias: "carol", Priority: "high", SentAt: sent, NagAfter: "1h",
+			Nags: 1, LastNaggedAt: now.Add(-10 * time.Minute).UTC().Format(time.RFC3339)},
+		{MessageID: "last", ToAlias: "dave", Priority: "high", SentAt: sent, NagAfter: "1h", Nags: maxMailNags - 1},
+		{MessageID: "gone", ToAlias: "erin", Priority: "high", SentAt: sent, NagAfter: "1h"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var reminded []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var req aweb.SendMessageRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			reminded = append(reminded, req.ToAlias+": "+req.Subject)
+			json.NewEncoder(w).Encode(aweb.SendMessageResponse{MessageID: "nag"})
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/messages/"), "/receipt")
+		switch id {
+		case "gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "read":
+			json.NewEncoder(w).Encode(client.SentMessage{MessageID: id, ReadAt: now.UTC().Format(time.RFC3339)})
+		default:
+			json.NewEncoder(w).Encode(client.SentMessage{MessageID: id, DeliveredAt: sent})
+		}
+	}))
+	defer server.Close()
+	aw, err := aweb.NewWithAPIKey(server.URL, "aw_sk_test123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notices := checkMailReceipts(context.Background(), client.New(server.URL), aw, now)
+
+	if got := strings.Join(reminded, ","); got != "bob: Reminder: prod,dave: Reminder: unread message" {
+		t.Errorf("reminders sent = %s", got)
+	}
+	if len(notices) != 3 || notices[0].ReadAt == "" || notices[1].Nags != 1 || !notices[2].GaveUp {
+		t.Fatalf("notices = %+v", notices)
+	}
+	tracked, _ := loadTrackedReceipts(path)
+	var ids []string
+	for _, tr := range tracked {
+		ids = append(ids, tr.MessageID)
+	}
+	if strings.Join(ids, ",") != "unread,recent" {
+		t.Errorf("still tracked = %v", ids)
+	}
+	if !strings.Contains(formatMailReceiptNotice(notices[2]), "no more reminders") {
+		t.Errorf("give-up notice = %q", formatMailReceiptNotice(notices[2]))
+	}
+}
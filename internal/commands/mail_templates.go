@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Message templates (the templates map in .beadhub) are bodies for the
+// coordination messages agents send over and over, such as handoffs:
+//
+//	templates:
+//	  handoff: "Taking a break; status: {{status}}, next: {{next}}"
+//
+// `mail send bob --template handoff --var status=green --var next=bd-43`
+// fills in every placeholder; a missing or unknown variable is an error, so
+// a half-filled template is never sent.
+
+var (
+	awebMailTemplate string
+	awebMailVars     []string
+)
+
+// MessageTemplate is one configured template.
+type MessageTemplate struct {
+	Name      string   `json:"name"`
+	Variables []string `json:"variables"`
+	Body      string   `json:"body"`
+}
+
+var awebMailTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List message templates",
+	Long: `List the message templates in .beadhub (templates) and their variables.
+
+Examples:
+  bdh :aweb mail templates
+  bdh :aweb mail send bob --template handoff --var status="tests green" --var next=bd-43`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadWorkspaceConfig()
+		if err != nil {
+			return err
+		}
+		fmt.Print(formatMessageTemplates(sortedMessageTemplates(cfg.Templates), awebMailJSON))
+		return nil
+	},
+}
+
+func init() {
+	awebMailCmd.AddCommand(awebMailTemplatesCmd)
+
+	awebMailSendCmd.Flags().StringVar(&awebMailTemplate, "template", "", "Use the named template from .beadhub as the message")
+	awebMailSendCmd.Flags().StringArrayVar(&awebMailVars, "var", nil, "Template variable as name=value (repeatable)")
+}
+
+func sortedMessageTemplates(templates map[string]string) []MessageTemplate {
+	out := make([]MessageTemplate, 0, len(templates))
+	for _, name := range sortedKeys(templates) {
+		vars, _ := config.TemplateVariables(templates[name])
+		if vars == nil {
+			vars = []string{}
+		}
+		out = append(out, MessageTemplate{Name: name, Variables: vars, Body: templates[name]})
+	}
+	return out
+}
+
+func formatMessageTemplates(templates []MessageTemplate, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(templates)
+	}
+	if len(templates) == 0 {
+		return "No message templates. Add some under templates: in .beadhub.\n"
+	}
+	var sb strings.Builder
+	for _, tmpl := range templates {
+		vars := "no variables"
+		if len(tmpl.Variables) > 0 {
+			vars = strings.Join(tmpl.Variables, ", ")
+		}
+		sb.WriteString(fmt.Sprintf("%s (%s)\n  %s\n", tmpl.Name, vars, tmpl.Body))
+	}
+	return sb.String()
+}
+
+// mailSendBody returns the body for mail send: the message argument, or the
+// rendered --template.
+func mailSendBody(args []string) (string, error) {
+	if awebMailTemplate == "" {
+		if len(awebMailVars) > 0 {
+			return "", fmt.Errorf("--var needs --template")
+		}
+		if len(args) < 2 {
+			return "", fmt.Errorf("message is required (or use --template)")
+		}
+		return args[1], nil
+	}
+	if len(args) > 1 {
+		return "", fmt.Errorf("give either a message or --template, not both")
+	}
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return "", err
+	}
+	return renderMessageTemplate(cfg.Templates, awebMailTemplate, awebMailVars)
+}
+
+// renderMessageTemplate fills template name with vars ("name=value"). Every
+// variable the template uses must be given, and only those.
+func renderMessageTemplate(templates map[string]string, name string, vars []string) (string, error) {
+	body, ok := templates[name]
+	if !ok {
+		if len(templates) == 0 {
+			return "", fmt.Errorf("unknown template %q: no templates in .beadhub", name)
+		}
+		return "", fmt.Errorf("unknown template %q (have: %s)", name, strings.Join(sortedKeys(templates), ", "))
+	}
+	used, ok := config.TemplateVariables(body)
+	if !ok {
+		return "", fmt.Errorf("template %s has a malformed placeholder", name)
+	}
+	wanted := make(map[string]bool, len(used))
+	for _, v := range used {
+		wanted[v] = true
+	}
+
+	values := make(map[string]string, len(vars))
+	for _, kv := range vars {
+		key, value, found := strings.Cut(kv, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return "", fmt.Errorf("invalid --var %q: expected name=value", kv)
+		}
+		if !wanted[key] {
+			return "", fmt.Errorf("template %s has no variable %q (variables: %s)", name, key, strings.Join(used, ", "))
+		}
+		values[key] = value
+	}
+	var missing []string
+	for _, v := range used {
+		if _, ok := values[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf("template %s needs --var for: %s", name, strings.Join(missing, ", "))
+	}
+	return config.RenderTemplate(body, values), nil
+}
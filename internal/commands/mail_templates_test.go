@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMessageTemplate(t *testing.T) {
+	templates := map[string]string{
+		"handoff": "Taking a break; status: {{status}}, next: {{ next }}",
+		"ping":    "Still there?",
+	}
+
+	got, err := renderMessageTemplate(templates, "handoff", []string{"status=tests green", "next=bd-43=maybe"})
+	if err != nil || got != "Taking a break; status: tests green, next: bd-43=maybe" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if got, err := renderMessageTemplate(templates, "ping", nil); err != nil || got != "Still there?" {
+		t.Errorf("got %q, %v", got, err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		vars []string
+		want string
+	}{
+		{"nope", nil, `unknown template "nope" (have: handoff, ping)`},
+		{"handoff", []string{"status=green"}, "template handoff needs --var for: next"},
+		{"handoff", []string{"status=green", "next=x", "eta=5m"}, `template handoff has no variable "eta" (variables: status, next)`},
+		{"handoff", []string{"status"}, `invalid --var "status": expected name=value`},
+	} {
+		if _, err := renderMessageTemplate(templates, tc.name, tc.vars); err == nil || err.Error() != tc.want {
+			t.Errorf("renderMessageTemplate(%s, %v) error = %v, want %q", tc.name, tc.vars, err, tc.want)
+		}
+	}
+}
+
+func TestMailSendBody_FlagCombinations(t *testing.T) {
+	t.Cleanup(func() { awebMailTemplate, awebMailVars = "", nil })
+
+	if body, err := mailSendBody([]string{"bob", "hello"}); err != nil || body != "hello" {
+		t.Errorf("got %q, %v", body, err)
+	}
+	if _, err := mailSendBody([]string{"bob"}); err == nil || !strings.Contains(err.Error(), "message is required") {
+		t.Errorf("expected a missing message error, got %v", err)
+	}
+	awebMailVars = []string{"status=green"}
+	if _, err := mailSendBody([]string{"bob", "hello"}); err == nil || !strings.Contains(err.Error(), "--var needs --template") {
+		t.Errorf("expected --var to need --template, got %v", err)
+	}
+	awebMailTemplate = "handoff"
+	if _, err := mailSendBody([]string{"bob", "hello"}); err == nil || !strings.Contains(err.Error(), "not both") {
+		t.Errorf("expected message and --template to conflict, got %v", err)
+	}
+}
+
+func TestFormatMessageTemplates(t *testing.T) {
+	templates := sortedMessageTemplates(map[string]string{
+		"ping":    "Still there?",
+		"handoff": "status: {{status}}, next: {{next}}",
+	})
+	out := formatMessageTemplates(templates, false)
+	want := "handoff (status, next)\n  status: {{status}}, next: {{next}}\nping (no variables)\n  Still there?\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+	if !strings.Contains(formatMessageTemplates(nil, false), "No message templates") {
+		t.Error("expected a hint when no templates are configured")
+	}
+	if js := formatMessageTemplates(templates, true); !strings.Contains(js, `"variables": [`) {
+		t.Errorf("unexpected JSON: %s", js)
+	}
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/i18n"
+)
+
+// Agents working on beads under the same epic usually end up merging into
+// the same branch. When the files they reserve overlap, ready warns them of
+// the merge conflict ahead. Auto-reserve tags each reservation with the bead
+// it was taken for (metadata "bead_id") when the agent holds exactly one;
+// untagged reservations count for every epic their holder works under.
+
+const reservationBeadIDKey = "bead_id"
+
+// MergeConflictRisk is a set of overlapping reservations held by agents
+// working under the same epic.
+type MergeConflictRisk struct {
+	ApexID    string   `json:"apex_id"`
+	ApexTitle string   `json:"apex_title,omitempty"`
+	Agents    []string `json:"agents"`
+	Paths     []string `json:"paths"`
+}
+
+// autoReserveBeadID returns the bead auto-reserved files are for: the one
+// bead this workspace holds, counting the bead being claimed by bdArgs.
+// Returns "" when there are none or several.
+func autoReserveBeadID(workspaceID string, bdArgs []string, inProgress []client.BeadInProgress) string {
+	beads := make(map[string]bool)
+	for _, bip := range inProgress {
+		if bip.WorkspaceID == workspaceID && bip.BeadID != "" {
+			beads[bip.BeadID] = true
+		}
+	}
+	if isClaimCommand(bdArgs) {
+		if id := extractBeadIDFromArgs(bdArgs); id != "" {
+			beads[id] = true
+		}
+	}
+	if len(beads) != 1 {
+		return ""
+	}
+	for id := range beads {
+		return id
+	}
+	return ""
+}
+
+// predictMergeConflicts finds the epics under which two or more agents hold
+// overlapping reservations.
+func predictMergeConflicts(workspaces []client.Workspace, locks []aweb.ReservationView) []MergeConflictRisk {
+	beadApex := make(map[string]string)
+	apexTitles := make(map[string]string)
+	aliasApexes := make(map[string]map[string]bool)
+	for _, ws := range workspaces {
+		for _, claim := range ws.Claims {
+			if claim.ApexID == "" {
+				continue
+			}
+			beadApex[claim.BeadID] = claim.ApexID
+			if claim.ApexTitle != "" {
+				apexTitles[claim.ApexID] = claim.ApexTitle
+			}
+			if aliasApexes[ws.Alias] == nil {
+				aliasApexes[ws.Alias] = make(map[string]bool)
+			}
+			aliasApexes[ws.Alias][claim.ApexID] = true
+		}
+	}
+
+	// Reservations per epic, by holder
+	type held struct{ alias, key string }
+	byApex := make(map[string][]held)
+	for _, lock := range locks {
+		if lock.HolderAlias == "" || lock.ResourceKey == "" {
+			continue
+		}
+		if beadID, ok := lock.Metadata[reservationBeadIDKey].(string); ok && beadApex[beadID] != "" {
+			byApex[beadApex[beadID]] = append(byApex[beadApex[beadID]], held{lock.HolderAlias, lock.ResourceKey})
+			continue
+		}
+		for apex := range aliasApexes[lock.HolderAlias] {
+			byApex[apex] = append(byApex[apex], held{lock.HolderAlias, lock.ResourceKey})
+		}
+	}
+
+	var risks []MergeConflictRisk
+	for apex, locks := range byApex {
+		agents := make(map[string]bool)
+		paths := make(map[string]bool)
+		for i := range locks {
+			for j := i + 1; j < len(locks); j++ {
+				a, b := locks[i], locks[j]
+				if a.alias == b.alias || !reservationsOverlap(a.key, b.key) {
+					continue
+				}
+				agents[a.alias], agents[b.alias] = true, true
+				paths[a.key], paths[b.key] = true, true
+			}
+		}
+		if len(agents) == 0 {
+			continue
+		}
+		risks = append(risks, MergeConflictRisk{
+			ApexID:    apex,
+			ApexTitle: apexTitles[apex],
+			Agents:    sortedKeys(agents),
+			Paths:     sortedKeys(paths),
+		})
+	}
+	sort.Slice(risks, func(i, j int) bool { return risks[i].ApexID < risks[j].ApexID })
+	return risks
+}
+
+// formatMergeConflictRisks renders one line per epic at risk.
+func formatMergeConflictRisks(risks []MergeConflictRisk) string {
+	if len(risks) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinationHeader())
+	sb.WriteString("\n## " + i18n.T("ready.merge_risk.title") + "\n")
+	for _, r := range risks {
+		apex := r.ApexID
+		if r.ApexTitle != "" {
+			apex += " (" + r.ApexTitle + ")"
+		}
+		sb.WriteString(i18n.T("ready.merge_risk.entry", apex, strings.Join(r.Agents, ", "), strings.Join(r.Paths, ", ")) + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestAutoReserveBeadID(t *testing.T) {
+	mine := []client.BeadInProgress{{BeadID: "bd-1", WorkspaceID: "ws-me"}, {BeadID: "bd-9", WorkspaceID: "ws-other"}}
+	if got := autoReserveBeadID("ws-me", []string{"list"}, mine); got != "bd-1" {
+		t.Errorf("one claim = %q, want bd-1", got)
+	}
+	if got := autoReserveBeadID("ws-me", []string{"update", "bd-1", "--status", "in_progress"}, mine); got != "bd-1" {
+		t.Errorf("re-claiming the same bead = %q, want bd-1", got)
+	}
+	if got := autoReserveBeadID("ws-me", []string{"update", "bd-2", "--status", "in_progress"}, mine); got != "" {
+		t.Errorf("two beads = %q, want none", got)
+	}
+	if got := autoReserveBeadID("ws-me", []string{"update", "bd-2", "--status", "in_progress"}, nil); got != "bd-2" {
+		t.Errorf("claiming the first bead = %q, want bd-2", got)
+	}
+}
+
+func TestPredictMergeConflicts(t *testing.T) {
+	workspaces := []client.Workspace{
+		{Alias: "alice", Claims: []client.Claim{{BeadID: "bd-2", ApexID: "bd-1", ApexTitle: "Accounts"}}},
+		{Alias: "bob", Claims: []client.Claim{
+			{BeadID: "bd-3", ApexID: "bd-1", ApexTitle: "Accounts"},
+			{BeadID: "bd-11", ApexID: "bd-10"},
+		}},
+		{Alias: "carol", Claims: []client.Claim{{BeadID: "bd-12", ApexID: "bd-10"}}},
+	}
+	locks := []aweb.ReservationView{
+		{HolderAlias: "alice", ResourceKey: "api/accounts/**", Metadata: map[string]any{"bead_id": "bd-2"}},
+		{HolderAlias: "bob", ResourceKey: "api/accounts/signup.go", Metadata: map[string]any{"bead_id": "bd-3"}},
+		// Tagged for the search epic: must not count against carol's epic
+		{HolderAlias: "bob", ResourceKey: "search/index.go", Metadata: map[string]any{"bead_id": "bd-3"}},
+		{HolderAlias: "carol", ResourceKey: "search/index.go"},
+		{HolderAlias: "alice", ResourceKey: "README.md", Metadata: map[string]any{"bead_id": "bd-2"}},
+	}
+
+	risks := predictMergeConflicts(workspaces, locks)
+	if len(risks) != 1 {
+		t.Fatalf("risks = %+v, want only the accounts epic", risks)
+	}
+	r := risks[0]
+	if r.ApexID != "bd-1" || strings.Join(r.Agents, ",") != "alice,bob" || strings.Join(r.Paths, ",") != "api/accounts/**,api/accounts/signup.go" {
+		t.Errorf("risk = %+v", r)
+	}
+
+	// Untagged reservations count for every epic the holder works under
+	locks[2].Metadata = nil
+	if risks := predictMergeConflicts(workspaces, locks); len(risks) != 2 || risks[1].ApexID != "bd-10" {
+		t.Errorf("untagged risks = %+v", risks)
+	}
+
+	out := formatMergeConflictRisks(risks)
+	if !strings.Contains(out, "- bd-1 (Accounts): alice, bob reserve overlapping files: api/accounts/**, api/accounts/signup.go") {
+		t.Errorf("output:\n%s", out)
+	}
+}
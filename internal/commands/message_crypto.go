@@ -0,0 +1,343 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// Sealed messages are mail and chat bodies encrypted end to end with age
+// (filippo.io/age) for the recipients' X25519 keys, published through the
+// server's key registry (bdh :keys init). The server only ever sees the
+// armored age file; bdh decrypts it wherever it renders a body. The private
+// key lives in .beadhub-cache/message-key.json, which never leaves the
+// workspace.
+//
+// The registry is trusted on first use: the first key seen for an alias is
+// pinned in .beadhub-cache/known-keys.json, and sealing refuses to encrypt
+// for a key that differs from the pinned one until it is accepted with
+// 'bdh :keys trust'. Otherwise whoever controls the server could swap in a
+// key of its own and read the message.
+//
+// Sealing hides the body, not the sender: like the rest of mail, who sent a
+// message is whatever the server says.
+
+// messageKeyFile is the on-disk form of this workspace's key pair.
+type messageKeyFile struct {
+	Alias      string `json:"alias"`
+	PrivateKey string `json:"private_key"`
+	PublicKey  string `json:"public_key"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// knownMessageKey is the key pinned for an alias the first time it was seen.
+type knownMessageKey struct {
+	PublicKey string `json:"public_key"`
+	FirstSeen string `json:"first_seen"`
+}
+
+// messageRecipientKey is a recipient's alias and public key.
+type messageRecipientKey struct {
+	Alias string
+	Key   *age.X25519Recipient
+}
+
+var (
+	localMessageKeyOnce sync.Once
+	localMessageKey     *age.X25519Identity
+)
+
+func messageKeyPath() (string, error) {
+	return cacheFilePath("message-key.json")
+}
+
+func knownKeysPath() (string, error) {
+	return cacheFilePath("known-keys.json")
+}
+
+// loadMessageKey reads this workspace's private key. Returns (nil, nil) when
+// no key has been created yet.
+func loadMessageKey() (*age.X25519Identity, error) {
+	path, err := messageKeyPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file messageKeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	key, err := age.ParseX25519Identity(file.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// cachedLocalMessageKey loads the private key once per process, best-effort.
+func cachedLocalMessageKey() *age.X25519Identity {
+	localMessageKeyOnce.Do(func() {
+		localMessageKey, _ = loadMessageKey()
+	})
+	return localMessageKey
+}
+
+// saveMessageKey writes key to .beadhub-cache/message-key.json (mode 0600).
+func saveMessageKey(key *age.X25519Identity, alias string, now time.Time) error {
+	path, err := messageKeyPath()
+	if err != nil {
+		return err
+	}
+	return writeJSONAtomic(path, messageKeyFile{
+		Alias:      alias,
+		PrivateKey: key.String(),
+		PublicKey:  key.Recipient().String(),
+		CreatedAt:  now.UTC().Format(time.RFC3339),
+	})
+}
+
+// loadKnownKeys reads the pinned keys by alias. A missing file is empty.
+func loadKnownKeys(path string) (map[string]knownMessageKey, error) {
+	known := make(map[string]knownMessageKey)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return known, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &known); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return known, nil
+}
+
+// messageKeyID is a short fingerprint of a public key.
+func messageKeyID(pub *age.X25519Recipient) string {
+	sum := sha256.Sum256([]byte(pub.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// parsePublishedKey decodes a key from the registry.
+func parsePublishedKey(k client.PublicKey) (*age.X25519Recipient, error) {
+	if k.Algorithm != "" && k.Algorithm != client.KeyAlgorithmAgeX25519 {
+		return nil, fmt.Errorf("%s published an unsupported %s key", k.Alias, k.Algorithm)
+	}
+	pub, err := age.ParseX25519Recipient(k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s published a malformed key: %w", k.Alias, err)
+	}
+	return pub, nil
+}
+
+// fetchRecipientKeys looks up the published keys of aliases and fails if any
+// of them has none: sealing for only some recipients would leave the others
+// with a message they cannot read. Keys are checked against the pinned ones.
+func fetchRecipientKeys(ctx context.Context, c BeadHubAPI, aliases []string) ([]messageRecipientKey, error) {
+	resp, err := c.PublicKeys(ctx, &client.PublicKeysRequest{Aliases: aliases})
+	if err != nil {
+		return nil, fmt.Errorf("looking up encryption keys: %w", err)
+	}
+	byAlias := make(map[string]client.PublicKey, len(resp.Keys))
+	for _, k := range resp.Keys {
+		byAlias[k.Alias] = k
+	}
+	var missing []string
+	keys := make([]messageRecipientKey, 0, len(aliases))
+	for _, alias := range aliases {
+		k, ok := byAlias[alias]
+		if !ok {
+			missing = append(missing, alias)
+			continue
+		}
+		pub, err := parsePublishedKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, messageRecipientKey{Alias: alias, Key: pub})
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("cannot encrypt: no published key for %s (they need to run 'bdh :keys init')", strings.Join(missing, ", "))
+	}
+	if err := checkKnownKeys(keys, time.Now()); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// checkKnownKeys pins keys seen for the first time and fails when a key
+// differs from the one pinned for its alias.
+func checkKnownKeys(keys []messageRecipientKey, now time.Time) error {
+	path, err := knownKeysPath()
+	if err != nil {
+		return err
+	}
+	known, err := loadKnownKeys(path)
+	if err != nil {
+		return err
+	}
+	var changed []string
+	added := false
+	for _, k := range keys {
+		pinned, ok := known[k.Alias]
+		switch {
+		case !ok:
+			known[k.Alias] = knownMessageKey{PublicKey: k.Key.String(), FirstSeen: now.UTC().Format(time.RFC3339)}
+			added = true
+		case pinned.PublicKey != k.Key.String():
+			changed = append(changed, k.Alias)
+		}
+	}
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		return fmt.Errorf("cannot encrypt: the published key for %s changed since it was first seen; "+
+			"confirm the new key with them, then run 'bdh :keys trust %s'",
+			strings.Join(changed, ", "), strings.Join(changed, " "))
+	}
+	if !added {
+		return nil
+	}
+	return writeJSONAtomic(path, known)
+}
+
+// trustKeys pins keys, replacing whatever was pinned for their aliases.
+func trustKeys(keys []messageRecipientKey, now time.Time) error {
+	path, err := knownKeysPath()
+	if err != nil {
+		return err
+	}
+	known, err := loadKnownKeys(path)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		known[k.Alias] = knownMessageKey{PublicKey: k.Key.String(), FirstSeen: now.UTC().Format(time.RFC3339)}
+	}
+	return writeJSONAtomic(path, known)
+}
+
+// sealOutgoingBody encrypts body for the recipients' published keys, and for
+// this workspace's own key when it has one so the sender can read it back.
+func sealOutgoingBody(ctx context.Context, c BeadHubAPI, body string, recipients []string, selfAlias string) (string, error) {
+	keys, err := fetchRecipientKeys(ctx, c, recipients)
+	if err != nil {
+		return "", err
+	}
+	if own, err := loadMessageKey(); err == nil && own != nil {
+		keys = append(keys, messageRecipientKey{Alias: selfAlias, Key: own.Recipient()})
+	}
+	return sealMessage(body, keys)
+}
+
+// sealMessage encrypts plaintext for recipients and returns the armored body,
+// headed by a line naming the recipients for readers without bdh.
+func sealMessage(plaintext string, recipients []messageRecipientKey) (string, error) {
+	if len(recipients) == 0 {
+		return "", fmt.Errorf("cannot encrypt: no recipients")
+	}
+	var names []string
+	var ageRecipients []age.Recipient
+	seen := make(map[string]bool)
+	for _, r := range recipients {
+		if seen[r.Key.String()] {
+			continue
+		}
+		seen[r.Key.String()] = true
+		names = append(names, r.Alias)
+		ageRecipients = append(ageRecipients, r.Key)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🔒 Encrypted message for %s (read it with bdh)\n", strings.Join(names, ", ")))
+	armored := armor.NewWriter(&sb)
+	w, err := age.Encrypt(armored, ageRecipients...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(w, plaintext); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	if err := armored.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// sealedMessagePayload extracts the armored age file from body. ok is false
+// when body is not a sealed message.
+func sealedMessagePayload(body string) (payload string, ok bool, err error) {
+	start := strings.Index(body, armor.Header)
+	if start < 0 {
+		return "", false, nil
+	}
+	end := strings.Index(body[start:], armor.Footer)
+	if end < 0 {
+		return "", true, fmt.Errorf("truncated sealed message")
+	}
+	return body[start:start+end+len(armor.Footer)] + "\n", true, nil
+}
+
+var errNotARecipient = errors.New("not encrypted for this workspace's key")
+
+// openSealedMessage decrypts an armored body with key. ok is false when body
+// is not a sealed message.
+func openSealedMessage(body string, key *age.X25519Identity) (plaintext string, ok bool, err error) {
+	payload, ok, err := sealedMessagePayload(body)
+	if !ok || err != nil {
+		return "", ok, err
+	}
+	if key == nil {
+		return "", true, fmt.Errorf("no message key in this workspace (run 'bdh :keys init')")
+	}
+	r, err := age.Decrypt(armor.NewReader(strings.NewReader(payload)), key)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return "", true, errNotARecipient
+		}
+		return "", true, fmt.Errorf("cannot decrypt message: %w", err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return "", true, fmt.Errorf("cannot decrypt message: %w", err)
+	}
+	return string(plain), true, nil
+}
+
+// decryptMessageBody returns body with a sealed message replaced by its
+// plaintext, or by a one-line notice when this workspace cannot read it.
+// Plain bodies are returned unchanged.
+func decryptMessageBody(body string) string {
+	plaintext, ok, err := openSealedMessage(body, cachedLocalMessageKey())
+	if !ok {
+		return body
+	}
+	if err != nil {
+		return fmt.Sprintf("🔒 [encrypted message: %v]", err)
+	}
+	return "🔒 " + plaintext
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func newTestMessageKey(t *testing.T) *age.X25519Identity {
+	t.Helper()
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("GenerateX25519Identity: %v", err)
+	}
+	return key
+}
+
+// keyServer publishes keys by alias from the /v1/keys registry.
+func keyServer(t *testing.T, keys map[string]*age.X25519Identity) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp client.PublicKeysResponse
+		for _, alias := range strings.Split(r.URL.Query().Get("aliases"), ",") {
+			if key, ok := keys[alias]; ok {
+				resp.Keys = append(resp.Keys, client.PublicKey{
+					Alias:     alias,
+					Algorithm: client.KeyAlgorithmAgeX25519,
+					PublicKey: key.Recipient().String(),
+				})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSealMessage_RoundTripForEachRecipient(t *testing.T) {
+	alice, bob, eve := newTestMessageKey(t), newTestMessageKey(t), newTestMessageKey(t)
+	sealed, err := sealMessage("token: s3cret", []messageRecipientKey{
+		{Alias: "alice", Key: alice.Recipient()},
+		{Alias: "bob", Key: bob.Recipient()},
+	})
+	if err != nil {
+		t.Fatalf("sealMessage: %v", err)
+	}
+	if strings.Contains(sealed, "s3cret") {
+		t.Fatalf("sealed body leaks plaintext:\n%s", sealed)
+	}
+	if !strings.Contains(sealed, "for alice, bob") {
+		t.Errorf("expected recipients in the armor hint, got:\n%s", sealed)
+	}
+
+	for name, key := range map[string]*age.X25519Identity{"alice": alice, "bob": bob} {
+		plain, ok, err := openSealedMessage(sealed, key)
+		if !ok || err != nil || plain != "token: s3cret" {
+			t.Errorf("%s: openSealedMessage = %q, %v, %v", name, plain, ok, err)
+		}
+	}
+
+	if _, ok, err := openSealedMessage(sealed, eve); !ok || err != errNotARecipient {
+		t.Errorf("eve: expected errNotARecipient, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestOpenSealedMessage_PlainBody(t *testing.T) {
+	if _, ok, err := openSealedMessage("just text", newTestMessageKey(t)); ok || err != nil {
+		t.Errorf("plain body: ok=%v err=%v, want not sealed", ok, err)
+	}
+}
+
+func TestOpenSealedMessage_Tampered(t *testing.T) {
+	key := newTestMessageKey(t)
+	sealed, err := sealMessage("hello", []messageRecipientKey{{Alias: "alice", Key: key.Recipient()}})
+	if err != nil {
+		t.Fatalf("sealMessage: %v", err)
+	}
+	payload, _, err := sealedMessagePayload(sealed)
+	if err != nil {
+		t.Fatalf("sealedMessagePayload: %v", err)
+	}
+	data, err := io.ReadAll(armor.NewReader(strings.NewReader(payload)))
+	if err != nil {
+		t.Fatalf("reading armor: %v", err)
+	}
+	data[len(data)-1] ^= 0xff
+	var tampered bytes.Buffer
+	w := armor.NewWriter(&tampered)
+	w.Write(data)
+	w.Close()
+
+	if _, ok, err := openSealedMessage(tampered.String(), key); !ok || err == nil {
+		t.Errorf("tampered ciphertext: ok=%v err=%v, want a decryption error", ok, err)
+	}
+}
+
+func TestFetchRecipientKeys_MissingKey(t *testing.T) {
+	t.Chdir(t.TempDir())
+	server := keyServer(t, map[string]*age.X25519Identity{"alice": newTestMessageKey(t)})
+
+	c := client.New(server.URL)
+	if _, err := fetchRecipientKeys(context.Background(), c, []string{"alice"}); err != nil {
+		t.Fatalf("alice only: %v", err)
+	}
+	_, err := fetchRecipientKeys(context.Background(), c, []string{"alice", "bob"})
+	if err == nil || !strings.Contains(err.Error(), "no published key for bob") {
+		t.Errorf("expected missing-key error for bob, got %v", err)
+	}
+}
+
+func TestKeyListEntries(t *testing.T) {
+	alice := newTestMessageKey(t)
+	entries := keyListEntries([]string{"alice", "bob"}, []client.PublicKey{{
+		Alias:     "alice",
+		PublicKey: alice.Recipient().String(),
+	}}, nil)
+	if len(entries) != 2 || !entries[0].Published || entries[0].KeyID != messageKeyID(alice.Recipient()) || entries[1].Published {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+	if out := formatKeyList(entries, false); !strings.Contains(out, "bob — no published key") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestFetchRecipientKeys_PinsKeysOnFirstUse(t *testing.T) {
+	t.Chdir(t.TempDir())
+	keys := map[string]*age.X25519Identity{"alice": newTestMessageKey(t)}
+	c := client.New(keyServer(t, keys).URL)
+
+	if _, err := fetchRecipientKeys(context.Background(), c, []string{"alice"}); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+
+	// The server now hands out a different key for alice.
+	keys["alice"] = newTestMessageKey(t)
+	_, err := fetchRecipientKeys(context.Background(), c, []string{"alice"})
+	if err == nil || !strings.Contains(err.Error(), "key for alice changed") || !strings.Contains(err.Error(), "bdh :keys trust alice") {
+		t.Fatalf("expected a changed-key error, got %v", err)
+	}
+
+	path, err := knownKeysPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	known, err := loadKnownKeys(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := keyListEntries([]string{"alice"}, []client.PublicKey{{Alias: "alice", PublicKey: keys["alice"].Recipient().String()}}, known)
+	if !entries[0].Changed || !strings.Contains(formatKeyList(entries, false), "changed since first seen") {
+		t.Errorf("list should flag the changed key: %+v", entries)
+	}
+
+	if err := trustKeys([]messageRecipientKey{{Alias: "alice", Key: keys["alice"].Recipient()}}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fetchRecipientKeys(context.Background(), c, []string{"alice"}); err != nil {
+		t.Errorf("after trust: %v", err)
+	}
+}
@@ -0,0 +1,345 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// With metrics.enabled in .beadhub, every bd command run through bdh adds to
+// counters and latency histograms kept in .beadhub-cache/metrics.json.
+// bdh :metrics serve exposes them in the Prometheus text format so platform
+// teams can alert on an agent fleet's coordination health; :metrics show
+// prints the same text once, e.g. for node_exporter's textfile collector.
+//
+// Concurrent commands serialize their updates with a lock file next to it.
+// Recording is best-effort: when the lock can't be had quickly, the command's
+// sample is dropped rather than slowing the command down.
+
+const (
+	defaultMetricsAddr = "127.0.0.1:9464"
+	// metricsLockWait bounds how long a command waits for the lock.
+	metricsLockWait = 200 * time.Millisecond
+)
+
+// metricsLatencyBuckets are the histogram upper bounds, in seconds.
+var metricsLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var metricsCommandLabelPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,31}$`)
+
+// MetricsHistogram counts observations per bucket of metricsLatencyBuckets;
+// the last count is for observations above every bound.
+type MetricsHistogram struct {
+	Counts []uint64 `json:"counts"`
+	Sum    float64  `json:"sum"`
+}
+
+func (h *MetricsHistogram) observe(d time.Duration) {
+	if len(h.Counts) != len(metricsLatencyBuckets)+1 {
+		h.Counts = make([]uint64, len(metricsLatencyBuckets)+1)
+	}
+	secs := d.Seconds()
+	i := sort.SearchFloat64s(metricsLatencyBuckets, secs)
+	h.Counts[i]++
+	h.Sum += secs
+}
+
+// CommandMetrics is the state behind bdh :metrics.
+type CommandMetrics struct {
+	Commands        map[string]uint64 `json:"commands,omitempty"`
+	Rejections      uint64            `json:"rejections"`
+	JumpIns         uint64            `json:"jump_ins"`
+	Blocked         uint64            `json:"blocked"`
+	Syncs           map[string]uint64 `json:"syncs,omitempty"`
+	SyncFailures    uint64            `json:"sync_failures"`
+	SyncBytes       uint64            `json:"sync_bytes"`
+	Preflight       MetricsHistogram  `json:"preflight_seconds"`
+	CommandDuration MetricsHistogram  `json:"command_seconds"`
+}
+
+var (
+	metricsAddr string
+	metricsJSON bool
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   ":metrics",
+	Short: "Expose coordination metrics for Prometheus",
+	Long: `Expose counters for bd commands run through bdh: commands by name,
+rejections, --:jump-in overrides, blocked commands, syncs by mode, sync
+failures and bytes uploaded, plus pre-flight and command latency histograms.
+
+Recording is off until you enable it in .beadhub:
+  metrics:
+    enabled: true
+
+Examples:
+  bdh :metrics serve                         # http://127.0.0.1:9464/metrics
+  bdh :metrics serve --addr 0.0.0.0:9464
+  bdh :metrics show > /var/lib/node_exporter/bdh.prom`,
+}
+
+var metricsServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve /metrics in the Prometheus text format",
+	Args:  cobra.NoArgs,
+	RunE:  runMetricsServe,
+}
+
+var metricsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the metrics once",
+	Args:  cobra.NoArgs,
+	RunE:  runMetricsShow,
+}
+
+func init() {
+	metricsServeCmd.Flags().StringVar(&metricsAddr, "addr", defaultMetricsAddr, "Address to listen on")
+	metricsShowCmd.Flags().BoolVar(&metricsJSON, "json", false, "Output as JSON")
+
+	metricsCmd.AddCommand(metricsServeCmd)
+	metricsCmd.AddCommand(metricsShowCmd)
+}
+
+func runMetricsShow(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if !cfg.MetricsEnabled() {
+		fmt.Fprintln(os.Stderr, "Note: metrics.enabled is off in .beadhub, so nothing new is being recorded.")
+	}
+	path, err := metricsPath()
+	if err != nil {
+		return err
+	}
+	m, err := loadCommandMetrics(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if metricsJSON {
+		fmt.Print(marshalJSONOrFallback(m))
+		return nil
+	}
+	fmt.Print(formatPrometheusMetrics(m, cfg))
+	return nil
+}
+
+func runMetricsServe(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	if !cfg.MetricsEnabled() {
+		return fmt.Errorf("metrics are not being recorded - set metrics.enabled: true in .beadhub first")
+	}
+	path, err := metricsPath()
+	if err != nil {
+		return err
+	}
+	SuppressNotifications()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		m, err := loadCommandMetrics(path)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, formatPrometheusMetrics(m, cfg))
+	})
+
+	listener, err := net.Listen("tcp", metricsAddr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", metricsAddr, err)
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving metrics on http://%s/metrics (Ctrl-C to stop)\n", listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func metricsPath() (string, error) {
+	return cacheFilePath("metrics.json")
+}
+
+// loadCommandMetrics reads the metrics file; a missing or corrupt file
+// starts from zero (a counter reset, as far as Prometheus is concerned).
+func loadCommandMetrics(path string) (*CommandMetrics, error) {
+	m := &CommandMetrics{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, err
+	}
+	if json.Unmarshal(data, m) != nil {
+		return &CommandMetrics{}, nil
+	}
+	return m, nil
+}
+
+func saveCommandMetrics(path string, m *CommandMetrics) error {
+	return writeJSONAtomic(path, m)
+}
+
+// recordCommandMetrics adds one passthrough command to the metrics file when
+// metrics are enabled (best-effort).
+func recordCommandMetrics(args []string, result *PassthroughResult, elapsed time.Duration) {
+	cfg, err := config.Load()
+	if err != nil || !cfg.MetricsEnabled() {
+		return
+	}
+	path, err := metricsPath()
+	if err != nil {
+		return
+	}
+	if expanded, _, err := expandCommandAlias(cfg.Aliases, args); err == nil {
+		args = expanded
+	}
+	if ensureCacheDir(path) != nil {
+		return
+	}
+	unlock, err := lockCacheFileTimeout(path, metricsLockWait)
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	m, err := loadCommandMetrics(path)
+	if err != nil {
+		return
+	}
+	m.add(metricsCommandLabel(args), result, elapsed)
+	_ = saveCommandMetrics(path, m)
+}
+
+func (m *CommandMetrics) add(command string, result *PassthroughResult, elapsed time.Duration) {
+	if m.Commands == nil {
+		m.Commands = make(map[string]uint64)
+	}
+	m.Commands[command]++
+	if result.Rejected {
+		m.Rejections++
+	}
+	if result.JumpedIn {
+		m.JumpIns++
+	}
+	if result.Blocked != "" {
+		m.Blocked++
+	}
+	if result.SyncFailed {
+		m.SyncFailures++
+	} else if result.SyncBytes > 0 {
+		if m.Syncs == nil {
+			m.Syncs = make(map[string]uint64)
+		}
+		m.Syncs[result.SyncMode]++
+		m.SyncBytes += uint64(result.SyncBytes)
+	}
+	if result.PreflightDuration > 0 {
+		m.Preflight.observe(result.PreflightDuration)
+	}
+	m.CommandDuration.observe(elapsed)
+}
+
+// metricsCommandLabel is the bd subcommand, kept to a bounded set of label
+// values: anything that doesn't look like a command name is "other".
+func metricsCommandLabel(args []string) string {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if metricsCommandLabelPattern.MatchString(arg) {
+			return arg
+		}
+		return "other"
+	}
+	return "other"
+}
+
+// formatPrometheusMetrics renders m in the Prometheus text exposition format.
+// Every series carries the workspace alias and project.
+func formatPrometheusMetrics(m *CommandMetrics, cfg *config.Config) string {
+	base := fmt.Sprintf(`alias="%s",project="%s"`, promEscape(cfg.Alias), promEscape(cfg.ProjectSlug))
+	labels := func(extra ...string) string {
+		parts := append([]string{base}, extra...)
+		return "{" + strings.Join(parts, ",") + "}"
+	}
+
+	var sb strings.Builder
+	header := func(name, kind, help string) {
+		sb.WriteString(fmt.Sprintf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind))
+	}
+	counter := func(name, help string, v uint64) {
+		header(name, "counter", help)
+		sb.WriteString(fmt.Sprintf("%s%s %d\n", name, labels(), v))
+	}
+	labeled := func(name, help, label string, values map[string]uint64) {
+		header(name, "counter", help)
+		keys := sortedKeys(values)
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("%s%s %d\n", name, labels(fmt.Sprintf(`%s="%s"`, label, promEscape(k))), values[k]))
+		}
+	}
+	histogram := func(name, help string, h MetricsHistogram) {
+		header(name, "histogram", help)
+		var cumulative uint64
+		for i, bound := range metricsLatencyBuckets {
+			if i < len(h.Counts) {
+				cumulative += h.Counts[i]
+			}
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			sb.WriteString(fmt.Sprintf("%s_bucket%s %d\n", name, labels(fmt.Sprintf(`le="%s"`, le)), cumulative))
+		}
+		if len(h.Counts) > len(metricsLatencyBuckets) {
+			cumulative += h.Counts[len(metricsLatencyBuckets)]
+		}
+		sb.WriteString(fmt.Sprintf("%s_bucket%s %d\n", name, labels(`le="+Inf"`), cumulative))
+		sb.WriteString(fmt.Sprintf("%s_sum%s %s\n", name, labels(), strconv.FormatFloat(h.Sum, 'g', -1, 64)))
+		sb.WriteString(fmt.Sprintf("%s_count%s %d\n", name, labels(), cumulative))
+	}
+
+	labeled("bdh_commands_total", "bd commands run through bdh.", "command", m.Commands)
+	counter("bdh_rejections_total", "Commands the server rejected.", m.Rejections)
+	counter("bdh_jump_ins_total", "Rejections overridden with --:jump-in.", m.JumpIns)
+	counter("bdh_blocked_total", "Commands not run because of the degradation policy.", m.Blocked)
+	labeled("bdh_syncs_total", "Successful issue syncs, by mode.", "mode", m.Syncs)
+	counter("bdh_sync_failures_total", "Issue syncs that failed to upload.", m.SyncFailures)
+	counter("bdh_sync_bytes_total", "Issue data uploaded by successful syncs.", m.SyncBytes)
+	histogram("bdh_preflight_duration_seconds", "Pre-flight round trip to the BeadHub server.", m.Preflight)
+	histogram("bdh_command_duration_seconds", "Total time of a bd command run through bdh.", m.CommandDuration)
+	return sb.String()
+}
+
+func promEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
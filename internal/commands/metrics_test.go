@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestCommandMetrics_AddAndFormat(t *testing.T) {
+	m := &CommandMetrics{}
+	m.add("ready", &PassthroughResult{PreflightDuration: 30 * time.Millisecond}, 200*time.Millisecond)
+	m.add("update", &PassthroughResult{JumpedIn: true, SyncMode: "patch", SyncBytes: 120, PreflightDuration: 80 * time.Millisecond}, 700*time.Millisecond)
+	m.add("update", &PassthroughResult{Rejected: true, PreflightDuration: 40 * time.Millisecond}, 60*time.Millisecond)
+	m.add("close", &PassthroughResult{SyncMode: "incremental", SyncFailed: true}, 20*time.Second)
+
+	out := formatPrometheusMetrics(m, &config.Config{Alias: "claude-be", ProjectSlug: "demo"})
+	for _, want := range []string{
+		"# TYPE bdh_commands_total counter\n",
+		`bdh_commands_total{alias="claude-be",project="demo",command="update"} 2`,
+		`bdh_rejections_total{alias="claude-be",project="demo"} 1`,
+		`bdh_jump_ins_total{alias="claude-be",project="demo"} 1`,
+		`bdh_syncs_total{alias="claude-be",project="demo",mode="patch"} 1`,
+		`bdh_sync_failures_total{alias="claude-be",project="demo"} 1`,
+		`bdh_sync_bytes_total{alias="claude-be",project="demo"} 120`,
+		"# TYPE bdh_preflight_duration_seconds histogram\n",
+		`bdh_preflight_duration_seconds_bucket{alias="claude-be",project="demo",le="0.05"} 2`,
+		`bdh_preflight_duration_seconds_bucket{alias="claude-be",project="demo",le="0.1"} 3`,
+		`bdh_preflight_duration_seconds_count{alias="claude-be",project="demo"} 3`,
+		`bdh_command_duration_seconds_bucket{alias="claude-be",project="demo",le="10"} 3`,
+		`bdh_command_duration_seconds_bucket{alias="claude-be",project="demo",le="+Inf"} 4`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestMetricsCommandLabel(t *testing.T) {
+	cases := map[string][]string{
+		"ready":  {"ready", "--json"},
+		"update": {"--no-daemon", "update", "bd-1"},
+		"other":  {"Weird$Cmd"},
+	}
+	for want, args := range cases {
+		if got := metricsCommandLabel(args); got != want {
+			t.Errorf("metricsCommandLabel(%v) = %q, want %q", args, got, want)
+		}
+	}
+	if got := metricsCommandLabel(nil); got != "other" {
+		t.Errorf("empty args = %q", got)
+	}
+}
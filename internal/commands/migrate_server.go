@@ -0,0 +1,370 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/beads"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+var (
+	migrateServerJSON   bool
+	migrateServerDryRun bool
+)
+
+var migrateServerCmd = &cobra.Command{
+	Use:   ":migrate-server <new-url>",
+	Short: "Move this workspace to a different BeadHub server",
+	Long: `Move this workspace to a different BeadHub server.
+
+This command will:
+1. Register the workspace (same alias, role, project) on the new server
+2. Run a full sync of .beads/issues.jsonl to the new server
+3. Re-claim the beads you currently hold and restore your focus
+4. Update .beadhub and the aw account/context to point at the new server
+5. Verify the new server has the same issue count and claims
+
+The old server is only read from; nothing is deleted there.
+
+Examples:
+  bdh :migrate-server https://beadhub.example.com
+  bdh :migrate-server https://beadhub.example.com --dry-run
+  bdh :migrate-server https://beadhub.example.com --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMigrateServer,
+}
+
+func init() {
+	migrateServerCmd.Flags().BoolVar(&migrateServerJSON, "json", false, "Output as JSON")
+	migrateServerCmd.Flags().BoolVar(&migrateServerDryRun, "dry-run", false, "Show what would be migrated without contacting the new server")
+}
+
+// MigrateServerResult contains the result of migrating a workspace to a new server.
+type MigrateServerResult struct {
+	OldURL string `json:"old_url"`
+	NewURL string `json:"new_url"`
+	DryRun bool   `json:"dry_run,omitempty"`
+
+	OldWorkspaceID string `json:"old_workspace_id"`
+	NewWorkspaceID string `json:"new_workspace_id,omitempty"`
+	Alias          string `json:"alias"`
+
+	LocalIssues    int            `json:"local_issues"`
+	ServerIssues   int            `json:"server_issues"`
+	Claims         []string       `json:"claims,omitempty"`
+	ClaimsMigrated []string       `json:"claims_migrated,omitempty"`
+	ClaimsRejected []string       `json:"claims_rejected,omitempty"`
+	FocusApexID    string         `json:"focus_apex_id,omitempty"`
+	ParityOK       bool           `json:"parity_ok"`
+	ParityProblems []string       `json:"parity_problems,omitempty"`
+	AccountName    string         `json:"account,omitempty"`
+	ServerName     string         `json:"server,omitempty"`
+	ConfigUpdated  bool           `json:"config_updated"`
+	NewConfig      *config.Config `json:"-"`
+	NewAPIKey      string         `json:"-"`
+}
+
+func runMigrateServer(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	newURL := strings.TrimRight(strings.TrimSpace(args[0]), "/")
+
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := migrateServerWithConfig(ctx, cfg, newURL, migrateServerDryRun)
+	if err != nil {
+		return err
+	}
+
+	if !result.DryRun {
+		if err := result.NewConfig.Save(); err != nil {
+			return fmt.Errorf("migration succeeded on the new server but saving .beadhub failed: %w", err)
+		}
+		result.ConfigUpdated = true
+
+		accountName, serverName, err := persistBeadhubAccountAndContext(newURL, result.NewConfig.ProjectSlug, result.NewConfig.Alias, result.NewAPIKey, result.NewConfig.WorkspaceID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: .beadhub updated but aw account could not be saved: %v\n", err)
+		} else {
+			result.AccountName = accountName
+			result.ServerName = serverName
+		}
+	}
+
+	fmt.Print(formatMigrateServerOutput(result, migrateServerJSON))
+	if !result.DryRun && !result.ParityOK {
+		return fmt.Errorf("migration finished with parity problems - see above")
+	}
+	return nil
+}
+
+// migrateServerWithConfig registers the workspace on newURL, syncs issues and
+// claims, and verifies parity. It does not touch .beadhub or the aw config (for testing).
+func migrateServerWithConfig(ctx context.Context, cfg *config.Config, newURL string, dryRun bool) (*MigrateServerResult, error) {
+	if !strings.HasPrefix(newURL, "http://") && !strings.HasPrefix(newURL, "https://") {
+		return nil, fmt.Errorf("new server URL must be an HTTP(S) URL")
+	}
+	if strings.TrimRight(cfg.BeadhubURL, "/") == newURL {
+		return nil, fmt.Errorf("workspace is already using %s", newURL)
+	}
+
+	result := &MigrateServerResult{
+		OldURL:         cfg.BeadhubURL,
+		NewURL:         newURL,
+		DryRun:         dryRun,
+		OldWorkspaceID: cfg.WorkspaceID,
+		Alias:          cfg.Alias,
+	}
+
+	content, err := os.ReadFile(beads.IssuesJSONLPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading %s: %w", beads.IssuesJSONLPath(), err)
+	}
+	content, _ = sync.NormalizeJSONL(content) // match what sync uploads
+	hashes, err := sync.ComputeIssueHashes(content)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute issue hashes: %w", err)
+	}
+	result.LocalIssues = len(hashes)
+
+	// Snapshot claims and focus from the old server (best-effort: it may already be gone).
+	oldClient := newBeadHubClient(ctx, cfg.BeadhubURL)
+	oldCtx, oldCancel := context.WithTimeout(ctx, apiTimeout)
+	includeClaims := true
+	wsResp, wsErr := oldClient.TeamWorkspaces(oldCtx, &client.TeamWorkspacesRequest{
+		IncludeClaims:            &includeClaims,
+		AlwaysIncludeWorkspaceID: cfg.WorkspaceID,
+		Limit:                    maxWorkspaceQueryLimit,
+	})
+	oldCancel()
+	if wsErr == nil {
+		for _, ws := range wsResp.Workspaces {
+			if ws.WorkspaceID != cfg.WorkspaceID {
+				continue
+			}
+			for _, claim := range ws.Claims {
+				result.Claims = append(result.Claims, claim.BeadID)
+			}
+			result.FocusApexID = ws.FocusApexID
+		}
+		sort.Strings(result.Claims)
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: could not read claims from old server (%v); claims will not be migrated\n", wsErr)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	// Register on the new server with the same identity.
+	newClient := beadHubClientFor(ctx, newURL, "")
+	alias := cfg.Alias
+	hostname, _ := os.Hostname()
+	workspacePath, _ := os.Getwd()
+	initCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	initResp, err := newClient.Init(initCtx, &client.InitRequest{
+		RepoOrigin:    cfg.RepoOrigin,
+		Alias:         &alias,
+		HumanName:     cfg.HumanName,
+		Role:          cfg.Role,
+		ProjectSlug:   cfg.ProjectSlug,
+		Hostname:      hostname,
+		WorkspacePath: workspacePath,
+	})
+	cancel()
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("registering on new server: BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		if client.IsPayloadError(err) {
+			return nil, fmt.Errorf("registering on new server: %w", err)
+		}
+		return nil, fmt.Errorf("could not reach BeadHub at %s: %w", newURL, err)
+	}
+	if !strings.HasPrefix(initResp.APIKey, "aw_sk_") {
+		return nil, fmt.Errorf("new server returned malformed API key")
+	}
+
+	newCfg := *cfg
+	newCfg.BeadhubURL = newURL
+	newCfg.WorkspaceID = initResp.WorkspaceID
+	newCfg.ProjectSlug = initResp.ProjectSlug
+	newCfg.RepoID = initResp.RepoID
+	if initResp.CanonicalOrigin != "" {
+		newCfg.CanonicalOrigin = initResp.CanonicalOrigin
+	}
+	newCfg.Alias = initResp.Alias
+	if err := newCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("new server returned invalid workspace data: %w", err)
+	}
+	result.NewConfig = &newCfg
+	result.NewAPIKey = initResp.APIKey
+	result.NewWorkspaceID = newCfg.WorkspaceID
+	result.Alias = newCfg.Alias
+
+	authed := beadHubClientFor(ctx, newURL, initResp.APIKey)
+
+	// Full sync: the new server has no history for this workspace.
+	syncCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	syncResp, err := authed.Sync(syncCtx, &client.SyncRequest{
+		WorkspaceID: newCfg.WorkspaceID,
+		RepoID:      newCfg.RepoID,
+		Alias:       newCfg.Alias,
+		HumanName:   newCfg.HumanName,
+		RepoOrigin:  newCfg.RepoOrigin,
+		Role:        newCfg.Role,
+		CommandLine: "bdh :migrate-server",
+		SyncMode:    "full",
+		IssuesJSONL: string(content),
+	})
+	cancel()
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("full sync to new server failed: BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("full sync to new server failed: %w", err)
+	}
+	result.ServerIssues = syncResp.IssuesCount
+
+	// Local sync state was built against the old server; start fresh from this full sync.
+	state := &sync.SyncState{ProtocolVersion: syncResp.SyncProtocolVersion}
+	sync.UpdateState(state, hashes)
+	if err := sync.SaveState(syncStatePathForConfig(&newCfg), state); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save sync state: %v\n", err)
+	}
+
+	// Re-claim beads by replaying the claim command through pre-flight.
+	for _, beadID := range result.Claims {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		cmdResp, err := authed.Command(ctx, &client.CommandRequest{
+			WorkspaceID: newCfg.WorkspaceID,
+			RepoID:      newCfg.RepoID,
+			Alias:       newCfg.Alias,
+			HumanName:   newCfg.HumanName,
+			RepoOrigin:  newCfg.RepoOrigin,
+			Role:        newCfg.Role,
+			CommandLine: fmt.Sprintf("update %s --status in_progress", beadID),
+		})
+		cancel()
+		if err != nil || !cmdResp.Approved {
+			result.ClaimsRejected = append(result.ClaimsRejected, beadID)
+			continue
+		}
+		result.ClaimsMigrated = append(result.ClaimsMigrated, beadID)
+	}
+
+	// Restore the focus, which may have been set explicitly on the old server.
+	var focusErr error
+	if result.FocusApexID != "" {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, focusErr = authed.SetFocus(ctx, newCfg.WorkspaceID, &client.SetFocusRequest{Alias: newCfg.Alias, ApexID: result.FocusApexID})
+		cancel()
+	}
+
+	result.ParityProblems = verifyMigrationParity(ctx, authed, result)
+	if focusErr != nil {
+		result.ParityProblems = append(result.ParityProblems, fmt.Sprintf("focus %s was not set on the new server: %v", result.FocusApexID, focusErr))
+	}
+	result.ParityOK = len(result.ParityProblems) == 0
+
+	return result, nil
+}
+
+// verifyMigrationParity compares the new server's view with the local snapshot.
+func verifyMigrationParity(ctx context.Context, c BeadHubAPI, result *MigrateServerResult) []string {
+	var problems []string
+	if result.ServerIssues != result.LocalIssues {
+		problems = append(problems, fmt.Sprintf("issue count mismatch: local %d, server %d", result.LocalIssues, result.ServerIssues))
+	}
+	for _, beadID := range result.ClaimsRejected {
+		problems = append(problems, fmt.Sprintf("claim on %s was not accepted by the new server", beadID))
+	}
+
+	if len(result.ClaimsMigrated) == 0 {
+		return problems
+	}
+
+	includeClaims := true
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+		IncludeClaims:            &includeClaims,
+		AlwaysIncludeWorkspaceID: result.NewWorkspaceID,
+		Limit:                    maxWorkspaceQueryLimit,
+	})
+	if err != nil {
+		return append(problems, fmt.Sprintf("could not verify claims on new server: %v", err))
+	}
+	held := make(map[string]bool)
+	for _, ws := range resp.Workspaces {
+		if ws.WorkspaceID != result.NewWorkspaceID {
+			continue
+		}
+		for _, claim := range ws.Claims {
+			held[claim.BeadID] = true
+		}
+	}
+	for _, beadID := range result.ClaimsMigrated {
+		if !held[beadID] {
+			problems = append(problems, fmt.Sprintf("claim on %s is missing on the new server", beadID))
+		}
+	}
+	return problems
+}
+
+// formatMigrateServerOutput formats the migration result for display.
+func formatMigrateServerOutput(result *MigrateServerResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.DryRun {
+		sb.WriteString(fmt.Sprintf("Dry run: would migrate %s from %s to %s\n", result.Alias, result.OldURL, result.NewURL))
+		sb.WriteString(fmt.Sprintf("  Issues to sync: %d\n", result.LocalIssues))
+		if len(result.Claims) > 0 {
+			sb.WriteString(fmt.Sprintf("  Claims to transfer: %s\n", strings.Join(result.Claims, ", ")))
+		} else {
+			sb.WriteString("  Claims to transfer: none\n")
+		}
+		if result.FocusApexID != "" {
+			sb.WriteString(fmt.Sprintf("  Focus: %s\n", result.FocusApexID))
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Migrated %s from %s to %s\n", result.Alias, result.OldURL, result.NewURL))
+	sb.WriteString(fmt.Sprintf("  workspace_id: %s (was %s)\n", result.NewWorkspaceID, result.OldWorkspaceID))
+	sb.WriteString(fmt.Sprintf("  issues: %d local, %d on server\n", result.LocalIssues, result.ServerIssues))
+	if len(result.ClaimsMigrated) > 0 {
+		sb.WriteString(fmt.Sprintf("  claims transferred: %s\n", strings.Join(result.ClaimsMigrated, ", ")))
+	}
+	if result.FocusApexID != "" {
+		sb.WriteString(fmt.Sprintf("  focus: %s\n", result.FocusApexID))
+	}
+	if result.AccountName != "" {
+		sb.WriteString(fmt.Sprintf("  account: %s (server: %s)\n", result.AccountName, result.ServerName))
+	}
+	if result.ParityOK {
+		sb.WriteString("\n✓ Parity verified\n")
+	} else {
+		sb.WriteString("\nParity problems:\n")
+		for _, p := range result.ParityProblems {
+			sb.WriteString(fmt.Sprintf("- %s\n", p))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/beads"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func setupBeadsWorkspace(t *testing.T, issues string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		beads.ResetCache()
+	})
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	beads.ResetCache()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".beads"), 0755); err != nil {
+		t.Fatalf("mkdir .beads: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".beads", "issues.jsonl"), []byte(issues), 0600); err != nil {
+		t.Fatalf("write issues.jsonl: %v", err)
+	}
+}
+
+func TestMigrateServer_SyncsAndTransfersClaims(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open"}
+{"id":"bd-2","title":"Two","status":"in_progress"}
+`)
+
+	oldServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/workspaces/team" {
+			t.Errorf("unexpected old-server request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"workspaces": []map[string]any{{
+				"workspace_id":  "11111111-1111-1111-1111-111111111111",
+				"alias":         "alice",
+				"focus_apex_id": "bd-epic",
+				"claims":        []map[string]any{{"bead_id": "bd-2"}},
+			}},
+		})
+	}))
+	defer oldServer.Close()
+
+	newWorkspaceID := "22222222-2222-2222-2222-222222222222"
+	var syncedJSONL string
+	var commandLines []string
+	var focus map[string]any
+	newServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/init":
+			json.NewEncoder(w).Encode(map[string]any{
+				"status":           "ok",
+				"api_key":          "aw_sk_" + strings.Repeat("a", 32),
+				"project_slug":     "test",
+				"workspace_id":     newWorkspaceID,
+				"alias":            "alice",
+				"canonical_origin": "github.com/test/repo",
+			})
+		case "/v1/bdh/sync":
+			if got := r.Header.Get("Authorization"); !strings.HasPrefix(got, "Bearer aw_sk_") {
+				t.Errorf("sync missing auth header: %q", got)
+			}
+			var req map[string]any
+			json.NewDecoder(r.Body).Decode(&req)
+			syncedJSONL, _ = req["issues_jsonl"].(string)
+			if req["sync_mode"] != "full" {
+				t.Errorf("expected full sync, got %v", req["sync_mode"])
+			}
+			json.NewEncoder(w).Encode(map[string]any{"synced": true, "issues_count": 2})
+		case "/v1/bdh/command":
+			var req map[string]any
+			json.NewDecoder(r.Body).Decode(&req)
+			commandLines = append(commandLines, req["command_line"].(string))
+			json.NewEncoder(w).Encode(map[string]any{"approved": true})
+		case "/v1/workspaces/" + newWorkspaceID + "/focus":
+			json.NewDecoder(r.Body).Decode(&focus)
+			json.NewEncoder(w).Encode(map[string]any{"workspace_id": newWorkspaceID, "focus_apex_id": focus["apex_id"]})
+		case "/v1/workspaces/team":
+			json.NewEncoder(w).Encode(map[string]any{
+				"workspaces": []map[string]any{{
+					"workspace_id": newWorkspaceID,
+					"alias":        "alice",
+					"claims":       []map[string]any{{"bead_id": "bd-2"}},
+				}},
+			})
+		default:
+			t.Errorf("unexpected new-server request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer newServer.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "11111111-1111-1111-1111-111111111111",
+		BeadhubURL:      oldServer.URL,
+		ProjectSlug:     "test",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "alice",
+		HumanName:       "Alice",
+	}
+
+	result, err := migrateServerWithConfig(context.Background(), cfg, newServer.URL, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(syncedJSONL, `"bd-2"`) {
+		t.Errorf("expected full JSONL to be synced, got %q", syncedJSONL)
+	}
+	if len(commandLines) != 1 || commandLines[0] != "update bd-2 --status in_progress" {
+		t.Errorf("unexpected claim commands: %v", commandLines)
+	}
+	if !result.ParityOK {
+		t.Errorf("expected parity OK, got problems: %v", result.ParityProblems)
+	}
+	if result.NewConfig.BeadhubURL != newServer.URL || result.NewConfig.WorkspaceID != newWorkspaceID {
+		t.Errorf("new config not updated: %+v", result.NewConfig)
+	}
+	if cfg.BeadhubURL != oldServer.URL {
+		t.Error("original config must not be mutated")
+	}
+	if result.FocusApexID != "bd-epic" {
+		t.Errorf("expected focus bd-epic, got %q", result.FocusApexID)
+	}
+	if focus["apex_id"] != "bd-epic" || focus["alias"] != "alice" {
+		t.Errorf("expected the focus to be set on the new server, got %v", focus)
+	}
+}
+
+func TestMigrateServer_ReportsParityMismatch(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open"}
+`)
+
+	oldServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer oldServer.Close()
+
+	newServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/init":
+			json.NewEncoder(w).Encode(map[string]any{
+				"api_key":      "aw_sk_" + strings.Repeat("b", 32),
+				"project_slug": "test",
+				"workspace_id": "33333333-3333-3333-3333-333333333333",
+				"alias":        "alice",
+			})
+		case "/v1/bdh/sync":
+			json.NewEncoder(w).Encode(map[string]any{"synced": true, "issues_count": 0})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer newServer.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "11111111-1111-1111-1111-111111111111",
+		BeadhubURL:      oldServer.URL,
+		ProjectSlug:     "test",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "alice",
+		HumanName:       "Alice",
+	}
+
+	result, err := migrateServerWithConfig(context.Background(), cfg, newServer.URL, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ParityOK {
+		t.Fatal("expected parity mismatch")
+	}
+	output := formatMigrateServerOutput(result, false)
+	if !strings.Contains(output, "issue count mismatch: local 1, server 0") {
+		t.Errorf("expected mismatch in output, got:\n%s", output)
+	}
+}
+
+func TestMigrateServer_RejectsSameURL(t *testing.T) {
+	cfg := &config.Config{BeadhubURL: "http://localhost:8000"}
+	if _, err := migrateServerWithConfig(context.Background(), cfg, "http://localhost:8000", true); err == nil {
+		t.Error("expected error when migrating to the current server")
+	}
+}
@@ -49,6 +49,7 @@ func init() {
 }
 
 func runNextAliasPrefix(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	// Get git remote origin
 	repoOrigin := os.Getenv("BEADHUB_REPO_ORIGIN")
 	if repoOrigin == "" {
@@ -75,8 +76,8 @@ func runNextAliasPrefix(cmd *cobra.Command, args []string) error {
 	}
 
 	// Call suggest-name-prefix API
-	c := client.New(beadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	c := beadHubClientFor(ctx, beadhubURL, "")
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	resp, err := c.SuggestNamePrefix(ctx, &client.SuggestNamePrefixRequest{
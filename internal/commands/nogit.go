@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Workspaces that are not git repositories (docs folders, data workspaces)
+// have no origin to register with, so :init --no-git gives them a synthetic
+// one under the reserved .invalid domain. It is derived from the hostname and
+// the absolute workspace path, so re-running :init in the same directory
+// finds the same repo on the server.
+
+const syntheticOriginHost = "nogit.invalid"
+
+// syntheticRepoOrigin returns the origin for a non-git workspace at dir on
+// host, e.g. https://nogit.invalid/build-box/design-docs-1a2b3c4d.
+func syntheticRepoOrigin(host, dir string) string {
+	sum := sha256.Sum256([]byte(host + "\x00" + dir))
+	hostSlug := config.SanitizeSlug(host)
+	if hostSlug == "" {
+		hostSlug = "localhost"
+	}
+	dirSlug := config.SanitizeSlug(filepath.Base(dir))
+	if dirSlug == "" {
+		dirSlug = "workspace"
+	}
+	return fmt.Sprintf("https://%s/%s/%s-%s", syntheticOriginHost, hostSlug, dirSlug, hex.EncodeToString(sum[:4]))
+}
+
+// currentSyntheticRepoOrigin is syntheticRepoOrigin for this machine and the
+// current directory.
+func currentSyntheticRepoOrigin() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("determining workspace path: %w", err)
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	host, _ := os.Hostname()
+	return syntheticRepoOrigin(host, dir), nil
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestSyntheticRepoOrigin(t *testing.T) {
+	origin := syntheticRepoOrigin("Build.Box", "/srv/Design Docs")
+	if !strings.HasPrefix(origin, "https://nogit.invalid/build-box/design-docs-") {
+		t.Fatalf("unexpected origin %q", origin)
+	}
+	if again := syntheticRepoOrigin("Build.Box", "/srv/Design Docs"); again != origin {
+		t.Errorf("origin not stable: %q vs %q", origin, again)
+	}
+	if other := syntheticRepoOrigin("Build.Box", "/home/me/Design Docs"); other == origin {
+		t.Errorf("different directories share origin %q", origin)
+	}
+
+	// The origin must pass .beadhub validation, and so must its canonical form.
+	cfg := validNoGitConfig(origin, canonicalizeOriginURL(origin))
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("synthetic origin rejected: %v", err)
+	}
+}
+
+func TestValidateRepoOriginMatchesCurrent_NoGit(t *testing.T) {
+	t.Setenv("BEADHUB_REPO_ORIGIN", "git@github.com:other/repo.git")
+	cfg := validNoGitConfig("https://nogit.invalid/box/docs-00000000", "nogit.invalid/box/docs-00000000")
+	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
+		t.Errorf("no_git workspace should skip the origin check, got %v", err)
+	}
+	if got := currentRepoOriginBestEffort(cfg); got != cfg.RepoOrigin {
+		t.Errorf("currentRepoOriginBestEffort = %q, want the synthetic origin", got)
+	}
+	if cfg.AutoReserveEnabled() {
+		t.Error("auto-reserve should be off for no_git workspaces")
+	}
+}
+
+func validNoGitConfig(origin, canonical string) *config.Config {
+	return &config.Config{
+		WorkspaceID:     "00000000-0000-0000-0000-000000000001",
+		BeadhubURL:      "http://localhost:8000",
+		ProjectSlug:     "docs",
+		RepoOrigin:      origin,
+		CanonicalOrigin: canonical,
+		Alias:           "alice-agent",
+		HumanName:       "Alice",
+		NoGit:           true,
+	}
+}
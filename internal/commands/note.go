@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+const (
+	maxNoteLength        = 2000
+	defaultNoteListLimit = 20
+	// passthroughNotesLimit bounds notes shown in rejection/jump-in context.
+	passthroughNotesLimit = 5
+)
+
+var (
+	noteJSON  bool
+	noteLimit int
+)
+
+var noteCmd = &cobra.Command{
+	Use:   ":note",
+	Short: "Attach short notes to beads, shared with other agents",
+	Long: `Attach short notes to a bead, stored on BeadHub so other agents see them
+immediately (no bd sync + pull cycle needed).
+
+Notes are also shown when a claim is rejected or when you --:jump-in.
+
+Examples:
+  bdh :note add bd-42 "found root cause in auth.py"
+  bdh :note list bd-42
+  bdh :note list bd-42 --json`,
+}
+
+var noteAddCmd = &cobra.Command{
+	Use:   "add <bead-id> <text>",
+	Short: "Add a note to a bead",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runNoteAdd,
+}
+
+var noteListCmd = &cobra.Command{
+	Use:   "list <bead-id>",
+	Short: "List notes on a bead",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNoteList,
+}
+
+func init() {
+	noteAddCmd.Flags().BoolVar(&noteJSON, "json", false, "Output as JSON")
+	noteListCmd.Flags().BoolVar(&noteJSON, "json", false, "Output as JSON")
+	noteListCmd.Flags().IntVar(&noteLimit, "limit", defaultNoteListLimit, "Maximum notes to show")
+
+	noteCmd.AddCommand(noteAddCmd)
+	noteCmd.AddCommand(noteListCmd)
+}
+
+func runNoteAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+
+	note, err := addNoteWithConfig(ctx, cfg, args[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	if noteJSON {
+		fmt.Print(marshalJSONOrFallback(note))
+		return nil
+	}
+	fmt.Printf("Note added to %s\n", note.BeadID)
+	return nil
+}
+
+func runNoteList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+
+	notes, err := listNotesWithConfig(ctx, cfg, args[0], noteLimit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(formatNoteListOutput(args[0], notes, noteJSON))
+	return nil
+}
+
+// addNoteWithConfig attaches a note to a bead (for testing).
+func addNoteWithConfig(ctx context.Context, cfg *config.Config, beadID, body string) (*client.BeadNote, error) {
+	beadID = strings.TrimSpace(beadID)
+	body = strings.TrimSpace(body)
+	if beadID == "" {
+		return nil, fmt.Errorf("bead ID cannot be empty")
+	}
+	if body == "" {
+		return nil, fmt.Errorf("note cannot be empty")
+	}
+	if len(body) > maxNoteLength {
+		return nil, fmt.Errorf("note too long (%d chars, max %d) - notes are for short findings; use bd comments for details", len(body), maxNoteLength)
+	}
+
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	note, err := c.AddBeadNote(ctx, beadID, &client.AddBeadNoteRequest{
+		WorkspaceID: cfg.WorkspaceID,
+		Alias:       cfg.Alias,
+		RepoID:      cfg.RepoID,
+		Body:        body,
+	})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("failed to add note: %w", err)
+	}
+	if note.BeadID == "" {
+		note.BeadID = beadID
+	}
+	return note, nil
+}
+
+// listNotesWithConfig lists notes on a bead (for testing).
+func listNotesWithConfig(ctx context.Context, cfg *config.Config, beadID string, limit int) ([]client.BeadNote, error) {
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	resp, err := c.ListBeadNotes(ctx, &client.ListBeadNotesRequest{BeadID: beadID, Limit: limit})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	return resp.Notes, nil
+}
+
+// fetchBeadNotes returns recent notes for a bead. Callers treat errors as
+// best-effort: the notes are context, never a reason to fail the command.
+func fetchBeadNotes(ctx context.Context, cfg *config.Config, beadID string) ([]client.BeadNote, error) {
+	if beadID == "" {
+		return nil, nil
+	}
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	resp, err := c.ListBeadNotes(ctx, &client.ListBeadNotesRequest{BeadID: beadID, Limit: passthroughNotesLimit})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Notes, nil
+}
+
+func formatNoteListOutput(beadID string, notes []client.BeadNote, asJSON bool) string {
+	if asJSON {
+		output := struct {
+			BeadID string            `json:"bead_id"`
+			Notes  []client.BeadNote `json:"notes"`
+			Count  int               `json:"count"`
+		}{
+			BeadID: beadID,
+			Notes:  notes,
+			Count:  len(notes),
+		}
+		if output.Notes == nil {
+			output.Notes = []client.BeadNote{}
+		}
+		return marshalJSONOrFallback(output)
+	}
+
+	if len(notes) == 0 {
+		return fmt.Sprintf("No notes on %s.\n", beadID)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("NOTES on %s:\n", beadID))
+	sb.WriteString(formatNoteLines(notes, "  "))
+	return sb.String()
+}
+
+// formatNoteLines renders notes as "- alias (age): body" lines.
+func formatNoteLines(notes []client.BeadNote, indent string) string {
+	var sb strings.Builder
+	for _, n := range notes {
+		author := n.Alias
+		if author == "" {
+			author = "unknown"
+		}
+		body := strings.ReplaceAll(strings.TrimSpace(n.Body), "\n", " ")
+		if n.CreatedAt != "" {
+			sb.WriteString(fmt.Sprintf("%s- %s (%s): %s\n", indent, author, formatTimestamp(n.CreatedAt), body))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s- %s: %s\n", indent, author, body))
+		}
+	}
+	return sb.String()
+}
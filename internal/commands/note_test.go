@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestAddNote_PostsToBead(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+
+	var gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		json.NewEncoder(w).Encode(map[string]any{
+			"note_id": "n-1",
+			"bead_id": "bd-42",
+			"alias":   "alice",
+			"body":    gotBody["body"],
+		})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:  server.URL,
+		RepoID:      "c3d4e5f6-7890-12cd-ef01-345678901234",
+		Alias:       "alice",
+	}
+
+	note, err := addNoteWithConfig(context.Background(), cfg, "bd-42", "  found root cause in auth.py  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/v1/beads/bd-42/notes" {
+		t.Errorf("unexpected path %q", gotPath)
+	}
+	if gotBody["body"] != "found root cause in auth.py" || gotBody["alias"] != "alice" {
+		t.Errorf("unexpected request body: %v", gotBody)
+	}
+	if note.NoteID != "n-1" {
+		t.Errorf("unexpected note: %+v", note)
+	}
+}
+
+func TestAddNote_RejectsEmptyAndOversized(t *testing.T) {
+	cfg := &config.Config{BeadhubURL: "http://localhost:1"}
+	if _, err := addNoteWithConfig(context.Background(), cfg, "bd-42", "   "); err == nil {
+		t.Error("expected error for empty note")
+	}
+	if _, err := addNoteWithConfig(context.Background(), cfg, "bd-42", strings.Repeat("x", maxNoteLength+1)); err == nil {
+		t.Error("expected error for oversized note")
+	}
+}
+
+func TestFormatNoteListOutput(t *testing.T) {
+	notes := []client.BeadNote{
+		{Alias: "bob", Body: "flaky on CI\nonly with -race"},
+	}
+	output := formatNoteListOutput("bd-42", notes, false)
+	if !strings.Contains(output, "- bob: flaky on CI only with -race") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+	if got := formatNoteListOutput("bd-42", nil, false); !strings.Contains(got, "No notes on bd-42") {
+		t.Errorf("unexpected empty output: %q", got)
+	}
+	if got := formatNoteListOutput("bd-42", nil, true); !strings.Contains(got, `"notes": []`) {
+		t.Errorf("expected empty JSON array, got: %s", got)
+	}
+}
+
+func TestPassthrough_RejectionShowsBeadNotes(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	os.MkdirAll(".beads", 0755)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/bdh/command":
+			json.NewEncoder(w).Encode(map[string]any{
+				"approved": false,
+				"reason":   "bd-42 is being worked on by bob (Maria)",
+			})
+		case "/v1/beads/bd-42/notes":
+			json.NewEncoder(w).Encode(map[string]any{
+				"notes": []map[string]any{{"bead_id": "bd-42", "alias": "bob", "body": "root cause is in auth.py"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	cfg.Save()
+
+	result, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--status", "in_progress"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Rejected {
+		t.Fatal("expected rejection")
+	}
+	if len(result.BeadNotes) != 1 {
+		t.Fatalf("expected 1 note, got %+v", result.BeadNotes)
+	}
+
+	output := formatPassthroughOutput(result)
+	if !strings.Contains(output, "Notes on bd-42:") || !strings.Contains(output, "bob: root cause is in auth.py") {
+		t.Errorf("expected notes in rejection output, got:\n%s", output)
+	}
+}
+
+func TestPassthrough_RejectionShowsBeadAttachments(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	os.MkdirAll(".beads", 0755)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/bdh/command":
+			json.NewEncoder(w).Encode(map[string]any{
+				"approved": false,
+				"reason":   "bd-42 is being worked on by bob (Maria)",
+			})
+		case "/v1/beads/bd-42/attachments":
+			json.NewEncoder(w).Encode(map[string]any{
+				"attachments": []map[string]any{{"bead_id": "bd-42", "alias": "bob", "kind": "path", "name": "design.md", "ref": "docs/design.md"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	cfg.Save()
+
+	result, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--status", "in_progress"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.BeadAttachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %+v", result.BeadAttachments)
+	}
+
+	output := formatPassthroughOutput(result)
+	if !strings.Contains(output, "Attachments on bd-42:") || !strings.Contains(output, "design.md (docs/design.md) by bob") {
+		t.Errorf("expected attachments in rejection output, got:\n%s", output)
+	}
+}
@@ -2,22 +2,33 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	aweb "github.com/awebai/aw"
 	"github.com/beadhub/bdh/internal/client"
 	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/i18n"
 )
 
 // NotificationContext contains all notification data fetched from BeadHub.
 type NotificationContext struct {
 	PendingConversations []PendingConversation
 	MessagesWaiting      int
+	UrgentMail           []UrgentMail
+	InlineMail           []InlineMail
 	GoneWorkspaces       []GoneWorkspace
+	Escalations          []EscalationNotice
+	ScheduledMail        []ScheduledMailNotice
+	MailReceipts         []MailReceiptNotice
+	AutoReplies          []AwayAutoReply
+	QuietHoursSummary    *QuietHoursSummary
 	CurrentAlias         string
 	Warning              string
 }
@@ -41,6 +52,7 @@ var (
 	excludeChatAlias          string
 	coordinationAlias         string // Agent alias for the header (empty = not set)
 	coordinationHeaderPrinted bool   // True after header has been output
+	notificationsSuppressed   bool   // Skip notifications for this command
 )
 
 // SetExcludeChatAlias sets an alias to exclude from chat notifications.
@@ -51,6 +63,14 @@ func SetExcludeChatAlias(alias string) {
 	notificationsMu.Unlock()
 }
 
+// SuppressNotifications skips the end-of-command notifications for this
+// command. Used by git hooks, which must stay quiet and fast.
+func SuppressNotifications() {
+	notificationsMu.Lock()
+	notificationsSuppressed = true
+	notificationsMu.Unlock()
+}
+
 // SetCoordinationHeaderAlias enables the coordination header for this command.
 // Call this early in command execution, before any coordination output.
 func SetCoordinationHeaderAlias(alias string) {
@@ -70,7 +90,7 @@ func FormatCoordinationHeader() string {
 		return ""
 	}
 
-	header := fmt.Sprintf("\n# Coordination Info for %s (you, the agent)\n", coordinationAlias)
+	header := "\n" + i18n.T("coordination.header", coordinationAlias) + "\n"
 	coordinationHeaderPrinted = true
 	return header
 }
@@ -83,74 +103,117 @@ func ResetCoordinationHeader() {
 	notificationsMu.Unlock()
 }
 
-// FetchNotifications retrieves all notification data from BeadHub.
-func FetchNotifications(cfg *config.Config) *NotificationContext {
-	ctx := &NotificationContext{
+// FetchNotifications retrieves all notification data from BeadHub. The
+// calls run concurrently under one shared deadline, and the first that
+// cannot reach the server cancels the rest rather than letting each of them
+// wait out its own timeout.
+func FetchNotifications(ctx context.Context, cfg *config.Config) *NotificationContext {
+	nc := &NotificationContext{
 		CurrentAlias: cfg.Alias,
 	}
 
-	c := newBeadHubClient(cfg.BeadhubURL)
-	aw, _ := newAwebClient(cfg.BeadhubURL)
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	aw, _ := newAwebClient(ctx, cfg.BeadhubURL)
+
+	fetchCtx, cancel := withCancelOnUnreachable(ctx, apiTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	fetch := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
 
 	// Refresh presence
-	refreshPresenceHeartbeat(cfg)
+	fetch(func() { refreshPresenceHeartbeat(fetchCtx, cfg) })
 
-	// Fetch pending chats (best-effort)
 	if aw != nil {
-		pendingCtx, pendingCancel := context.WithTimeout(context.Background(), apiTimeout)
-		pendingResp, err := aw.ChatPending(pendingCtx)
-		pendingCancel()
-		if err != nil {
-			ctx.Warning = fmt.Sprintf("Could not check chat notifications: %v", err)
-		} else {
-			ctx.PendingConversations = make([]PendingConversation, 0, len(pendingResp.Pending))
-			for _, p := range pendingResp.Pending {
-				ctx.PendingConversations = append(ctx.PendingConversations, PendingConversation{
-					SessionID:     p.SessionID,
-					Participants:  p.Participants,
-					LastMessage:   p.LastMessage,
-					LastFrom:      p.LastFrom,
-					UnreadCount:   p.UnreadCount,
-					LastActivity:  p.LastActivity,
-					SenderWaiting: p.SenderWaiting,
-				})
+		// Fetch pending chats (best-effort)
+		fetch(func() {
+			pendingResp, err := aw.ChatPending(fetchCtx)
+			if err != nil {
+				unreachable(fetchCtx, err)
+				nc.Warning = fmt.Sprintf("Could not check chat notifications: %v", err)
+				return
 			}
-		}
+			nc.PendingConversations = pendingConversationsFrom(pendingResp)
+			nc.AutoReplies = autoReplyWhileAway(fetchCtx, cfg, aw, nc.PendingConversations, time.Now())
+		})
 
 		// Fetch unread mail count (best-effort).
-		mailCtx, mailCancel := context.WithTimeout(context.Background(), apiTimeout)
-		inboxResp, mailErr := aw.Inbox(mailCtx, aweb.InboxParams{
-			UnreadOnly: true,
-			Limit:      500,
+		fetch(func() {
+			inboxResp, err := aw.Inbox(fetchCtx, aweb.InboxParams{
+				UnreadOnly: true,
+				Limit:      500,
+			})
+			if err != nil {
+				unreachable(fetchCtx, err)
+				return
+			}
+			nc.MessagesWaiting = len(inboxResp.Messages)
+			nc.UrgentMail = urgentMailFrom(inboxResp.Messages)
+			nc.InlineMail = inlineMailFrom(inboxResp.Messages, cfg.AutoAckDisplayedEnabled())
 		})
-		mailCancel()
-		if mailErr == nil && inboxResp != nil {
-			ctx.MessagesWaiting = len(inboxResp.Messages)
-		}
+
+		// Deliver mail queued locally with --send-at/--delay (best-effort).
+		fetch(func() { nc.ScheduledMail = deliverDueScheduledMail(fetchCtx, aw, time.Now()) })
+
+		// Report read receipts and remind recipients of unread --nag-after mail
+		fetch(func() { nc.MailReceipts = checkMailReceipts(fetchCtx, c, aw, time.Now()) })
 	}
 
 	// Detect and clean gone workspaces
-	ctx.GoneWorkspaces = detectGoneWorkspaces(cfg, c)
+	fetch(func() { nc.GoneWorkspaces = detectGoneWorkspaces(fetchCtx, cfg, c) })
 
-	return ctx
+	// Poll escalations created from this workspace (answered, or overdue)
+	fetch(func() { nc.Escalations = checkTrackedEscalations(fetchCtx, cfg, c, time.Now()) })
+
+	wg.Wait()
+	return nc
+}
+
+type cancelOnUnreachableKey struct{}
+
+// withCancelOnUnreachable returns a context with the given deadline that
+// unreachable cancels, so one failed connection stops the sibling calls.
+func withCancelOnUnreachable(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	return context.WithValue(ctx, cancelOnUnreachableKey{}, cancel), cancel
+}
+
+// unreachable reports whether err means the server could not be reached at
+// all, as opposed to answering with an error. Under withCancelOnUnreachable
+// it then cancels ctx.
+func unreachable(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+		return false
+	}
+	if cancel, ok := ctx.Value(cancelOnUnreachableKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+	return true
 }
 
 // detectGoneWorkspaces checks for workspaces on this hostname whose paths no longer exist.
-func detectGoneWorkspaces(cfg *config.Config, c *client.Client) []GoneWorkspace {
+func detectGoneWorkspaces(ctx context.Context, cfg *config.Config, c BeadHubAPI) []GoneWorkspace {
 	hostname, err := os.Hostname()
 	if err != nil || hostname == "" {
 		return nil
 	}
 
-	listCtx, listCancel := context.WithTimeout(context.Background(), apiTimeout)
-	defer listCancel()
-
 	includePresence := false
-	resp, err := c.Workspaces(listCtx, &client.WorkspacesRequest{
+	resp, err := c.Workspaces(ctx, &client.WorkspacesRequest{
 		Hostname:        hostname,
 		IncludePresence: &includePresence,
 	})
 	if err != nil {
+		unreachable(ctx, err)
 		return nil
 	}
 
@@ -160,9 +223,10 @@ func detectGoneWorkspaces(cfg *config.Config, c *client.Client) []GoneWorkspace
 			continue
 		}
 		if _, err := os.Stat(ws.WorkspacePath); os.IsNotExist(err) {
-			deleteCtx, deleteCancel := context.WithTimeout(context.Background(), apiTimeout)
-			_, deleteErr := c.DeleteWorkspace(deleteCtx, ws.WorkspaceID)
-			deleteCancel()
+			_, deleteErr := c.DeleteWorkspace(ctx, ws.WorkspaceID)
+			if unreachable(ctx, deleteErr) {
+				break
+			}
 			if deleteErr == nil {
 				gone = append(gone, GoneWorkspace{
 					WorkspaceID:   ws.WorkspaceID,
@@ -200,6 +264,18 @@ func FormatNotifications(ctx *NotificationContext, excludeAlias string) string {
 
 	var lines []string
 
+	// QUIET HOURS: what was held during a window that just ended
+	if ctx.QuietHoursSummary != nil {
+		if line := formatQuietHoursSummary(ctx.QuietHoursSummary); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	// ESCALATIONS: answered, or pending past the SLA
+	for _, n := range ctx.Escalations {
+		lines = append(lines, formatEscalationNotice(n))
+	}
+
 	// URGENT: sender actively waiting for response
 	seen := make(map[string]struct{})
 	for _, conv := range ctx.PendingConversations {
@@ -217,6 +293,10 @@ func FormatNotifications(ctx *NotificationContext, excludeAlias string) string {
 		lines = append(lines, fmt.Sprintf("- **URGENT**: %s is waiting for your response\n  → Respond now: `bdh :aweb chat send %s \"your reply\"`", alias, alias))
 	}
 
+	for _, r := range ctx.AutoReplies {
+		lines = append(lines, formatAwayAutoReply(r))
+	}
+
 	// CHATS: non-waiting with unread
 	nonWaiting := 0
 	for _, conv := range ctx.PendingConversations {
@@ -231,11 +311,24 @@ func FormatNotifications(ctx *NotificationContext, excludeAlias string) string {
 	}
 
 	// MAIL
-	if ctx.MessagesWaiting > 0 {
-		if ctx.MessagesWaiting == 1 {
-			lines = append(lines, "- **MAIL**: You have 1 unread message\n  → Check: `bdh :aweb mail list`")
+	for _, n := range ctx.ScheduledMail {
+		lines = append(lines, formatScheduledMailNotice(n))
+	}
+	for _, n := range ctx.MailReceipts {
+		lines = append(lines, formatMailReceiptNotice(n))
+	}
+	for _, m := range ctx.InlineMail {
+		lines = append(lines, formatInlineMail(m))
+	}
+	if waiting := ctx.MessagesWaiting - len(ctx.InlineMail); waiting > 0 {
+		more := ""
+		if len(ctx.InlineMail) > 0 {
+			more = " more"
+		}
+		if waiting == 1 {
+			lines = append(lines, fmt.Sprintf("- **MAIL**: You have 1%s unread message\n  → Check: `bdh :aweb mail list`", more))
 		} else {
-			lines = append(lines, fmt.Sprintf("- **MAIL**: You have %d unread messages\n  → Check: `bdh :aweb mail list`", ctx.MessagesWaiting))
+			lines = append(lines, fmt.Sprintf("- **MAIL**: You have %d%s unread messages\n  → Check: `bdh :aweb mail list`", waiting, more))
 		}
 	}
 
@@ -268,12 +361,19 @@ func FormatGoneWorkspaces(gone []GoneWorkspace) string {
 
 // PrintNotifications fetches and prints notifications.
 // This is the single entry point called by main.go at the end of every command.
-func PrintNotifications(w io.Writer) {
+func PrintNotifications(ctx context.Context, w io.Writer) {
 	notificationsMu.Lock()
 	exclude := excludeChatAlias
 	excludeChatAlias = ""
+	suppressed := notificationsSuppressed
+	notificationsSuppressed = false
 	notificationsMu.Unlock()
 
+	if suppressed {
+		ResetCoordinationHeader()
+		return
+	}
+
 	// Load config - if not initialized, silently skip
 	cfg, err := config.Load()
 	if err != nil {
@@ -285,15 +385,19 @@ func PrintNotifications(w io.Writer) {
 		return
 	}
 
-	ctx := FetchNotifications(cfg)
+	nc := FetchNotifications(ctx, cfg)
+	applyQuietHours(cfg, nc, time.Now())
+	if cfg.DesktopNotificationsEnabled() {
+		notifyDesktop(ctx, nc, exclude)
+	}
 
 	// Print gone workspaces
-	if gone := FormatGoneWorkspaces(ctx.GoneWorkspaces); gone != "" {
+	if gone := FormatGoneWorkspaces(nc.GoneWorkspaces); gone != "" {
 		_, _ = io.WriteString(w, gone)
 	}
 
 	// Print notifications with coordination header
-	if out := FormatNotifications(ctx, exclude); out != "" {
+	if out := FormatNotifications(nc, exclude); out != "" {
 		// Commands without coordination sections don't set up the header.
 		// Set it up now so notifications print under the coordination header.
 		notificationsMu.Lock()
@@ -308,7 +412,31 @@ func PrintNotifications(w io.Writer) {
 			_, _ = io.WriteString(w, header)
 		}
 		_, _ = io.WriteString(w, out)
+
+		// The inline mail was just shown in full; don't show it again.
+		if cfg.AutoAckDisplayedEnabled() && len(nc.InlineMail) > 0 {
+			if aw, err := newAwebClient(ctx, cfg.BeadhubURL); err == nil && aw != nil {
+				autoAckDisplayedMail(ctx, aw, nc.InlineMail)
+			}
+		}
 	}
 
 	ResetCoordinationHeader()
 }
+
+// pendingConversationsFrom converts the aweb pending-chat response.
+func pendingConversationsFrom(resp *aweb.ChatPendingResponse) []PendingConversation {
+	out := make([]PendingConversation, 0, len(resp.Pending))
+	for _, p := range resp.Pending {
+		out = append(out, PendingConversation{
+			SessionID:     p.SessionID,
+			Participants:  p.Participants,
+			LastMessage:   p.LastMessage,
+			LastFrom:      p.LastFrom,
+			UnreadCount:   p.UnreadCount,
+			LastActivity:  p.LastActivity,
+			SenderWaiting: p.SenderWaiting,
+		})
+	}
+	return out
+}
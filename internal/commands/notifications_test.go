@@ -1,8 +1,19 @@
 package commands
 
 import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
 )
 
 func TestFormatNotifications_ShowsWaiting(t *testing.T) {
@@ -121,3 +132,47 @@ func TestSetExcludeChatAlias(t *testing.T) {
 		t.Errorf("expected excludeChatAlias to be 'alice', got: %q", got)
 	}
 }
+
+func TestUnreachable_CancelsSiblingCalls(t *testing.T) {
+	ctx, cancel := withCancelOnUnreachable(context.Background(), time.Minute)
+	defer cancel()
+
+	if unreachable(ctx, &client.Error{StatusCode: 500}) || ctx.Err() != nil {
+		t.Fatal("a server that answered is not unreachable")
+	}
+	if !unreachable(ctx, &url.Error{Op: "Get", URL: "http://beadhub.invalid", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}) {
+		t.Fatal("a refused connection is unreachable")
+	}
+	if ctx.Err() == nil {
+		t.Error("the shared context should be cancelled after the first connection failure")
+	}
+}
+
+// unreachableAwebStub fails ChatPending as if the server were down; Inbox
+// waits until its context is cancelled.
+type unreachableAwebStub struct {
+	AwebAPI
+}
+
+func (unreachableAwebStub) ChatPending(ctx context.Context) (*aweb.ChatPendingResponse, error) {
+	return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+}
+
+func (unreachableAwebStub) Inbox(ctx context.Context, p aweb.InboxParams) (*aweb.InboxResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestFetchNotifications_StopsAfterConnectionFailure(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx := withAPIs(context.Background(), clienttest.New(), unreachableAwebStub{})
+
+	start := time.Now()
+	nc := FetchNotifications(ctx, &config.Config{WorkspaceID: "ws-me", Alias: "me", BeadhubURL: "http://beadhub.invalid"})
+	if elapsed := time.Since(start); elapsed > apiTimeout/2 {
+		t.Errorf("the inbox call should have been cancelled, took %s", elapsed)
+	}
+	if !strings.Contains(nc.Warning, "connection refused") {
+		t.Errorf("warning = %q", nc.Warning)
+	}
+}
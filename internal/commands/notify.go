@@ -32,6 +32,7 @@ Example hook configuration in .claude/settings.json:
 }
 
 func runNotify(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	cfg, err := config.Load()
 	if err != nil {
 		// Not initialized - silent exit
@@ -41,12 +42,12 @@ func runNotify(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	aw, err := newAwebClient(cfg.BeadhubURL)
+	aw, err := newAwebChatClient(ctx, cfg.BeadhubURL)
 	if err != nil || aw == nil {
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), apiTimeout)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	result, err := chat.Pending(ctx, aw)
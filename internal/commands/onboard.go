@@ -0,0 +1,392 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// onboardSandboxTitle marks the bead the tutorial creates so it's obvious it can be deleted.
+const onboardSandboxTitle = "bdh onboarding sandbox (safe to delete)"
+
+var (
+	onboardResume  bool
+	onboardRestart bool
+	onboardYes     bool
+)
+
+var onboardCmd = &cobra.Command{
+	Use:   ":onboard",
+	Short: "Guided first-run tutorial using a sandbox bead",
+	Long: `Walk through the coordination workflow on a throwaway bead.
+
+Steps: verify setup, create a sandbox bead, claim it, reserve a file,
+mail yourself, close it, sync, and clean up. Each step runs the real
+command and explains the coordination output it produces.
+
+Progress is checkpointed to .beadhub-cache/onboard.json. If you stop
+part way, run 'bdh :onboard --resume' to see where you are and continue.
+
+Examples:
+  bdh :onboard
+  bdh :onboard --resume
+  bdh :onboard --yes       # don't pause between steps`,
+	Args: cobra.NoArgs,
+	RunE: runOnboard,
+}
+
+func init() {
+	onboardCmd.Flags().BoolVar(&onboardResume, "resume", false, "Continue from the last checkpoint")
+	onboardCmd.Flags().BoolVar(&onboardRestart, "restart", false, "Discard the checkpoint and start over")
+	onboardCmd.Flags().BoolVar(&onboardYes, "yes", false, "Run all steps without pausing")
+}
+
+// OnboardState is the tutorial checkpoint.
+type OnboardState struct {
+	BeadID    string   `json:"bead_id,omitempty"`
+	Completed []string `json:"completed"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+func (s *OnboardState) done(step string) bool {
+	for _, name := range s.Completed {
+		if name == step {
+			return true
+		}
+	}
+	return false
+}
+
+// onboardStep is one stage of the tutorial. Run returns text to show the user.
+type onboardStep struct {
+	Name    string
+	Explain string
+	Run     func(state *OnboardState) (string, error)
+}
+
+func runOnboard(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+
+	statePath, err := onboardStatePath()
+	if err != nil {
+		return err
+	}
+	state, err := loadOnboardState(statePath)
+	if err != nil {
+		return err
+	}
+
+	if onboardRestart && state != nil {
+		if state.BeadID != "" {
+			fmt.Printf("Discarding checkpoint. Sandbox bead %s may still exist - delete it with 'bdh delete %s --force'.\n", state.BeadID, state.BeadID)
+		}
+		_ = os.Remove(statePath)
+		state = nil
+	}
+	if state != nil && !onboardResume {
+		return fmt.Errorf("onboarding already in progress (%d step(s) done) - run 'bdh :onboard --resume' to continue or --restart to start over", len(state.Completed))
+	}
+	if state == nil {
+		if onboardResume {
+			fmt.Println("No onboarding checkpoint found - starting from the beginning.")
+		}
+		state = &OnboardState{}
+	}
+
+	pause := func(onboardStep) bool { return true }
+	if !onboardYes && isTTY() {
+		in := bufio.NewReader(os.Stdin)
+		pause = func(step onboardStep) bool {
+			fmt.Print("Press Enter to run this step (q to stop): ")
+			line, err := readMenuLine(in)
+			return err == nil && strings.ToLower(line) != "q"
+		}
+	}
+
+	return runOnboardSteps(onboardSteps(ctx, cfg), state, statePath, os.Stdout, pause)
+}
+
+// runOnboardSteps runs the steps not yet completed in state, saving a checkpoint
+// after each. pause is called before each step; returning false stops the tutorial.
+func runOnboardSteps(steps []onboardStep, state *OnboardState, statePath string, out io.Writer, pause func(onboardStep) bool) error {
+	if len(state.Completed) > 0 {
+		fmt.Fprintln(out, formatOnboardProgress(steps, state))
+	}
+
+	for i, step := range steps {
+		if state.done(step.Name) {
+			continue
+		}
+		fmt.Fprintf(out, "\n## Step %d/%d: %s\n%s\n", i+1, len(steps), step.Name, step.Explain)
+		if !pause(step) {
+			fmt.Fprintln(out, "Stopped. Run 'bdh :onboard --resume' to continue.")
+			return nil
+		}
+
+		text, err := step.Run(state)
+		if text != "" {
+			fmt.Fprint(out, text)
+			if !strings.HasSuffix(text, "\n") {
+				fmt.Fprintln(out)
+			}
+		}
+		if err != nil {
+			if saveErr := saveOnboardState(statePath, state); saveErr != nil {
+				fmt.Fprintf(out, "Warning: could not save checkpoint: %v\n", saveErr)
+			}
+			return fmt.Errorf("onboarding step %q failed: %w (fix it, then run 'bdh :onboard --resume')", step.Name, err)
+		}
+
+		state.Completed = append(state.Completed, step.Name)
+		if i == len(steps)-1 {
+			_ = os.Remove(statePath)
+			break
+		}
+		if err := saveOnboardState(statePath, state); err != nil {
+			return fmt.Errorf("saving onboarding checkpoint: %w", err)
+		}
+	}
+
+	fmt.Fprintln(out, "\nOnboarding complete. You're ready to coordinate with 'bdh ready', 'bdh update <id> --status in_progress' and 'bdh :status'.")
+	return nil
+}
+
+// formatOnboardProgress lists which steps are done, for --resume.
+func formatOnboardProgress(steps []onboardStep, state *OnboardState) string {
+	var sb strings.Builder
+	sb.WriteString("Onboarding checkpoint")
+	if state.UpdatedAt != "" {
+		sb.WriteString(fmt.Sprintf(" (saved %s)", state.UpdatedAt))
+	}
+	sb.WriteString(":\n")
+	for _, step := range steps {
+		mark := " "
+		if state.done(step.Name) {
+			mark = "x"
+		}
+		sb.WriteString(fmt.Sprintf("  [%s] %s\n", mark, step.Name))
+	}
+	if state.BeadID != "" {
+		sb.WriteString(fmt.Sprintf("Sandbox bead: %s\n", state.BeadID))
+	}
+	return sb.String()
+}
+
+func onboardSteps(ctx context.Context, cfg *config.Config) []onboardStep {
+	return []onboardStep{
+		{
+			Name:    "verify",
+			Explain: "Check that bd is installed and the BeadHub server is reachable with your credentials.",
+			Run: func(state *OnboardState) (string, error) {
+				return onboardVerify(ctx, cfg)
+			},
+		},
+		{
+			Name:    "create",
+			Explain: "Create a sandbox bead. bdh passes the command to bd, then syncs the change to BeadHub.",
+			Run: func(state *OnboardState) (string, error) {
+				if state.BeadID != "" {
+					return fmt.Sprintf("Sandbox bead %s already exists.\n", state.BeadID), nil
+				}
+				result, err := runPassthrough(ctx, []string{"create", onboardSandboxTitle, "--type", "task", "--json"})
+				if err != nil {
+					return "", err
+				}
+				id, err := parseCreatedBeadID(result.Stdout)
+				if err != nil {
+					return "", err
+				}
+				state.BeadID = id
+				return fmt.Sprintf("Created %s. Every bdh mutation syncs issues to BeadHub so teammates see it.\n", id), nil
+			},
+		},
+		{
+			Name:    "claim",
+			Explain: "Claim the bead by setting it in_progress. BeadHub rejects claims on beads someone else holds.",
+			Run: func(state *OnboardState) (string, error) {
+				return onboardPassthrough(ctx, []string{"update", state.BeadID, "--status", "in_progress"})
+			},
+		},
+		{
+			Name:    "reserve",
+			Explain: "Reserve a path so other agents know you're editing it, then release it.",
+			Run: func(state *OnboardState) (string, error) {
+				return onboardReserve(ctx, state.BeadID)
+			},
+		},
+		{
+			Name:    "mail",
+			Explain: "Send yourself mail. Mail is async; use 'bdh :aweb chat send <alias> <msg>' when you need an answer now.",
+			Run: func(state *OnboardState) (string, error) {
+				body := fmt.Sprintf("[%s] onboarding test message - mail shows up in 'bdh :aweb mail list'", state.BeadID)
+				if err := sendMailToAliases(ctx, []string{cfg.Alias}, body); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("Mailed yourself (%s). Check it with 'bdh :aweb mail list'.\n", cfg.Alias), nil
+			},
+		},
+		{
+			Name:    "close",
+			Explain: "Close the bead. This releases your claim so the bead leaves the team's in-progress list.",
+			Run: func(state *OnboardState) (string, error) {
+				return onboardPassthrough(ctx, []string{"close", state.BeadID, "--reason", "onboarding complete"})
+			},
+		},
+		{
+			Name:    "sync",
+			Explain: "Verify that BeadHub has the same issues as your local issues.jsonl.",
+			Run: func(state *OnboardState) (string, error) {
+				result, err := verifySyncWithConfig(ctx, cfg)
+				if err != nil {
+					return "", err
+				}
+				text := formatVerifySyncOutput(result, false)
+				if !result.InSync {
+					text += "Drift is usually fixed by 'bdh :verify-sync --repair'.\n"
+				}
+				return text, nil
+			},
+		},
+		{
+			Name:    "cleanup",
+			Explain: "Delete the sandbox bead.",
+			Run: func(state *OnboardState) (string, error) {
+				if state.BeadID == "" {
+					return "", nil
+				}
+				text, err := onboardPassthrough(ctx, []string{"delete", state.BeadID, "--force"})
+				if err != nil {
+					return text, err
+				}
+				return text + fmt.Sprintf("Deleted %s.\n", state.BeadID), nil
+			},
+		},
+	}
+}
+
+func onboardVerify(ctx context.Context, cfg *config.Config) (string, error) {
+	var sb strings.Builder
+	if bd.MockEnabled() {
+		sb.WriteString("  bd: mock (BDH_MOCK_BD)\n")
+	} else {
+		if err := checkBdInstalled(newBdRunner(cfg, nil)); err != nil {
+			return "", err
+		}
+		sb.WriteString("  bd: found\n")
+	}
+
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return sb.String(), err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if _, err := c.Status(ctx, &client.StatusRequest{WorkspaceID: cfg.WorkspaceID}); err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return sb.String(), fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		if client.IsPayloadError(err) {
+			return sb.String(), err
+		}
+		return sb.String(), fmt.Errorf("BeadHub unreachable at %s: %w", cfg.BeadhubURL, err)
+	}
+	sb.WriteString(fmt.Sprintf("  BeadHub: reachable at %s\n", cfg.BeadhubURL))
+	sb.WriteString(fmt.Sprintf("  Identity: %s in project %s\n", cfg.Alias, cfg.ProjectSlug))
+	return sb.String(), nil
+}
+
+// onboardPassthrough runs a bd command through bdh and renders its output.
+func onboardPassthrough(ctx context.Context, args []string) (string, error) {
+	result, err := runPassthrough(ctx, args)
+	if err != nil {
+		return "", err
+	}
+	text := formatPassthroughOutput(result)
+	if result.Rejected {
+		return text, fmt.Errorf("%s", result.RejectionReason)
+	}
+	if result.ExitCode != 0 {
+		return text, fmt.Errorf("bd %s exited %d", args[0], result.ExitCode)
+	}
+	return text, nil
+}
+
+func onboardReserve(ctx context.Context, beadID string) (string, error) {
+	identity, err := currentAgentIdentityForAweb()
+	if err != nil {
+		return "", err
+	}
+	aw, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
+	if err != nil {
+		return "", err
+	}
+	key := "onboarding/" + beadID
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	if _, err := aw.ReservationAcquire(ctx, &aweb.ReservationAcquireRequest{
+		ResourceKey: key,
+		TTLSeconds:  60,
+		Metadata:    map[string]any{"reason": "bdh onboarding"},
+	}); err != nil {
+		return "", fmt.Errorf("reserving %s: %w", key, err)
+	}
+	if _, err := aw.ReservationRelease(ctx, &aweb.ReservationReleaseRequest{ResourceKey: key}); err != nil {
+		return "", fmt.Errorf("releasing %s: %w", key, err)
+	}
+	return fmt.Sprintf("Reserved and released %s. For real work: 'bdh :aweb lock <path>' and 'bdh :aweb unlock <path>'.\n", key), nil
+}
+
+// parseCreatedBeadID extracts the new bead's ID from 'bd create --json' output.
+func parseCreatedBeadID(stdout string) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &created); err != nil || created.ID == "" {
+		return "", fmt.Errorf("could not read new bead ID from bd create output")
+	}
+	return created.ID, nil
+}
+
+func onboardStatePath() (string, error) {
+	return cacheFilePath("onboard.json")
+}
+
+// loadOnboardState returns nil if there is no checkpoint.
+func loadOnboardState(path string) (*OnboardState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading onboarding checkpoint: %w", err)
+	}
+	var state OnboardState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing onboarding checkpoint %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func saveOnboardState(path string, state *OnboardState) error {
+	state.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	return writeJSONAtomic(path, state)
+}
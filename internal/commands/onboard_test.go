@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fakeOnboardSteps(ran *[]string, failOn string) []onboardStep {
+	var steps []onboardStep
+	for _, name := range []string{"verify", "create", "claim", "cleanup"} {
+		name := name
+		steps = append(steps, onboardStep{
+			Name: name,
+			Run: func(state *OnboardState) (string, error) {
+				*ran = append(*ran, name)
+				if name == "create" {
+					state.BeadID = "bd-sandbox"
+				}
+				if name == failOn {
+					return "", errors.New("boom")
+				}
+				return "", nil
+			},
+		})
+	}
+	return steps
+}
+
+func TestRunOnboardSteps_CheckpointsAndResumes(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), ".beadhub-cache", "onboard.json")
+	always := func(onboardStep) bool { return true }
+
+	var ran []string
+	var out bytes.Buffer
+	err := runOnboardSteps(fakeOnboardSteps(&ran, "claim"), &OnboardState{}, statePath, &out, always)
+	if err == nil || !strings.Contains(err.Error(), `"claim" failed`) {
+		t.Fatalf("expected claim failure, got %v", err)
+	}
+
+	state, err := loadOnboardState(statePath)
+	if err != nil || state == nil {
+		t.Fatalf("expected checkpoint, got %v, %v", state, err)
+	}
+	if strings.Join(state.Completed, ",") != "verify,create" || state.BeadID != "bd-sandbox" {
+		t.Errorf("unexpected checkpoint: %+v", state)
+	}
+
+	ran = nil
+	out.Reset()
+	if err := runOnboardSteps(fakeOnboardSteps(&ran, ""), state, statePath, &out, always); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if strings.Join(ran, ",") != "claim,cleanup" {
+		t.Errorf("resume ran %v, want only the remaining steps", ran)
+	}
+	if !strings.Contains(out.String(), "[x] create") || !strings.Contains(out.String(), "[ ] claim") {
+		t.Errorf("expected progress summary on resume:\n%s", out.String())
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("checkpoint should be removed after the last step")
+	}
+}
+
+func TestRunOnboardSteps_StopKeepsCheckpoint(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), ".beadhub-cache", "onboard.json")
+	var ran []string
+	var out bytes.Buffer
+	pause := func(step onboardStep) bool { return step.Name != "claim" }
+
+	if err := runOnboardSteps(fakeOnboardSteps(&ran, ""), &OnboardState{}, statePath, &out, pause); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(ran, ",") != "verify,create" {
+		t.Errorf("ran %v", ran)
+	}
+	if !strings.Contains(out.String(), "--resume") {
+		t.Errorf("expected resume hint:\n%s", out.String())
+	}
+	state, _ := loadOnboardState(statePath)
+	if state == nil || len(state.Completed) != 2 {
+		t.Errorf("expected checkpoint with 2 steps, got %+v", state)
+	}
+}
+
+func TestParseCreatedBeadID(t *testing.T) {
+	id, err := parseCreatedBeadID(`{"id":"bd-12","title":"x"}` + "\n")
+	if err != nil || id != "bd-12" {
+		t.Errorf("got %q, %v", id, err)
+	}
+	if _, err := parseCreatedBeadID("Created bd-12"); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+}
@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :orphan-scan looks for work that has fallen through the cracks of a shared
+// backlog: beads marked in_progress that nobody has claimed on the server,
+// beads that belong to no epic, and open epics with nothing left open under
+// them.
+
+// Orphan kinds.
+const (
+	orphanUnclaimed = "unclaimed_in_progress"
+	orphanNoEpic    = "no_epic"
+	orphanEmptyEpic = "empty_epic"
+)
+
+// OrphanFinding is one bead that needs attention, with a suggested action.
+// Command is a bd invocation that fixes it, when one can be derived.
+type OrphanFinding struct {
+	Kind       string `json:"kind"`
+	IssueID    string `json:"issue_id"`
+	Title      string `json:"title,omitempty"`
+	Suggestion string `json:"suggestion"`
+	Command    string `json:"command,omitempty"`
+}
+
+// OrphanScanResult is the output of :orphan-scan.
+type OrphanScanResult struct {
+	Findings []OrphanFinding `json:"findings"`
+	// ClaimsChecked is false when the server could not be asked for claims,
+	// so unclaimed in_progress beads were not looked for.
+	ClaimsChecked bool   `json:"claims_checked"`
+	Warning       string `json:"warning,omitempty"`
+}
+
+var (
+	orphanScanJSON      bool
+	orphanScanEmitFixes bool
+)
+
+var orphanScanCmd = &cobra.Command{
+	Use:   ":orphan-scan",
+	Short: "Find unowned in-progress beads, beads outside epics, and empty epics",
+	Long: `Scan issues.jsonl for beads that need an owner or a home:
+
+  - in_progress beads that no workspace has claimed on the server
+  - open beads that are not attached to any epic
+  - open epics with no open children
+
+Each finding comes with a suggested action. With --emit-fixes, only the bd
+commands that fix the findings are printed, so they can be reviewed and run
+as a script; findings that need a decision (which epic a bead belongs to)
+are emitted as comments.
+
+Examples:
+  bdh :orphan-scan
+  bdh :orphan-scan --json
+  bdh :orphan-scan --emit-fixes > fixes.sh`,
+	Args: cobra.NoArgs,
+	RunE: runOrphanScan,
+}
+
+func init() {
+	orphanScanCmd.Flags().BoolVar(&orphanScanJSON, "json", false, "Output as JSON")
+	orphanScanCmd.Flags().BoolVar(&orphanScanEmitFixes, "emit-fixes", false, "Print bd commands that fix the findings")
+}
+
+func runOrphanScan(cmd *cobra.Command, args []string) error {
+	if orphanScanJSON && orphanScanEmitFixes {
+		return fmt.Errorf("--json and --emit-fixes are mutually exclusive")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+
+	issues, err := loadIssues()
+	if err != nil {
+		return fmt.Errorf("reading issues: %w", err)
+	}
+
+	result := &OrphanScanResult{}
+	claimed, err := fetchClaimedBeadIDs(cmd.Context(), cfg)
+	if err != nil {
+		result.Warning = fmt.Sprintf("could not fetch claims, skipping the in_progress check: %v", err)
+	} else {
+		result.ClaimsChecked = true
+	}
+	result.Findings = scanOrphans(issues, claimed)
+
+	if orphanScanEmitFixes {
+		fmt.Print(formatOrphanFixes(result))
+		return nil
+	}
+	fmt.Print(formatOrphanScanOutput(result, orphanScanJSON))
+	return nil
+}
+
+// fetchClaimedBeadIDs returns every bead claimed by any workspace in the project.
+func fetchClaimedBeadIDs(ctx context.Context, cfg *config.Config) (map[string]bool, error) {
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	includeClaims := true
+	onlyWithClaims := true
+	resp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+		IncludeClaims:  &includeClaims,
+		OnlyWithClaims: &onlyWithClaims,
+		Limit:          maxWorkspaceQueryLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	claimed := make(map[string]bool)
+	for _, ws := range resp.Workspaces {
+		for _, claim := range ws.Claims {
+			claimed[claim.BeadID] = true
+		}
+	}
+	return claimed, nil
+}
+
+// scanOrphans finds orphaned beads. claimed is nil when claims are unknown,
+// which skips the in_progress check. Closed beads are never reported.
+func scanOrphans(issues []Issue, claimed map[string]bool) []OrphanFinding {
+	byID := make(map[string]*Issue, len(issues))
+	for i := range issues {
+		if _, ok := byID[issues[i].ID]; !ok {
+			byID[issues[i].ID] = &issues[i]
+		}
+	}
+
+	// children maps each parent to its children, via parent-child dependencies.
+	children := make(map[string][]string)
+	hasParent := make(map[string]bool)
+	for _, id := range sortedKeys(byID) {
+		for _, dep := range byID[id].Dependencies {
+			if dep.Type != "parent-child" || dep.DependsOnID == "" {
+				continue
+			}
+			if _, ok := byID[dep.DependsOnID]; !ok {
+				continue
+			}
+			children[dep.DependsOnID] = append(children[dep.DependsOnID], id)
+			hasParent[id] = true
+		}
+	}
+
+	var findings []OrphanFinding
+	for _, id := range sortedKeys(byID) {
+		issue := byID[id]
+		if issue.Status == "closed" {
+			continue
+		}
+
+		if claimed != nil && issue.Status == "in_progress" && !claimed[id] {
+			findings = append(findings, OrphanFinding{
+				Kind:       orphanUnclaimed,
+				IssueID:    id,
+				Title:      issue.Title,
+				Suggestion: "in_progress but nobody holds a claim; claim it or put it back in the backlog",
+				Command:    fmt.Sprintf("bd update %s --status open", id),
+			})
+		}
+
+		if issue.IssueType == "epic" {
+			open := 0
+			for _, child := range children[id] {
+				if byID[child].Status != "closed" {
+					open++
+				}
+			}
+			if open == 0 {
+				f := OrphanFinding{Kind: orphanEmptyEpic, IssueID: id, Title: issue.Title}
+				if len(children[id]) > 0 {
+					f.Suggestion = fmt.Sprintf("all %d children are closed; close the epic", len(children[id]))
+					f.Command = fmt.Sprintf("bd close %s --reason %s", id, shellQuoteArg("all children closed"))
+				} else {
+					f.Suggestion = "has no children; add work under it or close it"
+				}
+				findings = append(findings, f)
+			}
+			continue
+		}
+
+		if !hasParent[id] {
+			findings = append(findings, OrphanFinding{
+				Kind:       orphanNoEpic,
+				IssueID:    id,
+				Title:      issue.Title,
+				Suggestion: fmt.Sprintf("not under any epic; attach it with: bd dep add %s <epic-id> --type parent-child", id),
+			})
+		}
+	}
+	return findings
+}
+
+var orphanKindHeadings = []struct {
+	kind    string
+	heading string
+}{
+	{orphanUnclaimed, "In progress without a claim"},
+	{orphanEmptyEpic, "Epics with no open children"},
+	{orphanNoEpic, "Not under any epic"},
+}
+
+func formatOrphanScanOutput(result *OrphanScanResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n\n", result.Warning))
+	}
+	if len(result.Findings) == 0 {
+		sb.WriteString("No orphaned beads found.\n")
+		return sb.String()
+	}
+
+	byKind := make(map[string][]OrphanFinding)
+	for _, f := range result.Findings {
+		byKind[f.Kind] = append(byKind[f.Kind], f)
+	}
+	for _, k := range orphanKindHeadings {
+		findings := byKind[k.kind]
+		if len(findings) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s (%d):\n", k.heading, len(findings)))
+		for _, f := range findings {
+			sb.WriteString(fmt.Sprintf("  %s", f.IssueID))
+			if f.Title != "" {
+				sb.WriteString(fmt.Sprintf(" \"%s\"", f.Title))
+			}
+			sb.WriteString(fmt.Sprintf(" — %s\n", f.Suggestion))
+			if f.Command != "" {
+				sb.WriteString(fmt.Sprintf("    fix: %s\n", f.Command))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("Run with --emit-fixes to print the fix commands as a script.\n")
+	return sb.String()
+}
+
+// formatOrphanFixes prints the fix commands, and findings without one as
+// comments, in a form that can be saved and run as a shell script.
+func formatOrphanFixes(result *OrphanScanResult) string {
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString(fmt.Sprintf("# Warning: %s\n", result.Warning))
+	}
+	findings := append([]OrphanFinding(nil), result.Findings...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		return (findings[i].Command != "") && (findings[j].Command == "")
+	})
+	for _, f := range findings {
+		if f.Command != "" {
+			sb.WriteString(f.Command + "\n")
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("# %s: %s\n", f.IssueID, f.Suggestion))
+	}
+	return sb.String()
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func orphanTestIssues() []Issue {
+	child := func(id, status, parent string) Issue {
+		return Issue{ID: id, Title: id, Status: status, IssueType: "task",
+			Dependencies: []Dependency{{IssueID: id, DependsOnID: parent, Type: "parent-child"}}}
+	}
+	return []Issue{
+		{ID: "bd-e1", Title: "Auth", Status: "open", IssueType: "epic"},
+		child("bd-1", "in_progress", "bd-e1"),
+		child("bd-2", "in_progress", "bd-e1"),
+		{ID: "bd-e2", Title: "Done epic", Status: "open", IssueType: "epic"},
+		child("bd-3", "closed", "bd-e2"),
+		{ID: "bd-e3", Title: "Empty", Status: "open", IssueType: "epic"},
+		{ID: "bd-4", Title: "Stray", Status: "open", IssueType: "bug"},
+		{ID: "bd-5", Title: "Old stray", Status: "closed", IssueType: "bug"},
+	}
+}
+
+func TestScanOrphans(t *testing.T) {
+	findings := scanOrphans(orphanTestIssues(), map[string]bool{"bd-1": true})
+
+	got := make(map[string]string)
+	for _, f := range findings {
+		got[f.IssueID] = f.Kind
+	}
+	want := map[string]string{
+		"bd-2":  orphanUnclaimed,
+		"bd-e2": orphanEmptyEpic,
+		"bd-e3": orphanEmptyEpic,
+		"bd-4":  orphanNoEpic,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for id, kind := range want {
+		if got[id] != kind {
+			t.Errorf("%s: got %q, want %q", id, got[id], kind)
+		}
+	}
+
+	for _, f := range findings {
+		switch f.IssueID {
+		case "bd-2":
+			if f.Command != "bd update bd-2 --status open" {
+				t.Errorf("bd-2 fix: %q", f.Command)
+			}
+		case "bd-e2":
+			if f.Command != "bd close bd-e2 --reason 'all children closed'" {
+				t.Errorf("bd-e2 fix: %q", f.Command)
+			}
+		case "bd-e3", "bd-4":
+			if f.Command != "" {
+				t.Errorf("%s: expected no automatic fix, got %q", f.IssueID, f.Command)
+			}
+		}
+	}
+}
+
+func TestScanOrphans_ClaimsUnknown(t *testing.T) {
+	for _, f := range scanOrphans(orphanTestIssues(), nil) {
+		if f.Kind == orphanUnclaimed {
+			t.Errorf("unexpected unclaimed finding without claims: %+v", f)
+		}
+	}
+}
+
+func TestFormatOrphanFixes(t *testing.T) {
+	result := &OrphanScanResult{Findings: scanOrphans(orphanTestIssues(), map[string]bool{})}
+	out := formatOrphanFixes(result)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if !strings.HasPrefix(lines[0], "bd ") {
+		t.Errorf("expected commands first, got:\n%s", out)
+	}
+	if !strings.Contains(out, "# bd-4: not under any epic") {
+		t.Errorf("expected a comment for bd-4, got:\n%s", out)
+	}
+}
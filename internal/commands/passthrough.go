@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -16,6 +18,7 @@ import (
 	"github.com/beadhub/bdh/internal/beads"
 	"github.com/beadhub/bdh/internal/client"
 	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/i18n"
 	"github.com/beadhub/bdh/internal/sync"
 )
 
@@ -93,6 +96,20 @@ func parseJumpIn(args []string) (cleanArgs []string, message string, hasJumpIn b
 	return cleanArgs, message, hasJumpIn
 }
 
+// parseBoolFlag removes a boolean --:flag from args.
+// Returns the cleaned args and whether the flag was present.
+func parseBoolFlag(args []string, flag string) (cleanArgs []string, present bool) {
+	cleanArgs = make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == flag {
+			present = true
+			continue
+		}
+		cleanArgs = append(cleanArgs, arg)
+	}
+	return cleanArgs, present
+}
+
 // RelatedWorkItem represents a bead being worked on that is related to the one just closed.
 type RelatedWorkItem struct {
 	BeadID      string // e.g., "bd-43"
@@ -111,16 +128,36 @@ type PassthroughResult struct {
 	ExitCode int
 	JSONMode bool
 
+	// BdStreamed is true when bd's output was already shown live (bd.pty);
+	// Stdout and Stderr then hold a plain-text copy that is not printed again.
+	BdStreamed bool
+
+	// Output level: quiet, normal or verbose (config.Verbosity*)
+	Verbosity string
+
 	// From coordination
 	Warning         string // Warning message (e.g., server unreachable)
 	Rejected        bool   // True if server rejected the command
-	RejectionReason string // Why the command was rejected
+	JumpedIn        bool   // True if --:jump-in overrode a rejection
+	JumpInBeadID    string // Bead joined with --:jump-in
+	JumpInClaimants []JumpInClaimant
+	RejectionReason string           // Why the command was rejected
+	Approval        *client.Approval // Approval request for a supervised command
 	BeadsInProgress []client.BeadInProgress
+	MessagesWaiting int // Unread messages, from the server's command context
 
 	// From sync
 	SyncWarning string // Warning message from sync attempt
 	SyncStats   *client.SyncStats
-	SyncMode    string // "full" or "incremental"
+	SyncMode    string // "full", "incremental" or "patch"
+	SyncBytes   int    // Issue data uploaded
+	SyncFailed  bool   // The upload failed (server or network)
+
+	// Lines of issues.jsonl that were repaired or skipped before sync
+	SyncInputWarnings []string
+
+	// Issue graph problems found before sync (fix list)
+	GraphProblems []GraphProblem
 
 	// From auto-reserve
 	AutoReserveWarning   string
@@ -128,9 +165,19 @@ type PassthroughResult struct {
 	AutoRenewed          []string
 	AutoReleased         []string
 	AutoReserveConflicts []ReservationConflict
+	AutoReserveShared    []ReservationConflict // Held by squadmates
+	Takeovers            []TakeoverOutcome     // --:request-takeover results
+	TakeoverRequested    bool
+
+	// Project announcement banner (ready; shown before bd output)
+	Announcement *client.Announcement
+
+	// Pinned beads (ready; shown before bd output whatever the view)
+	Pins []PinStatus
 
 	// Ready command context (shown after bd ready output)
 	IsReadyCommand   bool
+	ReadyView        string         // readyViewMine or readyViewTeam; bd ready is skipped
 	MyAlias          string         // Current agent's alias for filtering
 	MyClaims         []client.Claim // My own active bead claims
 	MyFocusApexID    string
@@ -139,10 +186,56 @@ type PassthroughResult struct {
 	TeamStatus       []client.Workspace // Other workspaces with their current beads
 	TeamStatusLimit  int
 	TeamStatusMore   bool
+	TeamOthers       *client.TeamOthers // Set when TeamStatus lists only members relevant to me
 	ReadyLocks       []aweb.ReservationView
+	MergeRisks       []MergeConflictRisk // Overlapping reservations under one epic
+	RoleTemplate     *RoleTemplate       // Extra section for this workspace's role
+	FocusSuggestions []FocusSuggestion   // Epics to focus on, when there is no focus or claim
 
 	// Close command context: related work in progress
 	RelatedWork []RelatedWorkItem
+
+	// Policy adapter content for this command (pre-claim checklist, close requirements)
+	PolicyAdapter *PolicyAdapter
+	// PolicyAdapterShown is set when the adapter was printed before bd ran
+	PolicyAdapterShown bool
+
+	// Notes left on the contested bead (shown on rejection and --:jump-in)
+	NotesBeadID string
+	BeadNotes   []client.BeadNote
+	// Attachments on the same bead (:attach)
+	BeadAttachments []client.BeadAttachment
+
+	// Closure summary compiled for close --:summary
+	CloseSummary *CloseSummary
+
+	// Pending chats (ready context, JSON mode only; text mode shows them as notifications)
+	ReadyPendingChats []PendingConversation
+
+	// Context fetches that failed; reported in JSON mode only
+	ContextErrors []ContextError
+
+	// Claimants told about a dep edit on their bead
+	DependencyNotices []DependencyNotice
+
+	// Workspace re-registered under a new alias with --:rename ("old -> new")
+	Renamed string
+
+	// Degradation policy (.beadhub degradation settings)
+	Blocked          string // Why bd was not run
+	SyncBlocked      string // bd ran but its sync failed and further changes are blocked
+	SyncStrictFailed string // bd ran but its sync failed under --:strict-sync
+	QueuedSyncNotice string // Outcome of retrying a queued sync before this command
+
+	// Sync held back because a :txn is open
+	TxnNotice string
+
+	// Pre-flight round trip, for :metrics
+	PreflightDuration time.Duration
+
+	// What was sent, what came back and which local rules applied, for :why
+	Preflight     *PreflightTrace
+	DecisionRules []string
 }
 
 // runPassthrough executes a bd command with pre-flight coordination check.
@@ -157,7 +250,7 @@ type PassthroughResult struct {
 //   - The rejection is overridden (result.Rejected will be false)
 //   - A notification is sent to other agents working on the bead
 //   - The command proceeds normally
-func runPassthrough(args []string) (*PassthroughResult, error) {
+func runPassthrough(ctx context.Context, args []string) (*PassthroughResult, error) {
 	result := &PassthroughResult{}
 
 	// Parse --:local-config flag first (affects config loading)
@@ -172,14 +265,61 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 		return nil, fmt.Errorf("no command provided")
 	}
 
+	// Expand command aliases (aliases in .beadhub) before anything reads the args
+	if cfg, err := config.Load(); err == nil {
+		expanded, _, err := expandCommandAlias(cfg.Aliases, args)
+		if err != nil {
+			return nil, err
+		}
+		args = expanded
+	}
+
 	// Parse --:jump-in flag (must be done before validation)
 	cleanArgs, jumpInMessage, hasJumpIn := parseJumpIn(args)
+	cleanArgs, renameAlias, hasRename := parseRename(cleanArgs)
+	cleanArgs, confirmDeletes := parseBoolFlag(cleanArgs, "--:confirm-deletes")
+	cleanArgs, forceSync := parseBoolFlag(cleanArgs, "--:force")
+	cleanArgs, strictSync := parseBoolFlag(cleanArgs, "--:strict-sync")
+	cleanArgs, wantCloseSummary := parseBoolFlag(cleanArgs, "--:summary")
+	cleanArgs, requestTakeover := parseBoolFlag(cleanArgs, "--:request-takeover")
+	cleanArgs, checklistChecked := parseValueFlag(cleanArgs, "--:check")
+	cleanArgs, checklistNotApplicable := parseValueFlag(cleanArgs, "--:na")
+	cleanArgs, notifyOnlyValues := parseValueFlag(cleanArgs, "--:notify-only")
+	cleanArgs, bdEnvValues := parseValueFlag(cleanArgs, "--:bd-env")
+	cleanArgs, waitApprovalValues := parseValueFlag(cleanArgs, "--:wait-approval")
+	cleanArgs, readyView, err := parseReadyView(cleanArgs)
+	if err != nil {
+		return nil, err
+	}
+	cleanArgs, verbosity, err := parseVerbosityFlags(cleanArgs)
+	if err != nil {
+		return nil, err
+	}
 	result.JSONMode = isJSONOutputRequested(cleanArgs)
 
 	// Validate --:jump-in requires a message
 	if hasJumpIn && jumpInMessage == "" {
 		return nil, fmt.Errorf("--:jump-in requires a message explaining why you're joining")
 	}
+	notifyOnly, err := parseNotifyOnly(notifyOnlyValues)
+	if err != nil {
+		return nil, err
+	}
+	extraBdEnv, err := parseBdEnvFlags(bdEnvValues)
+	if err != nil {
+		return nil, err
+	}
+	if len(notifyOnly) > 0 && !hasJumpIn {
+		return nil, fmt.Errorf("--:notify-only can only be used with --:jump-in")
+	}
+	var waitApproval time.Duration
+	if len(waitApprovalValues) > 0 {
+		value := waitApprovalValues[len(waitApprovalValues)-1]
+		waitApproval, err = time.ParseDuration(value)
+		if err != nil || waitApproval <= 0 {
+			return nil, fmt.Errorf("--:wait-approval needs a duration such as 10m, got %q", value)
+		}
+	}
 
 	// Load config
 	cfg, err := config.Load()
@@ -188,11 +328,14 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 			if hasJumpIn {
 				return nil, fmt.Errorf("--:jump-in requires a configured workspace - run 'bdh :init' first")
 			}
+			if hasRename {
+				return nil, fmt.Errorf("--:rename requires a configured workspace - run 'bdh :init' first")
+			}
 
 			result.Warning = "No .beadhub config found - running without coordination"
 
-			runner := bd.New()
-			bdResult, runErr := runner.Run(context.Background(), cleanArgs)
+			runner := newBdRunner(nil, extraBdEnv)
+			bdResult, runErr := runner.Run(ctx, cleanArgs)
 			if runErr != nil {
 				return nil, fmt.Errorf("running bd: %w", runErr)
 			}
@@ -211,20 +354,63 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 		return nil, err
 	}
+	result.Verbosity = verbosity
+	if result.Verbosity == "" {
+		result.Verbosity = cfg.Verbosity()
+	}
+	if result.Verbosity == config.VerbosityQuiet && !result.JSONMode {
+		SuppressNotifications()
+	}
+
+	// Re-register under a new alias first (--:rename), so this command already uses it
+	if hasRename {
+		oldAlias := cfg.Alias
+		if _, err := renameWorkspace(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), renameAlias); err != nil {
+			return nil, err
+		}
+		result.Renamed = fmt.Sprintf("%s -> %s", oldAlias, cfg.Alias)
+	}
 
 	// Set up coordination header for this agent (printed once before first coordination section)
 	SetCoordinationHeaderAlias(cfg.Alias)
 
-	// Build command line string for the server (without --:jump-in)
-	commandLine := strings.Join(cleanArgs, " ")
+	// Inside :txn, syncs wait for :txn commit
+	txn, err := activeTxn()
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the degradation policy for earlier sync failures
+	if txn == nil {
+		result.QueuedSyncNotice = flushQueuedSync(ctx, cfg)
+	}
+	if reason := degradationBlockBeforeRun(cfg, cleanArgs); reason != "" {
+		result.Blocked = reason
+		result.noteRule("degradation policy: blocked before the pre-flight check")
+		return result, nil
+	}
+
+	// Read-only commands start bd now, overlapping its run with the pre-flight
+	// check and context fetch
+	var early *earlyBdRun
+	if startsBeforePreflight(cfg, cleanArgs, result.JSONMode, hasJumpIn, readyView) {
+		early = startEarlyBdRun(ctx, newBdRunner(cfg, extraBdEnv), cleanArgs)
+		defer early.stop()
+		result.noteRule("read-only command: bd started alongside the pre-flight check")
+	}
+
+	// Build command line string for the server (without --:jump-in),
+	// redacted or hashed per privacy.report_command_line
+	commandLine := reportedCommandLine(cfg, cleanArgs)
 
 	// Create client for BeadHub server
-	c := newBeadHubClient(cfg.BeadhubURL)
-	aw, _ := newAwebClient(cfg.BeadhubURL)
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	aw, _ := newAwebClient(ctx, cfg.BeadhubURL)
 
 	// Pre-flight check with BeadHub server
-	cmdCtx, cmdCancel := context.WithTimeout(context.Background(), apiTimeout)
-	cmdResp, err := c.Command(cmdCtx, &client.CommandRequest{
+	preflightStart := time.Now()
+	cmdCtx, cmdCancel := context.WithTimeout(ctx, apiTimeout)
+	cmdReq := &client.CommandRequest{
 		WorkspaceID: cfg.WorkspaceID,
 		RepoID:      cfg.RepoID,
 		Alias:       cfg.Alias,
@@ -232,8 +418,11 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 		RepoOrigin:  cfg.RepoOrigin,
 		Role:        cfg.Role,
 		CommandLine: commandLine,
-	})
+	}
+	cmdResp, err := c.Command(cmdCtx, cmdReq)
 	cmdCancel()
+	result.PreflightDuration = time.Since(preflightStart)
+	result.Preflight = newPreflightTrace(cmdReq, cmdResp, err, result.PreflightDuration)
 
 	// Track if we need to notify other agents (when --:jump-in overrides rejection)
 	var notifyAgents []client.BeadInProgress
@@ -249,14 +438,29 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 			}
 			// Other HTTP errors (4xx, 5xx) - warn but continue
 			result.Warning = fmt.Sprintf("BeadHub error (%d) - running without coordination", clientErr.StatusCode)
+			if client.IsAliasConflict(err) {
+				result.Warning = aliasConflictMessage(cfg.Alias) + "; running without coordination"
+			}
+		} else if client.IsPayloadError(err) {
+			// The server answered, but not with a payload this bdh understands
+			result.Warning = err.Error() + " - running without coordination"
 		} else {
 			// Network error (connection refused, timeout, etc.)
 			result.Warning = fmt.Sprintf("BeadHub unreachable at %s - running without coordination", cfg.BeadhubURL)
 		}
+		if clientErr == nil || clientErr.StatusCode >= 500 {
+			if reason := serverUnreachableBlock(cfg, cleanArgs, result.Warning); reason != "" {
+				result.Blocked = reason
+				result.noteRule("degradation policy: blocked while BeadHub is unavailable")
+				return result, nil
+			}
+		}
+		result.noteRule("no answer from BeadHub: ran without coordination")
 	} else {
 		// Server responded successfully
 		if cmdResp.Context != nil {
 			result.BeadsInProgress = cmdResp.Context.BeadsInProgress
+			result.MessagesWaiting = cmdResp.Context.MessagesWaiting
 		}
 
 		if !cmdResp.Approved {
@@ -276,20 +480,31 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 					}
 				}
 				// Don't mark as rejected since we're overriding
+				result.JumpedIn = true
+				result.noteRule("jump-in path: --:jump-in overrode the rejection")
+			} else if early != nil {
+				// bd already ran the read: the rejection is only advisory
+				result.Warning = fmt.Sprintf("BeadHub rejected this command (%s) - it only reads, so its output is shown anyway", cmdResp.Reason)
+				result.noteRule("read-only command: the rejection was advisory")
 			} else {
 				result.Rejected = true
 				result.RejectionReason = cmdResp.Reason
+				result.noteRule("server rejection: bd was not run (--:jump-in overrides)")
 			}
 		} else if isCloseCommandFromArgs(cleanArgs) {
 			// For close commands, check if other agents have claims on this bead
 			beadID := extractBeadIDFromArgs(cleanArgs)
 			if beadID != "" && cmdResp.Context != nil {
 				otherClaimants := hasOtherClaimants(beadID, cfg.WorkspaceID, cmdResp.Context.BeadsInProgress)
-				if len(otherClaimants) > 0 {
+				if len(otherClaimants) == 0 {
+					result.noteRule("close-claimant check: no one else claims %s", beadID)
+				} else {
 					if hasJumpIn {
 						// --:jump-in allows closing, notify others
 						notifyBeadID = beadID
 						notifyAgents = otherClaimants
+						result.JumpedIn = true
+						result.noteRule("close-claimant check: %s is claimed by others; --:jump-in let the close through", beadID)
 					} else {
 						// Require --:jump-in to close when others are working
 						result.Rejected = true
@@ -301,53 +516,125 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 							"%s has active claims by: %s. Use --:jump-in \"reason\" to close anyway and notify them.",
 							beadID, strings.Join(names, ", "))
 						result.BeadsInProgress = cmdResp.Context.BeadsInProgress
+						result.noteRule("close-claimant check: %s is claimed by others; rejected without --:jump-in", beadID)
 					}
 				}
 			}
 		}
 	}
 
+	// Supervised workspaces park rejected and high-risk commands for approval
+	if early == nil {
+		superviseCommand(ctx, cfg, c, cleanArgs, commandLine, result, waitApproval)
+		if result.Approval != nil {
+			result.noteRule("supervision: approval %s is %s", result.Approval.ApprovalID, result.Approval.Status)
+		}
+	}
+
+	// Rank the claimants of the joined bead and pick who to notify
+	if len(notifyAgents) > 0 {
+		claimants := rankJumpInClaimants(notifyAgents, fetchClaimantLastSeen(ctx, cfg, c, notifyAgents))
+		if err := markJumpInRecipients(claimants, notifyOnly); err != nil {
+			return nil, err
+		}
+		result.JumpInBeadID = notifyBeadID
+		result.JumpInClaimants = claimants
+	}
+
+	// Show notes on the contested bead so the agent sees what others found
+	notesBeadID := notifyBeadID
+	if result.Rejected {
+		notesBeadID = extractBeadIDFromArgs(cleanArgs)
+	}
+	if notesBeadID != "" {
+		result.NotesBeadID = notesBeadID
+		notes, notesErr := fetchBeadNotes(ctx, cfg, notesBeadID)
+		if notesErr != nil {
+			result.ContextErrors = append(result.ContextErrors,
+				contextErrorFrom("bead_notes", "GET /v1/beads/"+notesBeadID+"/notes", notesErr))
+		}
+		result.BeadNotes = notes
+
+		attachments, attachErr := fetchBeadAttachments(ctx, cfg, notesBeadID)
+		if attachErr != nil {
+			result.ContextErrors = append(result.ContextErrors,
+				contextErrorFrom("bead_attachments", "GET /v1/beads/"+notesBeadID+"/attachments", attachErr))
+		}
+		result.BeadAttachments = attachments
+	}
+
 	// If rejected without --:jump-in, don't run bd - just return rejection info
 	if result.Rejected {
 		return result, nil
 	}
 
 	// For "ready" command, fetch additional context (team status)
-	if len(cleanArgs) > 0 && cleanArgs[0] == "ready" {
+	if len(cleanArgs) > 0 && cleanArgs[0] == "ready" && (result.Verbosity != config.VerbosityQuiet || result.JSONMode || readyView != "") {
 		result.IsReadyCommand = true
+		result.ReadyView = readyView
 		result.MyAlias = cfg.Alias
+		result.RoleTemplate = resolveRoleTemplate(ctx, cfg)
+		if result.JSONMode {
+			result.Announcement = currentAnnouncement(ctx, cfg, time.Now())
+		} else {
+			result.Announcement = announcementBanner(ctx, cfg, time.Now())
+		}
 
 		// Use timeout context for non-blocking operations to avoid hanging
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 		defer cancel()
 
-		// Fetch team status (non-blocking - silently fail on errors)
+		result.Pins = loadPinStatuses(ctx, c, workspacePins())
+
+		// Fetch team status (non-blocking - failures only surface in JSON context_errors)
 		// Query all workspaces (not just those with claims) to show focus apex
 		includeClaims := true
 		includePresence := true
 		onlyWithClaims := false
 		teamLimit := defaultReadyTeamLimit
+		if result.Verbosity == config.VerbosityVerbose {
+			teamLimit = maxWorkspaceQueryLimit - readyTeamQueryOverflow
+		}
 		queryLimit := teamLimit + readyTeamQueryOverflow
-		workspacesResp, wsErr := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
-			IncludeClaims:            &includeClaims,
-			IncludePresence:          &includePresence,
-			OnlyWithClaims:           &onlyWithClaims,
-			AlwaysIncludeWorkspaceID: cfg.WorkspaceID,
-			Limit:                    queryLimit,
-		})
+		// Servers without the team query get an empty team section, not an error
+		workspacesResp := &client.WorkspacesResponse{}
+		var wsErr error
+		wsEndpoint := "GET /v1/workspaces/team"
+		if readyView == readyViewMine {
+			// Only this workspace is needed: look it up by alias
+			wsEndpoint = "GET /v1/workspaces"
+			workspacesResp, wsErr = c.Workspaces(ctx, &client.WorkspacesRequest{
+				Alias:           cfg.Alias,
+				IncludeClaims:   true,
+				IncludePresence: &includePresence,
+			})
+		} else if serverSupports(ctx, cfg, featureTeamQuery) {
+			workspacesResp, wsErr = c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+				IncludeClaims:            &includeClaims,
+				IncludePresence:          &includePresence,
+				OnlyWithClaims:           &onlyWithClaims,
+				AlwaysIncludeWorkspaceID: cfg.WorkspaceID,
+				Limit:                    queryLimit,
+			})
+		}
 		if wsErr == nil {
 			// Find my own claims and filter team status
 			// Include workspaces with focus OR claims that were recently active
 			var activeTeam []client.Workspace
+			foundMe := false
 			activeThreshold := teamActivityThreshold()
 			for _, ws := range workspacesResp.Workspaces {
 				if ws.WorkspaceID == cfg.WorkspaceID {
+					if readyView == readyViewTeam {
+						continue
+					}
 					// This is my workspace - capture my claims
+					foundMe = true
 					result.MyClaims = ws.Claims
 					result.MyFocusApexID = ws.FocusApexID
 					result.MyFocusApexTitle = ws.FocusApexTitle
 					result.MyFocusApexType = ws.FocusApexType
-				} else if ws.FocusApexID != "" || len(ws.Claims) > 0 {
+				} else if readyView != readyViewMine && (ws.FocusApexID != "" || len(ws.Claims) > 0) {
 					// Other workspaces with focus or claims - check if recently active
 					if isWorkspaceRecentlyActive(ws, activeThreshold) {
 						activeTeam = append(activeTeam, ws)
@@ -362,13 +649,33 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 				result.TeamStatusMore = true
 			}
 			result.TeamStatus = activeTeam
+			if readyView != readyViewMine {
+				shardTeamStatus(ctx, cfg, c, result)
+			}
+
+			// Nothing claimed and no focus: suggest where to start
+			if foundMe && len(result.MyClaims) == 0 && strings.TrimSpace(result.MyFocusApexID) == "" {
+				result.FocusSuggestions = focusSuggestionsFor(ctx, cfg, c, workspacesResp.Workspaces, serverNow())
+			}
+		} else {
+			result.ContextErrors = append(result.ContextErrors,
+				contextErrorFrom("team", wsEndpoint, wsErr))
 		}
 
-		// Fetch active locks (non-blocking - silently fail on errors)
+		// Fetch active locks (non-blocking - failures only surface in JSON context_errors)
 		if aw != nil {
 			locksResp, locksErr := aw.ReservationList(ctx, "")
-			if locksErr == nil {
+			if locksErr != nil {
+				result.ContextErrors = append(result.ContextErrors,
+					contextErrorFrom("locks", "GET /v1/reservations", locksErr))
+			} else {
 				result.ReadyLocks = locksResp.Reservations
+				if wsErr == nil && readyView != readyViewMine {
+					result.MergeRisks = predictMergeConflicts(workspacesResp.Workspaces, result.ReadyLocks)
+				}
+				if readyView == readyViewMine {
+					result.ReadyLocks = locksHeldBy(result.ReadyLocks, cfg.Alias)
+				}
 				sort.Slice(result.ReadyLocks, func(i, j int) bool {
 					if result.ReadyLocks[i].ResourceKey == result.ReadyLocks[j].ResourceKey {
 						return result.ReadyLocks[i].HolderAlias < result.ReadyLocks[j].HolderAlias
@@ -376,24 +683,109 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 					return result.ReadyLocks[i].ResourceKey < result.ReadyLocks[j].ResourceKey
 				})
 			}
+
+			// JSON consumers don't see the stderr notifications, so include
+			// pending chats in the ready context.
+			if result.JSONMode && readyView != readyViewTeam {
+				pendingResp, chatErr := aw.ChatPending(ctx)
+				if chatErr != nil {
+					result.ContextErrors = append(result.ContextErrors,
+						contextErrorFrom("chats", "GET /v1/chat/pending", chatErr))
+				} else {
+					result.ReadyPendingChats = pendingConversationsFrom(pendingResp)
+				}
+			}
 		}
 	}
 
+	// ready --:mine and --:team show only coordination context, without bd's list
+	if result.ReadyView != "" {
+		return result, nil
+	}
+
+	// Surface policy guidance for claim/close (non-blocking, uses the policy cache)
+	result.PolicyAdapter = fetchPolicyAdapterForCommand(ctx, cfg, cleanArgs)
+
+	// Close requirements must be confirmed before bd close runs (--:check, --:na)
+	if isCloseCommandFromArgs(cleanArgs) {
+		interactive := isTTY() && !result.JSONMode
+		reasonText, blocked, err := runCloseChecklist(result.PolicyAdapter, checklistChecked, checklistNotApplicable, interactive)
+		if err != nil {
+			return nil, err
+		}
+		if blocked != "" {
+			result.Blocked = blocked
+			return result, nil
+		}
+		cleanArgs = withCloseSummaryReason(cleanArgs, reasonText)
+	} else if len(checklistChecked) > 0 || len(checklistNotApplicable) > 0 {
+		return nil, fmt.Errorf("--:check and --:na only apply to bd close")
+	}
+
+	// Show the guidance before bd runs, not after the claim or close is done
+	result.PolicyAdapterShown = showPolicyAdapterPreflight(result.PolicyAdapter, result.JSONMode)
+
 	// Auto-reserve modified files before running bd (non-blocking)
 	if aw != nil {
-		if autoResult := autoReserve(context.Background(), cfg, aw); autoResult != nil {
+		var inProgress []client.BeadInProgress
+		if cmdResp != nil && cmdResp.Context != nil {
+			inProgress = cmdResp.Context.BeadsInProgress
+		}
+		beadID := autoReserveBeadID(cfg.WorkspaceID, cleanArgs, inProgress)
+		if autoResult := autoReserve(ctx, cfg, aw, beadID); autoResult != nil {
 			result.AutoReserveWarning = autoResult.Warning
 			result.AutoReserved = autoResult.Acquired
 			result.AutoRenewed = autoResult.Renewed
 			result.AutoReleased = autoResult.Released
 			result.AutoReserveConflicts = autoResult.Conflicts
+			result.AutoReserveShared = autoResult.Shared
+			recordReservationEvents(cfg, autoResult, time.Now())
+			reportReservationConflicts(ctx, cfg, c, autoResult.Conflicts, time.Now())
+		}
+		if requestTakeover {
+			result.TakeoverRequested = true
+			result.Takeovers = requestTakeovers(ctx, cfg, c, result.AutoReserveConflicts, serverNow())
+		}
+	}
+	if reason := reservationConflictBlock(cfg, cleanArgs, result.AutoReserveConflicts); reason != "" {
+		result.Blocked = reason
+		return result, nil
+	}
+
+	// Compile a closure summary and append it to the close reason (--:summary)
+	if wantCloseSummary && isCloseCommandFromArgs(cleanArgs) {
+		if beadID := extractBeadIDFromArgs(cleanArgs); beadID != "" {
+			var beadsInProgress []client.BeadInProgress
+			if cmdResp != nil && cmdResp.Context != nil {
+				beadsInProgress = cmdResp.Context.BeadsInProgress
+			}
+			summaryCtx, summaryCancel := context.WithTimeout(ctx, apiTimeout)
+			result.CloseSummary = buildCloseSummary(summaryCtx, cfg, aw, beadID, beadsInProgress, time.Now())
+			summaryCancel()
+			cleanArgs = withCloseSummaryReason(cleanArgs, result.CloseSummary.Text)
 		}
 	}
 
+	// Ctrl-C before bd started: bd does not run at all
+	if interrupted(ctx) {
+		return result, interruptedPassthrough(ctx, cleanArgs, aw, result, false, false)
+	}
+
 	// Run bd with cleaned args (without --:jump-in)
-	runner := bd.New()
-	bdResult, err := runner.Run(context.Background(), cleanArgs)
+	runner := newBdRunner(cfg, extraBdEnv)
+	var bdResult *bd.Result
+	if early != nil {
+		bdResult, err = early.wait()
+	} else if useBdPTY(cfg, result.JSONMode, cleanArgs) {
+		bdResult, result.BdStreamed, err = runner.RunLive(ctx, cleanArgs, os.Stdout, os.Stderr)
+	} else {
+		bdResult, err = runner.Run(ctx, cleanArgs)
+	}
 	if err != nil {
+		if interrupted(ctx) {
+			result.ExitCode = -1
+			return result, interruptedPassthrough(ctx, cleanArgs, aw, result, true, txn == nil && bd.IsMutationCommand(cleanArgs))
+		}
 		return nil, fmt.Errorf("running bd: %w", err)
 	}
 
@@ -401,14 +793,89 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 	result.Stderr = bdResult.Stderr
 	result.ExitCode = bdResult.ExitCode
 
+	// Ctrl-C while bd ran: skip the sync but queue it, since bd may have changed issues
+	if interrupted(ctx) {
+		return result, interruptedPassthrough(ctx, cleanArgs, aw, result, true, txn == nil && bd.IsMutationCommand(cleanArgs))
+	}
+
+	// Notifications to other agents when --:jump-in is used, sent at the end
+	// regardless of bd exit code - the notification is about intent to join
+	var jumpInNotes []TxnNotification
+	if aw != nil && len(result.JumpInClaimants) > 0 {
+		jumpInNotes = jumpInNotifications(cfg.Alias, notifyBeadID, jumpInMessage, result.JumpInClaimants, time.Now())
+	}
+
+	// Inside :txn, record the mutation for :txn commit instead of syncing
+	if txn != nil && bd.IsMutationCommand(cleanArgs) && bdResult.ExitCode == 0 {
+		recorded := TxnCommand{
+			Args:           cleanArgs,
+			Targets:        bd.AffectedIssueIDs(cleanArgs),
+			ConfirmDeletes: confirmDeletes,
+			Force:          forceSync,
+			Notifications:  jumpInNotes,
+		}
+		jumpInNotes = nil
+		if cmdResp != nil && cmdResp.Context != nil {
+			recorded.BeadsInProgress = cmdResp.Context.BeadsInProgress
+		}
+		notice, err := recordTxnCommand(txn, recorded)
+		if err != nil {
+			return nil, err
+		}
+		result.TxnNotice = notice
+	}
+
 	// Sync after mutation commands (non-blocking - just warn on failure)
-	if bd.IsMutationCommand(cleanArgs) && bdResult.ExitCode == 0 {
-		syncResult := syncToBeadHub(cfg, cleanArgs)
+	if txn == nil && bd.IsMutationCommand(cleanArgs) && bdResult.ExitCode == 0 {
+		opts := syncOptions{
+			ConfirmDeletes: confirmDeletes,
+			Force:          forceSync,
+			Targets:        bd.AffectedIssueIDs(cleanArgs),
+			BdEnv:          extraBdEnv,
+		}
+		syncResult := syncToBeadHub(ctx, cfg, cleanArgs, opts)
 		if syncResult.Warning != "" {
 			result.SyncWarning = syncResult.Warning
 		}
+		opID := ""
+		if syncResult.Retryable {
+			result.SyncFailed = true
+			opID = recordFailedOp(FailedOperation{
+				Kind:           failedOpSync,
+				Error:          syncResult.Warning,
+				BdArgs:         cleanArgs,
+				Targets:        opts.Targets,
+				ConfirmDeletes: opts.ConfirmDeletes,
+				Force:          opts.Force,
+			})
+			result.SyncWarning += replayHint(opID)
+			result.SyncBlocked = syncFailureBlock(cfg, opID)
+		}
+		result.SyncStrictFailed = strictSyncFailure(strictSync || cfg.SyncStrictEnabled(), syncResult, opID)
+		result.GraphProblems = syncResult.GraphProblems
+		result.SyncInputWarnings = syncResult.InputWarnings
 		result.SyncStats = syncResult.Stats
 		result.SyncMode = syncResult.SyncMode
+		result.SyncBytes = syncResult.Bytes
+	}
+
+	// Ctrl-C during the sync: a failed sync was already recorded for :replay
+	if interrupted(ctx) {
+		return result, interruptedPassthrough(ctx, cleanArgs, aw, result, true, false)
+	}
+
+	// Tell agents working on the affected beads that their dependencies changed
+	if txn == nil && bdResult.ExitCode == 0 && cmdResp != nil && cmdResp.Context != nil {
+		result.DependencyNotices = notifyDependencyChange(ctx, cfg, aw, cleanArgs, cmdResp.Context.BeadsInProgress)
+	}
+
+	// Attach the closure summary to the bead as a note once the close succeeded
+	if result.CloseSummary != nil {
+		if bdResult.ExitCode != 0 {
+			result.CloseSummary = nil
+		} else if result.CloseSummary.Text != "" {
+			postCloseSummaryNote(ctx, cfg, result.CloseSummary)
+		}
 	}
 
 	// For successful close commands, find related work in progress
@@ -426,23 +893,8 @@ func runPassthrough(args []string) (*PassthroughResult, error) {
 		}
 	}
 
-	// Send notifications to other agents when --:jump-in is used
-	// We send regardless of bd exit code - the notification is about intent to join
-	if len(notifyAgents) > 0 {
-		notifyMessage := fmt.Sprintf("%s is joining work on %s: %s", cfg.Alias, notifyBeadID, jumpInMessage)
-		for _, agent := range notifyAgents {
-			// Non-blocking - silently ignore errors
-			if aw == nil {
-				continue
-			}
-			notifyCtx, notifyCancel := context.WithTimeout(context.Background(), apiTimeout)
-			_, _ = aw.SendMessage(notifyCtx, &aweb.SendMessageRequest{
-				ToAgentID: agent.WorkspaceID,
-				Body:      notifyMessage,
-			})
-			notifyCancel()
-		}
-	}
+	// Non-blocking - failures are only recorded for :replay
+	sendTxnNotifications(ctx, aw, jumpInNotes)
 
 	return result, nil
 }
@@ -469,9 +921,12 @@ func extractBeadIDFromArgs(args []string) string {
 type Issue struct {
 	ID           string       `json:"id"`
 	Title        string       `json:"title"`
+	Description  string       `json:"description,omitempty"`
 	Status       string       `json:"status"`
+	IssueType    string       `json:"issue_type,omitempty"`
 	Dependencies []Dependency `json:"dependencies,omitempty"`
 	Labels       []string     `json:"labels,omitempty"`
+	ClosedAt     string       `json:"closed_at,omitempty"`
 }
 
 // Dependency represents a dependency relationship between issues.
@@ -481,27 +936,67 @@ type Dependency struct {
 	Type        string `json:"type"` // "blocks", "parent-child", "discovered-from"
 }
 
+// maxIssueWarningsShown caps the per-line issues.jsonl warnings printed.
+const maxIssueWarningsShown = 5
+
 // loadIssues parses issues.jsonl from the beads directory and returns all issues.
+// The file is streamed line by line so very large exports stay cheap; lines
+// that had to be repaired or skipped are reported on stderr.
 func loadIssues() ([]Issue, error) {
-	content, err := os.ReadFile(beads.IssuesJSONLPath())
+	path := beads.IssuesJSONLPath()
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
+
+	issues, warnings, err := decodeIssuesJSONL(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	for _, w := range formatLineWarnings(path, warnings) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	return issues, nil
+}
+
+// parseIssuesJSONL parses issues.jsonl content, skipping malformed lines.
+func parseIssuesJSONL(content []byte) []Issue {
+	issues, _, _ := decodeIssuesJSONL(bytes.NewReader(content))
+	return issues
+}
 
+// decodeIssuesJSONL streams issues from r. Malformed lines are skipped with a
+// warning rather than failing the whole file; only read errors are returned.
+func decodeIssuesJSONL(r io.Reader) ([]Issue, []sync.LineWarning, error) {
 	var issues []Issue
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+	var invalid []sync.LineWarning
+	warnings, err := sync.ScanJSONL(r, func(lineNo int, line []byte) error {
 		var issue Issue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			continue // Skip malformed lines
+		if err := json.Unmarshal(line, &issue); err != nil {
+			invalid = append(invalid, sync.LineWarning{Line: lineNo, Message: fmt.Sprintf("invalid JSON - skipped (%v)", err)})
+			return nil
 		}
 		issues = append(issues, issue)
+		return nil
+	})
+	warnings = append(warnings, invalid...)
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].Line < warnings[j].Line })
+	return issues, warnings, err
+}
+
+// formatLineWarnings renders JSONL line warnings for display, capped at
+// maxIssueWarningsShown with a count of the rest.
+func formatLineWarnings(path string, warnings []sync.LineWarning) []string {
+	var out []string
+	for i, w := range warnings {
+		if i == maxIssueWarningsShown {
+			out = append(out, fmt.Sprintf("%s: %d more line(s) repaired or skipped", path, len(warnings)-i))
+			break
+		}
+		out = append(out, fmt.Sprintf("%s: %s", path, w.String()))
 	}
-	return issues, nil
+	return out
 }
 
 // findRelatedBeadIDs finds bead IDs that are related to the given bead ID.
@@ -677,22 +1172,31 @@ type SyncResult struct {
 	Warning     string
 	IssuesCount int
 	// Sync mode and stats
-	SyncMode string // "full" or "incremental"
+	SyncMode string // "full", "incremental" or "patch"
 	Stats    *client.SyncStats
+	// Lines of issues.jsonl that were repaired or skipped before upload
+	InputWarnings []string
+	// Issue graph problems that blocked (or, with --:force, did not block) the sync
+	GraphProblems []GraphProblem
+	// Retryable is set when the upload itself failed (server or network), so
+	// re-running just the sync (bdh :replay) may succeed.
+	Retryable bool
+	// Bytes of issue data uploaded by a successful sync
+	Bytes int
 }
 
 // syncToBeadHub reads issues.jsonl from the beads directory and syncs to BeadHub.
 // Uses incremental sync when possible (only sending changed issues).
 // Returns warning on failure but never errors (non-blocking design).
-func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
+func syncToBeadHub(ctx context.Context, cfg *config.Config, bdArgs []string, opts syncOptions) *SyncResult {
 	result := &SyncResult{}
 
 	issuesPath, exportArgs := resolveIssuesPathAndExportArgs(bdArgs)
 
 	// Force an explicit export before uploading so the JSONL reflects the latest
 	// state even when bd is operating via the daemon (which may export async).
-	exportRunner := bd.New()
-	exportCtx, exportCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	exportRunner := newBdRunner(cfg, opts.BdEnv)
+	exportCtx, exportCancel := context.WithTimeout(ctx, 10*time.Second)
 	defer exportCancel()
 	exportResult, exportErr := exportRunner.Run(exportCtx, exportArgs)
 	if exportErr != nil {
@@ -704,8 +1208,11 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 		return result
 	}
 
-	// Read issues.jsonl
-	content, err := os.ReadFile(issuesPath)
+	// Read issues.jsonl in one streaming pass, normalizing it into clean JSONL
+	// (no BOM, LF endings, valid UTF-8) so the server and local hashes agree,
+	// and hashing each issue as it goes; report every line that had to be
+	// changed.
+	issuesFile, err := os.Open(issuesPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return result // No file to sync
@@ -713,28 +1220,37 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 		result.Warning = fmt.Sprintf("could not read %s: %v", issuesPath, err)
 		return result
 	}
+	content, currentHashes, lineWarnings, err := sync.ReadJSONL(issuesFile)
+	_ = issuesFile.Close()
+	result.InputWarnings = formatLineWarnings(issuesPath, lineWarnings)
+	if err != nil {
+		result.Warning = fmt.Sprintf("could not read %s: %v", issuesPath, err)
+		return result
+	}
+
+	// Don't propagate a broken dependency graph to every other agent
+	result.GraphProblems = checkIssueGraph(parseIssuesJSONL(content))
+	if blocking := blockingGraphProblems(result.GraphProblems); blocking > 0 && !opts.Force {
+		result.Warning = fmt.Sprintf("sync blocked: issue graph has %d problem(s) - fix them or use --:force to sync anyway", blocking)
+		return result
+	}
 
 	// Load sync state for incremental sync
-	syncStatePath := beads.SyncStatePath()
+	syncStatePath := syncStatePathForConfig(cfg)
 	syncState, err := sync.LoadState(syncStatePath)
 	if err != nil {
 		// Can't load state - fall back to full sync
 		syncState = &sync.SyncState{IssueHashes: make(map[string]string)}
 	}
 
-	// Compute current hashes
-	currentHashes, err := sync.ComputeIssueHashes(content)
-	if err != nil {
-		result.Warning = fmt.Sprintf("could not compute issue hashes: %v", err)
-		return result
-	}
-
 	// Determine sync mode and prepare request
-	c := newBeadHubClient(cfg.BeadhubURL)
-	syncCtx, syncCancel := context.WithTimeout(context.Background(), apiTimeout)
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	syncCtx, syncCancel := context.WithTimeout(ctx, apiTimeout)
 	defer syncCancel()
 
 	var req *client.SyncRequest
+	var incrementalReq *client.SyncRequest // fallback when the server refuses patches
+	var syncedIDs []string                 // set for targeted syncs; nil means everything was sent
 
 	if sync.NeedsFullSync(syncState) {
 		// Full sync: send everything
@@ -746,7 +1262,7 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 			HumanName:   cfg.HumanName,
 			RepoOrigin:  cfg.RepoOrigin,
 			Role:        cfg.Role,
-			CommandLine: strings.Join(bdArgs, " "),
+			CommandLine: reportedCommandLine(cfg, bdArgs),
 			SyncMode:    "full",
 			IssuesJSONL: string(content),
 			SyncProtocolVersion: func() *int {
@@ -757,14 +1273,33 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 	} else {
 		// Incremental sync: only send changes
 		result.SyncMode = "incremental"
+		warnServerVersion(ctx, cfg, requirementIncrementalSync)
 		changedIDs := sync.FindChangedIssues(currentHashes, syncState.IssueHashes)
 		deletedIDs := sync.FindDeletedIssues(currentHashes, syncState.IssueHashes)
+		if len(opts.Targets) > 0 {
+			changedIDs = targetedIssueIDs(changedIDs, opts.Targets)
+			deletedIDs = nil
+			syncedIDs = changedIDs
+		}
 
 		if len(changedIDs) == 0 && len(deletedIDs) == 0 {
 			// Nothing to upload.
 			return result
 		}
 
+		// A truncated export looks exactly like a mass deletion; refuse to
+		// propagate it to the server without explicit confirmation.
+		if len(deletedIDs) > 0 {
+			if warning := checkDeletionSafety(len(deletedIDs), len(syncState.IssueHashes), cfg.SyncMaxDeletePercent(), opts.ConfirmDeletes); warning != "" {
+				result.Warning = warning
+				return result
+			}
+			if dbCount, ok := countIssuesInDB(ctx, exportRunner, exportArgs); ok && len(currentHashes) < dbCount {
+				result.Warning = fmt.Sprintf("sync blocked - %s has %d issues but the local database has %d (export looks truncated)", issuesPath, len(currentHashes), dbCount)
+				return result
+			}
+		}
+
 		// Extract changed issues from JSONL
 		changedIssues, err := sync.ExtractIssuesByID(content, changedIDs)
 		if err != nil {
@@ -784,7 +1319,7 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 			HumanName:     cfg.HumanName,
 			RepoOrigin:    cfg.RepoOrigin,
 			Role:          cfg.Role,
-			CommandLine:   strings.Join(bdArgs, " "),
+			CommandLine:   reportedCommandLine(cfg, bdArgs),
 			SyncMode:      "incremental",
 			ChangedIssues: changedIssues,
 			DeletedIDs:    deletedIDs,
@@ -793,35 +1328,62 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 				return &v
 			}(),
 		}
+		if patchReq := patchSyncRequest(cfg, req, content, changedIDs, syncState); patchReq != nil {
+			incrementalReq, req = req, patchReq
+			result.SyncMode = "patch"
+		}
+	}
+
+	env := currentWorkspaceEnv(ctx)
+	req.Env = env
+	if incrementalReq != nil {
+		incrementalReq.Env = env
 	}
 
+	sent := req
 	resp, err := c.Sync(syncCtx, req)
+	var clientErr *client.Error
+	if err != nil && incrementalReq != nil && !client.IsAliasConflict(err) &&
+		errors.As(err, &clientErr) && clientErr.StatusCode == 409 {
+		// Patches refused (the server's copy diverged, or it no longer
+		// speaks v2): downgrade to whole issues.
+		result.SyncMode = "incremental"
+		sent = incrementalReq
+		resp, err = c.Sync(syncCtx, incrementalReq)
+	}
 	if err != nil {
-		var clientErr *client.Error
+		if client.IsAliasConflict(err) {
+			// Retrying can't help until the workspace is renamed
+			result.Warning = "sync failed - " + aliasConflictMessage(cfg.Alias)
+			return result
+		}
 		if errors.As(err, &clientErr) && clientErr.StatusCode == 409 {
 			// Protocol mismatch: retry once with full sync.
 			result.SyncMode = "full"
-				fullReq := &client.SyncRequest{
-					WorkspaceID: cfg.WorkspaceID,
-					RepoID:      cfg.RepoID,
-					Alias:       cfg.Alias,
-					HumanName:   cfg.HumanName,
-					RepoOrigin:  cfg.RepoOrigin,
-					Role:        cfg.Role,
-					CommandLine: strings.Join(bdArgs, " "),
-					SyncMode:    "full",
-					IssuesJSONL: string(content),
-					SyncProtocolVersion: func() *int {
-						v := syncState.ProtocolVersion
-						return &v
+			fullReq := &client.SyncRequest{
+				WorkspaceID: cfg.WorkspaceID,
+				RepoID:      cfg.RepoID,
+				Alias:       cfg.Alias,
+				HumanName:   cfg.HumanName,
+				RepoOrigin:  cfg.RepoOrigin,
+				Role:        cfg.Role,
+				CommandLine: reportedCommandLine(cfg, bdArgs),
+				SyncMode:    "full",
+				IssuesJSONL: string(content),
+				Env:         env,
+				SyncProtocolVersion: func() *int {
+					v := syncState.ProtocolVersion
+					return &v
 				}(),
 			}
 
+			sent = fullReq
 			resp, err = c.Sync(syncCtx, fullReq)
 		}
 
 		// Non-blocking: just warn on failure
 		if err != nil {
+			result.Retryable = true
 			if errors.As(err, &clientErr) {
 				result.Warning = fmt.Sprintf("sync failed (%d) - changes saved locally only", clientErr.StatusCode)
 			} else {
@@ -831,11 +1393,18 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 		}
 	}
 
+	// Keep a snapshot of each uploaded version for bdh :diff (best-effort)
+	recordSyncHistory(cfg, bdArgs, content, currentHashes, syncState.IssueHashes, syncedIDs)
+
 	// Update sync state on success
 	if resp.SyncProtocolVersion > 0 {
 		syncState.ProtocolVersion = resp.SyncProtocolVersion
 	}
-	sync.UpdateState(syncState, currentHashes)
+	if syncedIDs != nil && result.SyncMode != "full" {
+		sync.UpdateStateForIssues(syncState, currentHashes, syncedIDs)
+	} else {
+		sync.UpdateState(syncState, currentHashes)
+	}
 	if err := sync.SaveState(syncStatePath, syncState); err != nil {
 		// Non-fatal: state save failed, next sync will be full
 		result.Warning = "sync succeeded but could not save sync state"
@@ -844,10 +1413,35 @@ func syncToBeadHub(cfg *config.Config, bdArgs []string) *SyncResult {
 	result.Synced = resp.Synced
 	result.IssuesCount = resp.IssuesCount
 	result.Stats = resp.Stats
+	result.Bytes = syncPayloadBytes(sent)
 
 	return result
 }
 
+// syncPayloadBytes is the size of the issue data in a sync request.
+func syncPayloadBytes(req *client.SyncRequest) int {
+	n := len(req.IssuesJSONL) + len(req.ChangedIssues)
+	for _, p := range req.IssuePatches {
+		n += len(p.Patch)
+	}
+	return n
+}
+
+// targetedIssueIDs keeps the changed issues that a targeted sync covers.
+func targetedIssueIDs(changedIDs, targets []string) []string {
+	wanted := make(map[string]bool, len(targets))
+	for _, id := range targets {
+		wanted[id] = true
+	}
+	ids := []string{}
+	for _, id := range changedIDs {
+		if wanted[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func resolveIssuesPathAndExportArgs(bdArgs []string) (issuesPath string, exportArgs []string) {
 	var dbPath string
 	noDaemon := false
@@ -902,19 +1496,47 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 	if result.JSONMode {
 		return formatPassthroughOutputJSON(result)
 	}
+	if result.Verbosity == config.VerbosityQuiet {
+		return formatQuietPassthroughOutput(result)
+	}
 
 	var sb strings.Builder
 
+	if result.Renamed != "" {
+		sb.WriteString(fmt.Sprintf("Renamed workspace: %s\n\n", result.Renamed))
+	}
+	if result.QueuedSyncNotice != "" {
+		sb.WriteString("SYNC: " + result.QueuedSyncNotice + "\n\n")
+	}
+	if result.Blocked != "" {
+		sb.WriteString(i18n.T("blocked", result.Blocked) + "\n")
+		return sb.String()
+	}
+
+	sb.WriteString(formatAnnouncementBanner(result.Announcement))
+	sb.WriteString(formatPinnedSection(result.Pins))
+
 	// Show warning if any
 	if result.Warning != "" {
-		sb.WriteString(fmt.Sprintf("Warning: %s\n\n", result.Warning))
+		sb.WriteString(i18n.T("warning", result.Warning) + "\n\n")
 	}
 
 	// Show rejection info if rejected
 	if result.Rejected {
-		sb.WriteString(fmt.Sprintf("REJECTED: %s\n\n", result.RejectionReason))
+		sb.WriteString(i18n.T("rejected", result.RejectionReason) + "\n\n")
+		if a := result.Approval; a != nil {
+			if a.Status == client.ApprovalDenied {
+				sb.WriteString(i18n.T("rejected.approval_denied", a.ApprovalID, a.DecidedBy) + "\n")
+				if a.Note != "" {
+					sb.WriteString("  " + a.Note + "\n")
+				}
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString(i18n.T("rejected.approval_pending", a.ApprovalID, a.ApprovalID) + "\n\n")
+			}
+		}
 		if len(result.BeadsInProgress) > 0 {
-			sb.WriteString("Beads in progress:\n")
+			sb.WriteString(i18n.T("rejected.beads_in_progress") + "\n")
 			for _, b := range result.BeadsInProgress {
 				if b.Title != "" {
 					sb.WriteString(fmt.Sprintf("  %s — %s (%s) — \"%s\"\n", b.BeadID, b.Alias, b.HumanName, b.Title))
@@ -924,15 +1546,25 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 			}
 			sb.WriteString("\n")
 		}
-		sb.WriteString("Options:\n")
-		sb.WriteString("  - Pick different work: bdh ready\n")
-		sb.WriteString("  - Message them: bdh :aweb mail send <agent-name> \"message\"\n")
-		sb.WriteString("  - Escalate: bdh :escalate \"subject\" \"situation\"\n")
+		if len(result.BeadNotes) > 0 {
+			sb.WriteString(i18n.T("rejected.notes", result.NotesBeadID) + "\n")
+			sb.WriteString(formatNoteLines(result.BeadNotes, "  "))
+			sb.WriteString("\n")
+		}
+		if len(result.BeadAttachments) > 0 {
+			sb.WriteString(i18n.T("rejected.attachments", result.NotesBeadID) + "\n")
+			sb.WriteString(formatAttachmentLines(result.BeadAttachments, "  "))
+			sb.WriteString("\n")
+		}
+		sb.WriteString(i18n.T("rejected.options") + "\n")
+		sb.WriteString(i18n.T("rejected.option_ready") + "\n")
+		sb.WriteString(i18n.T("rejected.option_mail") + "\n")
+		sb.WriteString(i18n.T("rejected.option_escalate") + "\n")
 		sb.WriteString("\n")
 	}
 
 	// Show bd output (normalize trailing newlines for consistent spacing)
-	if result.Stdout != "" {
+	if result.Stdout != "" && !result.BdStreamed {
 		stdout := strings.TrimRight(result.Stdout, "\n")
 		stdout = rewriteBDHelpOutput(stdout)
 		if stdout != "" {
@@ -940,12 +1572,13 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 			sb.WriteString("\n")
 		}
 	}
-	if result.Stderr != "" {
+	if result.Stderr != "" && !result.BdStreamed {
 		sb.WriteString(rewriteBDHelpOutput(result.Stderr))
 	}
 
 	// For "ready" command, show coordination context AFTER bd output
 	if result.IsReadyCommand {
+		sb.WriteString(formatReadyViewEmpty(result))
 		// Show apex context (what epics/features we're working on)
 		if len(result.MyClaims) > 0 {
 			// Collect unique apexes (sorted for deterministic output)
@@ -966,7 +1599,7 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 				}
 				sort.Strings(apexIDs)
 				sb.WriteString(FormatCoordinationHeader())
-				sb.WriteString("\n## Your Current Epics\n")
+				sb.WriteString("\n## " + i18n.T("ready.epics.title") + "\n")
 				for _, apexID := range apexIDs {
 					sb.WriteString(fmt.Sprintf("- %s \"%s\"\n", apexID, apexes[apexID]))
 				}
@@ -974,14 +1607,14 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 
 			// Show claims with stale warnings
 			sb.WriteString(FormatCoordinationHeader())
-			sb.WriteString("\n## Your Claims\n")
-			sb.WriteString("Issues you have claimed and should complete:\n")
+			sb.WriteString("\n## " + i18n.T("ready.claims.title") + "\n")
+			sb.WriteString(i18n.T("ready.claims.intro") + "\n")
 			hasStale := false
 			for _, claim := range result.MyClaims {
-				claimAge := formatTimeAgo(claim.ClaimedAt)
+				claimAge := formatTimestamp(claim.ClaimedAt)
 				staleIndicator := ""
 				if isClaimStale(claim.ClaimedAt) {
-					staleIndicator = " ⚠️ stale"
+					staleIndicator = " " + i18n.T("ready.claims.stale")
 					hasStale = true
 				}
 				if claim.Title != "" {
@@ -991,20 +1624,22 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 				}
 			}
 			if hasStale {
-				sb.WriteString("  → Release stale claims: `bdh close <id> --reason \"releasing stale claim\"`\n")
+				sb.WriteString(i18n.T("ready.claims.release_stale") + "\n")
 			}
 		} else if strings.TrimSpace(result.MyFocusApexID) != "" {
 			sb.WriteString(FormatCoordinationHeader())
-			sb.WriteString("\n## Your Focus\n")
+			sb.WriteString("\n## " + i18n.T("ready.focus.title") + "\n")
 			if strings.TrimSpace(result.MyFocusApexTitle) != "" {
 				sb.WriteString(fmt.Sprintf("- %s \"%s\"\n", result.MyFocusApexID, result.MyFocusApexTitle))
 			} else {
 				sb.WriteString(fmt.Sprintf("- %s\n", result.MyFocusApexID))
 			}
+		} else {
+			sb.WriteString(formatFocusSuggestions(result.FocusSuggestions))
 		}
 
 		// Show team status (who's working on what)
-		if len(result.TeamStatus) > 0 {
+		if len(result.TeamStatus) > 0 || result.TeamOthers != nil {
 			limit := result.TeamStatusLimit
 			if limit == 0 {
 				limit = defaultReadyTeamLimit
@@ -1014,71 +1649,119 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 				teamStatus = teamStatus[:limit]
 			}
 			sb.WriteString(FormatCoordinationHeader())
-			sb.WriteString("\n## Team Status\n")
-			sb.WriteString("Check before claiming work to avoid conflicts:\n")
+			sb.WriteString("\n## " + i18n.T("ready.team.title") + "\n")
+			sb.WriteString(i18n.T("ready.team.intro") + "\n")
+			if result.TeamOthers != nil {
+				sb.WriteString(i18n.T("ready.team.relevant", len(teamStatus)) + "\n")
+			}
 			for _, ws := range teamStatus {
 				// Show focus apex if available
 				if ws.FocusApexID != "" {
 					if ws.FocusApexTitle != "" {
-						sb.WriteString(fmt.Sprintf("- %s — focused on %s \"%s\"\n", ws.Alias, ws.FocusApexID, ws.FocusApexTitle))
+						sb.WriteString(i18n.T("ready.team.focused_on_titled", teamMemberLabel(ws), ws.FocusApexID, ws.FocusApexTitle) + "\n")
 					} else {
-						sb.WriteString(fmt.Sprintf("- %s — focused on %s\n", ws.Alias, ws.FocusApexID))
+						sb.WriteString(i18n.T("ready.team.focused_on", teamMemberLabel(ws), ws.FocusApexID) + "\n")
 					}
 				} else if len(ws.Claims) > 0 {
 					// Fall back to showing claims if no focus apex
 					for _, claim := range ws.Claims {
 						if claim.Title != "" {
-							sb.WriteString(fmt.Sprintf("- %s — working on %s \"%s\"\n", ws.Alias, claim.BeadID, claim.Title))
+							sb.WriteString(i18n.T("ready.team.working_on_titled", teamMemberLabel(ws), claim.BeadID, claim.Title) + "\n")
 						} else {
-							sb.WriteString(fmt.Sprintf("- %s — working on %s\n", ws.Alias, claim.BeadID))
+							sb.WriteString(i18n.T("ready.team.working_on", teamMemberLabel(ws), claim.BeadID) + "\n")
 						}
 					}
 				}
+				if notice := presenceNotice(ws, serverNow()); notice != "" {
+					sb.WriteString(fmt.Sprintf("- %s\n", notice))
+				}
+			}
+			if result.TeamOthers != nil {
+				sb.WriteString(formatTeamOthers(result.TeamOthers) + "\n")
 			}
-			if result.TeamStatusMore {
-				sb.WriteString("  → More agents: `bdh :aweb who`\n")
+			if result.TeamStatusMore || result.TeamOthers != nil {
+				sb.WriteString(i18n.T("ready.team.more") + "\n")
 			}
 		}
 
+		// ready --:mine lists the reservations this agent holds instead
+		sb.WriteString(formatMyReadyLocks(result))
+
 		// Show active locks from OTHER agents so this agent knows what to avoid
 		// Filter out own locks - those are shown in "Your File Reservations"
 		var othersLocks []aweb.ReservationView
 		for _, lock := range result.ReadyLocks {
-			if lock.HolderAlias != result.MyAlias {
+			if lock.HolderAlias != result.MyAlias && result.ReadyView != readyViewMine {
 				othersLocks = append(othersLocks, lock)
 			}
 		}
 		if len(othersLocks) > 0 {
 			maxLocks := defaultReadyLocksLimit
-			if maxLocks <= 0 {
-				maxLocks = defaultReadyLocksLimit
+			if result.Verbosity == config.VerbosityVerbose {
+				maxLocks = len(othersLocks)
 			}
 			sb.WriteString(FormatCoordinationHeader())
-			sb.WriteString("\n## File Reservations\n")
-			sb.WriteString("These files are locked by other agents. Do not edit them:\n")
+			sb.WriteString("\n## " + i18n.T("ready.locks.title") + "\n")
+			sb.WriteString(i18n.T("ready.locks.intro") + "\n")
 			shown := othersLocks
 			if len(shown) > maxLocks {
 				shown = shown[:maxLocks]
 			}
-			now := time.Now()
+			now := serverNow()
 			for _, lock := range shown {
 				expiresIn := formatDuration(ttlRemainingSeconds(lock.ExpiresAt, now))
-				owner := lock.HolderAlias
+				owner := displayAlias(lock.HolderAlias)
 				if owner == "" {
-					owner = "unknown"
+					owner = i18n.T("ready.locks.unknown_owner")
 				}
-				sb.WriteString(fmt.Sprintf("- `%s` — %s (expires in %s)", lock.ResourceKey, owner, expiresIn))
+				sb.WriteString(i18n.T("ready.locks.entry", lock.ResourceKey, owner, expiresIn))
 				if reason, ok := lock.Metadata["reason"].(string); ok && strings.TrimSpace(reason) != "" {
 					sb.WriteString(fmt.Sprintf(" \"%s\"", reason))
 				}
 				sb.WriteString("\n")
 			}
 			if len(othersLocks) > maxLocks {
-				sb.WriteString(fmt.Sprintf("  → %d more locks: `bdh :aweb locks`\n", len(othersLocks)-maxLocks))
+				sb.WriteString(i18n.T("ready.locks.more", len(othersLocks)-maxLocks) + "\n")
 			}
 		}
+		sb.WriteString(formatMergeConflictRisks(result.MergeRisks))
+		sb.WriteString(formatRoleSection(renderRoleSection(result.RoleTemplate, result)))
+	}
+
+	if !result.Rejected && result.Approval != nil {
+		sb.WriteString(i18n.T("approval.granted", result.Approval.ApprovalID, result.Approval.DecidedBy) + "\n\n")
+	}
+
+	// Show who else holds the bead being joined (--:jump-in)
+	if !result.Rejected {
+		sb.WriteString(formatJumpInSection(result.JumpInBeadID, result.JumpInClaimants))
+	}
+
+	// Show notes on the bead being joined (--:jump-in)
+	if !result.Rejected && len(result.BeadNotes) > 0 {
+		sb.WriteString(FormatCoordinationHeader())
+		sb.WriteString("\n## " + i18n.T("notes.title", result.NotesBeadID) + "\n")
+		sb.WriteString(formatNoteLines(result.BeadNotes, ""))
+	}
+	if !result.Rejected && len(result.BeadAttachments) > 0 {
+		sb.WriteString(FormatCoordinationHeader())
+		sb.WriteString("\n## " + i18n.T("attachments.title", result.NotesBeadID) + "\n")
+		sb.WriteString(formatAttachmentLines(result.BeadAttachments, ""))
+	}
+
+	// Show policy adapter guidance (pre-claim checklist, close requirements)
+	// unless it was already shown before bd ran
+	if result.PolicyAdapter != nil && !result.PolicyAdapterShown {
+		sb.WriteString(formatPolicyAdapterSection(result.PolicyAdapter))
 	}
 
+	// Show the closure summary (close --:summary)
+	if result.CloseSummary != nil {
+		sb.WriteString(formatCloseSummarySection(result.CloseSummary))
+	}
+
+	sb.WriteString(formatDependencyNotices(result.DependencyNotices))
+
 	// Show related work in progress (after close command)
 	if len(result.RelatedWork) > 0 {
 		sb.WriteString("\nRELATED WORK IN PROGRESS:\n")
@@ -1115,10 +1798,24 @@ func formatPassthroughOutput(result *PassthroughResult) string {
 		}
 	}
 
+	if result.TxnNotice != "" {
+		sb.WriteString("\nSYNC: " + result.TxnNotice + "\n")
+	}
+
 	// Show sync warning if any
 	if result.SyncWarning != "" {
-		sb.WriteString(fmt.Sprintf("\nWarning: %s\n", result.SyncWarning))
+		sb.WriteString("\n" + i18n.T("warning", result.SyncWarning) + "\n")
+	}
+	if result.SyncBlocked != "" {
+		sb.WriteString(i18n.T("blocked", result.SyncBlocked) + "\n")
+	}
+	if result.SyncStrictFailed != "" {
+		sb.WriteString(i18n.T("sync.failed", result.SyncStrictFailed) + "\n")
+	}
+	for _, w := range result.SyncInputWarnings {
+		sb.WriteString(i18n.T("warning", w) + "\n")
 	}
+	sb.WriteString(formatGraphProblems(result.GraphProblems))
 
 	// YOUR RESERVED FILES section - show lock changes from this command
 	reservedFiles := formatReservedFiles(result)
@@ -1138,7 +1835,8 @@ func formatReservedFiles(result *PassthroughResult) string {
 		len(result.AutoReserved) > 0 ||
 		len(result.AutoRenewed) > 0 ||
 		len(result.AutoReleased) > 0 ||
-		len(result.AutoReserveConflicts) > 0
+		len(result.AutoReserveConflicts) > 0 ||
+		len(result.AutoReserveShared) > 0
 
 	if !hasContent {
 		return ""
@@ -1169,15 +1867,33 @@ func formatReservedFiles(result *PassthroughResult) string {
 			sb.WriteString(fmt.Sprintf("- `%s`\n", path))
 		}
 	}
+	if len(result.AutoReserveShared) > 0 {
+		sb.WriteString("Shared with your squad:\n")
+		for _, shared := range result.AutoReserveShared {
+			heldBy := shared.HeldBy
+			if shared.HeldKey != "" && shared.HeldKey != shared.ResourceKey {
+				heldBy += fmt.Sprintf(" via `%s`", shared.HeldKey)
+			}
+			sb.WriteString(fmt.Sprintf("- `%s` — held by your squadmate %s (shared)\n", shared.ResourceKey, heldBy))
+		}
+	}
 	if len(result.AutoReserveConflicts) > 0 {
 		sb.WriteString("\n**CONFLICT: Do not edit these files** — held by other agents:\n")
 		for _, conflict := range result.AutoReserveConflicts {
 			expiresIn := formatDuration(conflict.RetryAfterSeconds)
-			sb.WriteString(fmt.Sprintf("- `%s` — %s (expires in %s)\n", conflict.ResourceKey, conflict.HeldBy, expiresIn))
+			heldBy := conflict.HeldBy
+			if conflict.HeldKey != "" && conflict.HeldKey != conflict.ResourceKey {
+				heldBy += fmt.Sprintf(" via `%s`", conflict.HeldKey)
+			}
+			sb.WriteString(fmt.Sprintf("- `%s` — %s (expires in %s)\n", conflict.ResourceKey, heldBy, expiresIn))
 		}
+		sb.WriteString(formatTakeoverOutcomes(result.Takeovers, serverNow()))
 		sb.WriteString("\nYour options:\n")
 		sb.WriteString("- Coordinate: `bdh :aweb chat send <alias> \"Need <path>...\"`\n")
 		sb.WriteString("- Stash your changes: `git stash`\n")
+		if !result.TakeoverRequested {
+			sb.WriteString("- Holder gone for hours: run the command again with `--:request-takeover`\n")
+		}
 		sb.WriteString("- Wait for expiry\n")
 	}
 
@@ -1206,12 +1922,18 @@ func rewriteBDHelpOutput(output string) string {
 type passthroughJSON struct {
 	Rejected        bool              `json:"rejected"`
 	RejectionReason string            `json:"rejection_reason,omitempty"`
+	Approval        *client.Approval  `json:"approval,omitempty"`
 	Warning         string            `json:"warning,omitempty"`
 	SyncWarning     string            `json:"sync_warning,omitempty"`
+	GraphProblems   []GraphProblem    `json:"graph_problems,omitempty"`
 	SyncStats       *client.SyncStats `json:"sync_stats,omitempty"`
 	SyncMode        string            `json:"sync_mode,omitempty"`
 
+	SyncInputWarnings []string       `json:"sync_input_warnings,omitempty"`
+	ContextErrors     []ContextError `json:"context_errors,omitempty"`
+
 	BeadsInProgress []client.BeadInProgress `json:"beads_in_progress,omitempty"`
+	JumpInClaimants []JumpInClaimant        `json:"jump_in_claimants,omitempty"`
 
 	AutoReserve *passthroughAutoReserveJSON `json:"auto_reserve,omitempty"`
 
@@ -1221,6 +1943,27 @@ type passthroughJSON struct {
 	BDStderr   string          `json:"bd_stderr,omitempty"`
 
 	ReadyContext *passthroughReadyContextJSON `json:"ready_context,omitempty"`
+
+	Summary CommandSummary `json:"summary"`
+
+	PolicyAdapter *PolicyAdapter `json:"policy_adapter,omitempty"`
+
+	BeadNotes []client.BeadNote `json:"bead_notes,omitempty"`
+
+	BeadAttachments []client.BeadAttachment `json:"bead_attachments,omitempty"`
+
+	CloseSummary *CloseSummary `json:"close_summary,omitempty"`
+
+	DependencyNotices []DependencyNotice `json:"dependency_notices,omitempty"`
+
+	Renamed string `json:"renamed,omitempty"`
+
+	Blocked     string `json:"blocked,omitempty"`
+	SyncBlocked string `json:"sync_blocked,omitempty"`
+	SyncFailed  string `json:"sync_failed,omitempty"`
+	QueuedSync  string `json:"queued_sync,omitempty"`
+
+	Txn string `json:"txn,omitempty"`
 }
 
 type passthroughAutoReserveJSON struct {
@@ -1229,9 +1972,12 @@ type passthroughAutoReserveJSON struct {
 	Renewed   []string              `json:"renewed,omitempty"`
 	Released  []string              `json:"released,omitempty"`
 	Conflicts []ReservationConflict `json:"conflicts,omitempty"`
+	Shared    []ReservationConflict `json:"shared,omitempty"`
+	Takeovers []TakeoverOutcome     `json:"takeovers,omitempty"`
 }
 
 type passthroughReadyContextJSON struct {
+	View             string                 `json:"view,omitempty"`
 	MyClaims         []client.Claim         `json:"my_claims,omitempty"`
 	MyFocusApexID    string                 `json:"my_focus_apex_id,omitempty"`
 	MyFocusApexTitle string                 `json:"my_focus_apex_title,omitempty"`
@@ -1239,7 +1985,16 @@ type passthroughReadyContextJSON struct {
 	TeamStatus       []client.Workspace     `json:"team_status,omitempty"`
 	TeamStatusLimit  int                    `json:"team_status_limit,omitempty"`
 	TeamStatusMore   bool                   `json:"team_status_more,omitempty"`
+	TeamOthers       *client.TeamOthers     `json:"team_others,omitempty"`
 	ActiveLocks      []aweb.ReservationView `json:"active_locks,omitempty"`
+	MergeRisks       []MergeConflictRisk    `json:"merge_conflict_risks,omitempty"`
+	RoleSection      *RoleSection           `json:"role_section,omitempty"`
+	FocusSuggestions []FocusSuggestion      `json:"focus_suggestions,omitempty"`
+
+	Announcement *client.Announcement `json:"announcement,omitempty"`
+	Pinned       []PinStatus          `json:"pinned,omitempty"`
+
+	PendingChats []PendingConversation `json:"pending_chats,omitempty"`
 }
 
 func formatPassthroughOutputJSON(result *PassthroughResult) string {
@@ -1253,19 +2008,22 @@ func formatPassthroughOutputJSON(result *PassthroughResult) string {
 	}
 
 	var autoReserve *passthroughAutoReserveJSON
-	if result.AutoReserveWarning != "" || len(result.AutoReserved) > 0 || len(result.AutoRenewed) > 0 || len(result.AutoReleased) > 0 || len(result.AutoReserveConflicts) > 0 {
+	if result.AutoReserveWarning != "" || len(result.AutoReserved) > 0 || len(result.AutoRenewed) > 0 || len(result.AutoReleased) > 0 || len(result.AutoReserveConflicts) > 0 || len(result.AutoReserveShared) > 0 {
 		autoReserve = &passthroughAutoReserveJSON{
 			Warning:   result.AutoReserveWarning,
 			Reserved:  result.AutoReserved,
 			Renewed:   result.AutoRenewed,
 			Released:  result.AutoReleased,
 			Conflicts: result.AutoReserveConflicts,
+			Shared:    result.AutoReserveShared,
+			Takeovers: result.Takeovers,
 		}
 	}
 
 	var readyContext *passthroughReadyContextJSON
 	if result.IsReadyCommand {
 		readyContext = &passthroughReadyContextJSON{
+			View:             result.ReadyView,
 			MyClaims:         result.MyClaims,
 			MyFocusApexID:    result.MyFocusApexID,
 			MyFocusApexTitle: result.MyFocusApexTitle,
@@ -1273,24 +2031,55 @@ func formatPassthroughOutputJSON(result *PassthroughResult) string {
 			TeamStatus:       result.TeamStatus,
 			TeamStatusLimit:  result.TeamStatusLimit,
 			TeamStatusMore:   result.TeamStatusMore,
+			TeamOthers:       result.TeamOthers,
 			ActiveLocks:      result.ReadyLocks,
+			MergeRisks:       result.MergeRisks,
+			RoleSection:      renderRoleSection(result.RoleTemplate, result),
+			FocusSuggestions: result.FocusSuggestions,
+
+			Announcement: result.Announcement,
+			Pinned:       result.Pins,
+
+			PendingChats: result.ReadyPendingChats,
 		}
 	}
 
 	output := passthroughJSON{
 		Rejected:        result.Rejected,
 		RejectionReason: result.RejectionReason,
+		Approval:        result.Approval,
 		Warning:         result.Warning,
 		SyncWarning:     result.SyncWarning,
+		GraphProblems:   result.GraphProblems,
 		SyncStats:       result.SyncStats,
 		SyncMode:        result.SyncMode,
 		BeadsInProgress: result.BeadsInProgress,
+		JumpInClaimants: result.JumpInClaimants,
 		AutoReserve:     autoReserve,
 		BDExitCode:      result.ExitCode,
+		Summary:         summarizePassthrough(result),
 		BDStdout:        bdJSON,
 		BDText:          bdText,
 		BDStderr:        strings.TrimSpace(result.Stderr),
 		ReadyContext:    readyContext,
+		PolicyAdapter:   result.PolicyAdapter,
+		BeadNotes:       result.BeadNotes,
+		BeadAttachments: result.BeadAttachments,
+		CloseSummary:    result.CloseSummary,
+
+		DependencyNotices: result.DependencyNotices,
+
+		Renamed: result.Renamed,
+
+		Blocked:     result.Blocked,
+		SyncBlocked: result.SyncBlocked,
+		SyncFailed:  result.SyncStrictFailed,
+		QueuedSync:  result.QueuedSyncNotice,
+
+		Txn: result.TxnNotice,
+
+		SyncInputWarnings: result.SyncInputWarnings,
+		ContextErrors:     result.ContextErrors,
 	}
 
 	data, err := json.MarshalIndent(output, "", "  ")
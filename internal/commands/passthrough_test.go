@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -13,8 +14,10 @@ import (
 	"time"
 
 	aweb "github.com/awebai/aw"
+	"github.com/beadhub/bdh/internal/bd"
 	"github.com/beadhub/bdh/internal/client"
 	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
 )
 
 func TestPassthrough_PreservesArgsWhenInvokingBd(t *testing.T) {
@@ -92,7 +95,7 @@ func TestPassthrough_PreservesArgsWhenInvokingBd(t *testing.T) {
 		"reason with spaces",
 	}
 
-	result, err := runPassthrough(args)
+	result, err := runPassthrough(context.Background(), args)
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
 	}
@@ -199,7 +202,7 @@ func TestPassthrough_ReadyUsesBoundedTeamQuery(t *testing.T) {
 
 	t.Setenv("BEADHUB_API_KEY", "test-api-key")
 
-	_, err := runPassthrough([]string{"ready"})
+	_, err := runPassthrough(context.Background(), []string{"ready"})
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
 	}
@@ -334,7 +337,7 @@ func TestPassthrough_RunsBdWhenServerUnreachable(t *testing.T) {
 	cfg.Save()
 
 	// Run passthrough with a simple bd command
-	result, err := runPassthrough([]string{"--version"})
+	result, err := runPassthrough(context.Background(), []string{"--version"})
 
 	// Should NOT error - bd should still run
 	if err != nil {
@@ -367,7 +370,7 @@ func TestPassthrough_ShowsWarningWhenServerUnreachable(t *testing.T) {
 	}
 	cfg.Save()
 
-	result, _ := runPassthrough([]string{"--version"})
+	result, _ := runPassthrough(context.Background(), []string{"--version"})
 
 	// Should contain a warning about server being unreachable
 	if !strings.Contains(result.Warning, "BeadHub unreachable") {
@@ -411,7 +414,7 @@ func TestPassthrough_RunsBdWhenApproved(t *testing.T) {
 	}
 	cfg.Save()
 
-	result, err := runPassthrough([]string{"--version"})
+	result, err := runPassthrough(context.Background(), []string{"--version"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -469,7 +472,7 @@ func TestPassthrough_RejectsClaimWithError(t *testing.T) {
 	cfg.Save()
 
 	// Simulate claiming a bead that's already taken
-	result, err := runPassthrough([]string{"update", "bd-42", "--status", "in_progress"})
+	result, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--status", "in_progress"})
 
 	// Should return rejection result (not a Go error, but rejection info)
 	if err != nil {
@@ -517,7 +520,7 @@ func TestPassthrough_RunsBdWhenServerReturns5xx(t *testing.T) {
 	}
 	cfg.Save()
 
-	result, err := runPassthrough([]string{"--version"})
+	result, err := runPassthrough(context.Background(), []string{"--version"})
 
 	// Should NOT error - bd should still run (non-blocking design)
 	if err != nil {
@@ -555,7 +558,7 @@ func TestPassthrough_EmptyArgsReturnsError(t *testing.T) {
 	}
 	cfg.Save()
 
-	_, err := runPassthrough([]string{})
+	_, err := runPassthrough(context.Background(), []string{})
 
 	if err == nil {
 		t.Fatal("runPassthrough should error on empty args")
@@ -648,7 +651,7 @@ esac
 	}
 	cfg.Save()
 
-	_, err := runPassthrough([]string{"create", "--title", "Test", "--json"})
+	_, err := runPassthrough(context.Background(), []string{"create", "--title", "Test", "--json"})
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
 	}
@@ -714,7 +717,7 @@ func TestPassthrough_DoesNotSyncOnBdFailure(t *testing.T) {
 	cfg.Save()
 
 	// Run a mutation command that will fail (create with no args)
-	result, _ := runPassthrough([]string{"create"})
+	result, _ := runPassthrough(context.Background(), []string{"create"})
 
 	// bd create without args should fail
 	if result.ExitCode == 0 {
@@ -797,7 +800,7 @@ esac
 	}
 	cfg.Save()
 
-	result, err := runPassthrough([]string{"create", "--title", "Test", "--json"})
+	result, err := runPassthrough(context.Background(), []string{"create", "--title", "Test", "--json"})
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
 	}
@@ -818,7 +821,7 @@ func TestPassthrough_RequiresBeadhubConfig(t *testing.T) {
 	os.MkdirAll(".beads", 0755)
 	// No .beadhub file
 
-	result, err := runPassthrough([]string{"--version"})
+	result, err := runPassthrough(context.Background(), []string{"--version"})
 	if err != nil {
 		t.Fatalf("runPassthrough should succeed without .beadhub, got: %v", err)
 	}
@@ -939,7 +942,7 @@ func TestPassthrough_LocalConfigMissingPath(t *testing.T) {
 	cfg.Save()
 
 	// Run with --:local-config but no path (uses default config)
-	result, err := runPassthrough([]string{"--:local-config", "--version"})
+	result, err := runPassthrough(context.Background(), []string{"--:local-config", "--version"})
 
 	// Should still work (falls back to empty path which means default)
 	// --:local-config with no value means hasFlag=true, path="" -> no SetPath called
@@ -994,7 +997,7 @@ func TestPassthrough_LocalConfigUsesCustomPath(t *testing.T) {
 	config.SetPath("") // Reset for the test
 
 	// Run passthrough with --:local-config
-	result, err := runPassthrough([]string{"--:local-config", customPath, "--version"})
+	result, err := runPassthrough(context.Background(), []string{"--:local-config", customPath, "--version"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -1154,7 +1157,7 @@ func TestPassthrough_JumpInOverridesRejection(t *testing.T) {
 	cfg.Save()
 
 	// Run with --:jump-in flag
-	result, err := runPassthrough([]string{"update", "bd-42", "--status", "in_progress", "--:jump-in", "I'll handle the tests"})
+	result, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--status", "in_progress", "--:jump-in", "I'll handle the tests"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough should not error with --:jump-in, got: %v", err)
@@ -1201,7 +1204,7 @@ func TestPassthrough_JumpInRequiresMessage(t *testing.T) {
 	cfg.Save()
 
 	// --:jump-in without message should error
-	_, err := runPassthrough([]string{"update", "bd-42", "--status", "in_progress", "--:jump-in"})
+	_, err := runPassthrough(context.Background(), []string{"update", "bd-42", "--status", "in_progress", "--:jump-in"})
 
 	if err == nil {
 		t.Fatal("runPassthrough should error when --:jump-in has no message")
@@ -1249,7 +1252,7 @@ func TestPassthrough_JumpInWarnsWhenBeadIDNotExtracted(t *testing.T) {
 	cfg.Save()
 
 	// Use --:jump-in with a command that doesn't have a bead ID (like "show")
-	result, err := runPassthrough([]string{"show", "--:jump-in", "testing"})
+	result, err := runPassthrough(context.Background(), []string{"show", "--:jump-in", "testing"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -1305,7 +1308,7 @@ func TestPassthrough_CloseRejectsWhenOthersHaveClaims(t *testing.T) {
 	cfg.Save()
 
 	// Try to close a bead that another agent is working on
-	result, err := runPassthrough([]string{"close", "bd-42", "--reason", "done"})
+	result, err := runPassthrough(context.Background(), []string{"close", "bd-42", "--reason", "done"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -1380,7 +1383,7 @@ func TestPassthrough_CloseWithJumpInWhenOthersHaveClaims(t *testing.T) {
 	cfg.Save()
 
 	// Close with --:jump-in to override
-	result, err := runPassthrough([]string{"close", "bd-42", "--reason", "done", "--:jump-in", "Closing because tests pass"})
+	result, err := runPassthrough(context.Background(), []string{"close", "bd-42", "--reason", "done", "--:jump-in", "Closing because tests pass"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -1444,7 +1447,7 @@ func TestPassthrough_CloseWorksWhenOnlyClaimant(t *testing.T) {
 	cfg.Save()
 
 	// Close when we're the only claimant - should work without --:jump-in
-	result, err := runPassthrough([]string{"close", "bd-42", "--reason", "done"})
+	result, err := runPassthrough(context.Background(), []string{"close", "bd-42", "--reason", "done"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -1654,7 +1657,7 @@ func TestPassthrough_CloseShowsRelatedWorkInProgress(t *testing.T) {
 	}
 	cfg.Save()
 
-	result, err := runPassthrough([]string{"close", "bd-42", "--reason", "done"})
+	result, err := runPassthrough(context.Background(), []string{"close", "bd-42", "--reason", "done"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -1856,7 +1859,7 @@ func TestPassthrough_CloseNoSuggestionsWhenNoRelatedWork(t *testing.T) {
 	}
 	cfg.Save()
 
-	result, _ := runPassthrough([]string{"close", "bd-42", "--reason", "done"})
+	result, _ := runPassthrough(context.Background(), []string{"close", "bd-42", "--reason", "done"})
 	output := formatPassthroughOutput(result)
 
 	// Should NOT show RELATED WORK section when no related work
@@ -1912,7 +1915,7 @@ func TestPassthrough_JumpInNotNeededWhenApproved(t *testing.T) {
 	cfg.Save()
 
 	// --:jump-in when already approved should still work but not send notification
-	_, err := runPassthrough([]string{"--version", "--:jump-in", "Just in case"})
+	_, err := runPassthrough(context.Background(), []string{"--version", "--:jump-in", "Just in case"})
 
 	if err != nil {
 		t.Fatalf("runPassthrough error: %v", err)
@@ -2104,3 +2107,91 @@ func TestIsWorkspaceRecentlyActive(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeIssuesJSONL_WarnsInsteadOfSilentlySkipping(t *testing.T) {
+	input := "\xef\xbb\xbf{\"id\":\"bd-1\",\"status\":\"open\"}\r\n" +
+		"{not json}\r\n" +
+		"\r\n" +
+		"{\"id\":\"bd-2\",\"title\":\"caf\xe9\",\"status\":\"open\"}\n"
+
+	issues, warnings, err := decodeIssuesJSONL(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("decodeIssuesJSONL: %v", err)
+	}
+	if len(issues) != 2 || issues[0].ID != "bd-1" || issues[1].ID != "bd-2" {
+		t.Fatalf("expected bd-1 and bd-2, got %+v", issues)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if warnings[0].Line != 2 || !strings.Contains(warnings[0].Message, "invalid JSON") {
+		t.Errorf("warning 0 = %v", warnings[0])
+	}
+	if warnings[1].Line != 4 || !strings.Contains(warnings[1].Message, "invalid UTF-8") {
+		t.Errorf("warning 1 = %v", warnings[1])
+	}
+}
+
+func TestFormatLineWarnings_Capped(t *testing.T) {
+	var warnings []sync.LineWarning
+	for i := 1; i <= maxIssueWarningsShown+3; i++ {
+		warnings = append(warnings, sync.LineWarning{Line: i, Message: "invalid JSON - skipped"})
+	}
+	out := formatLineWarnings("issues.jsonl", warnings)
+	if len(out) != maxIssueWarningsShown+1 {
+		t.Fatalf("expected %d lines, got %d: %v", maxIssueWarningsShown+1, len(out), out)
+	}
+	if out[0] != "issues.jsonl: line 1: invalid JSON - skipped" {
+		t.Errorf("out[0] = %q", out[0])
+	}
+	if !strings.Contains(out[len(out)-1], "3 more line(s)") {
+		t.Errorf("last line = %q", out[len(out)-1])
+	}
+}
+
+func TestPassthrough_MockBdSyncsEndToEnd(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, "")
+
+	var synced []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/bdh/command":
+			json.NewEncoder(w).Encode(map[string]any{"approved": true, "context": map[string]any{}})
+		case "/v1/bdh/sync":
+			var req client.SyncRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			synced = append(synced, req.IssuesJSONL, req.ChangedIssues)
+			json.NewEncoder(w).Encode(map[string]any{"synced": true, "issues_count": 1})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := runPassthrough(context.Background(), []string{"create", "Mock issue", "--json"})
+	if err != nil {
+		t.Fatalf("runPassthrough error: %v", err)
+	}
+	if result.ExitCode != 0 || result.SyncWarning != "" {
+		t.Fatalf("result = %+v", result)
+	}
+	if len(synced) == 0 || !strings.Contains(strings.Join(synced, "\n"), `"title":"Mock issue"`) {
+		t.Errorf("synced = %q", synced)
+	}
+}
@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Pinned beads are kept in .beadhub-cache/pins.json, local to the workspace.
+// ready and :status list them first, with their current status and
+// claimants, whatever view or filters are in effect, so an agent tracking a
+// long-running blocker sees it again at the start of every session.
+
+// maxPins bounds the pin list; pins are for the few beads worth watching.
+const maxPins = 20
+
+// PinnedBead is a pin as stored.
+type PinnedBead struct {
+	BeadID   string `json:"bead_id"`
+	PinnedAt string `json:"pinned_at"`
+}
+
+// PinStatus is a pinned bead with its current state.
+type PinStatus struct {
+	BeadID    string   `json:"bead_id"`
+	Title     string   `json:"title,omitempty"`
+	Status    string   `json:"status,omitempty"` // empty when the bead is not in issues.jsonl
+	Claimants []string `json:"claimants"`
+	PinnedAt  string   `json:"pinned_at"`
+
+	claimsUnknown bool
+}
+
+var pinJSON bool
+
+var pinCmd = &cobra.Command{
+	Use:   ":pin <bead-id>...",
+	Short: "Pin beads to the top of ready and :status",
+	Long: `Pin beads you need to keep an eye on, such as a long-running blocker.
+Pinned beads are listed first in ready and :status output with their
+current status and claimants, regardless of filters. Pins are local to
+this workspace.
+
+Examples:
+  bdh :pin bd-42
+  bdh :pin list
+  bdh :pin remove bd-42`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pinned, err := addPins(args, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Pinned %s\n", strings.Join(pinned, ", "))
+		return nil
+	},
+}
+
+var pinListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pinned beads with their status and claimants",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		path, err := pinsPath()
+		if err != nil {
+			return err
+		}
+		pins, err := loadPins(path)
+		if err != nil {
+			return err
+		}
+		var c BeadHubAPI
+		if cfg, err := config.Load(); err == nil {
+			c = newBeadHubClient(ctx, cfg.BeadhubURL)
+		}
+		statuses := loadPinStatuses(ctx, c, pins)
+		if pinJSON {
+			if statuses == nil {
+				statuses = []PinStatus{}
+			}
+			fmt.Print(marshalJSONOrFallback(statuses))
+			return nil
+		}
+		if len(statuses) == 0 {
+			fmt.Println("No pinned beads. Pin one with: bdh :pin <bead-id>")
+			return nil
+		}
+		fmt.Print(formatPinnedSection(statuses))
+		return nil
+	},
+}
+
+var pinRemoveCmd = &cobra.Command{
+	Use:   "remove <bead-id>...",
+	Short: "Unpin beads",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := removePins(args)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Unpinned %s\n", strings.Join(removed, ", "))
+		return nil
+	},
+}
+
+func init() {
+	pinListCmd.Flags().BoolVar(&pinJSON, "json", false, "Output as JSON")
+	pinCmd.AddCommand(pinListCmd)
+	pinCmd.AddCommand(pinRemoveCmd)
+}
+
+func pinsPath() (string, error) {
+	return cacheFilePath("pins.json")
+}
+
+func loadPins(path string) ([]PinnedBead, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var pins []PinnedBead
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return pins, nil
+}
+
+func savePins(path string, pins []PinnedBead) error {
+	if len(pins) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONAtomic(path, pins)
+}
+
+// addPins pins beadIDs, checking them against issues.jsonl when it can be
+// read. Returns the IDs newly pinned.
+func addPins(beadIDs []string, now time.Time) ([]string, error) {
+	path, err := pinsPath()
+	if err != nil {
+		return nil, err
+	}
+	pins, err := loadPins(path)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	issues, issuesErr := loadIssues()
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+
+	var added []string
+	for _, id := range beadIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil, fmt.Errorf("bead ID cannot be empty")
+		}
+		if issuesErr == nil && !known[id] {
+			return nil, fmt.Errorf("no bead %s in .beads/issues.jsonl", id)
+		}
+		if pinIndex(pins, id) >= 0 {
+			continue
+		}
+		if len(pins) >= maxPins {
+			return nil, fmt.Errorf("already %d pins - remove some with: bdh :pin remove <bead-id>", maxPins)
+		}
+		pins = append(pins, PinnedBead{BeadID: id, PinnedAt: now.UTC().Format(time.RFC3339)})
+		added = append(added, id)
+	}
+	if len(added) == 0 {
+		return nil, fmt.Errorf("%s already pinned", strings.Join(beadIDs, ", "))
+	}
+	if err := savePins(path, pins); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// removePins unpins beadIDs. Every ID must be pinned.
+func removePins(beadIDs []string) ([]string, error) {
+	path, err := pinsPath()
+	if err != nil {
+		return nil, err
+	}
+	pins, err := loadPins(path)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, id := range beadIDs {
+		id = strings.TrimSpace(id)
+		i := pinIndex(pins, id)
+		if i < 0 {
+			return nil, fmt.Errorf("%s is not pinned", id)
+		}
+		pins = append(pins[:i], pins[i+1:]...)
+		removed = append(removed, id)
+	}
+	if err := savePins(path, pins); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+func pinIndex(pins []PinnedBead, beadID string) int {
+	for i, p := range pins {
+		if p.BeadID == beadID {
+			return i
+		}
+	}
+	return -1
+}
+
+// workspacePins returns this workspace's pins, or nil if there are none or
+// they cannot be read.
+func workspacePins() []PinnedBead {
+	path, err := pinsPath()
+	if err != nil {
+		return nil
+	}
+	pins, _ := loadPins(path)
+	return pins
+}
+
+// loadPinStatuses looks up the pins' status in issues.jsonl and their
+// claimants on BeadHub (skipped when c is nil). Both are best-effort.
+func loadPinStatuses(ctx context.Context, c BeadHubAPI, pins []PinnedBead) []PinStatus {
+	if len(pins) == 0 {
+		return nil
+	}
+	issues, _ := loadIssues()
+	var workspaces []client.Workspace
+	claimsKnown := false
+	if c != nil {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.Workspaces(ctx, &client.WorkspacesRequest{IncludeClaims: true, Limit: maxWorkspaceQueryLimit})
+		cancel()
+		if err == nil {
+			workspaces, claimsKnown = resp.Workspaces, true
+		}
+	}
+	return pinStatuses(pins, issues, workspaces, claimsKnown)
+}
+
+// pinStatuses joins pins with issues and the workspaces' claims, in pin
+// order.
+func pinStatuses(pins []PinnedBead, issues []Issue, workspaces []client.Workspace, claimsKnown bool) []PinStatus {
+	byID := make(map[string]Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	claimants := make(map[string][]string)
+	for _, ws := range workspaces {
+		for _, claim := range ws.Claims {
+			claimants[claim.BeadID] = append(claimants[claim.BeadID], ws.Alias)
+		}
+	}
+	out := make([]PinStatus, 0, len(pins))
+	for _, pin := range pins {
+		status := PinStatus{BeadID: pin.BeadID, PinnedAt: pin.PinnedAt, Claimants: []string{}, claimsUnknown: !claimsKnown}
+		if issue, ok := byID[pin.BeadID]; ok {
+			status.Title, status.Status = issue.Title, issue.Status
+		}
+		if aliases := claimants[pin.BeadID]; len(aliases) > 0 {
+			status.Claimants = append(status.Claimants, aliases...)
+			sort.Strings(status.Claimants)
+		}
+		out = append(out, status)
+	}
+	return out
+}
+
+func formatPinnedSection(pins []PinStatus) string {
+	if len(pins) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("## Pinned\n")
+	for _, pin := range pins {
+		line := "- " + pin.BeadID
+		if pin.Title != "" {
+			line += fmt.Sprintf(" %q", pin.Title)
+		}
+		if pin.Status != "" {
+			line += " — " + pin.Status
+		} else {
+			line += " — not in issues.jsonl"
+		}
+		switch {
+		case len(pin.Claimants) > 0:
+			line += " — claimed by " + strings.Join(pin.Claimants, ", ")
+		case !pin.claimsUnknown && pin.Status != "closed":
+			line += " — unclaimed"
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+)
+
+func TestPins_AddListRemove(t *testing.T) {
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"Flaky CI","status":"blocked"}
+{"id":"bd-2","title":"Release","status":"open"}
+`)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	added, err := addPins([]string{"bd-1", "bd-2"}, now)
+	if err != nil || strings.Join(added, ",") != "bd-1,bd-2" {
+		t.Fatalf("addPins = %v, %v", added, err)
+	}
+	if added, err := addPins([]string{"bd-2", "bd-1"}, now); err == nil || !strings.Contains(err.Error(), "already pinned") {
+		t.Errorf("expected already pinned, got %v, %v", added, err)
+	}
+	if _, err := addPins([]string{"bd-99"}, now); err == nil || !strings.Contains(err.Error(), "no bead bd-99") {
+		t.Errorf("expected an unknown bead error, got %v", err)
+	}
+
+	fake := clienttest.New()
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-a", Alias: "alice", Claims: []client.Claim{{BeadID: "bd-1"}}})
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-c", Alias: "carol", Claims: []client.Claim{{BeadID: "bd-1"}}})
+	statuses := loadPinStatuses(context.Background(), fake, workspacePins())
+	want := "## Pinned\n" +
+		"- bd-1 \"Flaky CI\" — blocked — claimed by alice, carol\n" +
+		"- bd-2 \"Release\" — open — unclaimed\n\n"
+	if got := formatPinnedSection(statuses); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := removePins([]string{"bd-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := removePins([]string{"bd-1"}); err == nil || !strings.Contains(err.Error(), "not pinned") {
+		t.Errorf("expected not pinned, got %v", err)
+	}
+	if pins := workspacePins(); len(pins) != 1 || pins[0].BeadID != "bd-2" {
+		t.Errorf("unexpected pins %+v", pins)
+	}
+}
+
+func TestPinStatuses_UnknownClaimsAndMissingBead(t *testing.T) {
+	pins := []PinnedBead{{BeadID: "bd-1"}, {BeadID: "bd-gone"}}
+	issues := []Issue{{ID: "bd-1", Title: "Flaky CI", Status: "open"}}
+
+	got := formatPinnedSection(pinStatuses(pins, issues, nil, false))
+	want := "## Pinned\n" +
+		"- bd-1 \"Flaky CI\" — open\n" +
+		"- bd-gone — not in issues.jsonl\n\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if formatPinnedSection(nil) != "" {
+		t.Error("no pins should print nothing")
+	}
+}
+
+func TestFormatStatusOutput_PinsFirst(t *testing.T) {
+	result := &StatusResult{Alias: "claude-be", Pinned: []PinStatus{{BeadID: "bd-1", Status: "open", Claimants: []string{}}}}
+	out := formatStatusOutput(result, false)
+	if !strings.HasPrefix(out, "## Pinned\n- bd-1 — open — unclaimed\n") {
+		t.Errorf("expected pins at the top:\n%s", out)
+	}
+}
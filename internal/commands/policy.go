@@ -62,17 +62,9 @@ type PolicyResult struct {
 }
 
 func runPolicy(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
-		}
-		return fmt.Errorf("loading config: %w", err)
-	}
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid .beadhub config: %w", err)
-	}
-	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 		return err
 	}
 
@@ -102,7 +94,7 @@ func runPolicy(cmd *cobra.Command, args []string) error {
 	if root, err := config.WorkspaceRoot(); err == nil {
 		workspaceRoot = root
 	}
-	result, err := fetchActivePolicyCachedWithConfig(cfg, role, policyOnlySelected, workspaceRoot)
+	result, err := fetchActivePolicyCachedWithConfig(ctx, cfg, role, policyOnlySelected, workspaceRoot)
 	if err != nil {
 		return err
 	}
@@ -112,9 +104,14 @@ func runPolicy(cmd *cobra.Command, args []string) error {
 }
 
 // fetchActivePolicyCachedWithConfig fetches the active policy bundle with workspace-local caching and offline fallback (for testing).
-func fetchActivePolicyCachedWithConfig(cfg *config.Config, role string, onlySelected bool, workspaceRoot string) (*PolicyResult, error) {
+func fetchActivePolicyCachedWithConfig(ctx context.Context, cfg *config.Config, role string, onlySelected bool, workspaceRoot string) (*PolicyResult, error) {
 	cacheDir := filepath.Join(workspaceRoot, ".beadhub-cache")
-	cachePath := filepath.Join(cacheDir, policyCacheFilename(role, onlySelected))
+	cacheName := policyCacheFilename(role, onlySelected)
+	if cfg.ActiveOverride != "" {
+		// Policies are per project; keep overridden projects' caches apart.
+		cacheName = cfg.ProjectSlug + "-" + cacheName
+	}
+	cachePath := filepath.Join(cacheDir, cacheName)
 
 	now := time.Now()
 	cache, err := readPolicyCache(cachePath)
@@ -130,7 +127,7 @@ func fetchActivePolicyCachedWithConfig(cfg *config.Config, role string, onlySele
 		return result, nil
 	}
 
-	c, err := newBeadHubClientRequired(cfg.BeadhubURL)
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
 	if err != nil {
 		if cache != nil && cache.Policy != nil {
 			result := policyResultFromPolicy(cache.Policy, role, onlySelected)
@@ -144,7 +141,7 @@ func fetchActivePolicyCachedWithConfig(cfg *config.Config, role string, onlySele
 		}
 		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	fetchCtx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	var opts *client.ActivePolicyFetchOptions
@@ -155,14 +152,14 @@ func fetchActivePolicyCachedWithConfig(cfg *config.Config, role string, onlySele
 		}
 	}
 
-	fetchResp, fetchErr := c.ActivePolicyFetch(ctx, &client.ActivePolicyRequest{
+	fetchResp, fetchErr := c.ActivePolicyFetch(fetchCtx, &client.ActivePolicyRequest{
 		Role:         role,
 		OnlySelected: &onlySelected,
 	}, opts)
 	if fetchErr != nil {
 		var clientErr *client.Error
 		if errors.As(fetchErr, &clientErr) && role != "" && clientErr.StatusCode == 400 {
-			if roles, rolesErr := fetchAvailablePolicyRolesWithConfig(cfg); rolesErr == nil && len(roles) > 0 {
+			if roles, rolesErr := fetchAvailablePolicyRolesWithConfig(ctx, cfg); rolesErr == nil && len(roles) > 0 {
 				return nil, fmt.Errorf("role %q rejected by server (available roles: %s)", role, strings.Join(roles, ", "))
 			}
 		}
@@ -235,19 +232,19 @@ func fetchActivePolicyCachedWithConfig(cfg *config.Config, role string, onlySele
 }
 
 // fetchActivePolicyWithConfig fetches the active policy bundle for a workspace's project (for testing).
-func fetchActivePolicyWithConfig(cfg *config.Config, role string, onlySelected bool) (*PolicyResult, error) {
-	c := newBeadHubClient(cfg.BeadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+func fetchActivePolicyWithConfig(ctx context.Context, cfg *config.Config, role string, onlySelected bool) (*PolicyResult, error) {
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	fetchCtx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
-	resp, err := c.ActivePolicy(ctx, &client.ActivePolicyRequest{
+	resp, err := c.ActivePolicy(fetchCtx, &client.ActivePolicyRequest{
 		Role:         role,
 		OnlySelected: &onlySelected,
 	})
 	if err != nil {
 		var clientErr *client.Error
 		if errors.As(err, &clientErr) && role != "" && clientErr.StatusCode == 400 {
-			if roles, rolesErr := fetchAvailablePolicyRolesWithConfig(cfg); rolesErr == nil && len(roles) > 0 {
+			if roles, rolesErr := fetchAvailablePolicyRolesWithConfig(ctx, cfg); rolesErr == nil && len(roles) > 0 {
 				return nil, fmt.Errorf("role %q rejected by server (available roles: %s)", role, strings.Join(roles, ", "))
 			}
 		}
@@ -303,9 +300,9 @@ func policyResultFromPolicy(policy *client.ActivePolicyResponse, role string, on
 	}
 }
 
-func fetchAvailablePolicyRolesWithConfig(cfg *config.Config) ([]string, error) {
-	c := newBeadHubClient(cfg.BeadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+func fetchAvailablePolicyRolesWithConfig(ctx context.Context, cfg *config.Config) ([]string, error) {
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	onlySelected := false
@@ -346,7 +343,7 @@ func formatPolicyPlain(result *PolicyResult) string {
 
 	sb.WriteString(fmt.Sprintf("Policy: v%d", p.Version))
 	if p.UpdatedAt != "" {
-		sb.WriteString(fmt.Sprintf(" (updated %s)", formatTimeAgo(p.UpdatedAt)))
+		sb.WriteString(fmt.Sprintf(" (updated %s)", formatTimestamp(p.UpdatedAt)))
 	}
 	sb.WriteString("\n")
 	sb.WriteString(fmt.Sprintf("Role: %s\n\n", result.Role))
@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Policy adapters contract
+//
+// The policy bundle's "adapters" map lets a project attach guidance to specific
+// moments in the bdh workflow. bdh understands these keys:
+//
+//	pre_claim_checklist  - shown before bd claims a bead (update --status in_progress)
+//	close_requirements   - shown before bd closes a bead
+//	ready_template       - a section added to ready output, its body_md a Go
+//	                       text/template (see role_templates.go)
+//
+// Each adapter value may be:
+//   - a string (markdown body)
+//   - a list of strings (checklist items)
+//   - an object with optional "title", "body_md", "items", and "roles"; "roles"
+//     maps a role name to an adapter value that replaces the default for that role
+//
+// Unknown keys and malformed values are ignored.
+const (
+	policyAdapterPreClaim = "pre_claim_checklist"
+	policyAdapterClose    = "close_requirements"
+	policyAdapterReady    = "ready_template"
+)
+
+// policyAdapterPreflightOut receives the guidance shown before bd runs.
+var policyAdapterPreflightOut io.Writer = os.Stderr
+
+// PolicyAdapter is the resolved content of a single adapter for a role.
+type PolicyAdapter struct {
+	Key    string   `json:"key"`
+	Title  string   `json:"title,omitempty"`
+	BodyMD string   `json:"body_md,omitempty"`
+	Items  []string `json:"items,omitempty"`
+
+	// Answers maps a checklist item to checklistDone or checklistNA once it
+	// was confirmed before a close.
+	Answers map[string]string `json:"answers,omitempty"`
+}
+
+func (a *PolicyAdapter) isEmpty() bool {
+	return a == nil || (strings.TrimSpace(a.BodyMD) == "" && len(a.Items) == 0)
+}
+
+// resolvePolicyAdapter extracts the adapter for key, applying role overrides.
+// Returns nil when the policy has no usable adapter for the key.
+func resolvePolicyAdapter(policy *client.ActivePolicyResponse, key, role string) *PolicyAdapter {
+	if policy == nil || policy.Adapters == nil {
+		return nil
+	}
+	raw, ok := policy.Adapters[key]
+	if !ok {
+		return nil
+	}
+
+	if m, ok := raw.(map[string]any); ok {
+		if roles, ok := m["roles"].(map[string]any); ok && role != "" {
+			if roleRaw, ok := roles[config.NormalizeRole(role)]; ok {
+				if adapter := parsePolicyAdapterValue(key, roleRaw); !adapter.isEmpty() {
+					return adapter
+				}
+			}
+		}
+	}
+
+	adapter := parsePolicyAdapterValue(key, raw)
+	if adapter.isEmpty() {
+		return nil
+	}
+	return adapter
+}
+
+func parsePolicyAdapterValue(key string, raw any) *PolicyAdapter {
+	adapter := &PolicyAdapter{Key: key}
+	switch v := raw.(type) {
+	case string:
+		adapter.BodyMD = v
+	case []any:
+		adapter.Items = stringItems(v)
+	case map[string]any:
+		if title, ok := v["title"].(string); ok {
+			adapter.Title = strings.TrimSpace(title)
+		}
+		if body, ok := v["body_md"].(string); ok {
+			adapter.BodyMD = body
+		}
+		if items, ok := v["items"].([]any); ok {
+			adapter.Items = stringItems(items)
+		}
+	}
+	return adapter
+}
+
+func stringItems(values []any) []string {
+	var items []string
+	for _, v := range values {
+		if s, ok := v.(string); ok && strings.TrimSpace(s) != "" {
+			items = append(items, strings.TrimSpace(s))
+		}
+	}
+	return items
+}
+
+// policyAdapterKeyForCommand returns the adapter key relevant to a bd command, or "".
+func policyAdapterKeyForCommand(args []string) string {
+	if isClaimCommand(args) {
+		return policyAdapterPreClaim
+	}
+	if isCloseCommandFromArgs(args) {
+		return policyAdapterClose
+	}
+	return ""
+}
+
+// fetchPolicyAdapterForCommand returns the policy adapter for this command,
+// if any.
+func fetchPolicyAdapterForCommand(ctx context.Context, cfg *config.Config, args []string) *PolicyAdapter {
+	key := policyAdapterKeyForCommand(args)
+	if key == "" {
+		return nil
+	}
+	return fetchPolicyAdapter(ctx, cfg, key)
+}
+
+// workspaceRole is the workspace's normalized role; implementer when unset.
+func workspaceRole(cfg *config.Config) string {
+	role := cfg.Role
+	if role == "" {
+		role = "implementer"
+	}
+	return config.NormalizeRole(role)
+}
+
+// fetchPolicyAdapter loads the (cached) policy and resolves the adapter for
+// key and this workspace's role. Best-effort: returns nil on any failure.
+func fetchPolicyAdapter(ctx context.Context, cfg *config.Config, key string) *PolicyAdapter {
+	if !serverSupports(ctx, cfg, featurePolicies) {
+		return nil
+	}
+	role := workspaceRole(cfg)
+
+	workspaceRoot := filepath.Dir(config.GetPath())
+	if root, err := config.WorkspaceRoot(); err == nil {
+		workspaceRoot = root
+	}
+	result, err := fetchActivePolicyCachedWithConfig(ctx, cfg, role, true, workspaceRoot)
+	if err != nil || result == nil {
+		return nil
+	}
+	return resolvePolicyAdapter(result.Policy, key, role)
+}
+
+// showPolicyAdapterPreflight prints the claim or close guidance before bd
+// runs, while it can still change what the agent does, and reports whether
+// it did. JSON output carries the adapter instead.
+func showPolicyAdapterPreflight(adapter *PolicyAdapter, jsonMode bool) bool {
+	if jsonMode || adapter.isEmpty() {
+		return false
+	}
+	fmt.Fprint(policyAdapterPreflightOut, formatPolicyAdapterBody(adapter))
+	return true
+}
+
+// formatPolicyAdapterSection renders an adapter as a coordination section.
+func formatPolicyAdapterSection(adapter *PolicyAdapter) string {
+	if adapter.isEmpty() {
+		return ""
+	}
+	return FormatCoordinationHeader() + formatPolicyAdapterBody(adapter)
+}
+
+// formatPolicyAdapterBody renders an adapter's heading, body and checklist.
+func formatPolicyAdapterBody(adapter *PolicyAdapter) string {
+	heading := adapter.Title
+	if heading == "" {
+		switch adapter.Key {
+		case policyAdapterPreClaim:
+			heading = "Pre-Claim Checklist"
+		case policyAdapterClose:
+			heading = "Close Requirements"
+		default:
+			heading = adapter.Key
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n## %s (project policy)\n", heading))
+	if body := strings.TrimSpace(adapter.BodyMD); body != "" {
+		sb.WriteString(body)
+		sb.WriteString("\n")
+	}
+	for _, item := range adapter.Items {
+		mark := "[ ]"
+		switch adapter.Answers[item] {
+		case checklistDone:
+			mark = "[x]"
+		case checklistNA:
+			mark = "[n/a]"
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s\n", mark, item))
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestResolvePolicyAdapter(t *testing.T) {
+	policy := &client.ActivePolicyResponse{
+		Adapters: map[string]any{
+			"pre_claim_checklist": []any{"Read the bead description", "Check team status"},
+			"close_requirements": map[string]any{
+				"title":   "Before closing",
+				"body_md": "Tests must pass.",
+				"roles": map[string]any{
+					"reviewer": map[string]any{"items": []any{"Leave review notes"}},
+				},
+			},
+			"unknown_adapter": 42,
+		},
+	}
+
+	pre := resolvePolicyAdapter(policy, policyAdapterPreClaim, "implementer")
+	if pre == nil || len(pre.Items) != 2 {
+		t.Fatalf("expected 2 checklist items, got %+v", pre)
+	}
+
+	closeDefault := resolvePolicyAdapter(policy, policyAdapterClose, "implementer")
+	if closeDefault == nil || closeDefault.Title != "Before closing" || closeDefault.BodyMD != "Tests must pass." {
+		t.Errorf("unexpected default close adapter: %+v", closeDefault)
+	}
+
+	closeReviewer := resolvePolicyAdapter(policy, policyAdapterClose, "Reviewer")
+	if closeReviewer == nil || len(closeReviewer.Items) != 1 || closeReviewer.Items[0] != "Leave review notes" {
+		t.Errorf("expected reviewer override, got %+v", closeReviewer)
+	}
+
+	if got := resolvePolicyAdapter(policy, "missing", "implementer"); got != nil {
+		t.Errorf("expected nil for missing adapter, got %+v", got)
+	}
+	if got := resolvePolicyAdapter(&client.ActivePolicyResponse{Adapters: map[string]any{"close_requirements": 42}}, policyAdapterClose, ""); got != nil {
+		t.Errorf("expected nil for malformed adapter, got %+v", got)
+	}
+}
+
+func TestPolicyAdapterKeyForCommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"update", "bd-1", "--status", "in_progress"}, policyAdapterPreClaim},
+		{[]string{"close", "bd-1"}, policyAdapterClose},
+		{[]string{"update", "bd-1", "--title", "x"}, ""},
+		{[]string{"ready"}, ""},
+	}
+	for _, tt := range tests {
+		if got := policyAdapterKeyForCommand(tt.args); got != tt.want {
+			t.Errorf("policyAdapterKeyForCommand(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestFormatPolicyAdapterSection(t *testing.T) {
+	ResetCoordinationHeader()
+	defer ResetCoordinationHeader()
+
+	output := formatPolicyAdapterSection(&PolicyAdapter{
+		Key:   policyAdapterPreClaim,
+		Items: []string{"Read the bead description"},
+	})
+	if !strings.Contains(output, "## Pre-Claim Checklist (project policy)") {
+		t.Errorf("missing heading:\n%s", output)
+	}
+	if !strings.Contains(output, "- [ ] Read the bead description") {
+		t.Errorf("missing item:\n%s", output)
+	}
+	if formatPolicyAdapterSection(&PolicyAdapter{Key: policyAdapterClose}) != "" {
+		t.Error("empty adapter should render nothing")
+	}
+}
+
+func TestShowPolicyAdapterPreflight(t *testing.T) {
+	ResetCoordinationHeader()
+	defer ResetCoordinationHeader()
+	var out bytes.Buffer
+	prev := policyAdapterPreflightOut
+	policyAdapterPreflightOut = &out
+	defer func() { policyAdapterPreflightOut = prev }()
+
+	adapter := &PolicyAdapter{Key: policyAdapterPreClaim, Items: []string{"Read the bead description"}}
+	if showPolicyAdapterPreflight(adapter, true) || out.Len() != 0 {
+		t.Errorf("JSON mode should leave the adapter to the JSON output, got %q", out.String())
+	}
+	if !showPolicyAdapterPreflight(adapter, false) || !strings.Contains(out.String(), "- [ ] Read the bead description") {
+		t.Errorf("expected the checklist before bd runs, got %q", out.String())
+	}
+
+	// Shown once: the coordination output after bd does not repeat it
+	result := &PassthroughResult{PolicyAdapter: adapter, PolicyAdapterShown: true}
+	if got := formatPassthroughOutput(result); strings.Contains(got, "Pre-Claim Checklist") {
+		t.Errorf("checklist repeated after bd:\n%s", got)
+	}
+}
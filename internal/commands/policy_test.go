@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -162,7 +163,7 @@ func TestFetchActivePolicyWithConfig_UnknownRoleListsAvailableRoles(t *testing.T
 		BeadhubURL: server.URL,
 	}
 
-	_, err := fetchActivePolicyWithConfig(cfg, "unknown-role", false)
+	_, err := fetchActivePolicyWithConfig(context.Background(), cfg, "unknown-role", false)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -251,7 +252,7 @@ func TestFetchActivePolicyCachedWithConfig_UsesCacheWhenServerDown(t *testing.T)
 	cfg := &config.Config{
 		BeadhubURL: serverURL,
 	}
-	result, err := fetchActivePolicyCachedWithConfig(cfg, "coordinator", false, root)
+	result, err := fetchActivePolicyCachedWithConfig(context.Background(), cfg, "coordinator", false, root)
 	if err != nil {
 		t.Fatalf("fetchActivePolicyCachedWithConfig: %v", err)
 	}
@@ -297,7 +298,7 @@ func TestFetchActivePolicyCachedWithConfig_UsesConditionalGET304(t *testing.T) {
 	cfg := &config.Config{
 		BeadhubURL: server.URL,
 	}
-	result, err := fetchActivePolicyCachedWithConfig(cfg, "coordinator", false, root)
+	result, err := fetchActivePolicyCachedWithConfig(context.Background(), cfg, "coordinator", false, root)
 	if err != nil {
 		t.Fatalf("fetchActivePolicyCachedWithConfig: %v", err)
 	}
@@ -386,7 +387,7 @@ func TestFetchActivePolicyCachedWithConfig_UsesAccountWhenEnvMissing(t *testing.
 	}
 
 	cfg := &config.Config{BeadhubURL: server.URL}
-	result, err := fetchActivePolicyCachedWithConfig(cfg, "coordinator", false, tmp)
+	result, err := fetchActivePolicyCachedWithConfig(context.Background(), cfg, "coordinator", false, tmp)
 	if err != nil {
 		t.Fatalf("fetchActivePolicyCachedWithConfig: %v", err)
 	}
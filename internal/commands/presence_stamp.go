@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Most commands refresh presence (/v1/agents/register) on their way out.
+// Agents running commands in a tight loop would register many times a
+// second, so the last refresh is stamped in .beadhub-cache/presence.json and
+// the routine refresh is skipped while the stamp is younger than
+// http.presence_interval_seconds. The stamp is shared by every bdh process in
+// the workspace and is written before the request goes out, so processes
+// starting together send one refresh between them. A failed refresh removes
+// the stamp so the next command tries again. Explicit presence changes
+// (:dnd, :tags) always go out and refresh the stamp.
+
+type presenceStamp struct {
+	WorkspaceID string `json:"workspace_id"`
+	RefreshedAt string `json:"refreshed_at"`
+}
+
+func presenceStampPath() (string, error) {
+	return cacheFilePath("presence.json")
+}
+
+// presenceRefreshDue reports whether this workspace's presence should be
+// refreshed at now, given the last stamp.
+func presenceRefreshDue(cfg *config.Config, now time.Time) bool {
+	interval := cfg.PresenceInterval()
+	if interval <= 0 {
+		return true
+	}
+	path, err := presenceStampPath()
+	if err != nil {
+		return true
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return true
+	}
+	var stamp presenceStamp
+	if json.Unmarshal(data, &stamp) != nil || stamp.WorkspaceID != cfg.WorkspaceID {
+		return true
+	}
+	at, ok := parseTimeBestEffort(stamp.RefreshedAt)
+	return !ok || at.After(now) || now.Sub(at) >= interval
+}
+
+// stampPresenceRefresh records a refresh at now (best-effort). The stamp is
+// replaced atomically so a concurrent reader never sees half of it.
+func stampPresenceRefresh(cfg *config.Config, now time.Time) {
+	path, err := presenceStampPath()
+	if err != nil {
+		return
+	}
+	_ = writeJSONAtomic(path, presenceStamp{WorkspaceID: cfg.WorkspaceID, RefreshedAt: now.UTC().Format(time.RFC3339Nano)})
+}
+
+// clearPresenceStamp makes the next command refresh presence.
+func clearPresenceStamp() {
+	if path, err := presenceStampPath(); err == nil {
+		_ = os.Remove(path)
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestRefreshPresenceHeartbeat_OncePerInterval(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fake := clienttest.New()
+	ctx := withFakeBeadHub(fake)
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "claude-be", WorkspaceID: "ws-1"}
+
+	refreshPresenceHeartbeat(ctx, cfg)
+	refreshPresenceHeartbeat(ctx, cfg)
+	if n := len(fake.Calls("RefreshPresence")); n != 1 {
+		t.Fatalf("expected one refresh within the interval, got %d", n)
+	}
+
+	// Another workspace ID in the same directory is not throttled by the stamp
+	other := *cfg
+	other.WorkspaceID = "ws-2"
+	refreshPresenceHeartbeat(ctx, &other)
+	if n := len(fake.Calls("RefreshPresence")); n != 2 {
+		t.Fatalf("expected a refresh for the other workspace, got %d", n)
+	}
+
+	zero := 0
+	cfg.HTTP = &config.HTTPConfig{PresenceIntervalSeconds: &zero}
+	refreshPresenceHeartbeat(ctx, cfg)
+	if n := len(fake.Calls("RefreshPresence")); n != 3 {
+		t.Fatalf("interval 0 should refresh every time, got %d", n)
+	}
+}
+
+func TestRefreshPresenceHeartbeat_FailureClearsStamp(t *testing.T) {
+	t.Chdir(t.TempDir())
+	fake := clienttest.New()
+	ctx := withFakeBeadHub(fake)
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "claude-be", WorkspaceID: "ws-1"}
+
+	fake.Fail("RefreshPresence", errors.New("connection refused"))
+	refreshPresenceHeartbeat(ctx, cfg)
+	if !presenceRefreshDue(cfg, time.Now()) {
+		t.Fatal("a failed refresh should leave the next one due")
+	}
+	fake.Fail("RefreshPresence", nil)
+	refreshPresenceHeartbeat(ctx, cfg)
+	if presenceRefreshDue(cfg, time.Now()) {
+		t.Error("a successful refresh should stamp the workspace")
+	}
+	if !presenceRefreshDue(cfg, time.Now().Add(config.DefaultPresenceIntervalSeconds*time.Second)) {
+		t.Error("the stamp should expire after the interval")
+	}
+}
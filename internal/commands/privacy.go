@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// reportedCommandLine is the command line sent to the server in pre-flight
+// and sync requests, according to privacy.report_command_line.
+//
+// Claim commands are always reported in full: the server needs the bead ID
+// and status to detect a conflicting claim, so hiding them would silently
+// turn off claim coordination.
+func reportedCommandLine(cfg *config.Config, args []string) string {
+	full := strings.Join(args, " ")
+	if len(args) == 0 || isClaimCommand(args) {
+		return full
+	}
+
+	switch cfg.CommandLineReporting() {
+	case config.CommandLineRedact:
+		return args[0]
+	case config.CommandLineHash:
+		if len(args) == 1 {
+			return args[0]
+		}
+		sum := sha256.Sum256([]byte(strings.Join(args[1:], "\x00")))
+		return args[0] + " sha256:" + hex.EncodeToString(sum[:8])
+	case config.CommandLineOff:
+		return ""
+	default:
+		return full
+	}
+}
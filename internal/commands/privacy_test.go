@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestReportedCommandLine(t *testing.T) {
+	args := []string{"show", "bd-42", "--json"}
+	claim := []string{"update", "bd-42", "--status", "in_progress"}
+
+	tests := []struct {
+		mode string
+		args []string
+		want string
+	}{
+		{mode: "", args: args, want: "show bd-42 --json"},
+		{mode: config.CommandLineFull, args: args, want: "show bd-42 --json"},
+		{mode: config.CommandLineRedact, args: args, want: "show"},
+		{mode: config.CommandLineOff, args: args, want: ""},
+		{mode: config.CommandLineHash, args: []string{"ready"}, want: "ready"},
+		{mode: config.CommandLineRedact, args: claim, want: "update bd-42 --status in_progress"},
+		{mode: config.CommandLineOff, args: claim, want: "update bd-42 --status in_progress"},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Privacy: &config.PrivacyConfig{ReportCommandLine: tt.mode}}
+		if got := reportedCommandLine(cfg, tt.args); got != tt.want {
+			t.Errorf("mode %q, args %v: got %q, want %q", tt.mode, tt.args, got, tt.want)
+		}
+	}
+
+	cfg := &config.Config{Privacy: &config.PrivacyConfig{ReportCommandLine: config.CommandLineHash}}
+	hashed := reportedCommandLine(cfg, args)
+	if !strings.HasPrefix(hashed, "show sha256:") || strings.Contains(hashed, "bd-42") {
+		t.Errorf("hash mode leaked arguments: %q", hashed)
+	}
+	if hashed != reportedCommandLine(cfg, args) {
+		t.Error("hash should be stable")
+	}
+	if hashed == reportedCommandLine(cfg, []string{"show", "bd-43", "--json"}) {
+		t.Error("different arguments should hash differently")
+	}
+}
@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/beads"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+var projectJSON bool
+
+var projectCmd = &cobra.Command{
+	Use:   ":project",
+	Short: "Show project routing and cross-repo status",
+	Long: `Inspect project routing for the current directory, and the status of
+every repo in the project.
+
+A .beadhub file may declare project_overrides keyed by path prefix, so
+that commands run inside a subdirectory of a monorepo sync and coordinate
+with a different BeadHub project:
+
+  project_overrides:
+    services/billing:
+      project_slug: billing
+      workspace_id: "<workspace registered in billing>"
+
+Examples:
+  bdh :project current         # Show the resolved project
+  bdh :project current --json  # Output as JSON
+  bdh :project status          # Sync and activity for every repo in the project`,
+}
+
+var projectCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the project resolved for the current directory",
+	Args:  cobra.NoArgs,
+	RunE:  runProjectCurrent,
+}
+
+var projectStatusStale time.Duration
+
+var projectStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show sync and activity for every repo in the project",
+	Long: `List all repos in this project with their last sync time, issue counts
+by status, and the agents active in each.
+
+Repos that have not synced within --stale are flagged, so leads can spot
+repos whose agents stopped syncing.
+
+Examples:
+  bdh :project status
+  bdh :project status --stale 2h
+  bdh :project status --json`,
+	Args: cobra.NoArgs,
+	RunE: runProjectStatus,
+}
+
+func init() {
+	projectCurrentCmd.Flags().BoolVar(&projectJSON, "json", false, "Output as JSON")
+	projectStatusCmd.Flags().BoolVar(&projectJSON, "json", false, "Output as JSON")
+	projectStatusCmd.Flags().DurationVar(&projectStatusStale, "stale", staleClaimThreshold, "Flag repos not synced within this long")
+
+	projectCmd.AddCommand(projectCurrentCmd)
+	projectCmd.AddCommand(projectStatusCmd)
+}
+
+// ProjectResolution describes how the current directory maps to a project.
+type ProjectResolution struct {
+	ProjectSlug    string   `json:"project_slug"`
+	WorkspaceID    string   `json:"workspace_id"`
+	Alias          string   `json:"alias"`
+	BeadhubURL     string   `json:"beadhub_url"`
+	ConfigPath     string   `json:"config_path"`
+	RelativeDir    string   `json:"relative_dir"`
+	Override       string   `json:"override,omitempty"`
+	DefaultProject string   `json:"default_project"`
+	Overrides      []string `json:"overrides,omitempty"`
+}
+
+func runProjectCurrent(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+
+	configPath, err := config.FindPath()
+	if err != nil {
+		return fmt.Errorf("locating config: %w", err)
+	}
+	base, err := config.LoadFrom(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	fmt.Print(formatProjectResolutionOutput(resolveProjectWithConfig(cfg, base, configPath), projectJSON))
+	return nil
+}
+
+// resolveProjectWithConfig builds a resolution from the effective config and
+// the config as written on disk (for testing).
+func resolveProjectWithConfig(cfg, base *config.Config, configPath string) *ProjectResolution {
+	res := &ProjectResolution{
+		ProjectSlug:    cfg.ProjectSlug,
+		WorkspaceID:    cfg.WorkspaceID,
+		Alias:          cfg.Alias,
+		BeadhubURL:     cfg.BeadhubURL,
+		ConfigPath:     configPath,
+		Override:       cfg.ActiveOverride,
+		DefaultProject: base.ProjectSlug,
+	}
+	if abs, err := filepath.Abs(configPath); err == nil {
+		res.ConfigPath = abs
+	}
+	if rel, ok := config.RelativeWorkingDir(configPath); ok {
+		res.RelativeDir = rel
+	}
+	for prefix := range base.ProjectOverrides {
+		res.Overrides = append(res.Overrides, config.NormalizeOverridePrefix(prefix))
+	}
+	sort.Strings(res.Overrides)
+	return res
+}
+
+func formatProjectResolutionOutput(res *ProjectResolution, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(res)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Project:   %s\n", res.ProjectSlug))
+	if res.Override != "" {
+		sb.WriteString(fmt.Sprintf("Source:    project_overrides[%s] (default: %s)\n", res.Override, res.DefaultProject))
+	} else {
+		sb.WriteString("Source:    default (no override matches this directory)\n")
+	}
+	sb.WriteString(fmt.Sprintf("Workspace: %s (%s)\n", res.Alias, res.WorkspaceID))
+	sb.WriteString(fmt.Sprintf("Server:    %s\n", res.BeadhubURL))
+	sb.WriteString(fmt.Sprintf("Config:    %s\n", res.ConfigPath))
+	if res.RelativeDir != "" {
+		sb.WriteString(fmt.Sprintf("Directory: %s\n", res.RelativeDir))
+	}
+	if len(res.Overrides) > 0 {
+		sb.WriteString("\nOverrides:\n")
+		for _, prefix := range res.Overrides {
+			marker := " "
+			if prefix == res.Override {
+				marker = "*"
+			}
+			sb.WriteString(fmt.Sprintf(" %s %s/\n", marker, prefix))
+		}
+	}
+	return sb.String()
+}
+
+// ProjectRepoSummary is one repo in :project status output.
+type ProjectRepoSummary struct {
+	client.ProjectRepoStatus
+	Stale   bool `json:"stale"`
+	Current bool `json:"current"` // The repo this workspace belongs to
+}
+
+// ProjectStatusResult is the output of :project status.
+type ProjectStatusResult struct {
+	ProjectID    string               `json:"project_id"`
+	ProjectSlug  string               `json:"project_slug"`
+	StaleAfter   string               `json:"stale_after"`
+	Repos        []ProjectRepoSummary `json:"repos"`
+	StaleCount   int                  `json:"stale_count"`
+	ActiveAgents int                  `json:"active_agents"`
+}
+
+func runProjectStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+
+	result, err := fetchProjectStatus(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), projectStatusStale, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatProjectStatusOutput(result, projectJSON))
+	return nil
+}
+
+// fetchProjectStatus resolves the configured project and summarises each of
+// its repos, most recently synced first, never-synced repos last.
+func fetchProjectStatus(ctx context.Context, cfg *config.Config, c BeadHubAPI, staleAfter time.Duration, now time.Time) (*ProjectStatusResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	projects, err := c.ListProjects(ctx)
+	if err != nil {
+		return nil, projectStatusError("failed to list projects", err)
+	}
+	projectID := ""
+	for _, p := range projects.Projects {
+		if p.Slug == cfg.ProjectSlug {
+			projectID = p.ID
+			break
+		}
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("project not found: %s", cfg.ProjectSlug)
+	}
+
+	resp, err := c.ProjectStatus(ctx, projectID)
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) && clientErr.StatusCode == 404 {
+			return nil, fmt.Errorf("this BeadHub server does not support project status - upgrade the server, or use 'bdh :projects list' for repo counts")
+		}
+		return nil, projectStatusError("failed to get project status", err)
+	}
+
+	result := &ProjectStatusResult{
+		ProjectID:   projectID,
+		ProjectSlug: cfg.ProjectSlug,
+		StaleAfter:  staleAfter.String(),
+		Repos:       make([]ProjectRepoSummary, 0, len(resp.Repos)),
+	}
+	for _, repo := range resp.Repos {
+		summary := ProjectRepoSummary{
+			ProjectRepoStatus: repo,
+			Current:           repo.ID != "" && repo.ID == cfg.RepoID,
+		}
+		if summary.ActiveAgents == nil {
+			summary.ActiveAgents = []client.ProjectRepoAgent{}
+		}
+		lastSync, ok := parseTimeBestEffort(repo.LastSyncAt)
+		summary.Stale = !ok || now.Sub(lastSync) > staleAfter
+		if summary.Stale {
+			result.StaleCount++
+		}
+		result.ActiveAgents += len(repo.ActiveAgents)
+		result.Repos = append(result.Repos, summary)
+	}
+	sort.SliceStable(result.Repos, func(i, j int) bool {
+		ti, okI := parseTimeBestEffort(result.Repos[i].LastSyncAt)
+		tj, okJ := parseTimeBestEffort(result.Repos[j].LastSyncAt)
+		if okI != okJ {
+			return okI
+		}
+		return ti.After(tj)
+	})
+	return result, nil
+}
+
+func projectStatusError(what string, err error) error {
+	var clientErr *client.Error
+	if errors.As(err, &clientErr) {
+		return fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+	}
+	return fmt.Errorf("%s: %w", what, err)
+}
+
+// formatIssueCounts renders counts as "3 open, 1 in_progress", in a fixed
+// order for the common statuses and alphabetically for the rest.
+func formatIssueCounts(counts map[string]int) string {
+	order := []string{"open", "in_progress", "blocked", "closed"}
+	known := make(map[string]bool, len(order))
+	for _, st := range order {
+		known[st] = true
+	}
+	var extra []string
+	for st := range counts {
+		if !known[st] {
+			extra = append(extra, st)
+		}
+	}
+	sort.Strings(extra)
+
+	var parts []string
+	for _, st := range append(order, extra...) {
+		if n := counts[st]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, st))
+		}
+	}
+	if len(parts) == 0 {
+		return "no issues"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatProjectStatusOutput(result *ProjectStatusResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("PROJECT %s: %d repo(s), %d active agent(s)\n", result.ProjectSlug, len(result.Repos), result.ActiveAgents))
+	if len(result.Repos) == 0 {
+		sb.WriteString("\nNo repos registered.\n")
+		return sb.String()
+	}
+
+	for _, repo := range result.Repos {
+		name := repo.Name
+		if name == "" {
+			name = repo.CanonicalOrigin
+		}
+		sb.WriteString("\n")
+		sb.WriteString(name)
+		if repo.Current {
+			sb.WriteString(" (this repo)")
+		}
+		if repo.Stale {
+			sb.WriteString(" [STALE]")
+		}
+		sb.WriteString("\n")
+		if repo.Name != "" && repo.CanonicalOrigin != "" {
+			sb.WriteString(fmt.Sprintf("  origin:    %s\n", repo.CanonicalOrigin))
+		}
+		lastSync := "never"
+		if repo.LastSyncAt != "" {
+			lastSync = formatTimestamp(repo.LastSyncAt)
+		}
+		sb.WriteString(fmt.Sprintf("  last sync: %s\n", lastSync))
+		sb.WriteString(fmt.Sprintf("  issues:    %s\n", formatIssueCounts(repo.IssueCounts)))
+		if len(repo.ActiveAgents) == 0 {
+			sb.WriteString("  agents:    none active\n")
+			continue
+		}
+		sb.WriteString("  agents:\n")
+		for _, agent := range repo.ActiveAgents {
+			sb.WriteString("    " + agent.Alias)
+			if agent.Role != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", agent.Role))
+			}
+			if agent.LastSeen != "" {
+				sb.WriteString(" — seen " + formatTimestamp(agent.LastSeen))
+			}
+			if agent.LastSyncAt != "" {
+				sb.WriteString(", synced " + formatTimestamp(agent.LastSyncAt))
+			} else {
+				sb.WriteString(", never synced")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if result.StaleCount > 0 {
+		sb.WriteString(fmt.Sprintf("\n%d repo(s) not synced in the last %s - check that their agents are running bdh.\n", result.StaleCount, result.StaleAfter))
+	}
+	return sb.String()
+}
+
+// syncStatePathForConfig keeps sync state separate per overridden project,
+// since each project tracks its own view of the shared issues.jsonl.
+func syncStatePathForConfig(cfg *config.Config) string {
+	path := beads.SyncStatePath()
+	if cfg == nil || cfg.ActiveOverride == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(path), fmt.Sprintf("sync-state.%s.json", cfg.ProjectSlug))
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestFormatProjectResolutionOutput_ShowsOverride(t *testing.T) {
+	res := &ProjectResolution{
+		ProjectSlug:    "billing",
+		WorkspaceID:    "b2c3d4e5-6789-01ab-cdef-234567890abc",
+		Alias:          "alice",
+		BeadhubURL:     "http://localhost:8000",
+		ConfigPath:     "/repo/.beadhub",
+		RelativeDir:    "services/billing/api",
+		Override:       "services/billing",
+		DefaultProject: "platform",
+		Overrides:      []string{"services/billing", "services/search"},
+	}
+
+	output := formatProjectResolutionOutput(res, false)
+	for _, want := range []string{
+		"Project:   billing",
+		"project_overrides[services/billing] (default: platform)",
+		"* services/billing/",
+		"  services/search/",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	res.Override = ""
+	res.ProjectSlug = "platform"
+	if output := formatProjectResolutionOutput(res, false); !strings.Contains(output, "default (no override matches this directory)") {
+		t.Errorf("expected default source, got:\n%s", output)
+	}
+}
+
+func TestSyncStatePathForConfig_SeparatesOverriddenProjects(t *testing.T) {
+	setupBeadsWorkspace(t, "")
+
+	base := syncStatePathForConfig(&config.Config{ProjectSlug: "platform"})
+	if filepath.Base(base) != "sync-state.json" {
+		t.Errorf("default path = %q", base)
+	}
+	overridden := syncStatePathForConfig(&config.Config{ProjectSlug: "billing", ActiveOverride: "services/billing"})
+	if filepath.Base(overridden) != "sync-state.billing.json" {
+		t.Errorf("override path = %q", overridden)
+	}
+	if filepath.Dir(base) != filepath.Dir(overridden) {
+		t.Errorf("expected same cache dir, got %q and %q", base, overridden)
+	}
+}
+
+func TestFetchProjectStatus_FlagsStaleRepos(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects":
+			w.Write([]byte(`{"projects":[{"id":"p-other","slug":"other"},{"id":"p-1","slug":"platform"}]}`))
+		case "/v1/projects/p-1/status":
+			w.Write([]byte(`{"project_id":"p-1","slug":"platform","repos":[
+				{"id":"r-old","canonical_origin":"github.com/acme/old","last_sync_at":"2026-10-14T12:00:00Z","issue_counts":{"open":2}},
+				{"id":"r-never","canonical_origin":"github.com/acme/never"},
+				{"id":"r-api","name":"api","canonical_origin":"github.com/acme/api","last_sync_at":"2026-10-17T11:30:00Z",
+				 "issue_counts":{"open":3,"closed":5,"deferred":1},
+				 "active_agents":[{"alias":"alice","role":"dev","last_sync_at":"2026-10-17T11:30:00Z"},{"alias":"bob"}]}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	cfg := &config.Config{ProjectSlug: "platform", RepoID: "r-api"}
+
+	result, err := fetchProjectStatus(context.Background(), cfg, client.New(server.URL), 24*time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, r := range result.Repos {
+		order = append(order, r.ID)
+	}
+	if strings.Join(order, ",") != "r-api,r-old,r-never" {
+		t.Fatalf("order = %v", order)
+	}
+	if result.Repos[0].Stale || !result.Repos[0].Current || !result.Repos[1].Stale || !result.Repos[2].Stale {
+		t.Errorf("repos = %+v", result.Repos)
+	}
+	if result.StaleCount != 2 || result.ActiveAgents != 2 {
+		t.Errorf("stale = %d, agents = %d", result.StaleCount, result.ActiveAgents)
+	}
+
+	out := formatProjectStatusOutput(result, false)
+	for _, want := range []string{"api (this repo)\n", "3 open, 5 closed, 1 deferred", "github.com/acme/never [STALE]", "last sync: never", "bob, never synced", "2 repo(s) not synced in the last 24h0m0s"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	cfg.ProjectSlug = "missing"
+	if _, err := fetchProjectStatus(context.Background(), cfg, client.New(server.URL), time.Hour, now); err == nil || !strings.Contains(err.Error(), "project not found") {
+		t.Errorf("err = %v", err)
+	}
+}
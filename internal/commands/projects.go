@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -23,9 +25,10 @@ var projectsCmd = &cobra.Command{
 By default, lists all projects. Use subcommands for other operations.
 
 Examples:
-  bdh :projects             # List all projects
-  bdh :projects list        # List all projects (explicit)
-  bdh :projects delete <id> # Delete a project by ID or slug`,
+  bdh :projects                # List all projects
+  bdh :projects list           # List all projects (explicit)
+  bdh :projects create <slug>  # Create a project
+  bdh :projects delete <id>    # Delete a project by ID or slug`,
 	RunE: runProjectsList,
 }
 
@@ -50,22 +53,40 @@ var projectsDeleteCmd = &cobra.Command{
 DANGER: Project deletion is catastrophic and irreversible!
 This will cascade delete ALL repos, workspaces, claims, and messages.
 
-You MUST pass --confirm to proceed. The command will show what will be
-deleted and require the flag as an explicit safety gate.
+The command lists the repos and workspaces that will be deleted. On a
+terminal it then asks you to type the project slug; otherwise (or with
+--json) you MUST pass --confirm to proceed.
 
 Examples:
-  bdh :projects delete my-project           # Shows what would be deleted (dry run)
-  bdh :projects delete my-project --confirm # Actually deletes the project`,
+  bdh :projects delete my-project           # Preview, then prompt on a terminal
+  bdh :projects delete my-project --confirm # Delete without prompting
+  bdh :projects delete my-project --json    # Preview as JSON (dry run)`,
 	Args: cobra.ExactArgs(1),
 	RunE: runProjectsDelete,
 }
 
+var projectsCreateCmd = &cobra.Command{
+	Use:   "create <slug>",
+	Short: "Create a project",
+	Long: `Create a project with the given slug. If it already exists, nothing
+changes and the existing project is reported.
+
+Examples:
+  bdh :projects create my-project
+  bdh :projects create my-project --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectsCreate,
+}
+
 func init() {
 	projectsCmd.Flags().BoolVar(&projectsJSON, "json", false, "Output as JSON")
 	projectsListCmd.Flags().BoolVar(&projectsJSON, "json", false, "Output as JSON")
-	projectsDeleteCmd.Flags().BoolVar(&projectsDeleteConfirm, "confirm", false, "Confirm destructive deletion (REQUIRED)")
+	projectsCreateCmd.Flags().BoolVar(&projectsJSON, "json", false, "Output as JSON")
+	projectsDeleteCmd.Flags().BoolVar(&projectsJSON, "json", false, "Output as JSON")
+	projectsDeleteCmd.Flags().BoolVar(&projectsDeleteConfirm, "confirm", false, "Confirm destructive deletion (required when not prompting)")
 
 	projectsCmd.AddCommand(projectsListCmd)
+	projectsCmd.AddCommand(projectsCreateCmd)
 	projectsCmd.AddCommand(projectsDeleteCmd)
 }
 
@@ -75,7 +96,8 @@ type ProjectsListResult struct {
 }
 
 func runProjectsList(cmd *cobra.Command, args []string) error {
-	result, err := listProjects()
+	ctx := cmd.Context()
+	result, err := listProjects(ctx)
 	if err != nil {
 		return err
 	}
@@ -85,7 +107,7 @@ func runProjectsList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func listProjects() (*ProjectsListResult, error) {
+func listProjects(ctx context.Context) (*ProjectsListResult, error) {
 	// Load config to get BeadHub URL
 	cfg, err := config.Load()
 	beadhubURL := "http://localhost:8000" // default
@@ -97,8 +119,8 @@ func listProjects() (*ProjectsListResult, error) {
 		beadhubURL = cfg.BeadhubURL
 	}
 
-	c := client.New(beadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	c := beadHubClientFor(ctx, beadhubURL, "")
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	resp, err := c.ListProjects(ctx)
@@ -148,7 +170,75 @@ func formatProjectsListOutput(result *ProjectsListResult, asJSON bool) string {
 	return sb.String()
 }
 
+// ProjectCreateResult contains the result of creating a project.
+type ProjectCreateResult struct {
+	ProjectID string `json:"project_id"`
+	Slug      string `json:"slug"`
+	Created   bool   `json:"created"`
+}
+
+func runProjectsCreate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("no .beadhub config found: creating projects requires a configured workspace.\nRun 'bdh :init' to configure your workspace")
+	}
+	result, err := createProject(ctx, cfg.BeadhubURL, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatProjectCreateOutput(result, projectsJSON))
+	return nil
+}
+
+func createProject(ctx context.Context, beadhubURL, slug string) (*ProjectCreateResult, error) {
+	slug = strings.TrimSpace(slug)
+	if !config.IsValidSlug(slug) {
+		return nil, fmt.Errorf("invalid project slug %q: must be lowercase alphanumeric with hyphens", slug)
+	}
+
+	c := beadHubClientFor(ctx, beadhubURL, "")
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	resp, err := c.EnsureProject(ctx, &client.EnsureProjectRequest{Slug: slug})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	return &ProjectCreateResult{ProjectID: resp.ProjectID, Slug: resp.Slug, Created: resp.Created}, nil
+}
+
+func formatProjectCreateOutput(result *ProjectCreateResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	if result.Created {
+		return fmt.Sprintf("✓ Project created: %s (ID: %s)\n", result.Slug, result.ProjectID)
+	}
+	return fmt.Sprintf("Project already exists: %s (ID: %s)\n", result.Slug, result.ProjectID)
+}
+
+// ProjectDeletePreview shows what deleting a project will remove.
+type ProjectDeletePreview struct {
+	Project    client.ProjectSummary `json:"project"`
+	Repos      []client.ProjectRepo  `json:"repos"`
+	Workspaces []string              `json:"workspaces"`
+	Warning    string                `json:"warning,omitempty"`
+}
+
+// ProjectDeleteResult contains the outcome of :projects delete.
+type ProjectDeleteResult struct {
+	Preview *ProjectDeletePreview         `json:"preview"`
+	Deleted bool                          `json:"deleted"`
+	Result  *client.DeleteProjectResponse `json:"result,omitempty"`
+}
+
 func runProjectsDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	idOrSlug := args[0]
 
 	// Load config - REQUIRED for destructive operations
@@ -156,23 +246,58 @@ func runProjectsDelete(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("no .beadhub config found: destructive operations require a configured workspace.\nRun 'bdh :init' to configure your workspace")
 	}
-	beadhubURL := cfg.BeadhubURL
+	c := beadHubClientFor(ctx, cfg.BeadhubURL, "")
+
+	preview, err := previewProjectDeletion(ctx, c, idOrSlug)
+	if err != nil {
+		return err
+	}
+	if !projectsJSON {
+		fmt.Print(formatProjectDeletePreview(preview))
+	}
 
-	c := client.New(beadhubURL)
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	confirmed := projectsDeleteConfirm
+	if !confirmed && !projectsJSON && isTTY() {
+		confirmed = promptProjectDeletion(preview.Project.Slug)
+	}
+	if !confirmed {
+		if projectsJSON {
+			fmt.Print(marshalJSONOrFallback(&ProjectDeleteResult{Preview: preview}))
+		} else {
+			fmt.Printf("To proceed, re-run with --confirm:\n")
+			fmt.Printf("  bdh :projects delete %s --confirm\n\n", idOrSlug)
+		}
+		return fmt.Errorf("deletion aborted: --confirm flag required")
+	}
+
+	if !projectsJSON {
+		fmt.Printf("Deleting project...\n")
+	}
+	result, err := deleteProject(ctx, c, preview)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatProjectDeleteOutput(result, projectsJSON))
+	return nil
+}
+
+// previewProjectDeletion resolves idOrSlug and lists what the cascade will
+// remove. Repo and workspace listings are best-effort; the counts from the
+// project summary are always available.
+func previewProjectDeletion(ctx context.Context, c BeadHubAPI, idOrSlug string) (*ProjectDeletePreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
-	// First, resolve slug to ID and get project details
-	var project *client.ProjectSummary
 	resp, err := c.ListProjects(ctx)
 	if err != nil {
 		var clientErr *client.Error
 		if errors.As(err, &clientErr) {
-			return fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
 		}
-		return fmt.Errorf("failed to list projects: %w", err)
+		return nil, fmt.Errorf("failed to list projects: %w", err)
 	}
 
+	var project *client.ProjectSummary
 	for i := range resp.Projects {
 		p := &resp.Projects[i]
 		if p.Slug == idOrSlug || p.ID == idOrSlug {
@@ -180,45 +305,90 @@ func runProjectsDelete(cmd *cobra.Command, args []string) error {
 			break
 		}
 	}
-
 	if project == nil {
-		return fmt.Errorf("project not found: %s", idOrSlug)
+		return nil, fmt.Errorf("project not found: %s", idOrSlug)
+	}
+
+	preview := &ProjectDeletePreview{Project: *project, Repos: []client.ProjectRepo{}, Workspaces: []string{}}
+	var unavailable []string
+	if repos, err := c.ListProjectRepos(ctx, project.ID); err == nil {
+		preview.Repos = repos.Repos
+	} else {
+		unavailable = append(unavailable, "repos")
+	}
+	if workspaces, err := c.ListProjectWorkspaces(ctx, project.ID); err == nil {
+		for _, ws := range workspaces.Workspaces {
+			preview.Workspaces = append(preview.Workspaces, ws.Alias)
+		}
+		sort.Strings(preview.Workspaces)
+	} else {
+		unavailable = append(unavailable, "workspaces")
+	}
+	if len(unavailable) > 0 {
+		preview.Warning = fmt.Sprintf("could not list %s - showing counts only", strings.Join(unavailable, " and "))
 	}
+	return preview, nil
+}
 
-	// Show what will be deleted
-	fmt.Printf("\n⚠️  DANGER: Project deletion is CATASTROPHIC and IRREVERSIBLE!\n\n")
-	fmt.Printf("Project to delete:\n")
-	fmt.Printf("  Name:       %s\n", project.Slug)
-	fmt.Printf("  ID:         %s\n", project.ID)
-	fmt.Printf("  Repos:      %d (will be HARD DELETED)\n", project.RepoCount)
-	fmt.Printf("  Workspaces: %d (will be SOFT DELETED)\n", project.WorkspaceCount)
-	fmt.Printf("\nThis will also delete all claims, messages, and presence data.\n\n")
+func formatProjectDeletePreview(preview *ProjectDeletePreview) string {
+	project := preview.Project
+	var sb strings.Builder
+	sb.WriteString("\n⚠️  DANGER: Project deletion is CATASTROPHIC and IRREVERSIBLE!\n\n")
+	sb.WriteString("Project to delete:\n")
+	sb.WriteString(fmt.Sprintf("  Name:       %s\n", project.Slug))
+	sb.WriteString(fmt.Sprintf("  ID:         %s\n", project.ID))
+	sb.WriteString(fmt.Sprintf("  Repos:      %d (will be HARD DELETED)\n", project.RepoCount))
+	for _, r := range preview.Repos {
+		sb.WriteString(fmt.Sprintf("    - %s (%d workspaces)\n", r.CanonicalOrigin, r.WorkspaceCount))
+	}
+	sb.WriteString(fmt.Sprintf("  Workspaces: %d (will be SOFT DELETED)\n", project.WorkspaceCount))
+	if len(preview.Workspaces) > 0 {
+		sb.WriteString(fmt.Sprintf("    - %s\n", joinCapped(preview.Workspaces, 20)))
+	}
+	if preview.Warning != "" {
+		sb.WriteString(fmt.Sprintf("  Note: %s\n", preview.Warning))
+	}
+	sb.WriteString("\nThis will also delete all claims, messages, and presence data.\n\n")
+	return sb.String()
+}
 
-	if !projectsDeleteConfirm {
-		fmt.Printf("To proceed, re-run with --confirm:\n")
-		fmt.Printf("  bdh :projects delete %s --confirm\n\n", idOrSlug)
-		return fmt.Errorf("deletion aborted: --confirm flag required")
+// promptProjectDeletion asks the user to type the slug to confirm.
+func promptProjectDeletion(slug string) bool {
+	fmt.Printf("Type the project slug (%s) to confirm deletion: ", slug)
+	answer, err := readMenuLine(bufio.NewReader(os.Stdin))
+	if err != nil {
+		return false
 	}
+	return answer == slug
+}
 
-	// User confirmed, proceed with deletion
-	fmt.Printf("Deleting project...\n")
+func deleteProject(ctx context.Context, c BeadHubAPI, preview *ProjectDeletePreview) (*ProjectDeleteResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
 
-	deleteResp, err := c.DeleteProject(ctx, project.ID)
+	deleteResp, err := c.DeleteProject(ctx, preview.Project.ID)
 	if err != nil {
 		var clientErr *client.Error
 		if errors.As(err, &clientErr) {
 			if clientErr.StatusCode == 404 {
-				return fmt.Errorf("project not found: %s", idOrSlug)
+				return nil, fmt.Errorf("project not found: %s", preview.Project.Slug)
 			}
-			return fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
 		}
-		return fmt.Errorf("failed to delete project: %w", err)
+		return nil, fmt.Errorf("failed to delete project: %w", err)
 	}
+	return &ProjectDeleteResult{Preview: preview, Deleted: true, Result: deleteResp}, nil
+}
 
-	fmt.Printf("\n✓ Project deleted: %s (ID: %s)\n", project.Slug, deleteResp.ID)
-	fmt.Printf("  Repos deleted:      %d\n", deleteResp.ReposDeleted)
-	fmt.Printf("  Workspaces deleted: %d\n", deleteResp.WorkspacesDeleted)
-	fmt.Printf("  Claims deleted:     %d\n", deleteResp.ClaimsDeleted)
-	fmt.Printf("  Presence cleared:   %d\n", deleteResp.PresenceCleared)
-	return nil
+func formatProjectDeleteOutput(result *ProjectDeleteResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n✓ Project deleted: %s (ID: %s)\n", result.Preview.Project.Slug, result.Result.ID))
+	sb.WriteString(fmt.Sprintf("  Repos deleted:      %d\n", result.Result.ReposDeleted))
+	sb.WriteString(fmt.Sprintf("  Workspaces deleted: %d\n", result.Result.WorkspacesDeleted))
+	sb.WriteString(fmt.Sprintf("  Claims deleted:     %d\n", result.Result.ClaimsDeleted))
+	sb.WriteString(fmt.Sprintf("  Presence cleared:   %d\n", result.Result.PresenceCleared))
+	return sb.String()
 }
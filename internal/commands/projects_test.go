@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -140,3 +143,135 @@ func TestFormatProjectsListOutput_SingleProject(t *testing.T) {
 		t.Errorf("expected singular project, got: %s", output)
 	}
 }
+
+func TestCreateProject(t *testing.T) {
+	var gotSlug string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/ensure" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var req client.EnsureProjectRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		gotSlug = req.Slug
+		json.NewEncoder(w).Encode(client.EnsureProjectResponse{ProjectID: "proj-1", Slug: req.Slug, Created: req.Slug == "new-project"})
+	}))
+	defer server.Close()
+
+	result, err := createProject(context.Background(), server.URL, "new-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotSlug != "new-project" || !result.Created {
+		t.Errorf("slug=%q result=%+v", gotSlug, result)
+	}
+	if out := formatProjectCreateOutput(result, false); !strings.Contains(out, "Project created: new-project (ID: proj-1)") {
+		t.Errorf("output = %q", out)
+	}
+
+	result, err = createProject(context.Background(), server.URL, "existing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := formatProjectCreateOutput(result, false); !strings.Contains(out, "already exists: existing") {
+		t.Errorf("output = %q", out)
+	}
+
+	if _, err := createProject(context.Background(), server.URL, "Bad Slug"); err == nil || !strings.Contains(err.Error(), "invalid project slug") {
+		t.Errorf("expected slug validation error, got %v", err)
+	}
+}
+
+func TestPreviewProjectDeletion_ListsCascade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects":
+			json.NewEncoder(w).Encode(client.ListProjectsResponse{Projects: []client.ProjectSummary{
+				{ID: "proj-1", Slug: "doomed", RepoCount: 2, WorkspaceCount: 3},
+			}})
+		case "/v1/projects/proj-1/repos":
+			json.NewEncoder(w).Encode(client.ListProjectReposResponse{Repos: []client.ProjectRepo{
+				{ID: "r1", CanonicalOrigin: "github.com/acme/api", WorkspaceCount: 2},
+				{ID: "r2", CanonicalOrigin: "github.com/acme/web", WorkspaceCount: 1},
+			}})
+		case "/v1/projects/proj-1/workspaces":
+			json.NewEncoder(w).Encode(client.WorkspacesResponse{Workspaces: []client.Workspace{
+				{Alias: "carol"}, {Alias: "alice"}, {Alias: "bob"},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := client.New(server.URL)
+
+	preview, err := previewProjectDeletion(context.Background(), c, "doomed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Repos) != 2 || strings.Join(preview.Workspaces, ",") != "alice,bob,carol" || preview.Warning != "" {
+		t.Errorf("preview = %+v", preview)
+	}
+	out := formatProjectDeletePreview(preview)
+	for _, want := range []string{"github.com/acme/api (2 workspaces)", "- alice, bob, carol", "Repos:      2 (will be HARD DELETED)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := previewProjectDeletion(context.Background(), c, "missing"); err == nil || !strings.Contains(err.Error(), "project not found") {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestPreviewProjectDeletion_FallsBackToCounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects" {
+			json.NewEncoder(w).Encode(client.ListProjectsResponse{Projects: []client.ProjectSummary{
+				{ID: "proj-1", Slug: "old-server", RepoCount: 1, WorkspaceCount: 1},
+			}})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	preview, err := previewProjectDeletion(context.Background(), client.New(server.URL), "proj-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Warning != "could not list repos and workspaces - showing counts only" {
+		t.Errorf("warning = %q", preview.Warning)
+	}
+	if !strings.Contains(formatProjectDeletePreview(preview), "Workspaces: 1 (will be SOFT DELETED)") {
+		t.Error("counts should still be shown")
+	}
+}
+
+func TestDeleteProject_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/v1/projects/proj-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(client.DeleteProjectResponse{ID: "proj-1", ReposDeleted: 2, WorkspacesDeleted: 3})
+	}))
+	defer server.Close()
+
+	preview := &ProjectDeletePreview{Project: client.ProjectSummary{ID: "proj-1", Slug: "doomed"}}
+	result, err := deleteProject(context.Background(), client.New(server.URL), preview)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parsed struct {
+		Deleted bool `json:"deleted"`
+		Result  struct {
+			ReposDeleted int `json:"repos_deleted"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(formatProjectDeleteOutput(result, true)), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Deleted || parsed.Result.ReposDeleted != 2 {
+		t.Errorf("parsed = %+v", parsed)
+	}
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// During notifications.quiet_hours the notifications printed after each
+// command keep only what cannot wait: escalation updates, chats whose sender
+// is waiting, urgent mail and away auto-replies. Unread counts, other chats
+// and non-urgent inline mail are held, and what was held is recorded in .beadhub-cache/quiet_hours.json. The first command after the
+// window ends prints a summary of it, followed by the current notifications.
+// Reports of what bdh did on the agent's behalf, such as scheduled mail it
+// sent or read receipts it collected, are never held: they are not shown
+// again later.
+
+// QuietHoursState is what was held during the current or last quiet period.
+type QuietHoursState struct {
+	Since       string   `json:"since"`
+	Until       string   `json:"until"`
+	UnreadMail  int      `json:"unread_mail"`
+	ChatAliases []string `json:"chat_aliases,omitempty"`
+}
+
+// QuietHoursSummary is shown once a quiet period is over, if anything was
+// held.
+type QuietHoursSummary struct {
+	Since       time.Time
+	Until       time.Time
+	UnreadMail  int
+	ChatAliases []string
+}
+
+func quietHoursPath() (string, error) {
+	return cacheFilePath("quiet_hours.json")
+}
+
+func loadQuietHoursState(path string) (*QuietHoursState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state QuietHoursState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func saveQuietHoursState(path string, state *QuietHoursState) error {
+	return writeJSONAtomic(path, state)
+}
+
+// applyQuietHours holds ctx's non-urgent notifications while cfg's quiet
+// hours are on, and adds the summary of a quiet period that has ended.
+// Best-effort: if the state cannot be saved, the held notifications are not
+// summarized later.
+func applyQuietHours(cfg *config.Config, ctx *NotificationContext, now time.Time) {
+	path, err := quietHoursPath()
+	if err != nil {
+		return
+	}
+	state, err := loadQuietHoursState(path)
+	if err != nil {
+		_ = os.Remove(path)
+		state = nil
+	}
+	if state != nil {
+		until, ok := parseTimeBestEffort(state.Until)
+		if !ok || !now.Before(until) {
+			ctx.QuietHoursSummary = quietHoursSummaryFrom(state)
+			_ = os.Remove(path)
+			state = nil
+		}
+	}
+
+	until, quiet := cfg.QuietHoursUntil(now)
+	if !quiet {
+		return
+	}
+	if state == nil {
+		state = &QuietHoursState{Since: now.UTC().Format(time.RFC3339)}
+	}
+	state.Until = until.UTC().Format(time.RFC3339)
+	holdNonUrgent(ctx, state)
+	_ = saveQuietHoursState(path, state)
+}
+
+// holdNonUrgent strips ctx's inbound notifications down to the urgent ones,
+// recording the rest in state.
+func holdNonUrgent(ctx *NotificationContext, state *QuietHoursState) {
+	var kept []PendingConversation
+	for _, conv := range ctx.PendingConversations {
+		if conv.SenderWaiting {
+			kept = append(kept, conv)
+			continue
+		}
+		if alias := strings.TrimSpace(conv.LastFrom); alias != "" && !containsString(state.ChatAliases, alias) {
+			state.ChatAliases = append(state.ChatAliases, alias)
+		}
+	}
+	sort.Strings(state.ChatAliases)
+	ctx.PendingConversations = kept
+
+	var urgentInline []InlineMail
+	for _, m := range ctx.InlineMail {
+		if m.Priority == aweb.PriorityUrgent {
+			urgentInline = append(urgentInline, m)
+		}
+	}
+	ctx.InlineMail = urgentInline
+	// The latest count, not a sum: the same unread mail is seen by every command
+	state.UnreadMail = max(ctx.MessagesWaiting-len(ctx.UrgentMail), 0)
+	ctx.MessagesWaiting = min(ctx.MessagesWaiting, len(ctx.UrgentMail))
+}
+
+func quietHoursSummaryFrom(state *QuietHoursState) *QuietHoursSummary {
+	since, _ := parseTimeBestEffort(state.Since)
+	until, _ := parseTimeBestEffort(state.Until)
+	return &QuietHoursSummary{Since: since, Until: until, UnreadMail: state.UnreadMail, ChatAliases: state.ChatAliases}
+}
+
+func formatQuietHoursSummary(s *QuietHoursSummary) string {
+	var held []string
+	if s.UnreadMail == 1 {
+		held = append(held, "1 unread message")
+	} else if s.UnreadMail > 1 {
+		held = append(held, fmt.Sprintf("%d unread messages", s.UnreadMail))
+	}
+	if len(s.ChatAliases) > 0 {
+		held = append(held, "chats from "+strings.Join(s.ChatAliases, ", "))
+	}
+	if len(held) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("- **QUIET HOURS** %s–%s are over; held %s\n  → Current notifications follow; check: `bdh :aweb mail list`, `bdh :aweb chat pending`",
+		s.Since.Local().Format("15:04"), s.Until.Local().Format("15:04"), strings.Join(held, ", "))
+}
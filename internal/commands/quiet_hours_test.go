@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func quietNotifications() *NotificationContext {
+	return &NotificationContext{
+		PendingConversations: []PendingConversation{
+			{SessionID: "s1", LastFrom: "alice", SenderWaiting: true},
+			{SessionID: "s2", LastFrom: "bob"},
+		},
+		MessagesWaiting: 4,
+		UrgentMail:      []UrgentMail{{MessageID: "m1", From: "carol"}},
+		InlineMail: []InlineMail{
+			{MessageID: "m1", From: "carol", Body: "prod is down", Priority: aweb.PriorityUrgent, Complete: true},
+			{MessageID: "m2", From: "dave", Body: "fyi", Priority: aweb.PriorityLow, Complete: true},
+		},
+		MailReceipts: []MailReceiptNotice{{}},
+		CurrentAlias: "claude-be",
+	}
+}
+
+func TestApplyQuietHours_HoldsThenSummarizes(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{Notifications: &config.NotificationsConfig{QuietHours: []string{"22:00-07:00"}}}
+	night := time.Date(2026, 3, 2, 23, 0, 0, 0, time.Local)
+
+	ctx := quietNotifications()
+	applyQuietHours(cfg, ctx, night)
+	out := FormatNotifications(ctx, "")
+	if !strings.Contains(out, "alice is waiting") || !strings.Contains(out, "prod is down") {
+		t.Errorf("urgent notifications should break through:\n%s", out)
+	}
+	for _, held := range []string{"unread conversation", "fyi", "unread message"} {
+		if strings.Contains(out, held) {
+			t.Errorf("%q should be held during quiet hours:\n%s", held, out)
+		}
+	}
+	if len(ctx.MailReceipts) != 1 {
+		t.Errorf("read receipts report completed work and must not be dropped, got %+v", ctx.MailReceipts)
+	}
+
+	// A later command in the same window: still held, no summary yet
+	ctx = quietNotifications()
+	applyQuietHours(cfg, ctx, night.Add(2*time.Hour))
+	if ctx.QuietHoursSummary != nil || len(ctx.PendingConversations) != 1 {
+		t.Fatalf("expected the window to continue, got %+v", ctx)
+	}
+
+	morning := time.Date(2026, 3, 3, 8, 0, 0, 0, time.Local)
+	ctx = quietNotifications()
+	applyQuietHours(cfg, ctx, morning)
+	if len(ctx.PendingConversations) != 2 || len(ctx.InlineMail) != 2 {
+		t.Errorf("nothing should be held after the window, got %+v", ctx)
+	}
+	out = FormatNotifications(ctx, "")
+	if !strings.Contains(out, "**QUIET HOURS** 23:00–07:00 are over; held 3 unread messages, chats from bob\n") {
+		t.Errorf("expected the summary:\n%s", out)
+	}
+
+	// The summary is shown once
+	ctx = quietNotifications()
+	applyQuietHours(cfg, ctx, morning.Add(time.Minute))
+	if ctx.QuietHoursSummary != nil {
+		t.Errorf("summary shown twice: %+v", ctx.QuietHoursSummary)
+	}
+}
+
+func TestFormatQuietHoursSummary_NothingHeld(t *testing.T) {
+	if got := formatQuietHoursSummary(&QuietHoursSummary{}); got != "" {
+		t.Errorf("expected no summary when nothing was held, got %q", got)
+	}
+}
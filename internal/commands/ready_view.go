@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/i18n"
+)
+
+// bdh ready prints bd's ready list followed by the agent's own context and
+// the team's. ready --:mine and ready --:team print one half of that
+// context only; they skip bd and the fetches the other half needs.
+
+const (
+	readyViewMine = "mine"
+	readyViewTeam = "team"
+)
+
+// parseReadyView removes --:mine and --:team from args and returns the view
+// they select ("" for the full ready output).
+func parseReadyView(args []string) ([]string, string, error) {
+	args, mine := parseBoolFlag(args, "--:mine")
+	args, team := parseBoolFlag(args, "--:team")
+	if !mine && !team {
+		return args, "", nil
+	}
+	if mine && team {
+		return nil, "", fmt.Errorf("--:mine and --:team cannot be combined (plain 'bdh ready' shows both)")
+	}
+	if len(args) == 0 || args[0] != "ready" {
+		return nil, "", fmt.Errorf("--:mine and --:team only apply to 'bdh ready'")
+	}
+	if mine {
+		return args, readyViewMine, nil
+	}
+	return args, readyViewTeam, nil
+}
+
+// locksHeldBy returns the reservations held by alias.
+func locksHeldBy(locks []aweb.ReservationView, alias string) []aweb.ReservationView {
+	var out []aweb.ReservationView
+	for _, lock := range locks {
+		if lock.HolderAlias == alias {
+			out = append(out, lock)
+		}
+	}
+	return out
+}
+
+// formatReadyViewEmpty says so when a ready subview has nothing to show,
+// since there is no bd output above it.
+func formatReadyViewEmpty(result *PassthroughResult) string {
+	switch result.ReadyView {
+	case readyViewMine:
+		if len(result.MyClaims) == 0 && strings.TrimSpace(result.MyFocusApexID) == "" {
+			return i18n.T("ready.mine.none") + "\n"
+		}
+	case readyViewTeam:
+		if len(result.TeamStatus) == 0 && result.TeamOthers == nil {
+			return i18n.T("ready.team.none") + "\n"
+		}
+	}
+	return ""
+}
+
+// formatMyReadyLocks lists the reservations the agent holds (ready --:mine).
+func formatMyReadyLocks(result *PassthroughResult) string {
+	if result.ReadyView != readyViewMine {
+		return ""
+	}
+	mine := locksHeldBy(result.ReadyLocks, result.MyAlias)
+	if len(mine) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinationHeader())
+	sb.WriteString("\n## " + i18n.T("ready.mylocks.title") + "\n")
+	now := serverNow()
+	for _, lock := range mine {
+		sb.WriteString(i18n.T("ready.mylocks.entry", lock.ResourceKey, formatDuration(ttlRemainingSeconds(lock.ExpiresAt, now))) + "\n")
+	}
+	return sb.String()
+}
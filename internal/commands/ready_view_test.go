@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestParseReadyView(t *testing.T) {
+	args, view, err := parseReadyView([]string{"ready", "--:mine", "--json"})
+	if err != nil || view != readyViewMine || strings.Join(args, " ") != "ready --json" {
+		t.Fatalf("got %v %q %v", args, view, err)
+	}
+	if _, view, _ := parseReadyView([]string{"ready", "--:team"}); view != readyViewTeam {
+		t.Fatalf("view = %q, want team", view)
+	}
+	if _, view, err := parseReadyView([]string{"ready"}); view != "" || err != nil {
+		t.Fatalf("plain ready: %q %v", view, err)
+	}
+	if _, _, err := parseReadyView([]string{"ready", "--:mine", "--:team"}); err == nil {
+		t.Error("expected error combining --:mine and --:team")
+	}
+	if _, _, err := parseReadyView([]string{"list", "--:mine"}); err == nil {
+		t.Error("expected error for --:mine outside ready")
+	}
+}
+
+// readyViewServer serves the ready context and records which paths were hit.
+func readyViewServer(t *testing.T) (*httptest.Server, func() map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	hits := map[string]int{}
+	expires := time.Now().Add(30 * time.Minute).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		me := map[string]any{
+			"workspace_id": "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+			"alias":        "test-agent",
+			"claims":       []any{map[string]any{"bead_id": "bd-7", "title": "Mine", "claimed_at": time.Now().UTC().Format(time.RFC3339)}},
+		}
+		switch r.URL.Path {
+		case "/v1/bdh/command":
+			json.NewEncoder(w).Encode(map[string]any{"approved": true, "context": map[string]any{"beads_in_progress": []any{}}})
+		case "/v1/workspaces":
+			json.NewEncoder(w).Encode(map[string]any{"workspaces": []any{me}, "count": 1})
+		case "/v1/workspaces/team":
+			json.NewEncoder(w).Encode(map[string]any{"workspaces": []any{me, map[string]any{
+				"workspace_id": "ws-other",
+				"alias":        "other-agent",
+				"last_seen":    time.Now().UTC().Format(time.RFC3339),
+				"claims":       []any{map[string]any{"bead_id": "bd-9", "claimed_at": time.Now().UTC().Format(time.RFC3339)}},
+			}}, "count": 2})
+		case "/v1/reservations":
+			json.NewEncoder(w).Encode(map[string]any{"reservations": []any{
+				map[string]any{"resource_key": "src/mine.go", "holder_alias": "test-agent", "expires_at": expires},
+				map[string]any{"resource_key": "src/theirs.go", "holder_alias": "other-agent", "expires_at": expires},
+			}, "count": 2})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+		out := map[string]int{}
+		for k, v := range hits {
+			out[k] = v
+		}
+		return out
+	}
+}
+
+func setupReadyViewWorkspace(t *testing.T, url string) {
+	t.Helper()
+	resetCapabilitiesMemo()
+	t.Cleanup(resetCapabilitiesMemo)
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(origDir) })
+	os.Chdir(tmpDir)
+	os.MkdirAll(".beads", 0755)
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      url,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPassthrough_ReadyMine(t *testing.T) {
+	server, hits := readyViewServer(t)
+	defer server.Close()
+	setupReadyViewWorkspace(t, server.URL)
+	// bd is not on PATH here: the subview must not run it
+	t.Setenv("PATH", t.TempDir())
+
+	result, err := runPassthrough(context.Background(), []string{"ready", "--:mine"})
+	if err != nil {
+		t.Fatalf("runPassthrough: %v", err)
+	}
+	if result.ReadyView != readyViewMine || result.Stdout != "" {
+		t.Fatalf("view=%q stdout=%q", result.ReadyView, result.Stdout)
+	}
+	if h := hits(); h["/v1/workspaces/team"] != 0 || h["/v1/workspaces"] != 1 {
+		t.Fatalf("unexpected fetches: %v", h)
+	}
+	if len(result.ReadyLocks) != 1 || result.ReadyLocks[0].ResourceKey != "src/mine.go" {
+		t.Fatalf("locks = %+v", result.ReadyLocks)
+	}
+
+	out := formatPassthroughOutput(result)
+	for _, want := range []string{"bd-7", "Your File Reservations", "src/mine.go"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "src/theirs.go") || strings.Contains(out, "other-agent") {
+		t.Errorf("--:mine should not show team context:\n%s", out)
+	}
+}
+
+func TestPassthrough_ReadyTeam(t *testing.T) {
+	server, hits := readyViewServer(t)
+	defer server.Close()
+	setupReadyViewWorkspace(t, server.URL)
+	t.Setenv("PATH", t.TempDir())
+
+	result, err := runPassthrough(context.Background(), []string{"ready", "--:team", "--json"})
+	if err != nil {
+		t.Fatalf("runPassthrough: %v", err)
+	}
+	if h := hits(); h["/v1/workspaces"] != 0 || h["/v1/chat/pending"] != 0 {
+		t.Fatalf("unexpected fetches: %v", h)
+	}
+	if len(result.MyClaims) != 0 || len(result.TeamStatus) != 1 || result.TeamStatus[0].Alias != "other-agent" {
+		t.Fatalf("claims=%+v team=%+v", result.MyClaims, result.TeamStatus)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal([]byte(formatPassthroughOutputJSON(result)), &out); err != nil {
+		t.Fatal(err)
+	}
+	ready, _ := out["ready_context"].(map[string]any)
+	if ready["view"] != "team" {
+		t.Fatalf("ready_context = %v", out["ready_context"])
+	}
+
+	text := formatPassthroughOutput(result)
+	if strings.Contains(text, "bd-7") || !strings.Contains(text, "other-agent") {
+		t.Errorf("--:team output:\n%s", text)
+	}
+}
@@ -0,0 +1,288 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :recover gathers what an agent session that died mid-work leaves behind:
+// reservations still held, claims still in progress, coordination steps
+// waiting for :replay, an open :txn, and chats waiting on a reply. --resume
+// and --cleanup then act on all of it at once.
+
+const (
+	recoverModeResume  = "resume"
+	recoverModeCleanup = "cleanup"
+)
+
+// RecoverReport is the output of :recover.
+type RecoverReport struct {
+	Reservations []aweb.ReservationView `json:"reservations"`
+	Claims       []client.Claim         `json:"claims"`
+	FailedOps    []FailedOperation      `json:"failed_ops"`
+	Txn          *TxnState              `json:"txn,omitempty"`
+	Chats        []PendingConversation  `json:"chats"`
+	Mode         string                 `json:"mode,omitempty"`
+	Actions      []string               `json:"actions,omitempty"`
+	Warnings     []string               `json:"warnings,omitempty"`
+}
+
+var (
+	recoverResume  bool
+	recoverCleanup bool
+	recoverJSON    bool
+)
+
+var recoverCmd = &cobra.Command{
+	Use:   ":recover",
+	Short: "Find and deal with what a crashed agent session left behind",
+	Long: `List what this workspace still holds after an agent process died
+mid-work: file reservations, claims in progress, failed syncs and
+notifications waiting for :replay, an open transaction, and chats waiting on
+a reply.
+
+--resume picks the work back up: failed steps are replayed, and claims and
+reservations are kept.
+--cleanup lets the work go: failed steps are replayed, reservations are
+released, and claimed beads are set back to open.
+
+An open transaction is never committed or aborted for you; finish it with
+bdh :txn commit or bdh :txn abort.
+
+Examples:
+  bdh :recover             # Show what is left over
+  bdh :recover --resume    # Continue where the session stopped
+  bdh :recover --cleanup   # Release everything for someone else`,
+	Args: cobra.NoArgs,
+	RunE: runRecover,
+}
+
+func init() {
+	recoverCmd.Flags().BoolVar(&recoverResume, "resume", false, "Replay failed steps and keep claims and reservations")
+	recoverCmd.Flags().BoolVar(&recoverCleanup, "cleanup", false, "Replay failed steps, release reservations and reopen claimed beads")
+	recoverCmd.Flags().BoolVar(&recoverJSON, "json", false, "Output as JSON")
+}
+
+func runRecover(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if recoverResume && recoverCleanup {
+		return fmt.Errorf("--resume and --cleanup cannot be used together")
+	}
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	aw, err := newAwebClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+
+	report := gatherRecoverReport(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), aw)
+	switch {
+	case recoverResume:
+		resumeSession(ctx, cfg, report)
+	case recoverCleanup:
+		cleanupSession(ctx, cfg, aw, report, func(beadID string) error { return reopenClaimedBead(ctx, beadID) })
+	}
+	fmt.Print(formatRecoverOutput(report, recoverJSON))
+	return nil
+}
+
+// gatherRecoverReport collects what this workspace still holds. Each part
+// that cannot be read becomes a warning, so the rest is still shown.
+func gatherRecoverReport(ctx context.Context, cfg *config.Config, c BeadHubAPI, aw AwebAPI) *RecoverReport {
+	report := &RecoverReport{}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	if locks, err := aw.ReservationList(ctx, ""); err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("could not list reservations: %v", err))
+	} else {
+		report.Reservations = locksHeldBy(locks.Reservations, cfg.Alias)
+	}
+
+	if resp, err := c.Workspaces(ctx, &client.WorkspacesRequest{Alias: cfg.Alias, IncludeClaims: true}); err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("could not list claims: %v", err))
+	} else {
+		for _, ws := range resp.Workspaces {
+			if ws.WorkspaceID == cfg.WorkspaceID {
+				report.Claims = append(report.Claims, ws.Claims...)
+			}
+		}
+	}
+
+	if pending, err := aw.ChatPending(ctx); err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("could not list pending chats: %v", err))
+	} else {
+		report.Chats = pendingConversationsFrom(pending)
+	}
+
+	if path, err := failedOpsPath(); err == nil {
+		ops, err := loadFailedOps(path)
+		if err != nil {
+			report.Warnings = append(report.Warnings, err.Error())
+		}
+		report.FailedOps = ops
+	}
+
+	txn, err := activeTxn()
+	if err != nil {
+		report.Warnings = append(report.Warnings, err.Error())
+	}
+	report.Txn = txn
+	return report
+}
+
+// replayFailedOps retries every recorded failed step, oldest first.
+func replayFailedOps(ctx context.Context, cfg *config.Config, report *RecoverReport) {
+	path, err := failedOpsPath()
+	if err != nil {
+		return
+	}
+	for _, op := range report.FailedOps {
+		ops, err := loadFailedOps(path)
+		if err != nil {
+			report.Warnings = append(report.Warnings, err.Error())
+			return
+		}
+		result, err := replayWithConfig(ctx, cfg, path, ops, op.ID)
+		switch {
+		case err != nil:
+			report.Warnings = append(report.Warnings, fmt.Sprintf("could not replay %s: %v", op.ID, err))
+		case result.Succeeded:
+			report.Actions = append(report.Actions, "replayed "+describeFailedOp(op))
+		default:
+			report.Warnings = append(report.Warnings, fmt.Sprintf("replay of %s failed: %s", op.ID, result.Error))
+		}
+	}
+}
+
+// resumeSession replays failed steps and keeps everything else.
+func resumeSession(ctx context.Context, cfg *config.Config, report *RecoverReport) {
+	report.Mode = recoverModeResume
+	replayFailedOps(ctx, cfg, report)
+}
+
+// cleanupSession replays failed steps, so no change stays local-only, then
+// releases reservations and reopens claimed beads with reopen.
+func cleanupSession(ctx context.Context, cfg *config.Config, aw AwebAPI, report *RecoverReport, reopen func(beadID string) error) {
+	report.Mode = recoverModeCleanup
+	replayFailedOps(ctx, cfg, report)
+
+	for _, lock := range report.Reservations {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, err := aw.ReservationRelease(ctx, &aweb.ReservationReleaseRequest{ResourceKey: lock.ResourceKey})
+		cancel()
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("could not release %s: %v", lock.ResourceKey, err))
+			continue
+		}
+		report.Actions = append(report.Actions, "released "+lock.ResourceKey)
+	}
+
+	for _, claim := range report.Claims {
+		if err := reopen(claim.BeadID); err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("could not reopen %s: %v", claim.BeadID, err))
+			continue
+		}
+		report.Actions = append(report.Actions, "reopened "+claim.BeadID)
+	}
+}
+
+// reopenClaimedBead sets a bead back to open through the usual passthrough,
+// so the change is synced and the claim disappears for the team.
+func reopenClaimedBead(ctx context.Context, beadID string) error {
+	result, err := runPassthrough(ctx, []string{"update", beadID, "--status", "open"})
+	if err != nil {
+		return err
+	}
+	switch {
+	case result.Blocked != "":
+		return fmt.Errorf("%s", result.Blocked)
+	case result.ExitCode != 0:
+		return fmt.Errorf("bd exited %d: %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	case result.SyncWarning != "":
+		return fmt.Errorf("reopened locally, but %s", result.SyncWarning)
+	}
+	return nil
+}
+
+func (r *RecoverReport) empty() bool {
+	return len(r.Reservations) == 0 && len(r.Claims) == 0 && len(r.FailedOps) == 0 && r.Txn == nil && len(r.Chats) == 0
+}
+
+func formatRecoverOutput(report *RecoverReport, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(report)
+	}
+	var sb strings.Builder
+	for _, w := range report.Warnings {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", w))
+	}
+	if report.Mode != "" {
+		if len(report.Actions) == 0 {
+			sb.WriteString("Nothing to do.\n")
+		}
+		for _, a := range report.Actions {
+			sb.WriteString(fmt.Sprintf("✓ %s\n", a))
+		}
+		if report.Mode == recoverModeResume {
+			for _, claim := range report.Claims {
+				sb.WriteString(fmt.Sprintf("Continue: bdh show %s\n", claim.BeadID))
+			}
+		}
+		if report.Txn != nil {
+			sb.WriteString(fmt.Sprintf("Transaction %s is still open: bdh :txn commit or bdh :txn abort\n", report.Txn.ID))
+		}
+		return sb.String()
+	}
+
+	if report.empty() {
+		sb.WriteString("Nothing left over: no reservations, claims, failed steps or pending chats.\n")
+		return sb.String()
+	}
+	if len(report.Reservations) > 0 {
+		sb.WriteString("Reservations you hold:\n")
+		for _, lock := range report.Reservations {
+			sb.WriteString(fmt.Sprintf("  %s (%s)\n", lock.ResourceKey, formatExpiry(lock.ExpiresAt, serverNow())))
+		}
+	}
+	if len(report.Claims) > 0 {
+		sb.WriteString("Claims in progress:\n")
+		for _, claim := range report.Claims {
+			line := "  " + claim.BeadID
+			if claim.Title != "" {
+				line += " " + claim.Title
+			}
+			if claim.ClaimedAt != "" {
+				line += fmt.Sprintf(" (claimed %s)", formatTimestamp(claim.ClaimedAt))
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	if len(report.FailedOps) > 0 {
+		sb.WriteString("Failed steps waiting for :replay:\n")
+		for _, op := range report.FailedOps {
+			sb.WriteString(fmt.Sprintf("  %s  %s\n", op.ID, describeFailedOp(op)))
+		}
+	}
+	if report.Txn != nil {
+		sb.WriteString(fmt.Sprintf("Open transaction %s with %d command(s): bdh :txn commit or bdh :txn abort\n", report.Txn.ID, len(report.Txn.Commands)))
+	}
+	if len(report.Chats) > 0 {
+		sb.WriteString("Chats waiting on you:\n")
+		for _, chat := range report.Chats {
+			sb.WriteString(fmt.Sprintf("  %s: %s → bdh :aweb chat send %s \"your reply\"\n",
+				displayAlias(chat.LastFrom), truncateText(chat.LastMessage, 60), chat.LastFrom))
+		}
+	}
+	sb.WriteString("\nPick the work back up: bdh :recover --resume\nLet it go for someone else: bdh :recover --cleanup\n")
+	return sb.String()
+}
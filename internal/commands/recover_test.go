@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestRecover_GatherAndCleanup(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{WorkspaceID: "ws-me", Alias: "me"}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	var released []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/reservations":
+			json.NewEncoder(w).Encode(aweb.ReservationListResponse{Reservations: []aweb.ReservationView{
+				{ResourceKey: "api/auth.py", HolderAlias: "me"},
+				{ResourceKey: "web/app.ts", HolderAlias: "bob"},
+			}})
+		case "/v1/reservations/release":
+			var req aweb.ReservationReleaseRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			released = append(released, req.ResourceKey)
+			json.NewEncoder(w).Encode(map[string]any{"status": "released"})
+		case "/v1/workspaces":
+			json.NewEncoder(w).Encode(client.WorkspacesResponse{Workspaces: []client.Workspace{
+				{WorkspaceID: "ws-me", Alias: "me", Claims: []client.Claim{{BeadID: "bd-1"}, {BeadID: "bd-2"}}},
+			}})
+		case "/v1/chat/pending":
+			json.NewEncoder(w).Encode(aweb.ChatPendingResponse{Pending: []aweb.ChatPendingItem{
+				{SessionID: "s1", LastFrom: "bob", LastMessage: "are you still on bd-1?", SenderWaiting: true},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	aw, err := aweb.NewWithAPIKey(server.URL, "aw_sk_test123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := gatherRecoverReport(context.Background(), cfg, client.New(server.URL), aw)
+	if len(report.Warnings) != 0 || len(report.Reservations) != 1 || len(report.Claims) != 2 || len(report.Chats) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	out := formatRecoverOutput(report, false)
+	for _, want := range []string{"api/auth.py", "bd-1", `bdh :aweb chat send bob "your reply"`, "bdh :recover --cleanup"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "web/app.ts") {
+		t.Errorf("other agents' reservations must not be listed:\n%s", out)
+	}
+
+	var reopened []string
+	cleanupSession(context.Background(), cfg, aw, report, func(beadID string) error {
+		if beadID == "bd-2" {
+			return fmt.Errorf("bd exited 1")
+		}
+		reopened = append(reopened, beadID)
+		return nil
+	})
+	if strings.Join(released, ",") != "api/auth.py" || strings.Join(reopened, ",") != "bd-1" {
+		t.Errorf("released %v, reopened %v", released, reopened)
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "bd-2") {
+		t.Errorf("warnings = %v", report.Warnings)
+	}
+}
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+)
+
+// maxMenuReadyBeads is how many ready beads the [p]ick option offers.
+const maxMenuReadyBeads = 5
+
+// rejectionMenuActions are the side effects the rejection menu can trigger.
+// Swappable so the menu logic can be tested without a server or bd.
+type rejectionMenuActions struct {
+	sendMessage func(toAliases []string, body string) error
+	rerun       func(args []string) (*PassthroughResult, error)
+	readyBeads  func() ([]Issue, error)
+}
+
+func defaultRejectionMenuActions(ctx context.Context) rejectionMenuActions {
+	return rejectionMenuActions{
+		sendMessage: func(toAliases []string, body string) error { return sendMailToAliases(ctx, toAliases, body) },
+		rerun:       func(args []string) (*PassthroughResult, error) { return runPassthrough(ctx, args) },
+		readyBeads:  func() ([]Issue, error) { return listReadyBeads(ctx) },
+	}
+}
+
+// shouldOfferRejectionMenu reports whether to prompt interactively after a rejection.
+func shouldOfferRejectionMenu(result *PassthroughResult) bool {
+	return result != nil && result.Rejected && !result.JSONMode && isTTY()
+}
+
+// runRejectionMenu offers [m]essage / [j]ump in / [p]ick another / [q]uit after
+// a rejected claim. It returns the args and result of a follow-up command, or
+// ok=false if the user quit or only sent a message.
+func runRejectionMenu(in *bufio.Reader, out io.Writer, args []string, result *PassthroughResult, actions rejectionMenuActions) (nextArgs []string, next *PassthroughResult, ok bool) {
+	beadID := extractBeadIDFromArgs(args)
+	claimants := rejectionClaimants(beadID, result)
+
+	for {
+		fmt.Fprint(out, "What now? [m]essage claimant, [j]ump in, [p]ick another ready bead, [q]uit: ")
+		choice, err := readMenuLine(in)
+		if err != nil {
+			return nil, nil, false
+		}
+
+		switch strings.ToLower(choice) {
+		case "m", "message":
+			if len(claimants) == 0 {
+				fmt.Fprintln(out, "No claimant to message.")
+				continue
+			}
+			fmt.Fprintf(out, "Message to %s: ", strings.Join(claimants, ", "))
+			body, err := readMenuLine(in)
+			if err != nil || body == "" {
+				fmt.Fprintln(out, "No message sent.")
+				continue
+			}
+			if beadID != "" {
+				body = fmt.Sprintf("[%s] %s", beadID, body)
+			}
+			if err := actions.sendMessage(claimants, body); err != nil {
+				fmt.Fprintf(out, "Failed to send: %v\n", err)
+				continue
+			}
+			fmt.Fprintf(out, "Sent to %s.\n", strings.Join(claimants, ", "))
+			return nil, nil, false
+
+		case "j", "jump", "jump-in":
+			fmt.Fprint(out, "Reason for joining: ")
+			reason, err := readMenuLine(in)
+			if err != nil || reason == "" {
+				fmt.Fprintln(out, "A reason is required to jump in.")
+				continue
+			}
+			nextArgs = append(append([]string{}, args...), "--:jump-in", reason)
+			next, err := actions.rerun(nextArgs)
+			if err != nil {
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return nil, nil, false
+			}
+			return nextArgs, next, true
+
+		case "p", "pick":
+			pickedArgs, ok := pickReadyBead(in, out, args, beadID, result, actions)
+			if !ok {
+				continue
+			}
+			next, err := actions.rerun(pickedArgs)
+			if err != nil {
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return nil, nil, false
+			}
+			return pickedArgs, next, true
+
+		case "q", "quit", "":
+			return nil, nil, false
+
+		default:
+			fmt.Fprintf(out, "Unknown choice %q.\n", choice)
+		}
+	}
+}
+
+// pickReadyBead lists ready beads nobody else holds and rewrites args to claim
+// the chosen one instead of beadID.
+func pickReadyBead(in *bufio.Reader, out io.Writer, args []string, beadID string, result *PassthroughResult, actions rejectionMenuActions) ([]string, bool) {
+	if beadID == "" {
+		fmt.Fprintln(out, "Couldn't tell which bead this command targets.")
+		return nil, false
+	}
+	ready, err := actions.readyBeads()
+	if err != nil {
+		fmt.Fprintf(out, "Could not list ready beads: %v\n", err)
+		return nil, false
+	}
+
+	taken := map[string]bool{beadID: true}
+	for _, b := range result.BeadsInProgress {
+		taken[b.BeadID] = true
+	}
+	var candidates []Issue
+	for _, issue := range ready {
+		if taken[issue.ID] {
+			continue
+		}
+		candidates = append(candidates, issue)
+		if len(candidates) == maxMenuReadyBeads {
+			break
+		}
+	}
+	if len(candidates) == 0 {
+		fmt.Fprintln(out, "No other ready beads.")
+		return nil, false
+	}
+
+	for i, issue := range candidates {
+		fmt.Fprintf(out, "  %d. %s \"%s\"\n", i+1, issue.ID, issue.Title)
+	}
+	fmt.Fprintf(out, "Pick 1-%d: ", len(candidates))
+	choice, err := readMenuLine(in)
+	if err != nil {
+		return nil, false
+	}
+	n, err := strconv.Atoi(choice)
+	if err != nil || n < 1 || n > len(candidates) {
+		fmt.Fprintln(out, "No bead picked.")
+		return nil, false
+	}
+
+	picked := make([]string, len(args))
+	for i, arg := range args {
+		if arg == beadID {
+			arg = candidates[n-1].ID
+		}
+		picked[i] = arg
+	}
+	return picked, true
+}
+
+// rejectionClaimants returns aliases of other agents holding beadID.
+func rejectionClaimants(beadID string, result *PassthroughResult) []string {
+	var aliases []string
+	seen := make(map[string]bool)
+	for _, b := range result.BeadsInProgress {
+		if beadID != "" && b.BeadID != beadID {
+			continue
+		}
+		if b.Alias == "" || seen[b.Alias] {
+			continue
+		}
+		seen[b.Alias] = true
+		aliases = append(aliases, b.Alias)
+	}
+	return aliases
+}
+
+func readMenuLine(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func sendMailToAliases(ctx context.Context, toAliases []string, body string) error {
+	return sendMailWithSubject(ctx, toAliases, "", body)
+}
+
+// sendMailWithSubject sends the same mail to each alias, stopping at the
+// first failure.
+func sendMailWithSubject(ctx context.Context, toAliases []string, subject, body string) error {
+	identity, err := currentAgentIdentityForAweb()
+	if err != nil {
+		return err
+	}
+	aw, err := awebClientFor(ctx, identity.BaseURL, identity.APIKey)
+	if err != nil {
+		return err
+	}
+	for _, alias := range toAliases {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, err := aw.SendMessage(ctx, &aweb.SendMessageRequest{ToAlias: alias, Subject: subject, Body: body})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("sending to %s: %w", alias, err)
+		}
+	}
+	return nil
+}
+
+// listReadyBeads returns bd's ready queue in bd's priority order.
+func listReadyBeads(ctx context.Context) ([]Issue, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	res, err := defaultBdRunner().Run(ctx, []string{"ready", "--json"})
+	if err != nil {
+		return nil, err
+	}
+	if res.ExitCode != 0 {
+		return nil, fmt.Errorf("bd ready failed (exit %d)", res.ExitCode)
+	}
+	var issues []Issue
+	if err := json.Unmarshal([]byte(strings.TrimSpace(res.Stdout)), &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd ready output: %w", err)
+	}
+	return issues, nil
+}
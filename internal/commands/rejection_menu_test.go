@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func rejectedClaimResult() *PassthroughResult {
+	return &PassthroughResult{
+		Rejected:        true,
+		RejectionReason: "bd-42 is being worked on by bob",
+		BeadsInProgress: []client.BeadInProgress{
+			{BeadID: "bd-42", Alias: "bob"},
+			{BeadID: "bd-7", Alias: "carol"},
+		},
+	}
+}
+
+func TestRejectionMenu_JumpInRerunsWithReason(t *testing.T) {
+	var rerunArgs []string
+	actions := rejectionMenuActions{
+		rerun: func(args []string) (*PassthroughResult, error) {
+			rerunArgs = args
+			return &PassthroughResult{}, nil
+		},
+	}
+	args := []string{"update", "bd-42", "--status", "in_progress"}
+	in := bufio.NewReader(strings.NewReader("j\npairing on the fix\n"))
+	var out bytes.Buffer
+
+	nextArgs, next, ok := runRejectionMenu(in, &out, args, rejectedClaimResult(), actions)
+	if !ok || next == nil {
+		t.Fatalf("expected follow-up result, output:\n%s", out.String())
+	}
+	want := "update bd-42 --status in_progress --:jump-in pairing on the fix"
+	if strings.Join(rerunArgs, " ") != want || strings.Join(nextArgs, " ") != want {
+		t.Errorf("rerun args = %q, want %q", rerunArgs, want)
+	}
+}
+
+func TestRejectionMenu_MessagesClaimant(t *testing.T) {
+	var sentTo []string
+	var sentBody string
+	actions := rejectionMenuActions{
+		sendMessage: func(to []string, body string) error {
+			sentTo, sentBody = to, body
+			return nil
+		},
+	}
+	in := bufio.NewReader(strings.NewReader("m\nare you still on this?\n"))
+	var out bytes.Buffer
+
+	_, _, ok := runRejectionMenu(in, &out, []string{"update", "bd-42", "--status", "in_progress"}, rejectedClaimResult(), actions)
+	if ok {
+		t.Error("messaging should not produce a follow-up command")
+	}
+	if strings.Join(sentTo, ",") != "bob" {
+		t.Errorf("sent to %v, want only the bd-42 claimant", sentTo)
+	}
+	if sentBody != "[bd-42] are you still on this?" {
+		t.Errorf("body = %q", sentBody)
+	}
+}
+
+func TestRejectionMenu_PickSkipsClaimedBeads(t *testing.T) {
+	var rerunArgs []string
+	actions := rejectionMenuActions{
+		readyBeads: func() ([]Issue, error) {
+			return []Issue{
+				{ID: "bd-42", Title: "Contested"},
+				{ID: "bd-7", Title: "Carol has it"},
+				{ID: "bd-8", Title: "Free one"},
+				{ID: "bd-9", Title: "Another"},
+			}, nil
+		},
+		rerun: func(args []string) (*PassthroughResult, error) {
+			rerunArgs = args
+			return &PassthroughResult{}, nil
+		},
+	}
+	in := bufio.NewReader(strings.NewReader("p\n1\n"))
+	var out bytes.Buffer
+
+	_, _, ok := runRejectionMenu(in, &out, []string{"update", "bd-42", "--status", "in_progress"}, rejectedClaimResult(), actions)
+	if !ok {
+		t.Fatalf("expected a follow-up claim, output:\n%s", out.String())
+	}
+	if strings.Join(rerunArgs, " ") != "update bd-8 --status in_progress" {
+		t.Errorf("rerun args = %v", rerunArgs)
+	}
+	if strings.Contains(out.String(), "bd-7") {
+		t.Errorf("claimed bead should not be offered:\n%s", out.String())
+	}
+}
+
+func TestRejectionMenu_QuitAndEOF(t *testing.T) {
+	for _, input := range []string{"q\n", ""} {
+		in := bufio.NewReader(strings.NewReader(input))
+		var out bytes.Buffer
+		if _, _, ok := runRejectionMenu(in, &out, []string{"update", "bd-42"}, rejectedClaimResult(), rejectionMenuActions{}); ok {
+			t.Errorf("input %q: expected no follow-up", input)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Coordination steps that fail after bd has already run (the sync upload,
+// notifications to other agents) are recorded in .beadhub-cache/failed-ops.json
+// so that :replay can retry just that step instead of the user making a fake
+// mutation to trigger a new sync.
+
+const (
+	failedOpSync   = "sync"
+	failedOpNotify = "notify"
+
+	// maxFailedOps bounds the replay log; the oldest entries are dropped.
+	maxFailedOps = 20
+)
+
+// FailedOperation is a coordination step that can be retried with :replay.
+type FailedOperation struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	CreatedAt string `json:"created_at"`
+	Error     string `json:"error"`
+	Attempts  int    `json:"attempts"`
+
+	// sync: the bd command that triggered it and its sync options
+	BdArgs         []string `json:"bd_args,omitempty"`
+	Targets        []string `json:"targets,omitempty"`
+	ConfirmDeletes bool     `json:"confirm_deletes,omitempty"`
+	Force          bool     `json:"force,omitempty"`
+
+	// notify: the message that was not delivered
+	ToAgentID string `json:"to_agent_id,omitempty"`
+	ToAlias   string `json:"to_alias,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+	Body      string `json:"body,omitempty"`
+}
+
+// ReplayResult is the outcome of re-running one failed operation.
+type ReplayResult struct {
+	Operation FailedOperation `json:"operation"`
+	Succeeded bool            `json:"succeeded"`
+	Error     string          `json:"error,omitempty"`
+	Remaining int             `json:"remaining"`
+}
+
+var replayJSON bool
+
+var replayCmd = &cobra.Command{
+	Use:   ":replay [last|<id>]",
+	Short: "Re-run a failed sync or notification",
+	Long: `Re-run a coordination step that failed after bd had already run.
+
+When the sync to BeadHub or a notification to another agent fails, bdh
+records it. :replay retries just that step - bd is not run again. Without
+arguments it lists the recorded failures.
+
+Examples:
+  bdh :replay            # List failed steps
+  bdh :replay last       # Retry the most recent one
+  bdh :replay op-1a2b3c  # Retry a specific one`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReplay,
+}
+
+func init() {
+	replayCmd.Flags().BoolVar(&replayJSON, "json", false, "Output as JSON")
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+
+	path, err := failedOpsPath()
+	if err != nil {
+		return err
+	}
+	ops, err := loadFailedOps(path)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Print(formatFailedOps(ops, replayJSON))
+		return nil
+	}
+
+	result, err := replayWithConfig(ctx, cfg, path, ops, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatReplayResult(result, replayJSON))
+	if !result.Succeeded {
+		return fmt.Errorf("replay of %s failed", result.Operation.ID)
+	}
+	return nil
+}
+
+func failedOpsPath() (string, error) {
+	return cacheFilePath("failed-ops.json")
+}
+
+func loadFailedOps(path string) ([]FailedOperation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ops []FailedOperation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return ops, nil
+}
+
+func saveFailedOps(path string, ops []FailedOperation) error {
+	if len(ops) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONAtomic(path, ops)
+}
+
+func newFailedOpID() string {
+	var b [3]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("op-%d", time.Now().UnixNano())
+	}
+	return "op-" + hex.EncodeToString(b[:])
+}
+
+// recordFailedOp appends op to the replay log and returns its ID, or "" if
+// it could not be recorded. A newer failed sync replaces older ones: replaying
+// it re-exports and uploads everything that is still pending anyway.
+func recordFailedOp(op FailedOperation) string {
+	path, err := failedOpsPath()
+	if err != nil {
+		return ""
+	}
+	ops, err := loadFailedOps(path)
+	if err != nil {
+		return ""
+	}
+	op.ID = newFailedOpID()
+	if op.CreatedAt == "" {
+		op.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	if op.Kind == failedOpSync {
+		kept := ops[:0]
+		for _, existing := range ops {
+			if existing.Kind != failedOpSync {
+				kept = append(kept, existing)
+			}
+		}
+		ops = kept
+	}
+	ops = append(ops, op)
+	if len(ops) > maxFailedOps {
+		ops = ops[len(ops)-maxFailedOps:]
+	}
+	if err := saveFailedOps(path, ops); err != nil {
+		return ""
+	}
+	return op.ID
+}
+
+// recordFailedNotification records a message to another agent that could not
+// be sent.
+func recordFailedNotification(req *aweb.SendMessageRequest, toAlias string, sendErr error) string {
+	return recordFailedOp(FailedOperation{
+		Kind:      failedOpNotify,
+		Error:     sendErr.Error(),
+		ToAgentID: req.ToAgentID,
+		ToAlias:   toAlias,
+		Subject:   req.Subject,
+		Body:      req.Body,
+	})
+}
+
+func replayHint(id string) string {
+	if id == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (retry: bdh :replay %s)", id)
+}
+
+// findFailedOp resolves "last" or an ID (or unique ID prefix).
+func findFailedOp(ops []FailedOperation, ref string) (int, error) {
+	if len(ops) == 0 {
+		return -1, fmt.Errorf("no failed operations to replay")
+	}
+	if ref == "last" {
+		return len(ops) - 1, nil
+	}
+	match := -1
+	for i, op := range ops {
+		if op.ID == ref {
+			return i, nil
+		}
+		if strings.HasPrefix(op.ID, ref) {
+			if match >= 0 {
+				return -1, fmt.Errorf("%q matches more than one operation", ref)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, fmt.Errorf("no failed operation %q (run 'bdh :replay' to list them)", ref)
+	}
+	return match, nil
+}
+
+// replayWithConfig re-runs one failed operation. On success it is removed
+// from the log; on failure its error and attempt count are updated.
+func replayWithConfig(ctx context.Context, cfg *config.Config, path string, ops []FailedOperation, ref string) (*ReplayResult, error) {
+	idx, err := findFailedOp(ops, ref)
+	if err != nil {
+		return nil, err
+	}
+	op := ops[idx]
+	op.Attempts++
+
+	var replayErr string
+	switch op.Kind {
+	case failedOpSync:
+		syncResult := syncToBeadHub(ctx, cfg, op.BdArgs, syncOptions{
+			ConfirmDeletes: op.ConfirmDeletes,
+			Force:          op.Force,
+			Targets:        op.Targets,
+		})
+		replayErr = syncResult.Warning
+	case failedOpNotify:
+		aw, awErr := newAwebClientRequired(ctx, cfg.BeadhubURL)
+		if awErr != nil {
+			return nil, awErr
+		}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, sendErr := aw.SendMessage(ctx, &aweb.SendMessageRequest{
+			ToAgentID: op.ToAgentID,
+			Subject:   op.Subject,
+			Body:      op.Body,
+		})
+		cancel()
+		if sendErr != nil {
+			replayErr = sendErr.Error()
+		}
+	default:
+		return nil, fmt.Errorf("cannot replay operation of kind %q", op.Kind)
+	}
+
+	result := &ReplayResult{Operation: op, Succeeded: replayErr == "", Error: replayErr}
+	if result.Succeeded {
+		ops = append(ops[:idx:idx], ops[idx+1:]...)
+	} else {
+		op.Error = replayErr
+		ops[idx] = op
+	}
+	if err := saveFailedOps(path, ops); err != nil {
+		return nil, fmt.Errorf("saving %s: %w", path, err)
+	}
+	result.Remaining = len(ops)
+	return result, nil
+}
+
+func describeFailedOp(op FailedOperation) string {
+	switch op.Kind {
+	case failedOpSync:
+		if len(op.BdArgs) == 0 {
+			return "sync"
+		}
+		return "sync after: bd " + strings.Join(op.BdArgs, " ")
+	case failedOpNotify:
+		to := op.ToAlias
+		if to == "" {
+			to = op.ToAgentID
+		}
+		return fmt.Sprintf("message to %s: %s", to, truncateText(op.Body, 60))
+	default:
+		return op.Kind
+	}
+}
+
+func formatFailedOps(ops []FailedOperation, asJSON bool) string {
+	if asJSON {
+		if ops == nil {
+			ops = []FailedOperation{}
+		}
+		return marshalJSONOrFallback(ops)
+	}
+	if len(ops) == 0 {
+		return "No failed operations.\n"
+	}
+	var sb strings.Builder
+	sb.WriteString("Failed operations (oldest first):\n")
+	for _, op := range ops {
+		sb.WriteString(fmt.Sprintf("  %s  %s  %s\n", op.ID, formatTimestamp(op.CreatedAt), describeFailedOp(op)))
+		sb.WriteString(fmt.Sprintf("      error: %s\n", op.Error))
+	}
+	sb.WriteString("\nRetry with: bdh :replay last  (or bdh :replay <id>)\n")
+	return sb.String()
+}
+
+func formatReplayResult(result *ReplayResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	if result.Succeeded {
+		return fmt.Sprintf("Replayed %s: %s\n", result.Operation.ID, describeFailedOp(result.Operation))
+	}
+	return fmt.Sprintf("Replay of %s failed (attempt %d): %s\n", result.Operation.ID, result.Operation.Attempts, result.Error)
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestRecordFailedOp_KeepsOneSyncAndCaps(t *testing.T) {
+	t.Chdir(t.TempDir())
+	first := recordFailedOp(FailedOperation{Kind: failedOpSync, Error: "sync failed (503)"})
+	recordFailedOp(FailedOperation{Kind: failedOpNotify, ToAlias: "bob", Body: "hi", Error: "timeout"})
+	second := recordFailedOp(FailedOperation{Kind: failedOpSync, Error: "sync failed (502)"})
+	if first == "" || second == "" || first == second {
+		t.Fatalf("ids = %q, %q", first, second)
+	}
+
+	path, _ := failedOpsPath()
+	ops, err := loadFailedOps(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 2 || ops[0].Kind != failedOpNotify || ops[1].ID != second {
+		t.Fatalf("ops = %+v", ops)
+	}
+
+	for i := 0; i < maxFailedOps+5; i++ {
+		recordFailedOp(FailedOperation{Kind: failedOpNotify, Error: "x"})
+	}
+	ops, _ = loadFailedOps(path)
+	if len(ops) != maxFailedOps {
+		t.Errorf("expected the log to be capped at %d, got %d", maxFailedOps, len(ops))
+	}
+}
+
+func TestFindFailedOp(t *testing.T) {
+	ops := []FailedOperation{{ID: "op-aa11"}, {ID: "op-aa22"}, {ID: "op-bb33"}}
+	tests := []struct {
+		ref     string
+		want    int
+		wantErr string
+	}{
+		{ref: "last", want: 2},
+		{ref: "op-aa22", want: 1},
+		{ref: "op-b", want: 2},
+		{ref: "op-aa", wantErr: "more than one"},
+		{ref: "op-zz", wantErr: "no failed operation"},
+	}
+	for _, tt := range tests {
+		got, err := findFailedOp(ops, tt.ref)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q: err = %v, want %q", tt.ref, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q = %d, %v; want %d", tt.ref, got, err, tt.want)
+		}
+	}
+	if _, err := findFailedOp(nil, "last"); err == nil {
+		t.Error("expected error for empty log")
+	}
+}
+
+func TestReplay_SyncAndNotify(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open"}
+`)
+
+	failing := true
+	var sent []aweb.SendMessageRequest
+	syncs := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/bdh/sync":
+			syncs++
+			w.Write([]byte(`{"synced": true, "issues_count": 1}`))
+		case "/v1/messages":
+			var req aweb.SendMessageRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sent = append(sent, req)
+			json.NewEncoder(w).Encode(aweb.SendMessageResponse{MessageID: "m1"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:  server.URL,
+		Alias:       "alice",
+	}
+
+	syncResult := syncToBeadHub(context.Background(), cfg, []string{"update", "bd-1", "--status", "in_progress"}, syncOptions{})
+	if !syncResult.Retryable {
+		t.Fatalf("expected a retryable sync failure, got %+v", syncResult)
+	}
+	syncID := recordFailedOp(FailedOperation{Kind: failedOpSync, Error: syncResult.Warning, BdArgs: []string{"update", "bd-1", "--status", "in_progress"}})
+	recordFailedNotification(&aweb.SendMessageRequest{ToAgentID: "ws-bob", Body: "alice is joining work on bd-1"}, "bob", errors.New("timeout"))
+
+	path, _ := failedOpsPath()
+	ops, _ := loadFailedOps(path)
+
+	// Still failing: the error and attempt count are updated, the op is kept.
+	result, err := replayWithConfig(context.Background(), cfg, path, ops, syncID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Succeeded || result.Operation.Attempts != 1 || result.Remaining != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	failing = false
+	ops, _ = loadFailedOps(path)
+	result, err = replayWithConfig(context.Background(), cfg, path, ops, syncID)
+	if err != nil || !result.Succeeded || syncs != 1 || result.Remaining != 1 {
+		t.Fatalf("sync replay = %+v, %v (syncs=%d)", result, err, syncs)
+	}
+
+	ops, _ = loadFailedOps(path)
+	result, err = replayWithConfig(context.Background(), cfg, path, ops, "last")
+	if err != nil || !result.Succeeded || result.Remaining != 0 {
+		t.Fatalf("notify replay = %+v, %v", result, err)
+	}
+	if len(sent) != 1 || sent[0].ToAgentID != "ws-bob" || sent[0].Body != "alice is joining work on bd-1" {
+		t.Errorf("sent = %+v", sent)
+	}
+	if out := formatReplayResult(result, false); !strings.Contains(out, "message to bob") {
+		t.Errorf("output = %s", out)
+	}
+	if out := formatFailedOps(nil, false); out != "No failed operations.\n" {
+		t.Errorf("empty list output = %q", out)
+	}
+}
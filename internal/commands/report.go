@@ -0,0 +1,399 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :report compiles the project's coordination activity (closes, claims,
+// escalations, jump-ins) per agent and per epic into a digest. It is meant
+// to run from cron: with --send the digest goes to the recipients in the
+// report section of .beadhub, as BeadHub mail and/or email through SMTP.
+
+const defaultReportWindow = 7 * 24 * time.Hour
+
+// maxReportParentDepth bounds the walk from a bead up to its epic.
+const maxReportParentDepth = 10
+
+// ActivityCounts counts one agent's or epic's events by kind.
+type ActivityCounts struct {
+	Closes      int `json:"closes"`
+	Claims      int `json:"claims"`
+	Escalations int `json:"escalations"`
+	JumpIns     int `json:"jump_ins"`
+}
+
+func (a *ActivityCounts) add(kind string) bool {
+	switch kind {
+	case client.ActivityClose:
+		a.Closes++
+	case client.ActivityClaim:
+		a.Claims++
+	case client.ActivityEscalation:
+		a.Escalations++
+	case client.ActivityJumpIn:
+		a.JumpIns++
+	default:
+		return false
+	}
+	return true
+}
+
+func (a ActivityCounts) total() int {
+	return a.Closes + a.Claims + a.Escalations + a.JumpIns
+}
+
+// AgentActivity is one agent's share of the digest.
+type AgentActivity struct {
+	Alias string `json:"alias"`
+	ActivityCounts
+}
+
+// EpicActivity is one epic's share of the digest. EpicID is empty for beads
+// that are not under any epic.
+type EpicActivity struct {
+	EpicID string `json:"epic_id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	ActivityCounts
+}
+
+// ReportResult is the output of :report.
+type ReportResult struct {
+	Project     string                 `json:"project"`
+	Since       time.Time              `json:"since"`
+	Until       time.Time              `json:"until"`
+	Events      int                    `json:"events"`
+	Totals      ActivityCounts         `json:"totals"`
+	Agents      []AgentActivity        `json:"agents"`
+	Epics       []EpicActivity         `json:"epics"`
+	Escalations []client.ActivityEvent `json:"escalations,omitempty"`
+	Delivered   []string               `json:"delivered,omitempty"`
+	Warning     string                 `json:"warning,omitempty"`
+}
+
+var (
+	reportSince time.Duration
+	reportSend  bool
+	reportJSON  bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   ":report",
+	Short: "Compile a digest of the project's activity per agent and per epic",
+	Long: `Summarize the project's coordination activity over a period: beads
+closed and claimed, escalations raised and --:jump-in overrides, per agent
+and per epic.
+
+With --send the digest is delivered to the recipients configured in .beadhub
+instead of printed, so it can run from cron:
+
+  report:
+    mail_to: [lead-alice]          # BeadHub mail to these workspaces
+    email_to: [lead@example.com]   # email through the SMTP server below
+    smtp:
+      host: smtp.example.com
+      port: 587
+      username: bdh
+      password_env: BDH_SMTP_PASSWORD
+      from: bdh@example.com
+
+Examples:
+  bdh :report                  # Last 7 days
+  bdh :report --since 24h      # Daily digest
+  bdh :report --json
+  0 9 * * MON cd /path/to/repo && bdh :report --send`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().DurationVar(&reportSince, "since", defaultReportWindow, "Report activity within this long")
+	reportCmd.Flags().BoolVar(&reportSend, "send", false, "Deliver the digest to the configured recipients")
+	reportCmd.Flags().BoolVar(&reportJSON, "json", false, "Output as JSON")
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+	if reportSince <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	if reportSend {
+		if err := checkReportRecipients(cfg.Report); err != nil {
+			return err
+		}
+	}
+	if err := requireServerFeature(ctx, cfg, featureActivity, "the activity feed"); err != nil {
+		return err
+	}
+
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+	until := time.Now()
+	since := until.Add(-reportSince)
+	reqCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	resp, err := c.Activity(reqCtx, &client.ActivityRequest{Since: since.UTC().Format(time.RFC3339)})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("fetching activity: %w", err)
+	}
+
+	issues, issuesErr := loadIssues()
+	result := buildReport(resp.Events, issues, since, until)
+	result.Project = cfg.ProjectSlug
+	if issuesErr != nil {
+		result.Warning = fmt.Sprintf("could not read issues (%v) - activity is not grouped by epic", issuesErr)
+	}
+
+	if !reportSend {
+		fmt.Print(formatReportOutput(result, reportJSON))
+		return nil
+	}
+
+	subject := reportSubject(result)
+	body := formatReportOutput(result, false)
+	result.Delivered, err = deliverReport(ctx, cfg.Report, subject, body, until)
+	if reportJSON {
+		fmt.Print(marshalJSONOrFallback(result))
+	} else if len(result.Delivered) > 0 {
+		fmt.Printf("Sent %s to %s\n", subject, strings.Join(result.Delivered, ", "))
+	}
+	return err
+}
+
+// checkReportRecipients fails early when --send has nowhere to go.
+func checkReportRecipients(rc *config.ReportConfig) error {
+	if rc == nil || (len(rc.MailTo) == 0 && len(rc.EmailTo) == 0) {
+		return fmt.Errorf("no report recipients - set report.mail_to or report.email_to in .beadhub")
+	}
+	if len(rc.EmailTo) > 0 && (rc.SMTP == nil || rc.SMTP.Host == "" || rc.SMTP.From == "") {
+		return fmt.Errorf("report.email_to needs report.smtp.host and report.smtp.from")
+	}
+	return nil
+}
+
+// buildReport aggregates events from [since, until) per agent and per epic.
+// issues are used to find each bead's epic; they may be nil.
+func buildReport(events []client.ActivityEvent, issues []Issue, since, until time.Time) *ReportResult {
+	result := &ReportResult{Since: since, Until: until}
+	epicOf := beadEpics(issues)
+	titles := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		titles[issue.ID] = issue.Title
+	}
+
+	agents := make(map[string]*AgentActivity)
+	epics := make(map[string]*EpicActivity)
+	for _, e := range events {
+		if at, ok := parseTimeBestEffort(e.At); ok && (at.Before(since) || !at.Before(until)) {
+			continue
+		}
+		if !result.Totals.add(e.Kind) {
+			continue
+		}
+		result.Events++
+
+		agent := agents[e.Alias]
+		if agent == nil {
+			agent = &AgentActivity{Alias: e.Alias}
+			agents[e.Alias] = agent
+		}
+		agent.add(e.Kind)
+
+		epicID := epicOf[e.BeadID]
+		epic := epics[epicID]
+		if epic == nil {
+			epic = &EpicActivity{EpicID: epicID, Title: titles[epicID]}
+			epics[epicID] = epic
+		}
+		epic.add(e.Kind)
+
+		if e.Kind == client.ActivityEscalation {
+			result.Escalations = append(result.Escalations, e)
+		}
+	}
+
+	for _, alias := range sortedKeys(agents) {
+		result.Agents = append(result.Agents, *agents[alias])
+	}
+	sort.SliceStable(result.Agents, func(i, j int) bool {
+		return result.Agents[i].total() > result.Agents[j].total()
+	})
+	for _, id := range sortedKeys(epics) {
+		result.Epics = append(result.Epics, *epics[id])
+	}
+	sort.SliceStable(result.Epics, func(i, j int) bool {
+		// Beads outside any epic go last.
+		if (result.Epics[i].EpicID == "") != (result.Epics[j].EpicID == "") {
+			return result.Epics[j].EpicID == ""
+		}
+		return result.Epics[i].total() > result.Epics[j].total()
+	})
+	return result
+}
+
+// beadEpics maps each bead to the nearest epic above it through
+// parent-child dependencies. Epics map to themselves.
+func beadEpics(issues []Issue) map[string]string {
+	byID := make(map[string]*Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	parentOf := func(issue *Issue) string {
+		for _, dep := range issue.Dependencies {
+			if dep.Type == "parent-child" && dep.DependsOnID != "" {
+				return dep.DependsOnID
+			}
+		}
+		return ""
+	}
+
+	epics := make(map[string]string, len(issues))
+	for id, issue := range byID {
+		for depth := 0; issue != nil && depth < maxReportParentDepth; depth++ {
+			if issue.IssueType == "epic" {
+				epics[id] = issue.ID
+				break
+			}
+			issue = byID[parentOf(issue)]
+		}
+	}
+	return epics
+}
+
+func reportSubject(result *ReportResult) string {
+	return fmt.Sprintf("BeadHub digest for %s, %s to %s", result.Project,
+		result.Since.Local().Format("Jan 2"), result.Until.Local().Format("Jan 2"))
+}
+
+func formatActivityCounts(a ActivityCounts) string {
+	return fmt.Sprintf("%d closed, %d claimed, %d escalations, %d jump-ins", a.Closes, a.Claims, a.Escalations, a.JumpIns)
+}
+
+func formatReportOutput(result *ReportResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.Warning != "" {
+		sb.WriteString("Warning: " + result.Warning + "\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("Activity in %s from %s to %s\n", result.Project,
+		result.Since.Local().Format("2006-01-02 15:04"), result.Until.Local().Format("2006-01-02 15:04")))
+	if result.Events == 0 {
+		sb.WriteString("\nNo activity in this period.\n")
+		return sb.String()
+	}
+	sb.WriteString(formatActivityCounts(result.Totals) + "\n")
+
+	sb.WriteString("\nBy agent:\n")
+	for _, a := range result.Agents {
+		sb.WriteString(fmt.Sprintf("  %-20s %s\n", a.Alias, formatActivityCounts(a.ActivityCounts)))
+	}
+
+	sb.WriteString("\nBy epic:\n")
+	for _, e := range result.Epics {
+		name := "(no epic)"
+		if e.EpicID != "" {
+			name = e.EpicID
+			if e.Title != "" {
+				name += " \"" + e.Title + "\""
+			}
+		}
+		sb.WriteString(fmt.Sprintf("  %s\n    %s\n", name, formatActivityCounts(e.ActivityCounts)))
+	}
+
+	if len(result.Escalations) > 0 {
+		sb.WriteString("\nEscalations:\n")
+		for _, e := range result.Escalations {
+			line := "  " + e.Alias
+			if e.BeadID != "" {
+				line += " on " + e.BeadID
+			}
+			if e.Detail != "" {
+				line += ": " + truncateText(e.Detail, 100)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// deliverReport sends the digest to every configured recipient and returns
+// the ones it reached. Delivery continues past failures; the first error is
+// returned.
+func deliverReport(ctx context.Context, rc *config.ReportConfig, subject, body string, now time.Time) ([]string, error) {
+	var delivered []string
+	var firstErr error
+	if len(rc.MailTo) > 0 {
+		if err := sendMailWithSubject(ctx, rc.MailTo, subject, body); err != nil {
+			firstErr = fmt.Errorf("sending BeadHub mail: %w", err)
+		} else {
+			delivered = append(delivered, rc.MailTo...)
+		}
+	}
+	if len(rc.EmailTo) > 0 {
+		if err := sendReportEmail(rc.SMTP, rc.EmailTo, subject, body, now); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("sending email: %w", err)
+			}
+		} else {
+			delivered = append(delivered, rc.EmailTo...)
+		}
+	}
+	return delivered, firstErr
+}
+
+// sendReportEmail sends the digest through SMTP. net/smtp upgrades to TLS
+// when the server offers STARTTLS, and refuses to send credentials otherwise.
+func sendReportEmail(sc *config.SMTPConfig, to []string, subject, body string, now time.Time) error {
+	var auth smtp.Auth
+	if sc.Username != "" {
+		password := ""
+		if sc.PasswordEnv != "" {
+			password = os.Getenv(sc.PasswordEnv)
+		}
+		auth = smtp.PlainAuth("", sc.Username, password, sc.Host)
+	}
+	addr := net.JoinHostPort(sc.Host, strconv.Itoa(sc.SMTPPort()))
+	return smtp.SendMail(addr, auth, sc.From, to, buildReportEmail(sc.From, to, subject, body, now))
+}
+
+// buildReportEmail renders a plain-text RFC 5322 message.
+func buildReportEmail(from string, to []string, subject, body string, now time.Time) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	sb.WriteString("Subject: " + subject + "\r\n")
+	sb.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	sb.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(sb.String())
+}
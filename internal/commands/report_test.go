@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func reportTestIssues() []Issue {
+	child := func(id, parent string) Issue {
+		return Issue{ID: id, Title: id, Status: "open", IssueType: "task",
+			Dependencies: []Dependency{{IssueID: id, DependsOnID: parent, Type: "parent-child"}}}
+	}
+	return []Issue{
+		{ID: "bd-e1", Title: "Auth", Status: "open", IssueType: "epic"},
+		child("bd-1", "bd-e1"),
+		child("bd-2", "bd-1"),
+		{ID: "bd-3", Title: "Stray", Status: "open", IssueType: "bug"},
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	until := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	since := until.Add(-defaultReportWindow)
+	events := []client.ActivityEvent{
+		{Kind: client.ActivityClaim, Alias: "alice", BeadID: "bd-1", At: "2025-06-10T09:00:00Z"},
+		{Kind: client.ActivityClose, Alias: "alice", BeadID: "bd-2", At: "2025-06-11T09:00:00Z"},
+		{Kind: client.ActivityJumpIn, Alias: "bob", BeadID: "bd-1", At: "2025-06-12T09:00:00Z"},
+		{Kind: client.ActivityEscalation, Alias: "bob", BeadID: "bd-3", Detail: "need a decision", At: "2025-06-13T09:00:00Z"},
+		{Kind: client.ActivityClose, Alias: "carol", BeadID: "bd-3", At: "2025-06-01T09:00:00Z"}, // before the window
+		{Kind: "renamed", Alias: "carol", At: "2025-06-13T09:00:00Z"},
+	}
+	result := buildReport(events, reportTestIssues(), since, until)
+
+	if result.Events != 4 || result.Totals != (ActivityCounts{Closes: 1, Claims: 1, Escalations: 1, JumpIns: 1}) {
+		t.Errorf("unexpected totals: %d %+v", result.Events, result.Totals)
+	}
+	if len(result.Agents) != 2 || result.Agents[0].Alias != "alice" || result.Agents[1].Alias != "bob" {
+		t.Fatalf("unexpected agents: %+v", result.Agents)
+	}
+	if len(result.Epics) != 2 || result.Epics[0].EpicID != "bd-e1" || result.Epics[0].total() != 3 || result.Epics[1].EpicID != "" {
+		t.Errorf("unexpected epics: %+v", result.Epics)
+	}
+	if len(result.Escalations) != 1 || result.Escalations[0].BeadID != "bd-3" {
+		t.Errorf("unexpected escalations: %+v", result.Escalations)
+	}
+
+	out := formatReportOutput(result, false)
+	for _, want := range []string{"By agent:", "bd-e1 \"Auth\"", "(no epic)", "bob on bd-3: need a decision"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestCheckReportRecipients(t *testing.T) {
+	if err := checkReportRecipients(nil); err == nil {
+		t.Error("expected an error without recipients")
+	}
+	if err := checkReportRecipients(&config.ReportConfig{EmailTo: []string{"lead@example.com"}}); err == nil {
+		t.Error("expected an error for email without smtp")
+	}
+	if err := checkReportRecipients(&config.ReportConfig{MailTo: []string{"lead"}}); err != nil {
+		t.Errorf("mail only: %v", err)
+	}
+}
+
+func TestBuildReportEmail(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	msg := string(buildReportEmail("bdh@example.com", []string{"a@example.com", "b@example.com"}, "Digest", "line 1\nline 2\n", now))
+	for _, want := range []string{"To: a@example.com, b@example.com\r\n", "Subject: Digest\r\n", "\r\n\r\nline 1\r\nline 2\r\n"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("missing %q in %q", want, msg)
+		}
+	}
+}
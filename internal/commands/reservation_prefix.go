@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+)
+
+// Directory reservations use keys like "src/api/**" and cover every path
+// under the directory. The server compares resource keys verbatim, so the
+// overlap between a directory reservation and the files (or directories)
+// inside it is enforced here: before acquiring, bdh checks the others'
+// reservations with reservationsOverlap.
+
+const (
+	dirReservationSuffix = "/**"
+	// autoReserveCollapseThreshold is how many changed files under one
+	// directory make auto-reserve take a single directory reservation.
+	autoReserveCollapseThreshold = 10
+)
+
+// isDirReservation reports whether key reserves a directory prefix.
+func isDirReservation(key string) bool {
+	return strings.HasSuffix(key, dirReservationSuffix)
+}
+
+// dirReservationKey returns the directory reservation for dir ("src/api").
+func dirReservationKey(dir string) string {
+	return strings.TrimSuffix(dir, "/") + dirReservationSuffix
+}
+
+// normalizeReservationKey turns "src/api/" into "src/api/**" and rejects
+// wildcards other than a trailing "/**".
+func normalizeReservationKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if strings.HasSuffix(key, "/") && !strings.HasSuffix(key, "//") {
+		key = dirReservationKey(key)
+	}
+	dir := strings.TrimSuffix(key, dirReservationSuffix)
+	if dir == "" || dir == "." || dir == "*" {
+		return "", fmt.Errorf("cannot reserve the whole repository; reserve a directory such as src/api/**")
+	}
+	if strings.Contains(dir, "*") {
+		return "", fmt.Errorf("%q: only a trailing /** wildcard is supported", key)
+	}
+	return key, nil
+}
+
+// reservationCovers reports whether holding key covers target, which may be
+// a file path or another directory reservation.
+func reservationCovers(key, target string) bool {
+	if key == target {
+		return true
+	}
+	if !isDirReservation(key) {
+		return false
+	}
+	prefix := strings.TrimSuffix(key, "**")
+	return strings.HasPrefix(target, prefix)
+}
+
+// reservationsOverlap reports whether two keys conflict: one covers the other.
+func reservationsOverlap(a, b string) bool {
+	return reservationCovers(a, b) || reservationCovers(b, a)
+}
+
+// coveringReservation finds the reservation in held (indexed by key) that
+// covers filePath: the path itself or any directory above it.
+func coveringReservation(held map[string]aweb.ReservationView, filePath string) (aweb.ReservationView, bool) {
+	if r, ok := held[filePath]; ok {
+		return r, true
+	}
+	for dir := path.Dir(filePath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if r, ok := held[dirReservationKey(dir)]; ok {
+			return r, true
+		}
+	}
+	return aweb.ReservationView{}, false
+}
+
+// overlappingReservation returns the first blocking reservation held by
+// someone other than myAlias that overlaps key.
+func overlappingReservation(reservations []aweb.ReservationView, myAlias, key string) (aweb.ReservationView, bool) {
+	for _, r := range reservations {
+		if r.HolderAlias == myAlias || r.ResourceKey == "" {
+			continue
+		}
+		if exclusive, ok := r.Metadata["exclusive"].(bool); ok && !exclusive {
+			continue
+		}
+		if reservationsOverlap(r.ResourceKey, key) {
+			return r, true
+		}
+	}
+	return aweb.ReservationView{}, false
+}
+
+// collapseLockPaths replaces the files under any directory holding at least
+// threshold of them with one directory reservation, deepest directories
+// first so the reservations stay as narrow as possible. Directories that
+// overlap a reservation held by someone else are never collapsed, so a
+// collapse cannot turn one file conflict into a whole-directory one.
+func collapseLockPaths(paths map[string]struct{}, threshold int, others []aweb.ReservationView, myAlias string) map[string]struct{} {
+	if threshold <= 1 || len(paths) < threshold {
+		return paths
+	}
+
+	filesUnder := make(map[string][]string)
+	for p := range paths {
+		for dir := path.Dir(p); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			filesUnder[dir] = append(filesUnder[dir], p)
+		}
+	}
+	dirs := make([]string, 0, len(filesUnder))
+	for dir, files := range filesUnder {
+		if len(files) >= threshold {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		di, dj := strings.Count(dirs[i], "/"), strings.Count(dirs[j], "/")
+		if di != dj {
+			return di > dj
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	covered := make(map[string]bool)
+	collapsed := make(map[string]struct{})
+	for _, dir := range dirs {
+		var remaining []string
+		for _, p := range filesUnder[dir] {
+			if !covered[p] {
+				remaining = append(remaining, p)
+			}
+		}
+		if len(remaining) < threshold {
+			continue
+		}
+		key := dirReservationKey(dir)
+		if _, taken := overlappingReservation(others, myAlias, key); taken {
+			continue
+		}
+		for _, p := range remaining {
+			covered[p] = true
+		}
+		for inner := range collapsed {
+			if reservationCovers(key, inner) {
+				delete(collapsed, inner)
+			}
+		}
+		collapsed[key] = struct{}{}
+	}
+	if len(collapsed) == 0 {
+		return paths
+	}
+
+	for p := range paths {
+		if !covered[p] {
+			collapsed[p] = struct{}{}
+		}
+	}
+	return collapsed
+}
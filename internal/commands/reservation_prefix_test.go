@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+)
+
+func TestNormalizeReservationKey(t *testing.T) {
+	cases := map[string]string{
+		"src/api.go":  "src/api.go",
+		"src/api/":    "src/api/**",
+		" src/api/**": "src/api/**",
+	}
+	for in, want := range cases {
+		got, err := normalizeReservationKey(in)
+		if err != nil || got != want {
+			t.Errorf("normalizeReservationKey(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"/**", "**", "src/*.go", "src/*/**"} {
+		if _, err := normalizeReservationKey(bad); err == nil {
+			t.Errorf("normalizeReservationKey(%q) should fail", bad)
+		}
+	}
+}
+
+func TestReservationsOverlap(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"src/api/**", "src/api/router.go", true},
+		{"src/api/router.go", "src/api/**", true},
+		{"src/api/**", "src/api/v2/**", true},
+		{"src/**", "src/api/**", true},
+		{"src/api/**", "src/apiclient/x.go", false},
+		{"src/api/**", "src/web/**", false},
+		{"src/a.go", "src/b.go", false},
+		{"src/a.go", "src/a.go", true},
+	}
+	for _, tc := range cases {
+		if got := reservationsOverlap(tc.a, tc.b); got != tc.want {
+			t.Errorf("reservationsOverlap(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestCollapseLockPaths(t *testing.T) {
+	paths := map[string]struct{}{"README.md": {}, "src/main.go": {}}
+	for i := 0; i < 12; i++ {
+		paths[fmt.Sprintf("src/api/handler%02d.go", i)] = struct{}{}
+	}
+	for i := 0; i < 3; i++ {
+		paths[fmt.Sprintf("src/web/page%d.go", i)] = struct{}{}
+	}
+
+	got := collapseLockPaths(paths, 10, nil, "me")
+	want := []string{"README.md", "src/api/**", "src/main.go", "src/web/page0.go", "src/web/page1.go", "src/web/page2.go"}
+	if keys := sortedKeys(got); strings.Join(keys, ",") != strings.Join(want, ",") {
+		t.Fatalf("collapsed = %v, want %v", keys, want)
+	}
+
+	// Someone else holding a file inside keeps the directory uncollapsed
+	others := []aweb.ReservationView{{ResourceKey: "src/api/handler03.go", HolderAlias: "bob"}}
+	if got := collapseLockPaths(paths, 10, others, "me"); len(got) != len(paths) {
+		t.Fatalf("expected no collapse next to bob's reservation, got %v", sortedKeys(got))
+	}
+
+	// Below the threshold nothing changes
+	small := map[string]struct{}{"src/a.go": {}, "src/b.go": {}}
+	if got := collapseLockPaths(small, 10, nil, "me"); len(got) != 2 {
+		t.Fatalf("got %v", sortedKeys(got))
+	}
+}
+
+func TestStagedReservationConflicts_DirectoryReservation(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(10 * time.Minute).UTC().Format(time.RFC3339)
+	reservations := []aweb.ReservationView{{ResourceKey: "src/api/**", HolderAlias: "alice", ExpiresAt: expires}}
+	problems := stagedReservationConflicts([]string{"src/api/v2/router.go", "src/apiclient.go"}, reservations, "me", now)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "src/api/v2/router.go is reserved by alice") {
+		t.Errorf("problems = %v", problems)
+	}
+}
+
+func TestFormatReservedFiles_ShowsCoveringDirectory(t *testing.T) {
+	out := formatReservedFiles(&PassthroughResult{AutoReserveConflicts: []ReservationConflict{
+		{ResourceKey: "src/api/router.go", HeldBy: "alice", RetryAfterSeconds: 120, HeldKey: "src/api/**"},
+	}})
+	if !strings.Contains(out, "- `src/api/router.go` — alice via `src/api/**` (expires in 2m)") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
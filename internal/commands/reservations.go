@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -46,6 +45,7 @@ type ReservationsResult struct {
 }
 
 func runReservations(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	cfg, err := config.Load()
 	if err == nil && cfg.Validate() == nil {
 		if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
@@ -53,7 +53,7 @@ func runReservations(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	result, err := listReservations()
+	result, err := listReservations(ctx)
 	if err != nil {
 		return err
 	}
@@ -63,21 +63,11 @@ func runReservations(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func listReservations() (*ReservationsResult, error) {
+func listReservations(ctx context.Context) (*ReservationsResult, error) {
 	result := &ReservationsResult{}
 
-	cfg, err := config.Load()
+	cfg, err := loadWorkspaceConfig()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("no .beadhub file found - run 'bdh init' first")
-		}
-		return nil, fmt.Errorf("loading config: %w", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid .beadhub config: %w", err)
-	}
-	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 		return nil, err
 	}
 
@@ -90,10 +80,10 @@ func listReservations() (*ReservationsResult, error) {
 		req.Alias = cfg.Alias
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
-	c, err := newBeadHubClientRequired(cfg.BeadhubURL)
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +94,9 @@ func listReservations() (*ReservationsResult, error) {
 		if errors.As(err, &clientErr) {
 			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
 		}
+		if client.IsPayloadError(err) {
+			return nil, err
+		}
 		result.Warning = fmt.Sprintf("BeadHub unreachable at %s", cfg.BeadhubURL)
 		return result, nil
 	}
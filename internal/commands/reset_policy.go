@@ -3,12 +3,9 @@ package commands
 import (
 	"context"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
-
-	"github.com/beadhub/bdh/internal/config"
 )
 
 var resetPolicyForce bool
@@ -33,31 +30,23 @@ func init() {
 }
 
 func runResetPolicy(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
 	if !resetPolicyForce {
 		return fmt.Errorf("this will reset the project policy to defaults - use --force to confirm")
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadWorkspaceConfig()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
-		}
-		return fmt.Errorf("loading config: %w", err)
-	}
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid .beadhub config: %w", err)
-	}
-	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 		return err
 	}
 
 	// Notifications are handled by main.go's PrintNotifications
 
-	c, err := newBeadHubClientRequired(cfg.BeadhubURL)
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
 	if err != nil {
 		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	resp, err := c.ResetPolicy(ctx)
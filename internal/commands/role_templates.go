@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Role templates add a section to ready output chosen by the workspace's
+// role, so reviewers can see a review queue and implementers a list of
+// suggested next steps. The template comes from output.templates in .beadhub,
+// or else from the policy's ready_template adapter, and is a Go text/template
+// rendered over readyTemplateData. A template that fails to render shows the
+// error in place of the section; the rest of the output is unaffected.
+
+// Where a role template came from.
+const (
+	roleTemplateSourceConfig = "config"
+	roleTemplateSourcePolicy = "policy"
+)
+
+// RoleTemplate is the ready output template for this workspace's role.
+type RoleTemplate struct {
+	Role   string
+	Title  string
+	Body   string
+	Source string
+}
+
+// RoleSection is a rendered role template, as shown in ready output.
+type RoleSection struct {
+	Role   string `json:"role"`
+	Title  string `json:"title"`
+	Source string `json:"source"`
+	Text   string `json:"text,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// readyTemplateData is what role templates can use.
+type readyTemplateData struct {
+	Alias      string
+	Role       string
+	Claims     []client.Claim
+	FocusID    string
+	FocusTitle string
+	FocusType  string
+	Team       []client.Workspace
+	Locks      []aweb.ReservationView // held by other agents
+	MyLocks    []aweb.ReservationView
+	MergeRisks []MergeConflictRisk
+}
+
+// roleTemplateFuncs are the helpers role templates can call.
+var roleTemplateFuncs = template.FuncMap{
+	"name":     displayAlias,
+	"ago":      formatTimestamp,
+	"expires":  func(expiresAt string) string { return formatExpiry(expiresAt, serverNow()) },
+	"join":     strings.Join,
+	"truncate": func(max int, s string) string { return truncateText(s, max) },
+}
+
+// resolveRoleTemplate returns the template for this workspace's role, or
+// nil when neither .beadhub nor the policy defines one.
+func resolveRoleTemplate(ctx context.Context, cfg *config.Config) *RoleTemplate {
+	role := workspaceRole(cfg)
+	if tmpl, ok := cfg.OutputTemplate(role); ok {
+		return &RoleTemplate{Role: role, Title: tmpl.Title, Body: tmpl.Body, Source: roleTemplateSourceConfig}
+	}
+	adapter := fetchPolicyAdapter(ctx, cfg, policyAdapterReady)
+	if adapter == nil || strings.TrimSpace(adapter.BodyMD) == "" {
+		return nil
+	}
+	return &RoleTemplate{Role: role, Title: adapter.Title, Body: adapter.BodyMD, Source: roleTemplateSourcePolicy}
+}
+
+// renderRoleSection renders tmpl over the ready context in result.
+func renderRoleSection(tmpl *RoleTemplate, result *PassthroughResult) *RoleSection {
+	if tmpl == nil {
+		return nil
+	}
+	section := &RoleSection{Role: tmpl.Role, Title: tmpl.Title, Source: tmpl.Source}
+	if section.Title == "" {
+		section.Title = fmt.Sprintf("For %s", tmpl.Role)
+	}
+
+	data := readyTemplateData{
+		Alias:      result.MyAlias,
+		Role:       tmpl.Role,
+		Claims:     result.MyClaims,
+		FocusID:    result.MyFocusApexID,
+		FocusTitle: result.MyFocusApexTitle,
+		FocusType:  result.MyFocusApexType,
+		Team:       result.TeamStatus,
+		MergeRisks: result.MergeRisks,
+	}
+	for _, lock := range result.ReadyLocks {
+		if lock.HolderAlias == result.MyAlias {
+			data.MyLocks = append(data.MyLocks, lock)
+		} else {
+			data.Locks = append(data.Locks, lock)
+		}
+	}
+
+	parsed, err := template.New(tmpl.Role).Funcs(roleTemplateFuncs).Option("missingkey=zero").Parse(tmpl.Body)
+	if err != nil {
+		section.Error = err.Error()
+		return section
+	}
+	var sb strings.Builder
+	if err := parsed.Execute(&sb, data); err != nil {
+		section.Error = err.Error()
+		return section
+	}
+	section.Text = strings.TrimSpace(sb.String())
+	return section
+}
+
+// formatRoleSection renders a role section for text output. A template with
+// no output adds nothing.
+func formatRoleSection(section *RoleSection) string {
+	if section == nil || (section.Text == "" && section.Error == "") {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinationHeader())
+	sb.WriteString(fmt.Sprintf("\n## %s\n", section.Title))
+	if section.Error != "" {
+		sb.WriteString(fmt.Sprintf("Warning: the %s template for %s failed: %s\n", section.Source, section.Role, section.Error))
+		return sb.String()
+	}
+	sb.WriteString(section.Text + "\n")
+	return sb.String()
+}
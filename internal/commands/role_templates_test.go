@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestResolveRoleTemplate_Config(t *testing.T) {
+	cfg := &config.Config{Role: "Reviewer", Output: &config.OutputConfig{Templates: map[string]config.OutputTemplate{
+		"reviewer": {Title: "Review queue", Body: "{{range .Team}}{{.Alias}} {{end}}"},
+	}}}
+	tmpl := resolveRoleTemplate(context.Background(), cfg)
+	if tmpl == nil || tmpl.Role != "reviewer" || tmpl.Title != "Review queue" || tmpl.Source != roleTemplateSourceConfig {
+		t.Errorf("resolveRoleTemplate = %+v", tmpl)
+	}
+}
+
+func TestResolvePolicyAdapter_ReadyTemplateByRole(t *testing.T) {
+	policy := &client.ActivePolicyResponse{Adapters: map[string]any{
+		policyAdapterReady: map[string]any{
+			"title":   "Suggested next",
+			"body_md": "{{range .Claims}}{{.BeadID}}{{end}}",
+			"roles": map[string]any{
+				"reviewer": map[string]any{"title": "Review queue", "body_md": "{{len .Team}} to review"},
+			},
+		},
+	}}
+	if a := resolvePolicyAdapter(policy, policyAdapterReady, "reviewer"); a == nil || a.Title != "Review queue" {
+		t.Errorf("reviewer adapter = %+v", a)
+	}
+	if a := resolvePolicyAdapter(policy, policyAdapterReady, "implementer"); a == nil || a.Title != "Suggested next" {
+		t.Errorf("implementer adapter = %+v", a)
+	}
+}
+
+func TestRenderRoleSection(t *testing.T) {
+	withDisplayNames(t, map[string]string{"bob": "Bob"})
+	result := &PassthroughResult{
+		MyAlias:    "me",
+		MyClaims:   []client.Claim{{BeadID: "bd-1", Title: "Fix login"}},
+		TeamStatus: []client.Workspace{{Alias: "bob", Claims: []client.Claim{{BeadID: "bd-2", Title: "Add search"}}}},
+		ReadyLocks: []aweb.ReservationView{{ResourceKey: "a.go", HolderAlias: "me"}, {ResourceKey: "b.go", HolderAlias: "bob"}},
+	}
+	tmpl := &RoleTemplate{Role: "reviewer", Title: "Review queue", Source: roleTemplateSourceConfig,
+		Body: "{{range .Team}}{{range .Claims}}- {{.BeadID}} {{.Title}} ({{name $.Alias}} / {{len $.MyLocks}} mine, {{len $.Locks}} theirs)\n{{end}}{{end}}"}
+
+	section := renderRoleSection(tmpl, result)
+	if section.Error != "" || section.Text != "- bd-2 Add search (me / 1 mine, 1 theirs)" {
+		t.Errorf("section = %+v", section)
+	}
+	out := formatRoleSection(section)
+	if !strings.Contains(out, "## Review queue\n- bd-2 Add search") {
+		t.Errorf("output = %q", out)
+	}
+
+	tmpl.Body = "{{.Nope.Deeper}}"
+	section = renderRoleSection(tmpl, result)
+	if section.Error == "" || !strings.Contains(formatRoleSection(section), "Warning: the config template for reviewer failed") {
+		t.Errorf("broken template: %+v", section)
+	}
+
+	tmpl.Body = "{{if .FocusID}}focus{{end}}"
+	if out := formatRoleSection(renderRoleSection(tmpl, result)); out != "" {
+		t.Errorf("empty template output should add nothing, got %q", out)
+	}
+}
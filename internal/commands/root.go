@@ -2,14 +2,18 @@
 package commands
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 
+	"github.com/beadhub/bdh/internal/client"
 	"github.com/beadhub/bdh/internal/config"
 )
 
@@ -46,10 +50,30 @@ Commands:
 Setup:
   bdh init              - Initialize beads (passthrough to bd)
   bdh :init             - Register workspace with BeadHub
+  bdh :seed             - Fill a fresh workspace with sample beads (demos)
 
 Global flags:
   -h, --help               - Show bdh help + bd help
   --:local-config <path>   - Use an alternate .beadhub config file
+  --:debug-http            - Dump HTTP requests and responses to stderr
+  --:install-bd            - Install the pinned bd release into ~/.local/bin (checksum verified)
+  --:raw-aliases           - Show agent aliases instead of display.aliases names
+  --:confirm-deletes       - Allow a sync that deletes many issues at once
+  --:force                 - Sync even if the issue graph fails hygiene checks
+  --:strict-sync           - Exit non-zero if the sync after a change fails
+  --:bd-env KEY=VALUE      - Set an environment variable for bd (repeatable; see bd.env)
+  --:request-takeover      - Ask to take over conflicting reservations of agents idle for hours
+  --:summary               - On close, add a summary (claim time, files, chats) to the reason
+  --:check <n|all>         - On close, confirm policy close requirements by number
+  --:na <n|all>            - On close, mark policy close requirements not applicable
+  --:rename <alias>        - Re-register this workspace under a new alias, then run
+  --:notify-only <alias>   - With --:jump-in, notify only these claimants (comma-separated)
+  --:mine                  - With ready, show only your claims, focus and reservations
+  --:team                  - With ready, show only what the rest of the team is doing
+  --:quiet                 - Print only bd output and fatal coordination warnings
+  --:normal                - Print the usual coordination sections (default)
+  --:verbose-context       - Print every coordination section without truncation
+  --:wait-approval <dur>   - In a supervised workspace, wait this long for a parked command's approval
 
 Help:
   bdh :help              - Show only bdh help (not bd)
@@ -60,7 +84,14 @@ Environment variables (for bdh :init):
   BEADHUB_ALIAS        - Workspace alias (default: auto-suggested)
   BEADHUB_ROLE         - Workspace role (default: agent)
   BEADHUB_HUMAN        - Human name (default: $USER)
-  BEADHUB_REPO_ORIGIN  - Override git remote origin (testing only)`,
+  BEADHUB_REPO_ORIGIN  - Override git remote origin (testing only)
+  BEADHUB_HTTP_STATS   - Print HTTP connection reuse stats to stderr
+  BEADHUB_DEBUG_HTTP   - Same as --:debug-http
+  BDH_MOCK_BD=1        - Use a built-in minimal bd on .beads/issues.jsonl (development/CI without beads)
+
+Environment variables (output):
+  BDH_LANG             - Language for coordination output (en, es; default en)
+  BDH_LOCALE_DIR       - Directory of <lang>.json catalogs that add or override messages`,
 	// Don't show usage/errors on errors from subcommands (main.go handles errors)
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -84,6 +115,46 @@ func init() {
 	rootCmd.AddCommand(projectsCmd)
 	rootCmd.AddCommand(addWorktreeCmd)
 	rootCmd.AddCommand(notifyCmd)
+	rootCmd.AddCommand(migrateServerCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(projectCmd)
+	rootCmd.AddCommand(verifySyncCmd)
+	rootCmd.AddCommand(onboardCmd)
+	rootCmd.AddCommand(loadCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(dndCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(hooksCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(teamCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(hotspotsCmd)
+	rootCmd.AddCommand(hookContextCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(commandAliasCmd)
+	rootCmd.AddCommand(metricsCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(orphanScanCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(staleClaimsCmd)
+	rootCmd.AddCommand(dumpCmd)
+	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(txnCmd)
+	rootCmd.AddCommand(worklogCmd)
+	rootCmd.AddCommand(whyCmd)
+	rootCmd.AddCommand(announceCmd)
+	rootCmd.AddCommand(conflictsCmd)
+	rootCmd.AddCommand(seedCmd)
+	rootCmd.AddCommand(tagsCmd)
+	rootCmd.AddCommand(findOwnerCmd)
+	rootCmd.AddCommand(recoverCmd)
+	rootCmd.AddCommand(apiCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(focusCmd)
+	rootCmd.AddCommand(inspectCmd)
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(pinCmd)
 	rootCmd.AddCommand(helpCmd)
 }
 
@@ -104,6 +175,12 @@ func loadDotenvBestEffort() {
 //   - -V, --version    → bdh version, then bd --version
 //   - everything else  → passthrough to bd
 func Execute() error {
+	return ExecuteContext(context.Background())
+}
+
+// ExecuteContext runs bdh under ctx; cancelling it (Ctrl-C) stops in-flight
+// HTTP calls and bd.
+func ExecuteContext(ctx context.Context) error {
 	// Parse --:local-config globally (affects all commands)
 	if len(os.Args) > 1 {
 		cleanedArgs, configPath, hasLocalConfig := parseLocalConfig(os.Args[1:])
@@ -115,18 +192,61 @@ func Execute() error {
 		os.Args = append([]string{os.Args[0]}, cleanedArgs...)
 	}
 
+	// Parse --:debug-http globally (dumps HTTP traffic to stderr)
+	if len(os.Args) > 1 {
+		cleanedArgs, debugHTTP := parseBoolFlag(os.Args[1:], "--:debug-http")
+		os.Args = append([]string{os.Args[0]}, cleanedArgs...)
+		if debugHTTP || os.Getenv("BEADHUB_DEBUG_HTTP") != "" {
+			client.SetDebugHTTP(os.Stderr)
+		}
+	}
+
+	// Parse --:raw-aliases globally (ignores display.aliases in output)
+	if len(os.Args) > 1 {
+		cleanedArgs, raw := parseBoolFlag(os.Args[1:], "--:raw-aliases")
+		os.Args = append([]string{os.Args[0]}, cleanedArgs...)
+		rawAliases = raw
+	}
+
 	loadDotenvBestEffort()
 
+	// Share one tuned connection pool across the BeadHub and aweb clients.
+	client.InstallAsDefault()
+	configureHTTPDecoding()
+	prewarmBeadhubConnection(ctx)
+	if os.Getenv("BEADHUB_HTTP_STATS") != "" {
+		defer printHTTPStats(os.Stderr)
+	}
+	defer warnClockSkew(os.Stderr)
+
+	// Parse --:install-bd globally (installs the pinned bd release, then runs
+	// the rest of the command line, if any)
+	if len(os.Args) > 1 {
+		cleanedArgs, installBd := parseBoolFlag(os.Args[1:], "--:install-bd")
+		os.Args = append([]string{os.Args[0]}, cleanedArgs...)
+		if installBd {
+			if err := runInstallBd(ctx); err != nil {
+				return err
+			}
+			if len(os.Args) <= 1 {
+				return nil
+			}
+		}
+	}
+
 	if len(os.Args) <= 1 {
 		// No args - show help
-		return rootCmd.Execute()
+		return rootCmd.ExecuteContext(ctx)
 	}
 
 	firstArg := os.Args[1]
 
 	// :* commands are handled by cobra
 	if strings.HasPrefix(firstArg, ":") {
-		return rootCmd.Execute()
+		start := time.Now()
+		err := rootCmd.ExecuteContext(ctx)
+		recordWorklogCommand(os.Args[1:], err, start)
+		return err
 	}
 
 	// Help: show bdh help, then bd help
@@ -134,7 +254,11 @@ func Execute() error {
 		_ = rootCmd.Help()
 		fmt.Println("\n--- bd commands (all available via bdh) ---")
 		fmt.Println()
-		return executePassthrough([]string{"--help"})
+		if err := checkBdInstalled(defaultBdRunner()); err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		return executePassthrough(ctx, []string{"--help"})
 	}
 
 	// Version: show bdh version, then bd version
@@ -147,32 +271,67 @@ func Execute() error {
 			fmt.Printf("  built:  %s\n", versionInfo.date)
 		}
 		fmt.Println()
-		return executePassthrough([]string{"--version"})
+		if err := checkBdInstalled(defaultBdRunner()); err != nil {
+			fmt.Println(err)
+			return nil
+		}
+		return executePassthrough(ctx, []string{"--version"})
 	}
 
 	// Everything else passes through to bd
-	return executePassthrough(os.Args[1:])
+	return executePassthrough(ctx, os.Args[1:])
 }
 
 // executePassthrough runs a bd command with coordination.
-func executePassthrough(args []string) error {
-	result, err := runPassthrough(args)
+func executePassthrough(ctx context.Context, args []string) error {
+	// Without bd there is nothing to coordinate: explain how to get it
+	if err := checkBdInstalled(defaultBdRunner()); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	result, err := runPassthrough(ctx, args)
 	if err != nil {
+		// An interrupted command still shows what it got done
+		if result != nil {
+			fmt.Print(formatPassthroughOutput(result))
+		}
+		recordWorklogCommand(args, err, start)
 		return err
 	}
+	recordCommandMetrics(args, result, time.Since(start))
+	recordWorklogPassthrough(args, result, start)
+	recordLastDecision(args, result, start)
 
 	// Print formatted output (notifications are printed by main.go)
 	output := formatPassthroughOutput(result)
 	fmt.Print(output)
 
-	// Exit with non-zero code if rejected (bd was not run)
-	if result.Rejected {
-		os.Exit(1)
+	// Humans at a terminal get a menu instead of retyping the command with flags
+	if shouldOfferRejectionMenu(result) {
+		in := bufio.NewReader(os.Stdin)
+		for shouldOfferRejectionMenu(result) {
+			nextArgs, next, ok := runRejectionMenu(in, os.Stdout, args, result, defaultRejectionMenuActions(ctx))
+			if !ok {
+				break
+			}
+			args, result = nextArgs, next
+			recordWorklogPassthrough(args, result, time.Now())
+			recordLastDecision(args, result, time.Now())
+			fmt.Print(formatPassthroughOutput(result))
+		}
 	}
 
-	// Exit with bd's exit code
-	if result.ExitCode != 0 {
-		os.Exit(result.ExitCode)
+	// Exit with non-zero code if rejected or blocked (bd was not run), or if
+	// the degradation policy or strict sync fails the command after a failed
+	// sync; otherwise with bd's exit code
+	summary := summarizePassthrough(result)
+	if !result.JSONMode {
+		setPendingSummary(&summary)
+	}
+	if summary.Exit != 0 {
+		PrintSummary(os.Stderr)
+		os.Exit(summary.Exit)
 	}
 
 	return nil
@@ -0,0 +1,380 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// Mail sent with --send-at/--delay is handed to the server's delayed-send
+// endpoint. Servers without it answer 404, and the message goes to a local
+// queue in .beadhub-cache/scheduled-mail.json instead; PrintNotifications
+// delivers due messages after each bdh command in this workspace.
+
+// maxScheduledMailDeliveries bounds the sends made per command.
+const maxScheduledMailDeliveries = 5
+
+const (
+	scheduledMailQueueServer = "server"
+	scheduledMailQueueLocal  = "local"
+)
+
+// ScheduledMail is a message waiting in the local queue.
+type ScheduledMail struct {
+	ID        string `json:"id"`
+	ToAlias   string `json:"to_alias"`
+	Subject   string `json:"subject,omitempty"`
+	Body      string `json:"body"`
+	Priority  string `json:"priority,omitempty"`
+	DeliverAt string `json:"deliver_at"`
+	QueuedAt  string `json:"queued_at"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// ScheduledMailResult is the output of mail send with --send-at or --delay.
+type ScheduledMailResult struct {
+	ScheduledID string `json:"scheduled_id"`
+	ToAlias     string `json:"to_alias"`
+	DeliverAt   string `json:"deliver_at"`
+	Queue       string `json:"queue"` // "server" or "local"
+}
+
+// ScheduledMailNotice reports a locally queued message that was delivered,
+// or given up on.
+type ScheduledMailNotice struct {
+	ToAlias string
+	Subject string
+	Error   string // empty when delivered
+}
+
+var (
+	awebMailSendAt string
+	awebMailDelay  string
+
+	awebMailScheduledCancel string
+)
+
+var awebMailScheduledCmd = &cobra.Command{
+	Use:   "scheduled",
+	Short: "List or cancel mail queued locally for later delivery",
+	Long: `List mail queued in this workspace with --send-at/--delay.
+
+Only the local queue is shown: it is used when the server does not support
+delayed delivery. Messages the server accepted for later delivery are not
+listed here.
+
+Examples:
+  bdh :aweb mail scheduled
+  bdh :aweb mail scheduled --cancel local-1a2b3c4d`,
+	Args: cobra.NoArgs,
+	RunE: runMailScheduled,
+}
+
+func init() {
+	awebMailSendCmd.Flags().StringVar(&awebMailSendAt, "send-at", "", "Deliver at a local time (17:00) instead of now")
+	awebMailSendCmd.Flags().StringVar(&awebMailDelay, "delay", "", "Deliver after a delay (30m) instead of now")
+	awebMailScheduledCmd.Flags().StringVar(&awebMailScheduledCancel, "cancel", "", "Remove a queued message by ID")
+	awebMailCmd.AddCommand(awebMailScheduledCmd)
+}
+
+// resolveMailDeliverAt returns the delivery time requested with --send-at or
+// --delay. ok is false when neither was given (send now).
+func resolveMailDeliverAt(sendAt, delay string, now time.Time) (deliverAt time.Time, ok bool, err error) {
+	sendAt = strings.TrimSpace(sendAt)
+	delay = strings.TrimSpace(delay)
+	switch {
+	case sendAt != "" && delay != "":
+		return time.Time{}, false, fmt.Errorf("use either --send-at or --delay, not both")
+	case sendAt != "":
+		if _, err := time.ParseDuration(sendAt); err == nil {
+			return time.Time{}, false, fmt.Errorf("--send-at takes a clock time like 17:00; use --delay for durations")
+		}
+		deliverAt, err = parseFutureTime("--send-at", sendAt, now)
+	case delay != "":
+		d, perr := time.ParseDuration(delay)
+		if perr != nil || d <= 0 {
+			return time.Time{}, false, fmt.Errorf("invalid --delay %q: use a positive duration like 30m", delay)
+		}
+		deliverAt = now.Add(d)
+	default:
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return deliverAt, true, nil
+}
+
+// scheduleMail asks the server to deliver req later, falling back to the
+// local queue when the server has no delayed-send endpoint.
+func scheduleMail(ctx context.Context, c BeadHubAPI, req *client.ScheduleMessageRequest, now time.Time) (*ScheduledMailResult, error) {
+	resp, err := c.ScheduleMessage(ctx, req)
+	if err == nil {
+		deliverAt := resp.DeliverAt
+		if deliverAt == "" {
+			deliverAt = req.DeliverAt
+		}
+		return &ScheduledMailResult{ScheduledID: resp.ScheduledID, ToAlias: req.ToAlias, DeliverAt: deliverAt, Queue: scheduledMailQueueServer}, nil
+	}
+
+	var clientErr *client.Error
+	if !errors.As(err, &clientErr) || (clientErr.StatusCode != 404 && clientErr.StatusCode != 405) {
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("scheduling mail: %w", err)
+	}
+
+	path, err := scheduledMailPath()
+	if err != nil {
+		return nil, fmt.Errorf("server does not support scheduled mail and there is no workspace for a local queue - run 'bdh :init' first")
+	}
+	item := ScheduledMail{
+		ID:        newScheduledMailID(),
+		ToAlias:   req.ToAlias,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		Priority:  req.Priority,
+		DeliverAt: req.DeliverAt,
+		QueuedAt:  now.UTC().Format(time.RFC3339),
+	}
+	err = updateScheduledMail(path, func(queue []ScheduledMail) ([]ScheduledMail, error) {
+		return append(queue, item), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("saving scheduled mail: %w", err)
+	}
+	return &ScheduledMailResult{ScheduledID: item.ID, ToAlias: item.ToAlias, DeliverAt: item.DeliverAt, Queue: scheduledMailQueueLocal}, nil
+}
+
+func newScheduledMailID() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("local-%d", time.Now().UnixNano())
+	}
+	return "local-" + hex.EncodeToString(b[:])
+}
+
+func formatScheduledMailResult(result *ScheduledMailResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	if result.Queue == scheduledMailQueueLocal {
+		return fmt.Sprintf("Queued mail to %s for %s (id=%s)\n"+
+			"The server does not support delayed delivery; it will be sent by the first bdh command run here after that time.\n",
+			result.ToAlias, formatClockTime(result.DeliverAt), result.ScheduledID)
+	}
+	return fmt.Sprintf("Scheduled mail to %s for %s (scheduled_id=%s)\n", result.ToAlias, formatClockTime(result.DeliverAt), result.ScheduledID)
+}
+
+func scheduledMailPath() (string, error) {
+	return cacheFilePath("scheduled-mail.json")
+}
+
+func loadScheduledMail(path string) ([]ScheduledMail, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var queue []ScheduledMail
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return queue, nil
+}
+
+func saveScheduledMail(path string, queue []ScheduledMail) error {
+	if len(queue) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONAtomic(path, queue)
+}
+
+// updateScheduledMail rewrites the queue with update while holding its
+// lock, so concurrent bdh processes never act on the same snapshot.
+func updateScheduledMail(path string, update func([]ScheduledMail) ([]ScheduledMail, error)) error {
+	if err := ensureCacheDir(path); err != nil {
+		return err
+	}
+	unlock, err := lockCacheFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	queue, err := loadScheduledMail(path)
+	if err != nil {
+		return err
+	}
+	queue, err = update(queue)
+	if err != nil {
+		return err
+	}
+	return saveScheduledMail(path, queue)
+}
+
+// deliverDueScheduledMail sends locally queued messages whose time has come.
+// Due messages are taken off the queue, under its lock, before sending so a
+// concurrent bdh command does not send them twice; transient failures are
+// put back. Messages the server rejects outright (4xx) are dropped and
+// reported.
+func deliverDueScheduledMail(ctx context.Context, aw AwebAPI, now time.Time) []ScheduledMailNotice {
+	path, err := scheduledMailPath()
+	if err != nil {
+		return nil
+	}
+	// Most commands find nothing queued; don't take the lock for that
+	if queue, err := loadScheduledMail(path); err != nil || len(queue) == 0 {
+		return nil
+	}
+
+	var due []ScheduledMail
+	err = updateScheduledMail(path, func(queue []ScheduledMail) ([]ScheduledMail, error) {
+		var pending []ScheduledMail
+		for _, m := range queue {
+			at, ok := parseTimeBestEffort(m.DeliverAt)
+			if (!ok || !at.After(now)) && len(due) < maxScheduledMailDeliveries {
+				due = append(due, m)
+			} else {
+				pending = append(pending, m)
+			}
+		}
+		return pending, nil
+	})
+	if err != nil || len(due) == 0 {
+		return nil
+	}
+
+	var notices []ScheduledMailNotice
+	var retry []ScheduledMail
+	for _, m := range due {
+		if ctx.Err() != nil {
+			retry = append(retry, m)
+			continue
+		}
+		_, err := aw.SendMessage(ctx, &aweb.SendMessageRequest{
+			ToAlias:  m.ToAlias,
+			Subject:  m.Subject,
+			Body:     m.Body,
+			Priority: aweb.MessagePriority(m.Priority),
+		})
+		if err == nil {
+			notices = append(notices, ScheduledMailNotice{ToAlias: m.ToAlias, Subject: m.Subject})
+			continue
+		}
+		if status, ok := aweb.HTTPStatusCode(err); ok && status >= 400 && status < 500 && status != 429 {
+			notices = append(notices, ScheduledMailNotice{ToAlias: m.ToAlias, Subject: m.Subject, Error: err.Error()})
+			continue
+		}
+		unreachable(ctx, err)
+		m.LastError = err.Error()
+		retry = append(retry, m)
+	}
+
+	if len(retry) > 0 {
+		_ = updateScheduledMail(path, func(queue []ScheduledMail) ([]ScheduledMail, error) {
+			return append(retry, queue...), nil
+		})
+	}
+	return notices
+}
+
+func formatScheduledMailNotice(n ScheduledMailNotice) string {
+	what := "Scheduled mail to " + n.ToAlias
+	if n.Subject != "" {
+		what += fmt.Sprintf(" (\"%s\")", n.Subject)
+	}
+	if n.Error == "" {
+		return "- **MAIL**: " + what + " was delivered"
+	}
+	return fmt.Sprintf("- **MAIL**: %s could not be delivered and was dropped: %s\n  → Resend: `bdh :aweb mail send %s \"...\"`",
+		what, truncateText(n.Error, 200), n.ToAlias)
+}
+
+func runMailScheduled(cmd *cobra.Command, args []string) error {
+	path, err := scheduledMailPath()
+	if err != nil {
+		return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+	}
+
+	if id := strings.TrimSpace(awebMailScheduledCancel); id != "" {
+		var removed *ScheduledMail
+		err := updateScheduledMail(path, func(queue []ScheduledMail) ([]ScheduledMail, error) {
+			kept := queue[:0]
+			for i := range queue {
+				if queue[i].ID == id && removed == nil {
+					m := queue[i]
+					removed = &m
+					continue
+				}
+				kept = append(kept, queue[i])
+			}
+			if removed == nil {
+				return nil, fmt.Errorf("no queued mail with id %s", id)
+			}
+			return kept, nil
+		})
+		if removed == nil && err != nil {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("saving scheduled mail: %w", err)
+		}
+		if awebMailJSON {
+			fmt.Print(marshalJSONOrFallback(removed))
+			return nil
+		}
+		fmt.Printf("Cancelled mail to %s (id=%s)\n", removed.ToAlias, removed.ID)
+		return nil
+	}
+
+	queue, err := loadScheduledMail(path)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatScheduledMailList(queue, awebMailJSON))
+	return nil
+}
+
+func formatScheduledMailList(queue []ScheduledMail, asJSON bool) string {
+	if asJSON {
+		if queue == nil {
+			queue = []ScheduledMail{}
+		}
+		return marshalJSONOrFallback(struct {
+			Scheduled []ScheduledMail `json:"scheduled"`
+		}{Scheduled: queue})
+	}
+	if len(queue) == 0 {
+		return "No mail queued locally.\n"
+	}
+	var sb strings.Builder
+	sb.WriteString("Mail queued locally:\n")
+	for _, m := range queue {
+		preview := m.Subject
+		if preview == "" {
+			preview = truncateText(m.Body, 60)
+		}
+		sb.WriteString(fmt.Sprintf("  %s  to %s at %s: %s\n", m.ID, m.ToAlias, formatClockTime(m.DeliverAt), preview))
+		if m.LastError != "" {
+			sb.WriteString(fmt.Sprintf("    last attempt failed: %s\n", truncateText(m.LastError, 120)))
+		}
+	}
+	return sb.String()
+}
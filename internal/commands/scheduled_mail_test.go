@@ -0,0 +1,228 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestResolveMailDeliverAt(t *testing.T) {
+	loc := time.FixedZone("test", 0)
+	now := time.Date(2025, 6, 1, 13, 0, 0, 0, loc)
+
+	tests := []struct {
+		sendAt, delay string
+		want          time.Time
+		wantOK        bool
+		wantErr       string
+	}{
+		{},
+		{sendAt: "17:00", want: time.Date(2025, 6, 1, 17, 0, 0, 0, loc), wantOK: true},
+		{sendAt: "09:00", want: time.Date(2025, 6, 2, 9, 0, 0, 0, loc), wantOK: true},
+		{delay: "30m", want: now.Add(30 * time.Minute), wantOK: true},
+		{sendAt: "17:00", delay: "30m", wantErr: "not both"},
+		{sendAt: "2h", wantErr: "use --delay for durations"},
+		{delay: "17:00", wantErr: "invalid --delay"},
+		{delay: "-5m", wantErr: "invalid --delay"},
+	}
+	for _, tt := range tests {
+		got, ok, err := resolveMailDeliverAt(tt.sendAt, tt.delay, now)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("(%q, %q) error = %v, want %q", tt.sendAt, tt.delay, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || ok != tt.wantOK || !got.Equal(tt.want) {
+			t.Errorf("(%q, %q) = %v, %v, %v", tt.sendAt, tt.delay, got, ok, err)
+		}
+	}
+}
+
+func TestScheduleMail_ServerAndLocalFallback(t *testing.T) {
+	t.Chdir(t.TempDir())
+	supported := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages/scheduled" || !supported {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req client.ScheduleMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(client.ScheduleMessageResponse{ScheduledID: "sched-1", DeliverAt: req.DeliverAt})
+	}))
+	defer server.Close()
+	c := client.New(server.URL)
+	now := time.Now()
+	req := &client.ScheduleMessageRequest{ToAlias: "alice", Body: "end of day", DeliverAt: now.Add(time.Hour).UTC().Format(time.RFC3339)}
+
+	result, err := scheduleMail(context.Background(), c, req, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Queue != scheduledMailQueueServer || result.ScheduledID != "sched-1" {
+		t.Errorf("server result = %+v", result)
+	}
+
+	supported = false
+	result, err = scheduleMail(context.Background(), c, req, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Queue != scheduledMailQueueLocal || !strings.HasPrefix(result.ScheduledID, "local-") {
+		t.Errorf("local result = %+v", result)
+	}
+	path, _ := scheduledMailPath()
+	queue, err := loadScheduledMail(path)
+	if err != nil || len(queue) != 1 || queue[0].ToAlias != "alice" || queue[0].Body != "end of day" {
+		t.Fatalf("queue = %+v, err = %v", queue, err)
+	}
+	if !strings.Contains(formatScheduledMailResult(result, false), "first bdh command run here") {
+		t.Errorf("output = %s", formatScheduledMailResult(result, false))
+	}
+}
+
+func TestDeliverDueScheduledMail(t *testing.T) {
+	t.Chdir(t.TempDir())
+	now := time.Now()
+	past := now.Add(-time.Minute).UTC().Format(time.RFC3339)
+	path, _ := scheduledMailPath()
+	if err := saveScheduledMail(path, []ScheduledMail{
+		{ID: "local-1", ToAlias: "alice", Body: "due", DeliverAt: past},
+		{ID: "local-2", ToAlias: "bob", Body: "later", DeliverAt: now.Add(time.Hour).UTC().Format(time.RFC3339)},
+		{ID: "local-3", ToAlias: "ghost", Subject: "hi", Body: "due", DeliverAt: past},
+		{ID: "local-4", ToAlias: "carol", Body: "due", DeliverAt: past},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req aweb.SendMessageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		switch req.ToAlias {
+		case "ghost":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail":"unknown alias"}`))
+		case "carol":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			sent = append(sent, req.ToAlias)
+			json.NewEncoder(w).Encode(aweb.SendMessageResponse{MessageID: "m1"})
+		}
+	}))
+	defer server.Close()
+	aw, err := aweb.NewWithAPIKey(server.URL, "aw_sk_test123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notices := deliverDueScheduledMail(context.Background(), aw, now)
+	if strings.Join(sent, ",") != "alice" {
+		t.Errorf("sent = %v", sent)
+	}
+	if len(notices) != 2 || notices[0].Error != "" || notices[1].ToAlias != "ghost" || notices[1].Error == "" {
+		t.Fatalf("notices = %+v", notices)
+	}
+	if !strings.Contains(formatScheduledMailNotice(notices[1]), "could not be delivered and was dropped") {
+		t.Errorf("failure notice = %s", formatScheduledMailNotice(notices[1]))
+	}
+
+	queue, _ := loadScheduledMail(path)
+	var ids []string
+	for _, m := range queue {
+		ids = append(ids, m.ID)
+	}
+	if strings.Join(ids, ",") != "local-4,local-2" || queue[0].LastError == "" {
+		t.Errorf("remaining queue = %+v", queue)
+	}
+}
+
+// countingSender counts messages sent per recipient.
+type countingSender struct {
+	AwebAPI
+	mu   sync.Mutex
+	sent map[string]int
+}
+
+func (s *countingSender) SendMessage(ctx context.Context, req *aweb.SendMessageRequest) (*aweb.SendMessageResponse, error) {
+	time.Sleep(time.Millisecond)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[req.ToAlias]++
+	return &aweb.SendMessageResponse{MessageID: "m-" + req.ToAlias}, nil
+}
+
+func TestDeliverDueScheduledMail_Concurrent(t *testing.T) {
+	t.Chdir(t.TempDir())
+	now := time.Now()
+	past := now.Add(-time.Minute).UTC().Format(time.RFC3339)
+	path, _ := scheduledMailPath()
+	if err := saveScheduledMail(path, []ScheduledMail{{ID: "local-1", ToAlias: "alice", Body: "due", DeliverAt: past}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another bdh process holds the queue while it takes the message off to
+	// deliver it itself.
+	unlock, err := lockCacheFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aw := &countingSender{sent: make(map[string]int)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		deliverDueScheduledMail(context.Background(), aw, now)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if err := saveScheduledMail(path, nil); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	<-done
+	if len(aw.sent) != 0 {
+		t.Errorf("a message taken by another process was sent again: %v", aw.sent)
+	}
+
+	// Racing deliveries send each message once.
+	var queue []ScheduledMail
+	for i := 0; i < maxScheduledMailDeliveries; i++ {
+		queue = append(queue, ScheduledMail{ID: fmt.Sprintf("local-%d", i), ToAlias: fmt.Sprintf("agent-%d", i), Body: "due", DeliverAt: past})
+	}
+	if err := saveScheduledMail(path, queue); err != nil {
+		t.Fatal(err)
+	}
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			deliverDueScheduledMail(context.Background(), aw, now)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if len(aw.sent) != len(queue) {
+		t.Errorf("expected every message sent, got %v", aw.sent)
+	}
+	for alias, n := range aw.sent {
+		if n != 1 {
+			t.Errorf("%s got %d copies", alias, n)
+		}
+	}
+	if remaining, _ := loadScheduledMail(path); len(remaining) != 0 {
+		t.Errorf("queue should be empty, got %+v", remaining)
+	}
+}
@@ -0,0 +1,294 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+const defaultSearchLimit = 20
+
+var (
+	searchJSON   bool
+	searchServer bool
+	searchLimit  int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   ":search <query>",
+	Short: "Search issues by title/description, annotated with claims",
+	Long: `Search issues in the local .beads/issues.jsonl by title and description.
+
+Results are ranked (title matches rank above description matches) and
+annotated with who currently has each bead claimed across the team.
+
+When nothing matches locally, or with --server, BeadHub is searched for
+results from every repo in the project.
+
+Examples:
+  bdh :search "auth token"
+  bdh :search rebase --server
+  bdh :search "flaky test" --limit 5 --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "Output as JSON")
+	searchCmd.Flags().BoolVar(&searchServer, "server", false, "Always include project-wide results from BeadHub")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", defaultSearchLimit, "Maximum results to show")
+}
+
+// SearchHit is a single search result.
+type SearchHit struct {
+	BeadID    string   `json:"bead_id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status,omitempty"`
+	Repo      string   `json:"repo,omitempty"`
+	Score     float64  `json:"score"`
+	Source    string   `json:"source"` // "local" or "server"
+	ClaimedBy []string `json:"claimed_by,omitempty"`
+}
+
+// SearchResult contains the result of a :search invocation.
+type SearchResult struct {
+	Query         string      `json:"query"`
+	Hits          []SearchHit `json:"results"`
+	UsedServer    bool        `json:"used_server"`
+	ServerWarning string      `json:"server_warning,omitempty"`
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	query := strings.TrimSpace(strings.Join(args, " "))
+
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := searchWithConfig(ctx, cfg, query, searchLimit, searchServer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(formatSearchOutput(result, searchJSON))
+	return nil
+}
+
+// searchWithConfig runs a local search with optional server fallback (for testing).
+func searchWithConfig(ctx context.Context, cfg *config.Config, query string, limit int, forceServer bool) (*SearchResult, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	result := &SearchResult{Query: query}
+
+	issues, err := loadIssues()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading issues: %w", err)
+	}
+	result.Hits = searchIssuesLocal(issues, query)
+
+	c := newBeadHubClient(ctx, cfg.BeadhubURL)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	if forceServer || len(result.Hits) == 0 {
+		result.UsedServer = true
+		resp, err := c.SearchBeads(ctx, &client.SearchBeadsRequest{Query: query, Limit: limit})
+		if err != nil {
+			var clientErr *client.Error
+			if errors.As(err, &clientErr) {
+				result.ServerWarning = fmt.Sprintf("server search unavailable (%d)", clientErr.StatusCode)
+			} else if client.IsPayloadError(err) {
+				result.ServerWarning = err.Error() + " - showing local results only"
+			} else {
+				result.ServerWarning = fmt.Sprintf("BeadHub unreachable at %s - showing local results only", cfg.BeadhubURL)
+			}
+		} else {
+			result.Hits = mergeServerSearchHits(result.Hits, resp.Results, cfg.CanonicalOrigin)
+		}
+	}
+
+	sort.SliceStable(result.Hits, func(i, j int) bool {
+		if result.Hits[i].Score != result.Hits[j].Score {
+			return result.Hits[i].Score > result.Hits[j].Score
+		}
+		return result.Hits[i].BeadID < result.Hits[j].BeadID
+	})
+	if len(result.Hits) > limit {
+		result.Hits = result.Hits[:limit]
+	}
+
+	annotateSearchClaims(ctx, c, result.Hits, cfg.CanonicalOrigin)
+	return result, nil
+}
+
+// searchIssuesLocal ranks issues matching every query term.
+// Scoring: exact ID match 100, full phrase in title 10, each term in title 3,
+// each term in description 1.
+func searchIssuesLocal(issues []Issue, query string) []SearchHit {
+	phrase := strings.ToLower(strings.TrimSpace(query))
+	terms := strings.Fields(phrase)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var hits []SearchHit
+	for _, issue := range issues {
+		title := strings.ToLower(issue.Title)
+		desc := strings.ToLower(issue.Description)
+
+		score := 0.0
+		if strings.ToLower(issue.ID) == phrase {
+			score += 100
+		}
+		if strings.Contains(title, phrase) {
+			score += 10
+		}
+		matchedAll := true
+		for _, term := range terms {
+			inTitle := strings.Contains(title, term)
+			inDesc := strings.Contains(desc, term)
+			if inTitle {
+				score += 3
+			}
+			if inDesc {
+				score++
+			}
+			if !inTitle && !inDesc && strings.ToLower(issue.ID) != term {
+				matchedAll = false
+			}
+		}
+		if !matchedAll || score == 0 {
+			continue
+		}
+		hits = append(hits, SearchHit{
+			BeadID: issue.ID,
+			Title:  issue.Title,
+			Status: issue.Status,
+			Score:  score,
+			Source: "local",
+		})
+	}
+	return hits
+}
+
+// mergeServerSearchHits appends server results not already present locally.
+// Results from this repo are already covered by the local search.
+func mergeServerSearchHits(local []SearchHit, remote []client.SearchBeadResult, myRepo string) []SearchHit {
+	seen := make(map[string]bool, len(local))
+	for _, h := range local {
+		seen[h.BeadID] = true
+	}
+	for _, r := range remote {
+		if seen[r.BeadID] && (r.Repo == "" || r.Repo == myRepo) {
+			continue
+		}
+		hit := SearchHit{
+			BeadID: r.BeadID,
+			Title:  r.Title,
+			Status: r.Status,
+			Repo:   r.Repo,
+			Score:  r.Score,
+			Source: "server",
+		}
+		for _, claimant := range r.Claimants {
+			hit.ClaimedBy = append(hit.ClaimedBy, claimant.Alias)
+		}
+		local = append(local, hit)
+	}
+	return local
+}
+
+// annotateSearchClaims fills ClaimedBy from the team's active claims
+// (best-effort). Bead IDs are only unique within a repo, so claims are
+// looked up per repo; hits without one are from myRepo.
+func annotateSearchClaims(ctx context.Context, c BeadHubAPI, hits []SearchHit, myRepo string) {
+	hitRepo := func(hit SearchHit) string {
+		if hit.Repo == "" {
+			return myRepo
+		}
+		return hit.Repo
+	}
+	var repos []string
+	for _, hit := range hits {
+		if len(hit.ClaimedBy) == 0 && !containsString(repos, hitRepo(hit)) {
+			repos = append(repos, hitRepo(hit))
+		}
+	}
+
+	includeClaims := true
+	onlyWithClaims := true
+	claimants := make(map[string][]string) // repo + "\x00" + bead ID -> aliases
+	for _, repo := range repos {
+		resp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+			Repo:           repo,
+			IncludeClaims:  &includeClaims,
+			OnlyWithClaims: &onlyWithClaims,
+			Limit:          maxWorkspaceQueryLimit,
+		})
+		if err != nil {
+			return
+		}
+		for _, ws := range resp.Workspaces {
+			for _, claim := range ws.Claims {
+				key := repo + "\x00" + claim.BeadID
+				claimants[key] = append(claimants[key], ws.Alias)
+			}
+		}
+	}
+	for i := range hits {
+		if len(hits[i].ClaimedBy) > 0 {
+			continue
+		}
+		hits[i].ClaimedBy = claimants[hitRepo(hits[i])+"\x00"+hits[i].BeadID]
+	}
+}
+
+// formatSearchOutput formats search results for display.
+func formatSearchOutput(result *SearchResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.ServerWarning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n\n", result.ServerWarning))
+	}
+	if len(result.Hits) == 0 {
+		sb.WriteString(fmt.Sprintf("No issues match %q.\n", result.Query))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("RESULTS for %q:\n", result.Query))
+	for _, hit := range result.Hits {
+		sb.WriteString(fmt.Sprintf("  %s", hit.BeadID))
+		if hit.Status != "" {
+			sb.WriteString(fmt.Sprintf(" [%s]", hit.Status))
+		}
+		if hit.Title != "" {
+			sb.WriteString(fmt.Sprintf(" \"%s\"", hit.Title))
+		}
+		if hit.Source == "server" && hit.Repo != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", hit.Repo))
+		}
+		if len(hit.ClaimedBy) > 0 {
+			sb.WriteString(fmt.Sprintf(" — claimed by %s", strings.Join(hit.ClaimedBy, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestSearchIssuesLocal_RanksTitleAboveDescription(t *testing.T) {
+	issues := []Issue{
+		{ID: "bd-1", Title: "Refactor logging", Description: "touches the auth token refresh path"},
+		{ID: "bd-2", Title: "Auth token expires early", Description: "users get logged out"},
+		{ID: "bd-3", Title: "Unrelated", Description: "nothing to see"},
+		{ID: "bd-4", Title: "Auth cleanup", Description: "no match for the second term"},
+	}
+
+	hits := searchIssuesLocal(issues, "auth token")
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 hits, got %d: %+v", len(hits), hits)
+	}
+	scores := map[string]float64{}
+	for _, h := range hits {
+		scores[h.BeadID] = h.Score
+	}
+	if scores["bd-2"] <= scores["bd-1"] {
+		t.Errorf("expected title match to outrank description match: %v", scores)
+	}
+}
+
+func TestSearchIssuesLocal_MatchesID(t *testing.T) {
+	hits := searchIssuesLocal([]Issue{{ID: "bd-42", Title: "Something"}}, "BD-42")
+	if len(hits) != 1 || hits[0].Score < 100 {
+		t.Errorf("expected exact ID hit, got %+v", hits)
+	}
+}
+
+func TestSearch_FallsBackToServerAndAnnotatesClaims(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"Unrelated","status":"open"}
+`)
+
+	var searchQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/beads/search":
+			searchQuery = r.URL.Query().Get("q")
+			json.NewEncoder(w).Encode(map[string]any{
+				"results": []map[string]any{
+					{"bead_id": "api-7", "title": "Rotate auth token", "status": "open", "repo": "github.com/test/api", "score": 4},
+				},
+			})
+		case "/v1/workspaces/team":
+			if repo := r.URL.Query().Get("repo"); repo != "github.com/test/api" {
+				t.Errorf("claims must be looked up in the hit's repo, got repo=%q", repo)
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"workspaces": []map[string]any{
+					{"workspace_id": "ws-2", "alias": "bob", "claims": []map[string]any{{"bead_id": "api-7"}}},
+				},
+			})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BeadhubURL: server.URL, CanonicalOrigin: "github.com/test/repo"}
+	result, err := searchWithConfig(context.Background(), cfg, "auth token", 10, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if searchQuery != "auth token" {
+		t.Errorf("expected server search for %q, got %q", "auth token", searchQuery)
+	}
+	if !result.UsedServer || len(result.Hits) != 1 {
+		t.Fatalf("expected one server hit, got %+v", result)
+	}
+	if got := result.Hits[0].ClaimedBy; len(got) != 1 || got[0] != "bob" {
+		t.Errorf("expected claim annotation bob, got %v", got)
+	}
+
+	output := formatSearchOutput(result, false)
+	if !strings.Contains(output, "api-7 [open] \"Rotate auth token\" (github.com/test/api) — claimed by bob") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+}
+
+func TestSearch_LocalHitsSkipServer(t *testing.T) {
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"Auth token bug","status":"open"}
+`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/beads/search" {
+			t.Error("server search should not run when local results exist")
+		}
+		json.NewEncoder(w).Encode(map[string]any{"workspaces": []any{}})
+	}))
+	defer server.Close()
+
+	result, err := searchWithConfig(context.Background(), &config.Config{BeadhubURL: server.URL}, "auth", 10, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.UsedServer || len(result.Hits) != 1 {
+		t.Errorf("expected single local hit, got %+v", result)
+	}
+}
@@ -0,0 +1,390 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :seed fills a fresh workspace with a small but realistic backlog (two epics
+// with tasks, bugs and dependencies) so demos, screenshots and manual tests
+// of coordination features don't need a real project. On a local dev server
+// it can also register fake teammates that each claim a ready task.
+
+// seedTask is a sample bead under a seedEpic. DependsOn names other tasks of
+// the same epic by Key.
+type seedTask struct {
+	Key         string
+	Title       string
+	Type        string
+	Priority    int
+	Description string
+	DependsOn   []string
+}
+
+type seedEpic struct {
+	Title       string
+	Priority    int
+	Description string
+	Tasks       []seedTask
+}
+
+var seedBacklog = []seedEpic{
+	{
+		Title:       "User accounts",
+		Priority:    1,
+		Description: "Let people sign up, log in and recover their accounts.",
+		Tasks: []seedTask{
+			{Key: "schema", Title: "Design the accounts schema", Type: "task", Priority: 1,
+				Description: "Users, sessions and password reset tokens, with migrations."},
+			{Key: "signup", Title: "Add the sign-up endpoint", Type: "feature", Priority: 1,
+				Description: "POST /accounts with email verification.", DependsOn: []string{"schema"}},
+			{Key: "login", Title: "Add login with session cookies", Type: "feature", Priority: 1,
+				Description: "POST /sessions; HttpOnly, SameSite=Lax cookies.", DependsOn: []string{"schema"}},
+			{Key: "docs", Title: "Document the accounts API", Type: "task", Priority: 2,
+				DependsOn: []string{"signup", "login"}},
+			{Key: "reset", Title: "Password reset emails mangle non-ASCII names", Type: "bug", Priority: 2,
+				Description: "Names like 'José' arrive as 'JosÃ©' in the reset email greeting."},
+		},
+	},
+	{
+		Title:       "Search",
+		Priority:    2,
+		Description: "Full-text search over projects and comments.",
+		Tasks: []seedTask{
+			{Key: "index", Title: "Index projects in the search backend", Type: "task", Priority: 2},
+			{Key: "api", Title: "Add the search API", Type: "feature", Priority: 2,
+				Description: "GET /search?q= with pagination.", DependsOn: []string{"index"}},
+			{Key: "page", Title: "Build the search results page", Type: "feature", Priority: 2,
+				DependsOn: []string{"api"}},
+			{Key: "bench", Title: "Benchmark search latency", Type: "chore", Priority: 3,
+				DependsOn: []string{"api"}},
+		},
+	},
+}
+
+// seedTeammate is a fake agent registered by --teammates.
+type seedTeammate struct {
+	Alias     string
+	HumanName string
+	Role      string
+}
+
+var seedTeammates = []seedTeammate{
+	{Alias: "seed-ada", HumanName: "Ada (demo)", Role: "backend"},
+	{Alias: "seed-grace", HumanName: "Grace (demo)", Role: "frontend"},
+	{Alias: "seed-linus", HumanName: "Linus (demo)", Role: "reviewer"},
+}
+
+// SeedIssue is a bead created by :seed.
+type SeedIssue struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Type      string   `json:"type"`
+	Parent    string   `json:"parent,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
+// SeedTeammateResult is a fake teammate registered by :seed.
+type SeedTeammateResult struct {
+	Alias       string `json:"alias"`
+	HumanName   string `json:"human_name"`
+	Role        string `json:"role"`
+	WorkspaceID string `json:"workspace_id"`
+	Claimed     string `json:"claimed,omitempty"`
+}
+
+// SeedResult is the output of :seed.
+type SeedResult struct {
+	Issues    []SeedIssue          `json:"issues"`
+	Teammates []SeedTeammateResult `json:"teammates,omitempty"`
+	Synced    bool                 `json:"synced"`
+	Warnings  []string             `json:"warnings,omitempty"`
+}
+
+var (
+	seedForce       bool
+	seedTeammateN   int
+	seedAllowRemote bool
+	seedJSON        bool
+)
+
+var seedCmd = &cobra.Command{
+	Use:   ":seed",
+	Short: "Create a demo project with sample beads",
+	Long: `Populate a fresh workspace with a sample backlog: two epics with tasks,
+features, bugs and dependencies between them. Use it for demos, screenshots
+and trying out coordination features without a real backlog.
+
+The beads are created with bd, so run 'bdh init' first. When the workspace is
+registered with BeadHub (bdh :init) they are synced right away.
+
+--teammates registers up to 3 fake agents (seed-ada, seed-grace, seed-linus)
+in this project, each claiming a ready task, so ready, :status and claim
+conflicts have someone to show. Fake teammates are only created on a local
+server unless --allow-remote is given.
+
+Examples:
+  bdh :seed
+  bdh :seed --teammates 2
+  bdh :seed --force        # Add the sample to a workspace that has beads`,
+	Args: cobra.NoArgs,
+	RunE: runSeed,
+}
+
+func init() {
+	seedCmd.Flags().BoolVar(&seedForce, "force", false, "Seed even if the workspace already has beads")
+	seedCmd.Flags().IntVar(&seedTeammateN, "teammates", 0, fmt.Sprintf("Register this many fake teammates (max %d)", len(seedTeammates)))
+	seedCmd.Flags().BoolVar(&seedAllowRemote, "allow-remote", false, "Allow fake teammates on a non-local server")
+	seedCmd.Flags().BoolVar(&seedJSON, "json", false, "Output as JSON")
+}
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if seedTeammateN < 0 || seedTeammateN > len(seedTeammates) {
+		return fmt.Errorf("--teammates must be between 0 and %d", len(seedTeammates))
+	}
+	cfg, err := config.Load()
+	switch {
+	case os.IsNotExist(err):
+		cfg = nil
+	case err != nil:
+		return fmt.Errorf("loading config: %w", err)
+	default:
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid .beadhub config: %w", err)
+		}
+	}
+	if seedTeammateN > 0 {
+		if cfg == nil {
+			return fmt.Errorf("--teammates requires a configured workspace - run 'bdh :init' first")
+		}
+		if !seedAllowRemote && !isLocalServer(cfg.BeadhubURL) {
+			return fmt.Errorf("fake teammates are only created on a local dev server (%s is not local); use --allow-remote to override", cfg.BeadhubURL)
+		}
+	}
+
+	result, err := seedIssues(ctx, newBdRunner(cfg, nil), seedForce)
+	if err != nil {
+		return err
+	}
+	if cfg != nil {
+		syncResult := syncToBeadHub(ctx, cfg, []string{"create"}, syncOptions{})
+		result.Synced = syncResult.Synced
+		if syncResult.Warning != "" {
+			result.Warnings = append(result.Warnings, syncResult.Warning)
+		}
+	}
+	if seedTeammateN > 0 {
+		seedFakeTeammates(ctx, cfg, beadHubClientFor(ctx, cfg.BeadhubURL, ""), result, seedTeammateN)
+	}
+	fmt.Print(formatSeedOutput(result, seedJSON))
+	return nil
+}
+
+// seedIssues creates seedBacklog with bd. Dependencies that bd cannot add
+// (the bd emulator has no dep command) are reported as warnings.
+func seedIssues(ctx context.Context, runner *bd.Runner, force bool) (*SeedResult, error) {
+	res, err := runSeedBd(ctx, runner, []string{"list", "--all", "--json"})
+	if err != nil {
+		return nil, err
+	}
+	if res.ExitCode != 0 {
+		return nil, fmt.Errorf("bd list failed - run 'bdh init' to create a beads database first: %s", strings.TrimSpace(res.Stderr))
+	}
+	var existing []json.RawMessage
+	if err := json.Unmarshal([]byte(strings.TrimSpace(res.Stdout)), &existing); err == nil && len(existing) > 0 && !force {
+		return nil, fmt.Errorf("this workspace already has %d bead(s) - :seed is for fresh workspaces (use --force to add the sample anyway)", len(existing))
+	}
+
+	result := &SeedResult{}
+	for _, epic := range seedBacklog {
+		epicID, err := createSeedIssue(ctx, runner, epic.Title, "epic", epic.Priority, epic.Description, "")
+		if err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, SeedIssue{ID: epicID, Title: epic.Title, Type: "epic"})
+
+		ids := make(map[string]string, len(epic.Tasks))
+		for _, task := range epic.Tasks {
+			id, err := createSeedIssue(ctx, runner, task.Title, task.Type, task.Priority, task.Description, epicID)
+			if err != nil {
+				return nil, err
+			}
+			ids[task.Key] = id
+			result.Issues = append(result.Issues, SeedIssue{ID: id, Title: task.Title, Type: task.Type, Parent: epicID})
+		}
+		for _, task := range epic.Tasks {
+			for _, dep := range task.DependsOn {
+				id, depID := ids[task.Key], ids[dep]
+				res, err := runSeedBd(ctx, runner, []string{"dep", "add", id, depID})
+				if err != nil || res.ExitCode != 0 {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("could not make %s depend on %s: %s", id, depID, bdFailure(res, err)))
+					continue
+				}
+				for i := range result.Issues {
+					if result.Issues[i].ID == id {
+						result.Issues[i].DependsOn = append(result.Issues[i].DependsOn, depID)
+					}
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func createSeedIssue(ctx context.Context, runner *bd.Runner, title, issueType string, priority int, description, parent string) (string, error) {
+	args := []string{"create", title, "--type", issueType, "--priority", strconv.Itoa(priority)}
+	if description != "" {
+		args = append(args, "--description", description)
+	}
+	if parent != "" {
+		args = append(args, "--parent", parent)
+	}
+	res, err := runSeedBd(ctx, runner, append(args, "--json"))
+	if err != nil || res.ExitCode != 0 {
+		return "", fmt.Errorf("creating %q: %s", title, bdFailure(res, err))
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(res.Stdout)), &created); err != nil || created.ID == "" {
+		return "", fmt.Errorf("creating %q: unexpected bd output: %s", title, strings.TrimSpace(res.Stdout))
+	}
+	return created.ID, nil
+}
+
+func runSeedBd(ctx context.Context, runner *bd.Runner, args []string) (*bd.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	res, err := runner.Run(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("running bd: %w", err)
+	}
+	return res, nil
+}
+
+// bdFailure describes a failed bd run in one line.
+func bdFailure(res *bd.Result, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	if msg := strings.TrimSpace(res.Stderr); msg != "" {
+		return msg
+	}
+	return fmt.Sprintf("bd exited with code %d", res.ExitCode)
+}
+
+// seedFakeTeammates registers n fake teammates in cfg's project and has each
+// claim a different ready task (best-effort: failures become warnings).
+func seedFakeTeammates(ctx context.Context, cfg *config.Config, c BeadHubAPI, result *SeedResult, n int) {
+	var ready []SeedIssue
+	for _, issue := range result.Issues {
+		if issue.Type != "epic" && len(issue.DependsOn) == 0 {
+			ready = append(ready, issue)
+		}
+	}
+	for i, mate := range seedTeammates[:n] {
+		alias := mate.Alias
+		initCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.Init(initCtx, &client.InitRequest{
+			RepoOrigin:    cfg.RepoOrigin,
+			Alias:         &alias,
+			HumanName:     mate.HumanName,
+			Role:          mate.Role,
+			ProjectSlug:   cfg.ProjectSlug,
+			Hostname:      "bdh-seed",
+			WorkspacePath: "seed/" + mate.Alias,
+		})
+		cancel()
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("could not register %s: %v", mate.Alias, err))
+			continue
+		}
+		teammate := SeedTeammateResult{Alias: resp.Alias, HumanName: mate.HumanName, Role: mate.Role, WorkspaceID: resp.WorkspaceID}
+		if i < len(ready) {
+			cmdCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+			_, err := beadHubClientFor(ctx, cfg.BeadhubURL, resp.APIKey).Command(cmdCtx, &client.CommandRequest{
+				WorkspaceID: resp.WorkspaceID,
+				RepoID:      resp.RepoID,
+				Alias:       resp.Alias,
+				HumanName:   mate.HumanName,
+				RepoOrigin:  cfg.RepoOrigin,
+				Role:        mate.Role,
+				CommandLine: "update " + ready[i].ID + " --status in_progress",
+			})
+			cancel()
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s could not claim %s: %v", resp.Alias, ready[i].ID, err))
+			} else {
+				teammate.Claimed = ready[i].ID
+			}
+		}
+		result.Teammates = append(result.Teammates, teammate)
+	}
+}
+
+// isLocalServer reports whether rawURL points at this machine.
+func isLocalServer(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func formatSeedOutput(result *SeedResult, asJSON bool) string {
+	if asJSON {
+		if result.Issues == nil {
+			result.Issues = []SeedIssue{}
+		}
+		return marshalJSONOrFallback(result)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Created %d sample bead(s):\n", len(result.Issues)))
+	for _, issue := range result.Issues {
+		indent := ""
+		if issue.Parent != "" {
+			indent = "  "
+		}
+		line := fmt.Sprintf("  %s%s [%s] %s", indent, issue.ID, issue.Type, issue.Title)
+		if len(issue.DependsOn) > 0 {
+			line += " (after " + strings.Join(issue.DependsOn, ", ") + ")"
+		}
+		sb.WriteString(line + "\n")
+	}
+	if len(result.Teammates) > 0 {
+		sb.WriteString("\nFake teammates:\n")
+		for _, mate := range result.Teammates {
+			line := fmt.Sprintf("  %s (%s, %s)", mate.Alias, mate.HumanName, mate.Role)
+			if mate.Claimed != "" {
+				line += " working on " + mate.Claimed
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	if result.Synced {
+		sb.WriteString("\nSynced to BeadHub.\n")
+	}
+	for _, w := range result.Warnings {
+		sb.WriteString("Warning: " + w + "\n")
+	}
+	sb.WriteString("\nNext: bdh ready\n")
+	return sb.String()
+}
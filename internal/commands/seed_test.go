@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestSeedIssues_WithBdEmulator(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	setupBeadsWorkspace(t, "")
+
+	result, err := seedIssues(context.Background(), bd.New(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := 0
+	for _, epic := range seedBacklog {
+		tasks += 1 + len(epic.Tasks)
+	}
+	if len(result.Issues) != tasks {
+		t.Fatalf("created %d issues, want %d", len(result.Issues), tasks)
+	}
+	if first := result.Issues[0]; first.Type != "epic" || first.Parent != "" {
+		t.Errorf("first issue = %+v, want the first epic", first)
+	}
+	if second := result.Issues[1]; second.Parent != result.Issues[0].ID {
+		t.Errorf("task parent = %q, want %q", second.Parent, result.Issues[0].ID)
+	}
+	// The emulator has no dep command: dependencies become warnings
+	if len(result.Warnings) == 0 || !strings.Contains(result.Warnings[0], "could not make") {
+		t.Errorf("warnings = %v", result.Warnings)
+	}
+
+	if _, err := seedIssues(context.Background(), bd.New(), false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("seeding a non-empty workspace: err = %v", err)
+	}
+	if _, err := seedIssues(context.Background(), bd.New(), true); err != nil {
+		t.Fatalf("--force: %v", err)
+	}
+}
+
+func TestSeedFakeTeammates_ClaimReadyTasks(t *testing.T) {
+	var mu sync.Mutex
+	claims := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/v1/init":
+			alias := body["alias"].(string)
+			if alias == "seed-grace" {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"detail":"alias_exists"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"api_key": "aw_sk_" + strings.Repeat("x", 32), "alias": alias, "workspace_id": "ws-" + alias,
+			})
+		case "/v1/bdh/command":
+			mu.Lock()
+			claims[body["alias"].(string)] = body["command_line"].(string)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]any{"approved": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BeadhubURL: server.URL, RepoOrigin: "git@github.com:acme/demo.git", ProjectSlug: "demo"}
+	result := &SeedResult{Issues: []SeedIssue{
+		{ID: "bd-1", Type: "epic"},
+		{ID: "bd-2", Type: "task", Parent: "bd-1"},
+		{ID: "bd-3", Type: "feature", Parent: "bd-1", DependsOn: []string{"bd-2"}},
+		{ID: "bd-4", Type: "bug", Parent: "bd-1"},
+		{ID: "bd-5", Type: "task", Parent: "bd-1"},
+	}}
+	seedFakeTeammates(context.Background(), cfg, newBeadHubClient(context.Background(), server.URL), result, 3)
+
+	if len(result.Teammates) != 2 || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "seed-grace") {
+		t.Fatalf("teammates = %+v, warnings = %v", result.Teammates, result.Warnings)
+	}
+	if claims["seed-ada"] != "update bd-2 --status in_progress" || claims["seed-linus"] != "update bd-5 --status in_progress" {
+		t.Errorf("claims = %v (blocked bd-3 and the epic must not be claimed)", claims)
+	}
+	if result.Teammates[1].Claimed != "bd-5" {
+		t.Errorf("linus claimed %q", result.Teammates[1].Claimed)
+	}
+}
+
+func TestIsLocalServer(t *testing.T) {
+	for url, want := range map[string]bool{
+		"http://localhost:8000":      true,
+		"http://127.0.0.1:8000":      true,
+		"http://[::1]:8000":          true,
+		"https://beadhub.example.io": false,
+		"http://10.0.0.5:8000":       false,
+	} {
+		if got := isLocalServer(url); got != want {
+			t.Errorf("isLocalServer(%q) = %v, want %v", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Capability discovery hides features a server does not advertise, but a
+// server without discovery is assumed to support everything, and a mixed
+// deployment can advertise a feature from a build too old to serve it the
+// way bdh expects. When the server's version is known (capabilities, the
+// X-BeadHub-Version header or GET /v1/version), bdh compares it with the
+// minimum each feature needs and warns once per run when a feature in use
+// is not covered. :doctor lists every unmet minimum.
+
+// requirementIncrementalSync is not a capability: every server accepts
+// incremental syncs, but old ones apply them incorrectly.
+const requirementIncrementalSync = "incremental_sync"
+
+// serverRequirement is the oldest server release that serves a feature.
+type serverRequirement struct {
+	Feature    string
+	What       string
+	MinVersion string
+}
+
+var serverRequirements = []serverRequirement{
+	{Feature: requirementIncrementalSync, What: "incremental sync", MinVersion: "0.5.0"},
+	{Feature: featureTeamQuery, What: "the team query (ready, :status)", MinVersion: "0.8.0"},
+	{Feature: featureChatSessions, What: "chat sessions (chat v2.5)", MinVersion: "0.10.0"},
+}
+
+var (
+	serverVersionWarnMu  sync.Mutex
+	serverVersionWarned            = map[string]bool{}
+	serverVersionWarnOut io.Writer = os.Stderr
+)
+
+// serverVersionProblem describes why version is too old for feature, or
+// returns "" when it is new enough or either version is unknown.
+func serverVersionProblem(version, feature string) string {
+	for _, req := range serverRequirements {
+		if req.Feature != feature {
+			continue
+		}
+		if cmp, ok := compareVersions(version, req.MinVersion); ok && cmp < 0 {
+			return fmt.Sprintf("BeadHub server %s is older than %s, which %s needs", version, req.MinVersion, req.What)
+		}
+	}
+	return ""
+}
+
+// serverVersionProblems lists every requirement version does not meet.
+func serverVersionProblems(version string) []string {
+	var problems []string
+	for _, req := range serverRequirements {
+		if p := serverVersionProblem(version, req.Feature); p != "" {
+			problems = append(problems, p)
+		}
+	}
+	return problems
+}
+
+// warnServerVersion prints a warning, once per run, when cfg's server is
+// known to be too old for feature.
+func warnServerVersion(ctx context.Context, cfg *config.Config, feature string) {
+	caps := serverCapabilities(ctx, cfg)
+	if caps == nil || caps.ServerVersion == "" {
+		return
+	}
+	checkServerVersion(caps.ServerVersion, feature)
+}
+
+// checkServerVersion is warnServerVersion for a known version.
+func checkServerVersion(version, feature string) {
+	problem := serverVersionProblem(version, feature)
+	if problem == "" {
+		return
+	}
+	serverVersionWarnMu.Lock()
+	defer serverVersionWarnMu.Unlock()
+	if serverVersionWarned[feature] {
+		return
+	}
+	serverVersionWarned[feature] = true
+	fmt.Fprintf(serverVersionWarnOut, "Warning: %s - upgrade the server or expect errors\n", problem)
+}
+
+// resetServerVersionWarnings forgets the warnings already printed.
+func resetServerVersionWarnings() {
+	serverVersionWarnMu.Lock()
+	defer serverVersionWarnMu.Unlock()
+	serverVersionWarned = map[string]bool{}
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+func TestServerVersionProblems(t *testing.T) {
+	if got := serverVersionProblem("0.9.0", featureTeamQuery); got != "" {
+		t.Errorf("0.9.0 should serve the team query, got %q", got)
+	}
+	if got := serverVersionProblem("0.7.3", featureTeamQuery); !strings.Contains(got, "older than 0.8.0") {
+		t.Errorf("problem = %q", got)
+	}
+	if got := serverVersionProblem("dev", featureChatSessions); got != "" {
+		t.Errorf("unparseable versions should not warn, got %q", got)
+	}
+	if got := serverVersionProblems("0.9.0"); len(got) != 1 || !strings.Contains(got[0], "chat sessions") {
+		t.Errorf("problems for 0.9.0 = %v", got)
+	}
+	if got := serverVersionProblems(""); len(got) != 0 {
+		t.Errorf("unknown version should have no problems, got %v", got)
+	}
+}
+
+func TestCheckServerVersion_WarnsOncePerFeature(t *testing.T) {
+	var buf bytes.Buffer
+	old := serverVersionWarnOut
+	serverVersionWarnOut = &buf
+	t.Cleanup(func() {
+		serverVersionWarnOut = old
+		resetServerVersionWarnings()
+	})
+	resetServerVersionWarnings()
+
+	checkServerVersion("0.4.0", requirementIncrementalSync)
+	checkServerVersion("0.4.0", requirementIncrementalSync)
+	checkServerVersion("0.4.0", featureTeamQuery)
+	checkServerVersion("1.0.0", featureChatSessions)
+
+	out := buf.String()
+	if strings.Count(out, "incremental sync") != 1 || strings.Count(out, "Warning:") != 2 {
+		t.Errorf("warnings:\n%s", out)
+	}
+}
+
+func TestFetchCapabilities_LegacyServerVersionHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(client.ServerVersionHeader, "0.4.2")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	caps, err := fetchCapabilities(context.Background(), client.New(server.URL), server.URL, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.Legacy || caps.ServerVersion != "0.4.2" {
+		t.Errorf("caps = %+v, want legacy 0.4.2 from the header", caps)
+	}
+}
+
+func TestFormatDoctorOutput_ServerWarnings(t *testing.T) {
+	result := &DoctorResult{
+		Local:          &client.WorkspaceEnv{BdhVersion: "0.5.0"},
+		ServerVersion:  "0.7.0",
+		ServerWarnings: serverVersionProblems("0.7.0"),
+	}
+	out := formatDoctorOutput(result, false)
+	for _, want := range []string{"BeadHub server: 0.7.0", "⚠ BeadHub server 0.7.0 is older than 0.8.0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// A squad is a group of agents working closely enough to edit the same
+// files. Reservations held by a squadmate are shared: auto-reserve reports
+// them as "held by your squadmate X (shared)" instead of a conflict, while
+// they stay exclusive against everyone else. Squads are declared in the
+// config (squad.members) or defined on the server; both are merged.
+
+// reservationSquadKey is the reservation metadata key holding the squad
+// name, so a server that supports squads can share the reservation.
+const reservationSquadKey = "squad"
+
+type squad struct {
+	Name    string
+	members map[string]bool
+}
+
+// has reports whether alias is in the squad. A nil squad has no members.
+func (s *squad) has(alias string) bool {
+	return s != nil && alias != "" && s.members[alias]
+}
+
+// loadSquad returns this workspace's squad, or nil when it is in none.
+// Best-effort: a server that cannot be asked leaves the config's squad.
+func loadSquad(ctx context.Context, cfg *config.Config) *squad {
+	s := &squad{members: make(map[string]bool)}
+	if cfg.Squad != nil {
+		s.Name = strings.TrimSpace(cfg.Squad.Name)
+		for _, alias := range cfg.Squad.Members {
+			s.members[alias] = true
+		}
+	}
+	if serverSupports(ctx, cfg, featureSquads) {
+		if resp, err := newBeadHubClient(ctx, cfg.BeadhubURL).Squad(ctx, cfg.WorkspaceID); err == nil {
+			if s.Name == "" {
+				s.Name = resp.Name
+			}
+			for _, m := range resp.Members {
+				s.members[m.Alias] = true
+			}
+		}
+	}
+	delete(s.members, cfg.Alias)
+	if len(s.members) == 0 {
+		return nil
+	}
+	return s
+}
+
+// squadResolver loads the squad on first use, so commands without a
+// conflict never ask the server.
+func squadResolver(ctx context.Context, cfg *config.Config) func(alias string) bool {
+	var s *squad
+	loaded := false
+	return func(alias string) bool {
+		if !loaded {
+			s, loaded = loadSquad(ctx, cfg), true
+		}
+		return s.has(alias)
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// reservationsStub is an aweb server holding reservations for others.
+type reservationsStub struct {
+	AwebAPI
+	held     []aweb.ReservationView
+	acquired []*aweb.ReservationAcquireRequest
+}
+
+func (s *reservationsStub) ReservationList(ctx context.Context, prefix string) (*aweb.ReservationListResponse, error) {
+	return &aweb.ReservationListResponse{Reservations: s.held}, nil
+}
+
+func (s *reservationsStub) ReservationAcquire(ctx context.Context, req *aweb.ReservationAcquireRequest) (*aweb.ReservationAcquireResponse, error) {
+	for _, r := range s.held {
+		if r.ResourceKey == req.ResourceKey {
+			return nil, &aweb.ReservationHeldError{HolderAlias: r.HolderAlias, ExpiresAt: r.ExpiresAt}
+		}
+	}
+	s.acquired = append(s.acquired, req)
+	return &aweb.ReservationAcquireResponse{}, nil
+}
+
+func TestLoadSquad_MergesConfigAndServer(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	fake := clienttest.New()
+	fake.EnableFeatures(featureSquads)
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-me", Alias: "claude-be"})
+	fake.AddSquad("web", "claude-be", "claude-fe")
+	ctx := withFakeBeadHub(fake)
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "claude-be", WorkspaceID: "ws-me",
+		Squad: &config.SquadConfig{Members: []string{"claude-qa", "claude-be"}}}
+	s := loadSquad(ctx, cfg)
+	if s == nil || s.Name != "web" || !s.has("claude-fe") || !s.has("claude-qa") || s.has("claude-be") || s.has("bob") {
+		t.Errorf("unexpected squad %+v", s)
+	}
+
+	cfg.WorkspaceID, cfg.Squad = "ws-other", nil
+	if s := loadSquad(ctx, cfg); s != nil {
+		t.Errorf("expected no squad, got %+v", s)
+	}
+}
+
+func TestAutoReserve_SharesWithSquadmates(t *testing.T) {
+	repoDir := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repoDir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	runGit("init", "-q")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test")
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte("v1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "init")
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte("v2\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(repoDir)
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+	ctx := withFakeBeadHub(clienttest.New())
+
+	expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	aw := &reservationsStub{held: []aweb.ReservationView{
+		{ResourceKey: "a.go", HolderAlias: "claude-fe", ExpiresAt: expires},
+		{ResourceKey: "b.go", HolderAlias: "mallory", ExpiresAt: expires},
+	}}
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "claude-be", WorkspaceID: "ws-me",
+		Squad: &config.SquadConfig{Name: "web", Members: []string{"claude-fe"}}}
+
+	res := autoReserve(ctx, cfg, aw, "")
+	if res == nil || len(res.Shared) != 1 || res.Shared[0].ResourceKey != "a.go" || res.Shared[0].HeldBy != "claude-fe" {
+		t.Fatalf("expected a.go shared with claude-fe, got %+v", res)
+	}
+	if len(res.Conflicts) != 1 || res.Conflicts[0].HeldBy != "mallory" {
+		t.Errorf("expected b.go to conflict with mallory, got %+v", res.Conflicts)
+	}
+	if len(aw.acquired) != 1 || aw.acquired[0].ResourceKey != "c.go" || aw.acquired[0].Metadata[reservationSquadKey] != "web" {
+		t.Errorf("expected c.go acquired for squad web, got %+v", aw.acquired)
+	}
+
+	out := formatReservedFiles(&PassthroughResult{AutoReserveShared: res.Shared, AutoReserveConflicts: res.Conflicts})
+	for _, want := range []string{
+		"- `a.go` — held by your squadmate claude-fe (shared)",
+		"- `b.go` — mallory (expires in",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
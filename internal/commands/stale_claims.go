@@ -0,0 +1,395 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// :stale-claims finds my claims whose work has already landed: every local
+// branch that names the bead (bd-42-fix-login, as the pre-push hook
+// expects) was merged into origin's default branch or deleted upstream.
+// Such claims linger in everyone's team view until someone notices. Each
+// can be closed or released (set back to open); the other participants in
+// the bead's epic get a note either way.
+
+// Stale claim actions.
+const (
+	staleActionClose   = "closed"
+	staleActionRelease = "released"
+	staleActionSkip    = "skipped"
+)
+
+// StaleClaim is a claim of mine whose branches are all merged or gone.
+type StaleClaim struct {
+	BeadID   string       `json:"bead_id"`
+	Title    string       `json:"title,omitempty"`
+	EpicID   string       `json:"epic_id,omitempty"`
+	Branches []BranchFate `json:"branches"`
+	Action   string       `json:"action,omitempty"`
+	Notified []string     `json:"notified,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// StaleClaimsResult is the output of :stale-claims.
+type StaleClaimsResult struct {
+	DefaultBranch string       `json:"default_branch,omitempty"`
+	Claims        []StaleClaim `json:"claims"`
+	Warnings      []string     `json:"warnings,omitempty"`
+}
+
+var (
+	staleClaimsClose   bool
+	staleClaimsRelease bool
+	staleClaimsFetch   bool
+	staleClaimsJSON    bool
+)
+
+var staleClaimsCmd = &cobra.Command{
+	Use:   ":stale-claims",
+	Short: "Close or release claims whose branch was merged or deleted",
+	Long: `Find beads you still claim although their work has landed: every local
+branch named after the bead (bd-42-fix-login) was merged into origin's
+default branch or deleted upstream. origin is fetched (with --prune) first.
+
+In a terminal you are asked, per bead, whether to close it, release it
+(set it back to open) or leave it. --close or --release acts on all of them
+without asking, for hooks and scripts. The other participants in the bead's
+epic are sent a note.
+
+Examples:
+  bdh :stale-claims              # List, and ask in a terminal
+  bdh :stale-claims --close      # Close every stale claim
+  bdh :stale-claims --release --no-fetch`,
+	Args: cobra.NoArgs,
+	RunE: runStaleClaims,
+}
+
+func init() {
+	staleClaimsCmd.Flags().BoolVar(&staleClaimsClose, "close", false, "Close every stale claim without asking")
+	staleClaimsCmd.Flags().BoolVar(&staleClaimsRelease, "release", false, "Release (reopen) every stale claim without asking")
+	staleClaimsCmd.Flags().BoolVar(&staleClaimsFetch, "fetch", true, "Fetch origin with --prune first (--no-fetch to skip)")
+	staleClaimsCmd.Flags().BoolVar(&staleClaimsJSON, "json", false, "Output as JSON")
+}
+
+// staleClaimActions performs the bead changes; tests substitute them.
+type staleClaimActions struct {
+	close   func(beadID, reason string) error
+	release func(beadID string) error
+}
+
+func defaultStaleClaimActions(ctx context.Context) staleClaimActions {
+	return staleClaimActions{
+		close:   func(beadID, reason string) error { return closeStaleBead(ctx, beadID, reason) },
+		release: func(beadID string) error { return reopenClaimedBead(ctx, beadID) },
+	}
+}
+
+func runStaleClaims(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if staleClaimsClose && staleClaimsRelease {
+		return fmt.Errorf("--close and --release cannot be used together")
+	}
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	root := currentRepoRoot(ctx)
+	if root == "" {
+		return fmt.Errorf("not a git repository")
+	}
+
+	result := &StaleClaimsResult{}
+	if staleClaimsFetch {
+		if err := gitFetchPrune(ctx, root); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("could not fetch origin, using the branches as last fetched: %v", err))
+		}
+	}
+	result.DefaultBranch = gitDefaultBranch(ctx, root)
+	if result.DefaultBranch == "" {
+		result.Warnings = append(result.Warnings, "could not tell origin's default branch - only deleted branches are detected")
+	}
+	fates, err := gitBranchFates(ctx, root, result.DefaultBranch)
+	if err != nil {
+		return err
+	}
+
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return err
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, apiTimeout)
+	includeClaims := true
+	team, err := c.TeamWorkspaces(reqCtx, &client.TeamWorkspacesRequest{
+		IncludeClaims:            &includeClaims,
+		AlwaysIncludeWorkspaceID: cfg.WorkspaceID,
+		Limit:                    maxWorkspaceQueryLimit,
+	})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("fetching claims: %w", err)
+	}
+	issues, issuesErr := loadIssues()
+	if issuesErr != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("could not read issues (%v) - epic participants are not notified", issuesErr))
+	}
+	result.Claims = findStaleClaims(myClaims(team.Workspaces, cfg.WorkspaceID), fates, issues)
+
+	decide := func(StaleClaim) string { return "" }
+	switch {
+	case staleClaimsClose:
+		decide = func(StaleClaim) string { return staleActionClose }
+	case staleClaimsRelease:
+		decide = func(StaleClaim) string { return staleActionRelease }
+	case isTTY() && !staleClaimsJSON && len(result.Claims) > 0:
+		fmt.Print(formatStaleClaims(result))
+		in := bufio.NewReader(os.Stdin)
+		decide = func(claim StaleClaim) string { return promptStaleClaim(in, os.Stdout, claim) }
+	}
+	aw, _ := newAwebClient(ctx, cfg.BeadhubURL)
+	applyStaleClaims(ctx, cfg, aw, result, team.Workspaces, decide, defaultStaleClaimActions(ctx))
+
+	if staleClaimsJSON {
+		fmt.Print(marshalJSONOrFallback(result))
+		return nil
+	}
+	fmt.Print(formatStaleClaimsOutcome(result))
+	return nil
+}
+
+func myClaims(workspaces []client.Workspace, myWorkspaceID string) []client.Claim {
+	for _, ws := range workspaces {
+		if ws.WorkspaceID == myWorkspaceID {
+			return ws.Claims
+		}
+	}
+	return nil
+}
+
+// findStaleClaims returns the claims with at least one branch named after
+// them, all of which are done. A claim with a branch still in flight (a
+// second branch for a follow-up) is not stale.
+func findStaleClaims(claims []client.Claim, fates []BranchFate, issues []Issue) []StaleClaim {
+	ids := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		ids = append(ids, claim.BeadID)
+	}
+	byBead := make(map[string][]BranchFate)
+	for _, fate := range fates {
+		if id := beadIDInBranch(fate.Branch, ids); id != "" {
+			byBead[id] = append(byBead[id], fate)
+		}
+	}
+	epics := beadEpics(issues)
+
+	var stale []StaleClaim
+	for _, claim := range claims {
+		branches := byBead[claim.BeadID]
+		if len(branches) == 0 {
+			continue
+		}
+		done := true
+		for _, fate := range branches {
+			done = done && fate.Done()
+		}
+		if !done {
+			continue
+		}
+		epicID := claim.ApexID
+		if epicID == "" {
+			epicID = epics[claim.BeadID]
+		}
+		stale = append(stale, StaleClaim{BeadID: claim.BeadID, Title: claim.Title, EpicID: epicID, Branches: branches})
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].BeadID < stale[j].BeadID })
+	return stale
+}
+
+func promptStaleClaim(in *bufio.Reader, out io.Writer, claim StaleClaim) string {
+	for {
+		fmt.Fprintf(out, "%s: [c]lose, [r]elease, [s]kip? ", claim.BeadID)
+		line, err := in.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "c", "close":
+			return staleActionClose
+		case "r", "release":
+			return staleActionRelease
+		case "s", "skip", "":
+			return staleActionSkip
+		}
+		if err != nil {
+			return staleActionSkip
+		}
+	}
+}
+
+// applyStaleClaims closes or releases each claim as decide says ("" leaves
+// it listed only) and notes the change to the epic's other participants.
+func applyStaleClaims(ctx context.Context, cfg *config.Config, aw AwebAPI, result *StaleClaimsResult, workspaces []client.Workspace, decide func(StaleClaim) string, actions staleClaimActions) {
+	for i := range result.Claims {
+		claim := &result.Claims[i]
+		action := decide(*claim)
+		var err error
+		switch action {
+		case staleActionClose:
+			err = actions.close(claim.BeadID, staleClaimReason(*claim, result.DefaultBranch))
+		case staleActionRelease:
+			err = actions.release(claim.BeadID)
+		case staleActionSkip:
+			claim.Action = action
+			continue
+		default:
+			continue
+		}
+		if err != nil {
+			claim.Error = err.Error()
+			continue
+		}
+		claim.Action = action
+		claim.Notified = notifyEpicParticipants(ctx, cfg, aw, *claim, result.DefaultBranch, workspaces)
+	}
+}
+
+// staleClaimReason explains the claim's end, e.g. "branch bd-42-fix merged
+// into origin/main".
+func staleClaimReason(claim StaleClaim, defaultBranch string) string {
+	var parts []string
+	for _, fate := range claim.Branches {
+		switch {
+		case fate.Merged && defaultBranch != "":
+			parts = append(parts, fmt.Sprintf("branch %s merged into %s", fate.Branch, defaultBranch))
+		case fate.Gone:
+			parts = append(parts, fmt.Sprintf("branch %s deleted upstream", fate.Branch))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// epicParticipants returns the other workspaces working in epicID: claiming
+// a bead under it or focused on it.
+func epicParticipants(epicID, myWorkspaceID string, workspaces []client.Workspace) []client.Workspace {
+	if epicID == "" {
+		return nil
+	}
+	var participants []client.Workspace
+	for _, ws := range workspaces {
+		if ws.WorkspaceID == myWorkspaceID {
+			continue
+		}
+		in := ws.FocusApexID == epicID || ws.ApexID == epicID
+		for _, claim := range ws.Claims {
+			in = in || claim.ApexID == epicID || claim.BeadID == epicID
+		}
+		if in {
+			participants = append(participants, ws)
+		}
+	}
+	return participants
+}
+
+// notifyEpicParticipants mails the note about claim to the epic's other
+// participants. Failed sends are recorded for :replay. Returns the aliases
+// notified.
+func notifyEpicParticipants(ctx context.Context, cfg *config.Config, aw AwebAPI, claim StaleClaim, defaultBranch string, workspaces []client.Workspace) []string {
+	participants := epicParticipants(claim.EpicID, cfg.WorkspaceID, workspaces)
+	if aw == nil || len(participants) == 0 {
+		return nil
+	}
+	subject := claim.BeadID
+	if claim.Title != "" {
+		subject += fmt.Sprintf(" %q", claim.Title)
+	}
+	body := fmt.Sprintf("%s %s %s in epic %s: %s.", cfg.Alias, claim.Action, subject, claim.EpicID, staleClaimReason(claim, defaultBranch))
+	var notified []string
+	for _, ws := range participants {
+		req := &aweb.SendMessageRequest{ToAgentID: ws.WorkspaceID, Body: body}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, err := aw.SendMessage(ctx, req)
+		cancel()
+		if err != nil {
+			recordFailedNotification(req, ws.Alias, err)
+			continue
+		}
+		notified = append(notified, ws.Alias)
+	}
+	return notified
+}
+
+// closeStaleBead closes a bead through the usual passthrough, so the close
+// is checked, synced and the claim disappears for the team.
+func closeStaleBead(ctx context.Context, beadID, reason string) error {
+	result, err := runPassthrough(ctx, []string{"close", beadID, "--reason", reason})
+	if err != nil {
+		return err
+	}
+	switch {
+	case result.Rejected:
+		return fmt.Errorf("%s", result.RejectionReason)
+	case result.Blocked != "":
+		return fmt.Errorf("%s", result.Blocked)
+	case result.ExitCode != 0:
+		return fmt.Errorf("bd exited %d: %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	case result.SyncWarning != "":
+		return fmt.Errorf("closed locally, but %s", result.SyncWarning)
+	}
+	return nil
+}
+
+func formatStaleClaims(result *StaleClaimsResult) string {
+	var sb strings.Builder
+	for _, w := range result.Warnings {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", w))
+	}
+	if len(result.Claims) == 0 {
+		sb.WriteString("No stale claims: none of your claimed beads has a merged or deleted branch.\n")
+		return sb.String()
+	}
+	sb.WriteString("Claims whose work has landed:\n")
+	for _, claim := range result.Claims {
+		line := "- " + claim.BeadID
+		if claim.Title != "" {
+			line += fmt.Sprintf(" %q", claim.Title)
+		}
+		sb.WriteString(line + " — " + staleClaimReason(claim, result.DefaultBranch) + "\n")
+	}
+	return sb.String()
+}
+
+func formatStaleClaimsOutcome(result *StaleClaimsResult) string {
+	acted := false
+	var sb strings.Builder
+	for _, claim := range result.Claims {
+		switch {
+		case claim.Error != "":
+			acted = true
+			sb.WriteString(fmt.Sprintf("✗ %s: %s\n", claim.BeadID, claim.Error))
+		case claim.Action == staleActionClose || claim.Action == staleActionRelease:
+			acted = true
+			line := fmt.Sprintf("✓ %s %s", claim.Action, claim.BeadID)
+			if len(claim.Notified) > 0 {
+				line += " (notified " + strings.Join(claim.Notified, ", ") + ")"
+			}
+			sb.WriteString(line + "\n")
+		case claim.Action == staleActionSkip:
+			acted = true
+		}
+	}
+	if acted {
+		return sb.String()
+	}
+	out := formatStaleClaims(result)
+	if len(result.Claims) > 0 {
+		out += "Close them: bdh :stale-claims --close (or --release to reopen)\n"
+	}
+	return out
+}
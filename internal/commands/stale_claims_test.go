@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestGitBranchFates(t *testing.T) {
+	tmpDir := t.TempDir()
+	origin := filepath.Join(tmpDir, "origin.git")
+	repoDir := filepath.Join(tmpDir, "repo")
+	runGit := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	runGit(tmpDir, "init", "--bare", "-b", "main", origin)
+	runGit(tmpDir, "clone", origin, repoDir)
+	runGit(repoDir, "config", "user.email", "test@example.com")
+	runGit(repoDir, "config", "user.name", "Test")
+	runGit(repoDir, "checkout", "-b", "main")
+	runGit(repoDir, "commit", "--allow-empty", "-m", "init")
+	runGit(repoDir, "push", "-u", "origin", "main")
+
+	// bd-1: pushed, then merged into main.
+	runGit(repoDir, "checkout", "-b", "bd-1-login")
+	runGit(repoDir, "commit", "--allow-empty", "-m", "login")
+	runGit(repoDir, "push", "-u", "origin", "bd-1-login")
+	runGit(repoDir, "checkout", "main")
+	runGit(repoDir, "merge", "--no-ff", "-m", "merge bd-1", "bd-1-login")
+	runGit(repoDir, "push", "origin", "main")
+	// bd-2: pushed, then deleted on the server without merging.
+	runGit(repoDir, "checkout", "-b", "bd-2-search")
+	runGit(repoDir, "commit", "--allow-empty", "-m", "search")
+	runGit(repoDir, "push", "-u", "origin", "bd-2-search")
+	runGit(tmpDir, "--git-dir", origin, "branch", "-D", "bd-2-search")
+	// bd-3: still in flight; bd-4: never pushed.
+	runGit(repoDir, "checkout", "-b", "bd-3-export", "main")
+	runGit(repoDir, "commit", "--allow-empty", "-m", "export")
+	runGit(repoDir, "push", "-u", "origin", "bd-3-export")
+	runGit(repoDir, "checkout", "-b", "bd-4-local", "main")
+	runGit(repoDir, "checkout", "main")
+
+	t.Chdir(repoDir)
+	if err := gitFetchPrune(context.Background(), repoDir); err != nil {
+		t.Fatal(err)
+	}
+	defaultRef := gitDefaultBranch(context.Background(), repoDir)
+	if defaultRef != "origin/main" {
+		t.Fatalf("default branch = %q", defaultRef)
+	}
+	fates, err := gitBranchFates(context.Background(), repoDir, defaultRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, fate := range fates {
+		state := "open"
+		switch {
+		case fate.Merged:
+			state = "merged"
+		case fate.Gone:
+			state = "gone"
+		}
+		got = append(got, fate.Branch+":"+state)
+	}
+	want := "bd-1-login:merged|bd-2-search:gone|bd-3-export:open|bd-4-local:open"
+	if strings.Join(got, "|") != want {
+		t.Errorf("fates = %s, want %s", strings.Join(got, "|"), want)
+	}
+}
+
+func TestFindStaleClaims(t *testing.T) {
+	claims := []client.Claim{
+		{BeadID: "bd-1", Title: "Login", ApexID: "bd-10"},
+		{BeadID: "bd-2"},
+		{BeadID: "bd-3"},
+		{BeadID: "bd-5"},
+	}
+	fates := []BranchFate{
+		{Branch: "bd-1-login", Upstream: "origin/bd-1-login", Merged: true},
+		{Branch: "bd-2-search", Upstream: "origin/bd-2-search", Gone: true},
+		{Branch: "bd-3-export", Upstream: "origin/bd-3-export", Merged: true},
+		{Branch: "bd-3-followup", Upstream: "origin/bd-3-followup"},
+		{Branch: "bd-42-other", Upstream: "origin/bd-42-other", Merged: true},
+	}
+	issues := []Issue{
+		{ID: "bd-20", IssueType: "epic"},
+		{ID: "bd-2", Dependencies: []Dependency{{DependsOnID: "bd-20", Type: "parent-child"}}},
+	}
+
+	stale := findStaleClaims(claims, fates, issues)
+	if len(stale) != 2 {
+		t.Fatalf("expected bd-1 and bd-2, got %+v", stale)
+	}
+	if stale[0].BeadID != "bd-1" || stale[0].EpicID != "bd-10" || stale[0].Title != "Login" {
+		t.Errorf("unexpected %+v", stale[0])
+	}
+	if stale[1].BeadID != "bd-2" || stale[1].EpicID != "bd-20" {
+		t.Errorf("the epic should come from the issues, got %+v", stale[1])
+	}
+	if got := staleClaimReason(stale[0], "origin/main"); got != "branch bd-1-login merged into origin/main" {
+		t.Errorf("reason = %q", got)
+	}
+	if got := staleClaimReason(stale[1], "origin/main"); got != "branch bd-2-search deleted upstream" {
+		t.Errorf("reason = %q", got)
+	}
+}
+
+// staleMailStub records messages, failing for one recipient.
+type staleMailStub struct {
+	AwebAPI
+	sent []*aweb.SendMessageRequest
+	fail string
+}
+
+func (s *staleMailStub) SendMessage(ctx context.Context, req *aweb.SendMessageRequest) (*aweb.SendMessageResponse, error) {
+	if req.ToAgentID == s.fail {
+		return nil, errors.New("connection refused")
+	}
+	s.sent = append(s.sent, req)
+	return &aweb.SendMessageResponse{}, nil
+}
+
+func TestApplyStaleClaims(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{WorkspaceID: "ws-me", Alias: "me"}
+	workspaces := []client.Workspace{
+		{WorkspaceID: "ws-me", Alias: "me", Claims: []client.Claim{{BeadID: "bd-1", ApexID: "bd-10"}}},
+		{WorkspaceID: "ws-a", Alias: "alice", Claims: []client.Claim{{BeadID: "bd-11", ApexID: "bd-10"}}},
+		{WorkspaceID: "ws-b", Alias: "bob", FocusApexID: "bd-10"},
+		{WorkspaceID: "ws-c", Alias: "carol", FocusApexID: "bd-99"},
+		{WorkspaceID: "ws-d", Alias: "dave", Claims: []client.Claim{{BeadID: "bd-21", ApexID: "bd-20"}}},
+	}
+	result := &StaleClaimsResult{DefaultBranch: "origin/main", Claims: []StaleClaim{
+		{BeadID: "bd-1", Title: "Login", EpicID: "bd-10", Branches: []BranchFate{{Branch: "bd-1-login", Merged: true}}},
+		{BeadID: "bd-2", EpicID: "bd-20", Branches: []BranchFate{{Branch: "bd-2-search", Gone: true}}},
+		{BeadID: "bd-3", Branches: []BranchFate{{Branch: "bd-3-x", Gone: true}}},
+	}}
+	var closed, released []string
+	actions := staleClaimActions{
+		close: func(beadID, reason string) error {
+			closed = append(closed, beadID+": "+reason)
+			return nil
+		},
+		release: func(beadID string) error {
+			if beadID == "bd-3" {
+				return errors.New("bd exited 1")
+			}
+			released = append(released, beadID)
+			return nil
+		},
+	}
+	decide := func(claim StaleClaim) string {
+		if claim.BeadID == "bd-1" {
+			return staleActionClose
+		}
+		return staleActionRelease
+	}
+	aw := &staleMailStub{fail: "ws-d"}
+
+	applyStaleClaims(context.Background(), cfg, aw, result, workspaces, decide, actions)
+
+	if strings.Join(closed, "|") != "bd-1: branch bd-1-login merged into origin/main" || strings.Join(released, "|") != "bd-2" {
+		t.Errorf("closed %v, released %v", closed, released)
+	}
+	if got := strings.Join(result.Claims[0].Notified, ","); got != "alice,bob" {
+		t.Errorf("bd-1 notified %q, want the epic's other participants", got)
+	}
+	if len(aw.sent) != 2 || !strings.Contains(aw.sent[0].Body, `me closed bd-1 "Login" in epic bd-10: branch bd-1-login merged into origin/main.`) {
+		t.Errorf("unexpected messages %+v", aw.sent)
+	}
+	if result.Claims[1].Action != staleActionRelease || len(result.Claims[1].Notified) != 0 {
+		t.Errorf("bd-2 was released but its note failed: %+v", result.Claims[1])
+	}
+	path, err := failedOpsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed, _ := loadFailedOps(path); len(failed) != 1 || failed[0].ToAlias != "dave" {
+		t.Errorf("the failed note should be kept for replay, got %+v", failed)
+	}
+	if result.Claims[2].Action != "" || result.Claims[2].Error != "bd exited 1" {
+		t.Errorf("bd-3 failed to release: %+v", result.Claims[2])
+	}
+
+	out := formatStaleClaimsOutcome(result)
+	for _, want := range []string{"✓ closed bd-1 (notified alice, bob)", "✓ released bd-2", "✗ bd-3: bd exited 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
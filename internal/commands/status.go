@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -22,6 +22,7 @@ var statusCmd = &cobra.Command{
 	Long: `Show comprehensive coordination status.
 
 Displays:
+  - Beads you pinned with :pin
   - Your identity (alias, role)
   - Your claims and reservations
   - Team members with their claims, reservations, and status
@@ -65,6 +66,9 @@ type TeamMemberInfo struct {
 	ApexType  string        `json:"apex_type,omitempty"`
 	Claims    []ClaimInfo   `json:"claims,omitempty"`
 	Locks     []LockSummary `json:"locks,omitempty"`
+
+	// Presence is the do-not-disturb notice, if the member is heads-down.
+	Presence string `json:"presence,omitempty"`
 }
 
 // StatusResult contains the result of the status command.
@@ -75,28 +79,26 @@ type StatusResult struct {
 	YourLocks          []LockSummary
 	Team               []TeamMemberInfo
 	EscalationsPending int
+	Announcement       *client.Announcement `json:",omitempty"`
+	Pinned             []PinStatus          `json:",omitempty"`
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
-		}
-		return fmt.Errorf("loading config: %w", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid .beadhub config: %w", err)
-	}
-	if err := validateRepoOriginMatchesCurrent(cfg); err != nil {
 		return err
 	}
 
-	result, err := fetchStatusWithConfig(cfg)
+	result, err := fetchStatusWithConfig(ctx, cfg)
 	if err != nil {
 		return err
 	}
+	if statusJSON {
+		result.Announcement = currentAnnouncement(ctx, cfg, time.Now())
+	} else {
+		result.Announcement = announcementBanner(ctx, cfg, time.Now())
+	}
 
 	output := formatStatusOutput(result, statusJSON)
 	fmt.Print(output)
@@ -104,12 +106,12 @@ func runStatus(cmd *cobra.Command, args []string) error {
 }
 
 // fetchStatusWithConfig fetches status information using the provided config.
-func fetchStatusWithConfig(cfg *config.Config) (*StatusResult, error) {
-	c, err := newBeadHubClientRequired(cfg.BeadhubURL)
+func fetchStatusWithConfig(ctx context.Context, cfg *config.Config) (*StatusResult, error) {
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
 	if err != nil {
 		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
 
 	result := &StatusResult{
@@ -186,9 +188,16 @@ func fetchStatusWithConfig(cfg *config.Config) (*StatusResult, error) {
 			ApexType:  ws.ApexType,
 			Claims:    claims,
 			Locks:     locks,
+			Presence:  presenceNotice(ws, serverNow()),
 		})
 	}
 
+	// Pinned beads, with claimants from the team listing
+	if pins := workspacePins(); len(pins) > 0 {
+		issues, _ := loadIssues()
+		result.Pinned = pinStatuses(pins, issues, teamResp.Workspaces, true)
+	}
+
 	// Fetch escalations count
 	statusResp, err := c.Status(ctx, &client.StatusRequest{})
 	if err == nil {
@@ -205,6 +214,8 @@ func formatStatusOutput(result *StatusResult, asJSON bool) string {
 	}
 
 	var sb strings.Builder
+	sb.WriteString(formatAnnouncementBanner(result.Announcement))
+	sb.WriteString(formatPinnedSection(result.Pinned))
 
 	// Your identity (brief)
 	sb.WriteString("## You\n")
@@ -217,7 +228,7 @@ func formatStatusOutput(result *StatusResult, asJSON bool) string {
 	if len(result.YourClaims) > 0 {
 		sb.WriteString("\n## Your Claims\n")
 		for _, claim := range result.YourClaims {
-			claimAge := formatTimeAgo(claim.ClaimedAt)
+			claimAge := formatTimestamp(claim.ClaimedAt)
 			staleIndicator := ""
 			if isClaimStale(claim.ClaimedAt) {
 				staleIndicator = " ⚠️"
@@ -245,14 +256,17 @@ func formatStatusOutput(result *StatusResult, asJSON bool) string {
 		sb.WriteString("No other team members.\n")
 	} else {
 		for _, member := range result.Team {
-			timeAgo := formatTimeAgo(member.LastSeen)
+			timeAgo := formatTimestamp(member.LastSeen)
 
 			// Header line: alias — role — status — time
-			sb.WriteString(fmt.Sprintf("- **%s**", member.Alias))
+			sb.WriteString(fmt.Sprintf("- **%s**", displayAlias(member.Alias)))
 			if member.Role != "" {
 				sb.WriteString(fmt.Sprintf(" — %s", member.Role))
 			}
 			sb.WriteString(fmt.Sprintf(" — %s — %s\n", member.Status, timeAgo))
+			if member.Presence != "" {
+				sb.WriteString(fmt.Sprintf("  - %s\n", member.Presence))
+			}
 
 			// Repo/branch if available
 			repoName := strings.TrimSpace(member.RepoName)
@@ -284,7 +298,7 @@ func formatStatusOutput(result *StatusResult, asJSON bool) string {
 			if len(member.Claims) > 0 {
 				sb.WriteString("  Claims:\n")
 				for _, claim := range member.Claims {
-					claimAge := formatTimeAgo(claim.ClaimedAt)
+					claimAge := formatTimestamp(claim.ClaimedAt)
 					staleIndicator := ""
 					if isClaimStale(claim.ClaimedAt) {
 						staleIndicator = " ⚠️"
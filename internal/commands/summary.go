@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Every bd command run through bdh ends with one summary line on stderr
+// (unless it fails before bd runs, or is interrupted), so wrappers can read
+// the coordination outcome without parsing JSON:
+//
+//	BDH_SUMMARY exit=0 rejected=false synced=true messages=2 conflicts=0
+//
+// This format is a contract. The prefix and the order of the keys do not
+// change; new keys are only ever appended. Values are integers or
+// true/false, never quoted, never containing spaces:
+//
+//	exit       bdh's exit code
+//	rejected   BeadHub rejected the command, so bd did not run
+//	synced     false when changes made by bd are not on the server yet
+//	           (the sync failed, or the command ran inside :txn)
+//	messages   unread messages waiting, as reported by BeadHub
+//	conflicts  modified files reserved by other agents
+//
+// With --json the same fields are in the output's "summary" object and no
+// line is printed.
+
+const summaryPrefix = "BDH_SUMMARY"
+
+// CommandSummary is the outcome of a bd command run through bdh.
+type CommandSummary struct {
+	Exit      int  `json:"exit"`
+	Rejected  bool `json:"rejected"`
+	Synced    bool `json:"synced"`
+	Messages  int  `json:"messages"`
+	Conflicts int  `json:"conflicts"`
+}
+
+func (s CommandSummary) String() string {
+	return fmt.Sprintf("%s exit=%d rejected=%t synced=%t messages=%d conflicts=%d",
+		summaryPrefix, s.Exit, s.Rejected, s.Synced, s.Messages, s.Conflicts)
+}
+
+// passthroughExitCode is bdh's exit code for result: 1 when bd did not run
+// or the command was failed after a failed sync, otherwise bd's.
+func passthroughExitCode(result *PassthroughResult) int {
+	if result.Rejected || result.Blocked != "" || result.SyncBlocked != "" || result.SyncStrictFailed != "" {
+		return 1
+	}
+	return result.ExitCode
+}
+
+func summarizePassthrough(result *PassthroughResult) CommandSummary {
+	return CommandSummary{
+		Exit:      passthroughExitCode(result),
+		Rejected:  result.Rejected,
+		Synced:    !result.SyncFailed && result.TxnNotice == "",
+		Messages:  result.MessagesWaiting,
+		Conflicts: len(result.AutoReserveConflicts),
+	}
+}
+
+// pendingSummary is printed by PrintSummary once notifications are out, so
+// it stays the last line.
+var (
+	summaryMu      sync.Mutex
+	pendingSummary *CommandSummary
+)
+
+func setPendingSummary(s *CommandSummary) {
+	summaryMu.Lock()
+	pendingSummary = s
+	summaryMu.Unlock()
+}
+
+// PrintSummary prints the summary line of the bd command that just ran, if
+// any. main.go calls it last.
+func PrintSummary(w io.Writer) {
+	summaryMu.Lock()
+	s := pendingSummary
+	pendingSummary = nil
+	summaryMu.Unlock()
+	if s != nil {
+		_, _ = fmt.Fprintln(w, s.String())
+	}
+}
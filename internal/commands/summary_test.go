@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// summaryLinePattern is the documented BDH_SUMMARY contract. Changing it
+// breaks wrappers; append keys instead.
+var summaryLinePattern = regexp.MustCompile(`^BDH_SUMMARY exit=-?\d+ rejected=(true|false) synced=(true|false) messages=\d+ conflicts=\d+$`)
+
+func TestCommandSummary_String(t *testing.T) {
+	s := CommandSummary{Exit: 0, Rejected: false, Synced: true, Messages: 2, Conflicts: 0}
+	if got, want := s.String(), "BDH_SUMMARY exit=0 rejected=false synced=true messages=2 conflicts=0"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if !summaryLinePattern.MatchString(s.String()) {
+		t.Errorf("summary line breaks the contract: %q", s.String())
+	}
+}
+
+func TestSummarizePassthrough(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *PassthroughResult
+		want   CommandSummary
+	}{
+		{"ok", &PassthroughResult{MessagesWaiting: 2}, CommandSummary{Synced: true, Messages: 2}},
+		{"bd failed", &PassthroughResult{ExitCode: 3}, CommandSummary{Exit: 3, Synced: true}},
+		{"rejected", &PassthroughResult{Rejected: true}, CommandSummary{Exit: 1, Rejected: true, Synced: true}},
+		{"blocked", &PassthroughResult{Blocked: "reserved"}, CommandSummary{Exit: 1, Synced: true}},
+		{"sync failed", &PassthroughResult{SyncFailed: true}, CommandSummary{Synced: false}},
+		{"strict sync", &PassthroughResult{SyncFailed: true, SyncStrictFailed: "down"}, CommandSummary{Exit: 1}},
+		{"txn", &PassthroughResult{TxnNotice: "recorded"}, CommandSummary{Synced: false}},
+		{"conflicts", &PassthroughResult{AutoReserveConflicts: []ReservationConflict{{ResourceKey: "a.go"}, {ResourceKey: "b.go"}}},
+			CommandSummary{Synced: true, Conflicts: 2}},
+	}
+	for _, tt := range tests {
+		if got := summarizePassthrough(tt.result); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrintSummary_PrintsOnce(t *testing.T) {
+	setPendingSummary(&CommandSummary{Exit: 1, Rejected: true, Synced: true})
+	var sb strings.Builder
+	PrintSummary(&sb)
+	PrintSummary(&sb)
+	if got := sb.String(); got != "BDH_SUMMARY exit=1 rejected=true synced=true messages=0 conflicts=0\n" {
+		t.Errorf("PrintSummary printed %q", got)
+	}
+}
+
+func TestFormatPassthroughOutputJSON_IncludesSummary(t *testing.T) {
+	out := formatPassthroughOutput(&PassthroughResult{JSONMode: true, ExitCode: 0, MessagesWaiting: 4})
+	var parsed struct {
+		Summary map[string]any `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	want := map[string]any{"exit": 0.0, "rejected": false, "synced": true, "messages": 4.0, "conflicts": 0.0}
+	for key, value := range want {
+		if parsed.Summary[key] != value {
+			t.Errorf("summary.%s = %v, want %v", key, parsed.Summary[key], value)
+		}
+	}
+}
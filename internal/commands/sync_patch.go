@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+// Servers speaking sync protocol v2 accept per-issue JSON merge patches, so
+// an edit to one field of an issue with a large description uploads just
+// that field. The base each patch applies to is the last uploaded version,
+// taken from the sync history (see :diff); issues without a usable base,
+// and patches no smaller than the issue itself, are sent whole. A server that
+// refuses the patches (409, e.g. its copy diverged) gets a plain incremental
+// sync instead.
+
+// patchSyncRequest returns a patch-mode copy of the incremental request req,
+// or nil when the server does not speak v2 or no issue benefits from a patch.
+func patchSyncRequest(cfg *config.Config, req *client.SyncRequest, content []byte, changedIDs []string, state *sync.SyncState) *client.SyncRequest {
+	if state.ProtocolVersion < sync.PatchProtocolVersion || len(changedIDs) == 0 {
+		return nil
+	}
+	history, err := sync.LoadHistory(syncHistoryPathForConfig(cfg))
+	if err != nil {
+		return nil
+	}
+	extracted, err := sync.ExtractIssuesByID(content, changedIDs)
+	if err != nil {
+		return nil
+	}
+
+	current := make(map[string]string, len(changedIDs))
+	for _, line := range strings.Split(extracted, "\n") {
+		var probe struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal([]byte(line), &probe) == nil && probe.ID != "" {
+			current[probe.ID] = line
+		}
+	}
+
+	var patches []client.IssuePatch
+	var whole []string
+	for _, id := range changedIDs {
+		line, ok := current[id]
+		if !ok {
+			continue
+		}
+		if patch := issuePatch(history[id], state.IssueHashes[id], line); patch != nil {
+			patches = append(patches, client.IssuePatch{ID: id, BaseHash: state.IssueHashes[id], Patch: patch})
+			continue
+		}
+		whole = append(whole, line)
+	}
+	if len(patches) == 0 {
+		return nil
+	}
+
+	patchReq := *req
+	patchReq.SyncMode = "patch"
+	patchReq.IssuePatches = patches
+	patchReq.ChangedIssues = ""
+	if len(whole) > 0 {
+		patchReq.ChangedIssues = strings.Join(whole, "\n") + "\n"
+	}
+	return &patchReq
+}
+
+// issuePatch returns the merge patch from the last uploaded version of an
+// issue to line, or nil when that version is unknown or the patch would not
+// be smaller than the issue.
+func issuePatch(entries []sync.HistoryEntry, syncedHash, line string) json.RawMessage {
+	if len(entries) == 0 || syncedHash == "" {
+		return nil
+	}
+	base := entries[len(entries)-1]
+	if base.Deleted || base.Hash != syncedHash || len(base.Issue) == 0 {
+		return nil
+	}
+	patch, err := sync.CreateMergePatch(base.Issue, []byte(line))
+	if err != nil || len(patch) >= len(line) {
+		return nil
+	}
+	return patch
+}
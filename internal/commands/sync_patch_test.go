@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/beads"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+func TestSyncToBeadHub_PatchProtocol(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	long := strings.Repeat("A long description that rarely changes. ", 20)
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open","description":"`+long+`"}
+{"id":"bd-2","title":"Two","status":"open"}
+`)
+
+	var requests []client.SyncRequest
+	refusePatches := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.SyncRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		if req.SyncMode == "patch" && refusePatches {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"detail":"base_hash mismatch for bd-1"}`))
+			return
+		}
+		w.Write([]byte(`{"synced": true, "issues_count": 2, "sync_protocol_version": 2}`))
+	}))
+	defer server.Close()
+	cfg := &config.Config{
+		WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		Alias:       "alice",
+		BeadhubURL:  server.URL,
+	}
+
+	// First sync is full; the server announces v2
+	if result := syncToBeadHub(context.Background(), cfg, []string{"create", "One"}, syncOptions{}); result.Warning != "" || result.SyncMode != "full" {
+		t.Fatalf("first sync = %+v", result)
+	}
+	state, _ := sync.LoadState(syncStatePathForConfig(cfg))
+	baseHash := state.IssueHashes["bd-1"]
+
+	// A status change on bd-1 travels as a patch; new bd-3 is sent whole
+	writeIssues(t, `{"id":"bd-1","title":"One","status":"closed","description":"`+long+`"}
+{"id":"bd-2","title":"Two","status":"open"}
+{"id":"bd-3","title":"Three","status":"open"}
+`)
+	result := syncToBeadHub(context.Background(), cfg, []string{"close", "bd-1"}, syncOptions{})
+	if result.Warning != "" || result.SyncMode != "patch" {
+		t.Fatalf("second sync = %+v", result)
+	}
+	got := requests[len(requests)-1]
+	if len(got.IssuePatches) != 1 || got.IssuePatches[0].ID != "bd-1" || got.IssuePatches[0].BaseHash != baseHash ||
+		string(got.IssuePatches[0].Patch) != `{"status":"closed"}` {
+		t.Errorf("patches = %+v", got.IssuePatches)
+	}
+	if strings.Contains(got.ChangedIssues, `"bd-1"`) || !strings.Contains(got.ChangedIssues, `"bd-3"`) {
+		t.Errorf("changed issues = %s", got.ChangedIssues)
+	}
+
+	// A refused patch is retried as a plain incremental sync
+	refusePatches = true
+	writeIssues(t, `{"id":"bd-1","title":"One","status":"open","description":"`+long+`"}
+{"id":"bd-2","title":"Two","status":"open"}
+{"id":"bd-3","title":"Three","status":"open"}
+`)
+	sent := len(requests)
+	result = syncToBeadHub(context.Background(), cfg, []string{"reopen", "bd-1"}, syncOptions{})
+	if result.Warning != "" || result.SyncMode != "incremental" || len(requests) != sent+2 {
+		t.Fatalf("downgraded sync = %+v after %d request(s)", result, len(requests)-sent)
+	}
+	if got := requests[len(requests)-1]; got.SyncMode != "incremental" || len(got.IssuePatches) != 0 || !strings.Contains(got.ChangedIssues, `"bd-1"`) {
+		t.Errorf("fallback request = %+v", got)
+	}
+}
+
+func writeIssues(t *testing.T, issues string) {
+	t.Helper()
+	if err := os.WriteFile(beads.IssuesJSONLPath(), []byte(issues), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/bd"
+)
+
+// syncOptions controls optional safety behavior of syncToBeadHub.
+type syncOptions struct {
+	// ConfirmDeletes allows an incremental sync to delete more issues than
+	// the configured sync.max_delete_percent.
+	ConfirmDeletes bool
+	// Force uploads even when the issue graph fails hygiene checks.
+	Force bool
+	// Targets limits an incremental sync to these issues (the ones a dep or
+	// label edit touched). Other local changes stay pending for the next sync.
+	Targets []string
+	// BdEnv holds --:bd-env variables, applied to bd export as well.
+	BdEnv []string
+}
+
+// checkDeletionSafety returns a warning when an incremental sync would delete
+// more than maxPercent of the previously synced issues. Returns "" when safe.
+func checkDeletionSafety(deletedCount, knownCount, maxPercent int, confirmed bool) string {
+	if confirmed || deletedCount == 0 || knownCount == 0 {
+		return ""
+	}
+	// Always allow a single deletion so tiny projects aren't stuck.
+	if deletedCount == 1 {
+		return ""
+	}
+	percent := deletedCount * 100 / knownCount
+	if percent <= maxPercent {
+		return ""
+	}
+	return fmt.Sprintf(
+		"sync blocked - would delete %d of %d issues (%d%%, limit %d%%). If intended, re-run with --:confirm-deletes",
+		deletedCount, knownCount, percent, maxPercent)
+}
+
+// countIssuesInDB asks bd how many issues the local database holds.
+// exportArgs are the args produced by resolveIssuesPathAndExportArgs; their
+// global flags (--db, --no-daemon, ...) are reused. Returns ok=false when the
+// count can't be determined, in which case callers should skip the check.
+func countIssuesInDB(ctx context.Context, runner *bd.Runner, exportArgs []string) (int, bool) {
+	var globalArgs []string
+	for _, arg := range exportArgs {
+		if arg == "export" {
+			break
+		}
+		globalArgs = append(globalArgs, arg)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := runner.Run(ctx, append(globalArgs, "count", "--json"))
+	if err != nil || res.ExitCode != 0 {
+		return 0, false
+	}
+	return parseIssueCount(res.Stdout)
+}
+
+// parseIssueCount accepts either a bare integer or {"count": N}.
+func parseIssueCount(output string) (int, bool) {
+	output = strings.TrimSpace(output)
+	if n, err := strconv.Atoi(output); err == nil {
+		return n, true
+	}
+	var parsed struct {
+		Count *int `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil || parsed.Count == nil {
+		return 0, false
+	}
+	return *parsed.Count, true
+}
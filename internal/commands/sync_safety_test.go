@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckDeletionSafety(t *testing.T) {
+	tests := []struct {
+		name      string
+		deleted   int
+		known     int
+		max       int
+		confirmed bool
+		blocked   bool
+	}{
+		{"no deletions", 0, 100, 20, false, false},
+		{"single deletion always allowed", 1, 2, 20, false, false},
+		{"under limit", 10, 100, 20, false, false},
+		{"at limit", 20, 100, 20, false, false},
+		{"over limit", 21, 100, 20, false, true},
+		{"mass delete of truncated file", 95, 100, 20, false, true},
+		{"confirmed mass delete", 95, 100, 20, true, false},
+		{"no prior state", 5, 0, 20, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := checkDeletionSafety(tt.deleted, tt.known, tt.max, tt.confirmed)
+			if (warning != "") != tt.blocked {
+				t.Errorf("checkDeletionSafety() = %q, blocked want %v", warning, tt.blocked)
+			}
+			if tt.blocked && !strings.Contains(warning, "--:confirm-deletes") {
+				t.Errorf("warning should mention --:confirm-deletes: %q", warning)
+			}
+		})
+	}
+}
+
+func TestParseIssueCount(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+		ok    bool
+	}{
+		{"42\n", 42, true},
+		{`{"count": 7}`, 7, true},
+		{`{"total": 7}`, 0, false},
+		{"not a number", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseIssueCount(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseIssueCount(%q) = %d, %v; want %d, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseBoolFlag(t *testing.T) {
+	args, present := parseBoolFlag([]string{"close", "bd-1", "--:confirm-deletes"}, "--:confirm-deletes")
+	if !present {
+		t.Error("expected flag to be detected")
+	}
+	if strings.Join(args, " ") != "close bd-1" {
+		t.Errorf("unexpected cleaned args: %v", args)
+	}
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Capability tags ("python", "frontend", "infra") are kept in .beadhub and
+// sent with every presence refresh. Team status shows them next to each
+// agent, and :find-owner matches them against the path being asked about.
+
+var tagsJSON bool
+
+var tagsCmd = &cobra.Command{
+	Use:   ":tags",
+	Short: "Advertise what this workspace knows (languages, subsystems)",
+	Long: `Show or set the capability tags this workspace advertises to the team.
+
+Tags are short lowercase words such as languages or subsystems. Other agents
+see them in team status, and bdh :find-owner uses them to suggest whom to ask
+about a file.
+
+Examples:
+  bdh :tags                     # Show your tags
+  bdh :tags set python infra    # Replace your tags
+  bdh :tags clear`,
+	Args: cobra.NoArgs,
+	RunE: runTagsShow,
+}
+
+var tagsSetCmd = &cobra.Command{
+	Use:   "set <tag>...",
+	Short: "Replace this workspace's tags",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runTagsSet,
+}
+
+var tagsClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all of this workspace's tags",
+	Args:  cobra.NoArgs,
+	RunE:  runTagsClear,
+}
+
+func init() {
+	tagsCmd.PersistentFlags().BoolVar(&tagsJSON, "json", false, "Output as JSON")
+
+	tagsCmd.AddCommand(tagsSetCmd)
+	tagsCmd.AddCommand(tagsClearCmd)
+}
+
+// TagsResult is the output of :tags commands.
+type TagsResult struct {
+	Alias   string   `json:"alias"`
+	Tags    []string `json:"tags"`
+	Warning string   `json:"warning,omitempty"`
+}
+
+func runTagsShow(cmd *cobra.Command, args []string) error {
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatTagsOutput(&TagsResult{Alias: cfg.Alias, Tags: cfg.Tags}, tagsJSON))
+	return nil
+}
+
+func runTagsSet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	var words []string
+	for _, arg := range args {
+		words = append(words, strings.Split(arg, ",")...)
+	}
+	result, err := setTags(ctx, cfg, words)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatTagsOutput(result, tagsJSON))
+	return nil
+}
+
+func runTagsClear(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	result, err := setTags(ctx, cfg, nil)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatTagsOutput(result, tagsJSON))
+	return nil
+}
+
+// setTags saves the tags in .beadhub and pushes them with a presence
+// refresh. A failed push is only a warning: the next command retries it.
+func setTags(ctx context.Context, cfg *config.Config, tags []string) (*TagsResult, error) {
+	tags = config.NormalizeTags(tags)
+	if err := config.ValidateTags(tags); err != nil {
+		return nil, err
+	}
+	cfg.Tags = tags
+	if err := cfg.Save(); err != nil {
+		return nil, err
+	}
+	return &TagsResult{
+		Alias:   cfg.Alias,
+		Tags:    tags,
+		Warning: pushPresence(ctx, cfg, presenceRequest(ctx, cfg, time.Now())),
+	}, nil
+}
+
+// teamMemberLabel is the name shown in team status, with its tags.
+func teamMemberLabel(ws client.Workspace) string {
+	if len(ws.Tags) == 0 {
+		return displayAlias(ws.Alias)
+	}
+	return fmt.Sprintf("%s [%s]", displayAlias(ws.Alias), strings.Join(ws.Tags, ", "))
+}
+
+func formatTagsOutput(result *TagsResult, asJSON bool) string {
+	if asJSON {
+		if result.Tags == nil {
+			result.Tags = []string{}
+		}
+		return marshalJSONOrFallback(result)
+	}
+	var sb strings.Builder
+	if len(result.Tags) == 0 {
+		sb.WriteString(fmt.Sprintf("%s has no tags. Add some with: bdh :tags set <tag>...\n", result.Alias))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", result.Alias, strings.Join(result.Tags, ", ")))
+	}
+	if result.Warning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", result.Warning))
+	}
+	return sb.String()
+}
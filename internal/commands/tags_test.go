@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestSetTags_SavesAndPushesPresence(t *testing.T) {
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	t.Chdir(t.TempDir())
+
+	var pushed []client.RefreshPresenceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.RefreshPresenceRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		pushed = append(pushed, req)
+		json.NewEncoder(w).Encode(map[string]any{})
+	}))
+	defer server.Close()
+	cfg := &config.Config{BeadhubURL: server.URL, WorkspaceID: "ws-1", Alias: "maria-be"}
+
+	result, err := setTags(context.Background(), cfg, []string{"Python", "infra", "python"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(result.Tags, ",") != "python,infra" || result.Warning != "" {
+		t.Errorf("result = %+v", result)
+	}
+	if len(pushed) != 1 || strings.Join(pushed[0].Tags, ",") != "python,infra" {
+		t.Fatalf("pushed = %+v", pushed)
+	}
+	saved, err := config.Load()
+	if err != nil || strings.Join(saved.Tags, ",") != "python,infra" {
+		t.Fatalf("saved tags = %v, err = %v", saved.Tags, err)
+	}
+
+	// Clearing sends an empty list, not a missing field
+	if _, err := setTags(context.Background(), cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(pushed) != 2 || pushed[1].Tags == nil || len(pushed[1].Tags) != 0 {
+		t.Errorf("clear pushed = %+v", pushed[1])
+	}
+
+	if _, err := setTags(context.Background(), cfg, []string{"not ok"}); err == nil {
+		t.Error("invalid tag should fail")
+	}
+}
+
+func TestFormatReadyTeamStatus_ShowsTags(t *testing.T) {
+	out := formatPassthroughOutput(&PassthroughResult{
+		IsReadyCommand: true,
+		TeamStatus: []client.Workspace{
+			{Alias: "alice", Tags: []string{"python", "infra"}, Claims: []client.Claim{{BeadID: "bd-1"}}},
+		},
+	})
+	if !strings.Contains(out, "alice [python, infra] — working on bd-1") {
+		t.Errorf("output:\n%s", out)
+	}
+}
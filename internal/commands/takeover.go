@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// --:request-takeover asks the server to hand over reservations that block
+// auto-reserve when their holder has not been seen for a while. The server
+// tells the holder and gives them takeoverGracePeriod to refresh; if they
+// don't, the reservation becomes ours instead of waiting out its TTL.
+// Holders seen recently are left alone: chatting is the better option then.
+
+const (
+	takeoverIdleThreshold = 2 * time.Hour
+	takeoverGracePeriod   = 15 * time.Minute
+
+	// takeoverNotRequested is the status of a takeover bdh did not send.
+	takeoverNotRequested = "not_requested"
+)
+
+// TakeoverOutcome is the result of one takeover request.
+type TakeoverOutcome struct {
+	ResourceKey string `json:"resource_key"`
+	HeldBy      string `json:"held_by"`
+	Status      string `json:"status"`
+	GrantAt     string `json:"grant_at,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// requestTakeovers asks to take over the reservations behind conflicts,
+// one request per reservation, skipping holders seen within
+// takeoverIdleThreshold.
+func requestTakeovers(ctx context.Context, cfg *config.Config, c BeadHubAPI, conflicts []ReservationConflict, now time.Time) []TakeoverOutcome {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	lastSeen := make(map[string]string)
+	seen := make(map[string]bool)
+	var outcomes []TakeoverOutcome
+	for _, conflict := range conflicts {
+		key := conflict.ResourceKey
+		if conflict.HeldKey != "" {
+			key = conflict.HeldKey
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		outcome := TakeoverOutcome{ResourceKey: key, HeldBy: conflict.HeldBy}
+
+		if !serverSupports(ctx, cfg, featureReservationTakeover) {
+			outcome.Status = takeoverNotRequested
+			outcome.Reason = "the server does not support takeover requests"
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+		if _, ok := lastSeen[conflict.HeldBy]; !ok {
+			lastSeen[conflict.HeldBy] = holderLastSeen(ctx, c, conflict.HeldBy)
+		}
+		if ts, ok := parseTimeBestEffort(lastSeen[conflict.HeldBy]); ok && now.Sub(ts) < takeoverIdleThreshold {
+			outcome.Status = takeoverNotRequested
+			outcome.Reason = fmt.Sprintf("%s was seen %s; ask them instead", displayAlias(conflict.HeldBy), formatTimeAgoAt(lastSeen[conflict.HeldBy], now))
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.RequestTakeover(ctx, &client.TakeoverRequest{
+			ResourceKey:  key,
+			WorkspaceID:  cfg.WorkspaceID,
+			Alias:        cfg.Alias,
+			GraceSeconds: int(takeoverGracePeriod.Seconds()),
+		})
+		cancel()
+		if err != nil {
+			outcome.Status = takeoverNotRequested
+			var clientErr *client.Error
+			if errors.As(err, &clientErr) {
+				outcome.Reason = fmt.Sprintf("BeadHub error (%d): %s", clientErr.StatusCode, apiErrorDetail(clientErr.Body))
+			} else {
+				outcome.Reason = err.Error()
+			}
+			outcomes = append(outcomes, outcome)
+			continue
+		}
+		outcome.Status = resp.Status
+		outcome.GrantAt = resp.GrantAt
+		outcome.Reason = resp.Reason
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// holderLastSeen returns when alias was last seen, or "" when unknown; the
+// server then decides whether the holder is idle.
+func holderLastSeen(ctx context.Context, c BeadHubAPI, alias string) string {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	resp, err := c.Workspaces(ctx, &client.WorkspacesRequest{Alias: alias, Limit: 1})
+	if err != nil || len(resp.Workspaces) == 0 {
+		return ""
+	}
+	return resp.Workspaces[0].LastSeen
+}
+
+// formatTakeoverOutcomes renders takeover results under the conflict list.
+func formatTakeoverOutcomes(outcomes []TakeoverOutcome, now time.Time) string {
+	if len(outcomes) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\nTakeover requests:\n")
+	for _, o := range outcomes {
+		holder := displayAlias(o.HeldBy)
+		switch o.Status {
+		case client.TakeoverGranted:
+			sb.WriteString(fmt.Sprintf("- `%s` — granted: the reservation is yours\n", o.ResourceKey))
+		case client.TakeoverQueued:
+			line := fmt.Sprintf("- `%s` — queued: %s was told", o.ResourceKey, holder)
+			if o.GrantAt != "" {
+				line += fmt.Sprintf(" and has %s to refresh it, then it is yours", formatDuration(ttlRemainingSeconds(o.GrantAt, now)))
+			}
+			sb.WriteString(line + "\n")
+		case client.TakeoverRefused:
+			sb.WriteString(fmt.Sprintf("- `%s` — refused: %s\n", o.ResourceKey, o.Reason))
+		default:
+			sb.WriteString(fmt.Sprintf("- `%s` — not requested: %s\n", o.ResourceKey, o.Reason))
+		}
+	}
+	return sb.String()
+}
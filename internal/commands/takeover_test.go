@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestRequestTakeovers(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/capabilities":
+			json.NewEncoder(w).Encode(map[string]any{"features": map[string]bool{featureReservationTakeover: true}})
+		case "/v1/workspaces":
+			lastSeen := now.Add(-5 * time.Hour)
+			if r.URL.Query().Get("alias") == "bob" {
+				lastSeen = now.Add(-10 * time.Minute)
+			}
+			json.NewEncoder(w).Encode(client.WorkspacesResponse{Workspaces: []client.Workspace{
+				{Alias: r.URL.Query().Get("alias"), LastSeen: lastSeen.Format(time.RFC3339)},
+			}})
+		case "/v1/reservations/takeover":
+			requests.Add(1)
+			var req client.TakeoverRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.ResourceKey != "src/api/" || req.Alias != "me" || req.GraceSeconds != 900 {
+				t.Errorf("unexpected request %+v", req)
+			}
+			json.NewEncoder(w).Encode(client.TakeoverResponse{
+				ResourceKey: req.ResourceKey, HolderAlias: "alice", Status: client.TakeoverQueued,
+				GrantAt: now.Add(takeoverGracePeriod).Format(time.RFC3339), HolderNotified: true,
+			})
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BeadhubURL: server.URL, Alias: "me", WorkspaceID: "ws-me"}
+	conflicts := []ReservationConflict{
+		{ResourceKey: "src/api/a.go", HeldBy: "alice", HeldKey: "src/api/"},
+		{ResourceKey: "src/api/b.go", HeldBy: "alice", HeldKey: "src/api/"},
+		{ResourceKey: "web/app.ts", HeldBy: "bob"},
+	}
+	outcomes := requestTakeovers(context.Background(), cfg, client.New(server.URL), conflicts, now)
+	if requests.Load() != 1 || len(outcomes) != 2 {
+		t.Fatalf("requests = %d, outcomes = %+v", requests.Load(), outcomes)
+	}
+	if outcomes[0].Status != client.TakeoverQueued || outcomes[1].Status != takeoverNotRequested {
+		t.Errorf("outcomes = %+v", outcomes)
+	}
+
+	out := formatTakeoverOutcomes(outcomes, now)
+	for _, want := range []string{
+		"`src/api/` — queued: alice was told and has 15m to refresh it",
+		"`web/app.ts` — not requested: bob was seen 10m ago; ask them instead",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %q", want, out)
+		}
+	}
+}
+
+func TestFormatReservedFiles_SuggestsTakeover(t *testing.T) {
+	result := &PassthroughResult{AutoReserveConflicts: []ReservationConflict{{ResourceKey: "a.go", HeldBy: "alice", RetryAfterSeconds: 3600}}}
+	if out := formatReservedFiles(result); !strings.Contains(out, "--:request-takeover") {
+		t.Errorf("expected a takeover hint, got %q", out)
+	}
+	result.TakeoverRequested = true
+	result.Takeovers = []TakeoverOutcome{{ResourceKey: "a.go", HeldBy: "alice", Status: client.TakeoverGranted}}
+	out := formatReservedFiles(result)
+	if strings.Contains(out, "--:request-takeover") || !strings.Contains(out, "`a.go` — granted") {
+		t.Errorf("after a request: %q", out)
+	}
+}
@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+var (
+	teamJSON            bool
+	teamInviteRole      string
+	teamInviteAlias     string
+	teamInviteEmail     string
+	teamInviteProvision bool
+)
+
+var teamCmd = &cobra.Command{
+	Use:   ":team",
+	Short: "Bring new teammates into this project",
+	Long: `Helpers for growing the team working on this project.
+
+Examples:
+  bdh :team invite --role reviewer`,
+}
+
+var teamInviteCmd = &cobra.Command{
+	Use:   "invite",
+	Short: "Print an onboarding bundle for a new teammate or agent",
+	Long: `Print everything a new teammate (human or agent) needs to join this
+project: how to get the repo and bdh, and the exact :init command with this
+server URL and project slug filled in.
+
+Where the server supports invites, an invite token is created and added to
+the :init command. With --provision the workspace is created now and the
+token claims it; with --email the server also mails the invite. Servers
+without invite support still get the printed bundle.
+
+Examples:
+  bdh :team invite
+  bdh :team invite --role reviewer --alias carol-reviewer
+  bdh :team invite --email carol@example.com --provision
+  bdh :team invite --json`,
+	Args: cobra.NoArgs,
+	RunE: runTeamInvite,
+}
+
+func init() {
+	teamInviteCmd.Flags().BoolVar(&teamJSON, "json", false, "Output as JSON")
+	teamInviteCmd.Flags().StringVar(&teamInviteRole, "role", "", "Role for the new workspace (e.g., reviewer)")
+	teamInviteCmd.Flags().StringVar(&teamInviteAlias, "alias", "", "Alias to reserve for the new workspace")
+	teamInviteCmd.Flags().StringVar(&teamInviteEmail, "email", "", "Email the invite (server support required)")
+	teamInviteCmd.Flags().BoolVar(&teamInviteProvision, "provision", false, "Create the workspace now (server support required)")
+
+	teamCmd.AddCommand(teamInviteCmd)
+}
+
+// TeamInviteOptions are the inputs to an invite.
+type TeamInviteOptions struct {
+	Role      string
+	Alias     string
+	Email     string
+	Provision bool
+}
+
+// TeamInvite is the onboarding bundle for a new teammate.
+type TeamInvite struct {
+	BeadhubURL  string `json:"beadhub_url"`
+	ProjectSlug string `json:"project_slug"`
+	RepoOrigin  string `json:"repo_origin,omitempty"`
+	InvitedBy   string `json:"invited_by"`
+	Role        string `json:"role,omitempty"`
+	Alias       string `json:"alias,omitempty"`
+
+	Token       string `json:"token,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	Emailed     string `json:"emailed,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+
+	CloneCommand string `json:"clone_command,omitempty"`
+	InitCommand  string `json:"init_command"`
+	// Warning is set when the server could not create the invite; the
+	// bundle is still usable without a token.
+	Warning string `json:"warning,omitempty"`
+}
+
+func runTeamInvite(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no .beadhub file found - run 'bdh :init' first")
+		}
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid .beadhub config: %w", err)
+	}
+
+	opts := TeamInviteOptions{
+		Role:      strings.TrimSpace(teamInviteRole),
+		Alias:     strings.TrimSpace(teamInviteAlias),
+		Email:     strings.TrimSpace(teamInviteEmail),
+		Provision: teamInviteProvision,
+	}
+	invite, err := createTeamInvite(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), opts)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatTeamInviteOutput(invite, teamJSON))
+	return nil
+}
+
+// createTeamInvite builds the bundle, asking the server for a token when it
+// supports invites. Only explicit server-side requests (--email,
+// --provision) fail when the server can't honour them.
+func createTeamInvite(ctx context.Context, cfg *config.Config, c BeadHubAPI, opts TeamInviteOptions) (*TeamInvite, error) {
+	if opts.Alias != "" && !config.IsValidAlias(opts.Alias) {
+		return nil, fmt.Errorf("invalid alias %q", opts.Alias)
+	}
+	if opts.Role != "" && !config.IsValidRole(opts.Role) {
+		return nil, fmt.Errorf("invalid role %q", opts.Role)
+	}
+	serverSide := opts.Email != "" || opts.Provision
+
+	invite := &TeamInvite{
+		BeadhubURL:  cfg.BeadhubURL,
+		ProjectSlug: cfg.ProjectSlug,
+		RepoOrigin:  cfg.RepoOrigin,
+		InvitedBy:   cfg.Alias,
+		Role:        opts.Role,
+		Alias:       opts.Alias,
+	}
+
+	if !serverSupports(ctx, cfg, featureInvites) {
+		if serverSide {
+			return nil, requireServerFeature(ctx, cfg, featureInvites, "invites")
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		resp, err := c.CreateInvite(ctx, &client.CreateInviteRequest{
+			ProjectSlug: cfg.ProjectSlug,
+			RepoOrigin:  cfg.RepoOrigin,
+			InvitedBy:   cfg.Alias,
+			Role:        opts.Role,
+			Alias:       opts.Alias,
+			Email:       opts.Email,
+			Provision:   opts.Provision,
+		})
+		cancel()
+		if err != nil {
+			var clientErr *client.Error
+			notSupported := errors.As(err, &clientErr) && clientErr.StatusCode == 404
+			switch {
+			case serverSide && notSupported:
+				return nil, fmt.Errorf("this BeadHub server does not support invites - drop --email/--provision to print the bundle only")
+			case serverSide && clientErr != nil:
+				return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+			case serverSide:
+				return nil, fmt.Errorf("creating invite: %w", err)
+			case !notSupported:
+				invite.Warning = fmt.Sprintf("could not create an invite token (%v) - the bundle works without one", err)
+			}
+		} else {
+			invite.Token = resp.Token
+			invite.ExpiresAt = resp.ExpiresAt
+			invite.WorkspaceID = resp.WorkspaceID
+			if resp.Alias != "" {
+				invite.Alias = resp.Alias
+			}
+			if resp.Emailed {
+				invite.Emailed = opts.Email
+			}
+		}
+	}
+
+	if invite.RepoOrigin != "" {
+		invite.CloneCommand = "git clone " + shellQuoteArg(invite.RepoOrigin)
+	}
+	invite.InitCommand = teamInviteInitCommand(invite)
+	return invite, nil
+}
+
+// teamInviteInitCommand returns the :init command line for the invitee.
+func teamInviteInitCommand(invite *TeamInvite) string {
+	parts := []string{"bdh", ":init",
+		"--beadhub-url", shellQuoteArg(invite.BeadhubURL),
+		"--project", shellQuoteArg(invite.ProjectSlug)}
+	if invite.Role != "" {
+		parts = append(parts, "--role", shellQuoteArg(invite.Role))
+	}
+	if invite.Alias != "" {
+		parts = append(parts, "--alias", shellQuoteArg(invite.Alias))
+	}
+	if invite.Token != "" {
+		parts = append(parts, "--invite-token", shellQuoteArg(invite.Token))
+	}
+	return strings.Join(parts, " ")
+}
+
+var shellSafeArg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuoteArg single-quotes s unless it is safe to paste into a shell as is.
+func shellQuoteArg(s string) string {
+	if shellSafeArg.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func formatTeamInviteOutput(invite *TeamInvite, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(invite)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("INVITE: join project %s on %s (from %s)\n\n", invite.ProjectSlug, invite.BeadhubURL, invite.InvitedBy))
+
+	step := 1
+	if invite.CloneCommand != "" {
+		sb.WriteString(fmt.Sprintf("%d. Get the repo:\n     %s\n", step, invite.CloneCommand))
+		step++
+	}
+	sb.WriteString(fmt.Sprintf("%d. Install bdh (and bd):\n     curl -fsSL https://raw.githubusercontent.com/beadhub/bdh/main/install.sh | bash\n", step))
+	step++
+	sb.WriteString(fmt.Sprintf("%d. From the repo root, register the workspace:\n     %s\n", step, invite.InitCommand))
+	step++
+	sb.WriteString(fmt.Sprintf("%d. Learn the workflow:\n     bdh :onboard\n", step))
+
+	if invite.Token != "" {
+		sb.WriteString("\n")
+		if invite.ExpiresAt != "" {
+			sb.WriteString(fmt.Sprintf("The invite token expires %s.\n", invite.ExpiresAt))
+		}
+		if invite.WorkspaceID != "" {
+			sb.WriteString(fmt.Sprintf("Workspace %s is provisioned and waiting for the token.\n", invite.WorkspaceID))
+		}
+		if invite.Emailed != "" {
+			sb.WriteString(fmt.Sprintf("Invite emailed to %s.\n", invite.Emailed))
+		}
+	}
+	if invite.Warning != "" {
+		sb.WriteString("\nWarning: " + invite.Warning + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/i18n"
+)
+
+// On large teams the ready team status cannot list everyone. When it does
+// not fit and the server supports it, bdh asks again for only the members
+// relevant to this agent: those sharing its focus apex, or holding
+// reservations in the directory it is changing. The server counts the rest,
+// and ready shows them as one summary line.
+
+// teamArea returns the top-level directory most of this agent's modified
+// files are in, or "" when there is none.
+func teamArea(ctx context.Context, cfg *config.Config) string {
+	counts := make(map[string]int)
+	for _, path := range modifiedPaths(ctx, cfg) {
+		if dir, _, ok := strings.Cut(path, "/"); ok && dir != "" {
+			counts[dir]++
+		}
+	}
+	area := ""
+	for _, dir := range sortedKeys(counts) {
+		if counts[dir] > counts[area] {
+			area = dir
+		}
+	}
+	return area
+}
+
+// fetchTeamShard lists the recently active team members relevant to
+// focusApexID or area, excluding this workspace, and the server's summary of
+// the others. ok is false when the team cannot be sharded.
+func fetchTeamShard(ctx context.Context, cfg *config.Config, c BeadHubAPI, focusApexID, area string, limit int) (team []client.Workspace, others *client.TeamOthers, ok bool) {
+	if focusApexID == "" && area == "" {
+		return nil, nil, false
+	}
+	if !serverSupports(ctx, cfg, featureTeamShards) {
+		return nil, nil, false
+	}
+	includeClaims := true
+	includePresence := true
+	resp, err := c.TeamWorkspaces(ctx, &client.TeamWorkspacesRequest{
+		IncludeClaims:   &includeClaims,
+		IncludePresence: &includePresence,
+		FocusApexID:     focusApexID,
+		Area:            area,
+		Limit:           limit,
+	})
+	if err != nil || resp.Others == nil {
+		return nil, nil, false
+	}
+	threshold := teamActivityThreshold()
+	for _, ws := range resp.Workspaces {
+		if ws.WorkspaceID == cfg.WorkspaceID || (ws.FocusApexID == "" && len(ws.Claims) == 0) {
+			continue
+		}
+		if isWorkspaceRecentlyActive(ws, threshold) {
+			team = append(team, ws)
+		}
+	}
+	return team, resp.Others, true
+}
+
+// formatTeamOthers renders the summary of the team members left out of a
+// sharded team status.
+func formatTeamOthers(others *client.TeamOthers) string {
+	line := i18n.T("ready.team.others", others.Count, others.Epics)
+	if len(others.ByRole) == 0 {
+		return line
+	}
+	roles := sortedKeys(others.ByRole)
+	sort.SliceStable(roles, func(i, j int) bool {
+		if others.ByRole[roles[i]] != others.ByRole[roles[j]] {
+			return others.ByRole[roles[i]] > others.ByRole[roles[j]]
+		}
+		return roles[j] == "" && roles[i] != ""
+	})
+	parts := make([]string, 0, len(roles))
+	for _, role := range roles {
+		name := role
+		if name == "" {
+			name = i18n.T("ready.team.no_role")
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", others.ByRole[role], name))
+	}
+	return line + ": " + strings.Join(parts, ", ")
+}
+
+// shardTeamStatus replaces result's team status with the members relevant
+// to this agent when the team did not fit. Best-effort.
+func shardTeamStatus(ctx context.Context, cfg *config.Config, c BeadHubAPI, result *PassthroughResult) {
+	if !result.TeamStatusMore {
+		return
+	}
+	area := teamArea(ctx, cfg)
+	team, others, ok := fetchTeamShard(ctx, cfg, c, strings.TrimSpace(result.MyFocusApexID), area, result.TeamStatusLimit+readyTeamQueryOverflow)
+	if !ok {
+		return
+	}
+	result.TeamStatusMore = len(team) > result.TeamStatusLimit
+	if result.TeamStatusMore {
+		team = team[:result.TeamStatusLimit]
+	}
+	result.TeamStatus = team
+	result.TeamOthers = others
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/clienttest"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestFormatTeamOthers(t *testing.T) {
+	got := formatTeamOthers(&client.TeamOthers{Count: 61, Epics: 9, ByRole: map[string]int{"frontend": 20, "backend": 30, "": 11}})
+	if want := "Others (61 agents across 9 epics): 30 backend, 20 frontend, 11 without a role"; got != want {
+		t.Errorf("formatTeamOthers = %q, want %q", got, want)
+	}
+	if got := formatTeamOthers(&client.TeamOthers{Count: 2, Epics: 1}); got != "Others (2 agents across 1 epics)" {
+		t.Errorf("formatTeamOthers without roles = %q", got)
+	}
+}
+
+func TestShardTeamStatus_FakeServer(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	seen := time.Now().UTC().Format(time.RFC3339)
+	fake := clienttest.New()
+	fake.EnableFeatures(featureTeamShards)
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-me", Alias: "me", FocusApexID: "bd-e1", LastSeen: seen})
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-b", Alias: "bob", FocusApexID: "bd-e1", LastSeen: seen})
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-c", Alias: "carol", Role: "backend", FocusApexID: "bd-e2", LastSeen: seen})
+	fake.AddWorkspace(client.Workspace{WorkspaceID: "ws-d", Alias: "dave", FocusApexID: "bd-e3", LastSeen: seen})
+	ctx := withFakeBeadHub(fake)
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "me", WorkspaceID: "ws-me"}
+	result := &PassthroughResult{
+		IsReadyCommand:  true,
+		MyFocusApexID:   "bd-e1",
+		TeamStatusLimit: 1,
+		TeamStatusMore:  true,
+	}
+	shardTeamStatus(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), result)
+	if len(result.TeamStatus) != 1 || result.TeamStatus[0].Alias != "bob" || result.TeamStatusMore {
+		t.Fatalf("expected only bob, got %+v more=%v", result.TeamStatus, result.TeamStatusMore)
+	}
+	if o := result.TeamOthers; o == nil || o.Count != 2 || o.Epics != 2 {
+		t.Fatalf("unexpected others: %+v", o)
+	}
+	if calls := fake.Calls("TeamWorkspaces"); len(calls) != 1 || calls[0].Args[0].(*client.TeamWorkspacesRequest).FocusApexID != "bd-e1" {
+		t.Errorf("expected one sharded team query, got %+v", calls)
+	}
+
+	out := formatPassthroughOutput(result)
+	for _, want := range []string{
+		"Relevant to your focus (1):\n- bob — focused on bd-e1",
+		"Others (2 agents across 2 epics): 1 backend, 1 without a role",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestShardTeamStatus_UnsupportedKeepsTeam(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+	resetCapabilitiesMemo()
+
+	fake := clienttest.New()
+	fake.EnableFeatures(featureTeamQuery)
+	ctx := withFakeBeadHub(fake)
+
+	cfg := &config.Config{BeadhubURL: "http://beadhub.invalid", Alias: "me", WorkspaceID: "ws-me"}
+	team := []client.Workspace{{Alias: "bob"}}
+	result := &PassthroughResult{MyFocusApexID: "bd-e1", TeamStatus: team, TeamStatusLimit: 1, TeamStatusMore: true}
+	shardTeamStatus(ctx, cfg, newBeadHubClient(ctx, cfg.BeadhubURL), result)
+	if result.TeamOthers != nil || len(result.TeamStatus) != 1 || !result.TeamStatusMore {
+		t.Errorf("team status should be unchanged, got %+v", result)
+	}
+	if calls := fake.Calls("TeamWorkspaces"); len(calls) != 0 {
+		t.Errorf("unexpected team queries: %+v", calls)
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestCreateTeamInvite(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(resetCapabilitiesMemo)
+
+	invites := true
+	var got client.CreateInviteRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/invites" && invites:
+			json.NewDecoder(r.Body).Decode(&got)
+			w.Write([]byte(`{"token":"inv_abc","expires_at":"2026-10-24T00:00:00Z","emailed":true,"workspace_id":"ws-new","alias":"carol-reviewer"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	cfg := &config.Config{
+		BeadhubURL:  server.URL,
+		ProjectSlug: "demo",
+		RepoOrigin:  "git@github.com:acme/demo.git",
+		Alias:       "alice",
+	}
+	c := client.New(server.URL)
+
+	resetCapabilitiesMemo()
+	invite, err := createTeamInvite(context.Background(), cfg, c, TeamInviteOptions{Role: "reviewer", Email: "carol@example.com", Provision: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ProjectSlug != "demo" || got.InvitedBy != "alice" || got.Role != "reviewer" || !got.Provision {
+		t.Errorf("request = %+v", got)
+	}
+	wantInit := "bdh :init --beadhub-url " + server.URL + " --project demo --role reviewer --alias carol-reviewer --invite-token inv_abc"
+	if invite.InitCommand != wantInit {
+		t.Errorf("init command = %q, want %q", invite.InitCommand, wantInit)
+	}
+	out := formatTeamInviteOutput(invite, false)
+	for _, want := range []string{"git clone git@github.com:acme/demo.git", wantInit, "Workspace ws-new is provisioned", "emailed to carol@example.com"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	// A server without invites still gets the bundle, without a token.
+	invites = false
+	invite, err = createTeamInvite(context.Background(), cfg, c, TeamInviteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invite.Token != "" || invite.Warning != "" || strings.Contains(invite.InitCommand, "--invite-token") {
+		t.Errorf("invite = %+v", invite)
+	}
+	if _, err := createTeamInvite(context.Background(), cfg, c, TeamInviteOptions{Provision: true}); err == nil {
+		t.Error("expected --provision to fail without server support")
+	}
+}
+
+func TestShellQuoteArg(t *testing.T) {
+	if got := shellQuoteArg("https://beadhub.example.com:8000"); got != "https://beadhub.example.com:8000" {
+		t.Errorf("safe arg quoted: %q", got)
+	}
+	if got := shellQuoteArg("it's here"); got != `'it'\''s here'` {
+		t.Errorf("quoted = %q", got)
+	}
+}
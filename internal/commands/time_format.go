@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// output.time_format picks one style for every timestamp bdh shows: ready,
+// status, locks, inbox and chat. When it is unset each output keeps its
+// built-in style, which is relative almost everywhere.
+
+// currentTimeFormat returns the configured style, or "" when unset. The
+// config is read once per process; tests replace this.
+var currentTimeFormat = sync.OnceValue(func() string {
+	cfg, err := config.Load()
+	if err != nil {
+		return ""
+	}
+	return cfg.TimeFormat()
+})
+
+// formatTimestamp renders a server timestamp in the configured style
+// (relative by default). Unparseable values are returned as-is.
+func formatTimestamp(ts string) string {
+	style := currentTimeFormat()
+	if style == "" {
+		style = config.TimeFormatRelative
+	}
+	return formatTimestampAs(ts, style, serverNow())
+}
+
+// formatTimestampAs renders ts in style, relative to now.
+func formatTimestampAs(ts, style string, now time.Time) string {
+	t, ok := parseTimeBestEffort(ts)
+	if !ok {
+		return ts
+	}
+	switch style {
+	case config.TimeFormatLocal:
+		return formatLocalTime(t.In(now.Location()), now, timeLocale())
+	case config.TimeFormatUTC:
+		return t.UTC().Format("2006-01-02 15:04 UTC")
+	case config.TimeFormatISO:
+		return t.UTC().Format(time.RFC3339)
+	default:
+		return formatTimeAgoAt(ts, now)
+	}
+}
+
+// formatTranscriptTime renders a chat message time for transcripts, which
+// show the clock ("15:04:05") unless a style is configured. Returns "" for
+// unparseable timestamps.
+func formatTranscriptTime(ts string) string {
+	t, ok := parseTimeBestEffort(ts)
+	if !ok {
+		return ""
+	}
+	if currentTimeFormat() == "" {
+		return t.Format("15:04:05")
+	}
+	return formatTimestamp(ts)
+}
+
+// formatExpiry renders when a reservation expires: "expires in 4m" by
+// default, or the expiry time itself in an absolute style.
+func formatExpiry(expiresAt string, now time.Time) string {
+	switch style := currentTimeFormat(); style {
+	case config.TimeFormatLocal, config.TimeFormatUTC, config.TimeFormatISO:
+		if _, ok := parseTimeBestEffort(expiresAt); ok {
+			return "expires " + formatTimestampAs(expiresAt, style, now)
+		}
+	}
+	return "expires in " + formatDuration(ttlRemainingSeconds(expiresAt, now))
+}
+
+// formatLocalTime renders t (already in the local zone) the way the locale
+// writes times: 12-hour clock and month-first dates for en_US and similar
+// locales, 24-hour and day-first elsewhere. The date is omitted for today
+// and the year for this year.
+func formatLocalTime(t, now time.Time, locale string) string {
+	twelveHour := uses12HourClock(locale)
+	clock := "15:04 MST"
+	if twelveHour {
+		clock = "3:04 PM MST"
+	}
+	switch {
+	case t.Year() == now.Year() && t.YearDay() == now.YearDay():
+		return t.Format(clock)
+	case twelveHour && t.Year() == now.Year():
+		return t.Format("Jan 2 " + clock)
+	case twelveHour:
+		return t.Format("Jan 2 2006 " + clock)
+	case t.Year() == now.Year():
+		return t.Format("2 Jan " + clock)
+	default:
+		return t.Format("2 Jan 2006 " + clock)
+	}
+}
+
+// timeLocale returns the locale used for time formatting, following the
+// usual precedence of LC_ALL, LC_TIME, LANG.
+func timeLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// twelveHourRegions are the locale regions that conventionally use a 12-hour
+// clock.
+var twelveHourRegions = map[string]bool{
+	"US": true, "CA": true, "AU": true, "NZ": true, "PH": true, "IN": true,
+}
+
+// uses12HourClock reports whether locale ("en_US.UTF-8") uses a 12-hour
+// clock. Unknown locales and "C"/"POSIX" use 24 hours.
+func uses12HourClock(locale string) bool {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	lang, region, ok := strings.Cut(locale, "_")
+	if !ok {
+		return false
+	}
+	// French-speaking Canada uses a 24-hour clock.
+	if region == "CA" && lang == "fr" {
+		return false
+	}
+	return twelveHourRegions[region]
+}
+
+// formatMessageTime renders a mail's send time when a time style is
+// configured; mail output shows no times otherwise. Returns "" when unset.
+func formatMessageTime(createdAt string) string {
+	if currentTimeFormat() == "" || createdAt == "" {
+		return ""
+	}
+	return formatTimestamp(createdAt)
+}
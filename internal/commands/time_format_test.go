@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func withTimeFormat(t *testing.T, style string) {
+	t.Helper()
+	orig := currentTimeFormat
+	currentTimeFormat = func() string { return style }
+	t.Cleanup(func() { currentTimeFormat = orig })
+}
+
+func TestFormatTimestampAs(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	ts := "2025-06-15T10:30:00+02:00"
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{config.TimeFormatRelative, "3h ago"},
+		{config.TimeFormatUTC, "2025-06-15 08:30 UTC"},
+		{config.TimeFormatISO, "2025-06-15T08:30:00Z"},
+		{"", "3h ago"},
+	}
+	for _, tt := range tests {
+		if got := formatTimestampAs(ts, tt.style, now); got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.style, got, tt.want)
+		}
+	}
+	if got := formatTimestampAs("yesterday", config.TimeFormatUTC, now); got != "yesterday" {
+		t.Errorf("unparseable: got %q", got)
+	}
+}
+
+func TestFormatLocalTime_Locale(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at     time.Time
+		locale string
+		want   string
+	}{
+		{time.Date(2025, 6, 15, 14, 5, 0, 0, time.UTC), "en_US.UTF-8", "2:05 PM UTC"},
+		{time.Date(2025, 6, 15, 14, 5, 0, 0, time.UTC), "de_DE.UTF-8", "14:05 UTC"},
+		{time.Date(2025, 6, 3, 14, 5, 0, 0, time.UTC), "en_US", "Jun 3 2:05 PM UTC"},
+		{time.Date(2025, 6, 3, 14, 5, 0, 0, time.UTC), "fr_CA.UTF-8", "3 Jun 14:05 UTC"},
+		{time.Date(2024, 6, 3, 14, 5, 0, 0, time.UTC), "C", "3 Jun 2024 14:05 UTC"},
+	}
+	for _, tt := range tests {
+		if got := formatLocalTime(tt.at, now, tt.locale); got != tt.want {
+			t.Errorf("%s %s: got %q, want %q", tt.locale, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTranscriptTime(t *testing.T) {
+	withTimeFormat(t, "")
+	if got := formatTranscriptTime("2025-06-15T10:30:00Z"); got != "10:30:00" {
+		t.Errorf("unset: got %q", got)
+	}
+	withTimeFormat(t, config.TimeFormatISO)
+	if got := formatTranscriptTime("2025-06-15T10:30:00Z"); got != "2025-06-15T10:30:00Z" {
+		t.Errorf("iso: got %q", got)
+	}
+	if got := formatTranscriptTime(""); got != "" {
+		t.Errorf("empty: got %q", got)
+	}
+}
+
+func TestFormatExpiry(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(4 * time.Minute).Format(time.RFC3339)
+
+	withTimeFormat(t, config.TimeFormatRelative)
+	if got := formatExpiry(expires, now); got != "expires in 4m" {
+		t.Errorf("relative: got %q", got)
+	}
+	withTimeFormat(t, config.TimeFormatUTC)
+	if got := formatExpiry(expires, now); got != "expires 2025-06-15 12:04 UTC" {
+		t.Errorf("utc: got %q", got)
+	}
+}
+
+func TestFormatMailListLine_TimeFormat(t *testing.T) {
+	withTimeFormat(t, config.TimeFormatUTC)
+	got := formatMailListLine("msg_1", "alice", "", "hi", "2025-06-15T10:30:00Z", false)
+	if want := "- alice: hi (id: msg_1, 2025-06-15 10:30 UTC)\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -21,14 +22,15 @@ func parseTimeBestEffort(value string) (time.Time, bool) {
 	return time.Time{}, false
 }
 
-// formatTimeAgo formats a timestamp string as "X ago" for human-friendly display.
+// formatTimeAgoAt formats a timestamp string as "X ago" (relative to now) for
+// human-friendly display.
 // If parsing fails, it falls back to the raw timestamp.
-func formatTimeAgo(timestamp string) string {
+func formatTimeAgoAt(timestamp string, now time.Time) string {
 	ts, ok := parseTimeBestEffort(timestamp)
 	if !ok {
 		return timestamp
 	}
-	d := time.Since(ts)
+	d := now.Sub(ts)
 	if d < 0 {
 		d = 0
 	}
@@ -53,5 +55,27 @@ func isClaimStale(claimedAt string) bool {
 	if !ok {
 		return false
 	}
-	return time.Since(ts) > staleClaimThreshold
+	return serverNow().Sub(ts) > staleClaimThreshold
+}
+
+// parseFutureTime accepts a positive duration ("2h") or a local clock time
+// ("15:00"), which resolves to its next occurrence after now. flag names the
+// option in error messages.
+func parseFutureTime(flag, value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("%s must be in the future, got %q", flag, value)
+		}
+		return now.Add(d), nil
+	}
+	clock, err := time.ParseInLocation("15:04", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: use a duration like 2h or a time like 15:00", flag, value)
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, nil
 }
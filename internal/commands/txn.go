@@ -0,0 +1,469 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	aweb "github.com/awebai/aw"
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// A transaction (:txn begin ... :txn commit) groups a sequence of bd
+// commands so that the rest of the team sees them all at once. While one is
+// open, bd still runs and pre-flight checks still apply, but the sync to
+// BeadHub, the dependency-change notifications and the --:jump-in messages
+// of each mutation are recorded in .beadhub-cache/txn.json instead of being
+// sent. Claims only reach the team with that sync. :txn commit performs a
+// single sync of everything and then sends the notifications; :txn abort
+// hands back every bead claimed during the transaction and drops the
+// notifications.
+
+var (
+	txnJSON bool
+	txnNote string
+)
+
+// TxnState is an open transaction.
+type TxnState struct {
+	ID        string       `json:"id"`
+	Note      string       `json:"note,omitempty"`
+	StartedAt string       `json:"started_at"`
+	Commands  []TxnCommand `json:"commands,omitempty"`
+}
+
+// TxnCommand is a mutation recorded while a transaction was open.
+type TxnCommand struct {
+	Args           []string `json:"args"`
+	Targets        []string `json:"targets,omitempty"`
+	Claims         []string `json:"claims,omitempty"`
+	ConfirmDeletes bool     `json:"confirm_deletes,omitempty"`
+	Force          bool     `json:"force,omitempty"`
+
+	// Claimants at the time of a dependency edit, notified on commit.
+	BeadsInProgress []client.BeadInProgress `json:"beads_in_progress,omitempty"`
+
+	// Messages to other agents, sent on commit.
+	Notifications []TxnNotification `json:"notifications,omitempty"`
+}
+
+// TxnNotification is a message held back until the transaction commits.
+type TxnNotification struct {
+	ToAgentID string `json:"to_agent_id"`
+	ToAlias   string `json:"to_alias,omitempty"`
+	Body      string `json:"body"`
+}
+
+// TxnResult is the output of :txn commands.
+type TxnResult struct {
+	Action    string             `json:"action"`
+	ID        string             `json:"id,omitempty"`
+	Note      string             `json:"note,omitempty"`
+	StartedAt string             `json:"started_at,omitempty"`
+	Commands  []TxnCommand       `json:"commands,omitempty"`
+	Released  []string           `json:"released,omitempty"`
+	SyncStats *client.SyncStats  `json:"sync_stats,omitempty"`
+	Notices   []DependencyNotice `json:"dependency_notices,omitempty"`
+	Warning   string             `json:"warning,omitempty"`
+}
+
+var txnCmd = &cobra.Command{
+	Use:   ":txn",
+	Short: "Group bd commands into one coordinated change",
+	Long: `Group a sequence of bd commands so the team sees them all at once.
+
+Between ":txn begin" and ":txn commit", bd commands run as usual but their
+sync to BeadHub and their notifications (dependency changes, --:jump-in) are
+held back, so claims reach the team only on commit. Commit syncs everything
+in one go and then sends the notifications. Abort drops the notifications
+and releases the beads claimed during the transaction (their status goes
+back to open); other local edits stay in bd and are synced by the next
+mutation.
+
+Examples:
+  bdh :txn begin --note "split auth epic"
+  bdh create "Token refresh" --parent bd-12
+  bdh update bd-13 --status in_progress
+  bdh :txn commit
+
+  bdh :txn status
+  bdh :txn abort`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTxnStatus(cmd, args)
+	},
+}
+
+var txnBeginCmd = &cobra.Command{
+	Use:   "begin",
+	Short: "Start a transaction",
+	Args:  cobra.NoArgs,
+	RunE:  runTxnBegin,
+}
+
+var txnCommitCmd = &cobra.Command{
+	Use:   "commit",
+	Short: "Sync and notify everything recorded in the transaction",
+	Args:  cobra.NoArgs,
+	RunE:  runTxnCommit,
+}
+
+var txnAbortCmd = &cobra.Command{
+	Use:   "abort",
+	Short: "Release claims taken during the transaction and close it",
+	Args:  cobra.NoArgs,
+	RunE:  runTxnAbort,
+}
+
+var txnStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the open transaction",
+	Args:  cobra.NoArgs,
+	RunE:  runTxnStatus,
+}
+
+func init() {
+	txnCmd.AddCommand(txnBeginCmd)
+	txnCmd.AddCommand(txnCommitCmd)
+	txnCmd.AddCommand(txnAbortCmd)
+	txnCmd.AddCommand(txnStatusCmd)
+	txnCmd.PersistentFlags().BoolVar(&txnJSON, "json", false, "Output as JSON")
+	txnBeginCmd.Flags().StringVar(&txnNote, "note", "", "What the transaction is for")
+}
+
+func txnPath() (string, error) {
+	return cacheFilePath("txn.json")
+}
+
+func newTxnID() string {
+	var b [3]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("txn-%d", time.Now().UnixNano())
+	}
+	return "txn-" + hex.EncodeToString(b[:])
+}
+
+func loadTxnState(path string) (*TxnState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state TxnState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func saveTxnState(path string, state *TxnState) error {
+	return writeJSONAtomic(path, state)
+}
+
+// activeTxn returns the open transaction of this workspace, or nil.
+func activeTxn() (*TxnState, error) {
+	path, err := txnPath()
+	if err != nil {
+		return nil, nil
+	}
+	state, err := loadTxnState(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading open transaction: %w (run 'bdh :txn abort' to discard it)", err)
+	}
+	return state, nil
+}
+
+// recordTxnCommand adds a successful mutation to the open transaction and
+// returns the notice shown in place of the sync result.
+func recordTxnCommand(state *TxnState, cmd TxnCommand) (string, error) {
+	path, err := txnPath()
+	if err != nil {
+		return "", err
+	}
+	if id := extractBeadIDFromArgs(cmd.Args); id != "" && isClaimCommand(cmd.Args) {
+		cmd.Claims = []string{id}
+	}
+	if !bd.IsDependencyMutation(cmd.Args) {
+		cmd.BeadsInProgress = nil
+	}
+
+	// Another bdh may have recorded a command since state was read
+	unlock, err := lockCacheFile(path)
+	if err != nil {
+		return "", fmt.Errorf("locking transaction %s: %w", state.ID, err)
+	}
+	defer unlock()
+	current, err := loadTxnState(path)
+	if err != nil {
+		return "", err
+	}
+	if current == nil || current.ID != state.ID {
+		return "", fmt.Errorf("transaction %s was closed while the command ran - the change stays in bd and is synced by the next mutation", state.ID)
+	}
+	current.Commands = append(current.Commands, cmd)
+	if err := saveTxnState(path, current); err != nil {
+		return "", fmt.Errorf("recording command in transaction %s: %w", state.ID, err)
+	}
+	*state = *current
+	return fmt.Sprintf("sync held for transaction %s (%d command(s)) - run 'bdh :txn commit' to publish", state.ID, len(state.Commands)), nil
+}
+
+// sendTxnNotifications sends held-back messages. Failures are recorded for
+// :replay rather than returned.
+func sendTxnNotifications(ctx context.Context, aw AwebAPI, notes []TxnNotification) {
+	if aw == nil {
+		return
+	}
+	for _, note := range notes {
+		req := &aweb.SendMessageRequest{ToAgentID: note.ToAgentID, Body: note.Body}
+		ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+		_, err := aw.SendMessage(ctx, req)
+		cancel()
+		if err != nil {
+			recordFailedNotification(req, note.ToAlias, err)
+		}
+	}
+}
+
+// txnSyncOptions merges the sync options of every recorded command. A
+// command without targets needs a full sync, so the merged sync is full too.
+func txnSyncOptions(state *TxnState) syncOptions {
+	var opts syncOptions
+	targets := []string{}
+	seen := make(map[string]bool)
+	full := false
+	for _, cmd := range state.Commands {
+		opts.ConfirmDeletes = opts.ConfirmDeletes || cmd.ConfirmDeletes
+		opts.Force = opts.Force || cmd.Force
+		if len(cmd.Targets) == 0 {
+			full = true
+		}
+		for _, id := range cmd.Targets {
+			if !seen[id] {
+				seen[id] = true
+				targets = append(targets, id)
+			}
+		}
+	}
+	if !full {
+		opts.Targets = targets
+	}
+	return opts
+}
+
+// txnClaims returns the beads claimed during the transaction, in order.
+func txnClaims(state *TxnState) []string {
+	var claims []string
+	seen := make(map[string]bool)
+	for _, cmd := range state.Commands {
+		for _, id := range cmd.Claims {
+			if !seen[id] {
+				seen[id] = true
+				claims = append(claims, id)
+			}
+		}
+	}
+	return claims
+}
+
+func runTxnBegin(cmd *cobra.Command, args []string) error {
+	if _, err := loadWorkspaceConfig(); err != nil {
+		return err
+	}
+	path, err := txnPath()
+	if err != nil {
+		return err
+	}
+	existing, err := loadTxnState(path)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("transaction %s is already open - commit or abort it first", existing.ID)
+	}
+	state := &TxnState{
+		ID:        newTxnID(),
+		Note:      strings.TrimSpace(txnNote),
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := saveTxnState(path, state); err != nil {
+		return fmt.Errorf("saving transaction: %w", err)
+	}
+	fmt.Print(formatTxnOutput(&TxnResult{Action: "begin", ID: state.ID, Note: state.Note, StartedAt: state.StartedAt}, txnJSON))
+	return nil
+}
+
+func runTxnStatus(cmd *cobra.Command, args []string) error {
+	path, err := txnPath()
+	if err != nil {
+		return err
+	}
+	state, err := loadTxnState(path)
+	if err != nil {
+		return err
+	}
+	result := &TxnResult{Action: "status"}
+	if state != nil {
+		result.ID = state.ID
+		result.Note = state.Note
+		result.StartedAt = state.StartedAt
+		result.Commands = state.Commands
+	}
+	fmt.Print(formatTxnOutput(result, txnJSON))
+	return nil
+}
+
+func runTxnCommit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+	result, err := commitTxnWithConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatTxnOutput(result, txnJSON))
+	return nil
+}
+
+func runTxnAbort(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if _, err := loadWorkspaceConfig(); err != nil {
+		return err
+	}
+	result, err := abortTxn(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatTxnOutput(result, txnJSON))
+	return nil
+}
+
+// commitTxnWithConfig syncs once for all recorded commands and then sends
+// their notifications. If the sync fails the transaction stays open so the
+// commit can be retried (or the transaction aborted).
+func commitTxnWithConfig(ctx context.Context, cfg *config.Config) (*TxnResult, error) {
+	path, err := txnPath()
+	if err != nil {
+		return nil, err
+	}
+	state, err := loadTxnState(path)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no open transaction - start one with 'bdh :txn begin'")
+	}
+
+	result := &TxnResult{Action: "commit", ID: state.ID, Note: state.Note, Commands: state.Commands}
+	if len(state.Commands) > 0 {
+		syncResult := syncToBeadHub(ctx, cfg, state.Commands[len(state.Commands)-1].Args, txnSyncOptions(state))
+		if syncResult.Warning != "" && !syncResult.Synced {
+			return nil, fmt.Errorf("transaction %s not committed: %s", state.ID, syncResult.Warning)
+		}
+		result.Warning = syncResult.Warning
+		result.SyncStats = syncResult.Stats
+
+		aw, _ := newAwebClient(ctx, cfg.BeadhubURL)
+		for _, cmd := range state.Commands {
+			result.Notices = append(result.Notices, notifyDependencyChange(ctx, cfg, aw, cmd.Args, cmd.BeadsInProgress)...)
+			sendTxnNotifications(ctx, aw, cmd.Notifications)
+		}
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("closing transaction: %w", err)
+	}
+	return result, nil
+}
+
+// abortTxn closes the transaction and reopens the beads claimed during it.
+// The reopen goes through the usual passthrough (like :recover), so it is
+// checked and synced; that is why the transaction is closed first. If a
+// bead cannot be released the transaction is reopened so abort can be
+// retried.
+func abortTxn(ctx context.Context) (*TxnResult, error) {
+	path, err := txnPath()
+	if err != nil {
+		return nil, err
+	}
+	state, err := loadTxnState(path)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no open transaction")
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("closing transaction: %w", err)
+	}
+
+	result := &TxnResult{Action: "abort", ID: state.ID, Note: state.Note, Commands: state.Commands}
+	var failed []string
+	for _, id := range txnClaims(state) {
+		if err := reopenClaimedBead(ctx, id); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", id, err))
+			continue
+		}
+		result.Released = append(result.Released, id)
+	}
+	if len(failed) > 0 {
+		if err := saveTxnState(path, state); err != nil {
+			return nil, fmt.Errorf("could not release %s, and reopening the transaction failed: %w", strings.Join(failed, ", "), err)
+		}
+		return nil, fmt.Errorf("could not release %s - the transaction stays open; fix bd and retry 'bdh :txn abort'", strings.Join(failed, ", "))
+	}
+	if len(state.Commands) > len(result.Released) {
+		result.Warning = "other changes made during the transaction remain in bd and will be synced by the next mutation"
+	}
+	return result, nil
+}
+
+func formatTxnOutput(result *TxnResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+	var sb strings.Builder
+	label := result.ID
+	if result.Note != "" {
+		label += " (" + result.Note + ")"
+	}
+	switch result.Action {
+	case "begin":
+		sb.WriteString(fmt.Sprintf("Transaction %s started. Syncs are held until 'bdh :txn commit'.\n", label))
+	case "status":
+		if result.ID == "" {
+			return "No open transaction.\n"
+		}
+		sb.WriteString(fmt.Sprintf("Transaction %s open since %s, %d command(s):\n", label, formatTimestamp(result.StartedAt), len(result.Commands)))
+		for _, cmd := range result.Commands {
+			sb.WriteString("  bd " + strings.Join(cmd.Args, " ") + "\n")
+		}
+	case "commit":
+		sb.WriteString(fmt.Sprintf("Transaction %s committed: %d command(s) synced together.\n", label, len(result.Commands)))
+		if s := result.SyncStats; s != nil {
+			sb.WriteString(fmt.Sprintf("SYNC: +%d inserted, ~%d updated, -%d deleted\n", s.Inserted, s.Updated, s.Deleted))
+		}
+		sb.WriteString(formatDependencyNotices(result.Notices))
+	case "abort":
+		sb.WriteString(fmt.Sprintf("Transaction %s aborted.\n", label))
+		if len(result.Released) > 0 {
+			sb.WriteString("Released claims: " + strings.Join(result.Released, ", ") + "\n")
+		}
+	}
+	if result.Warning != "" {
+		sb.WriteString("Warning: " + result.Warning + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// setupTxnWorkspace starts a mock-bd workspace with one open bead and a
+// server that counts syncs and records the messages sent.
+func setupTxnWorkspace(t *testing.T) (*config.Config, *int, *[]aweb.SendMessageRequest) {
+	t.Helper()
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	setupBeadsWorkspace(t, `{"id":"bd-1","title":"One","status":"open"}
+`)
+
+	syncs := 0
+	var sent []aweb.SendMessageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/bdh/command":
+			json.NewEncoder(w).Encode(map[string]any{"approved": true, "context": map[string]any{}})
+		case "/v1/bdh/sync":
+			syncs++
+			json.NewEncoder(w).Encode(map[string]any{"synced": true, "issues_count": 2})
+		case "/v1/messages":
+			var req aweb.SendMessageRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			sent = append(sent, req)
+			json.NewEncoder(w).Encode(aweb.SendMessageResponse{MessageID: "m1"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := txnPath()
+	if err := saveTxnState(path, &TxnState{ID: "txn-abc123", StartedAt: "2025-06-01T10:00:00Z"}); err != nil {
+		t.Fatal(err)
+	}
+	return cfg, &syncs, &sent
+}
+
+func TestTxn_HoldsSyncUntilCommit(t *testing.T) {
+	cfg, syncs, _ := setupTxnWorkspace(t)
+
+	for _, args := range [][]string{
+		{"create", "Two", "--json"},
+		{"update", "bd-1", "--status", "in_progress"},
+	} {
+		result, err := runPassthrough(context.Background(), args)
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		if result.ExitCode != 0 || !strings.Contains(result.TxnNotice, "txn-abc123") {
+			t.Fatalf("%v: result = %+v", args, result)
+		}
+	}
+	if *syncs != 0 {
+		t.Fatalf("no sync expected inside the transaction, got %d", *syncs)
+	}
+
+	state, _ := activeTxn()
+	if state == nil || len(state.Commands) != 2 || strings.Join(txnClaims(state), ",") != "bd-1" {
+		t.Fatalf("state = %+v", state)
+	}
+	if opts := txnSyncOptions(state); opts.Targets != nil {
+		t.Errorf("create has no targets, so the commit sync should be full: %+v", opts)
+	}
+
+	result, err := commitTxnWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *syncs != 1 || len(result.Commands) != 2 {
+		t.Errorf("syncs = %d, result = %+v", *syncs, result)
+	}
+	if state, _ := activeTxn(); state != nil {
+		t.Errorf("transaction should be closed: %+v", state)
+	}
+	if !strings.Contains(formatTxnOutput(result, false), "2 command(s) synced together") {
+		t.Errorf("output = %q", formatTxnOutput(result, false))
+	}
+}
+
+func TestTxn_AbortReleasesClaims(t *testing.T) {
+	_, syncs, _ := setupTxnWorkspace(t)
+
+	if _, err := runPassthrough(context.Background(), []string{"update", "bd-1", "--status", "in_progress"}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := abortTxn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The release goes through the passthrough, outside the transaction
+	if strings.Join(result.Released, ",") != "bd-1" || *syncs != 1 {
+		t.Errorf("result = %+v, syncs = %d", result, *syncs)
+	}
+	if state, _ := activeTxn(); state != nil {
+		t.Errorf("transaction should be closed: %+v", state)
+	}
+	data, _ := os.ReadFile(filepath.Join(".beads", "issues.jsonl"))
+	if !strings.Contains(string(data), `"status":"open"`) {
+		t.Errorf("bd-1 should be open again:\n%s", data)
+	}
+	if _, err := abortTxn(context.Background()); err == nil {
+		t.Error("expected an error without an open transaction")
+	}
+}
+
+func TestTxn_HoldsNotificationsUntilCommit(t *testing.T) {
+	cfg, _, sent := setupTxnWorkspace(t)
+
+	state, _ := activeTxn()
+	note := TxnNotification{ToAgentID: "ws-bob", ToAlias: "bob", Body: "test-agent is joining work on bd-1"}
+	if _, err := recordTxnCommand(state, TxnCommand{Args: []string{"update", "bd-1", "--status", "in_progress"}, Notifications: []TxnNotification{note}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*sent) != 0 {
+		t.Fatalf("no message expected before commit, got %+v", *sent)
+	}
+
+	if _, err := commitTxnWithConfig(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(*sent) != 1 || (*sent)[0].ToAgentID != "ws-bob" || (*sent)[0].Body != note.Body {
+		t.Errorf("sent = %+v", *sent)
+	}
+}
+
+func TestRecordTxnCommand_ClosedTransaction(t *testing.T) {
+	setupTxnWorkspace(t)
+
+	state, _ := activeTxn()
+	path, _ := txnPath()
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recordTxnCommand(state, TxnCommand{Args: []string{"create", "Two"}}); err == nil {
+		t.Error("expected an error once the transaction was closed")
+	}
+}
+
+func TestTxnSyncOptions_MergesTargets(t *testing.T) {
+	state := &TxnState{Commands: []TxnCommand{
+		{Targets: []string{"bd-1", "bd-2"}},
+		{Targets: []string{"bd-2", "bd-3"}, Force: true},
+	}}
+	opts := txnSyncOptions(state)
+	if strings.Join(opts.Targets, ",") != "bd-1,bd-2,bd-3" || !opts.Force || opts.ConfirmDeletes {
+		t.Errorf("opts = %+v", opts)
+	}
+}
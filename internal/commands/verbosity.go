@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/i18n"
+)
+
+// Coordination output comes in three levels, set per command with
+// --:quiet / --:normal / --:verbose-context or per workspace with
+// output.verbosity in .beadhub. Quiet prints bd's output plus the warnings
+// that mean bd did not do (or must not keep doing) what was asked; verbose
+// lifts the caps on the ready context. JSON output is never affected.
+
+var verbosityFlags = map[string]string{
+	"--:quiet":           config.VerbosityQuiet,
+	"--:normal":          config.VerbosityNormal,
+	"--:verbose-context": config.VerbosityVerbose,
+}
+
+// parseVerbosityFlags strips the verbosity flags from args. Returns "" when
+// none was given; giving more than one is an error.
+func parseVerbosityFlags(args []string) ([]string, string, error) {
+	var given []string
+	level := ""
+	for _, flag := range []string{"--:quiet", "--:normal", "--:verbose-context"} {
+		var present bool
+		args, present = parseBoolFlag(args, flag)
+		if present {
+			given = append(given, flag)
+			level = verbosityFlags[flag]
+		}
+	}
+	if len(given) > 1 {
+		return nil, "", fmt.Errorf("%s cannot be combined", strings.Join(given, " and "))
+	}
+	return args, level, nil
+}
+
+// formatQuietPassthroughOutput renders bd's output with only the fatal
+// coordination warnings: a blocked or rejected command and a blocked sync.
+func formatQuietPassthroughOutput(result *PassthroughResult) string {
+	var sb strings.Builder
+	if result.Blocked != "" {
+		sb.WriteString(i18n.T("blocked", result.Blocked) + "\n")
+		return sb.String()
+	}
+	if result.Rejected {
+		sb.WriteString(i18n.T("rejected", result.RejectionReason) + "\n")
+	}
+	if !result.BdStreamed {
+		if stdout := rewriteBDHelpOutput(strings.TrimRight(result.Stdout, "\n")); stdout != "" {
+			sb.WriteString(stdout + "\n")
+		}
+		if result.Stderr != "" {
+			sb.WriteString(rewriteBDHelpOutput(result.Stderr))
+		}
+	}
+	if result.SyncBlocked != "" {
+		sb.WriteString(i18n.T("blocked", result.SyncBlocked) + "\n")
+	}
+	if result.SyncStrictFailed != "" {
+		sb.WriteString(i18n.T("sync.failed", result.SyncStrictFailed) + "\n")
+	}
+	return sb.String()
+}
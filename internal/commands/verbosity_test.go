@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	aweb "github.com/awebai/aw"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestParseVerbosityFlags(t *testing.T) {
+	args, level, err := parseVerbosityFlags([]string{"ready", "--:quiet", "--json"})
+	if err != nil || level != config.VerbosityQuiet || strings.Join(args, " ") != "ready --json" {
+		t.Fatalf("got %v %q %v", args, level, err)
+	}
+	args, level, err = parseVerbosityFlags([]string{"ready"})
+	if err != nil || level != "" || len(args) != 1 {
+		t.Fatalf("got %v %q %v", args, level, err)
+	}
+	if _, _, err := parseVerbosityFlags([]string{"ready", "--:quiet", "--:verbose-context"}); err == nil ||
+		!strings.Contains(err.Error(), "--:quiet and --:verbose-context cannot be combined") {
+		t.Fatalf("expected combination error, got %v", err)
+	}
+}
+
+func TestFormatPassthroughOutput_QuietKeepsOnlyBdOutputAndFatalWarnings(t *testing.T) {
+	result := &PassthroughResult{
+		Verbosity:      config.VerbosityQuiet,
+		Stdout:         "✓ Updated issue bd-1\n",
+		Warning:        "BeadHub server unreachable - running without coordination",
+		SyncWarning:    "sync failed - timeout",
+		SyncBlocked:    "sync failed; further changes are blocked until it succeeds",
+		SyncStats:      &client.SyncStats{Updated: 1},
+		AutoReserved:   []string{"main.go"},
+		IsReadyCommand: true,
+		MyFocusApexID:  "bd-epic",
+	}
+	out := formatPassthroughOutput(result)
+	if !strings.Contains(out, "✓ Updated issue bd-1") || !strings.Contains(out, "further changes are blocked") {
+		t.Fatalf("quiet output lost bd output or fatal warning:\n%s", out)
+	}
+	for _, unwanted := range []string{"unreachable", "SYNC:", "timeout", "Reservations", "Your Focus"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("quiet output contains %q:\n%s", unwanted, out)
+		}
+	}
+
+	rejected := formatPassthroughOutput(&PassthroughResult{Verbosity: config.VerbosityQuiet, Rejected: true, RejectionReason: "bd-1 is claimed by alice"})
+	if !strings.Contains(rejected, "bd-1 is claimed by alice") {
+		t.Fatalf("quiet output dropped the rejection:\n%s", rejected)
+	}
+}
+
+func TestFormatPassthroughOutput_VerboseShowsAllLocks(t *testing.T) {
+	expires := time.Now().Add(5 * time.Minute).UTC().Format(time.RFC3339)
+	var locks []aweb.ReservationView
+	for i := 0; i < defaultReadyLocksLimit+3; i++ {
+		locks = append(locks, aweb.ReservationView{ResourceKey: fmt.Sprintf("file%02d.go", i), HolderAlias: "bob", ExpiresAt: expires})
+	}
+
+	normal := formatPassthroughOutput(&PassthroughResult{IsReadyCommand: true, MyAlias: "me", ReadyLocks: locks})
+	if strings.Contains(normal, "file12.go") {
+		t.Fatalf("normal output should be truncated:\n%s", normal)
+	}
+	verbose := formatPassthroughOutput(&PassthroughResult{Verbosity: config.VerbosityVerbose, IsReadyCommand: true, MyAlias: "me", ReadyLocks: locks})
+	if !strings.Contains(verbose, "file12.go") {
+		t.Fatalf("verbose output should list every lock:\n%s", verbose)
+	}
+}
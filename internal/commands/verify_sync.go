@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+// maxVerifyPreviewIDs caps how many IDs are listed per category in text output.
+const maxVerifyPreviewIDs = 20
+
+var (
+	verifySyncJSON   bool
+	verifySyncRepair bool
+	verifySyncYes    bool
+)
+
+var verifySyncCmd = &cobra.Command{
+	Use:   ":verify-sync",
+	Short: "Check that BeadHub has the same issues as local issues.jsonl",
+	Long: `Compare local issue hashes with the server's hash manifest.
+
+Reports issues whose content differs, issues missing on the server, and
+issues the server has that no longer exist locally. Differences that are
+just local changes not yet synced are counted separately.
+
+With --repair, shows what a full re-sync would change and then performs
+it (asks for confirmation on a terminal unless --yes is given).
+
+Examples:
+  bdh :verify-sync
+  bdh :verify-sync --json
+  bdh :verify-sync --repair`,
+	Args: cobra.NoArgs,
+	RunE: runVerifySync,
+}
+
+func init() {
+	verifySyncCmd.Flags().BoolVar(&verifySyncJSON, "json", false, "Output as JSON")
+	verifySyncCmd.Flags().BoolVar(&verifySyncRepair, "repair", false, "Fix drift with a full re-sync")
+	verifySyncCmd.Flags().BoolVar(&verifySyncYes, "yes", false, "Skip the confirmation prompt for --repair")
+}
+
+// VerifySyncResult is the outcome of comparing local and server issue hashes.
+type VerifySyncResult struct {
+	LocalCount      int      `json:"local_count"`
+	ServerCount     int      `json:"server_count"`
+	Mismatched      []string `json:"mismatched"`
+	MissingOnServer []string `json:"missing_on_server"`
+	MissingLocally  []string `json:"missing_locally"`
+	Unsynced        int      `json:"unsynced"` // differences explained by local changes since last sync
+	InSync          bool     `json:"in_sync"`
+
+	Repaired      bool              `json:"repaired,omitempty"`
+	RepairWarning string            `json:"repair_warning,omitempty"`
+	AfterRepair   *VerifySyncResult `json:"after_repair,omitempty"`
+
+	titles map[string]string
+}
+
+func runVerifySync(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	cfg, err := loadWorkspaceConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := verifySyncWithConfig(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if verifySyncRepair && !result.InSync {
+		if !verifySyncJSON {
+			fmt.Print(formatVerifySyncOutput(result, false))
+			fmt.Print(formatVerifyRepairPreview(result))
+		}
+		if !verifySyncJSON && !verifySyncYes && isTTY() {
+			if !confirmRepair() {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+		if err := repairSyncWithConfig(ctx, cfg, result); err != nil {
+			return err
+		}
+		if verifySyncJSON {
+			fmt.Print(formatVerifySyncOutput(result, true))
+		} else {
+			fmt.Print(formatVerifyRepairOutcome(result))
+		}
+		if result.AfterRepair == nil || !result.AfterRepair.InSync {
+			return fmt.Errorf("drift remains after repair")
+		}
+		return nil
+	}
+
+	fmt.Print(formatVerifySyncOutput(result, verifySyncJSON))
+	if !result.InSync {
+		return fmt.Errorf("sync drift detected - run 'bdh :verify-sync --repair' to fix")
+	}
+	return nil
+}
+
+// verifySyncWithConfig compares local hashes with the server manifest (for testing).
+func verifySyncWithConfig(ctx context.Context, cfg *config.Config) (*VerifySyncResult, error) {
+	issuesPath, _ := resolveIssuesPathAndExportArgs(nil)
+	content, err := os.ReadFile(issuesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading %s: %w", issuesPath, err)
+	}
+	content, _ = sync.NormalizeJSONL(content) // match what sync uploads
+	localHashes, err := sync.ComputeIssueHashes(content)
+	if err != nil {
+		return nil, fmt.Errorf("hashing local issues: %w", err)
+	}
+
+	c, err := newBeadHubClientRequired(ctx, cfg.BeadhubURL)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	manifest, err := c.SyncManifest(ctx, &client.SyncManifestRequest{
+		WorkspaceID: cfg.WorkspaceID,
+		RepoID:      cfg.RepoID,
+	})
+	if err != nil {
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			return nil, fmt.Errorf("BeadHub error (%d): %s", clientErr.StatusCode, clientErr.Body)
+		}
+		return nil, fmt.Errorf("fetching sync manifest: %w", err)
+	}
+
+	lastSynced := map[string]string{}
+	if state, err := sync.LoadState(syncStatePathForConfig(cfg)); err == nil && state.IssueHashes != nil {
+		lastSynced = state.IssueHashes
+	}
+
+	result := compareSyncManifest(localHashes, manifest.IssueHashes, lastSynced)
+	result.titles = localIssueTitles(content)
+	return result, nil
+}
+
+// compareSyncManifest diffs local hashes against the server's. A difference
+// counts as unsynced when the local hash has changed since the last sync
+// recorded in lastSynced, i.e. the next mutation will upload it anyway.
+func compareSyncManifest(local, server, lastSynced map[string]string) *VerifySyncResult {
+	result := &VerifySyncResult{
+		LocalCount:      len(local),
+		ServerCount:     len(server),
+		Mismatched:      []string{},
+		MissingOnServer: []string{},
+		MissingLocally:  []string{},
+	}
+
+	for id, localHash := range local {
+		serverHash, ok := server[id]
+		switch {
+		case !ok:
+			result.MissingOnServer = append(result.MissingOnServer, id)
+		case serverHash != localHash:
+			result.Mismatched = append(result.Mismatched, id)
+		default:
+			continue
+		}
+		if lastSynced[id] != localHash {
+			result.Unsynced++
+		}
+	}
+	for id := range server {
+		if _, ok := local[id]; !ok {
+			result.MissingLocally = append(result.MissingLocally, id)
+			if _, wasSynced := lastSynced[id]; wasSynced {
+				result.Unsynced++
+			}
+		}
+	}
+
+	sort.Strings(result.Mismatched)
+	sort.Strings(result.MissingOnServer)
+	sort.Strings(result.MissingLocally)
+	result.InSync = len(result.Mismatched) == 0 && len(result.MissingOnServer) == 0 && len(result.MissingLocally) == 0
+	return result
+}
+
+// repairSyncWithConfig performs a full re-sync and re-verifies (for testing).
+func repairSyncWithConfig(ctx context.Context, cfg *config.Config, result *VerifySyncResult) error {
+	syncResult, err := forceFullSync(ctx, cfg, syncOptions{})
+	if err != nil {
+		return err
+	}
+	if syncResult.Warning != "" {
+		result.RepairWarning = syncResult.Warning
+		return fmt.Errorf("repair sync failed: %s", syncResult.Warning)
+	}
+	result.Repaired = true
+
+	after, err := verifySyncWithConfig(ctx, cfg)
+	if err != nil {
+		result.RepairWarning = fmt.Sprintf("could not re-verify: %v", err)
+		return nil
+	}
+	result.AfterRepair = after
+	return nil
+}
+
+func confirmRepair() bool {
+	fmt.Print("Proceed with full re-sync? [y/N]: ")
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	input = strings.ToLower(strings.TrimSpace(input))
+	return input == "y" || input == "yes"
+}
+
+func localIssueTitles(content []byte) map[string]string {
+	titles := make(map[string]string)
+	for _, issue := range parseIssuesJSONL(content) {
+		titles[issue.ID] = issue.Title
+	}
+	return titles
+}
+
+func formatVerifySyncOutput(result *VerifySyncResult, asJSON bool) string {
+	if asJSON {
+		return marshalJSONOrFallback(result)
+	}
+
+	var sb strings.Builder
+	if result.InSync {
+		sb.WriteString(fmt.Sprintf("In sync: %d issues match BeadHub.\n", result.LocalCount))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("DRIFT: local %d issues, server %d issues\n", result.LocalCount, result.ServerCount))
+	writeVerifyIDs(&sb, "Content differs", result.Mismatched)
+	writeVerifyIDs(&sb, "Missing on server", result.MissingOnServer)
+	writeVerifyIDs(&sb, "Missing locally (server only)", result.MissingLocally)
+	if result.Unsynced > 0 {
+		sb.WriteString(fmt.Sprintf("\n%d of these are local changes not yet synced.\n", result.Unsynced))
+	}
+	return sb.String()
+}
+
+func writeVerifyIDs(sb *strings.Builder, label string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("\n%s (%d):\n", label, len(ids)))
+	for i, id := range ids {
+		if i >= maxVerifyPreviewIDs {
+			sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(ids)-maxVerifyPreviewIDs))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("  %s\n", id))
+	}
+}
+
+// formatVerifyRepairPreview shows what a full re-sync will change on the server.
+func formatVerifyRepairPreview(result *VerifySyncResult) string {
+	var sb strings.Builder
+	sb.WriteString("\nRepair preview (full re-sync of local issues.jsonl):\n")
+	write := func(marker string, ids []string) {
+		for i, id := range ids {
+			if i >= maxVerifyPreviewIDs {
+				sb.WriteString(fmt.Sprintf("  %s ... and %d more\n", marker, len(ids)-maxVerifyPreviewIDs))
+				return
+			}
+			if title := result.titles[id]; title != "" {
+				sb.WriteString(fmt.Sprintf("  %s %s \"%s\"\n", marker, id, title))
+			} else {
+				sb.WriteString(fmt.Sprintf("  %s %s\n", marker, id))
+			}
+		}
+	}
+	write("+", result.MissingOnServer)
+	write("~", result.Mismatched)
+	write("-", result.MissingLocally)
+	sb.WriteString("  (+ upload, ~ overwrite on server, - remove from server)\n\n")
+	return sb.String()
+}
+
+func formatVerifyRepairOutcome(result *VerifySyncResult) string {
+	var sb strings.Builder
+	if result.RepairWarning != "" {
+		sb.WriteString(fmt.Sprintf("Warning: %s\n", result.RepairWarning))
+	}
+	if result.AfterRepair == nil {
+		if result.Repaired {
+			sb.WriteString("Full re-sync completed.\n")
+		}
+		return sb.String()
+	}
+	if result.AfterRepair.InSync {
+		sb.WriteString(fmt.Sprintf("Repaired: %d issues now match BeadHub.\n", result.AfterRepair.LocalCount))
+		return sb.String()
+	}
+	sb.WriteString("Full re-sync completed, but drift remains:\n")
+	sb.WriteString(formatVerifySyncOutput(result.AfterRepair, false))
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beadhub/bdh/internal/bd"
+	"github.com/beadhub/bdh/internal/config"
+	"github.com/beadhub/bdh/internal/sync"
+)
+
+func TestCompareSyncManifest(t *testing.T) {
+	local := map[string]string{"bd-1": "h1", "bd-2": "h2-new", "bd-3": "h3"}
+	server := map[string]string{"bd-1": "h1", "bd-2": "h2-old", "bd-4": "h4"}
+	lastSynced := map[string]string{"bd-1": "h1", "bd-2": "h2-old", "bd-4": "h4"}
+
+	result := compareSyncManifest(local, server, lastSynced)
+	if result.InSync {
+		t.Fatal("expected drift")
+	}
+	if strings.Join(result.Mismatched, ",") != "bd-2" {
+		t.Errorf("Mismatched = %v", result.Mismatched)
+	}
+	if strings.Join(result.MissingOnServer, ",") != "bd-3" {
+		t.Errorf("MissingOnServer = %v", result.MissingOnServer)
+	}
+	if strings.Join(result.MissingLocally, ",") != "bd-4" {
+		t.Errorf("MissingLocally = %v", result.MissingLocally)
+	}
+	// bd-2 changed locally, bd-3 is new, bd-4 was deleted locally: all pending.
+	if result.Unsynced != 3 {
+		t.Errorf("Unsynced = %d, want 3", result.Unsynced)
+	}
+
+	// Same drift with a state that matches local means it's silent drift.
+	result = compareSyncManifest(local, server, local)
+	if result.Unsynced != 0 {
+		t.Errorf("Unsynced = %d, want 0", result.Unsynced)
+	}
+
+	if !compareSyncManifest(local, local, nil).InSync {
+		t.Error("identical hashes should be in sync")
+	}
+}
+
+func TestVerifySync_ReportsDriftAndRepairs(t *testing.T) {
+	t.Setenv(bd.MockEnv, "1")
+	t.Setenv("BEADHUB_API_KEY", "aw_sk_test123")
+	issues := `{"id":"bd-1","title":"One","status":"open"}
+{"id":"bd-2","title":"Two","status":"open"}
+`
+	setupBeadsWorkspace(t, issues)
+	localHashes, err := sync.ComputeIssueHashes([]byte(issues))
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	serverHashes := map[string]string{"bd-1": localHashes["bd-1"], "bd-9": "stale"}
+	var gotWorkspace string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/bdh/sync/manifest":
+			gotWorkspace = r.URL.Query().Get("workspace_id")
+			json.NewEncoder(w).Encode(map[string]any{
+				"issue_hashes": serverHashes,
+				"issues_count": len(serverHashes),
+			})
+		case "/v1/bdh/sync":
+			var req map[string]any
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["sync_mode"] != "full" {
+				t.Errorf("expected full sync, got %v", req["sync_mode"])
+			}
+			serverHashes = localHashes
+			json.NewEncoder(w).Encode(map[string]any{"synced": true, "issues_count": 2})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "alice",
+		HumanName:       "Alice",
+	}
+
+	result, err := verifySyncWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotWorkspace != cfg.WorkspaceID {
+		t.Errorf("manifest workspace_id = %q", gotWorkspace)
+	}
+	if result.InSync || len(result.MissingOnServer) != 1 || len(result.MissingLocally) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	preview := formatVerifyRepairPreview(result)
+	if !strings.Contains(preview, `+ bd-2 "Two"`) || !strings.Contains(preview, "- bd-9") {
+		t.Errorf("unexpected preview:\n%s", preview)
+	}
+
+	if err := repairSyncWithConfig(context.Background(), cfg, result); err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if result.AfterRepair == nil || !result.AfterRepair.InSync {
+		t.Errorf("expected in sync after repair, got %+v", result.AfterRepair)
+	}
+	if out := formatVerifyRepairOutcome(result); !strings.Contains(out, "Repaired: 2 issues") {
+		t.Errorf("unexpected outcome: %q", out)
+	}
+}
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/client"
+)
+
+// The last coordination decision - the pre-flight request and response, the
+// local rules that applied and the outcome - is kept in
+// .beadhub-cache/last_decision.json, replaced by every bd command that gets
+// as far as a decision. :why prints it back.
+
+// PreflightTrace is one pre-flight exchange with BeadHub.
+type PreflightTrace struct {
+	Request    *client.CommandRequest  `json:"request"`
+	Response   *client.CommandResponse `json:"response,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+	StatusCode int                     `json:"status_code,omitempty"`
+	DurationMS int64                   `json:"duration_ms"`
+}
+
+// LastDecision is the stored record of the last coordination decision.
+type LastDecision struct {
+	At        string           `json:"at"`
+	Args      []string         `json:"args"`
+	Preflight *PreflightTrace  `json:"preflight,omitempty"`
+	Rules     []string         `json:"rules,omitempty"`
+	Outcome   string           `json:"outcome"`
+	Detail    string           `json:"detail,omitempty"`
+	ExitCode  int              `json:"exit_code,omitempty"`
+	Warning   string           `json:"warning,omitempty"`
+	Approval  *client.Approval `json:"approval,omitempty"`
+}
+
+var whyJSON bool
+
+var whyCmd = &cobra.Command{
+	Use:   ":why",
+	Short: "Explain the last coordination decision",
+	Long: `Explain why the last bd command run through bdh was allowed, rejected
+or blocked: the pre-flight request sent to BeadHub, what the server
+answered, which local rules applied (close-claimant check, --:jump-in,
+read-only and degradation handling, supervision) and what resulted.
+
+Examples:
+  bdh :why
+  bdh :why --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := lastDecisionPath()
+		if err != nil {
+			return err
+		}
+		decision, err := loadLastDecision(path)
+		if err != nil {
+			return err
+		}
+		if decision == nil {
+			return fmt.Errorf("no coordination decision recorded yet - run a bd command through bdh first")
+		}
+		if whyJSON {
+			fmt.Print(marshalJSONOrFallback(decision))
+			return nil
+		}
+		fmt.Print(formatLastDecision(decision, time.Now()))
+		return nil
+	},
+}
+
+func init() {
+	whyCmd.Flags().BoolVar(&whyJSON, "json", false, "Output as JSON")
+}
+
+// noteRule records a local rule that shaped the decision, for :why.
+func (r *PassthroughResult) noteRule(format string, args ...any) {
+	r.DecisionRules = append(r.DecisionRules, fmt.Sprintf(format, args...))
+}
+
+func newPreflightTrace(req *client.CommandRequest, resp *client.CommandResponse, err error, took time.Duration) *PreflightTrace {
+	trace := &PreflightTrace{Request: req, Response: resp, DurationMS: took.Milliseconds()}
+	if err != nil {
+		trace.Response = nil
+		trace.Error = err.Error()
+		var clientErr *client.Error
+		if errors.As(err, &clientErr) {
+			trace.StatusCode = clientErr.StatusCode
+		}
+	}
+	return trace
+}
+
+func lastDecisionPath() (string, error) {
+	return cacheFilePath("last_decision.json")
+}
+
+func loadLastDecision(path string) (*LastDecision, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var decision LastDecision
+	if err := json.Unmarshal(data, &decision); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &decision, nil
+}
+
+func saveLastDecision(path string, decision *LastDecision) error {
+	return writeJSONAtomic(path, decision)
+}
+
+// recordLastDecision keeps result's decision for :why. Commands that never
+// reached a decision (no pre-flight and no local rule) leave the previous
+// one in place. Best-effort, like the worklog.
+func recordLastDecision(args []string, result *PassthroughResult, start time.Time) {
+	if result.Preflight == nil && len(result.DecisionRules) == 0 {
+		return
+	}
+	path, err := lastDecisionPath()
+	if err != nil {
+		return
+	}
+	_ = saveLastDecision(path, newLastDecision(args, result, start))
+}
+
+func newLastDecision(args []string, result *PassthroughResult, start time.Time) *LastDecision {
+	entry := newWorklogEntry(args, start)
+	decision := &LastDecision{
+		At:        entry.At,
+		Args:      args,
+		Preflight: result.Preflight,
+		Rules:     result.DecisionRules,
+		ExitCode:  result.ExitCode,
+		Warning:   result.Warning,
+		Approval:  result.Approval,
+	}
+	switch {
+	case result.Rejected:
+		decision.Outcome, decision.Detail = worklogRejected, result.RejectionReason
+	case result.Blocked != "":
+		decision.Outcome, decision.Detail = worklogBlocked, result.Blocked
+	case result.ExitCode != 0:
+		decision.Outcome, decision.Detail = worklogFailed, firstLine(strings.TrimSpace(result.Stderr))
+	default:
+		decision.Outcome = worklogOK
+		if result.JumpedIn {
+			decision.Detail = "jumped in"
+		}
+	}
+	return decision
+}
+
+func formatLastDecision(d *LastDecision, now time.Time) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Last decision: bdh %s (%s)\n", strings.Join(d.Args, " "), formatTimeAgoAt(d.At, now))
+
+	if p := d.Preflight; p != nil {
+		fmt.Fprintf(&sb, "\n## Sent to BeadHub (%dms)\n", p.DurationMS)
+		if req := p.Request; req != nil {
+			fmt.Fprintf(&sb, "- command_line: %s\n", req.CommandLine)
+			fmt.Fprintf(&sb, "- workspace: %s (alias %s", req.WorkspaceID, req.Alias)
+			if req.Role != "" {
+				fmt.Fprintf(&sb, ", role %s", req.Role)
+			}
+			sb.WriteString(")\n")
+			if req.RepoOrigin != "" {
+				fmt.Fprintf(&sb, "- repo: %s\n", req.RepoOrigin)
+			}
+		}
+
+		sb.WriteString("\n## Server answered\n")
+		switch {
+		case p.Error != "" && p.StatusCode != 0:
+			fmt.Fprintf(&sb, "- error (HTTP %d): %s\n", p.StatusCode, p.Error)
+		case p.Error != "":
+			fmt.Fprintf(&sb, "- no answer: %s\n", p.Error)
+		case p.Response == nil:
+			sb.WriteString("- empty response\n")
+		default:
+			resp := p.Response
+			if resp.Approved {
+				sb.WriteString("- approved\n")
+			} else {
+				fmt.Fprintf(&sb, "- rejected: %s\n", resp.Reason)
+			}
+			if resp.Context != nil {
+				fmt.Fprintf(&sb, "- messages waiting: %d\n", resp.Context.MessagesWaiting)
+				for _, bip := range resp.Context.BeadsInProgress {
+					fmt.Fprintf(&sb, "- in progress: %s by %s", bip.BeadID, bip.Alias)
+					if bip.HumanName != "" {
+						fmt.Fprintf(&sb, " (%s)", bip.HumanName)
+					}
+					sb.WriteString("\n")
+				}
+			}
+		}
+	} else {
+		sb.WriteString("\n## Sent to BeadHub\n- nothing: decided before the pre-flight check\n")
+	}
+
+	sb.WriteString("\n## Local rules\n")
+	if len(d.Rules) == 0 {
+		sb.WriteString("- none: the server's answer stood\n")
+	}
+	for _, rule := range d.Rules {
+		sb.WriteString("- " + rule + "\n")
+	}
+
+	sb.WriteString("\n## Result\n")
+	switch d.Outcome {
+	case worklogRejected:
+		fmt.Fprintf(&sb, "- rejected, bd not run: %s\n", d.Detail)
+	case worklogBlocked:
+		fmt.Fprintf(&sb, "- blocked, bd not run: %s\n", d.Detail)
+	case worklogFailed:
+		fmt.Fprintf(&sb, "- bd ran and exited %d", d.ExitCode)
+		if d.Detail != "" {
+			sb.WriteString(": " + d.Detail)
+		}
+		sb.WriteString("\n")
+	default:
+		sb.WriteString("- bd ran")
+		if d.Detail != "" {
+			sb.WriteString(" (" + d.Detail + ")")
+		}
+		sb.WriteString("\n")
+	}
+	if d.Warning != "" {
+		sb.WriteString("- warning: " + d.Warning + "\n")
+	}
+	if a := d.Approval; a != nil {
+		fmt.Fprintf(&sb, "- approval %s: %s", a.ApprovalID, a.Status)
+		if a.Note != "" {
+			sb.WriteString(" - " + a.Note)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestWhy_RecordsCloseClaimantRejection(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	os.MkdirAll(".beads", 0755)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/bdh/command" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"approved": true,
+				"context": map[string]any{
+					"messages_waiting": 2,
+					"beads_in_progress": []any{
+						map[string]any{"bead_id": "bd-42", "workspace_id": "other-ws-id", "alias": "other-agent", "human_name": "Maria"},
+					},
+				},
+			})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+		BeadhubURL:      server.URL,
+		ProjectSlug:     "test-project",
+		RepoID:          "c3d4e5f6-7890-12cd-ef01-345678901234",
+		RepoOrigin:      "git@github.com:test/repo.git",
+		CanonicalOrigin: "github.com/test/repo",
+		Alias:           "test-agent",
+		HumanName:       "Test Human",
+	}
+	cfg.Save()
+
+	args := []string{"close", "bd-42", "--reason", "done"}
+	start := time.Now()
+	result, err := runPassthrough(context.Background(), args)
+	if err != nil {
+		t.Fatalf("runPassthrough error: %v", err)
+	}
+	recordLastDecision(args, result, start)
+
+	path, err := lastDecisionPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decision, err := loadLastDecision(path)
+	if err != nil || decision == nil {
+		t.Fatalf("loadLastDecision = %v, %v", decision, err)
+	}
+	if decision.Preflight == nil || decision.Preflight.Request.CommandLine != "close bd-42 --reason done" {
+		t.Fatalf("pre-flight request not recorded: %+v", decision.Preflight)
+	}
+	out := formatLastDecision(decision, time.Now())
+	for _, want := range []string{
+		"Last decision: bdh close bd-42 --reason done",
+		"- command_line: close bd-42 --reason done",
+		"- approved\n",
+		"- messages waiting: 2",
+		"- in progress: bd-42 by other-agent (Maria)",
+		"- close-claimant check: bd-42 is claimed by others; rejected without --:jump-in",
+		"- rejected, bd not run: bd-42 has active claims by: other-agent (Maria)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWhy_KeepsDecisionWhenNoneWasMade(t *testing.T) {
+	setupBeadsWorkspace(t, "")
+	path, err := lastDecisionPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordLastDecision([]string{"list"}, &PassthroughResult{DecisionRules: []string{"degradation policy: blocked before the pre-flight check"}, Blocked: "sync failed"}, time.Now())
+	recordLastDecision([]string{"--version"}, &PassthroughResult{}, time.Now())
+
+	decision, err := loadLastDecision(path)
+	if err != nil || decision == nil {
+		t.Fatalf("loadLastDecision = %v, %v", decision, err)
+	}
+	if decision.Outcome != worklogBlocked || strings.Join(decision.Args, " ") != "list" {
+		t.Errorf("unexpected decision %+v", decision)
+	}
+}
+
+func TestFormatLastDecision_Unreachable(t *testing.T) {
+	req := &client.CommandRequest{WorkspaceID: "ws-1", Alias: "claude-be", CommandLine: "update bd-1 --status in_progress"}
+	result := &PassthroughResult{
+		Preflight: newPreflightTrace(req, nil, errors.New("dial tcp: connection refused"), 40*time.Millisecond),
+		Warning:   "BeadHub unreachable at http://localhost:8000 - running without coordination",
+	}
+	result.noteRule("no answer from BeadHub: ran without coordination")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	decision := newLastDecision([]string{"update", "bd-1", "--status", "in_progress"}, result, now.Add(-5*time.Minute))
+
+	out := formatLastDecision(decision, now)
+	for _, want := range []string{
+		"## Sent to BeadHub (40ms)",
+		"- workspace: ws-1 (alias claude-be)",
+		"- no answer: dial tcp: connection refused",
+		"- no answer from BeadHub: ran without coordination",
+		"- bd ran\n",
+		"- warning: BeadHub unreachable",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
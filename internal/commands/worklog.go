@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/beadhub/bdh/internal/config"
+)
+
+// Every bdh command run in a configured workspace appends one line to
+// .beadhub-cache/worklog.jsonl: the command, its outcome, the sync summary
+// and the messages bdh sent on the agent's behalf. The file is only ever
+// appended to, so an agent can reconstruct what it did after a context
+// reset and a human can audit it; :worklog show reads it back.
+
+const defaultWorklogWindow = 4 * time.Hour
+
+// Worklog outcomes.
+const (
+	worklogOK          = "ok"
+	worklogRejected    = "rejected"
+	worklogBlocked     = "blocked"
+	worklogFailed      = "failed"      // bd exited non-zero
+	worklogError       = "error"       // bdh itself returned an error
+	worklogInterrupted = "interrupted" // cancelled with Ctrl-C
+)
+
+// WorklogEntry is one line of the worklog.
+type WorklogEntry struct {
+	At         string   `json:"at"`
+	Command    string   `json:"command"`
+	Args       []string `json:"args,omitempty"`
+	Outcome    string   `json:"outcome"`
+	ExitCode   int      `json:"exit_code,omitempty"`
+	Detail     string   `json:"detail,omitempty"`
+	Sync       string   `json:"sync,omitempty"`
+	Messages   []string `json:"messages,omitempty"`
+	DurationMS int64    `json:"duration_ms"`
+}
+
+var (
+	worklogSince   time.Duration
+	worklogCommand string
+	worklogOutcome string
+	worklogGrep    string
+	worklogLimit   int
+	worklogJSON    bool
+)
+
+var worklogCmd = &cobra.Command{
+	Use:   ":worklog",
+	Short: "Show the local journal of bdh actions",
+	Long: `Show the append-only journal of what bdh did in this workspace.
+
+Every command is recorded in .beadhub-cache/worklog.jsonl with its outcome
+(ok, rejected, blocked, failed, error, interrupted), the sync summary and
+the messages bdh sent on your behalf.
+
+Examples:
+  bdh :worklog show                     # Last 4 hours
+  bdh :worklog show --since 24h --outcome rejected
+  bdh :worklog show --command close --grep bd-42
+  bdh :worklog show --json`,
+	Args: cobra.NoArgs,
+	RunE: runWorklogShow,
+}
+
+var worklogShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print journal entries",
+	Args:  cobra.NoArgs,
+	RunE:  runWorklogShow,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{worklogCmd, worklogShowCmd} {
+		cmd.Flags().DurationVar(&worklogSince, "since", defaultWorklogWindow, "Show entries within this long")
+		cmd.Flags().StringVar(&worklogCommand, "command", "", "Only entries for this command (e.g. close, :aweb)")
+		cmd.Flags().StringVar(&worklogOutcome, "outcome", "", "Only entries with this outcome (ok, rejected, blocked, failed, error, interrupted)")
+		cmd.Flags().StringVar(&worklogGrep, "grep", "", "Only entries mentioning this text")
+		cmd.Flags().IntVar(&worklogLimit, "limit", 0, "Show at most this many (most recent) entries")
+		cmd.Flags().BoolVar(&worklogJSON, "json", false, "Output as JSON")
+	}
+	worklogCmd.AddCommand(worklogShowCmd)
+}
+
+func worklogPath() (string, error) {
+	return cacheFilePath("worklog.jsonl")
+}
+
+// appendWorklog adds entry to the worklog of a configured workspace
+// (best-effort: the journal never fails a command).
+func appendWorklog(entry WorklogEntry) {
+	if _, err := config.Load(); err != nil {
+		return
+	}
+	path, err := worklogPath()
+	if err != nil {
+		return
+	}
+	if ensureCacheDir(path) != nil {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	// One write per line keeps concurrent appends from interleaving.
+	_, _ = f.Write(append(data, '\n'))
+	_ = f.Close()
+}
+
+// recordWorklogCommand journals a bdh command (":..."), or a bd command that
+// failed before bd ran. A nil err is recorded as ok.
+func recordWorklogCommand(args []string, err error, start time.Time) {
+	if len(args) > 0 && args[0] == ":worklog" {
+		return
+	}
+	entry := newWorklogEntry(args, start)
+	entry.Outcome = worklogOK
+	if IsInterrupted(err) {
+		entry.Outcome = worklogInterrupted
+	} else if err != nil {
+		entry.Outcome = worklogError
+	}
+	if err != nil {
+		entry.Detail = firstLine(err.Error())
+	}
+	appendWorklog(entry)
+}
+
+// recordWorklogPassthrough journals a bd command run through bdh.
+func recordWorklogPassthrough(args []string, result *PassthroughResult, start time.Time) {
+	entry := newWorklogEntry(args, start)
+	entry.ExitCode = result.ExitCode
+	switch {
+	case result.Rejected:
+		entry.Outcome = worklogRejected
+		entry.Detail = firstLine(result.RejectionReason)
+	case result.Blocked != "":
+		entry.Outcome = worklogBlocked
+		entry.Detail = firstLine(result.Blocked)
+	case result.ExitCode != 0:
+		entry.Outcome = worklogFailed
+		entry.Detail = firstLine(strings.TrimSpace(result.Stderr))
+	default:
+		entry.Outcome = worklogOK
+		if result.JumpedIn {
+			entry.Detail = "jumped in"
+		}
+	}
+
+	switch {
+	case result.SyncWarning != "":
+		entry.Sync = "warning: " + firstLine(result.SyncWarning)
+	case result.TxnNotice != "":
+		entry.Sync = result.TxnNotice
+	case result.SyncStats != nil:
+		s := result.SyncStats
+		entry.Sync = fmt.Sprintf("%s +%d ~%d -%d", result.SyncMode, s.Inserted, s.Updated, s.Deleted)
+	}
+	for _, c := range result.JumpInClaimants {
+		if c.Notified {
+			entry.Messages = append(entry.Messages, fmt.Sprintf("jump-in on %s to %s", result.JumpInBeadID, c.Alias))
+		}
+	}
+	for _, n := range result.DependencyNotices {
+		msg := fmt.Sprintf("dependency change on %s to %s", n.BeadID, n.Alias)
+		if n.Error != "" {
+			msg += " (failed)"
+		}
+		entry.Messages = append(entry.Messages, msg)
+	}
+	appendWorklog(entry)
+}
+
+func newWorklogEntry(args []string, start time.Time) WorklogEntry {
+	entry := WorklogEntry{
+		At:         start.UTC().Format(time.RFC3339),
+		Args:       args,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if len(args) > 0 {
+		entry.Command = args[0]
+	}
+	return entry
+}
+
+// loadWorklog reads every entry, skipping lines that do not parse.
+func loadWorklog(path string) ([]WorklogEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []WorklogEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry WorklogEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// WorklogFilter selects entries for :worklog show.
+type WorklogFilter struct {
+	Since   time.Time
+	Command string
+	Outcome string
+	Grep    string
+	Limit   int
+}
+
+func filterWorklog(entries []WorklogEntry, f WorklogFilter) []WorklogEntry {
+	grep := strings.ToLower(f.Grep)
+	var out []WorklogEntry
+	for _, e := range entries {
+		if at, ok := parseTimeBestEffort(e.At); ok && at.Before(f.Since) {
+			continue
+		}
+		if f.Command != "" && !strings.EqualFold(e.Command, f.Command) {
+			continue
+		}
+		if f.Outcome != "" && !strings.EqualFold(e.Outcome, f.Outcome) {
+			continue
+		}
+		if grep != "" && !strings.Contains(strings.ToLower(worklogText(e)), grep) {
+			continue
+		}
+		out = append(out, e)
+	}
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[len(out)-f.Limit:]
+	}
+	return out
+}
+
+// worklogText is the searchable text of an entry.
+func worklogText(e WorklogEntry) string {
+	return strings.Join([]string{strings.Join(e.Args, " "), e.Detail, e.Sync, strings.Join(e.Messages, " ")}, "\n")
+}
+
+func runWorklogShow(cmd *cobra.Command, args []string) error {
+	if worklogSince <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	path, err := worklogPath()
+	if err != nil {
+		return err
+	}
+	entries, err := loadWorklog(path)
+	if err != nil {
+		return fmt.Errorf("reading worklog: %w", err)
+	}
+	entries = filterWorklog(entries, WorklogFilter{
+		Since:   time.Now().Add(-worklogSince),
+		Command: worklogCommand,
+		Outcome: worklogOutcome,
+		Grep:    worklogGrep,
+		Limit:   worklogLimit,
+	})
+	fmt.Print(formatWorklogOutput(entries, worklogJSON))
+	return nil
+}
+
+func formatWorklogOutput(entries []WorklogEntry, asJSON bool) string {
+	if asJSON {
+		if entries == nil {
+			entries = []WorklogEntry{}
+		}
+		return marshalJSONOrFallback(map[string]any{"entries": entries})
+	}
+	if len(entries) == 0 {
+		return "No worklog entries.\n"
+	}
+	var sb strings.Builder
+	for _, e := range entries {
+		sb.WriteString(fmt.Sprintf("%s  %-8s bdh %s\n", formatTimestamp(e.At), e.Outcome, shellQuoteArgs(e.Args)))
+		if e.Detail != "" {
+			sb.WriteString("    " + truncateText(e.Detail, 200) + "\n")
+		}
+		if e.Sync != "" {
+			sb.WriteString("    sync: " + e.Sync + "\n")
+		}
+		for _, m := range e.Messages {
+			sb.WriteString("    sent: " + m + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func shellQuoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = shellQuoteArg(a)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beadhub/bdh/internal/client"
+	"github.com/beadhub/bdh/internal/config"
+)
+
+func TestWorklog_RecordsCommandsInConfiguredWorkspace(t *testing.T) {
+	t.Chdir(t.TempDir())
+	start := time.Now()
+
+	// Without a .beadhub nothing is written.
+	recordWorklogCommand([]string{":status"}, nil, start)
+	path, _ := worklogPath()
+	if entries, _ := loadWorklog(path); len(entries) != 0 {
+		t.Fatalf("entries without a workspace: %+v", entries)
+	}
+
+	cfg := &config.Config{WorkspaceID: "a1b2c3d4-5678-90ab-cdef-1234567890ab", BeadhubURL: "http://localhost:8000", Alias: "test-agent"}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	recordWorklogPassthrough([]string{"close", "bd-1"}, &PassthroughResult{
+		SyncMode:          "incremental",
+		SyncStats:         &client.SyncStats{Updated: 1},
+		DependencyNotices: []DependencyNotice{{Alias: "bob", BeadID: "bd-2"}},
+	}, start)
+	recordWorklogPassthrough([]string{"update", "bd-3", "--status", "in_progress"}, &PassthroughResult{
+		Rejected:        true,
+		RejectionReason: "bd-3 is being worked on by bob",
+	}, start)
+	recordWorklogCommand([]string{":aweb", "mail", "send", "bob", "hi"}, errors.New("BeadHub error (500): boom"), start)
+	recordWorklogCommand([]string{":worklog", "show"}, nil, start)
+
+	entries, err := loadWorklog(path)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("entries = %+v, err = %v", entries, err)
+	}
+	if e := entries[0]; e.Outcome != worklogOK || e.Sync != "incremental +0 ~1 -0" || len(e.Messages) != 1 {
+		t.Errorf("close entry = %+v", e)
+	}
+	if e := entries[1]; e.Outcome != worklogRejected || !strings.Contains(e.Detail, "bob") {
+		t.Errorf("rejected entry = %+v", e)
+	}
+	if e := entries[2]; e.Outcome != worklogError || e.Command != ":aweb" {
+		t.Errorf("error entry = %+v", e)
+	}
+}
+
+func TestFilterWorklog(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) string { return now.Add(-d).UTC().Format(time.RFC3339) }
+	entries := []WorklogEntry{
+		{At: at(5 * time.Hour), Command: "close", Args: []string{"close", "bd-1"}, Outcome: worklogOK},
+		{At: at(2 * time.Hour), Command: "close", Args: []string{"close", "bd-42"}, Outcome: worklogOK},
+		{At: at(time.Hour), Command: "update", Args: []string{"update", "bd-42"}, Outcome: worklogRejected},
+		{At: at(time.Minute), Command: "ready", Args: []string{"ready"}, Outcome: worklogOK},
+	}
+	since := now.Add(-4 * time.Hour)
+
+	if got := filterWorklog(entries, WorklogFilter{Since: since}); len(got) != 3 {
+		t.Errorf("since: %+v", got)
+	}
+	if got := filterWorklog(entries, WorklogFilter{Since: since, Grep: "BD-42"}); len(got) != 2 {
+		t.Errorf("grep: %+v", got)
+	}
+	if got := filterWorklog(entries, WorklogFilter{Since: since, Command: "close"}); len(got) != 1 || got[0].Args[1] != "bd-42" {
+		t.Errorf("command: %+v", got)
+	}
+	if got := filterWorklog(entries, WorklogFilter{Since: since, Outcome: "rejected"}); len(got) != 1 {
+		t.Errorf("outcome: %+v", got)
+	}
+	if got := filterWorklog(entries, WorklogFilter{Since: since, Limit: 1}); len(got) != 1 || got[0].Command != "ready" {
+		t.Errorf("limit keeps the most recent: %+v", got)
+	}
+
+	out := formatWorklogOutput([]WorklogEntry{{At: at(time.Minute), Args: []string{":aweb", "mail", "send", "bob", "on it"}, Outcome: worklogOK, Sync: "full +1 ~0 -0"}}, false)
+	if !strings.Contains(out, "bdh :aweb mail send bob 'on it'") || !strings.Contains(out, "sync: full +1 ~0 -0") {
+		t.Errorf("output:\n%s", out)
+	}
+}
@@ -9,6 +9,10 @@
 //	alias: "claude-code"                      - Human-friendly workspace address
 //	human_name: "Juan"                        - Human owner of this workspace
 //	role: "reviewer"                          - Optional short workspace role
+//	tags: [python, infra]                     - Optional capability tags
+//	display: {aliases: {claude-be: Backend}}  - Optional names shown for other agents
+//	no_git: true                              - Workspace is not a git repo (synthetic repo_origin)
+//	project_overrides: {path-prefix: {...}}   - Optional per-directory project routing
 package config
 
 import (
@@ -16,7 +20,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -79,6 +85,19 @@ var (
 	roleWordPattern        = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
 	roleMaxLength          = 50
 	roleMaxWords           = 2
+
+	commandLineReportingPattern = regexp.MustCompile(`^(redact|hash|full|off)$`)
+
+	warnOrBlockPattern     = regexp.MustCompile(`^(warn|block)$`)
+	syncFailureModePattern = regexp.MustCompile(`^(warn|queue|block)$`)
+	verbosityPattern       = regexp.MustCompile(`^(quiet|normal|verbose)$`)
+	timeFormatPattern      = regexp.MustCompile(`^(relative|local|utc|iso)$`)
+	commandAliasPattern    = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{0,31}$`)
+	templateVarPattern     = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+	emailAddressPattern    = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	envVarNamePattern      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	tagPattern             = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]{0,31}$`)
+	maxTags                = 16
 )
 
 // Config represents the .beadhub configuration file.
@@ -94,9 +113,354 @@ type Config struct {
 	Role             string `yaml:"role,omitempty"`
 	AutoReserve      *bool  `yaml:"auto_reserve,omitempty"`
 	ReserveUntracked *bool  `yaml:"reserve_untracked,omitempty"`
+
+	// Tags advertise what this workspace knows (languages, subsystems), sent
+	// with presence so others can find whom to ask about an area.
+	Tags []string `yaml:"tags,omitempty"`
+
+	// Squad declares agents this workspace shares file reservations with;
+	// see SquadConfig.
+	Squad *SquadConfig `yaml:"squad,omitempty"`
+
+	// Supervision parks commands for a human supervisor; see
+	// SupervisionConfig.
+	Supervision *SupervisionConfig `yaml:"supervision,omitempty"`
+
+	// ReportConflicts also sends auto-reserve conflicts to the server's
+	// reservation history, so :hotspots and :conflicts see them project-wide.
+	ReportConflicts *bool `yaml:"report_conflicts,omitempty"`
+
+	// NoGit marks a workspace that is not a git repository (docs folders,
+	// data workspaces). RepoOrigin is then synthetic (see :init --no-git)
+	// and git-dependent features are off: origin checks and auto-reserve.
+	NoGit bool `yaml:"no_git,omitempty"`
+
+	Sync *SyncConfig `yaml:"sync,omitempty"`
+	HTTP *HTTPConfig `yaml:"http,omitempty"`
+
+	// Escalations controls reminders for escalations left pending too long.
+	Escalations *EscalationConfig `yaml:"escalations,omitempty"`
+
+	// Privacy controls what bdh reports to the server about local commands.
+	Privacy *PrivacyConfig `yaml:"privacy,omitempty"`
+
+	// Degradation controls what happens when coordination fails.
+	Degradation *DegradationConfig `yaml:"degradation,omitempty"`
+
+	// Notifications controls how notifications reach the human at the machine.
+	Notifications *NotificationsConfig `yaml:"notifications,omitempty"`
+
+	// Output controls how much coordination context bdh prints.
+	Output *OutputConfig `yaml:"output,omitempty"`
+
+	// Display controls how other agents are named in bdh's output.
+	Display *DisplayConfig `yaml:"display,omitempty"`
+
+	// Metrics controls local coordination metrics for bdh :metrics.
+	Metrics *MetricsConfig `yaml:"metrics,omitempty"`
+
+	// BD controls how bd itself is run.
+	BD *BDConfig `yaml:"bd,omitempty"`
+
+	// Report controls where bdh :report --send delivers the activity digest.
+	Report *ReportConfig `yaml:"report,omitempty"`
+
+	// Away controls the automatic chat reply sent while this agent is away.
+	Away *AwayConfig `yaml:"away,omitempty"`
+
+	// Aliases maps a command name to the bd invocation it expands to, e.g.
+	// r: "ready --priority 1". Arguments after the alias are appended.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+
+	// Templates maps a name to the body of a message sent often, with
+	// {{variable}} placeholders filled in by mail send --template --var.
+	Templates map[string]string `yaml:"templates,omitempty"`
+
+	// ProjectOverrides routes commands run under a path prefix (relative to the
+	// workspace root) to a different BeadHub project. See ResolveProjectOverride.
+	ProjectOverrides map[string]ProjectOverride `yaml:"project_overrides,omitempty"`
+
+	// ActiveOverride is the path prefix of the override applied by Load, if any.
+	ActiveOverride string `yaml:"-"`
+}
+
+// SupervisionConfig lets a human supervisor release commands an agent may
+// not run on its own. Parked commands become approval requests on the
+// server; once a supervisor grants one with :approve, the agent's next run
+// of the same command goes ahead.
+type SupervisionConfig struct {
+	// Approvals parks commands BeadHub rejects instead of only failing them.
+	Approvals *bool `yaml:"approvals,omitempty"`
+
+	// HighRisk lists bd commands that always need approval, matched as a
+	// prefix of the arguments (delete, "close --force").
+	HighRisk []string `yaml:"high_risk,omitempty"`
+
+	// ApproverRoles are the workspace roles allowed to decide approval
+	// requests with :approve (default: supervisor). Set in the deciding
+	// workspace's .beadhub.
+	ApproverRoles []string `yaml:"approver_roles,omitempty"`
+}
+
+// SquadConfig declares a squad: agents working closely enough to edit the
+// same files. Reservations held by squadmates are shared with this workspace
+// instead of conflicting; they stay exclusive against everyone else. Each
+// member declares the squad in its own config, or the server defines it.
+type SquadConfig struct {
+	Name    string   `yaml:"name,omitempty"`
+	Members []string `yaml:"members,omitempty"`
+}
+
+// SyncConfig holds optional settings for syncing issues.jsonl to BeadHub.
+type SyncConfig struct {
+	// MaxDeletePercent caps how much of the previously synced issue set a single
+	// incremental sync may delete without --:confirm-deletes.
+	MaxDeletePercent *int `yaml:"max_delete_percent,omitempty"`
+
+	// Strict makes a failed sync after a mutation fail the command, for
+	// scripts and bots that must not leave changes local-only.
+	Strict *bool `yaml:"strict,omitempty"`
+}
+
+// HTTPConfig holds optional settings for the BeadHub HTTP connection.
+type HTTPConfig struct {
+	// Prewarm opens a connection to the server at command start so the first
+	// request does not pay for the TCP/TLS handshake.
+	Prewarm *bool `yaml:"prewarm,omitempty"`
+
+	// StrictDecoding rejects server responses with keys bdh does not know,
+	// to catch API drift early when testing against a new server version.
+	StrictDecoding *bool `yaml:"strict_decoding,omitempty"`
+
+	// PresenceIntervalSeconds is the least time between two presence
+	// refreshes from this workspace, shared by all bdh processes in it, so
+	// rapid command loops do not register the agent on every command. 0
+	// refreshes on every command.
+	PresenceIntervalSeconds *int `yaml:"presence_interval_seconds,omitempty"`
+}
+
+// DefaultPresenceIntervalSeconds is used when http.presence_interval_seconds
+// is not set.
+const DefaultPresenceIntervalSeconds = 60
+
+// NotificationsConfig holds optional notification delivery settings.
+type NotificationsConfig struct {
+	// Desktop raises an OS notification for urgent mail and chats waiting on
+	// a reply, so humans co-working with agents see them outside the terminal.
+	Desktop *bool `yaml:"desktop,omitempty"`
+
+	// AutoAckDisplayed acks mail once its full body has been shown inline
+	// (urgent mail, and low-priority mail while this is on), so it does not
+	// reappear on every command. Flagged messages are never acked this way.
+	AutoAckDisplayed *bool `yaml:"auto_ack_displayed,omitempty"`
+
+	// QuietHours are local-time windows ("22:00-07:00", "mon-fri
+	// 09:00-11:30") during which non-urgent notifications are held and then
+	// summarized once the window ends. See ParseQuietWindow.
+	QuietHours []string `yaml:"quiet_hours,omitempty"`
+}
+
+// MetricsConfig holds optional settings for local metrics.
+type MetricsConfig struct {
+	// Enabled records counters and latencies for each command in
+	// .beadhub-cache/metrics.json, served by bdh :metrics serve.
+	Enabled *bool `yaml:"enabled,omitempty"`
 }
 
+// BDConfig holds optional settings for running bd.
+type BDConfig struct {
+	// PTY runs bd on a pseudo-terminal when bdh's output is a terminal, so
+	// bd's colors and progress output reach the user live. Where no
+	// pseudo-terminal is available bd runs as usual.
+	PTY *bool `yaml:"pty,omitempty"`
+
+	// Env sets environment variables for bd (BD_NO_DAEMON, a custom
+	// database path...) on top of the environment bdh runs in.
+	Env map[string]string `yaml:"env,omitempty"`
+
+	// Command runs bd through a wrapper where bd is not on the agent's
+	// PATH: the argv bd's arguments are appended to, e.g.
+	// ["docker", "exec", "toolbox", "bd"].
+	Command []string `yaml:"command,omitempty"`
+}
+
+// ReportConfig holds the recipients of the bdh :report digest.
+type ReportConfig struct {
+	// MailTo lists workspace aliases that receive the digest as BeadHub mail.
+	MailTo []string `yaml:"mail_to,omitempty"`
+	// EmailTo lists email addresses that receive the digest through SMTP.
+	EmailTo []string `yaml:"email_to,omitempty"`
+	// SMTP is the mail server used for EmailTo.
+	SMTP *SMTPConfig `yaml:"smtp,omitempty"`
+}
+
+// SMTPConfig holds an outgoing mail server. The password is read from the
+// environment variable named by PasswordEnv so it stays out of .beadhub.
+type SMTPConfig struct {
+	Host        string `yaml:"host,omitempty"`
+	Port        *int   `yaml:"port,omitempty"`
+	Username    string `yaml:"username,omitempty"`
+	PasswordEnv string `yaml:"password_env,omitempty"`
+	From        string `yaml:"from,omitempty"`
+}
+
+// DefaultSMTPPort is used when report.smtp.port is not set (submission).
+const DefaultSMTPPort = 587
+
+// SMTPPort returns report.smtp.port, or DefaultSMTPPort.
+func (s *SMTPConfig) SMTPPort() int {
+	if s == nil || s.Port == nil {
+		return DefaultSMTPPort
+	}
+	return *s.Port
+}
+
+// AwayConfig holds the chat auto-responder. While :dnd is on, bdh answers
+// new chat sessions once with Message; the rendered message is also sent with
+// presence refreshes so the server can answer for an agent that has been
+// offline longer than OfflineAfterMinutes.
+type AwayConfig struct {
+	AutoReply           *bool  `yaml:"auto_reply,omitempty"`
+	OfflineAfterMinutes *int   `yaml:"offline_after_minutes,omitempty"`
+	Message             string `yaml:"message,omitempty"`
+}
+
+// DefaultAwayOfflineAfterMinutes is used when away.offline_after_minutes is
+// not set.
+const DefaultAwayOfflineAfterMinutes = 15
+
+// DefaultAwayMessage is used when away.message is not set. See
+// AwayMessage for the placeholders.
+const DefaultAwayMessage = "Auto-reply: {alias} is away ({status}) and expects to be back {until}. {note}"
+
+// AwayAutoReplyEnabled returns away.auto_reply (default false).
+func (c *Config) AwayAutoReplyEnabled() bool {
+	if c.Away == nil || c.Away.AutoReply == nil {
+		return false
+	}
+	return *c.Away.AutoReply
+}
+
+// AwayOfflineAfter returns away.offline_after_minutes as a duration.
+func (c *Config) AwayOfflineAfter() time.Duration {
+	if c.Away == nil || c.Away.OfflineAfterMinutes == nil {
+		return DefaultAwayOfflineAfterMinutes * time.Minute
+	}
+	return time.Duration(*c.Away.OfflineAfterMinutes) * time.Minute
+}
+
+// AwayMessage returns the away.message template, or DefaultAwayMessage.
+// Placeholders: {alias}, {status}, {until}, {note}.
+func (c *Config) AwayMessage() string {
+	if c.Away == nil || strings.TrimSpace(c.Away.Message) == "" {
+		return DefaultAwayMessage
+	}
+	return c.Away.Message
+}
+
+// OutputConfig holds optional settings for command output.
+type OutputConfig struct {
+	// Verbosity is one of the Verbosity* levels; default normal.
+	Verbosity string `yaml:"verbosity,omitempty"`
+
+	// TimeFormat is one of the TimeFormat* styles. Unset keeps each output's
+	// built-in style (mostly relative, clock times in chat transcripts).
+	TimeFormat string `yaml:"time_format,omitempty"`
+
+	// Templates adds a section to ready output for workspaces with a given
+	// role, keyed by role. It replaces the policy's ready_template adapter.
+	Templates map[string]OutputTemplate `yaml:"templates,omitempty"`
+}
+
+// OutputTemplate is a ready output section written as a Go text/template.
+type OutputTemplate struct {
+	Title string `yaml:"title,omitempty"`
+	Body  string `yaml:"body"`
+}
+
+// Values for output.verbosity.
+const (
+	VerbosityQuiet   = "quiet"   // bd output and fatal warnings only
+	VerbosityNormal  = "normal"  // coordination sections, truncated
+	VerbosityVerbose = "verbose" // every coordination section, untruncated
+)
+
+// Values for output.time_format.
+const (
+	TimeFormatRelative = "relative" // "5m ago"
+	TimeFormatLocal    = "local"    // local time zone, locale-dependent layout
+	TimeFormatUTC      = "utc"      // "2006-01-02 15:04 UTC"
+	TimeFormatISO      = "iso"      // RFC 3339
+)
+
+// DisplayConfig holds optional settings for how agents are shown.
+type DisplayConfig struct {
+	// Aliases maps an agent alias to the name shown for it in team status,
+	// locks and chat, e.g. claude-be: "🤖 Backend". Commands and JSON output
+	// keep the alias.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+}
+
+// maxDisplayNameLen bounds a display.aliases name, in characters.
+const maxDisplayNameLen = 64
+
+// EscalationConfig holds optional settings for escalation reminders.
+type EscalationConfig struct {
+	// SLAMinutes is how long an escalation may stay pending before bdh reminds you.
+	SLAMinutes *int `yaml:"sla_minutes,omitempty"`
+	// ReminderIntervalMinutes is the minimum time between reminders for one escalation.
+	ReminderIntervalMinutes *int `yaml:"reminder_interval_minutes,omitempty"`
+}
+
+// PrivacyConfig holds optional settings for what is reported to the server.
+type PrivacyConfig struct {
+	// ReportCommandLine is one of the CommandLine* modes; default full.
+	ReportCommandLine string `yaml:"report_command_line,omitempty"`
+}
+
+// Values for privacy.report_command_line.
+const (
+	CommandLineFull   = "full"   // report the command line as typed
+	CommandLineRedact = "redact" // report only the bd subcommand
+	CommandLineHash   = "hash"   // report the subcommand and a hash of its arguments
+	CommandLineOff    = "off"    // report nothing
+)
+
+// DegradationConfig holds what bdh does on each coordination failure mode.
+// Each setting is one of the Degrade* values; all default to warn, which runs
+// bd regardless and only reports the failure.
+type DegradationConfig struct {
+	// OnServerUnreachable: warn or block (don't run mutating bd commands).
+	OnServerUnreachable string `yaml:"on_server_unreachable,omitempty"`
+	// OnSyncFailure: warn, queue (retry before the next command), or block
+	// (fail the command and refuse further mutations until the sync succeeds).
+	OnSyncFailure string `yaml:"on_sync_failure,omitempty"`
+	// OnReservationConflict: warn or block (don't run mutating bd commands
+	// while files you changed are reserved by someone else).
+	OnReservationConflict string `yaml:"on_reservation_conflict,omitempty"`
+}
+
+// Values for the degradation settings.
+const (
+	DegradeWarn  = "warn"
+	DegradeQueue = "queue"
+	DegradeBlock = "block"
+)
+
+// Escalation reminder defaults, used when the escalations settings are not set.
+const (
+	DefaultEscalationSLAMinutes              = 60
+	DefaultEscalationReminderIntervalMinutes = 30
+)
+
+// DefaultSyncMaxDeletePercent is used when sync.max_delete_percent is not set.
+const DefaultSyncMaxDeletePercent = 20
+
 func (c *Config) AutoReserveEnabled() bool {
+	// Auto-reserve works from git status.
+	if c.NoGit {
+		return false
+	}
 	if c.AutoReserve == nil {
 		return true
 	}
@@ -110,18 +474,247 @@ func (c *Config) ReserveUntrackedEnabled() bool {
 	return *c.ReserveUntracked
 }
 
+// ReportConflictsEnabled reports whether auto-reserve conflicts are sent to
+// the server (default false: they are only recorded locally).
+func (c *Config) ReportConflictsEnabled() bool {
+	return c.ReportConflicts != nil && *c.ReportConflicts
+}
+
+func (c *Config) SyncMaxDeletePercent() int {
+	if c.Sync == nil || c.Sync.MaxDeletePercent == nil {
+		return DefaultSyncMaxDeletePercent
+	}
+	return *c.Sync.MaxDeletePercent
+}
+
+// SyncStrictEnabled returns sync.strict (default false).
+func (c *Config) SyncStrictEnabled() bool {
+	if c.Sync == nil || c.Sync.Strict == nil {
+		return false
+	}
+	return *c.Sync.Strict
+}
+
+func (c *Config) HTTPPrewarmEnabled() bool {
+	if c.HTTP == nil || c.HTTP.Prewarm == nil {
+		return false
+	}
+	return *c.HTTP.Prewarm
+}
+
+// PresenceInterval returns http.presence_interval_seconds, or
+// DefaultPresenceIntervalSeconds.
+func (c *Config) PresenceInterval() time.Duration {
+	if c.HTTP == nil || c.HTTP.PresenceIntervalSeconds == nil {
+		return DefaultPresenceIntervalSeconds * time.Second
+	}
+	return time.Duration(*c.HTTP.PresenceIntervalSeconds) * time.Second
+}
+
+// DesktopNotificationsEnabled returns notifications.desktop (default false).
+func (c *Config) DesktopNotificationsEnabled() bool {
+	if c.Notifications == nil || c.Notifications.Desktop == nil {
+		return false
+	}
+	return *c.Notifications.Desktop
+}
+
+// AutoAckDisplayedEnabled returns notifications.auto_ack_displayed (default false).
+func (c *Config) AutoAckDisplayedEnabled() bool {
+	if c.Notifications == nil || c.Notifications.AutoAckDisplayed == nil {
+		return false
+	}
+	return *c.Notifications.AutoAckDisplayed
+}
+
+// MetricsEnabled returns metrics.enabled (default false).
+func (c *Config) MetricsEnabled() bool {
+	if c.Metrics == nil || c.Metrics.Enabled == nil {
+		return false
+	}
+	return *c.Metrics.Enabled
+}
+
+// BdPTYEnabled reports whether bd.pty is set (default false).
+func (c *Config) BdPTYEnabled() bool {
+	if c.BD == nil || c.BD.PTY == nil {
+		return false
+	}
+	return *c.BD.PTY
+}
+
+// BdCommand returns bd.command, or nil to run bd from PATH.
+func (c *Config) BdCommand() []string {
+	if c.BD == nil || len(c.BD.Command) == 0 {
+		return nil
+	}
+	return c.BD.Command
+}
+
+// ApprovalsEnabled returns supervision.approvals (default false).
+func (c *Config) ApprovalsEnabled() bool {
+	if c.Supervision == nil || c.Supervision.Approvals == nil {
+		return false
+	}
+	return *c.Supervision.Approvals
+}
+
+// HighRiskCommands returns supervision.high_risk.
+func (c *Config) HighRiskCommands() []string {
+	if c.Supervision == nil {
+		return nil
+	}
+	return c.Supervision.HighRisk
+}
+
+// DefaultApproverRole is the role allowed to decide approval requests when
+// supervision.approver_roles is not set.
+const DefaultApproverRole = "supervisor"
+
+// ApproverRoles returns supervision.approver_roles, normalized, defaulting
+// to DefaultApproverRole.
+func (c *Config) ApproverRoles() []string {
+	if c.Supervision == nil || len(c.Supervision.ApproverRoles) == 0 {
+		return []string{DefaultApproverRole}
+	}
+	roles := make([]string, 0, len(c.Supervision.ApproverRoles))
+	for _, role := range c.Supervision.ApproverRoles {
+		if role = NormalizeRole(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// BdEnv returns bd.env as KEY=VALUE entries, sorted by key.
+func (c *Config) BdEnv() []string {
+	if c.BD == nil || len(c.BD.Env) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(c.BD.Env))
+	for k := range c.BD.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, k+"="+c.BD.Env[k])
+	}
+	return env
+}
+
+// DisplayName returns the display.aliases name for alias, or alias itself
+// when none is set.
+func (c *Config) DisplayName(alias string) string {
+	if c.Display == nil {
+		return alias
+	}
+	if name := strings.TrimSpace(c.Display.Aliases[alias]); name != "" {
+		return name
+	}
+	return alias
+}
+
+// HTTPStrictDecodingEnabled reports whether http.strict_decoding is set
+// (default false).
+func (c *Config) HTTPStrictDecodingEnabled() bool {
+	if c.HTTP == nil || c.HTTP.StrictDecoding == nil {
+		return false
+	}
+	return *c.HTTP.StrictDecoding
+}
+
+// Verbosity returns the output.verbosity level.
+func (c *Config) Verbosity() string {
+	if c.Output == nil || c.Output.Verbosity == "" {
+		return VerbosityNormal
+	}
+	return c.Output.Verbosity
+}
+
+// TimeFormat returns the output.time_format style, or "" when unset.
+func (c *Config) TimeFormat() string {
+	if c.Output == nil {
+		return ""
+	}
+	return c.Output.TimeFormat
+}
+
+// OutputTemplate returns output.templates for role, if any.
+func (c *Config) OutputTemplate(role string) (OutputTemplate, bool) {
+	if c.Output == nil {
+		return OutputTemplate{}, false
+	}
+	role = NormalizeRole(role)
+	for key, tmpl := range c.Output.Templates {
+		if NormalizeRole(key) == role && strings.TrimSpace(tmpl.Body) != "" {
+			return tmpl, true
+		}
+	}
+	return OutputTemplate{}, false
+}
+
+// CommandLineReporting returns the privacy.report_command_line mode.
+func (c *Config) CommandLineReporting() string {
+	if c.Privacy == nil || c.Privacy.ReportCommandLine == "" {
+		return CommandLineFull
+	}
+	return c.Privacy.ReportCommandLine
+}
+
+// OnServerUnreachable returns the degradation.on_server_unreachable mode.
+func (c *Config) OnServerUnreachable() string {
+	if c.Degradation == nil || c.Degradation.OnServerUnreachable == "" {
+		return DegradeWarn
+	}
+	return c.Degradation.OnServerUnreachable
+}
+
+// OnSyncFailure returns the degradation.on_sync_failure mode.
+func (c *Config) OnSyncFailure() string {
+	if c.Degradation == nil || c.Degradation.OnSyncFailure == "" {
+		return DegradeWarn
+	}
+	return c.Degradation.OnSyncFailure
+}
+
+// OnReservationConflict returns the degradation.on_reservation_conflict mode.
+func (c *Config) OnReservationConflict() string {
+	if c.Degradation == nil || c.Degradation.OnReservationConflict == "" {
+		return DegradeWarn
+	}
+	return c.Degradation.OnReservationConflict
+}
+
+func (c *Config) EscalationSLA() time.Duration {
+	minutes := DefaultEscalationSLAMinutes
+	if c.Escalations != nil && c.Escalations.SLAMinutes != nil {
+		minutes = *c.Escalations.SLAMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func (c *Config) EscalationReminderInterval() time.Duration {
+	minutes := DefaultEscalationReminderIntervalMinutes
+	if c.Escalations != nil && c.Escalations.ReminderIntervalMinutes != nil {
+		minutes = *c.Escalations.ReminderIntervalMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
 // Load reads and parses the .beadhub configuration file.
 // Uses the custom path if set via SetPath(), otherwise uses the default FileName.
+// If a project override matches the current directory, it is applied.
 func Load() (*Config, error) {
 	if customPath != "" {
-		return LoadFrom(customPath)
+		return loadWithOverrides(customPath)
 	}
 
 	path, err := findDefaultConfigPath()
 	if err != nil {
 		return nil, err
 	}
-	return LoadFrom(path)
+	return loadWithOverrides(path)
 }
 
 // LoadFrom reads and parses a .beadhub configuration file from a specific path.
@@ -196,6 +789,9 @@ func findGitRoot(start string) (string, bool) {
 // Save writes the configuration to the config file.
 // Uses the custom path if set via SetPath(), otherwise uses the default FileName.
 func (c *Config) Save() error {
+	if c.ActiveOverride != "" {
+		return fmt.Errorf("cannot save config with project override %q applied - run from outside %s", c.ActiveOverride, c.ActiveOverride)
+	}
 	path := GetPath()
 	data, err := yaml.Marshal(c)
 	if err != nil {
@@ -203,8 +799,14 @@ func (c *Config) Save() error {
 	}
 
 	// Write with header comment
-	header := "# Generated by: bdh init\n# DO NOT COMMIT - add to .gitignore\n\n"
-	content := header + string(data)
+	header := "# Generated by: bdh init\n# DO NOT COMMIT - add to .gitignore\n"
+	// Keep an editor $schema comment the user added.
+	if existing, err := os.ReadFile(path); err == nil {
+		if ref := SchemaReference(existing); ref != "" {
+			header += fmt.Sprintf("# yaml-language-server: $schema=%s\n", ref)
+		}
+	}
+	content := header + "\n" + string(data)
 
 	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
 		return fmt.Errorf("writing %s: %w", path, err)
@@ -215,56 +817,46 @@ func (c *Config) Save() error {
 
 // Validate checks that all required fields are present and valid.
 func (c *Config) Validate() error {
-	if c.WorkspaceID == "" {
-		return fmt.Errorf("workspace_id is required")
-	}
-	if !uuidPattern.MatchString(c.WorkspaceID) {
-		return fmt.Errorf("workspace_id must be a valid UUID")
-	}
-	if c.BeadhubURL == "" {
-		return fmt.Errorf("beadhub_url is required")
-	}
-	if !urlPattern.MatchString(c.BeadhubURL) {
-		return fmt.Errorf("beadhub_url must be a valid HTTP(S) URL")
-	}
-	if c.ProjectSlug == "" {
-		return fmt.Errorf("project_slug is required")
-	}
-	if !projectSlugPattern.MatchString(c.ProjectSlug) {
-		return fmt.Errorf("project_slug must be lowercase alphanumeric with hyphens")
-	}
-	if c.RepoID != "" && !uuidPattern.MatchString(c.RepoID) {
-		return fmt.Errorf("repo_id must be a valid UUID")
-	}
-	if c.RepoOrigin == "" {
-		return fmt.Errorf("repo_origin is required")
-	}
-	if !repoOriginPattern.MatchString(c.RepoOrigin) {
-		return fmt.Errorf("repo_origin must be a git SSH URL (git@host:path) or HTTPS URL")
+	var node yaml.Node
+	if err := node.Encode(c); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
 	}
-	if c.CanonicalOrigin == "" {
-		return fmt.Errorf("canonical_origin is required")
+	v := validator{lenient: true}
+	v.object(&node, configSchema, "")
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
 	}
-	if !canonicalOriginPattern.MatchString(c.CanonicalOrigin) {
-		return fmt.Errorf("canonical_origin must be in format host/org/repo (e.g., github.com/org/repo)")
-	}
-	if c.Alias == "" {
-		return fmt.Errorf("alias is required")
-	}
-	if !aliasPattern.MatchString(c.Alias) {
-		return fmt.Errorf("alias must start with an alphanumeric and contain only alphanumerics, dashes, or underscores (max 64 chars)")
-	}
-	if c.HumanName == "" {
-		return fmt.Errorf("human_name is required")
-	}
-	if !humanNamePattern.MatchString(c.HumanName) {
-		return fmt.Errorf("human_name must start with a letter and contain only letters, digits, spaces, hyphens, or apostrophes (max 64 chars)")
-	}
-	if c.Role != "" && !IsValidRole(c.Role) {
-		return fmt.Errorf("role must be 1-2 words (letters/numbers) with hyphens/underscores allowed; max 50 chars")
+	return nil
+}
+
+// IsValidCommandAlias checks if name can be used as a command alias.
+func IsValidCommandAlias(name string) bool {
+	return commandAliasPattern.MatchString(name)
+}
+
+// TemplateVariables returns the variables a message template uses, in order
+// of first use. ok is false when a "{{" does not open a valid placeholder.
+func TemplateVariables(template string) (vars []string, ok bool) {
+	matches := templateVarPattern.FindAllStringSubmatch(template, -1)
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			vars = append(vars, m[1])
+		}
 	}
+	return vars, strings.Count(template, "{{") == len(matches)
+}
 
-	return nil
+// RenderTemplate replaces each placeholder in template with its value.
+// Placeholders without a value are left as they are.
+func RenderTemplate(template string, values map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		if v, ok := values[templateVarPattern.FindStringSubmatch(placeholder)[1]]; ok {
+			return v
+		}
+		return placeholder
+	})
 }
 
 // IsValidAlias checks if the alias matches the server-compatible workspace alias rules.
@@ -303,6 +895,41 @@ func IsValidRole(role string) bool {
 	return true
 }
 
+// NormalizeTags lowercases and trims capability tags and drops blanks and
+// duplicates, keeping the first occurrence's position.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	return out
+}
+
+// IsValidTag checks a normalized capability tag: a lowercase word of up to
+// 32 characters, where + # . _ - are allowed after the first ("c++", "c#").
+func IsValidTag(tag string) bool {
+	return tagPattern.MatchString(tag)
+}
+
+// ValidateTags checks a normalized tag list.
+func ValidateTags(tags []string) error {
+	if len(tags) > maxTags {
+		return fmt.Errorf("at most %d tags are allowed", maxTags)
+	}
+	for _, tag := range tags {
+		if !IsValidTag(tag) {
+			return fmt.Errorf("invalid tag %q: use lowercase letters, digits and + # . _ - (max 32 chars)", tag)
+		}
+	}
+	return nil
+}
+
 // RoleToAliasPrefix converts a role to a hyphenated alias prefix.
 func RoleToAliasPrefix(role string) string {
 	return strings.ReplaceAll(NormalizeRole(role), " ", "-")
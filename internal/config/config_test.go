@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadAndSave(t *testing.T) {
@@ -530,6 +531,20 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "sync max_delete_percent out of range",
+			cfg: Config{
+				WorkspaceID:     "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+				BeadhubURL:      "http://localhost:8000",
+				ProjectSlug:     "beadhub",
+				RepoOrigin:      "git@github.com:anthropic/beadhub.git",
+				CanonicalOrigin: "github.com/anthropic/beadhub",
+				Alias:           "claude-code",
+				HumanName:       "Juan",
+				Sync:            &SyncConfig{MaxDeletePercent: intPtr(150)},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -638,3 +653,119 @@ func TestIsValidSlug(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncMaxDeletePercent(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.SyncMaxDeletePercent(); got != DefaultSyncMaxDeletePercent {
+		t.Errorf("default = %d, want %d", got, DefaultSyncMaxDeletePercent)
+	}
+	cfg.Sync = &SyncConfig{MaxDeletePercent: intPtr(50)}
+	if got := cfg.SyncMaxDeletePercent(); got != 50 {
+		t.Errorf("configured = %d, want 50", got)
+	}
+}
+
+func TestSyncStrictEnabled(t *testing.T) {
+	cfg := &Config{}
+	if cfg.SyncStrictEnabled() {
+		t.Error("strict sync should default to off")
+	}
+	strict := true
+	cfg.Sync = &SyncConfig{Strict: &strict}
+	if !cfg.SyncStrictEnabled() {
+		t.Error("sync.strict: true should enable strict sync")
+	}
+}
+
+func TestPresenceInterval(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.PresenceInterval(); got != DefaultPresenceIntervalSeconds*time.Second {
+		t.Errorf("default = %v", got)
+	}
+	cfg.HTTP = &HTTPConfig{PresenceIntervalSeconds: intPtr(0)}
+	if got := cfg.PresenceInterval(); got != 0 {
+		t.Errorf("configured = %v, want 0", got)
+	}
+}
+
+func TestBdEnv(t *testing.T) {
+	cfg := &Config{}
+	if env := cfg.BdEnv(); env != nil {
+		t.Errorf("default = %v, want none", env)
+	}
+	cfg.BD = &BDConfig{Env: map[string]string{"BD_NO_DAEMON": "1", "BEADS_DB": "/tmp/b.db"}}
+	if got := strings.Join(cfg.BdEnv(), " "); got != "BD_NO_DAEMON=1 BEADS_DB=/tmp/b.db" {
+		t.Errorf("BdEnv() = %q", got)
+	}
+}
+
+func TestBdCommand(t *testing.T) {
+	cfg := &Config{}
+	if cmd := cfg.BdCommand(); cmd != nil {
+		t.Errorf("default = %v, want none", cmd)
+	}
+	cfg.BD = &BDConfig{Command: []string{"devbox", "run", "bd"}}
+	if got := strings.Join(cfg.BdCommand(), " "); got != "devbox run bd" {
+		t.Errorf("BdCommand() = %q", got)
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.DisplayName("claude-be"); got != "claude-be" {
+		t.Errorf("default = %q", got)
+	}
+	cfg.Display = &DisplayConfig{Aliases: map[string]string{"claude-be": " 🤖 Backend ", "claude-fe": " "}}
+	if got := cfg.DisplayName("claude-be"); got != "🤖 Backend" {
+		t.Errorf("DisplayName(claude-be) = %q", got)
+	}
+	if got := cfg.DisplayName("claude-fe"); got != "claude-fe" {
+		t.Errorf("blank name: DisplayName(claude-fe) = %q", got)
+	}
+}
+
+func TestOutputTemplate(t *testing.T) {
+	cfg := &Config{}
+	if _, ok := cfg.OutputTemplate("reviewer"); ok {
+		t.Error("no templates configured")
+	}
+	cfg.Output = &OutputConfig{Templates: map[string]OutputTemplate{
+		"Reviewer": {Title: "Review queue", Body: "{{.Alias}}"},
+		"qa":       {Title: "Empty"},
+	}}
+	if tmpl, ok := cfg.OutputTemplate("reviewer"); !ok || tmpl.Title != "Review queue" {
+		t.Errorf("OutputTemplate(reviewer) = %+v, %v", tmpl, ok)
+	}
+	if _, ok := cfg.OutputTemplate("qa"); ok {
+		t.Error("a template without a body should be ignored")
+	}
+}
+
+func TestNormalizeAndValidateTags(t *testing.T) {
+	tags := NormalizeTags([]string{" Python", "infra", "python", "", "C++"})
+	if strings.Join(tags, ",") != "python,infra,c++" {
+		t.Errorf("NormalizeTags = %v", tags)
+	}
+	if err := ValidateTags(tags); err != nil {
+		t.Errorf("ValidateTags(%v) = %v", tags, err)
+	}
+	for _, bad := range [][]string{{"-x"}, {"has space"}, {strings.Repeat("a", 33)}} {
+		if err := ValidateTags(bad); err == nil {
+			t.Errorf("ValidateTags(%q) should fail", bad)
+		}
+	}
+}
+
+func TestTemplateVariables(t *testing.T) {
+	vars, ok := TemplateVariables("status: {{status}}, next: {{ next }}, again {{status}}")
+	if !ok || strings.Join(vars, ",") != "status,next" {
+		t.Errorf("TemplateVariables = %v, %v", vars, ok)
+	}
+	if _, ok := TemplateVariables("broken {{ status"); ok {
+		t.Error("an unclosed placeholder should be invalid")
+	}
+	got := RenderTemplate("status: {{status}}, next: {{ next }}", map[string]string{"status": "green"})
+	if got != "status: green, next: {{ next }}" {
+		t.Errorf("RenderTemplate = %q", got)
+	}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectOverride routes commands run under a path prefix to another project.
+//
+// Monorepos that host beads for several products can declare, in .beadhub:
+//
+//	project_overrides:
+//	  services/billing:
+//	    project_slug: billing
+//	    workspace_id: "uuid"   # workspace registered in the billing project
+//	    repo_id: "uuid"
+//
+// Unset fields fall back to the top-level values. API keys are still resolved
+// from the aw config, so a .aw/context inside the prefix can select a
+// different account.
+type ProjectOverride struct {
+	ProjectSlug string `yaml:"project_slug"`
+	WorkspaceID string `yaml:"workspace_id,omitempty"`
+	RepoID      string `yaml:"repo_id,omitempty"`
+	BeadhubURL  string `yaml:"beadhub_url,omitempty"`
+	Alias       string `yaml:"alias,omitempty"`
+	Role        string `yaml:"role,omitempty"`
+}
+
+// NormalizeOverridePrefix cleans a path prefix into slash-separated form
+// relative to the workspace root ("services/billing"). Returns "" for the root.
+func NormalizeOverridePrefix(prefix string) string {
+	p := path.Clean(filepath.ToSlash(strings.TrimSpace(prefix)))
+	p = strings.TrimPrefix(p, "./")
+	if p == "." || p == "/" {
+		return ""
+	}
+	return strings.TrimSuffix(p, "/")
+}
+
+// ResolveProjectOverride returns the override whose prefix contains relDir
+// (slash-separated, relative to the workspace root). The longest matching
+// prefix wins. Returns "", nil when no override applies.
+func (c *Config) ResolveProjectOverride(relDir string) (string, *ProjectOverride) {
+	dir := NormalizeOverridePrefix(relDir)
+	bestPrefix := ""
+	var best *ProjectOverride
+	for rawPrefix, override := range c.ProjectOverrides {
+		prefix := NormalizeOverridePrefix(rawPrefix)
+		if prefix == "" {
+			continue
+		}
+		if dir != prefix && !strings.HasPrefix(dir, prefix+"/") {
+			continue
+		}
+		if len(prefix) > len(bestPrefix) {
+			o := override
+			bestPrefix, best = prefix, &o
+		}
+	}
+	return bestPrefix, best
+}
+
+// ApplyProjectOverride replaces project-scoped fields with the override's values.
+func (c *Config) ApplyProjectOverride(prefix string, o *ProjectOverride) {
+	if o == nil {
+		return
+	}
+	c.ProjectSlug = o.ProjectSlug
+	if o.WorkspaceID != "" {
+		c.WorkspaceID = o.WorkspaceID
+	}
+	if o.RepoID != "" {
+		c.RepoID = o.RepoID
+	}
+	if o.BeadhubURL != "" {
+		c.BeadhubURL = o.BeadhubURL
+	}
+	if o.Alias != "" {
+		c.Alias = o.Alias
+	}
+	if o.Role != "" {
+		c.Role = o.Role
+	}
+	c.ActiveOverride = prefix
+}
+
+// RelativeWorkingDir returns the current directory relative to the directory
+// containing the config file at configPath, or ok=false if it is outside it.
+func RelativeWorkingDir(configPath string) (string, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	root, err := filepath.Abs(filepath.Dir(configPath))
+	if err != nil {
+		return "", false
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(cwd); err == nil {
+		cwd = resolved
+	}
+	rel, err := filepath.Rel(root, cwd)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func loadWithOverrides(configPath string) (*Config, error) {
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.ProjectOverrides) == 0 {
+		return cfg, nil
+	}
+	if rel, ok := RelativeWorkingDir(configPath); ok {
+		if prefix, override := cfg.ResolveProjectOverride(rel); override != nil {
+			cfg.ApplyProjectOverride(prefix, override)
+		}
+	}
+	return cfg, nil
+}
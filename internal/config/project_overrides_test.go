@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveProjectOverride_LongestPrefixWins(t *testing.T) {
+	cfg := &Config{
+		ProjectSlug: "platform",
+		ProjectOverrides: map[string]ProjectOverride{
+			"services":          {ProjectSlug: "services"},
+			"services/billing/": {ProjectSlug: "billing"},
+		},
+	}
+
+	tests := []struct {
+		dir        string
+		wantPrefix string
+		wantSlug   string
+	}{
+		{"", "", ""},
+		{"docs", "", ""},
+		{"services", "services", "services"},
+		{"services/auth", "services", "services"},
+		{"services/billing", "services/billing", "billing"},
+		{"services/billing/api/v2", "services/billing", "billing"},
+		{"services/billing-legacy", "services", "services"},
+		{"servicesx", "", ""},
+	}
+	for _, tt := range tests {
+		prefix, override := cfg.ResolveProjectOverride(tt.dir)
+		if prefix != tt.wantPrefix {
+			t.Errorf("ResolveProjectOverride(%q) prefix = %q, want %q", tt.dir, prefix, tt.wantPrefix)
+		}
+		gotSlug := ""
+		if override != nil {
+			gotSlug = override.ProjectSlug
+		}
+		if gotSlug != tt.wantSlug {
+			t.Errorf("ResolveProjectOverride(%q) slug = %q, want %q", tt.dir, gotSlug, tt.wantSlug)
+		}
+	}
+}
+
+func TestLoad_AppliesProjectOverrideFromSubdir(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "repo")
+	subDir := filepath.Join(repoDir, "services", "billing", "api")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0755); err != nil {
+		t.Fatalf("MkdirAll(.git) error: %v", err)
+	}
+
+	data := []byte(`workspace_id: "a1b2c3d4-5678-90ab-cdef-1234567890ab"
+beadhub_url: "http://localhost:8000"
+project_slug: "platform"
+repo_origin: "git@github.com:acme/mono.git"
+canonical_origin: "github.com/acme/mono"
+alias: "claude-code"
+human_name: "Juan"
+project_overrides:
+  services/billing:
+    project_slug: "billing"
+    workspace_id: "b2c3d4e5-6789-01ab-cdef-234567890abc"
+`)
+	if err := os.WriteFile(filepath.Join(repoDir, FileName), data, 0600); err != nil {
+		t.Fatalf("WriteFile(.beadhub) error: %v", err)
+	}
+
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+
+	if err := os.Chdir(subDir); err != nil {
+		t.Fatalf("Chdir() error: %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.ProjectSlug != "billing" || loaded.WorkspaceID != "b2c3d4e5-6789-01ab-cdef-234567890abc" {
+		t.Errorf("override not applied: slug=%q workspace=%q", loaded.ProjectSlug, loaded.WorkspaceID)
+	}
+	if loaded.Alias != "claude-code" {
+		t.Errorf("Alias = %q, want inherited %q", loaded.Alias, "claude-code")
+	}
+	if loaded.ActiveOverride != "services/billing" {
+		t.Errorf("ActiveOverride = %q", loaded.ActiveOverride)
+	}
+	if err := loaded.Validate(); err != nil {
+		t.Errorf("Validate() error: %v", err)
+	}
+	if err := loaded.Save(); err == nil || !strings.Contains(err.Error(), "project override") {
+		t.Errorf("Save() with override applied should fail, got: %v", err)
+	}
+
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Chdir() error: %v", err)
+	}
+	loaded, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.ProjectSlug != "platform" || loaded.ActiveOverride != "" {
+		t.Errorf("expected default project at root, got slug=%q override=%q", loaded.ProjectSlug, loaded.ActiveOverride)
+	}
+}
+
+func TestValidate_ProjectOverrides(t *testing.T) {
+	base := func(overrides map[string]ProjectOverride) *Config {
+		return &Config{
+			WorkspaceID:      "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+			BeadhubURL:       "http://localhost:8000",
+			ProjectSlug:      "platform",
+			RepoOrigin:       "git@github.com:acme/mono.git",
+			CanonicalOrigin:  "github.com/acme/mono",
+			Alias:            "claude-code",
+			HumanName:        "Juan",
+			ProjectOverrides: overrides,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]ProjectOverride
+		wantErr   string
+	}{
+		{"valid", map[string]ProjectOverride{"services/billing": {ProjectSlug: "billing"}}, ""},
+		{"missing slug", map[string]ProjectOverride{"services/billing": {}}, "project_slug is required"},
+		{"bad slug", map[string]ProjectOverride{"services/billing": {ProjectSlug: "Billing"}}, "lowercase"},
+		{"root prefix", map[string]ProjectOverride{".": {ProjectSlug: "billing"}}, "relative to the workspace root"},
+		{"escaping prefix", map[string]ProjectOverride{"../other": {ProjectSlug: "billing"}}, "relative to the workspace root"},
+		{"absolute prefix", map[string]ProjectOverride{"/srv/billing": {ProjectSlug: "billing"}}, "relative to the workspace root"},
+		{"bad workspace", map[string]ProjectOverride{"billing": {ProjectSlug: "billing", WorkspaceID: "nope"}}, "workspace_id must be a valid UUID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := base(tt.overrides).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
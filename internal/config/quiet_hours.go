@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Quiet hours (notifications.quiet_hours) are local-time windows during which
+// non-urgent notifications are held back:
+//
+//	notifications:
+//	  quiet_hours:
+//	    - "22:00-07:00"        # every night
+//	    - "mon-fri 09:00-11:30" # weekday focus block
+//
+// A window may cross midnight; its days are the days it starts on. 24:00
+// ends a window at midnight.
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// QuietWindow is one parsed quiet_hours entry.
+type QuietWindow struct {
+	days       uint8 // bit per time.Weekday; 0 means every day
+	start, end int   // minutes after midnight
+}
+
+// ParseQuietWindow parses "[days ]HH:MM-HH:MM", where days is a weekday
+// range (mon-fri) or list (sat,sun).
+func ParseQuietWindow(spec string) (QuietWindow, error) {
+	var w QuietWindow
+	fields := strings.Fields(strings.ToLower(spec))
+	if len(fields) == 0 || len(fields) > 2 {
+		return w, fmt.Errorf("expected [days ]HH:MM-HH:MM, got %q", spec)
+	}
+	if len(fields) == 2 {
+		days, err := parseWeekdays(fields[0])
+		if err != nil {
+			return w, err
+		}
+		w.days = days
+	}
+	from, to, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return w, fmt.Errorf("expected a HH:MM-HH:MM range, got %q", fields[len(fields)-1])
+	}
+	var err error
+	if w.start, err = parseClock(from, false); err != nil {
+		return w, err
+	}
+	if w.end, err = parseClock(to, true); err != nil {
+		return w, err
+	}
+	if w.start == w.end {
+		return w, fmt.Errorf("window %q is empty", spec)
+	}
+	return w, nil
+}
+
+func parseWeekdays(s string) (uint8, error) {
+	var mask uint8
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := weekdayNames[from]
+		if !ok {
+			return 0, fmt.Errorf("unknown day %q (use mon, tue, ... sun)", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdayNames[to]; !ok {
+				return 0, fmt.Errorf("unknown day %q (use mon, tue, ... sun)", to)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			mask |= 1 << d
+			if d == last {
+				break
+			}
+		}
+	}
+	return mask, nil
+}
+
+// parseClock parses HH:MM into minutes after midnight; 24:00 only as an end.
+func parseClock(s string, isEnd bool) (int, error) {
+	hh, mm, ok := strings.Cut(s, ":")
+	h, herr := strconv.Atoi(hh)
+	m, merr := strconv.Atoi(mm)
+	if !ok || herr != nil || merr != nil || len(mm) != 2 || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && (m != 0 || !isEnd)) {
+		return 0, fmt.Errorf("invalid time %q (use HH:MM)", s)
+	}
+	return h*60 + m, nil
+}
+
+func (w QuietWindow) onDay(d time.Weekday) bool {
+	return w.days == 0 || w.days&(1<<d) != 0
+}
+
+// activeAt returns the end of the window if t falls inside it.
+func (w QuietWindow) activeAt(t time.Time) (time.Time, bool) {
+	minute := t.Hour()*60 + t.Minute()
+	y, mo, d := t.Date()
+	endOn := func(dayOffset int) time.Time {
+		return time.Date(y, mo, d+dayOffset, w.end/60, w.end%60, 0, 0, t.Location())
+	}
+	if w.start < w.end {
+		if w.onDay(t.Weekday()) && minute >= w.start && minute < w.end {
+			return endOn(0), true
+		}
+		return time.Time{}, false
+	}
+	// Crosses midnight
+	if w.onDay(t.Weekday()) && minute >= w.start {
+		return endOn(1), true
+	}
+	if w.onDay((t.Weekday()+6)%7) && minute < w.end {
+		return endOn(0), true
+	}
+	return time.Time{}, false
+}
+
+// QuietHoursUntil reports whether t is within notifications.quiet_hours and,
+// if so, when the quiet period ends. Back-to-back windows count as one.
+// Invalid entries are ignored (Validate reports them).
+func (c *Config) QuietHoursUntil(t time.Time) (time.Time, bool) {
+	if c.Notifications == nil || len(c.Notifications.QuietHours) == 0 {
+		return time.Time{}, false
+	}
+	var windows []QuietWindow
+	for _, spec := range c.Notifications.QuietHours {
+		if w, err := ParseQuietWindow(spec); err == nil {
+			windows = append(windows, w)
+		}
+	}
+	var until time.Time
+	quiet := false
+	at := t
+	for range len(windows) + 1 {
+		extended := false
+		for _, w := range windows {
+			if end, ok := w.activeAt(at); ok && end.After(until) {
+				until, quiet, extended = end, true, true
+			}
+		}
+		if !extended {
+			break
+		}
+		at = until
+	}
+	return until, quiet
+}
+
+func isQuietWindow(spec string) bool {
+	_, err := ParseQuietWindow(spec)
+	return err == nil
+}
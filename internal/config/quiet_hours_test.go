@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuietWindow_Errors(t *testing.T) {
+	for _, spec := range []string{"", "22:00", "22:00-22:00", "25:00-07:00", "22:00-07:60", "24:00-07:00", "22:0-07:00", "weekdays 09:00-10:00", "mon-fri 09:00-10:00 extra"} {
+		if _, err := ParseQuietWindow(spec); err == nil {
+			t.Errorf("ParseQuietWindow(%q) should fail", spec)
+		}
+	}
+}
+
+func TestQuietHoursUntil(t *testing.T) {
+	cfg := &Config{Notifications: &NotificationsConfig{QuietHours: []string{"22:00-24:00", "00:00-07:00", "mon-fri 12:00-13:30", "bogus"}}}
+	at := func(day, hour, minute int) time.Time {
+		// March 2026: the 2nd is a Monday
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	for _, tc := range []struct {
+		now   time.Time
+		quiet bool
+		until time.Time
+	}{
+		{at(2, 23, 0), true, at(3, 7, 0)}, // back-to-back windows merge
+		{at(3, 6, 59), true, at(3, 7, 0)},
+		{at(3, 7, 0), false, time.Time{}},
+		{at(3, 12, 15), true, at(3, 13, 30)},
+		{at(7, 12, 15), false, time.Time{}}, // Saturday
+	} {
+		until, quiet := cfg.QuietHoursUntil(tc.now)
+		if quiet != tc.quiet || !until.Equal(tc.until) {
+			t.Errorf("QuietHoursUntil(%v) = %v, %v; want %v, %v", tc.now, until, quiet, tc.until, tc.quiet)
+		}
+	}
+
+	// A window crossing midnight belongs to the day it starts on
+	fri := &Config{Notifications: &NotificationsConfig{QuietHours: []string{"fri 18:00-09:00"}}}
+	if until, quiet := fri.QuietHoursUntil(at(7, 8, 0)); !quiet || !until.Equal(at(7, 9, 0)) {
+		t.Errorf("Saturday morning should still be in Friday's window, got %v, %v", until, quiet)
+	}
+	if _, quiet := fri.QuietHoursUntil(at(6, 8, 0)); quiet {
+		t.Error("Friday morning belongs to Thursday's window, which is not configured")
+	}
+	if _, quiet := (&Config{}).QuietHoursUntil(at(2, 23, 0)); quiet {
+		t.Error("no quiet hours configured")
+	}
+}
@@ -0,0 +1,548 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The .beadhub schema drives both Validate (on a loaded Config) and
+// ValidateBytes (on the raw file, which can also report unknown keys and line
+// numbers). All problems are collected rather than stopping at the first.
+
+type fieldType string
+
+const (
+	typeString  fieldType = "string"
+	typeBoolean fieldType = "boolean"
+	typeInteger fieldType = "integer"
+	typeObject  fieldType = "object" // fixed set of Fields
+	typeMap     fieldType = "map"    // arbitrary keys, each value an object of Fields
+	typeList    fieldType = "array"  // sequence of scalars, each checked against Value
+)
+
+// fieldSchema describes one key in the .beadhub file.
+type fieldSchema struct {
+	Key         string
+	Type        fieldType
+	Required    bool
+	Description string
+
+	// String values: Pattern or Check must pass, otherwise Message is reported.
+	Pattern *regexp.Regexp
+	Check   func(string) bool
+	Message string
+
+	// Integer bounds.
+	Min, Max *int
+
+	// Nested fields for objects and map values.
+	Fields []fieldSchema
+	// Value describes scalar map values and list items; when nil, map values
+	// are objects of Fields.
+	Value *fieldSchema
+	// KeyCheck validates map keys; returns a message suffix on failure.
+	KeyCheck func(string) string
+}
+
+func intPtr(v int) *int { return &v }
+
+var projectOverrideSchema = []fieldSchema{
+	{Key: "project_slug", Type: typeString, Required: true, Pattern: projectSlugPattern,
+		Message: "must be lowercase alphanumeric with hyphens", Description: "Project these directories belong to"},
+	{Key: "workspace_id", Type: typeString, Pattern: uuidPattern,
+		Message: "must be a valid UUID", Description: "Workspace registered in the override project"},
+	{Key: "repo_id", Type: typeString, Pattern: uuidPattern,
+		Message: "must be a valid UUID", Description: "Repo ID in the override project"},
+	{Key: "beadhub_url", Type: typeString, Pattern: urlPattern,
+		Message: "must be a valid HTTP(S) URL", Description: "BeadHub server for the override project"},
+	{Key: "alias", Type: typeString, Pattern: aliasPattern,
+		Message: "is not a valid alias", Description: "Alias used in the override project"},
+	{Key: "role", Type: typeString, Check: IsValidRole,
+		Message: "is not a valid role", Description: "Role used in the override project"},
+}
+
+// configSchema lists .beadhub keys in the order they are checked.
+var configSchema = []fieldSchema{
+	{Key: "workspace_id", Type: typeString, Required: true, Pattern: uuidPattern,
+		Message: "must be a valid UUID", Description: "Workspace identifier (auto-generated)"},
+	{Key: "beadhub_url", Type: typeString, Required: true, Pattern: urlPattern,
+		Message: "must be a valid HTTP(S) URL", Description: "BeadHub server URL"},
+	{Key: "project_slug", Type: typeString, Required: true, Pattern: projectSlugPattern,
+		Message: "must be lowercase alphanumeric with hyphens", Description: "Human-readable project slug"},
+	{Key: "repo_id", Type: typeString, Pattern: uuidPattern,
+		Message: "must be a valid UUID", Description: "Repo identifier assigned by BeadHub"},
+	{Key: "repo_origin", Type: typeString, Required: true, Pattern: repoOriginPattern,
+		Message: "must be a git SSH URL (git@host:path) or HTTPS URL", Description: "Git remote origin URL"},
+	{Key: "canonical_origin", Type: typeString, Required: true, Pattern: canonicalOriginPattern,
+		Message: "must be in format host/org/repo (e.g., github.com/org/repo)", Description: "Normalized origin (host/org/repo)"},
+	{Key: "alias", Type: typeString, Required: true, Pattern: aliasPattern,
+		Message:     "must start with an alphanumeric and contain only alphanumerics, dashes, or underscores (max 64 chars)",
+		Description: "Human-friendly workspace address"},
+	{Key: "human_name", Type: typeString, Required: true, Pattern: humanNamePattern,
+		Message:     "must start with a letter and contain only letters, digits, spaces, hyphens, or apostrophes (max 64 chars)",
+		Description: "Human owner of this workspace"},
+	{Key: "role", Type: typeString, Check: IsValidRole,
+		Message: "must be 1-2 words (letters/numbers) with hyphens/underscores allowed; max 50 chars", Description: "Optional short workspace role"},
+	{Key: "tags", Type: typeList, Description: "Capability tags advertised with presence (python, frontend, infra)",
+		Value: &fieldSchema{Type: typeString, Check: IsValidTag, Message: "is not a valid tag (lowercase letters, digits, + # . _ -)"}},
+	{Key: "no_git", Type: typeBoolean, Description: "Workspace is not a git repository; disables origin checks and auto-reserve"},
+	{Key: "auto_reserve", Type: typeBoolean, Description: "Reserve modified files automatically (default true)"},
+	{Key: "reserve_untracked", Type: typeBoolean, Description: "Also reserve untracked files (default false)"},
+	{Key: "report_conflicts", Type: typeBoolean, Description: "Send auto-reserve conflicts to the server's reservation history (default false)"},
+	{Key: "sync", Type: typeObject, Description: "Issue sync settings", Fields: []fieldSchema{
+		{Key: "max_delete_percent", Type: typeInteger, Min: intPtr(0), Max: intPtr(100),
+			Description: "Largest share of synced issues one sync may delete without --:confirm-deletes"},
+		{Key: "strict", Type: typeBoolean, Description: "Fail the command when its sync fails, like --:strict-sync (default false)"},
+	}},
+	{Key: "http", Type: typeObject, Description: "HTTP connection settings", Fields: []fieldSchema{
+		{Key: "prewarm", Type: typeBoolean, Description: "Open the server connection at command start"},
+		{Key: "strict_decoding", Type: typeBoolean, Description: "Reject server responses with unknown keys (default false)"},
+		{Key: "presence_interval_seconds", Type: typeInteger, Min: intPtr(0), Max: intPtr(3600),
+			Description: "Least seconds between presence refreshes from this workspace; 0 refreshes on every command (default 60)"},
+	}},
+	{Key: "escalations", Type: typeObject, Description: "Escalation reminder settings", Fields: []fieldSchema{
+		{Key: "sla_minutes", Type: typeInteger, Min: intPtr(1), Max: intPtr(10080),
+			Description: "Minutes an escalation may stay pending before reminders start (default 60)"},
+		{Key: "reminder_interval_minutes", Type: typeInteger, Min: intPtr(1), Max: intPtr(10080),
+			Description: "Minimum minutes between reminders for one escalation (default 30)"},
+	}},
+	{Key: "privacy", Type: typeObject, Description: "What bdh reports to the server", Fields: []fieldSchema{
+		{Key: "report_command_line", Type: typeString, Pattern: commandLineReportingPattern,
+			Message:     "must be one of redact, hash, full, off",
+			Description: "How command lines are reported: full (default), redact (subcommand only), hash, or off"},
+	}},
+	{Key: "degradation", Type: typeObject, Description: "What bdh does when coordination fails", Fields: []fieldSchema{
+		{Key: "on_server_unreachable", Type: typeString, Pattern: warnOrBlockPattern,
+			Message:     "must be one of warn, block",
+			Description: "Server unreachable: warn (default, run bd anyway) or block mutating commands"},
+		{Key: "on_sync_failure", Type: typeString, Pattern: syncFailureModePattern,
+			Message:     "must be one of warn, queue, block",
+			Description: "Sync failed: warn (default), queue (retry on the next command), or block until it succeeds"},
+		{Key: "on_reservation_conflict", Type: typeString, Pattern: warnOrBlockPattern,
+			Message:     "must be one of warn, block",
+			Description: "Changed files reserved by others: warn (default) or block mutating commands"},
+	}},
+	{Key: "supervision", Type: typeObject, Description: "Commands parked for a human supervisor (bdh :approve)", Fields: []fieldSchema{
+		{Key: "approvals", Type: typeBoolean, Description: "Park commands BeadHub rejects as approval requests (default false)"},
+		{Key: "high_risk", Type: typeList, Description: "bd commands that always need approval, e.g. delete or \"close --force\"",
+			Value: &fieldSchema{Type: typeString, Check: isCommandAliasExpansion, Message: "must be a bd command"}},
+		{Key: "approver_roles", Type: typeList, Description: "Workspace roles that may decide approval requests (default supervisor)",
+			Value: &fieldSchema{Type: typeString, Check: IsValidRole, Message: "is not a valid role"}},
+	}},
+	{Key: "squad", Type: typeObject, Description: "Agents that share file reservations with this workspace", Fields: []fieldSchema{
+		{Key: "name", Type: typeString, Check: isNonBlank, Message: "must not be empty", Description: "Squad name, stamped on reservations"},
+		{Key: "members", Type: typeList, Description: "Aliases of the squadmates",
+			Value: &fieldSchema{Type: typeString, Pattern: aliasPattern, Message: "is not a valid alias"}},
+	}},
+	{Key: "notifications", Type: typeObject, Description: "Notification delivery settings", Fields: []fieldSchema{
+		{Key: "desktop", Type: typeBoolean, Description: "Desktop alerts for urgent mail and chats waiting on you (default false)"},
+		{Key: "auto_ack_displayed", Type: typeBoolean, Description: "Ack mail once its full body is shown inline; also shows low-priority mail inline (default false)"},
+		{Key: "quiet_hours", Type: typeList, Description: "Local-time windows that hold non-urgent notifications, e.g. \"22:00-07:00\" or \"mon-fri 09:00-11:30\"",
+			Value: &fieldSchema{Type: typeString, Check: isQuietWindow, Message: "must be a window like 22:00-07:00 or mon-fri 09:00-11:30"}},
+	}},
+	{Key: "output", Type: typeObject, Description: "Command output settings", Fields: []fieldSchema{
+		{Key: "verbosity", Type: typeString, Pattern: verbosityPattern,
+			Message:     "must be one of quiet, normal, verbose",
+			Description: "Coordination context: quiet (bd output only), normal (default), or verbose (untruncated)"},
+		{Key: "time_format", Type: typeString, Pattern: timeFormatPattern,
+			Message:     "must be one of relative, local, utc, iso",
+			Description: "How timestamps are shown: relative (5m ago), local, utc, or iso (RFC 3339)"},
+		{Key: "templates", Type: typeMap, KeyCheck: checkRole, Fields: []fieldSchema{
+			{Key: "title", Type: typeString, Description: "Section heading"},
+			{Key: "body", Type: typeString, Required: true, Description: "Go text/template rendered over the ready context"},
+		}, Description: "Extra ready output section per role, e.g. reviewer: {title: Review queue, body: ...}"},
+	}},
+	{Key: "display", Type: typeObject, Description: "How agents are named in output", Fields: []fieldSchema{
+		{Key: "aliases", Type: typeMap, KeyCheck: checkAlias,
+			Value:       &fieldSchema{Type: typeString, Check: isDisplayName, Message: "must be a single line of at most 64 characters"},
+			Description: "Names shown instead of aliases, e.g. claude-be: \"🤖 Backend\" (bypass with --:raw-aliases)"},
+	}},
+	{Key: "metrics", Type: typeObject, Description: "Local coordination metrics", Fields: []fieldSchema{
+		{Key: "enabled", Type: typeBoolean, Description: "Record per-command metrics for bdh :metrics serve (default false)"},
+	}},
+	{Key: "bd", Type: typeObject, Description: "How bd is run", Fields: []fieldSchema{
+		{Key: "pty", Type: typeBoolean, Description: "Run bd on a pseudo-terminal to keep its colors and progress output (default false)"},
+		{Key: "env", Type: typeMap, KeyCheck: checkEnvVarName, Value: &fieldSchema{Type: typeString},
+			Description: "Environment variables set for bd, e.g. BD_NO_DAEMON: \"1\""},
+		{Key: "command", Type: typeList, Description: "Run bd through a wrapper, e.g. [devbox, run, bd] (default: bd from PATH)",
+			Value: &fieldSchema{Type: typeString, Check: isNonBlank, Message: "must not be empty"}},
+	}},
+	{Key: "report", Type: typeObject, Description: "Delivery of the bdh :report digest", Fields: []fieldSchema{
+		{Key: "mail_to", Type: typeList, Description: "Workspace aliases that receive the digest as BeadHub mail",
+			Value: &fieldSchema{Type: typeString, Pattern: aliasPattern, Message: "is not a valid alias"}},
+		{Key: "email_to", Type: typeList, Description: "Email addresses that receive the digest through report.smtp",
+			Value: &fieldSchema{Type: typeString, Pattern: emailAddressPattern, Message: "is not an email address"}},
+		{Key: "smtp", Type: typeObject, Description: "Outgoing mail server for report.email_to", Fields: []fieldSchema{
+			{Key: "host", Type: typeString, Description: "SMTP server host name"},
+			{Key: "port", Type: typeInteger, Min: intPtr(1), Max: intPtr(65535), Description: "SMTP port (default 587)"},
+			{Key: "username", Type: typeString, Description: "SMTP user name, if the server requires authentication"},
+			{Key: "password_env", Type: typeString, Pattern: envVarNamePattern,
+				Message: "must be an environment variable name", Description: "Environment variable holding the SMTP password"},
+			{Key: "from", Type: typeString, Pattern: emailAddressPattern,
+				Message: "is not an email address", Description: "Sender address"},
+		}},
+	}},
+	{Key: "away", Type: typeObject, Description: "Automatic chat reply while away", Fields: []fieldSchema{
+		{Key: "auto_reply", Type: typeBoolean, Description: "Answer new chat sessions while :dnd is on or after being offline (default false)"},
+		{Key: "offline_after_minutes", Type: typeInteger, Min: intPtr(1), Max: intPtr(10080),
+			Description: "Minutes offline before the server replies on your behalf (default 15)"},
+		{Key: "message", Type: typeString,
+			Description: "Reply template; placeholders {alias}, {status}, {until}, {note}"},
+	}},
+	{Key: "aliases", Type: typeMap, KeyCheck: checkCommandAlias,
+		Value:       &fieldSchema{Type: typeString, Check: isCommandAliasExpansion, Message: "must be a bd command"},
+		Description: "Command shortcuts expanded before running, e.g. r: \"ready --priority 1\""},
+	{Key: "templates", Type: typeMap, KeyCheck: checkCommandAlias,
+		Value:       &fieldSchema{Type: typeString, Check: isMessageTemplate, Message: "must be non-empty text with {{name}} placeholders"},
+		Description: "Message bodies for mail send --template, with {{variable}} placeholders"},
+	{Key: "project_overrides", Type: typeMap, Fields: projectOverrideSchema, KeyCheck: checkOverridePrefix,
+		Description: "Per-directory project routing, keyed by path prefix"},
+}
+
+func checkCommandAlias(name string) string {
+	if !IsValidCommandAlias(name) {
+		return "must start with a letter and contain only letters, digits, dashes, or underscores (max 32 chars)"
+	}
+	return ""
+}
+
+func checkRole(role string) string {
+	if !IsValidRole(role) {
+		return "is not a valid role"
+	}
+	return ""
+}
+
+func checkAlias(alias string) string {
+	if !IsValidAlias(alias) {
+		return "is not a valid alias"
+	}
+	return ""
+}
+
+// isDisplayName accepts a non-blank single line of at most
+// maxDisplayNameLen characters.
+func isDisplayName(name string) bool {
+	trimmed := strings.TrimSpace(name)
+	return trimmed != "" && !strings.ContainsAny(name, "\r\n") && utf8.RuneCountInString(trimmed) <= maxDisplayNameLen
+}
+
+func isNonBlank(s string) bool {
+	return strings.TrimSpace(s) != ""
+}
+
+func checkEnvVarName(name string) string {
+	if !envVarNamePattern.MatchString(name) {
+		return "must be an environment variable name"
+	}
+	return ""
+}
+
+func isMessageTemplate(template string) bool {
+	_, ok := TemplateVariables(template)
+	return ok && isNonBlank(template)
+}
+
+// isCommandAliasExpansion rejects expansions that are blank or that would
+// run a bdh command (":...") rather than a bd one.
+func isCommandAliasExpansion(expansion string) bool {
+	fields := strings.Fields(expansion)
+	return len(fields) > 0 && !strings.HasPrefix(fields[0], ":")
+}
+
+func checkOverridePrefix(rawPrefix string) string {
+	prefix := NormalizeOverridePrefix(rawPrefix)
+	if prefix == "" || path.IsAbs(prefix) || prefix == ".." || strings.HasPrefix(prefix, "../") {
+		return "must be a path relative to the workspace root"
+	}
+	return ""
+}
+
+// Problem is a single validation failure.
+type Problem struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+	return p.Message
+}
+
+// ValidationError reports every problem found in a config.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0].String()
+	}
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  - " + p.String()
+	}
+	return fmt.Sprintf("%d problems:\n%s", len(e.Problems), strings.Join(lines, "\n"))
+}
+
+// ValidateBytes checks raw .beadhub content against the schema, including
+// unknown keys and wrongly typed values. Returns nil if the file is valid.
+func ValidateBytes(data []byte) []Problem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []Problem{{Message: fmt.Sprintf("YAML syntax error: %v", err)}}
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return []Problem{{Line: root.Line, Message: "config must be a mapping of key: value pairs"}}
+	}
+	var v validator
+	v.object(root, configSchema, "")
+	return v.problems
+}
+
+type validator struct {
+	problems []Problem
+	// lenient skips unknown-key checks, for validating a marshaled Config.
+	lenient bool
+}
+
+func (v *validator) add(node *yaml.Node, fieldPath, format string, args ...any) {
+	line := 0
+	if node != nil {
+		line = node.Line
+	}
+	v.problems = append(v.problems, Problem{Path: fieldPath, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// object validates a mapping node against fields; prefix is the node's own path.
+func (v *validator) object(node *yaml.Node, fields []fieldSchema, prefix string) {
+	values := make(map[string]*yaml.Node)
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f.Key] = true
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, val := node.Content[i], node.Content[i+1]
+		if !known[k.Value] {
+			if !v.lenient {
+				msg := fmt.Sprintf("unknown key %q", joinFieldPath(prefix, k.Value))
+				if s := suggestKey(k.Value, fields); s != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", s)
+				}
+				v.add(k, joinFieldPath(prefix, k.Value), "%s", msg)
+			}
+			continue
+		}
+		if _, dup := values[k.Value]; dup {
+			v.add(k, joinFieldPath(prefix, k.Value), "%s is set more than once", joinFieldPath(prefix, k.Value))
+		}
+		values[k.Value] = val
+	}
+
+	for _, f := range fields {
+		fieldPath := joinFieldPath(prefix, f.Key)
+		val := values[f.Key]
+		if val == nil || isNullNode(val) || (f.Type == typeString && val.Kind == yaml.ScalarNode && val.Value == "") {
+			if f.Required {
+				v.add(node, fieldPath, "%s is required", fieldPath)
+			}
+			continue
+		}
+		v.value(val, f, fieldPath)
+	}
+}
+
+func (v *validator) value(node *yaml.Node, f fieldSchema, fieldPath string) {
+	switch f.Type {
+	case typeString:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, fieldPath, "%s must be a string", fieldPath)
+			return
+		}
+		if (f.Pattern != nil && !f.Pattern.MatchString(node.Value)) || (f.Check != nil && !f.Check(node.Value)) {
+			v.add(node, fieldPath, "%s %s", fieldPath, f.Message)
+		}
+	case typeBoolean:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.add(node, fieldPath, "%s must be true or false", fieldPath)
+		}
+	case typeInteger:
+		n, err := strconv.Atoi(node.Value)
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" || err != nil {
+			v.add(node, fieldPath, "%s must be an integer", fieldPath)
+			return
+		}
+		if (f.Min != nil && n < *f.Min) || (f.Max != nil && n > *f.Max) {
+			v.add(node, fieldPath, "%s must be between %d and %d", fieldPath, *f.Min, *f.Max)
+		}
+	case typeObject:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, fieldPath, "%s must be a mapping", fieldPath)
+			return
+		}
+		v.object(node, f.Fields, fieldPath)
+	case typeList:
+		if node.Kind != yaml.SequenceNode {
+			v.add(node, fieldPath, "%s must be a list", fieldPath)
+			return
+		}
+		for i, item := range node.Content {
+			v.value(item, *f.Value, fmt.Sprintf("%s[%d]", fieldPath, i))
+		}
+	case typeMap:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, fieldPath, "%s must be a mapping", fieldPath)
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, val := node.Content[i], node.Content[i+1]
+			entryPath := fmt.Sprintf("%s[%s]", fieldPath, k.Value)
+			if f.KeyCheck != nil {
+				if msg := f.KeyCheck(k.Value); msg != "" {
+					v.add(k, entryPath, "%s: %q %s", fieldPath, k.Value, msg)
+				}
+			}
+			if f.Value != nil {
+				v.value(val, *f.Value, entryPath)
+				continue
+			}
+			if val.Kind != yaml.MappingNode {
+				v.add(val, entryPath, "%s must be a mapping", entryPath)
+				continue
+			}
+			v.object(val, f.Fields, entryPath)
+		}
+	}
+}
+
+func joinFieldPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func isNullNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+// suggestKey returns the known key closest to an unknown one, if it looks like a typo.
+func suggestKey(key string, fields []fieldSchema) string {
+	best, bestDist := "", 3
+	for _, f := range fields {
+		if d := editDistance(strings.ToLower(key), f.Key); d < bestDist {
+			best, bestDist = f.Key, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// schemaModelinePattern matches editor schema comments:
+//
+//	# yaml-language-server: $schema=./.beadhub.schema.json
+//	# $schema: ./.beadhub.schema.json
+var schemaModelinePattern = regexp.MustCompile(`(?m)^#\s*(?:yaml-language-server:\s*\$schema=|\$schema:\s*)(\S+)\s*$`)
+
+// SchemaReference returns the $schema reference from a config file's comments, if any.
+func SchemaReference(data []byte) string {
+	m := schemaModelinePattern.FindSubmatch(data)
+	if m == nil {
+		return ""
+	}
+	return string(m[1])
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document for .beadhub,
+// for editors that validate YAML against a schema.
+func JSONSchema() map[string]any {
+	doc := objectJSONSchema(configSchema)
+	doc["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	doc["title"] = ".beadhub"
+	return doc
+}
+
+func objectJSONSchema(fields []fieldSchema) map[string]any {
+	props := make(map[string]any, len(fields))
+	var required []string
+	for _, f := range fields {
+		props[f.Key] = fieldJSONSchema(f)
+		if f.Required {
+			required = append(required, f.Key)
+		}
+	}
+	obj := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		obj["required"] = required
+	}
+	return obj
+}
+
+func fieldJSONSchema(f fieldSchema) map[string]any {
+	var s map[string]any
+	switch f.Type {
+	case typeObject:
+		s = objectJSONSchema(f.Fields)
+	case typeList:
+		s = map[string]any{"type": "array", "items": fieldJSONSchema(*f.Value)}
+	case typeMap:
+		if f.Value != nil {
+			s = map[string]any{"type": "object", "additionalProperties": fieldJSONSchema(*f.Value)}
+		} else {
+			s = map[string]any{"type": "object", "additionalProperties": objectJSONSchema(f.Fields)}
+		}
+	default:
+		s = map[string]any{"type": string(f.Type)}
+		if f.Pattern != nil {
+			s["pattern"] = f.Pattern.String()
+		}
+		if f.Min != nil {
+			s["minimum"] = *f.Min
+		}
+		if f.Max != nil {
+			s["maximum"] = *f.Max
+		}
+	}
+	if f.Description != "" {
+		s["description"] = f.Description
+	}
+	return s
+}
@@ -0,0 +1,326 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validConfigYAML = `workspace_id: "a1b2c3d4-5678-90ab-cdef-1234567890ab"
+beadhub_url: "http://localhost:8000"
+project_slug: "test"
+repo_origin: "git@github.com:test/repo.git"
+canonical_origin: "github.com/test/repo"
+alias: "alice"
+human_name: "Alice"
+`
+
+func TestValidateBytes_Valid(t *testing.T) {
+	if problems := ValidateBytes([]byte(validConfigYAML)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+}
+
+func TestValidateBytes_ReportsAllProblems(t *testing.T) {
+	data := `workspace_id: "not-a-uuid"
+beadhub_url: "ftp://example.com"
+project_slug: "test"
+repo_origin: "git@github.com:test/repo.git"
+canonical_origin: "github.com/test/repo"
+alais: "alice"
+human_name: "Alice"
+auto_reserve: "yes"
+sync:
+  max_delete_percent: 150
+project_overrides:
+  /abs:
+    project_slug: billing
+`
+	problems := ValidateBytes([]byte(data))
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.String())
+	}
+	got := strings.Join(messages, "\n")
+	for _, want := range []string{
+		"line 1: workspace_id must be a valid UUID",
+		"line 2: beadhub_url must be a valid HTTP(S) URL",
+		`line 6: unknown key "alais" (did you mean "alias"?)`,
+		"alias is required",
+		"line 8: auto_reserve must be true or false",
+		"line 10: sync.max_delete_percent must be between 0 and 100",
+		`project_overrides: "/abs" must be a path relative to the workspace root`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestValidateBytes_SyntaxError(t *testing.T) {
+	problems := ValidateBytes([]byte("workspace_id: [unclosed\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, "YAML syntax error") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidate_CollectsProblems(t *testing.T) {
+	cfg := &Config{WorkspaceID: "bad", BeadhubURL: "http://localhost:8000", ProjectSlug: "Bad Slug"}
+	err := cfg.Validate()
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+	// workspace_id, project_slug, repo_origin, canonical_origin, alias, human_name
+	if len(verr.Problems) != 6 {
+		t.Errorf("expected 6 problems, got %d:\n%v", len(verr.Problems), err)
+	}
+	if verr.Problems[0].Message != "workspace_id must be a valid UUID" {
+		t.Errorf("first problem = %q", verr.Problems[0].Message)
+	}
+}
+
+func TestSchemaReference_PreservedOnSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	content := "# yaml-language-server: $schema=./.beadhub.schema.json\n" + validConfigYAML
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if ref := SchemaReference([]byte(content)); ref != "./.beadhub.schema.json" {
+		t.Errorf("SchemaReference = %q", ref)
+	}
+	if ref := SchemaReference([]byte("# $schema: https://example.com/s.json\n")); ref != "https://example.com/s.json" {
+		t.Errorf("SchemaReference short form = %q", ref)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetPath(path)
+	defer SetPath("")
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	saved, _ := os.ReadFile(path)
+	if SchemaReference(saved) != "./.beadhub.schema.json" {
+		t.Errorf("schema comment lost on save:\n%s", saved)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := json.Marshal(JSONSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Required   []string                  `json:"required"`
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(strings.Join(doc.Required, ","), "workspace_id") {
+		t.Errorf("required = %v", doc.Required)
+	}
+	if doc.Properties["auto_reserve"]["type"] != "boolean" {
+		t.Errorf("auto_reserve = %v", doc.Properties["auto_reserve"])
+	}
+}
+
+func TestValidateBytes_PrivacyReportCommandLine(t *testing.T) {
+	if problems := ValidateBytes([]byte(validConfigYAML + "privacy:\n  report_command_line: hash\n")); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "privacy:\n  report_command_line: secret\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "privacy.report_command_line must be one of redact, hash, full, off") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_Degradation(t *testing.T) {
+	valid := "degradation:\n  on_server_unreachable: block\n  on_sync_failure: queue\n  on_reservation_conflict: warn\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "degradation:\n  on_sync_failure: retry\n  on_reservation_conflict: queue\n"))
+	if len(problems) != 2 ||
+		!strings.Contains(problems[0].String()+problems[1].String(), "degradation.on_sync_failure must be one of warn, queue, block") ||
+		!strings.Contains(problems[0].String()+problems[1].String(), "degradation.on_reservation_conflict must be one of warn, block") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_OutputVerbosity(t *testing.T) {
+	if problems := ValidateBytes([]byte(validConfigYAML + "output:\n  verbosity: quiet\n")); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "output:\n  verbosity: loud\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "output.verbosity must be one of quiet, normal, verbose") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_OutputTimeFormat(t *testing.T) {
+	if problems := ValidateBytes([]byte(validConfigYAML + "output:\n  time_format: local\n")); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "output:\n  time_format: epoch\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "output.time_format must be one of relative, local, utc, iso") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_Aliases(t *testing.T) {
+	valid := "aliases:\n  r: ready --priority 1\n  done: close --reason\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "aliases:\n  \"-x\": ready\n  st: \":status\"\n  obj:\n    a: b\n"))
+	if len(problems) != 3 {
+		t.Fatalf("got %v", problems)
+	}
+	all := problems[0].String() + problems[1].String() + problems[2].String()
+	for _, want := range []string{`aliases: "-x" must start with a letter`, "aliases[st] must be a bd command", "aliases[obj] must be a string"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("missing %q in %v", want, problems)
+		}
+	}
+}
+
+func TestValidateBytes_Templates(t *testing.T) {
+	valid := "templates:\n  handoff: \"Taking a break; status: {{status}}, next: {{ next }}\"\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "templates:\n  bad: \"status: {{status\"\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "templates[bad] must be non-empty text with {{name}} placeholders") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_Report(t *testing.T) {
+	valid := "report:\n  mail_to: [lead-alice]\n  email_to:\n    - lead@example.com\n  smtp:\n    host: smtp.example.com\n    port: 465\n    password_env: BDH_SMTP_PASSWORD\n    from: bdh@example.com\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "report:\n  mail_to: lead-alice\n  email_to: [lead@example.com, nobody]\n"))
+	if len(problems) != 2 {
+		t.Fatalf("got %v", problems)
+	}
+	all := problems[0].String() + problems[1].String()
+	for _, want := range []string{"report.mail_to must be a list", "report.email_to[1] is not an email address"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("missing %q in %v", want, problems)
+		}
+	}
+}
+
+func TestValidateBytes_BdEnv(t *testing.T) {
+	valid := "bd:\n  env:\n    BD_NO_DAEMON: \"1\"\n    BEADS_DB: /tmp/beads.db\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "bd:\n  env:\n    BD-MODE: fast\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "must be an environment variable name") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_BdCommand(t *testing.T) {
+	valid := "bd:\n  command: [docker, exec, toolbox, bd]\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "bd:\n  command: [devbox, \" \", bd]\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "bd.command[1] must not be empty") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_Squad(t *testing.T) {
+	valid := "squad:\n  name: web\n  members: [claude-fe, claude-qa]\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "squad:\n  members: [claude-fe, \"not an alias\"]\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "squad.members[1] is not a valid alias") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_Supervision(t *testing.T) {
+	valid := "supervision:\n  approvals: true\n  high_risk: [delete, \"close --force\"]\n  approver_roles: [supervisor, \"tech lead\"]\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "supervision:\n  high_risk: [\":status\"]\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "supervision.high_risk[0] must be a bd command") {
+		t.Errorf("got %v", problems)
+	}
+	problems = ValidateBytes([]byte(validConfigYAML + "supervision:\n  approver_roles: [\"\"]\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "supervision.approver_roles[0] is not a valid role") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_QuietHours(t *testing.T) {
+	valid := "notifications:\n  quiet_hours: [\"22:00-07:00\", \"mon-fri 12:00-13:00\", \"sat,sun 00:00-24:00\"]\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "notifications:\n  quiet_hours: [\"22:00-07:00\", \"noon-1pm\"]\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "notifications.quiet_hours[1] must be a window like") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_Away(t *testing.T) {
+	valid := "away:\n  auto_reply: true\n  offline_after_minutes: 30\n  message: \"{alias} is {status}, back {until}\"\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "away:\n  offline_after_minutes: 0\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "away.offline_after_minutes must be between 1 and 10080") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_ReportConflicts(t *testing.T) {
+	if problems := ValidateBytes([]byte(validConfigYAML + "report_conflicts: true\n")); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	if problems := ValidateBytes([]byte(validConfigYAML + "report_conflicts: often\n")); len(problems) != 1 {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateBytes_DisplayAliases(t *testing.T) {
+	valid := "display:\n  aliases:\n    claude-be: \"🤖 Backend\"\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "display:\n  aliases:\n    \"bad alias\": Backend\n    claude-fe: \"  \"\n"))
+	all := fmt.Sprint(problems)
+	for _, want := range []string{"is not a valid alias", "must be a single line"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("missing %q in %v", want, problems)
+		}
+	}
+}
+
+func TestValidateBytes_OutputTemplates(t *testing.T) {
+	valid := "output:\n  templates:\n    reviewer:\n      title: Review queue\n      body: \"{{range .Team}}{{.Alias}}{{end}}\"\n"
+	if problems := ValidateBytes([]byte(validConfigYAML + valid)); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	problems := ValidateBytes([]byte(validConfigYAML + "output:\n  templates:\n    reviewer:\n      title: Review queue\n"))
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), "body") {
+		t.Errorf("got %v", problems)
+	}
+}
@@ -0,0 +1,150 @@
+// Package i18n provides the message catalog for bdh's human-facing output.
+//
+// Messages are looked up by key in the catalog for the active locale
+// (BDH_LANG), falling back to English and then to the key itself. Catalogs
+// are flat JSON objects of key -> fmt template. Built-in catalogs live in
+// locales/; deployments can add or override languages by dropping
+// <lang>.json files into BDH_LOCALE_DIR, or by calling Register.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultLang is used when BDH_LANG is unset or names an unknown language.
+const DefaultLang = "en"
+
+// Catalog maps message keys to fmt templates.
+type Catalog map[string]string
+
+//go:embed locales/*.json
+var builtinFS embed.FS
+
+var (
+	mu       sync.Mutex
+	loaded   bool
+	catalogs = map[string]Catalog{}
+	external = map[string]bool{} // languages already looked up in BDH_LOCALE_DIR
+)
+
+func loadBuiltins() {
+	if loaded {
+		return
+	}
+	loaded = true
+	entries, err := builtinFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: reading built-in catalogs: %v", err))
+	}
+	for _, e := range entries {
+		lang := strings.TrimSuffix(e.Name(), ".json")
+		data, err := builtinFS.ReadFile(path.Join("locales", e.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: reading %s: %v", e.Name(), err))
+		}
+		var cat Catalog
+		if err := json.Unmarshal(data, &cat); err != nil {
+			panic(fmt.Sprintf("i18n: parsing %s: %v", e.Name(), err))
+		}
+		mergeLocked(lang, cat)
+	}
+}
+
+func mergeLocked(lang string, cat Catalog) {
+	existing := catalogs[lang]
+	if existing == nil {
+		existing = Catalog{}
+		catalogs[lang] = existing
+	}
+	for k, v := range cat {
+		existing[k] = v
+	}
+}
+
+// Register adds messages for lang, overriding built-in entries with the
+// same key.
+func Register(lang string, cat Catalog) {
+	mu.Lock()
+	defer mu.Unlock()
+	loadBuiltins()
+	mergeLocked(NormalizeLang(lang), cat)
+}
+
+// loadExternalLocked merges <lang>.json from BDH_LOCALE_DIR, once per
+// language. A missing or unreadable file is ignored: output falls back to
+// the built-in catalogs rather than failing the command.
+func loadExternalLocked(lang string) {
+	dir := strings.TrimSpace(os.Getenv("BDH_LOCALE_DIR"))
+	if dir == "" || external[dir+"\x00"+lang] {
+		return
+	}
+	external[dir+"\x00"+lang] = true
+	data, err := os.ReadFile(filepath.Join(dir, lang+".json"))
+	if err != nil {
+		return
+	}
+	var cat Catalog
+	if json.Unmarshal(data, &cat) == nil {
+		mergeLocked(lang, cat)
+	}
+}
+
+// NormalizeLang turns a locale name like "pt_BR.UTF-8" into "pt-br".
+func NormalizeLang(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	return strings.ReplaceAll(lang, "_", "-")
+}
+
+// Lang returns the active language: BDH_LANG if a catalog exists for it (or
+// for its base language, "pt" for "pt-br"), otherwise DefaultLang.
+func Lang() string {
+	requested := NormalizeLang(os.Getenv("BDH_LANG"))
+	if requested == "" || requested == "c" || requested == "posix" {
+		return DefaultLang
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	loadBuiltins()
+	candidates := []string{requested}
+	if base, _, ok := strings.Cut(requested, "-"); ok {
+		candidates = append(candidates, base)
+	}
+	for _, lang := range candidates {
+		loadExternalLocked(lang)
+		if len(catalogs[lang]) > 0 {
+			return lang
+		}
+	}
+	return DefaultLang
+}
+
+// T returns the message for key in the active language, formatted with args.
+func T(key string, args ...any) string {
+	lang := Lang()
+
+	mu.Lock()
+	loadBuiltins()
+	tmpl, ok := catalogs[lang][key]
+	if !ok {
+		tmpl, ok = catalogs[DefaultLang][key]
+	}
+	mu.Unlock()
+	if !ok {
+		tmpl = key
+	}
+	if len(args) == 0 {
+		return tmpl
+	}
+	return fmt.Sprintf(tmpl, args...)
+}
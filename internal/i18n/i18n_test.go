@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestLang(t *testing.T) {
+	tests := []struct {
+		env  string
+		want string
+	}{
+		{env: "", want: "en"},
+		{env: "C", want: "en"},
+		{env: "es", want: "es"},
+		{env: "es_ES.UTF-8", want: "es"},
+		{env: "ES-mx", want: "es"},
+		{env: "xx", want: "en"},
+	}
+	for _, tt := range tests {
+		t.Setenv("BDH_LANG", tt.env)
+		if got := Lang(); got != tt.want {
+			t.Errorf("Lang() with BDH_LANG=%q = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestT_FallsBack(t *testing.T) {
+	t.Setenv("BDH_LANG", "")
+	if got := T("warning", "disk full"); got != "Warning: disk full" {
+		t.Errorf("en = %q", got)
+	}
+
+	t.Setenv("BDH_LANG", "es")
+	if got := T("warning", "disk full"); got != "Aviso: disk full" {
+		t.Errorf("es = %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key = %q", got)
+	}
+}
+
+func TestExternalCatalog(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "fr.json"), []byte(`{"warning": "Attention : %s"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BDH_LOCALE_DIR", dir)
+	t.Setenv("BDH_LANG", "fr_FR.UTF-8")
+
+	if got := Lang(); got != "fr" {
+		t.Fatalf("Lang() = %q", got)
+	}
+	if got := T("warning", "x"); got != "Attention : x" {
+		t.Errorf("override = %q", got)
+	}
+	if got := T("ready.team.title"); got != "Team Status" {
+		t.Errorf("missing key should fall back to English, got %q", got)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("x-test", Catalog{"warning": "W! %s"})
+	t.Setenv("BDH_LANG", "x_TEST")
+	if got := T("warning", "y"); got != "W! y" {
+		t.Errorf("registered = %q", got)
+	}
+}
+
+// Every built-in catalog must translate every English key with the same
+// format verbs, so a translation can never garble or drop an argument.
+func TestBuiltinCatalogsMatchEnglish(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0]*[0-9]*[a-zA-Z]`)
+	mu.Lock()
+	loadBuiltins()
+	en := catalogs[DefaultLang]
+	builtin := map[string]Catalog{}
+	entries, _ := builtinFS.ReadDir("locales")
+	for _, e := range entries {
+		lang := e.Name()[:len(e.Name())-len(".json")]
+		builtin[lang] = catalogs[lang]
+	}
+	mu.Unlock()
+
+	if len(builtin) < 2 {
+		t.Fatalf("expected English plus at least one translation, got %d catalogs", len(builtin))
+	}
+	for lang, cat := range builtin {
+		for key, tmpl := range en {
+			got, ok := cat[key]
+			if !ok {
+				t.Errorf("%s: missing key %q", lang, key)
+				continue
+			}
+			if a, b := verbs.FindAllString(tmpl, -1), verbs.FindAllString(got, -1); len(a) != len(b) {
+				t.Errorf("%s: %q has verbs %v, English has %v", lang, key, b, a)
+			}
+		}
+		for key := range cat {
+			if _, ok := en[key]; !ok {
+				t.Errorf("%s: key %q is not in the English catalog", lang, key)
+			}
+		}
+	}
+}
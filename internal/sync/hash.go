@@ -10,6 +10,8 @@
 //   - Issues without 'id' field are silently skipped (can't be tracked for sync)
 //   - Invalid JSON lines cause the entire operation to fail
 //   - Both Windows (\r\n) and Unix (\n) line endings are supported
+//   - A leading UTF-8 BOM is ignored; NormalizeJSONL also repairs invalid
+//     UTF-8 and drops oversized lines, reporting each as a LineWarning
 //   - Hash is deterministic: different JSON key orders produce identical hashes
 //   - Array element order is preserved (different order = different hash)
 //
@@ -17,6 +19,7 @@
 package sync
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -70,20 +73,19 @@ func ComputeIssueHash(issueJSON []byte) (IssueHash, error) {
 func ComputeIssueHashes(jsonlContent []byte) (map[string]string, error) {
 	hashes := make(map[string]string)
 
-	// Split by newlines and process each line
-	lines := splitJSONL(jsonlContent)
-	for _, line := range lines {
-		if len(line) == 0 {
-			continue
-		}
-
+	// Stream line by line; repairs (BOM, bad UTF-8) are reported by NormalizeJSONL
+	_, err := ScanJSONL(bytes.NewReader(jsonlContent), func(lineNo int, line []byte) error {
 		issueHash, err := ComputeIssueHash(line)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("line %d: %w", lineNo, err)
 		}
 		if issueHash.ID != "" {
 			hashes[issueHash.ID] = issueHash.Hash
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return hashes, nil
@@ -193,6 +195,8 @@ func splitJSONL(content []byte) [][]byte {
 	var lines [][]byte
 	var start int
 
+	content = bytes.TrimPrefix(content, utf8BOM)
+
 	for i := 0; i < len(content); i++ {
 		if content[i] == '\n' {
 			line := content[start:i]
@@ -0,0 +1,212 @@
+package sync
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Issue history keeps a snapshot of every version of an issue that was
+// synced, so past states can be compared (bdh :diff). Snapshots are appended
+// to a JSONL file next to the sync state, only when an issue's hash differs
+// from its last recorded version, and each issue keeps at most
+// MaxHistoryPerIssue versions.
+
+// MaxHistoryPerIssue caps the snapshots kept per issue.
+const MaxHistoryPerIssue = 50
+
+// HistoryEntry is one synced version of an issue.
+type HistoryEntry struct {
+	ID       string    `json:"id"`
+	Hash     string    `json:"hash,omitempty"`
+	SyncedAt time.Time `json:"synced_at"`
+	// SyncedBy is the alias of the workspace whose sync uploaded this version.
+	SyncedBy string `json:"synced_by,omitempty"`
+	// Command is the bd command that produced this version, when the command
+	// named the issue. Empty when the change arrived some other way (for
+	// example a git pull) and was only picked up by the sync.
+	Command string          `json:"command,omitempty"`
+	Deleted bool            `json:"deleted,omitempty"`
+	Issue   json.RawMessage `json:"issue,omitempty"`
+}
+
+// HistoryMeta describes the sync being recorded.
+type HistoryMeta struct {
+	SyncedAt time.Time
+	SyncedBy string
+	Command  string
+	// Targets are the issues the command named; only they get Command.
+	Targets []string
+}
+
+// LoadHistory reads all snapshots grouped by issue ID, oldest first.
+// A missing file is an empty history; unreadable lines are skipped.
+func LoadHistory(path string) (map[string][]HistoryEntry, error) {
+	history := make(map[string][]HistoryEntry)
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if json.Unmarshal(line, &entry) != nil || entry.ID == "" {
+			continue
+		}
+		history[entry.ID] = append(history[entry.ID], entry)
+	}
+	return history, scanner.Err()
+}
+
+// RecordHistory appends a snapshot for each issue in ids whose hash differs
+// from its last recorded version, and a deletion marker for each of deleted.
+// content is the uploaded JSONL and hashes its issue hashes. It reads and
+// then writes path, so callers serialize concurrent calls.
+func RecordHistory(path string, content []byte, hashes map[string]string, ids, deleted []string, meta HistoryMeta) error {
+	history, err := LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	last := func(id string) *HistoryEntry {
+		entries := history[id]
+		if len(entries) == 0 {
+			return nil
+		}
+		return &entries[len(entries)-1]
+	}
+	targeted := make(map[string]bool, len(meta.Targets))
+	for _, id := range meta.Targets {
+		targeted[id] = true
+	}
+	newEntry := func(id string) HistoryEntry {
+		entry := HistoryEntry{ID: id, SyncedAt: meta.SyncedAt.UTC(), SyncedBy: meta.SyncedBy}
+		if targeted[id] {
+			entry.Command = meta.Command
+		}
+		return entry
+	}
+
+	var changed []string
+	for _, id := range ids {
+		if prev := last(id); prev != nil && !prev.Deleted && prev.Hash == hashes[id] {
+			continue
+		}
+		changed = append(changed, id)
+	}
+	snapshots := make(map[string]json.RawMessage, len(changed))
+	if len(changed) > 0 {
+		extracted, err := ExtractIssuesByID(content, changed)
+		if err != nil {
+			return err
+		}
+		for _, line := range splitJSONL([]byte(extracted)) {
+			var probe struct {
+				ID string `json:"id"`
+			}
+			if json.Unmarshal(line, &probe) == nil && probe.ID != "" {
+				snapshots[probe.ID] = append(json.RawMessage(nil), line...)
+			}
+		}
+	}
+
+	var added []HistoryEntry
+	for _, id := range changed {
+		snapshot, ok := snapshots[id]
+		if !ok {
+			continue
+		}
+		entry := newEntry(id)
+		entry.Hash = hashes[id]
+		entry.Issue = snapshot
+		added = append(added, entry)
+	}
+	for _, id := range deleted {
+		if prev := last(id); prev == nil || prev.Deleted {
+			continue
+		}
+		entry := newEntry(id)
+		entry.Deleted = true
+		added = append(added, entry)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	overCap := false
+	for _, entry := range added {
+		history[entry.ID] = append(history[entry.ID], entry)
+		if len(history[entry.ID]) > MaxHistoryPerIssue {
+			overCap = true
+		}
+	}
+	if overCap {
+		return rewriteHistory(path, history)
+	}
+	return appendHistory(path, added)
+}
+
+func appendHistory(path string, entries []HistoryEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// rewriteHistory writes history back, trimmed to MaxHistoryPerIssue per
+// issue, in order of recording.
+func rewriteHistory(path string, history map[string][]HistoryEntry) error {
+	var all []HistoryEntry
+	for id, entries := range history {
+		if len(entries) > MaxHistoryPerIssue {
+			entries = entries[len(entries)-MaxHistoryPerIssue:]
+			history[id] = entries
+		}
+		all = append(all, entries...)
+	}
+	sortHistoryEntries(all)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range all {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func sortHistoryEntries(entries []HistoryEntry) {
+	// A stable sort by time keeps the per-issue order intact.
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].SyncedAt.Before(entries[j].SyncedAt)
+	})
+}
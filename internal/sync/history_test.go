@@ -0,0 +1,84 @@
+package sync
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordHistory_OnlyChangedVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync-history.jsonl")
+	t0 := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	v1 := []byte(`{"id":"bd-1","title":"A","status":"open"}` + "\n" + `{"id":"bd-2","title":"B","status":"open"}` + "\n")
+	h1, err := ComputeIssueHashes(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := HistoryMeta{SyncedAt: t0, SyncedBy: "alice", Command: "bd update bd-1 --status open", Targets: []string{"bd-1"}}
+	if err := RecordHistory(path, v1, h1, []string{"bd-1", "bd-2"}, nil, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// bd-1 changes, bd-2 does not; bd-2 is then deleted
+	v2 := []byte(`{"id":"bd-1","title":"A","status":"closed"}` + "\n")
+	h2, _ := ComputeIssueHashes(v2)
+	meta = HistoryMeta{SyncedAt: t0.Add(time.Hour), SyncedBy: "bob"}
+	if err := RecordHistory(path, v2, h2, []string{"bd-1"}, []string{"bd-2", "bd-9"}, meta); err != nil {
+		t.Fatal(err)
+	}
+	// Re-syncing the same version records nothing
+	if err := RecordHistory(path, v2, h2, []string{"bd-1"}, []string{"bd-2"}, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(history["bd-1"]); got != 2 {
+		t.Fatalf("bd-1 versions = %d, want 2", got)
+	}
+	first, second := history["bd-1"][0], history["bd-1"][1]
+	if first.Command != "bd update bd-1 --status open" || first.SyncedBy != "alice" || !first.SyncedAt.Equal(t0) {
+		t.Errorf("first = %+v", first)
+	}
+	if second.Command != "" || second.SyncedBy != "bob" || string(second.Issue) != `{"id":"bd-1","title":"A","status":"closed"}` {
+		t.Errorf("second = %+v", second)
+	}
+	if bd2 := history["bd-2"]; len(bd2) != 2 || !bd2[1].Deleted || bd2[0].Command != "" {
+		t.Errorf("bd-2 = %+v", bd2)
+	}
+	if _, ok := history["bd-9"]; ok {
+		t.Error("deletion of an unrecorded issue should not be recorded")
+	}
+}
+
+func TestRecordHistory_CapsVersionsPerIssue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync-history.jsonl")
+	t0 := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < MaxHistoryPerIssue+5; i++ {
+		content := []byte(fmt.Sprintf(`{"id":"bd-1","title":"v%d"}`, i) + "\n" + `{"id":"bd-2","title":"same"}` + "\n")
+		hashes, _ := ComputeIssueHashes(content)
+		meta := HistoryMeta{SyncedAt: t0.Add(time.Duration(i) * time.Minute)}
+		if err := RecordHistory(path, content, hashes, []string{"bd-1", "bd-2"}, nil, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bd1 := history["bd-1"]
+	if len(bd1) != MaxHistoryPerIssue {
+		t.Fatalf("bd-1 versions = %d, want %d", len(bd1), MaxHistoryPerIssue)
+	}
+	if string(bd1[0].Issue) != `{"id":"bd-1","title":"v5"}` {
+		t.Errorf("oldest kept = %s", bd1[0].Issue)
+	}
+	if len(history["bd-2"]) != 1 {
+		t.Errorf("bd-2 versions = %d, want 1", len(history["bd-2"]))
+	}
+}
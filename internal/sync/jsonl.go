@@ -0,0 +1,125 @@
+package sync
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// MaxLineSize is the longest issues.jsonl line accepted. Real issues are far
+// smaller; a line this long is almost certainly corruption and is skipped.
+const MaxLineSize = 8 << 20
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// LineWarning describes a JSONL line that was repaired or skipped.
+type LineWarning struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (w LineWarning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// ScanJSONL streams JSONL from r and calls fn for each non-blank line with its
+// 1-based line number. Lines are cleaned before fn sees them:
+//   - a leading UTF-8 byte order mark is removed from each line
+//   - CRLF line endings and surrounding whitespace are trimmed
+//   - invalid UTF-8 is replaced with U+FFFD (warned)
+//   - lines longer than MaxLineSize are skipped (warned)
+//
+// The line slice is only valid during the call. An error from fn stops the
+// scan and is returned as is.
+func ScanJSONL(r io.Reader, fn func(lineNo int, line []byte) error) ([]LineWarning, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	var warnings []LineWarning
+	var buf []byte
+	lineNo := 0
+	tooLong := false
+
+	for {
+		chunk, isPrefix, err := br.ReadLine()
+		if err == io.EOF {
+			return warnings, nil
+		}
+		if err != nil {
+			return warnings, err
+		}
+		if !tooLong {
+			if len(buf)+len(chunk) > MaxLineSize {
+				tooLong = true
+				buf = buf[:0]
+			} else {
+				buf = append(buf, chunk...)
+			}
+		}
+		if isPrefix {
+			continue
+		}
+
+		lineNo++
+		if tooLong {
+			warnings = append(warnings, LineWarning{Line: lineNo, Message: fmt.Sprintf("longer than %d bytes - skipped", MaxLineSize)})
+			tooLong = false
+			buf = buf[:0]
+			continue
+		}
+
+		// A BOM can start any line when exports are concatenated
+		line := bytes.TrimSpace(bytes.TrimPrefix(buf, utf8BOM))
+		if len(line) == 0 {
+			buf = buf[:0]
+			continue
+		}
+		if !utf8.Valid(line) {
+			line = bytes.ToValidUTF8(line, []byte("\uFFFD"))
+			warnings = append(warnings, LineWarning{Line: lineNo, Message: "invalid UTF-8 replaced with U+FFFD"})
+		}
+		if err := fn(lineNo, line); err != nil {
+			return warnings, err
+		}
+		buf = buf[:0]
+	}
+}
+
+// NormalizeJSONL returns content rewritten as clean JSONL (no BOM, LF endings,
+// valid UTF-8, no blank or oversized lines) along with warnings for every line
+// that had to be repaired or dropped.
+func NormalizeJSONL(content []byte) ([]byte, []LineWarning) {
+	out := make([]byte, 0, len(content))
+	warnings, _ := ScanJSONL(bytes.NewReader(content), func(_ int, line []byte) error {
+		out = append(out, line...)
+		out = append(out, '\n')
+		return nil
+	})
+	return out, warnings
+}
+
+// ReadJSONL reads JSONL from r in one streaming pass, returning the
+// normalized content (as NormalizeJSONL), the hash of every issue (as
+// ComputeIssueHashes) and warnings for repaired or dropped lines. Unlike
+// reading the whole file first, lines of any length up to MaxLineSize are
+// handled without holding a second copy of the input.
+func ReadJSONL(r io.Reader) (content []byte, hashes map[string]string, warnings []LineWarning, err error) {
+	var out bytes.Buffer
+	hashes = make(map[string]string)
+	warnings, err = ScanJSONL(r, func(lineNo int, line []byte) error {
+		issueHash, err := ComputeIssueHash(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if issueHash.ID != "" {
+			hashes[issueHash.ID] = issueHash.Hash
+		}
+		out.Write(line)
+		out.WriteByte('\n')
+		return nil
+	})
+	if err != nil {
+		return nil, nil, warnings, err
+	}
+	return out.Bytes(), hashes, warnings, nil
+}
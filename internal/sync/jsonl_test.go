@@ -0,0 +1,212 @@
+package sync
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func scanAll(t *testing.T, input []byte) (map[int]string, []LineWarning) {
+	t.Helper()
+	lines := make(map[int]string)
+	warnings, err := ScanJSONL(bytes.NewReader(input), func(lineNo int, line []byte) error {
+		lines[lineNo] = string(line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanJSONL: %v", err)
+	}
+	return lines, warnings
+}
+
+func TestScanJSONL_StripsBOMAndCRLF(t *testing.T) {
+	input := append(append([]byte{}, utf8BOM...), []byte("{\"id\":\"bd-1\"}\r\n\r\n  {\"id\":\"bd-2\"}  \r\n")...)
+
+	lines, warnings := scanAll(t, input)
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+	if lines[1] != `{"id":"bd-1"}` {
+		t.Errorf("line 1 = %q", lines[1])
+	}
+	if lines[3] != `{"id":"bd-2"}` {
+		t.Errorf("line 3 = %q (line numbers must count blank lines)", lines[3])
+	}
+	if len(lines) != 2 {
+		t.Errorf("expected 2 lines, got %d", len(lines))
+	}
+}
+
+func TestScanJSONL_InvalidUTF8Warns(t *testing.T) {
+	input := []byte("{\"id\":\"bd-1\"}\n{\"id\":\"bd-2\",\"title\":\"caf\xe9\"}\n")
+
+	lines, warnings := scanAll(t, input)
+	if !utf8.ValidString(lines[2]) {
+		t.Errorf("line 2 still invalid UTF-8: %q", lines[2])
+	}
+	if !strings.Contains(lines[2], "caf�") {
+		t.Errorf("expected replacement character, got %q", lines[2])
+	}
+	if len(warnings) != 1 || warnings[0].Line != 2 {
+		t.Fatalf("expected one warning for line 2, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0].String(), "line 2: invalid UTF-8") {
+		t.Errorf("unexpected warning text: %s", warnings[0])
+	}
+}
+
+func TestScanJSONL_SkipsOversizedLine(t *testing.T) {
+	huge := `{"id":"bd-2","title":"` + strings.Repeat("x", MaxLineSize) + `"}`
+	input := []byte(`{"id":"bd-1"}` + "\n" + huge + "\n" + `{"id":"bd-3"}` + "\n")
+
+	lines, warnings := scanAll(t, input)
+	if _, ok := lines[2]; ok {
+		t.Error("oversized line should be skipped")
+	}
+	if lines[1] != `{"id":"bd-1"}` || lines[3] != `{"id":"bd-3"}` {
+		t.Errorf("neighbouring lines not preserved: %v", lines)
+	}
+	if len(warnings) != 1 || warnings[0].Line != 2 || !strings.Contains(warnings[0].Message, "skipped") {
+		t.Errorf("expected skip warning for line 2, got %v", warnings)
+	}
+}
+
+func TestScanJSONL_NoTrailingNewline(t *testing.T) {
+	lines, _ := scanAll(t, []byte(`{"id":"bd-1"}`+"\n"+`{"id":"bd-2"}`))
+	if lines[2] != `{"id":"bd-2"}` {
+		t.Errorf("last line without newline lost: %v", lines)
+	}
+}
+
+func TestNormalizeJSONL(t *testing.T) {
+	input := append(append([]byte{}, utf8BOM...), []byte("{\"id\":\"bd-1\"}\r\n\n{\"id\":\"bd-2\",\"t\":\"\xff\"}")...)
+
+	out, warnings := NormalizeJSONL(input)
+	want := "{\"id\":\"bd-1\"}\n{\"id\":\"bd-2\",\"t\":\"�\"}\n"
+	if string(out) != want {
+		t.Errorf("NormalizeJSONL = %q, want %q", out, want)
+	}
+	if len(warnings) != 1 || warnings[0].Line != 3 {
+		t.Errorf("expected one warning for line 3, got %v", warnings)
+	}
+}
+
+func TestComputeIssueHashes_BOM(t *testing.T) {
+	plain := []byte(`{"id":"bd-1","title":"First"}`)
+	withBOM := append(append([]byte{}, utf8BOM...), plain...)
+
+	a, err := ComputeIssueHashes(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ComputeIssueHashes(withBOM)
+	if err != nil {
+		t.Fatalf("BOM should be ignored: %v", err)
+	}
+	if a["bd-1"] == "" || a["bd-1"] != b["bd-1"] {
+		t.Errorf("hash differs with BOM: %v vs %v", a, b)
+	}
+}
+
+func TestComputeIssueHashes_ErrorHasLineNumber(t *testing.T) {
+	jsonl := []byte("{\"id\":\"bd-1\"}\n\n{broken\n")
+
+	_, err := ComputeIssueHashes(jsonl)
+	if err == nil || !strings.Contains(err.Error(), "line 3:") {
+		t.Errorf("expected error mentioning line 3, got %v", err)
+	}
+}
+
+func FuzzScanJSONL(f *testing.F) {
+	f.Add([]byte(`{"id":"bd-1"}` + "\n"))
+	f.Add([]byte("\xef\xbb\xbf{\"id\":\"bd-1\"}\r\n{\"id\":\"bd-2\"}"))
+	f.Add([]byte("{\"t\":\"\xff\xfe\"}\n\n\r\n"))
+	f.Add([]byte("\xef\xbb\xbf"))
+	f.Add([]byte(""))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		last := 0
+		_, err := ScanJSONL(bytes.NewReader(data), func(lineNo int, line []byte) error {
+			if lineNo <= last {
+				t.Fatalf("line numbers not increasing: %d after %d", lineNo, last)
+			}
+			last = lineNo
+			if len(line) == 0 {
+				t.Fatal("blank line passed to callback")
+			}
+			if !utf8.Valid(line) {
+				t.Fatalf("invalid UTF-8 passed to callback: %q", line)
+			}
+			if bytes.ContainsAny(line, "\n") {
+				t.Fatalf("line contains newline: %q", line)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Normalizing is idempotent and produces no further warnings.
+		once, _ := NormalizeJSONL(data)
+		twice, warnings := NormalizeJSONL(once)
+		if !bytes.Equal(once, twice) || len(warnings) != 0 {
+			t.Fatalf("NormalizeJSONL not idempotent: %q -> %q (%v)", once, twice, warnings)
+		}
+	})
+}
+
+func FuzzComputeIssueHashes(f *testing.F) {
+	f.Add([]byte(`{"id":"bd-1","title":"First"}` + "\n" + `{"id":"bd-2"}`))
+	f.Add([]byte("\xef\xbb\xbf{\"id\":\"bd-1\"}\r\n"))
+	f.Add([]byte(`{"id":"bd-1"` + "\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Must never panic; normalized content must hash the same as raw.
+		raw, rawErr := ComputeIssueHashes(data)
+		norm, _ := NormalizeJSONL(data)
+		clean, cleanErr := ComputeIssueHashes(norm)
+		if (rawErr == nil) != (cleanErr == nil) {
+			t.Fatalf("error mismatch: raw=%v normalized=%v", rawErr, cleanErr)
+		}
+		if rawErr == nil && len(raw) != len(clean) {
+			t.Fatalf("hash count mismatch: %d vs %d", len(raw), len(clean))
+		}
+	})
+}
+
+func TestReadJSONL_MatchesNormalizeAndHash(t *testing.T) {
+	long := strings.Repeat("x", 256*1024)
+	input := append(append([]byte{}, utf8BOM...), []byte("{\"id\":\"bd-1\"}\r\n\n{\"id\":\"bd-2\",\"t\":\"\xff\"}\n{\"id\":\"bd-3\",\"d\":\""+long+"\"}")...)
+
+	content, hashes, warnings, err := ReadJSONL(bytes.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantContent, wantWarnings := NormalizeJSONL(input)
+	if !bytes.Equal(content, wantContent) {
+		t.Errorf("content differs from NormalizeJSONL")
+	}
+	if len(warnings) != len(wantWarnings) || warnings[0] != wantWarnings[0] {
+		t.Errorf("warnings = %v, want %v", warnings, wantWarnings)
+	}
+	wantHashes, err := ComputeIssueHashes(wantContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != 3 {
+		t.Fatalf("expected 3 hashes, got %v", hashes)
+	}
+	for id, h := range wantHashes {
+		if hashes[id] != h {
+			t.Errorf("hash for %s = %s, want %s", id, hashes[id], h)
+		}
+	}
+}
+
+func TestReadJSONL_ErrorHasLineNumber(t *testing.T) {
+	_, _, _, err := ReadJSONL(strings.NewReader("{\"id\":\"bd-1\"}\n\n{broken\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3:") {
+		t.Errorf("expected error mentioning line 3, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package sync
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// PatchProtocolVersion is the first sync protocol version whose servers
+// accept per-issue JSON merge patches (RFC 7396) instead of whole issues.
+const PatchProtocolVersion = 2
+
+// ErrNullValue is returned by CreateMergePatch when the modified document
+// contains a null, which a merge patch cannot express (null means "remove").
+var ErrNullValue = errors.New("merge patch cannot set a null value")
+
+// CreateMergePatch returns the JSON merge patch that turns original into
+// modified. Both must be JSON objects. Arrays are replaced whole, as RFC 7396
+// requires.
+func CreateMergePatch(original, modified []byte) (json.RawMessage, error) {
+	var a, b map[string]any
+	if err := json.Unmarshal(original, &a); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(modified, &b); err != nil {
+		return nil, err
+	}
+	if containsNull(b) {
+		return nil, ErrNullValue
+	}
+	patch := mergePatch(a, b)
+	return marshalSorted(patch)
+}
+
+func mergePatch(a, b map[string]any) map[string]any {
+	patch := make(map[string]any)
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, bv := range b {
+		av, ok := a[k]
+		if !ok {
+			patch[k] = bv
+			continue
+		}
+		aObj, aIsObj := av.(map[string]any)
+		bObj, bIsObj := bv.(map[string]any)
+		if aIsObj && bIsObj {
+			if sub := mergePatch(aObj, bObj); len(sub) > 0 {
+				patch[k] = sub
+			}
+			continue
+		}
+		if !jsonEqual(av, bv) {
+			patch[k] = bv
+		}
+	}
+	return patch
+}
+
+func containsNull(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		for _, item := range v {
+			if containsNull(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if containsNull(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonEqual(a, b any) bool {
+	ab, errA := marshalSorted(a)
+	bb, errB := marshalSorted(b)
+	return errA == nil && errB == nil && bytes.Equal(ab, bb)
+}
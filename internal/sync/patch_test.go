@@ -0,0 +1,56 @@
+package sync
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// applyMergePatch applies an RFC 7396 merge patch, to check round trips.
+func applyMergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+		targetObj[k] = applyMergePatch(targetObj[k], v)
+	}
+	return targetObj
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	original := `{"id":"bd-1","title":"A","status":"open","description":"long text","labels":["x","y"],"meta":{"a":1,"b":2},"assignee":"bob"}`
+	modified := `{"id":"bd-1","title":"A","status":"closed","description":"long text","labels":["x"],"meta":{"a":1,"b":3,"c":true}}`
+
+	patch, err := CreateMergePatch([]byte(original), []byte(modified))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"assignee":null,"labels":["x"],"meta":{"b":3,"c":true},"status":"closed"}`
+	if string(patch) != want {
+		t.Errorf("patch = %s, want %s", patch, want)
+	}
+
+	var base, p, expected any
+	json.Unmarshal([]byte(original), &base)
+	json.Unmarshal(patch, &p)
+	json.Unmarshal([]byte(modified), &expected)
+	if !jsonEqual(applyMergePatch(base, p), expected) {
+		t.Error("applying the patch does not reproduce the modified issue")
+	}
+
+	if patch, err := CreateMergePatch([]byte(original), []byte(original)); err != nil || string(patch) != "{}" {
+		t.Errorf("identical documents: %s, %v", patch, err)
+	}
+	if _, err := CreateMergePatch([]byte(original), []byte(`{"id":"bd-1","closed_at":null}`)); !errors.Is(err, ErrNullValue) {
+		t.Errorf("null value: err = %v", err)
+	}
+}
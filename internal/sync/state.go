@@ -90,6 +90,21 @@ func UpdateState(state *SyncState, newHashes map[string]string) {
 	state.IssueHashes = newHashes
 }
 
+// UpdateStateForIssues records the current hashes of ids only, leaving every
+// other issue at its last-synced hash so a targeted sync does not mark
+// unrelated local changes as uploaded.
+func UpdateStateForIssues(state *SyncState, currentHashes map[string]string, ids []string) {
+	state.LastSync = time.Now().UTC()
+	if state.IssueHashes == nil {
+		state.IssueHashes = make(map[string]string)
+	}
+	for _, id := range ids {
+		if hash, ok := currentHashes[id]; ok {
+			state.IssueHashes[id] = hash
+		}
+	}
+}
+
 // NeedsFullSync returns true if a full sync is required.
 // This happens when there's no prior state (empty hashes).
 func NeedsFullSync(state *SyncState) bool {
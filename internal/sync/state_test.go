@@ -182,6 +182,36 @@ func TestUpdateState(t *testing.T) {
 	}
 }
 
+func TestUpdateStateForIssues(t *testing.T) {
+	state := &SyncState{
+		IssueHashes: map[string]string{
+			"bd-1": "old-hash",
+			"bd-2": "old-hash-2",
+		},
+	}
+
+	current := map[string]string{
+		"bd-1": "new-hash",
+		"bd-2": "new-hash-2",
+		"bd-3": "new-hash-3",
+	}
+
+	UpdateStateForIssues(state, current, []string{"bd-1", "bd-missing"})
+
+	if state.IssueHashes["bd-1"] != "new-hash" {
+		t.Errorf("bd-1 = %s, want new-hash", state.IssueHashes["bd-1"])
+	}
+	if state.IssueHashes["bd-2"] != "old-hash-2" {
+		t.Errorf("bd-2 = %s, want old-hash-2 (not part of the targeted sync)", state.IssueHashes["bd-2"])
+	}
+	if _, ok := state.IssueHashes["bd-3"]; ok {
+		t.Error("bd-3 should stay unsynced")
+	}
+	if state.LastSync.IsZero() {
+		t.Error("Expected LastSync to be set")
+	}
+}
+
 func TestNeedsFullSync(t *testing.T) {
 	tests := []struct {
 		name     string